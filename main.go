@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/cmd/builtin"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/pelletier/go-toml"
 	"github.com/sirupsen/logrus"
@@ -16,6 +17,7 @@ func main() {
 	log.Level = logrus.DebugLevel
 
 	chat.Global.Subscribe(chat.StdoutSubscriber{})
+	builtin.Register()
 
 	config, err := readConfig()
 	if err != nil {
@@ -1,6 +1,9 @@
 package action
 
-import "time"
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"time"
+)
 
 // Action represents an action that may be performed by a block. Typically, these actions are sent to
 // viewers in a world so that they can see these actions.
@@ -32,6 +35,14 @@ type ContinueCrack struct {
 // StopCrack is an action to make the cracks forming in a block stop and disappear.
 type StopCrack struct{ action }
 
+// BellRing is an action to make a bell swing as a result of it being rung. The bell swings away from the face
+// that was hit.
+type BellRing struct {
+	action
+	// Face is the face of the bell that was hit to ring it.
+	Face cube.Face
+}
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
@@ -16,6 +16,11 @@ func (a AncientDebris) BreakInfo() BreakInfo {
 	}, pickaxeEffective, oneOf(a))
 }
 
+// FireProof ...
+func (AncientDebris) FireProof() bool {
+	return true
+}
+
 // EncodeItem ...
 func (AncientDebris) EncodeItem() (name string, meta int16) {
 	return "minecraft:ancient_debris", 0
@@ -0,0 +1,161 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Banner is a tall decorative block that can be customised with up to six patterns using a loom.
+type Banner struct {
+	transparent
+	empty
+
+	// Colour is the base colour of the banner.
+	Colour item.Colour
+	// Attach is the attachment of the Banner. It is either of the type WallAttachment or StandingAttachment.
+	Attach Attachment
+	// Patterns holds the layers of patterns applied to the banner, from the bottom layer to the top layer.
+	Patterns []BannerPatternLayer
+	// Illager specifies if the banner is an ominous banner, carried by illagers.
+	Illager bool
+}
+
+// BannerPatternLayer is a single layer of a pattern applied to a banner.
+type BannerPatternLayer struct {
+	// Type is the type of the pattern of the layer.
+	Type item.BannerPatternType
+	// Colour is the colour that the pattern is drawn in.
+	Colour item.Colour
+}
+
+// MaxCount ...
+func (Banner) MaxCount() int {
+	return 16
+}
+
+// BreakInfo ...
+func (b Banner) BreakInfo() BreakInfo {
+	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(Banner{Colour: b.Colour, Patterns: b.Patterns, Illager: b.Illager}))
+}
+
+// FlammabilityInfo ...
+func (b Banner) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// SideClosed ...
+func (Banner) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// CanDisplace ...
+func (Banner) CanDisplace(l world.Liquid) bool {
+	_, water := l.(Water)
+	return water
+}
+
+// UseOnBlock ...
+func (b Banner) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, face, used = firstReplaceable(w, pos, face, b)
+	if !used || face == cube.FaceDown {
+		return false
+	}
+
+	if face == cube.FaceUp {
+		yaw, _ := user.Rotation()
+		b.Attach = StandingAttachment(cube.OrientationFromYaw(yaw).Opposite())
+	} else {
+		b.Attach = WallAttachment(face.Direction())
+	}
+	place(w, pos, b, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (b Banner) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if b.Attach.hanging {
+		if _, ok := w.Block(pos.Side(b.Attach.facing.Opposite().Face())).(Air); ok {
+			w.BreakBlock(pos)
+		}
+		return
+	}
+	if _, ok := w.Block(pos.Side(cube.FaceDown)).(Air); ok {
+		w.BreakBlock(pos)
+	}
+}
+
+// EncodeItem ...
+func (b Banner) EncodeItem() (name string, meta int16) {
+	return "minecraft:banner", invertColour(b.Colour)
+}
+
+// EncodeBlock ...
+func (b Banner) EncodeBlock() (name string, properties map[string]interface{}) {
+	if b.Attach.hanging {
+		return "minecraft:wall_banner", map[string]interface{}{"facing_direction": int32(b.Attach.facing + 2)}
+	}
+	return "minecraft:standing_banner", map[string]interface{}{"ground_sign_direction": int32(b.Attach.o)}
+}
+
+// EncodeNBT ...
+func (b Banner) EncodeNBT() map[string]interface{} {
+	patterns := make([]interface{}, 0, len(b.Patterns))
+	for _, p := range b.Patterns {
+		patterns = append(patterns, map[string]interface{}{
+			"Color":   int32(invertColour(p.Colour)),
+			"Pattern": p.Type.String(),
+		})
+	}
+	return map[string]interface{}{
+		"id":       "Banner",
+		"Base":     int32(invertColour(b.Colour)),
+		"Patterns": patterns,
+		"Type":     int32(boolByte(b.Illager)),
+	}
+}
+
+// DecodeNBT ...
+func (b Banner) DecodeNBT(data map[string]interface{}) interface{} {
+	if base, ok := data["Base"].(int32); ok {
+		// Banner items do not always carry their base colour in NBT, as it is already present in their meta.
+		b.Colour = invertColourID(int16(base))
+	}
+	b.Illager = nbtconv.MapInt32(data, "Type") == 1
+	b.Patterns = nil
+	for _, v := range nbtconv.MapSlice(data, "Patterns") {
+		p, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		t, ok := item.BannerPatternByCode(nbtconv.MapString(p, "Pattern"))
+		if !ok {
+			continue
+		}
+		b.Patterns = append(b.Patterns, BannerPatternLayer{Type: t, Colour: invertColourID(int16(nbtconv.MapInt32(p, "Color")))})
+	}
+	return b
+}
+
+// invertColour converts the item.Colour passed to its inverted ID, as used by banners for their meta and in NBT.
+func invertColour(c item.Colour) int16 {
+	return int16(15 - c.Uint8())
+}
+
+// invertColourID converts an inverted colour ID, as returned by invertColour, back to an item.Colour.
+func invertColourID(id int16) item.Colour {
+	return item.Colours()[15-(id&0xf)]
+}
+
+// allBanners returns all possible banner states.
+func allBanners() (banners []world.Block) {
+	for _, d := range cube.Directions() {
+		banners = append(banners, Banner{Attach: WallAttachment(d)})
+	}
+	for o := cube.Orientation(0); o <= 15; o++ {
+		banners = append(banners, Banner{Attach: StandingAttachment(o)})
+	}
+	return
+}
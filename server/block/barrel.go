@@ -113,8 +113,10 @@ func (b Barrel) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 	if !used {
 		return
 	}
+	inv := b.inventory
 	//noinspection GoAssignmentToReceiver
 	b = NewBarrel()
+	b.copyContents(inv)
 	b.Facing = calculateFace(user, pos)
 
 	place(w, pos, b, user, ctx)
@@ -123,7 +125,28 @@ func (b Barrel) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 
 // BreakInfo ...
 func (b Barrel) BreakInfo() BreakInfo {
-	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, simpleDrops(append(b.inventory.Items(), item.NewStack(b, 1))...))
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, simpleDrops(append(b.inventory.Items(), item.NewStack(Barrel{CustomName: b.CustomName}, 1))...))
+}
+
+// PickWithData returns the barrel as an item including its contents.
+func (b Barrel) PickWithData() item.Stack {
+	pick := NewBarrel()
+	pick.CustomName = b.CustomName
+	pick.copyContents(b.inventory)
+	return item.NewStack(pick, 1).WithLore("(+DATA)")
+}
+
+// copyContents copies the items in the inventory passed to the inventory of the barrel. If the inventory
+// passed is nil, copyContents does nothing.
+func (b Barrel) copyContents(inv *inventory.Inventory) {
+	if inv == nil {
+		return
+	}
+	for slot, it := range inv.Slots() {
+		if slot < b.inventory.Size() {
+			_ = b.inventory.SetItem(slot, it)
+		}
+	}
 }
 
 // FlammabilityInfo ...
@@ -2,6 +2,7 @@ package block
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/entity/physics"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
@@ -97,7 +98,8 @@ func (b Beacon) Level() int {
 }
 
 // Tick recalculates level, recalculates the active state of the beacon, and powers players,
-// once every 80 ticks (4 seconds).
+// once every 80 ticks (4 seconds). The effects of an active beacon are broadcast by a world.Emitter added to
+// the world.
 func (b Beacon) Tick(currentTick int64, pos cube.Pos, w *world.World) {
 	if currentTick%80 == 0 {
 		before := b.level
@@ -106,12 +108,11 @@ func (b Beacon) Tick(currentTick int64, pos cube.Pos, w *world.World) {
 		if before != b.level {
 			w.SetBlock(pos, b)
 		}
-		if b.level == 0 {
+		if b.level == 0 || b.obstructed(pos, w) {
+			w.RemoveEmitter(beaconEmitter{pos: pos})
 			return
 		}
-		if !b.obstructed(pos, w) {
-			b.broadcastBeaconEffects(pos, w)
-		}
+		w.AddEmitter(beaconEmitter{pos: pos})
 	}
 }
 
@@ -144,10 +145,9 @@ func (b Beacon) obstructed(pos cube.Pos, w *world.World) bool {
 	return w.HighestLightBlocker(pos.X(), pos.Z()) > pos[1]
 }
 
-// broadcastBeaconEffects determines the entities in range which could receive the beacon's powers, and
-// determines the powers (effects) that these entities could get. Afterwards, the entities in range that are
-// beaconAffected get their according effect(s).
-func (b Beacon) broadcastBeaconEffects(pos cube.Pos, w *world.World) {
+// beaconEffects returns the powers (effects) that entities in range of the beacon get with the current level
+// of the beacon.
+func (b Beacon) beaconEffects() []effect.Effect {
 	seconds := 9 + b.level*2
 	if b.level == 4 {
 		seconds--
@@ -173,38 +173,62 @@ func (b Beacon) broadcastBeaconEffects(pos cube.Pos, w *world.World) {
 	default:
 		secondary = b.Secondary
 	}
-	var primaryEff, secondaryEff effect.Effect
 	// Determining whether the primary power is set.
-	if primary != nil {
-		primaryEff = effect.NewAmbient(primary, 1, dur)
-		// Secondary power can only be set if the primary power is set.
-		if secondary != nil {
-			// It is possible to select 2 primary powers if the beacon's level is 4. This then means that the effect
-			// should get a level of 2.
-			if primary == secondary {
-				primaryEff = effect.NewAmbient(primary, 2, dur)
-			} else {
-				secondaryEff = effect.NewAmbient(secondary, 1, dur)
-			}
-		}
+	if primary == nil {
+		return nil
+	}
+	// Secondary power can only be set if the primary power is set.
+	if secondary == nil {
+		return []effect.Effect{effect.NewAmbient(primary, 1, dur)}
+	}
+	// It is possible to select 2 primary powers if the beacon's level is 4. This then means that the effect
+	// should get a level of 2.
+	if primary == secondary {
+		return []effect.Effect{effect.NewAmbient(primary, 2, dur)}
 	}
+	return []effect.Effect{effect.NewAmbient(primary, 1, dur), effect.NewAmbient(secondary, 1, dur)}
+}
+
+// beaconEmitter is a world.BlockEmitter that broadcasts the effects of the beacon at its position to all
+// beaconAffected entities in range of the beacon.
+type beaconEmitter struct {
+	pos cube.Pos
+}
+
+// Pos ...
+func (e beaconEmitter) Pos() cube.Pos {
+	return e.pos
+}
 
-	// Finding entities in range.
+// EmitArea returns the area that the beacon covers, which grows with its level. False is returned if the
+// beacon no longer exists or no longer has a pyramid.
+func (e beaconEmitter) EmitArea(w *world.World) (physics.AABB, bool) {
+	b, ok := w.Block(e.pos).(Beacon)
+	if !ok || b.level == 0 {
+		return physics.AABB{}, false
+	}
 	r := 10 + (b.level * 10)
-	entitiesInRange := w.EntitiesWithin(physics.NewAABB(
-		mgl64.Vec3{float64(pos.X() - r), -math.MaxFloat64, float64(pos.Z() - r)},
-		mgl64.Vec3{float64(pos.X() + r), math.MaxFloat64, float64(pos.Z() + r)},
-	), nil)
-	for _, e := range entitiesInRange {
-		if p, ok := e.(beaconAffected); ok {
-			if primaryEff.Type() != nil {
-				p.AddEffect(primaryEff)
-			}
-			if secondaryEff.Type() != nil {
-				p.AddEffect(secondaryEff)
-			}
-		}
+	return physics.NewAABB(
+		mgl64.Vec3{float64(e.pos.X() - r), -math.MaxFloat64, float64(e.pos.Z() - r)},
+		mgl64.Vec3{float64(e.pos.X() + r), math.MaxFloat64, float64(e.pos.Z() + r)},
+	), true
+}
+
+// EmitInterval ...
+func (beaconEmitter) EmitInterval() time.Duration {
+	return time.Second * 4
+}
+
+// Emit adds the effects of the beacon to all beaconAffected entities passed.
+func (e beaconEmitter) Emit(w *world.World, entities []world.Entity) {
+	b, ok := w.Block(e.pos).(Beacon)
+	if !ok {
+		return
 	}
+	entity.AddEffects(entities, func(e world.Entity) bool {
+		p, ok := e.(beaconAffected)
+		return ok && p.BeaconAffected()
+	}, b.beaconEffects()...)
 }
 
 // beaconAffected represents an entity that can be powered by a beacon. Only players will implement this.
@@ -0,0 +1,192 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Bed is a block that players may sleep in to skip the night and to set their spawn point. A bed consists of
+// two parts: A foot part and a head part, which is found in the direction the bed is facing.
+type Bed struct {
+	transparent
+
+	// Colour is the colour of the bed.
+	Colour item.Colour
+	// Facing is the direction that the bed is facing. The head of the bed is found in this direction from its
+	// foot.
+	Facing cube.Direction
+	// Head specifies if the bed is the head part of the bed, rather than the foot part.
+	Head bool
+}
+
+// Sleeper represents an entity that is able to sleep in a Bed.
+type Sleeper interface {
+	item.User
+	// Sleep makes the Sleeper sleep in the bed with its head at the position passed.
+	Sleep(pos cube.Pos)
+	// BedPosition returns the position of the head of the bed that the Sleeper is sleeping in. False is returned
+	// if the Sleeper is not sleeping.
+	BedPosition() (cube.Pos, bool)
+	// Message sends a message to the Sleeper.
+	Message(a ...interface{})
+}
+
+// bedExplosionRadius is the radius of the explosion caused by using a bed outside of the Overworld.
+const bedExplosionRadius = 5
+
+// MaxCount always returns 1.
+func (Bed) MaxCount() int {
+	return 1
+}
+
+// Model ...
+func (Bed) Model() world.BlockModel {
+	return model.Bed{}
+}
+
+// BreakInfo ...
+func (b Bed) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, nothingEffective, oneOf(Bed{Colour: b.Colour}))
+}
+
+// UseOnBlock ...
+func (b Bed) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, b)
+	if !used {
+		return false
+	}
+	b.Facing = user.Facing()
+	head := pos.Side(b.Facing.Face())
+	if !replaceableWith(w, head, b) {
+		return false
+	}
+	if !supports(w, pos, cube.FaceDown) || !supports(w, head, cube.FaceDown) {
+		return false
+	}
+
+	ctx.IgnoreAABB = true
+	place(w, pos, b, user, ctx)
+	b.Head = true
+	place(w, head, b, user, ctx)
+	return placed(ctx)
+}
+
+// Activate makes the user sleep in the bed if it is night or thundering. In dimensions other than the
+// Overworld, the bed explodes instead.
+func (b Bed) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) bool {
+	s, ok := u.(Sleeper)
+	if !ok {
+		return false
+	}
+	head, foot := b.parts(pos)
+	if w.Dimension() != world.Overworld {
+		w.SetBlock(head, nil)
+		w.SetBlock(foot, nil)
+		explode(head.Vec3Centre(), bedExplosionRadius, w, b)
+		return true
+	}
+	if _, sleeping := s.BedPosition(); sleeping {
+		return true
+	}
+	if _, thundering := w.Weather(); w.Daytime() && !thundering {
+		s.Message("You can only sleep at night")
+		return true
+	}
+	if b.occupied(head, w) {
+		s.Message("This bed is occupied")
+		return true
+	}
+	// TODO: Prevent sleeping with monsters nearby once hostile mobs are implemented.
+	s.Sleep(head)
+	return true
+}
+
+// occupied checks if any Sleeper is sleeping in the bed with its head at the position passed.
+func (b Bed) occupied(head cube.Pos, w *world.World) bool {
+	box := physics.NewAABB(head.Vec3(), head.Vec3().Add(mgl64.Vec3{1, 1, 1})).Grow(1)
+	for _, e := range w.EntitiesWithin(box, nil) {
+		if s, ok := e.(Sleeper); ok {
+			if pos, sleeping := s.BedPosition(); sleeping && pos == head {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parts returns the positions of the head and the foot of the bed, given the bed is at the position passed.
+func (b Bed) parts(pos cube.Pos) (head, foot cube.Pos) {
+	if b.Head {
+		return pos, pos.Side(b.Facing.Opposite().Face())
+	}
+	return pos.Side(b.Facing.Face()), pos
+}
+
+// NeighbourUpdateTick removes the bed if its other half was removed.
+func (b Bed) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	head, foot := b.parts(pos)
+	other := foot
+	if !b.Head {
+		other = head
+	}
+	if o, ok := w.Block(other).(Bed); !ok || o.Head == b.Head || o.Facing != b.Facing {
+		w.SetBlock(pos, nil)
+	}
+}
+
+// CanDisplace ...
+func (Bed) CanDisplace(l world.Liquid) bool {
+	_, water := l.(Water)
+	return water
+}
+
+// SideClosed ...
+func (Bed) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EncodeItem ...
+func (b Bed) EncodeItem() (name string, meta int16) {
+	return "minecraft:bed", int16(b.Colour.Uint8())
+}
+
+// EncodeBlock ...
+func (b Bed) EncodeBlock() (name string, properties map[string]interface{}) {
+	direction := 2
+	switch b.Facing {
+	case cube.South:
+		direction = 0
+	case cube.West:
+		direction = 1
+	case cube.East:
+		direction = 3
+	}
+	return "minecraft:bed", map[string]interface{}{"direction": int32(direction), "head_piece_bit": b.Head, "occupied_bit": false}
+}
+
+// EncodeNBT ...
+func (b Bed) EncodeNBT() map[string]interface{} {
+	return map[string]interface{}{"id": "Bed", "color": b.Colour.Uint8()}
+}
+
+// DecodeNBT ...
+func (b Bed) DecodeNBT(data map[string]interface{}) interface{} {
+	if c, ok := data["color"].(byte); ok {
+		// Bed items do not carry their colour in NBT, as it is already present in their meta.
+		b.Colour = item.Colours()[c%16]
+	}
+	return b
+}
+
+// allBeds returns all possible states of a bed.
+func allBeds() (beds []world.Block) {
+	for _, d := range cube.Directions() {
+		beds = append(beds, Bed{Facing: d})
+		beds = append(beds, Bed{Facing: d, Head: true})
+	}
+	return
+}
@@ -11,6 +11,12 @@ type Bedrock struct {
 	InfiniteBurning bool
 }
 
+// BreakInfo returns the BreakInfo of bedrock. Its hardness is negative, so that it cannot be broken unless the
+// break time is changed by a player.Handler, and it never drops anything.
+func (b Bedrock) BreakInfo() BreakInfo {
+	return newBreakInfo(-1, neverHarvestable, nothingEffective, simpleDrops())
+}
+
 // EncodeItem ...
 func (Bedrock) EncodeItem() (name string, meta int16) {
 	return "minecraft:bedrock", 0
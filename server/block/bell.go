@@ -0,0 +1,197 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/action"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// Bell is a block that rings when it is hit, either by hand or by a projectile. Its ringing may be listened for
+// using world.Handler.HandleBellRing.
+type Bell struct {
+	transparent
+
+	// Attach is the way the Bell is attached to the block(s) it was placed against: On top of it, hanging from
+	// it, against its side or between two blocks.
+	Attach BellAttachment
+	// Facing is the direction the Bell is facing.
+	Facing cube.Direction
+}
+
+// BreakInfo ...
+func (b Bell) BreakInfo() BreakInfo {
+	return newBreakInfo(5, pickaxeHarvestable, pickaxeEffective, oneOf(Bell{}))
+}
+
+// Model ...
+func (b Bell) Model() world.BlockModel {
+	return model.Bell{Attach: b.Attach.String(), Facing: b.Facing}
+}
+
+// Activate rings the bell if the face clicked is one that the bell can be rung on.
+func (b Bell) Activate(pos cube.Pos, clickedFace cube.Face, w *world.World, u item.User) bool {
+	return b.ring(pos, clickedFace, w, u)
+}
+
+// Punch rings the bell if the face punched is one that the bell can be rung on.
+func (b Bell) Punch(pos cube.Pos, clickedFace cube.Face, w *world.World, u item.User) {
+	b.ring(pos, clickedFace, w, u)
+}
+
+// ProjectileHit rings the bell if the face hit by the projectile is one that the bell can be rung on.
+func (b Bell) ProjectileHit(pos cube.Pos, face cube.Face, w *world.World, projectile world.Entity) {
+	b.ring(pos, face, w, projectile)
+}
+
+// ring rings the bell at the position passed, as a result of the entity passed hitting it on the face passed.
+// The ring may be cancelled by the world.Handler. If the bell cannot be rung on the face passed, false is
+// returned.
+func (b Bell) ring(pos cube.Pos, face cube.Face, w *world.World, e world.Entity) bool {
+	if face.Axis() == cube.Y {
+		return false
+	}
+	if b.Attach == StandingBell() && face.Axis() != b.Facing.Face().Axis() {
+		// A standing bell may only be rung on the front and back, which are not covered by its frame.
+		return false
+	}
+	if (b.Attach == WallBell() || b.Attach == DoubleWallBell()) && face.Axis() == b.Facing.Face().Axis() {
+		// A bell against a wall may only be rung on the sides, as its beam covers the front and back.
+		return false
+	}
+	ctx := event.C()
+	w.Handler().HandleBellRing(ctx, pos, face, e)
+	ctx.Continue(func() {
+		w.PlaySound(pos.Vec3Centre(), sound.BellRing{})
+		for _, v := range w.Viewers(pos.Vec3()) {
+			v.ViewBlockAction(pos, action.BellRing{Face: face})
+		}
+	})
+	return true
+}
+
+// UseOnBlock ...
+func (b Bell) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, b)
+	if !used {
+		return false
+	}
+	b.Facing = user.Facing().Opposite()
+	switch face {
+	case cube.FaceUp:
+		b.Attach = StandingBell()
+	case cube.FaceDown:
+		b.Attach = HangingBell()
+	default:
+		b.Attach, b.Facing = WallBell(), face.Direction()
+		if supports(w, pos, b.Facing.Face()) {
+			b.Attach = DoubleWallBell()
+		}
+	}
+	if !b.supported(pos, w) {
+		return false
+	}
+
+	place(w, pos, b, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (b Bell) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if b.Attach == DoubleWallBell() {
+		behind, front := supports(w, pos, b.Facing.Opposite().Face()), supports(w, pos, b.Facing.Face())
+		if behind && front {
+			return
+		}
+		if behind || front {
+			// One of the blocks holding the bell was removed, so it now hangs from the remaining one.
+			b.Attach = WallBell()
+			if front {
+				b.Facing = b.Facing.Opposite()
+			}
+			w.SetBlock(pos, b)
+			return
+		}
+	}
+	if !b.supported(pos, w) {
+		w.BreakBlock(pos)
+		it := entity.NewItem(item.NewStack(Bell{}, 1), pos.Vec3Centre())
+		it.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1})
+		w.AddEntity(it)
+	}
+}
+
+// supported checks if the Bell at the position passed is supported by the block(s) it is attached to.
+func (b Bell) supported(pos cube.Pos, w *world.World) bool {
+	switch b.Attach {
+	case StandingBell():
+		return supports(w, pos, cube.FaceDown)
+	case HangingBell():
+		return supports(w, pos, cube.FaceUp)
+	case DoubleWallBell():
+		return supports(w, pos, b.Facing.Opposite().Face()) && supports(w, pos, b.Facing.Face())
+	}
+	return supports(w, pos, b.Facing.Opposite().Face())
+}
+
+// supports checks if the block on the face passed of the position passed has a solid face towards the position.
+func supports(w *world.World, pos cube.Pos, face cube.Face) bool {
+	side := pos.Side(face)
+	return w.Block(side).Model().FaceSolid(side, face.Opposite(), w)
+}
+
+// CanDisplace ...
+func (b Bell) CanDisplace(l world.Liquid) bool {
+	_, water := l.(Water)
+	return water
+}
+
+// SideClosed ...
+func (b Bell) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EncodeItem ...
+func (b Bell) EncodeItem() (name string, meta int16) {
+	return "minecraft:bell", 0
+}
+
+// EncodeBlock ...
+func (b Bell) EncodeBlock() (name string, properties map[string]interface{}) {
+	direction := 2
+	switch b.Facing {
+	case cube.South:
+		direction = 0
+	case cube.West:
+		direction = 1
+	case cube.East:
+		direction = 3
+	}
+	return "minecraft:bell", map[string]interface{}{"attachment": b.Attach.String(), "direction": int32(direction), "toggle_bit": false}
+}
+
+// EncodeNBT ...
+func (b Bell) EncodeNBT() map[string]interface{} {
+	return map[string]interface{}{"id": "Bell", "Ringing": uint8(0), "Ticks": int32(0), "Direction": int32(0)}
+}
+
+// DecodeNBT ...
+func (b Bell) DecodeNBT(map[string]interface{}) interface{} {
+	return b
+}
+
+// allBells returns all possible states of a bell.
+func allBells() (bells []world.Block) {
+	for _, a := range BellAttachments() {
+		for i := cube.Direction(0); i <= 3; i++ {
+			bells = append(bells, Bell{Attach: a, Facing: i})
+		}
+	}
+	return
+}
@@ -0,0 +1,53 @@
+package block
+
+// BellAttachment represents a type of attachment of a Bell.
+type BellAttachment struct {
+	bellAttachment
+}
+
+type bellAttachment uint8
+
+// StandingBell is the attachment of a Bell placed on top of a block.
+func StandingBell() BellAttachment {
+	return BellAttachment{0}
+}
+
+// HangingBell is the attachment of a Bell hanging from the bottom of a block.
+func HangingBell() BellAttachment {
+	return BellAttachment{1}
+}
+
+// WallBell is the attachment of a Bell placed against the side of a single block.
+func WallBell() BellAttachment {
+	return BellAttachment{2}
+}
+
+// DoubleWallBell is the attachment of a Bell placed between two blocks on opposite sides of it.
+func DoubleWallBell() BellAttachment {
+	return BellAttachment{3}
+}
+
+// Uint8 returns the bell attachment as a uint8.
+func (b bellAttachment) Uint8() uint8 {
+	return uint8(b)
+}
+
+// String ...
+func (b bellAttachment) String() string {
+	switch b {
+	case 0:
+		return "standing"
+	case 1:
+		return "hanging"
+	case 2:
+		return "side"
+	case 3:
+		return "multiple"
+	}
+	panic("unknown bell attachment")
+}
+
+// BellAttachments returns all bell attachments.
+func BellAttachments() []BellAttachment {
+	return []BellAttachment{StandingBell(), HangingBell(), WallBell(), DoubleWallBell()}
+}
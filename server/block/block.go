@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
 )
 
 // Activatable represents a block that may be activated by a viewer of the world. When activated, the block
@@ -24,6 +25,13 @@ type Pickable interface {
 	Pick() item.Stack
 }
 
+// PickableWithData represents a block that may give an item including the data of the block, such as the contents
+// of a container, when picked in creative mode while the key to include block data is held.
+type PickableWithData interface {
+	// PickWithData returns the item that is picked when the block is picked including its data.
+	PickWithData() item.Stack
+}
+
 // Punchable represents a block that may be punched by a viewer of the world. When punched, the block
 // will execute some specific logic.
 type Punchable interface {
@@ -64,6 +72,21 @@ type EntityLander interface {
 	EntityLand(pos cube.Pos, w *world.World, e world.Entity)
 }
 
+// FallDamageLander is an EntityLander that changes the fall damage dealt to entities landing on it. Slime blocks,
+// for example, cancel fall damage completely, while honey blocks reduce it.
+type FallDamageLander interface {
+	EntityLander
+	// FallDamageMultiplier returns the multiplier of the fall damage dealt to the entity passed when it lands on
+	// the block.
+	FallDamageMultiplier(e world.Entity) float64
+}
+
+// EntityStepper represents a block that reacts to entities standing on top of it, such as magma blocks.
+type EntityStepper interface {
+	// SteppedOn is called every time an entity on the ground moves while standing on the block.
+	SteppedOn(pos cube.Pos, w *world.World, e world.Entity)
+}
+
 // EntityInsider represents a block that reacts to an entity going inside its 1x1x1 axis
 // aligned bounding box.
 type EntityInsider interface {
@@ -71,6 +94,16 @@ type EntityInsider interface {
 	EntityInside(pos cube.Pos, w *world.World, e world.Entity)
 }
 
+// FallDistanceModifier represents a block that changes the fall distance of entities that move through it. Water,
+// for example, resets the fall distance of entities falling into it, so that they do not take fall damage when
+// landing afterwards. Entities moving through several of these blocks at once use the lowest fall distance
+// returned.
+type FallDistanceModifier interface {
+	// ModifyFallDistance returns the fall distance of an entity after moving through the block, given its fall
+	// distance before.
+	ModifyFallDistance(distance float64) float64
+}
+
 // Frictional represents a block that may have a custom friction value, friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
@@ -78,6 +111,38 @@ type Frictional interface {
 	Friction() float64
 }
 
+// EntitySpeedModifier represents a block that changes the horizontal movement speed of entities on top of it, such
+// as soul sand and honey blocks. The client applies the same modifier based on the block, so only the movement of
+// entities without a client is affected by the server.
+type EntitySpeedModifier interface {
+	// EntitySpeedFactor returns the factor that the horizontal velocity of entities on the block is multiplied with
+	// every tick.
+	EntitySpeedFactor() float64
+}
+
+// Bouncy represents a block that entities bounce off when they land on it, such as slime blocks. The vertical
+// velocity of entities landing on the block is negated.
+type Bouncy interface {
+	// Bounces returns whether the entity passed bounces off the block when landing on it.
+	Bounces(e world.Entity) bool
+}
+
+// Climbable represents a block that entities are able to climb, such as ladders, vines and scaffolding. Entities
+// inside a Climbable block do not accumulate fall distance.
+type Climbable interface {
+	// ClimbSpeed returns the speed in blocks per tick with which entities move up the block when walking into it.
+	// Entities inside the block also never fall faster than this speed.
+	ClimbSpeed() float64
+}
+
+// BoneMealAffected represents a block that is affected when bone meal is used on it, such as crops, grass and
+// sea pickles. It is the extension point for plants added by plugins: Bone meal used on any block implementing
+// BoneMealAffected calls its BoneMeal method. If it returns true, one bone meal is consumed and bone meal
+// particles are shown. This counts for bone meal used by players and by dispensers alike.
+// BoneMealAffected is the same interface as item.BoneMealAffected, as the item package is unable to refer to
+// blocks.
+type BoneMealAffected = item.BoneMealAffected
+
 func calculateFace(user item.User, placePos cube.Pos) cube.Face {
 	userPos := user.Position()
 	pos := cube.PosFromVec3(userPos)
@@ -96,6 +161,13 @@ func calculateFace(user item.User, placePos cube.Pos) cube.Face {
 	return user.Facing().Opposite().Face()
 }
 
+// calculateTop calculates if a block such as a slab, stairs or a trapdoor should be placed in the top half of
+// its position, based on the face clicked and the position clicked on that face. This is the case if the
+// bottom face of a block is clicked or if the upper half of a side face is clicked.
+func calculateTop(face cube.Face, clickPos mgl64.Vec3) bool {
+	return face == cube.FaceDown || (clickPos[1] > 0.5 && face != cube.FaceUp)
+}
+
 func abs(x int) int {
 	if x > 0 {
 		return x
@@ -28,6 +28,9 @@ func BreakDuration(b world.Block, i item.Stack) time.Duration {
 		t = tool.None{}
 	}
 	info := breakable.BreakInfo()
+	if info.Hardness < 0 {
+		return math.MaxInt64
+	}
 
 	breakTime := info.Hardness * 5
 	if info.Harvestable(t) {
@@ -40,6 +43,7 @@ func BreakDuration(b world.Block, i item.Stack) time.Duration {
 		}
 		breakTime /= eff
 	}
+	breakTime /= i.MiningSpeed()
 	// TODO: Account for haste etc here.
 	timeInTicksAccurate := math.Round(breakTime/0.05) * 0.05
 
@@ -74,7 +78,8 @@ func BreaksInstantly(b world.Block, i item.Stack) bool {
 // BreakInfo is a struct returned by every block. It holds information on block breaking related data, such as
 // the tool type and tier required to break it.
 type BreakInfo struct {
-	// Hardness is the hardness of the block, which influences the speed with which the block may be mined.
+	// Hardness is the hardness of the block, which influences the speed with which the block may be mined. A
+	// negative hardness means the block cannot be broken, like bedrock.
 	Hardness float64
 	// Harvestable is a function called to check if the block is harvestable using the tool passed. If the
 	// item used to break the block is not a tool, a tool.None is passed.
@@ -39,10 +39,11 @@ func (c Carrot) Consume(_ *world.World, consumer item.Consumer) item.Stack {
 
 // BoneMeal ...
 func (c Carrot) BoneMeal(pos cube.Pos, w *world.World) bool {
-	if c.Growth == 7 {
+	growth, ok := c.boneMealGrowth()
+	if !ok {
 		return false
 	}
-	c.Growth = min(c.Growth+rand.Intn(4)+2, 7)
+	c.Growth = growth
 	w.PlaceBlock(pos, c)
 	return true
 }
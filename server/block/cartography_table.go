@@ -0,0 +1,43 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// CartographyTable is a block used to clone, zoom out and lock maps. It is also used as the cartographer's job
+// site block. Dragonfly does not implement maps, so items put in the table cannot be crafted into anything.
+type CartographyTable struct {
+	solid
+	bass
+}
+
+// FlammabilityInfo ...
+func (CartographyTable) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// BreakInfo ...
+func (c CartographyTable) BreakInfo() BreakInfo {
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, oneOf(c))
+}
+
+// Activate opens the cartography table UI for the user.
+func (CartographyTable) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// EncodeItem ...
+func (CartographyTable) EncodeItem() (name string, meta int16) {
+	return "minecraft:cartography_table", 0
+}
+
+// EncodeBlock ...
+func (CartographyTable) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:cartography_table", nil
+}
@@ -0,0 +1,345 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/armour"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
+	"math/rand"
+	"time"
+)
+
+// Cauldron is a block that can hold water, lava and potions. Water in a cauldron may be dyed to dye leather
+// armour dipped into it, while plain water washes the dye off leather armour.
+type Cauldron struct {
+	transparent
+
+	// FillLevel is the level of the liquid in the cauldron, ranging from 0 (empty) to 6 (full).
+	FillLevel int
+	// Liquid is the liquid held by the cauldron. Empty cauldrons always hold CauldronWater.
+	Liquid CauldronLiquid
+	// Colour is the colour of the water in the cauldron, as changed by using dyes on it. If the alpha channel of
+	// the colour is 0, the water has its default colour.
+	Colour color.RGBA
+	// Potion is the potion held by the cauldron if it holds water. If the potion is potion.Water(), the cauldron
+	// holds plain water.
+	Potion potion.Potion
+}
+
+// Model ...
+func (Cauldron) Model() world.BlockModel {
+	return model.Cauldron{}
+}
+
+// Activate ...
+func (c Cauldron) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) bool {
+	held, _ := u.HeldItems()
+	switch it := held.Item().(type) {
+	case item.Bucket:
+		return c.useBucket(it, pos, w, u)
+	case item.Potion:
+		return c.usePotion(it, pos, w, u)
+	case item.GlassBottle:
+		if c.Liquid != CauldronWater() || c.FillLevel < 2 {
+			return false
+		}
+		p := c.Potion
+		c.FillLevel -= 2
+		w.SetBlock(pos, c.normalise())
+		if p == potion.Water() {
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronTakeWater{})
+		} else {
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronTakePotion{})
+		}
+		exchangeHeld(u, item.NewStack(item.Potion{Type: p}, 1))
+		return true
+	case item.Dye:
+		if !c.plainWater() {
+			return false
+		}
+		if c.Colour.A == 0 {
+			c.Colour = it.Colour.RGBA()
+		} else {
+			c.Colour = mixColours(c.Colour, it.Colour.RGBA())
+		}
+		w.SetBlock(pos, c)
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronAddDye{})
+		exchangeHeld(u, item.Stack{})
+		return true
+	}
+	if colour, ok := leatherArmourColour(held.Item()); ok && c.plainWater() {
+		if c.Colour.A == 0 && colour.A == 0 {
+			// Neither the water nor the armour is dyed, so there is nothing to do.
+			return false
+		}
+		if c.Colour.A != 0 {
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronDyeArmour{})
+		} else {
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronCleanArmour{})
+		}
+		held = held.WithItem(withLeatherArmourColour(held.Item(), c.Colour))
+		c.FillLevel--
+		w.SetBlock(pos, c.normalise())
+
+		_, other := u.HeldItems()
+		u.SetHeldItems(held, other)
+		return true
+	}
+	return false
+}
+
+// useBucket handles the use of the bucket passed on the cauldron, either filling the cauldron or taking the
+// liquid out of it.
+func (c Cauldron) useBucket(b item.Bucket, pos cube.Pos, w *world.World, u item.User) bool {
+	if b.Empty() {
+		if c.FillLevel != 6 {
+			return false
+		}
+		var liquid world.Liquid
+		switch {
+		case c.Liquid == CauldronLava():
+			liquid = Lava{Still: true, Depth: 8}
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronTakeLava{})
+		case c.Liquid == CauldronWater() && c.Potion == potion.Water():
+			liquid = Water{Still: true, Depth: 8}
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronTakeWater{})
+		default:
+			return false
+		}
+		w.SetBlock(pos, Cauldron{})
+		exchangeHeld(u, item.NewStack(item.Bucket{Content: liquid}, 1))
+		return true
+	}
+	switch b.Content.(type) {
+	case Water:
+		if c.FillLevel == 6 && c.plainWater() && c.Colour.A == 0 {
+			return false
+		}
+		w.SetBlock(pos, Cauldron{FillLevel: 6})
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillWater{})
+	case Lava:
+		if c.FillLevel == 6 && c.Liquid == CauldronLava() {
+			return false
+		}
+		w.SetBlock(pos, Cauldron{FillLevel: 6, Liquid: CauldronLava()})
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillLava{})
+	default:
+		return false
+	}
+	exchangeHeld(u, item.NewStack(item.Bucket{}, 1))
+	return true
+}
+
+// usePotion handles the use of the potion passed on the cauldron. Water bottles add water to the cauldron, while
+// other potions may only be added to empty cauldrons or cauldrons holding the same potion.
+func (c Cauldron) usePotion(p item.Potion, pos cube.Pos, w *world.World, u item.User) bool {
+	if c.FillLevel == 6 || (c.FillLevel != 0 && (c.Liquid != CauldronWater() || c.Potion != p.Type)) {
+		return false
+	}
+	if c.FillLevel == 0 {
+		c = Cauldron{Potion: p.Type}
+	}
+	c.FillLevel += 2
+	if c.FillLevel > 6 {
+		c.FillLevel = 6
+	}
+	w.SetBlock(pos, c)
+	if p.Type == potion.Water() {
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillWater{})
+	} else {
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillPotion{})
+	}
+	exchangeHeld(u, item.NewStack(item.GlassBottle{}, 1))
+	return true
+}
+
+// plainWater checks if the cauldron holds water that does not hold a potion.
+func (c Cauldron) plainWater() bool {
+	return c.FillLevel > 0 && c.Liquid == CauldronWater() && c.Potion == potion.Water()
+}
+
+// normalise resets the liquid, colour and potion of the cauldron if it is empty, so that all empty cauldrons are
+// equal.
+func (c Cauldron) normalise() Cauldron {
+	if c.FillLevel <= 0 {
+		return Cauldron{}
+	}
+	return c
+}
+
+// EntityInside ...
+func (c Cauldron) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
+	if c.Liquid != CauldronLava() || c.FillLevel == 0 {
+		return
+	}
+	if flammable, ok := e.(entity.Flammable); ok {
+		if l, ok := e.(entity.Living); ok && !l.AttackImmune() {
+			l.Hurt(4, damage.SourceLava{})
+		}
+		if !flammable.FireProof() && flammable.OnFireDuration() < time.Second*15 {
+			flammable.SetOnFire(15 * time.Second)
+		}
+	}
+}
+
+// RandomTick ...
+func (c Cauldron) RandomTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if c.FillLevel == 6 || (c.FillLevel != 0 && !c.plainWater()) {
+		return
+	}
+	if w.RainingAt(pos.Side(cube.FaceUp)) {
+		// Rain slowly fills cauldrons exposed to the sky with water.
+		c.FillLevel++
+		w.SetBlock(pos, c)
+	}
+}
+
+// UseOnBlock ...
+func (c Cauldron) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, c)
+	if !used {
+		return
+	}
+	place(w, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (c Cauldron) BreakInfo() BreakInfo {
+	return newBreakInfo(2, pickaxeHarvestable, pickaxeEffective, oneOf(Cauldron{}))
+}
+
+// EncodeItem ...
+func (Cauldron) EncodeItem() (name string, meta int16) {
+	return "minecraft:cauldron", 0
+}
+
+// EncodeNBT ...
+func (c Cauldron) EncodeNBT() map[string]interface{} {
+	m := map[string]interface{}{"id": "Cauldron", "PotionId": int16(-1), "PotionType": int16(-1)}
+	if c.Potion != potion.Water() {
+		m["PotionId"], m["PotionType"] = int16(c.Potion.Uint8()), int16(0)
+	}
+	if c.Colour.A != 0 {
+		m["CustomColor"] = int32(uint32(c.Colour.A)<<24 | uint32(c.Colour.R)<<16 | uint32(c.Colour.G)<<8 | uint32(c.Colour.B))
+	}
+	return m
+}
+
+// DecodeNBT ...
+func (c Cauldron) DecodeNBT(data map[string]interface{}) interface{} {
+	c.Potion, c.Colour = potion.Water(), color.RGBA{}
+	if id := nbtconv.MapInt16(data, "PotionId"); id > 0 && c.Liquid == CauldronWater() {
+		c.Potion = potion.From(int32(id))
+	}
+	if v, ok := data["CustomColor"].(int32); ok {
+		c.Colour = color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: uint8(v >> 24)}
+	}
+	return c
+}
+
+// EncodeBlock ...
+func (c Cauldron) EncodeBlock() (string, map[string]interface{}) {
+	name := "minecraft:cauldron"
+	if c.Liquid == CauldronLava() {
+		name = "minecraft:lava_cauldron"
+	}
+	return name, map[string]interface{}{"fill_level": int32(c.FillLevel), "cauldron_liquid": c.Liquid.String()}
+}
+
+// allCauldrons returns all possible cauldron states.
+func allCauldrons() (cauldrons []world.Block) {
+	for _, liquid := range CauldronLiquids() {
+		for level := 0; level <= 6; level++ {
+			cauldrons = append(cauldrons, Cauldron{FillLevel: level, Liquid: liquid})
+		}
+	}
+	return
+}
+
+// exchangeHeld subtracts one item from the item held in the main hand of the user passed and gives it the item
+// passed in return, unless the user is in a game mode with a creative inventory.
+func exchangeHeld(u item.User, result item.Stack) {
+	if g, ok := u.(interface {
+		GameMode() world.GameMode
+	}); ok && g.GameMode().CreativeInventory() {
+		return
+	}
+	held, other := u.HeldItems()
+	if held.Count() == 1 {
+		u.SetHeldItems(result, other)
+		return
+	}
+	u.SetHeldItems(held.Grow(-1), other)
+	if result.Empty() {
+		return
+	}
+	if i, ok := u.(interface {
+		Inventory() *inventory.Inventory
+	}); ok {
+		n, err := i.Inventory().AddItem(result)
+		if err == nil {
+			return
+		}
+		// Not all items could be added to the inventory, so drop the rest.
+		result = result.Grow(-n)
+	}
+	if d, ok := u.(interface {
+		Drop(s item.Stack) int
+	}); ok {
+		d.Drop(result)
+	}
+}
+
+// mixColours mixes the two colours passed by averaging their channels.
+func mixColours(a, b color.RGBA) color.RGBA {
+	return color.RGBA{
+		R: uint8((int(a.R) + int(b.R)) / 2),
+		G: uint8((int(a.G) + int(b.G)) / 2),
+		B: uint8((int(a.B) + int(b.B)) / 2),
+		A: 0xff,
+	}
+}
+
+// leatherArmourColour returns the colour of the item passed if it is a piece of leather armour.
+func leatherArmourColour(i world.Item) (color.RGBA, bool) {
+	switch a := i.(type) {
+	case item.Helmet:
+		return a.Colour, a.Tier == armour.TierLeather
+	case item.Chestplate:
+		return a.Colour, a.Tier == armour.TierLeather
+	case item.Leggings:
+		return a.Colour, a.Tier == armour.TierLeather
+	case item.Boots:
+		return a.Colour, a.Tier == armour.TierLeather
+	}
+	return color.RGBA{}, false
+}
+
+// withLeatherArmourColour returns the piece of leather armour passed with its colour set to the colour passed.
+func withLeatherArmourColour(i world.Item, c color.RGBA) world.Item {
+	switch a := i.(type) {
+	case item.Helmet:
+		a.Colour = c
+		return a
+	case item.Chestplate:
+		a.Colour = c
+		return a
+	case item.Leggings:
+		a.Colour = c
+		return a
+	case item.Boots:
+		a.Colour = c
+		return a
+	}
+	return i
+}
@@ -33,6 +33,16 @@ type Chest struct {
 	inventory *inventory.Inventory
 	viewerMu  *sync.RWMutex
 	viewers   map[ContainerViewer]struct{}
+
+	// paired specifies if the chest is paired with another chest at pairX and pairZ. pairLead specifies if the
+	// chest holds the first 27 slots of the double chest. pairInv holds the 54-slot inventory shared by both
+	// chests and pairViewers its viewers. pairInv may be nil if the chest was just loaded, in which case the
+	// chest is paired again once it is activated.
+	paired, pairLead bool
+	pairX, pairZ     int
+	pairInv          *inventory.Inventory
+	pairViewerMu     *sync.RWMutex
+	pairViewers      map[ContainerViewer]struct{}
 }
 
 // NewChest creates a new initialised chest. The inventory is properly initialised.
@@ -55,9 +65,111 @@ func NewChest() Chest {
 // Inventory returns the inventory of the chest. The size of the inventory will be 27 or 54, depending on
 // whether the chest is single or double.
 func (c Chest) Inventory() *inventory.Inventory {
+	if c.paired && c.pairInv != nil {
+		return c.pairInv
+	}
 	return c.inventory
 }
 
+// Paired checks if the chest is paired with another chest to form a double chest.
+func (c Chest) Paired() bool {
+	return c.paired
+}
+
+// pairPos returns the position of the chest that the chest at the position passed is paired with.
+func (c Chest) pairPos(pos cube.Pos) cube.Pos {
+	return cube.Pos{c.pairX, pos[1], c.pairZ}
+}
+
+// pair pairs the chest at the position passed with the chest ch at pairPos. Both chests are returned with a
+// shared 54-slot inventory and shared viewers. The chest at the position passed becomes the lead of the pair,
+// holding the first 27 slots of the shared inventory. The chests themselves are not updated in the world.
+func (c Chest) pair(pos, pairPos cube.Pos, ch Chest) (Chest, Chest) {
+	c.paired, c.pairLead, c.pairX, c.pairZ = true, true, pairPos[0], pairPos[2]
+	ch.paired, ch.pairLead, ch.pairX, ch.pairZ = true, false, pos[0], pos[2]
+
+	left, right := c.inventory, ch.inventory
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	inv := inventory.New(54, func(slot int, item item.Stack) {
+		if slot < 27 {
+			_ = left.SetItem(slot, item)
+		} else {
+			_ = right.SetItem(slot-27, item)
+		}
+		m.RLock()
+		defer m.RUnlock()
+		for viewer := range v {
+			viewer.ViewSlotChange(slot, item)
+		}
+	})
+	for slot, it := range append(left.Slots(), right.Slots()...) {
+		_ = inv.SetItem(slot, it)
+	}
+	c.pairInv, ch.pairInv = inv, inv
+	c.pairViewerMu, ch.pairViewerMu = m, m
+	c.pairViewers, ch.pairViewers = v, v
+	return c, ch
+}
+
+// unpair unpairs the chest from the chest it was paired with, returning the chest with its own 27-slot
+// inventory and viewers again.
+func (c Chest) unpair() Chest {
+	c.paired, c.pairLead, c.pairX, c.pairZ = false, false, 0, 0
+	c.pairInv, c.pairViewerMu, c.pairViewers = nil, nil, nil
+	return c
+}
+
+// viewerSet returns the viewers of the inventory returned by Inventory and the mutex guarding them.
+func (c Chest) viewerSet() (*sync.RWMutex, map[ContainerViewer]struct{}) {
+	if c.paired && c.pairInv != nil {
+		return c.pairViewerMu, c.pairViewers
+	}
+	return c.viewerMu, c.viewers
+}
+
+// takeViewers removes all viewers from the inventory returned by Inventory without closing the chest, and
+// returns the inventory along with the viewers removed. It is used before the inventory of the chest is
+// replaced by pairing or unpairing it, after which the viewers are passed to reopenViewers.
+func (c Chest) takeViewers() (*inventory.Inventory, []ContainerViewer) {
+	m, v := c.viewerSet()
+	if m == nil {
+		return nil, nil
+	}
+	m.Lock()
+	defer m.Unlock()
+	viewers := make([]ContainerViewer, 0, len(v))
+	for viewer := range v {
+		viewers = append(viewers, viewer)
+		delete(v, viewer)
+	}
+	return c.Inventory(), viewers
+}
+
+// reopenViewers reopens the chest for the viewers passed, which were removed using takeViewers while viewing
+// the inventory passed, so that they view the new inventory of the chest. Viewers that were viewing a chest
+// that no longer exists have the chest closed. If none of the viewers opened the chest at the position passed
+// again, it is shown closing.
+func reopenViewers(w *world.World, pos cube.Pos, inv *inventory.Inventory, viewers []ContainerViewer) {
+	if len(viewers) == 0 {
+		return
+	}
+	for _, v := range viewers {
+		if r, ok := v.(ContainerReopener); ok {
+			r.ReopenContainer(inv)
+		}
+	}
+	if c, ok := w.Block(pos).(Chest); ok {
+		m, v := c.viewerSet()
+		m.RLock()
+		closed := len(v) == 0
+		m.RUnlock()
+		if closed {
+			c.close(w, pos)
+		}
+	}
+}
+
 // WithName returns the chest after applying a specific name to the block.
 func (c Chest) WithName(a ...interface{}) world.Item {
 	c.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
@@ -75,49 +187,80 @@ func (Chest) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
 	return false
 }
 
-// open opens the chest, displaying the animation and playing a sound.
+// open opens the chest, displaying the animation and playing a sound. If the chest is paired, the other half
+// of the chest is opened too.
 func (c Chest) open(w *world.World, pos cube.Pos) {
-	for _, v := range w.Viewers(pos.Vec3()) {
-		v.ViewBlockAction(pos, action.Open{})
-	}
-	w.PlaySound(pos.Vec3Centre(), sound.ChestOpen{})
+	c.showAction(w, pos, action.Open{}, sound.ChestOpen{})
 }
 
-// close closes the chest, displaying the animation and playing a sound.
+// close closes the chest, displaying the animation and playing a sound. If the chest is paired, the other
+// half of the chest is closed too.
 func (c Chest) close(w *world.World, pos cube.Pos) {
-	for _, v := range w.Viewers(pos.Vec3()) {
-		v.ViewBlockAction(pos, action.Close{})
+	c.showAction(w, pos, action.Close{}, sound.ChestClose{})
+}
+
+// showAction shows a block action to the viewers of the chest and of its other half if paired, and plays the
+// sound passed in the middle of the chest.
+func (c Chest) showAction(w *world.World, pos cube.Pos, a action.Action, s world.Sound) {
+	positions, centre := []cube.Pos{pos}, pos.Vec3Centre()
+	if c.paired {
+		pairPos := c.pairPos(pos)
+		positions, centre = append(positions, pairPos), centre.Add(pairPos.Vec3Centre()).Mul(0.5)
+	}
+	for _, p := range positions {
+		for _, v := range w.Viewers(p.Vec3()) {
+			v.ViewBlockAction(p, a)
+		}
 	}
-	w.PlaySound(pos.Vec3Centre(), sound.ChestClose{})
+	w.PlaySound(centre, s)
 }
 
 // AddViewer adds a viewer to the chest, so that it is updated whenever the inventory of the chest is changed.
 func (c Chest) AddViewer(v ContainerViewer, w *world.World, pos cube.Pos) {
-	c.viewerMu.Lock()
-	defer c.viewerMu.Unlock()
-	if len(c.viewers) == 0 {
+	m, viewers := c.viewerSet()
+	m.Lock()
+	defer m.Unlock()
+	if len(viewers) == 0 {
 		c.open(w, pos)
 	}
-	c.viewers[v] = struct{}{}
+	viewers[v] = struct{}{}
 }
 
 // RemoveViewer removes a viewer from the chest, so that slot updates in the inventory are no longer sent to
 // it.
 func (c Chest) RemoveViewer(v ContainerViewer, w *world.World, pos cube.Pos) {
-	c.viewerMu.Lock()
-	defer c.viewerMu.Unlock()
-	if len(c.viewers) == 0 {
+	m, viewers := c.viewerSet()
+	m.Lock()
+	defer m.Unlock()
+	if len(viewers) == 0 {
 		return
 	}
-	delete(c.viewers, v)
-	if len(c.viewers) == 0 {
+	delete(viewers, v)
+	if len(viewers) == 0 {
 		c.close(w, pos)
 	}
 }
 
 // Activate ...
-func (c Chest) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+func (c Chest) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) bool {
 	if opener, ok := u.(ContainerOpener); ok {
+		if c.paired && c.pairInv == nil {
+			// The chest was loaded from disk and was not yet paired with the chest next to it.
+			pairPos := c.pairPos(pos)
+			if ch, ok := w.Block(pairPos).(Chest); ok && ch.paired && ch.pairPos(pairPos) == pos {
+				// The chest that was the lead of the pair when it was saved remains the lead, so that the items
+				// keep their slots in the double chest.
+				if c.pairLead || !ch.pairLead {
+					c, ch = c.pair(pos, pairPos, ch)
+				} else {
+					ch, c = ch.pair(pairPos, pos, c)
+				}
+				w.SetBlock(pairPos, ch)
+			} else {
+				c = c.unpair()
+			}
+			w.SetBlock(pos, c)
+		}
 		opener.OpenBlockContainer(pos)
 		return true
 	}
@@ -130,17 +273,80 @@ func (c Chest) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.W
 	if !used {
 		return
 	}
+	inv := c.inventory
 	//noinspection GoAssignmentToReceiver
 	c = NewChest()
+	c.copyContents(inv)
 	c.Facing = user.Facing().Opposite()
 
+	var (
+		pairPos cube.Pos
+		pair    Chest
+		pairInv *inventory.Inventory
+		viewers []ContainerViewer
+	)
+	if s, ok := user.(interface{ Sneaking() bool }); !ok || !s.Sneaking() {
+		// Sneaking players place single chests, even if there is a chest next to it that could be paired with.
+		for _, dir := range []cube.Direction{c.Facing.RotateLeft(), c.Facing.RotateRight()} {
+			sidePos := pos.Side(dir.Face())
+			if ch, ok := w.Block(sidePos).(Chest); ok && !ch.paired && ch.Facing == c.Facing {
+				// Viewers of the single chest are reopened once it is paired, so that they view the double chest.
+				pairInv, viewers = ch.takeViewers()
+				c, pair = c.pair(pos, sidePos, ch)
+				pairPos = sidePos
+				break
+			}
+		}
+	}
+
 	place(w, pos, c, user, ctx)
+	if placed(ctx) && c.paired {
+		w.SetBlock(pairPos, pair)
+	}
+	reopenViewers(w, pairPos, pairInv, viewers)
 	return placed(ctx)
 }
 
+// NeighbourUpdateTick ...
+func (c Chest) NeighbourUpdateTick(pos, changed cube.Pos, w *world.World) {
+	if !c.paired || changed != c.pairPos(pos) {
+		return
+	}
+	if ch, ok := w.Block(changed).(Chest); ok && ch.paired && ch.pairPos(changed) == pos {
+		return
+	}
+	// The other half of the chest was broken. Viewers of the double chest are reopened, so that those that
+	// opened this half view its own 27-slot inventory and can no longer take out the dropped contents of the
+	// other half, while those that opened the other half have the chest closed.
+	inv, viewers := c.takeViewers()
+	w.SetBlock(pos, c.unpair())
+	reopenViewers(w, pos, inv, viewers)
+}
+
 // BreakInfo ...
 func (c Chest) BreakInfo() BreakInfo {
-	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, simpleDrops(append(c.inventory.Items(), item.NewStack(c, 1))...))
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, simpleDrops(append(c.inventory.Items(), item.NewStack(Chest{CustomName: c.CustomName}, 1))...))
+}
+
+// PickWithData returns the chest as an item including its contents.
+func (c Chest) PickWithData() item.Stack {
+	pick := NewChest()
+	pick.CustomName = c.CustomName
+	pick.copyContents(c.inventory)
+	return item.NewStack(pick, 1).WithLore("(+DATA)")
+}
+
+// copyContents copies the items in the inventory passed to the inventory of the chest. If the inventory
+// passed is nil, copyContents does nothing.
+func (c Chest) copyContents(inv *inventory.Inventory) {
+	if inv == nil {
+		return
+	}
+	for slot, it := range inv.Slots() {
+		if slot < c.inventory.Size() {
+			_ = c.inventory.SetItem(slot, it)
+		}
+	}
 }
 
 // FlammabilityInfo ...
@@ -155,6 +361,10 @@ func (c Chest) DecodeNBT(data map[string]interface{}) interface{} {
 	c = NewChest()
 	c.Facing = facing
 	c.CustomName = nbtconv.MapString(data, "CustomName")
+	if _, ok := data["pairx"]; ok {
+		c.paired, c.pairLead = true, nbtconv.MapByte(data, "pairlead") == 1
+		c.pairX, c.pairZ = int(nbtconv.MapInt32(data, "pairx")), int(nbtconv.MapInt32(data, "pairz"))
+	}
 	nbtconv.InvFromNBT(c.inventory, nbtconv.MapSlice(data, "Items"))
 	return c
 }
@@ -174,6 +384,9 @@ func (c Chest) EncodeNBT() map[string]interface{} {
 	if c.CustomName != "" {
 		m["CustomName"] = c.CustomName
 	}
+	if c.paired {
+		m["pairx"], m["pairz"], m["pairlead"] = int32(c.pairX), int32(c.pairZ), boolByte(c.pairLead)
+	}
 	return m
 }
 
@@ -0,0 +1,234 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// Conduit is a beacon-like block that grants the Conduit Power effect to players in water or rain nearby when it
+// is placed under water and surrounded by a frame of prismarine blocks.
+type Conduit struct {
+	transparent
+
+	// active specifies if the conduit is currently activated by its frame. frames is the amount of frame blocks
+	// found around the conduit when it was last ticked.
+	active bool
+	frames int
+}
+
+// ConduitFrame represents a block that can be part of the frame that activates a conduit, such as prismarine and
+// sea lanterns.
+type ConduitFrame interface {
+	// FramesConduit returns a bool which indicates whether the block can be part of the frame of a conduit.
+	FramesConduit() bool
+}
+
+// conduitActivationFrames is the minimum amount of frame blocks needed to activate a conduit.
+const conduitActivationFrames = 16
+
+// Model ...
+func (Conduit) Model() world.BlockModel {
+	return model.Conduit{}
+}
+
+// BreakInfo ...
+func (c Conduit) BreakInfo() BreakInfo {
+	return newBreakInfo(3, alwaysHarvestable, pickaxeEffective, oneOf(Conduit{}))
+}
+
+// LightEmissionLevel ...
+func (Conduit) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// CanDisplace ...
+func (Conduit) CanDisplace(l world.Liquid) bool {
+	_, water := l.(Water)
+	return water
+}
+
+// SideClosed ...
+func (Conduit) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// Active checks if the conduit is activated by a frame of prismarine blocks around it.
+func (c Conduit) Active() bool {
+	return c.active
+}
+
+// Range returns the radius in blocks around the conduit within which players receive Conduit Power. The range
+// grows with the frame of the conduit, from 32 blocks for the minimum frame up to 96 blocks for a complete frame.
+// Range returns 0 if the conduit is not active.
+func (c Conduit) Range() int {
+	if !c.active {
+		return 0
+	}
+	return c.frames / 7 * 16
+}
+
+// Tick recalculates the frame around the conduit and whether it is active once every 40 ticks (2 seconds). The
+// Conduit Power effect of an active conduit is granted by a world.Emitter added to the world.
+func (c Conduit) Tick(currentTick int64, pos cube.Pos, w *world.World) {
+	if currentTick%40 != 0 {
+		return
+	}
+	before := c
+	c.frames = c.recalculateFrames(pos, w)
+	c.active = c.frames >= conduitActivationFrames && c.submerged(pos, w)
+	if before != c {
+		// The block is set again so that viewers see the eye of the conduit open or close.
+		w.SetBlock(pos, c)
+	}
+	if !c.active {
+		w.RemoveEmitter(conduitEmitter{pos: pos})
+		return
+	}
+	w.AddEmitter(conduitEmitter{pos: pos})
+}
+
+// submerged checks if all blocks directly around the conduit, including those diagonally adjacent, are filled
+// with water.
+func (c Conduit) submerged(pos cube.Pos, w *world.World) bool {
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				if x == 0 && y == 0 && z == 0 {
+					continue
+				}
+				l, ok := w.Liquid(pos.Add(cube.Pos{x, y, z}))
+				if !ok {
+					return false
+				}
+				if _, water := l.(Water); !water {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// recalculateFrames counts the frame blocks around the conduit. The frame consists of three rings of 5x5 blocks
+// around the conduit, one along each axis, which hold 42 blocks in total.
+func (c Conduit) recalculateFrames(pos cube.Pos, w *world.World) int {
+	frames := 0
+	for x := -2; x <= 2; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -2; z <= 2; z++ {
+				if !conduitFramePos(x, y, z) {
+					continue
+				}
+				if f, ok := w.Block(pos.Add(cube.Pos{x, y, z})).(ConduitFrame); ok && f.FramesConduit() {
+					frames++
+				}
+			}
+		}
+	}
+	return frames
+}
+
+// conduitFramePos checks if the offset passed, relative to a conduit, is part of one of the rings of the frame
+// of the conduit.
+func conduitFramePos(x, y, z int) bool {
+	ax, ay, az := abs(x), abs(y), abs(z)
+	return (x == 0 && (ay == 2 || az == 2)) || (y == 0 && (ax == 2 || az == 2)) || (z == 0 && (ax == 2 || ay == 2))
+}
+
+// DecodeNBT ...
+func (c Conduit) DecodeNBT(data map[string]interface{}) interface{} {
+	c.active = nbtconv.MapByte(data, "Active") == 1
+	if c.active {
+		// The frame is not saved: Assume the smallest frame until the conduit is ticked again.
+		c.frames = conduitActivationFrames
+	}
+	return c
+}
+
+// EncodeNBT ...
+func (c Conduit) EncodeNBT() map[string]interface{} {
+	return map[string]interface{}{
+		"id":     "Conduit",
+		"Active": boolByte(c.active),
+		"Target": int64(-1),
+	}
+}
+
+// EncodeItem ...
+func (Conduit) EncodeItem() (name string, meta int16) {
+	return "minecraft:conduit", 0
+}
+
+// EncodeBlock ...
+func (Conduit) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:conduit", nil
+}
+
+// conduitEmitter is a world.BlockEmitter that grants Conduit Power to all conduitAffected entities in water or
+// rain within the range of the conduit at its position.
+type conduitEmitter struct {
+	pos cube.Pos
+}
+
+// Pos ...
+func (e conduitEmitter) Pos() cube.Pos {
+	return e.pos
+}
+
+// EmitArea returns the area that the conduit covers, which grows with its frame and extends up to the top of
+// the world. False is returned if the conduit no longer exists or is no longer active.
+func (e conduitEmitter) EmitArea(w *world.World) (physics.AABB, bool) {
+	c, ok := w.Block(e.pos).(Conduit)
+	if !ok || !c.active {
+		return physics.AABB{}, false
+	}
+	r := float64(c.Range())
+	centre := e.pos.Vec3Centre()
+	return physics.NewAABB(
+		mgl64.Vec3{centre[0] - r, centre[1] - r, centre[2] - r},
+		mgl64.Vec3{centre[0] + r, math.MaxFloat64, centre[2] + r},
+	), true
+}
+
+// EmitInterval ...
+func (conduitEmitter) EmitInterval() time.Duration {
+	return time.Second * 2
+}
+
+// Emit grants Conduit Power to all conduitAffected entities passed that are in water or rain.
+func (conduitEmitter) Emit(w *world.World, entities []world.Entity) {
+	entity.AddEffects(entities, func(e world.Entity) bool {
+		p, ok := e.(conduitAffected)
+		return ok && p.ConduitAffected() && inWaterOrRain(e, w)
+	}, effect.NewAmbient(effect.ConduitPower{}, 1, time.Second*13))
+}
+
+// inWaterOrRain checks if the entity passed is in water or standing in the rain.
+func inWaterOrRain(e world.Entity, w *world.World) bool {
+	pos := cube.PosFromVec3(e.Position())
+	for _, p := range []cube.Pos{pos, cube.PosFromVec3(entity.EyePosition(e))} {
+		if l, ok := w.Liquid(p); ok {
+			if _, water := l.(Water); water {
+				return true
+			}
+		}
+	}
+	return w.RainingAt(pos)
+}
+
+// conduitAffected represents an entity that can be granted Conduit Power by a conduit. Only players will
+// implement this.
+type conduitAffected interface {
+	// AddEffect adds a specific effect to the entity that implements this interface.
+	AddEffect(e effect.Effect)
+	// ConduitAffected returns whether this entity can be granted Conduit Power by a conduit.
+	ConduitAffected() bool
+}
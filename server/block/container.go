@@ -14,6 +14,16 @@ type ContainerViewer interface {
 	ViewSlotChange(slot int, newItem item.Stack)
 }
 
+// ContainerReopener is a ContainerViewer that is able to reopen the container it is viewing. Containers that
+// replace their inventory, such as chests that are paired or unpaired, remove their viewers and call
+// ReopenContainer on them, so that they view the new inventory.
+type ContainerReopener interface {
+	ContainerViewer
+	// ReopenContainer closes the container if the inventory passed is the one currently viewed, and opens the
+	// container at the same position again if there still is one.
+	ReopenContainer(inv *inventory.Inventory)
+}
+
 // ContainerOpener represents an entity that is able to open a container.
 type ContainerOpener interface {
 	// OpenBlockContainer opens a block container at the position passed.
@@ -27,3 +37,27 @@ type Container interface {
 	RemoveViewer(v ContainerViewer, w *world.World, pos cube.Pos)
 	Inventory() *inventory.Inventory
 }
+
+// SidedContainer is a Container that limits the slots that blocks such as hoppers may insert items into or
+// extract items from, depending on the face of the container through which it is accessed. Containers that do
+// not implement SidedContainer may have items inserted into and extracted from any of their slots.
+type SidedContainer interface {
+	Container
+	// InsertSlots returns the slots that the item passed may be inserted into through the face passed.
+	InsertSlots(face cube.Face, it item.Stack) []int
+	// ExtractSlots returns the slots that items may be extracted from through the face passed.
+	ExtractSlots(face cube.Face) []int
+}
+
+// copyContents copies the items in the inventory src to the inventory dst. If src is nil, copyContents does
+// nothing.
+func copyContents(dst, src *inventory.Inventory) {
+	if src == nil {
+		return
+	}
+	for slot, it := range src.Slots() {
+		if slot < dst.Size() {
+			_ = dst.SetItem(slot, it)
+		}
+	}
+}
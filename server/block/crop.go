@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item/tool"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"math"
 	"math/rand"
 )
 
@@ -47,6 +48,49 @@ func (c crop) HasLiquidDrops() bool {
 	return true
 }
 
+// boneMealGrowth returns the stage of growth that the crop reaches when bone meal is used on it. False is returned
+// if the crop is already fully grown.
+func (c crop) boneMealGrowth() (int, bool) {
+	if c.Growth == 7 {
+		return 0, false
+	}
+	return min(c.Growth+rand.Intn(4)+2, 7), true
+}
+
+// maxCropElapsedTicks is the maximum amount of random ticks that a crop is given when catching up on the time its
+// chunk was unloaded.
+const maxCropElapsedTicks = 64
+
+// SimulateElapsed random ticks the crop as many times as it would have been ticked on average during the ticks
+// passed, up to maxCropElapsedTicks times.
+func (c crop) SimulateElapsed(ticks int64, pos cube.Pos, w *world.World) {
+	r := rand.New(rand.NewSource(rand.Int63()))
+	n := elapsedRandomTicks(ticks, w, r)
+	for i := 0; i < min(n, maxCropElapsedTicks); i++ {
+		b, ok := w.Block(pos).(interface {
+			Crop
+			world.RandomTicker
+		})
+		if !ok {
+			// The crop was broken, for example because the light level around it was too low.
+			return
+		}
+		b.RandomTick(pos, w, r)
+	}
+}
+
+// elapsedRandomTicks returns the amount of random ticks that a single block receives on average during the ticks
+// passed, based on the random tick speed of the world.World passed.
+func elapsedRandomTicks(ticks int64, w *world.World, r *rand.Rand) int {
+	// Every tick, RandomTickSpeed blocks out of the 4096 blocks in a sub chunk are randomly ticked.
+	expected := float64(ticks) * float64(w.RandomTickSpeed()) / 4096
+	n := int(math.Min(expected, math.MaxInt32))
+	if r.Float64() < expected-float64(n) {
+		n++
+	}
+	return n
+}
+
 // GrowthStage returns the current stage of growth.
 func (c crop) GrowthStage() int {
 	return c.Growth
@@ -0,0 +1,225 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/armour"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"sync"
+)
+
+// DispenseBehaviour is a function executed when a Dispenser at a position dispenses an item towards the face
+// passed. The stack passed holds all items in the slot that is dispensed from. The behaviour returns the stack
+// that should be left in that slot and whether anything was dispensed. If false is returned, the dispenser
+// clicks without dispensing and the slot is left unchanged.
+type DispenseBehaviour func(pos cube.Pos, face cube.Face, w *world.World, s item.Stack) (left item.Stack, ok bool)
+
+// RegisterDispenseBehaviour registers a DispenseBehaviour that is executed when a Dispenser dispenses an item
+// with the same name as the item passed, regardless of its meta. A behaviour previously registered for the
+// item, including a built-in one, is overwritten. Items without a behaviour are dropped like a Dropper does.
+func RegisterDispenseBehaviour(it world.Item, f DispenseBehaviour) {
+	name, _ := it.EncodeItem()
+
+	dispenseMu.Lock()
+	defer dispenseMu.Unlock()
+	dispenseBehaviours[name] = f
+}
+
+var (
+	// dispenseMu protects dispenseBehaviours, as plugins may register behaviours while dispensers are in use.
+	dispenseMu sync.RWMutex
+	// dispenseBehaviours holds the dispense behaviours registered, indexed by the name of their item.
+	dispenseBehaviours = map[string]DispenseBehaviour{}
+)
+
+// dispenseBehaviour looks up the DispenseBehaviour registered for the item passed. If none was registered,
+// dispenseBehaviour returns dropDispenseBehaviour.
+func dispenseBehaviour(it world.Item) DispenseBehaviour {
+	name, _ := it.EncodeItem()
+
+	dispenseMu.RLock()
+	defer dispenseMu.RUnlock()
+	if f, ok := dispenseBehaviours[name]; ok {
+		return f
+	}
+	return dropDispenseBehaviour
+}
+
+// init registers the dispense behaviours of vanilla items.
+func init() {
+	RegisterDispenseBehaviour(item.Arrow{}, arrowDispenseBehaviour)
+	RegisterDispenseBehaviour(item.BoneMeal{}, useOnFrontDispenseBehaviour)
+	RegisterDispenseBehaviour(item.Bucket{}, useOnFrontDispenseBehaviour)
+	RegisterDispenseBehaviour(item.Bucket{Content: Water{}}, useOnFrontDispenseBehaviour)
+	RegisterDispenseBehaviour(item.Bucket{Content: Lava{}}, useOnFrontDispenseBehaviour)
+	for _, t := range armour.Tiers() {
+		RegisterDispenseBehaviour(item.Helmet{Tier: t}, armourDispenseBehaviour)
+		RegisterDispenseBehaviour(item.Chestplate{Tier: t}, armourDispenseBehaviour)
+		RegisterDispenseBehaviour(item.Leggings{Tier: t}, armourDispenseBehaviour)
+		RegisterDispenseBehaviour(item.Boots{Tier: t}, armourDispenseBehaviour)
+	}
+	// TODO: Ignite TNT using flint and steel once TNT is implemented.
+}
+
+// dropDispenseBehaviour drops a single item of the stack passed in front of the dispenser.
+func dropDispenseBehaviour(pos cube.Pos, face cube.Face, w *world.World, s item.Stack) (item.Stack, bool) {
+	dropDispensed(pos, face, w, s.Grow(-s.Count()+1))
+	return s.Grow(-1), true
+}
+
+// arrowDispenseBehaviour shoots an arrow out of the front of the dispenser.
+func arrowDispenseBehaviour(pos cube.Pos, face cube.Face, w *world.World, s item.Stack) (item.Stack, bool) {
+	yaw, pitch := faceRotation(face)
+	arrow := entity.NewArrow(dispensePosition(pos, face), yaw, pitch, nil)
+	arrow.SetVelocity(faceVector(face).Mul(1.1).Add(dispenseSpread(0.0075 * 6)))
+	w.AddEntity(arrow)
+	return s.Grow(-1), true
+}
+
+// useOnFrontDispenseBehaviour uses the item of the stack passed on the block in front of the dispenser, as is
+// done for bone meal and (empty) buckets. The item is used as if its user clicked the side of the block in
+// front that faces the dispenser.
+func useOnFrontDispenseBehaviour(pos cube.Pos, face cube.Face, w *world.World, s item.Stack) (item.Stack, bool) {
+	usable, ok := s.Item().(item.UsableOnBlock)
+	if !ok {
+		return s, false
+	}
+	front, ctx := pos.Side(face), &item.UseContext{}
+	if !usable.UseOnBlock(front, face.Opposite(), mgl64.Vec3{}, w, nil, ctx) {
+		return s, false
+	}
+	left := s.Grow(-ctx.CountSub)
+	if !ctx.NewItem.Empty() {
+		if left.Empty() {
+			return ctx.NewItem, true
+		}
+		// There are items left in the slot, such as when filling one of a stack of empty buckets, so the new
+		// item is dropped in front of the dispenser instead.
+		dropDispensed(pos, face, w, ctx.NewItem)
+	}
+	return left, true
+}
+
+// armourDispenseBehaviour equips the armour of the stack passed on the first entity in front of the dispenser
+// that has an empty armour slot for it.
+func armourDispenseBehaviour(pos cube.Pos, face cube.Face, w *world.World, s item.Stack) (item.Stack, bool) {
+	front := pos.Side(face)
+	box := physics.NewAABB(front.Vec3(), front.Vec3().Add(mgl64.Vec3{1, 1, 1}))
+	for _, e := range w.EntitiesWithin(box, nil) {
+		a, ok := e.(interface{ Armour() *inventory.Armour })
+		if !ok {
+			continue
+		}
+		inv, equip := a.Armour(), s.Grow(-s.Count()+1)
+		switch s.Item().(type) {
+		case armour.Helmet:
+			if inv.Helmet().Empty() {
+				inv.SetHelmet(equip)
+				return s.Grow(-1), true
+			}
+		case armour.Chestplate:
+			if inv.Chestplate().Empty() {
+				inv.SetChestplate(equip)
+				return s.Grow(-1), true
+			}
+		case armour.Leggings:
+			if inv.Leggings().Empty() {
+				inv.SetLeggings(equip)
+				return s.Grow(-1), true
+			}
+		case armour.Boots:
+			if inv.Boots().Empty() {
+				inv.SetBoots(equip)
+				return s.Grow(-1), true
+			}
+		}
+	}
+	// Nobody in front of the dispenser could wear the armour, so it is dropped instead.
+	return dropDispenseBehaviour(pos, face, w, s)
+}
+
+// dispenseFrom picks a random non-empty slot from the inventory passed and dispenses it from the dispenser or
+// dropper at the position passed using the DispenseBehaviour passed. dispenseFrom plays a click and shows
+// smoke if something was dispensed, or plays a failing click if not.
+func dispenseFrom(pos cube.Pos, face cube.Face, w *world.World, inv *inventory.Inventory, f func(world.Item) DispenseBehaviour) bool {
+	var slots []int
+	for slot, it := range inv.Slots() {
+		if !it.Empty() {
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) == 0 {
+		w.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return false
+	}
+	slot := slots[rand.Intn(len(slots))]
+	it, _ := inv.Item(slot)
+
+	left, ok := f(it.Item())(pos, face, w, it)
+	if !ok {
+		w.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return false
+	}
+	_ = inv.SetItem(slot, left)
+	w.PlaySound(pos.Vec3Centre(), sound.Click{})
+	w.AddParticle(dispensePosition(pos, face), particle.DispenseSmoke{Face: face})
+	return true
+}
+
+// dropDispensed drops the item stack passed out of the front of the dispenser or dropper at the position
+// passed.
+func dropDispensed(pos cube.Pos, face cube.Face, w *world.World, s item.Stack) {
+	spawn := dispensePosition(pos, face)
+	if face.Axis() != cube.Y {
+		// Items dropped to the side are spawned slightly lower, so that they come out of the centre of the face.
+		spawn = spawn.Sub(mgl64.Vec3{0, 0.15625})
+	}
+	speed := rand.Float64()*0.1 + 0.2
+	vel := faceVector(face).Mul(speed).Add(dispenseSpread(0.0075 * 6))
+	vel[1] += 0.2
+
+	it := entity.NewItem(s, spawn)
+	it.SetVelocity(vel)
+	w.AddEntity(it)
+}
+
+// dispensePosition returns the position in front of the dispenser or dropper at the position passed, facing
+// the face passed, at which entities are dispensed.
+func dispensePosition(pos cube.Pos, face cube.Face) mgl64.Vec3 {
+	return pos.Vec3Centre().Add(faceVector(face).Mul(0.7))
+}
+
+// faceVector returns a vector with a length of 1 that points in the direction of the face passed.
+func faceVector(face cube.Face) mgl64.Vec3 {
+	return cube.Pos{}.Side(face).Vec3()
+}
+
+// faceRotation returns the yaw and pitch of an entity looking in the direction of the face passed.
+func faceRotation(face cube.Face) (yaw, pitch float64) {
+	switch face {
+	case cube.FaceDown:
+		return 0, 90
+	case cube.FaceUp:
+		return 0, -90
+	case cube.FaceNorth:
+		return 180, 0
+	case cube.FaceEast:
+		return -90, 0
+	case cube.FaceWest:
+		return 90, 0
+	}
+	return 0, 0
+}
+
+// dispenseSpread returns a random vector with components following a normal distribution with the deviation
+// passed, used to spread out the direction of the entities dispensed.
+func dispenseSpread(deviation float64) mgl64.Vec3 {
+	return mgl64.Vec3{rand.NormFloat64() * deviation, rand.NormFloat64() * deviation, rand.NormFloat64() * deviation}
+}
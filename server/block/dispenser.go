@@ -0,0 +1,168 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+	"sync"
+)
+
+// Dispenser is a block that holds up to nine item stacks and dispenses them when activated. Depending on the
+// item dispensed, it may shoot the item as a projectile, use it on the block in front of it or drop it as an
+// item entity. The behaviour of items dispensed may be changed using RegisterDispenseBehaviour.
+type Dispenser struct {
+	solid
+	bassDrum
+
+	// Facing is the direction that the dispenser is facing. Items are dispensed towards this face.
+	Facing cube.Face
+	// CustomName is the custom name of the dispenser. This name is displayed when the dispenser is opened, and
+	// may include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewDispenser creates a new initialised dispenser. The inventory is properly initialised.
+func NewDispenser() Dispenser {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Dispenser{
+		inventory: inventory.New(9, func(slot int, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+	}
+}
+
+// Inventory returns the inventory of the dispenser. The size of the inventory will be 9.
+func (d Dispenser) Inventory() *inventory.Inventory {
+	return d.inventory
+}
+
+// WithName returns the dispenser after applying a specific name to the block.
+func (d Dispenser) WithName(a ...interface{}) world.Item {
+	d.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return d
+}
+
+// Dispense dispenses an item from a random non-empty slot of the dispenser at the position passed, using the
+// DispenseBehaviour registered for the item. Items without a behaviour are dropped in front of the dispenser.
+// False is returned if nothing was dispensed. As there is no redstone yet, Dispense must be called by plugins
+// to activate the dispenser.
+func (d Dispenser) Dispense(pos cube.Pos, w *world.World) bool {
+	return dispenseFrom(pos, d.Facing, w, d.inventory, dispenseBehaviour)
+}
+
+// AddViewer adds a viewer to the dispenser, so that it is updated whenever the inventory of the dispenser is
+// changed.
+func (d Dispenser) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	d.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the dispenser, so that slot updates in the inventory are no longer sent to
+// it.
+func (d Dispenser) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	delete(d.viewers, v)
+}
+
+// Activate ...
+func (d Dispenser) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (d Dispenser) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, d)
+	if !used {
+		return
+	}
+	inv := d.inventory
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	copyContents(d.inventory, inv)
+	d.Facing = calculateFace(user, pos)
+
+	place(w, pos, d, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (d Dispenser) BreakInfo() BreakInfo {
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, simpleDrops(append(d.inventory.Items(), item.NewStack(Dispenser{CustomName: d.CustomName}, 1))...))
+}
+
+// PickWithData returns the dispenser as an item including its contents.
+func (d Dispenser) PickWithData() item.Stack {
+	pick := NewDispenser()
+	pick.CustomName = d.CustomName
+	copyContents(pick.inventory, d.inventory)
+	return item.NewStack(pick, 1).WithLore("(+DATA)")
+}
+
+// DecodeNBT ...
+func (d Dispenser) DecodeNBT(data map[string]interface{}) interface{} {
+	facing := d.Facing
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	d.Facing = facing
+	d.CustomName = nbtconv.MapString(data, "CustomName")
+	nbtconv.InvFromNBT(d.inventory, nbtconv.MapSlice(data, "Items"))
+	return d
+}
+
+// EncodeNBT ...
+func (d Dispenser) EncodeNBT() map[string]interface{} {
+	if d.inventory == nil {
+		facing, customName := d.Facing, d.CustomName
+		//noinspection GoAssignmentToReceiver
+		d = NewDispenser()
+		d.Facing, d.CustomName = facing, customName
+	}
+	m := map[string]interface{}{
+		"Items": nbtconv.InvToNBT(d.inventory),
+		"id":    "Dispenser",
+	}
+	if d.CustomName != "" {
+		m["CustomName"] = d.CustomName
+	}
+	return m
+}
+
+// EncodeBlock ...
+func (d Dispenser) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:dispenser", map[string]interface{}{"facing_direction": int32(d.Facing), "triggered_bit": uint8(0)}
+}
+
+// EncodeItem ...
+func (d Dispenser) EncodeItem() (name string, meta int16) {
+	return "minecraft:dispenser", 0
+}
+
+// allDispensers returns all possible states of a dispenser.
+func allDispensers() (d []world.Block) {
+	for i := cube.Face(0); i < 6; i++ {
+		d = append(d, Dispenser{Facing: i})
+	}
+	return
+}
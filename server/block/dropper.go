@@ -0,0 +1,168 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+	"sync"
+)
+
+// Dropper is a block that holds up to nine item stacks and drops them as item entities when activated. Unlike
+// a Dispenser, a dropper never shoots or uses the items it holds.
+type Dropper struct {
+	solid
+	bassDrum
+
+	// Facing is the direction that the dropper is facing. Items are dropped towards this face.
+	Facing cube.Face
+	// CustomName is the custom name of the dropper. This name is displayed when the dropper is opened, and
+	// may include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewDropper creates a new initialised dropper. The inventory is properly initialised.
+func NewDropper() Dropper {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Dropper{
+		inventory: inventory.New(9, func(slot int, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+	}
+}
+
+// Inventory returns the inventory of the dropper. The size of the inventory will be 9.
+func (d Dropper) Inventory() *inventory.Inventory {
+	return d.inventory
+}
+
+// WithName returns the dropper after applying a specific name to the block.
+func (d Dropper) WithName(a ...interface{}) world.Item {
+	d.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return d
+}
+
+// Dispense drops a single item from a random non-empty slot of the dropper at the position passed in front of
+// the dropper. False is returned if the dropper was empty. As there is no redstone yet, Dispense must be called
+// by plugins to activate the dropper.
+func (d Dropper) Dispense(pos cube.Pos, w *world.World) bool {
+	return dispenseFrom(pos, d.Facing, w, d.inventory, func(world.Item) DispenseBehaviour {
+		return dropDispenseBehaviour
+	})
+}
+
+// AddViewer adds a viewer to the dropper, so that it is updated whenever the inventory of the dropper is
+// changed.
+func (d Dropper) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	d.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the dropper, so that slot updates in the inventory are no longer sent to
+// it.
+func (d Dropper) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	delete(d.viewers, v)
+}
+
+// Activate ...
+func (d Dropper) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (d Dropper) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, d)
+	if !used {
+		return
+	}
+	inv := d.inventory
+	//noinspection GoAssignmentToReceiver
+	d = NewDropper()
+	copyContents(d.inventory, inv)
+	d.Facing = calculateFace(user, pos)
+
+	place(w, pos, d, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (d Dropper) BreakInfo() BreakInfo {
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, simpleDrops(append(d.inventory.Items(), item.NewStack(Dropper{CustomName: d.CustomName}, 1))...))
+}
+
+// PickWithData returns the dropper as an item including its contents.
+func (d Dropper) PickWithData() item.Stack {
+	pick := NewDropper()
+	pick.CustomName = d.CustomName
+	copyContents(pick.inventory, d.inventory)
+	return item.NewStack(pick, 1).WithLore("(+DATA)")
+}
+
+// DecodeNBT ...
+func (d Dropper) DecodeNBT(data map[string]interface{}) interface{} {
+	facing := d.Facing
+	//noinspection GoAssignmentToReceiver
+	d = NewDropper()
+	d.Facing = facing
+	d.CustomName = nbtconv.MapString(data, "CustomName")
+	nbtconv.InvFromNBT(d.inventory, nbtconv.MapSlice(data, "Items"))
+	return d
+}
+
+// EncodeNBT ...
+func (d Dropper) EncodeNBT() map[string]interface{} {
+	if d.inventory == nil {
+		facing, customName := d.Facing, d.CustomName
+		//noinspection GoAssignmentToReceiver
+		d = NewDropper()
+		d.Facing, d.CustomName = facing, customName
+	}
+	m := map[string]interface{}{
+		"Items": nbtconv.InvToNBT(d.inventory),
+		"id":    "Dropper",
+	}
+	if d.CustomName != "" {
+		m["CustomName"] = d.CustomName
+	}
+	return m
+}
+
+// EncodeBlock ...
+func (d Dropper) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:dropper", map[string]interface{}{"facing_direction": int32(d.Facing), "triggered_bit": uint8(0)}
+}
+
+// EncodeItem ...
+func (d Dropper) EncodeItem() (name string, meta int16) {
+	return "minecraft:dropper", 0
+}
+
+// allDroppers returns all possible states of a dropper.
+func allDroppers() (d []world.Block) {
+	for i := cube.Face(0); i < 6; i++ {
+		d = append(d, Dropper{Facing: i})
+	}
+	return
+}
@@ -0,0 +1,150 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/action"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+)
+
+// EnderChest is a chest that stores items in an inventory bound to the player that opens it, rather than to
+// the block itself. Every ender chest opened by a player shows the same inventory.
+// The empty value of EnderChest is not valid. It must be created using block.NewEnderChest().
+type EnderChest struct {
+	chest
+	transparent
+	bass
+
+	// Facing is the direction that the ender chest is facing.
+	Facing cube.Direction
+
+	viewerMu *sync.RWMutex
+	viewers  map[ContainerViewer]struct{}
+}
+
+// NewEnderChest creates a new initialised ender chest.
+func NewEnderChest() EnderChest {
+	return EnderChest{
+		viewerMu: new(sync.RWMutex),
+		viewers:  make(map[ContainerViewer]struct{}, 1),
+	}
+}
+
+// EnderChestOpener represents an entity that is able to open an ender chest, showing its own ender chest
+// inventory.
+type EnderChestOpener interface {
+	// OpenEnderChest opens the ender chest inventory of the entity using the ender chest at the position
+	// passed.
+	OpenEnderChest(pos cube.Pos)
+}
+
+// AddViewer adds a viewer to the ender chest. The ender chest is opened if it did not have any viewers yet.
+func (c EnderChest) AddViewer(v ContainerViewer, w *world.World, pos cube.Pos) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+	if len(c.viewers) == 0 {
+		c.showAction(w, pos, action.Open{}, sound.EnderChestOpen{})
+	}
+	c.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the ender chest. The ender chest is closed if it no longer has any
+// viewers.
+func (c EnderChest) RemoveViewer(v ContainerViewer, w *world.World, pos cube.Pos) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+	if len(c.viewers) == 0 {
+		return
+	}
+	delete(c.viewers, v)
+	if len(c.viewers) == 0 {
+		c.showAction(w, pos, action.Close{}, sound.EnderChestClose{})
+	}
+}
+
+// showAction shows a block action to the viewers of the ender chest and plays the sound passed.
+func (c EnderChest) showAction(w *world.World, pos cube.Pos, a action.Action, s world.Sound) {
+	for _, v := range w.Viewers(pos.Vec3()) {
+		v.ViewBlockAction(pos, a)
+	}
+	w.PlaySound(pos.Vec3Centre(), s)
+}
+
+// Activate ...
+func (c EnderChest) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+	if opener, ok := u.(EnderChestOpener); ok {
+		opener.OpenEnderChest(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (c EnderChest) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, c)
+	if !used {
+		return
+	}
+	//noinspection GoAssignmentToReceiver
+	c = NewEnderChest()
+	c.Facing = user.Facing().Opposite()
+
+	place(w, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// CanDisplace ...
+func (EnderChest) CanDisplace(b world.Liquid) bool {
+	_, water := b.(Water)
+	return water
+}
+
+// SideClosed ...
+func (EnderChest) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// LightEmissionLevel ...
+func (EnderChest) LightEmissionLevel() uint8 {
+	return 7
+}
+
+// BreakInfo ...
+func (c EnderChest) BreakInfo() BreakInfo {
+	return newBreakInfo(22.5, pickaxeHarvestable, pickaxeEffective, silkTouchDrop(item.NewStack(Obsidian{}, 8), item.NewStack(c, 1)))
+}
+
+// DecodeNBT ...
+func (c EnderChest) DecodeNBT(map[string]interface{}) interface{} {
+	facing := c.Facing
+	//noinspection GoAssignmentToReceiver
+	c = NewEnderChest()
+	c.Facing = facing
+	return c
+}
+
+// EncodeNBT ...
+func (c EnderChest) EncodeNBT() map[string]interface{} {
+	return map[string]interface{}{"id": "EnderChest"}
+}
+
+// EncodeItem ...
+func (EnderChest) EncodeItem() (name string, meta int16) {
+	return "minecraft:ender_chest", 0
+}
+
+// EncodeBlock ...
+func (c EnderChest) EncodeBlock() (name string, properties map[string]interface{}) {
+	return "minecraft:ender_chest", map[string]interface{}{"facing_direction": 2 + int32(c.Facing)}
+}
+
+// allEnderChests ...
+func allEnderChests() (chests []world.Block) {
+	for _, direction := range cube.Directions() {
+		chests = append(chests, EnderChest{Facing: direction})
+	}
+	return
+}
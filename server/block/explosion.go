@@ -0,0 +1,35 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// explode creates an explosion with the radius passed at the centre passed, damaging and knocking back all
+// living entities around it. The block passed is the block that caused the explosion and is used as the source
+// of the damage dealt. Blocks around the explosion are not destroyed.
+func explode(centre mgl64.Vec3, radius float64, w *world.World, b world.Block) {
+	w.PlaySound(centre, sound.Explosion{})
+	w.AddParticle(centre, particle.HugeExplosion{})
+
+	size := 2 * radius
+	box := physics.NewAABB(centre, centre).Grow(size)
+	for _, e := range w.EntitiesWithin(box, nil) {
+		l, ok := e.(entity.Living)
+		if !ok {
+			continue
+		}
+		dist := world.Distance(e.Position(), centre)
+		if dist > size {
+			continue
+		}
+		impact := 1 - dist/size
+		l.Hurt((impact*impact+impact)*3.5*size+1, damage.SourceBlockExplosion{Block: b})
+		l.KnockBack(centre, impact, impact/2)
+	}
+}
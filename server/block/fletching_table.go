@@ -0,0 +1,28 @@
+package block
+
+// FletchingTable is a block that serves as the fletcher's job site block. Unlike in Java Edition, it has no UI
+// and cannot be used by players.
+type FletchingTable struct {
+	solid
+	bass
+}
+
+// FlammabilityInfo ...
+func (FletchingTable) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// BreakInfo ...
+func (f FletchingTable) BreakInfo() BreakInfo {
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, oneOf(f))
+}
+
+// EncodeItem ...
+func (FletchingTable) EncodeItem() (name string, meta int16) {
+	return "minecraft:fletching_table", 0
+}
+
+// EncodeBlock ...
+func (FletchingTable) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:fletching_table", nil
+}
@@ -0,0 +1,429 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/smelting"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Furnace is a block that smelts items, such as ores and food, using fuel. It holds the item being smelted, the
+// fuel and the output in its three slots.
+type Furnace struct {
+	solid
+	bassDrum
+
+	// Facing is the direction that the front of the furnace is facing.
+	Facing cube.Direction
+	// Lit specifies if the furnace is lit, which it is while it is burning fuel.
+	Lit bool
+	// CustomName is the custom name of the furnace. This name is displayed when the furnace is opened, and may
+	// include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+	state     *furnaceState
+}
+
+// furnaceState holds the progress of a furnace. It is shared between all copies of a Furnace.
+type furnaceState struct {
+	mu sync.Mutex
+	// burnTime is the amount of ticks left before the fuel that is burning runs out. maxBurnTime is the amount of
+	// ticks that this fuel burns for in total.
+	burnTime, maxBurnTime int64
+	// cookTime is the amount of ticks that the input of the furnace has been smelted for.
+	cookTime int64
+	// xp holds the experience of the items smelted that have not yet been taken out of the furnace. r decides if
+	// fractions of experience points are paid out when withdrawing it.
+	xp *smelting.StoredExperience
+	r  *rand.Rand
+}
+
+const (
+	// furnaceInputSlot is the slot of a furnace that holds the item that is smelted.
+	furnaceInputSlot = iota
+	// furnaceFuelSlot is the slot of a furnace that holds the fuel burned to smelt the input.
+	furnaceFuelSlot
+	// furnaceOutputSlot is the slot of a furnace that the smelted items end up in.
+	furnaceOutputSlot
+)
+
+// NewFurnace creates a new initialised furnace. The inventory is properly initialised.
+func NewFurnace() Furnace {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Furnace{
+		inventory: inventory.New(3, func(slot int, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+		state: &furnaceState{
+			xp: smelting.NewStoredExperience(0, 0),
+			r:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		},
+	}
+}
+
+// initialised returns the furnace with an initialised inventory and state if it was not created using
+// NewFurnace, such as a Furnace{} set directly in a world. The other fields of the furnace are kept.
+func (f Furnace) initialised() Furnace {
+	if f.inventory == nil {
+		facing, lit, customName := f.Facing, f.Lit, f.CustomName
+		//noinspection GoAssignmentToReceiver
+		f = NewFurnace()
+		f.Facing, f.Lit, f.CustomName = facing, lit, customName
+	}
+	return f
+}
+
+// Inventory returns the inventory of the furnace. The size of the inventory will be 3.
+func (f Furnace) Inventory() *inventory.Inventory {
+	return f.inventory
+}
+
+// WithName returns the furnace after applying a specific name to the block.
+func (f Furnace) WithName(a ...interface{}) world.Item {
+	f.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return f
+}
+
+// LightEmissionLevel ...
+func (f Furnace) LightEmissionLevel() uint8 {
+	if f.Lit {
+		return 13
+	}
+	return 0
+}
+
+// Tick burns fuel and smelts the input of the furnace, lighting the furnace while it burns fuel.
+func (f Furnace) Tick(_ int64, pos cube.Pos, w *world.World) {
+	if f.inventory == nil {
+		// The furnace was not initialised, for example because it was set in the world as Furnace{}. It is
+		// replaced by an initialised furnace, which smelts from the next tick on.
+		w.SetBlock(pos, f.initialised())
+		return
+	}
+	if lit := f.smelt(1); lit != f.Lit {
+		f.Lit = lit
+		w.SetBlock(pos, f)
+	}
+}
+
+// Loaded makes sure that the furnace is only lit if it has fuel left to burn. A furnace is not ticked while its
+// chunk is unloaded, so it resumes smelting where it left off, unless it catches up on the time that passed using
+// SimulateElapsed.
+func (f Furnace) Loaded(_ cube.Pos, _ int64) world.Block {
+	//noinspection GoAssignmentToReceiver
+	f = f.initialised()
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	f.Lit = f.state.burnTime > 0
+	return f
+}
+
+// SimulateElapsed burns fuel and smelts the input of the furnace for the ticks that passed while its chunk was
+// unloaded, as if the furnace had been ticked all along.
+func (f Furnace) SimulateElapsed(ticks int64, pos cube.Pos, w *world.World) {
+	if lit := f.smelt(ticks); lit != f.Lit {
+		f.Lit = lit
+		w.SetBlock(pos, f)
+	}
+}
+
+// WithdrawExperience withdraws the experience stored for n of the items smelted by the furnace, such as when n
+// items are taken out of its output slot, and returns the amount of experience points that should be paid out.
+func (f Furnace) WithdrawExperience(n int) int {
+	if f.state == nil {
+		return 0
+	}
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.state.xp.Withdraw(n, f.state.r)
+}
+
+// DrainExperience withdraws all experience stored in the furnace, such as when it is broken, and returns the
+// amount of experience points that should be paid out.
+func (f Furnace) DrainExperience() int {
+	if f.state == nil {
+		return 0
+	}
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.state.xp.Drain(f.state.r)
+}
+
+// smelt progresses the furnace by the amount of ticks passed, burning new fuel whenever the fuel burning runs out
+// while there is an input that may be smelted. True is returned if the furnace is still burning fuel afterwards.
+func (f Furnace) smelt(ticks int64) bool {
+	s := f.state
+	if s == nil || f.inventory == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ticks > 0 {
+		r, cookTime, ok := f.recipe()
+		if s.burnTime == 0 && (!ok || !f.burnFuel()) {
+			break
+		}
+		// Skip ahead to the first tick at which either the fuel runs out or the input is smelted.
+		step := min64(ticks, s.burnTime)
+		if ok {
+			step = min64(step, max64(cookTime-s.cookTime, 1))
+		}
+		ticks -= step
+		s.burnTime -= step
+		if !ok {
+			s.cookTime = 0
+			continue
+		}
+		if s.cookTime += step; s.cookTime >= cookTime {
+			s.cookTime = 0
+			s.xp.Store(r)
+			f.finishSmelting(r)
+		}
+	}
+	if ticks > 0 {
+		// A furnace that runs out of fuel slowly loses the progress made on the input.
+		s.cookTime = max64(s.cookTime-ticks*2, 0)
+	}
+	return s.burnTime > 0
+}
+
+// recipe returns the recipe that the input of the furnace is smelted with and the amount of ticks that smelting
+// it takes. False is returned if there is no input, if it cannot be smelted in a furnace or if its output does not
+// fit in the output slot.
+func (f Furnace) recipe() (smelting.Recipe, int64, bool) {
+	input, _ := f.inventory.Item(furnaceInputSlot)
+	if input.Empty() {
+		return smelting.Recipe{}, 0, false
+	}
+	r, ok := smelting.Smelt(input.Item(), smelting.SmelterFurnace())
+	if !ok {
+		return smelting.Recipe{}, 0, false
+	}
+	output, _ := f.inventory.Item(furnaceOutputSlot)
+	if !output.Empty() && (!output.Comparable(r.Output) || output.Count()+r.Output.Count() > output.MaxCount()) {
+		return smelting.Recipe{}, 0, false
+	}
+	return r, furnaceTicks(r.Duration), true
+}
+
+// burnFuel starts burning a single item from the fuel slot of the furnace. False is returned if the fuel slot does
+// not hold any fuel. burnFuel must be called while the state of the furnace is locked.
+func (f Furnace) burnFuel() bool {
+	fuel, _ := f.inventory.Item(furnaceFuelSlot)
+	if fuel.Empty() {
+		return false
+	}
+	info, ok := smelting.FuelOf(fuel.Item())
+	if !ok {
+		return false
+	}
+	f.state.burnTime = furnaceTicks(info.Duration)
+	f.state.maxBurnTime = f.state.burnTime
+
+	left := fuel.Grow(-1)
+	if left.Empty() && info.Residue != nil {
+		left = item.NewStack(info.Residue, 1)
+	}
+	_ = f.inventory.SetItem(furnaceFuelSlot, left)
+	return true
+}
+
+// finishSmelting replaces a single input item of the furnace with the output of the recipe passed.
+func (f Furnace) finishSmelting(r smelting.Recipe) {
+	input, _ := f.inventory.Item(furnaceInputSlot)
+	_ = f.inventory.SetItem(furnaceInputSlot, input.Grow(-1))
+
+	output, _ := f.inventory.Item(furnaceOutputSlot)
+	if output.Empty() {
+		output = r.Output
+	} else {
+		output = output.Grow(r.Output.Count())
+	}
+	_ = f.inventory.SetItem(furnaceOutputSlot, output)
+}
+
+// furnaceTicks returns the amount of ticks that a furnace smelts or burns for, for a recipe or fuel with the
+// duration passed.
+func furnaceTicks(d time.Duration) int64 {
+	return int64(smelting.SmelterFurnace().Duration(d) / (time.Second / 20))
+}
+
+// InsertSlots returns the input slot for items inserted through the top of the furnace and the fuel slot for fuel
+// inserted through its sides. Nothing may be inserted through the bottom.
+func (Furnace) InsertSlots(face cube.Face, it item.Stack) []int {
+	switch face {
+	case cube.FaceUp:
+		return []int{furnaceInputSlot}
+	case cube.FaceDown:
+		return []int{}
+	}
+	if _, ok := smelting.FuelOf(it.Item()); !ok {
+		return []int{}
+	}
+	return []int{furnaceFuelSlot}
+}
+
+// ExtractSlots returns the output slot for items extracted through the bottom of the furnace. Nothing may be
+// extracted through its other faces.
+func (Furnace) ExtractSlots(face cube.Face) []int {
+	if face == cube.FaceDown {
+		return []int{furnaceOutputSlot}
+	}
+	return []int{}
+}
+
+// AddViewer adds a viewer to the furnace, so that it is updated whenever the inventory of the furnace is changed.
+func (f Furnace) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	f.viewerMu.Lock()
+	defer f.viewerMu.Unlock()
+	f.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the furnace, so that slot updates in the inventory are no longer sent to
+// it.
+func (f Furnace) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	f.viewerMu.Lock()
+	defer f.viewerMu.Unlock()
+	delete(f.viewers, v)
+}
+
+// Activate ...
+func (f Furnace) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		if f.inventory == nil {
+			// The furnace was not initialised, so it is replaced by an initialised furnace before it is opened.
+			w.SetBlock(pos, f.initialised())
+		}
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (f Furnace) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, f)
+	if !used {
+		return
+	}
+	inv, customName := f.inventory, f.CustomName
+	//noinspection GoAssignmentToReceiver
+	f = NewFurnace()
+	copyContents(f.inventory, inv)
+	f.CustomName = customName
+	f.Facing = user.Facing().Opposite()
+
+	place(w, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (f Furnace) BreakInfo() BreakInfo {
+	var drops []item.Stack
+	if f.inventory != nil {
+		drops = f.inventory.Items()
+	}
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, simpleDrops(append(drops, item.NewStack(Furnace{CustomName: f.CustomName}, 1))...))
+}
+
+// PickWithData returns the furnace as an item including its contents.
+func (f Furnace) PickWithData() item.Stack {
+	pick := NewFurnace()
+	pick.CustomName = f.CustomName
+	copyContents(pick.inventory, f.inventory)
+	return item.NewStack(pick, 1).WithLore("(+DATA)")
+}
+
+// DecodeNBT ...
+func (f Furnace) DecodeNBT(data map[string]interface{}) interface{} {
+	facing, lit := f.Facing, f.Lit
+	//noinspection GoAssignmentToReceiver
+	f = NewFurnace()
+	f.Facing, f.Lit = facing, lit
+	f.CustomName = nbtconv.MapString(data, "CustomName")
+	f.state.burnTime = int64(nbtconv.MapInt16(data, "BurnTime"))
+	f.state.maxBurnTime = int64(nbtconv.MapInt16(data, "BurnDuration"))
+	f.state.cookTime = int64(nbtconv.MapInt16(data, "CookTime"))
+	f.state.xp = smelting.NewStoredExperience(float64(nbtconv.MapFloat32(data, "StoredXP")), int(nbtconv.MapInt32(data, "StoredXPItems")))
+	nbtconv.InvFromNBT(f.inventory, nbtconv.MapSlice(data, "Items"))
+	return f
+}
+
+// EncodeNBT ...
+func (f Furnace) EncodeNBT() map[string]interface{} {
+	//noinspection GoAssignmentToReceiver
+	f = f.initialised()
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	xp, items := f.state.xp.Experience()
+	m := map[string]interface{}{
+		"Items":         nbtconv.InvToNBT(f.inventory),
+		"BurnTime":      int16(f.state.burnTime),
+		"BurnDuration":  int16(f.state.maxBurnTime),
+		"CookTime":      int16(f.state.cookTime),
+		"StoredXP":      float32(xp),
+		"StoredXPItems": int32(items),
+		"id":            "Furnace",
+	}
+	if f.CustomName != "" {
+		m["CustomName"] = f.CustomName
+	}
+	return m
+}
+
+// EncodeBlock ...
+func (f Furnace) EncodeBlock() (string, map[string]interface{}) {
+	name := "minecraft:furnace"
+	if f.Lit {
+		name = "minecraft:lit_furnace"
+	}
+	return name, map[string]interface{}{"facing_direction": 2 + int32(f.Facing)}
+}
+
+// EncodeItem ...
+func (Furnace) EncodeItem() (name string, meta int16) {
+	return "minecraft:furnace", 0
+}
+
+// allFurnaces returns all possible states of a furnace.
+func allFurnaces() (furnaces []world.Block) {
+	for _, direction := range cube.Directions() {
+		furnaces = append(furnaces, Furnace{Facing: direction}, Furnace{Facing: direction, Lit: true})
+	}
+	return
+}
+
+// min64 returns the smallest of the two int64s passed.
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// max64 returns the largest of the two int64s passed.
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,126 @@
+package block_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// furnacePos is the position at which furnaces are placed in tests. It is outside the chunks loaded around the
+// origin, so that its chunk is unloaded when a servertest.World is focused on the origin.
+var furnacePos = cube.Pos{200, 5, 200}
+
+// newSmeltingFurnace places a furnace smelting eight raw iron using a single coal at furnacePos, and advances the
+// World until the first ingot was smelted and the second is a quarter done.
+func newSmeltingFurnace(t *testing.T, w *servertest.World) block.Furnace {
+	t.Helper()
+	w.Focus(furnacePos.Vec3())
+	f := block.NewFurnace()
+	_ = f.Inventory().SetItem(0, item.NewStack(item.RawIron{}, 8))
+	_ = f.Inventory().SetItem(1, item.NewStack(item.Coal{}, 1))
+	w.SetBlock(furnacePos, f)
+	w.Advance(250)
+	checkFurnace(t, w, 7, 1, true)
+	return f
+}
+
+// unloadFurnace unloads the chunk of the furnace at furnacePos and advances the World by the amount of ticks passed
+// while it is unloaded, after which the chunk is loaded again.
+func unloadFurnace(t *testing.T, w *servertest.World, f block.Furnace, ticks int) {
+	t.Helper()
+	w.Focus(mgl64.Vec3{})
+	w.UnloadUnusedChunks()
+	w.Advance(ticks)
+	w.Focus(furnacePos.Vec3())
+	if loaded, ok := w.Block(furnacePos).(block.Furnace); !ok || loaded.Inventory() == f.Inventory() {
+		t.Fatalf("furnace was not unloaded and loaded from the provider again")
+	}
+}
+
+// checkFurnace checks the amount of raw iron left in the furnace at furnacePos, the amount of iron ingots smelted
+// and whether the furnace is lit.
+func checkFurnace(t *testing.T, w *servertest.World, input, output int, lit bool) {
+	t.Helper()
+	f, ok := w.Block(furnacePos).(block.Furnace)
+	if !ok {
+		t.Fatalf("block at %v is %#v, want furnace", furnacePos, w.Block(furnacePos))
+	}
+	in, _ := f.Inventory().Item(0)
+	out, _ := f.Inventory().Item(2)
+	if in.Count() != input || out.Count() != output || f.Lit != lit {
+		t.Errorf("furnace has %v raw iron and %v ingots and is lit: %v, want %v, %v and %v", in.Count(), out.Count(), f.Lit, input, output, lit)
+	}
+}
+
+// TestFurnaceUnload checks that a furnace keeps its fuel and smelting progress when its chunk is unloaded and
+// loaded again, and that it does not smelt while unloaded.
+func TestFurnaceUnload(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	f := newSmeltingFurnace(t, w)
+
+	unloadFurnace(t, w, f, 1000)
+	checkFurnace(t, w, 7, 1, true)
+
+	// The second ingot was already smelted for 50 ticks, so it is done after another 150.
+	w.Advance(149)
+	checkFurnace(t, w, 7, 1, true)
+	w.Advance(1)
+	checkFurnace(t, w, 6, 2, true)
+
+	// The experience of both ingots, 0.7 points each, was kept while the furnace was unloaded.
+	f = w.Block(furnacePos).(block.Furnace)
+	if xp := f.WithdrawExperience(1); xp != 0 {
+		t.Errorf("withdrawing the first ingot paid out %v experience, want 0 of 1.4", xp)
+	}
+	if xp := f.WithdrawExperience(1); xp != 1 && xp != 2 {
+		t.Errorf("withdrawing the second ingot paid out %v experience, want 1 or 2", xp)
+	}
+	if xp := f.DrainExperience(); xp != 0 {
+		t.Errorf("%v experience left after withdrawing both ingots", xp)
+	}
+}
+
+// TestFurnaceElapsedSimulation checks that a furnace catches up on the time that its chunk spent unloaded if
+// elapsed simulation is enabled, and goes out once it runs out of fuel.
+func TestFurnaceElapsedSimulation(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.SetElapsedSimulation(true)
+	f := newSmeltingFurnace(t, w)
+
+	unloadFurnace(t, w, f, 600)
+	checkFurnace(t, w, 4, 4, true)
+
+	// A single coal smelts eight items, after which the furnace goes out.
+	unloadFurnace(t, w, f, 2000)
+	checkFurnace(t, w, 0, 8, false)
+}
+
+// TestFurnaceZeroValue checks that a furnace set in the world without being created using NewFurnace, as done by
+// commands such as /setblock, is ticked without panicking and smelts like any other furnace once it holds input
+// and fuel.
+func TestFurnaceZeroValue(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	pos := cube.Pos{0, 5, 0}
+	w.SetBlock(pos, block.Furnace{Lit: true})
+	if xp := w.Block(pos).(block.Furnace).DrainExperience(); xp != 0 {
+		t.Errorf("zero-value furnace paid out %v experience, want 0", xp)
+	}
+	w.Advance(1)
+	f, ok := w.Block(pos).(block.Furnace)
+	if !ok || f.Inventory() == nil {
+		t.Fatalf("furnace was not initialised after being ticked")
+	}
+	_ = f.Inventory().SetItem(0, item.NewStack(item.RawIron{}, 1))
+	_ = f.Inventory().SetItem(1, item.NewStack(item.Coal{}, 1))
+	w.Advance(250)
+	if it, _ := f.Inventory().Item(2); it.Count() != 1 {
+		t.Errorf("furnace smelted %v items, want 1", it.Count())
+	}
+}
@@ -0,0 +1,100 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Grindstone is a block that repairs items and removes their non-curse enchantments, refunding part of the
+// experience spent on the enchantments.
+type Grindstone struct {
+	transparent
+
+	// Attach is the way the Grindstone is attached to the block it was placed against: On top of it, hanging
+	// from it or against its side.
+	Attach GrindstoneAttachment
+	// Facing is the direction the Grindstone is facing.
+	Facing cube.Direction
+}
+
+// BreakInfo ...
+func (g Grindstone) BreakInfo() BreakInfo {
+	return newBreakInfo(2, pickaxeHarvestable, pickaxeEffective, oneOf(Grindstone{}))
+}
+
+// Activate opens the grindstone UI for the user.
+func (g Grindstone) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (g Grindstone) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, g)
+	if !used {
+		return false
+	}
+	g.Facing = user.Facing().Opposite()
+	switch face {
+	case cube.FaceUp:
+		g.Attach = StandingGrindstone()
+	case cube.FaceDown:
+		g.Attach = HangingGrindstone()
+	default:
+		g.Attach, g.Facing = WallGrindstone(), face.Direction()
+	}
+
+	place(w, pos, g, user, ctx)
+	return placed(ctx)
+}
+
+// CanDisplace ...
+func (g Grindstone) CanDisplace(l world.Liquid) bool {
+	_, water := l.(Water)
+	return water
+}
+
+// SideClosed ...
+func (g Grindstone) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// Model ...
+func (g Grindstone) Model() world.BlockModel {
+	return model.Grindstone{Axis: g.Facing.RotateRight().Face().Axis()}
+}
+
+// EncodeItem ...
+func (g Grindstone) EncodeItem() (name string, meta int16) {
+	return "minecraft:grindstone", 0
+}
+
+// EncodeBlock ...
+func (g Grindstone) EncodeBlock() (name string, properties map[string]interface{}) {
+	direction := 2
+	switch g.Facing {
+	case cube.South:
+		direction = 0
+	case cube.West:
+		direction = 1
+	case cube.East:
+		direction = 3
+	}
+	return "minecraft:grindstone", map[string]interface{}{"attachment": g.Attach.String(), "direction": int32(direction)}
+}
+
+// allGrindstones returns all possible states of a grindstone.
+func allGrindstones() (grindstones []world.Block) {
+	for _, a := range GrindstoneAttachments() {
+		for i := cube.Direction(0); i <= 3; i++ {
+			grindstones = append(grindstones, Grindstone{Attach: a, Facing: i})
+		}
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package block
+
+// GrindstoneAttachment represents a type of attachment of a Grindstone.
+type GrindstoneAttachment struct {
+	grindstoneAttachment
+}
+
+type grindstoneAttachment uint8
+
+// StandingGrindstone is the attachment of a Grindstone placed on top of a block.
+func StandingGrindstone() GrindstoneAttachment {
+	return GrindstoneAttachment{0}
+}
+
+// HangingGrindstone is the attachment of a Grindstone hanging from the bottom of a block.
+func HangingGrindstone() GrindstoneAttachment {
+	return GrindstoneAttachment{1}
+}
+
+// WallGrindstone is the attachment of a Grindstone placed against the side of a block.
+func WallGrindstone() GrindstoneAttachment {
+	return GrindstoneAttachment{2}
+}
+
+// Uint8 returns the grindstone attachment as a uint8.
+func (g grindstoneAttachment) Uint8() uint8 {
+	return uint8(g)
+}
+
+// String ...
+func (g grindstoneAttachment) String() string {
+	switch g {
+	case 0:
+		return "standing"
+	case 1:
+		return "hanging"
+	case 2:
+		return "side"
+	}
+	panic("unknown grindstone attachment")
+}
+
+// GrindstoneAttachments returns all grindstone attachments.
+func GrindstoneAttachments() []GrindstoneAttachment {
+	return []GrindstoneAttachment{StandingGrindstone(), HangingGrindstone(), WallGrindstone()}
+}
@@ -7,12 +7,15 @@ const (
 	hashAmethystBlock
 	hashAncientDebris
 	hashAndesite
+	hashBanner
 	hashBarrel
 	hashBarrier
 	hashBasalt
 	hashBeacon
+	hashBed
 	hashBedrock
 	hashBeetrootSeeds
+	hashBell
 	hashBlueIce
 	hashBoneBlock
 	hashBookshelf
@@ -21,6 +24,8 @@ const (
 	hashCalcite
 	hashCarpet
 	hashCarrot
+	hashCartographyTable
+	hashCauldron
 	hashChain
 	hashChest
 	hashChiseledQuartz
@@ -31,6 +36,7 @@ const (
 	hashCocoaBean
 	hashConcrete
 	hashConcretePowder
+	hashConduit
 	hashCopperOre
 	hashCoral
 	hashCoralBlock
@@ -40,19 +46,24 @@ const (
 	hashDiorite
 	hashDirt
 	hashDirtPath
+	hashDispenser
 	hashDoubleFlower
 	hashDoubleTallGrass
 	hashDragonEgg
 	hashDriedKelpBlock
 	hashDripstone
+	hashDropper
 	hashEmeraldBlock
 	hashEmeraldOre
 	hashEndBrickStairs
 	hashEndBricks
 	hashEndStone
+	hashEnderChest
 	hashFarmland
 	hashFire
+	hashFletchingTable
 	hashFlower
+	hashFurnace
 	hashGildedBlackstone
 	hashGlass
 	hashGlassPane
@@ -63,12 +74,17 @@ const (
 	hashGranite
 	hashGrass
 	hashGravel
+	hashGrindstone
+	hashHoney
 	hashHoneycombBlock
+	hashHopper
+	hashIce
 	hashInvisibleBedrock
 	hashIronBars
 	hashIronBlock
 	hashIronOre
 	hashItemFrame
+	hashJukebox
 	hashKelp
 	hashLadder
 	hashLantern
@@ -77,8 +93,11 @@ const (
 	hashLava
 	hashLeaves
 	hashLight
+	hashLightningRod
 	hashLitPumpkin
 	hashLog
+	hashLoom
+	hashMagma
 	hashMelon
 	hashMelonSeeds
 	hashMossCarpet
@@ -104,13 +123,17 @@ const (
 	hashRawCopperBlock
 	hashRawGoldBlock
 	hashRawIronBlock
+	hashRespawnAnchor
 	hashSand
 	hashSandstone
 	hashSandstoneStairs
+	hashScaffolding
 	hashSeaLantern
 	hashSeaPickle
 	hashShroomlight
 	hashSign
+	hashSlime
+	hashSmithingTable
 	hashSnow
 	hashSoulSand
 	hashSoulSoil
@@ -125,7 +148,10 @@ const (
 	hashTerracotta
 	hashTorch
 	hashTuff
+	hashTwistingVines
+	hashVines
 	hashWater
+	hashWeepingVines
 	hashWheatSeeds
 	hashWood
 	hashWoodDoor
@@ -153,6 +179,10 @@ func (a Andesite) Hash() uint64 {
 	return hashAndesite | uint64(boolByte(a.Polished))<<8
 }
 
+func (b Banner) Hash() uint64 {
+	return hashBanner | uint64(b.Attach.Uint8())<<8
+}
+
 func (b Barrel) Hash() uint64 {
 	return hashBarrel | uint64(b.Facing)<<8 | uint64(boolByte(b.Open))<<11
 }
@@ -169,6 +199,10 @@ func (Beacon) Hash() uint64 {
 	return hashBeacon
 }
 
+func (b Bed) Hash() uint64 {
+	return hashBed | uint64(b.Facing)<<8 | uint64(boolByte(b.Head))<<10
+}
+
 func (b Bedrock) Hash() uint64 {
 	return hashBedrock | uint64(boolByte(b.InfiniteBurning))<<8
 }
@@ -177,6 +211,10 @@ func (b BeetrootSeeds) Hash() uint64 {
 	return hashBeetrootSeeds | uint64(b.Growth)<<8
 }
 
+func (b Bell) Hash() uint64 {
+	return hashBell | uint64(b.Attach.Uint8())<<8 | uint64(b.Facing)<<10
+}
+
 func (BlueIce) Hash() uint64 {
 	return hashBlueIce
 }
@@ -209,6 +247,14 @@ func (c Carrot) Hash() uint64 {
 	return hashCarrot | uint64(c.Growth)<<8
 }
 
+func (CartographyTable) Hash() uint64 {
+	return hashCartographyTable
+}
+
+func (c Cauldron) Hash() uint64 {
+	return hashCauldron | uint64(c.FillLevel)<<8 | uint64(c.Liquid.Uint8())<<16
+}
+
 func (c Chain) Hash() uint64 {
 	return hashChain | uint64(c.Axis)<<8
 }
@@ -249,6 +295,10 @@ func (c ConcretePowder) Hash() uint64 {
 	return hashConcretePowder | uint64(c.Colour.Uint8())<<8
 }
 
+func (Conduit) Hash() uint64 {
+	return hashConduit
+}
+
 func (c CopperOre) Hash() uint64 {
 	return hashCopperOre | uint64(c.Type.Uint8())<<8
 }
@@ -285,6 +335,10 @@ func (DirtPath) Hash() uint64 {
 	return hashDirtPath
 }
 
+func (d Dispenser) Hash() uint64 {
+	return hashDispenser | uint64(d.Facing)<<8
+}
+
 func (d DoubleFlower) Hash() uint64 {
 	return hashDoubleFlower | uint64(boolByte(d.UpperPart))<<8 | uint64(d.Type.Uint8())<<9
 }
@@ -305,6 +359,10 @@ func (Dripstone) Hash() uint64 {
 	return hashDripstone
 }
 
+func (d Dropper) Hash() uint64 {
+	return hashDropper | uint64(d.Facing)<<8
+}
+
 func (EmeraldBlock) Hash() uint64 {
 	return hashEmeraldBlock
 }
@@ -325,6 +383,10 @@ func (EndStone) Hash() uint64 {
 	return hashEndStone
 }
 
+func (c EnderChest) Hash() uint64 {
+	return hashEnderChest | uint64(c.Facing)<<8
+}
+
 func (f Farmland) Hash() uint64 {
 	return hashFarmland | uint64(f.Hydration)<<8
 }
@@ -333,10 +395,18 @@ func (f Fire) Hash() uint64 {
 	return hashFire | uint64(f.Type.Uint8())<<8 | uint64(f.Age)<<9
 }
 
+func (FletchingTable) Hash() uint64 {
+	return hashFletchingTable
+}
+
 func (f Flower) Hash() uint64 {
 	return hashFlower | uint64(f.Type.Uint8())<<8
 }
 
+func (f Furnace) Hash() uint64 {
+	return hashFurnace | uint64(f.Facing)<<8 | uint64(boolByte(f.Lit))<<10
+}
+
 func (GildedBlackstone) Hash() uint64 {
 	return hashGildedBlackstone
 }
@@ -377,10 +447,26 @@ func (Gravel) Hash() uint64 {
 	return hashGravel
 }
 
+func (g Grindstone) Hash() uint64 {
+	return hashGrindstone | uint64(g.Attach.Uint8())<<8 | uint64(g.Facing)<<10
+}
+
+func (Honey) Hash() uint64 {
+	return hashHoney
+}
+
 func (HoneycombBlock) Hash() uint64 {
 	return hashHoneycombBlock
 }
 
+func (h Hopper) Hash() uint64 {
+	return hashHopper | uint64(h.Facing)<<8 | uint64(boolByte(h.Locked))<<11
+}
+
+func (Ice) Hash() uint64 {
+	return hashIce
+}
+
 func (InvisibleBedrock) Hash() uint64 {
 	return hashInvisibleBedrock
 }
@@ -401,6 +487,10 @@ func (i ItemFrame) Hash() uint64 {
 	return hashItemFrame | uint64(i.Facing)<<8 | uint64(boolByte(i.Glowing))<<11
 }
 
+func (Jukebox) Hash() uint64 {
+	return hashJukebox
+}
+
 func (k Kelp) Hash() uint64 {
 	return hashKelp | uint64(k.Age)<<8
 }
@@ -433,6 +523,10 @@ func (l Light) Hash() uint64 {
 	return hashLight | uint64(l.Level)<<8
 }
 
+func (l LightningRod) Hash() uint64 {
+	return hashLightningRod | uint64(l.Facing)<<8
+}
+
 func (l LitPumpkin) Hash() uint64 {
 	return hashLitPumpkin | uint64(l.Facing)<<8
 }
@@ -441,6 +535,14 @@ func (l Log) Hash() uint64 {
 	return hashLog | uint64(l.Wood.Uint8())<<8 | uint64(boolByte(l.Stripped))<<11 | uint64(l.Axis)<<12
 }
 
+func (l Loom) Hash() uint64 {
+	return hashLoom | uint64(l.Facing)<<8
+}
+
+func (Magma) Hash() uint64 {
+	return hashMagma
+}
+
 func (Melon) Hash() uint64 {
 	return hashMelon
 }
@@ -541,6 +643,10 @@ func (RawIronBlock) Hash() uint64 {
 	return hashRawIronBlock
 }
 
+func (r RespawnAnchor) Hash() uint64 {
+	return hashRespawnAnchor | uint64(r.Charge)<<8
+}
+
 func (s Sand) Hash() uint64 {
 	return hashSand | uint64(boolByte(s.Red))<<8
 }
@@ -553,6 +659,10 @@ func (s SandstoneStairs) Hash() uint64 {
 	return hashSandstoneStairs | uint64(boolByte(s.Smooth))<<8 | uint64(boolByte(s.Red))<<9 | uint64(boolByte(s.UpsideDown))<<10 | uint64(s.Facing)<<11
 }
 
+func (s Scaffolding) Hash() uint64 {
+	return hashScaffolding | uint64(s.Stability)<<8 | uint64(boolByte(s.StabilityCheck))<<16
+}
+
 func (SeaLantern) Hash() uint64 {
 	return hashSeaLantern
 }
@@ -569,6 +679,14 @@ func (s Sign) Hash() uint64 {
 	return hashSign | uint64(s.Wood.Uint8())<<8 | uint64(s.Attach.Uint8())<<11
 }
 
+func (Slime) Hash() uint64 {
+	return hashSlime
+}
+
+func (SmithingTable) Hash() uint64 {
+	return hashSmithingTable
+}
+
 func (Snow) Hash() uint64 {
 	return hashSnow
 }
@@ -625,10 +743,22 @@ func (Tuff) Hash() uint64 {
 	return hashTuff
 }
 
+func (t TwistingVines) Hash() uint64 {
+	return hashTwistingVines | uint64(t.Age)<<8
+}
+
+func (v Vines) Hash() uint64 {
+	return hashVines | uint64(boolByte(v.NorthDirection))<<8 | uint64(boolByte(v.EastDirection))<<9 | uint64(boolByte(v.SouthDirection))<<10 | uint64(boolByte(v.WestDirection))<<11
+}
+
 func (w Water) Hash() uint64 {
 	return hashWater | uint64(boolByte(w.Still))<<8 | uint64(w.Depth)<<9 | uint64(boolByte(w.Falling))<<17
 }
 
+func (t WeepingVines) Hash() uint64 {
+	return hashWeepingVines | uint64(t.Age)<<8
+}
+
 func (s WheatSeeds) Hash() uint64 {
 	return hashWheatSeeds | uint64(s.Growth)<<8
 }
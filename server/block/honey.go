@@ -0,0 +1,46 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Honey is a sticky block that slows down entities moving on top of it and reduces the fall damage of entities
+// landing on it.
+type Honey struct {
+	transparent
+}
+
+// Model ...
+func (Honey) Model() world.BlockModel {
+	return model.Honey{}
+}
+
+// BreakInfo ...
+func (h Honey) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(h))
+}
+
+// EntitySpeedFactor ...
+func (Honey) EntitySpeedFactor() float64 {
+	return 0.4
+}
+
+// EntityLand ...
+func (Honey) EntityLand(cube.Pos, *world.World, world.Entity) {}
+
+// FallDamageMultiplier reduces fall damage by 80%.
+func (Honey) FallDamageMultiplier(world.Entity) float64 {
+	return 0.2
+}
+
+// EncodeItem ...
+func (Honey) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_block", 0
+}
+
+// EncodeBlock ...
+func (Honey) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:honey_block", nil
+}
@@ -0,0 +1,436 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"go.uber.org/atomic"
+	"strings"
+	"sync"
+)
+
+// Hopper is a block that holds up to five item stacks. It pulls items from the container above it or from item
+// entities that land in it, and pushes items into the container that it is facing.
+type Hopper struct {
+	transparent
+
+	// Facing is the direction that the hopper is facing. Items are pushed into the container on this side of
+	// the hopper. A hopper can never face up.
+	Facing cube.Face
+	// Locked specifies if the hopper is locked. Locked hoppers neither pull nor push items. In vanilla, hoppers
+	// are locked when powered by redstone.
+	Locked bool
+	// CustomName is the custom name of the hopper. This name is displayed when the hopper is opened, and may
+	// include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+	// cooldown is the amount of ticks left before the hopper transfers items again.
+	cooldown *atomic.Int64
+}
+
+// hopperCooldown is the amount of ticks that a hopper waits after transferring items before it transfers items
+// again.
+const hopperCooldown = 8
+
+// NewHopper creates a new initialised hopper. The inventory is properly initialised.
+func NewHopper() Hopper {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Hopper{
+		inventory: inventory.New(5, func(slot int, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+		cooldown: atomic.NewInt64(0),
+	}
+}
+
+// initialised returns the hopper with an initialised inventory and cooldown if it was not created using
+// NewHopper, such as a Hopper{} set directly in a world. The other fields of the hopper are kept.
+func (h Hopper) initialised() Hopper {
+	if h.inventory != nil {
+		return h
+	}
+	facing, locked, customName := h.Facing, h.Locked, h.CustomName
+	//noinspection GoAssignmentToReceiver
+	h = NewHopper()
+	h.Facing, h.Locked, h.CustomName = facing, locked, customName
+	return h
+}
+
+// Inventory returns the inventory of the hopper. The size of the inventory will be 5.
+func (h Hopper) Inventory() *inventory.Inventory {
+	return h.inventory
+}
+
+// WithName returns the hopper after applying a specific name to the block.
+func (h Hopper) WithName(a ...interface{}) world.Item {
+	h.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return h
+}
+
+// Model ...
+func (Hopper) Model() world.BlockModel {
+	return model.Hopper{}
+}
+
+// Tick moves items into and out of the hopper once every 8 ticks. Unless the hopper is locked, it first pushes
+// a single item into the container that it faces, after which it pulls a single item from the container above
+// it, or items from an item entity in it if there is no container above.
+func (h Hopper) Tick(_ int64, pos cube.Pos, w *world.World) {
+	if h.inventory == nil {
+		// The hopper was not initialised, for example because it was set in the world as Hopper{}. It is replaced
+		// by an initialised hopper, which transfers items from the next tick on.
+		w.SetBlock(pos, h.initialised())
+		return
+	}
+	if h.cooldown.Load() > 0 && h.cooldown.Dec() > 0 {
+		return
+	}
+	if h.Locked {
+		return
+	}
+	pushed := h.push(pos, w)
+	if pulled := h.pull(pos, w); pushed || pulled {
+		h.cooldown.Store(hopperCooldown)
+	}
+}
+
+// push pushes a single item from the hopper into the container that the hopper faces. True is returned if an
+// item was moved.
+func (h Hopper) push(pos cube.Pos, w *world.World) bool {
+	c, ok := w.Block(pos.Side(h.Facing)).(Container)
+	if !ok {
+		return false
+	}
+	dst := c.Inventory()
+	if dst == nil || h.inventory == nil {
+		// Either container was not initialised, so no items can be moved between them.
+		return false
+	}
+	wasEmpty := dst.Empty()
+	for slot, it := range h.inventory.Slots() {
+		if it.Empty() {
+			continue
+		}
+		if moveItem(h.inventory, slot, dst, insertSlots(c, h.Facing.Opposite(), it)) {
+			if other, ok := c.(Hopper); ok && wasEmpty && other.cooldown != nil {
+				// Items pushed into an empty hopper wait a full cooldown before moving on, so that they don't
+				// travel through an entire chain of hoppers within a single tick.
+				other.cooldown.Store(hopperCooldown)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// pull pulls a single item from the container above the hopper into the hopper. If there is no container above
+// the hopper, items are absorbed from an item entity in the hopper instead. True is returned if any items were
+// moved.
+func (h Hopper) pull(pos cube.Pos, w *world.World) bool {
+	if h.inventory == nil {
+		return false
+	}
+	if c, ok := w.Block(pos.Side(cube.FaceUp)).(Container); ok {
+		src := c.Inventory()
+		if src == nil {
+			return false
+		}
+		for _, slot := range extractSlots(c, cube.FaceDown) {
+			if it, _ := src.Item(slot); !it.Empty() && moveItem(src, slot, h.inventory, nil) {
+				return true
+			}
+		}
+		return false
+	}
+	area := physics.NewAABB(mgl64.Vec3{0, 0.625, 0}, mgl64.Vec3{1, 2, 1}).Translate(pos.Vec3())
+	for _, e := range w.EntitiesWithin(area, nil) {
+		if it, ok := e.(*entity.Item); ok && h.absorb(it, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// absorb absorbs as many items from the item entity passed as fit in a single slot of the hopper. True is
+// returned if any items were absorbed.
+func (h Hopper) absorb(it *entity.Item, w *world.World) bool {
+	if h.inventory == nil {
+		return false
+	}
+	s := it.Item()
+	slot, ok := insertSlot(h.inventory, nil, s)
+	if !ok {
+		return false
+	}
+	existing, _ := h.inventory.Item(slot)
+	n := s.Count()
+	if !existing.Empty() && existing.Count()+n > existing.MaxCount() {
+		n = existing.MaxCount() - existing.Count()
+	}
+	ctx := event.C()
+	h.inventory.Handler().HandlePlace(ctx, slot, s.Grow(n-s.Count()))
+	if ctx.Cancelled() {
+		return false
+	}
+	absorbed := it.Absorb(n)
+	if absorbed.Empty() {
+		return false
+	}
+	if insertItem(h.inventory, slot, absorbed) {
+		return true
+	}
+	// The slot was changed since it was selected, so the items are added to any slot that still has space
+	// instead. Whatever doesn't fit is dropped back into the world.
+	added, _ := h.inventory.AddItem(absorbed)
+	if added < absorbed.Count() {
+		w.AddEntity(entity.NewItem(absorbed.Grow(-added), it.Position()))
+	}
+	return added > 0
+}
+
+// moveItem moves a single item from a slot in the inventory src to one of the slots passed in the inventory
+// dst. If slots is nil, the item may be moved to any slot. The handlers of both inventories are called, and
+// either may cancel the move. True is returned if the item was moved.
+func moveItem(src *inventory.Inventory, srcSlot int, dst *inventory.Inventory, slots []int) bool {
+	it, _ := src.Item(srcSlot)
+	if it.Empty() {
+		return false
+	}
+	single := it.Grow(1 - it.Count())
+	dstSlot, ok := insertSlot(dst, slots, single)
+	if !ok {
+		return false
+	}
+	ctx := event.C()
+	src.Handler().HandleTake(ctx, srcSlot, single)
+	if !ctx.Cancelled() {
+		dst.Handler().HandlePlace(ctx, dstSlot, single)
+	}
+	if ctx.Cancelled() {
+		return false
+	}
+	// Both slots may have been changed by another goroutine since they were read, so they are only updated if
+	// the item can still be taken out of the one and inserted into the other.
+	if !takeItem(src, srcSlot, single) {
+		return false
+	}
+	if !insertItem(dst, dstSlot, single) {
+		if !insertItem(src, srcSlot, single) {
+			_, _ = src.AddItem(single)
+		}
+		return false
+	}
+	return true
+}
+
+// takeItem atomically removes the stack passed from a slot of the inventory passed. False is returned if the
+// slot does not hold at least that many items of the same type.
+func takeItem(inv *inventory.Inventory, slot int, s item.Stack) bool {
+	ok, _ := inv.UpdateItem(slot, func(existing item.Stack) (item.Stack, bool) {
+		if !existing.Comparable(s) || existing.Count() < s.Count() {
+			return existing, false
+		}
+		return existing.Grow(-s.Count()), true
+	})
+	return ok
+}
+
+// insertItem atomically adds the stack passed to a slot of the inventory passed. False is returned if the slot
+// holds a different item or does not have space for the full stack.
+func insertItem(inv *inventory.Inventory, slot int, s item.Stack) bool {
+	ok, _ := inv.UpdateItem(slot, func(existing item.Stack) (item.Stack, bool) {
+		if existing.Empty() {
+			return s, true
+		}
+		if !existing.Comparable(s) || existing.Count()+s.Count() > existing.MaxCount() {
+			return existing, false
+		}
+		return existing.Grow(s.Count()), true
+	})
+	return ok
+}
+
+// insertSlot finds a slot out of the slots passed in the inventory that at least one item of the stack passed
+// may be inserted in. Slots holding the same item are preferred over empty slots. If slots is nil, all slots of
+// the inventory are considered.
+func insertSlot(inv *inventory.Inventory, slots []int, s item.Stack) (int, bool) {
+	if slots == nil {
+		slots = make([]int, inv.Size())
+		for i := range slots {
+			slots[i] = i
+		}
+	}
+	empty, emptyFound := 0, false
+	for _, slot := range slots {
+		existing, err := inv.Item(slot)
+		if err != nil {
+			continue
+		}
+		if existing.Empty() {
+			if !emptyFound {
+				empty, emptyFound = slot, true
+			}
+			continue
+		}
+		if existing.Comparable(s) && existing.Count() < existing.MaxCount() {
+			return slot, true
+		}
+	}
+	return empty, emptyFound
+}
+
+// insertSlots returns the slots of the container passed that the item passed may be inserted into through the
+// face passed. For containers other than SidedContainers, nil is returned, meaning that any slot may be used.
+func insertSlots(c Container, face cube.Face, it item.Stack) []int {
+	if sided, ok := c.(SidedContainer); ok {
+		if slots := sided.InsertSlots(face, it); slots != nil {
+			return slots
+		}
+		return []int{}
+	}
+	return nil
+}
+
+// extractSlots returns the slots of the container passed that items may be extracted from through the face
+// passed. For containers other than SidedContainers, all slots are returned.
+func extractSlots(c Container, face cube.Face) []int {
+	if sided, ok := c.(SidedContainer); ok {
+		return sided.ExtractSlots(face)
+	}
+	slots := make([]int, c.Inventory().Size())
+	for i := range slots {
+		slots[i] = i
+	}
+	return slots
+}
+
+// AddViewer adds a viewer to the hopper, so that it is updated whenever the inventory of the hopper is changed.
+func (h Hopper) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	h.viewerMu.Lock()
+	defer h.viewerMu.Unlock()
+	h.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the hopper, so that slot updates in the inventory are no longer sent to
+// it.
+func (h Hopper) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	h.viewerMu.Lock()
+	defer h.viewerMu.Unlock()
+	delete(h.viewers, v)
+}
+
+// Activate ...
+func (h Hopper) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		if h.inventory == nil {
+			// The hopper was not initialised, so it is replaced by an initialised hopper before it is opened.
+			w.SetBlock(pos, h.initialised())
+		}
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (h Hopper) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, face, used = firstReplaceable(w, pos, face, h)
+	if !used {
+		return
+	}
+	inv, customName := h.inventory, h.CustomName
+	//noinspection GoAssignmentToReceiver
+	h = NewHopper()
+	copyContents(h.inventory, inv)
+	h.CustomName = customName
+	if face != cube.FaceDown {
+		// The hopper faces the block that was clicked, unless it was placed against the bottom of a block.
+		h.Facing = face.Opposite()
+	}
+
+	place(w, pos, h, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (h Hopper) BreakInfo() BreakInfo {
+	var drops []item.Stack
+	if h.inventory != nil {
+		drops = h.inventory.Items()
+	}
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, simpleDrops(append(drops, item.NewStack(Hopper{CustomName: h.CustomName}, 1))...))
+}
+
+// PickWithData returns the hopper as an item including its contents.
+func (h Hopper) PickWithData() item.Stack {
+	pick := NewHopper()
+	pick.CustomName = h.CustomName
+	copyContents(pick.inventory, h.inventory)
+	return item.NewStack(pick, 1).WithLore("(+DATA)")
+}
+
+// DecodeNBT ...
+func (h Hopper) DecodeNBT(data map[string]interface{}) interface{} {
+	facing, locked := h.Facing, h.Locked
+	//noinspection GoAssignmentToReceiver
+	h = NewHopper()
+	h.Facing, h.Locked = facing, locked
+	h.CustomName = nbtconv.MapString(data, "CustomName")
+	h.cooldown.Store(int64(nbtconv.MapInt32(data, "TransferCooldown")))
+	nbtconv.InvFromNBT(h.inventory, nbtconv.MapSlice(data, "Items"))
+	return h
+}
+
+// EncodeNBT ...
+func (h Hopper) EncodeNBT() map[string]interface{} {
+	//noinspection GoAssignmentToReceiver
+	h = h.initialised()
+	m := map[string]interface{}{
+		"Items":            nbtconv.InvToNBT(h.inventory),
+		"TransferCooldown": int32(h.cooldown.Load()),
+		"id":               "Hopper",
+	}
+	if h.CustomName != "" {
+		m["CustomName"] = h.CustomName
+	}
+	return m
+}
+
+// EncodeBlock ...
+func (h Hopper) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:hopper", map[string]interface{}{"facing_direction": int32(h.Facing), "toggle_bit": boolByte(h.Locked)}
+}
+
+// EncodeItem ...
+func (h Hopper) EncodeItem() (name string, meta int16) {
+	return "minecraft:hopper", 0
+}
+
+// allHoppers returns all possible states of a hopper.
+func allHoppers() (hoppers []world.Block) {
+	for _, f := range cube.Faces() {
+		if f == cube.FaceUp {
+			continue
+		}
+		hoppers = append(hoppers, Hopper{Facing: f}, Hopper{Facing: f, Locked: true})
+	}
+	return
+}
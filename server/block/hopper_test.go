@@ -0,0 +1,113 @@
+package block_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// TestHopperLoop checks that items moving around a loop of four hoppers are never duplicated or lost, while the
+// items in the hoppers are also moved around concurrently, like players taking items out of them and putting
+// them back.
+func TestHopperLoop(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	// Each hopper faces the next one, so that the four hoppers form a loop.
+	positions := []cube.Pos{{0, 5, 0}, {1, 5, 0}, {1, 5, 1}, {0, 5, 1}}
+	facing := []cube.Face{cube.FaceEast, cube.FaceSouth, cube.FaceWest, cube.FaceNorth}
+	hoppers := make([]block.Hopper, len(positions))
+	for i, pos := range positions {
+		h := block.NewHopper()
+		h.Facing = facing[i]
+		_ = h.Inventory().SetItem(i, item.NewStack(block.Cobblestone{}, 16))
+		_ = h.Inventory().SetItem(4, item.NewStack(item.Diamond{}, 8))
+		w.SetBlock(pos, h)
+		hoppers[i] = h
+	}
+	count := func() (cobblestone, diamonds int) {
+		for _, h := range hoppers {
+			for _, it := range h.Inventory().Items() {
+				switch it.Item().(type) {
+				case block.Cobblestone:
+					cobblestone += it.Count()
+				case item.Diamond:
+					diamonds += it.Count()
+				}
+			}
+		}
+		return
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r := rand.New(rand.NewSource(1))
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			// Take a single item out of a random slot of a random hopper and put it into another random hopper.
+			src, slot := hoppers[r.Intn(len(hoppers))].Inventory(), r.Intn(5)
+			var taken item.Stack
+			_, _ = src.UpdateItem(slot, func(it item.Stack) (item.Stack, bool) {
+				if it.Empty() {
+					return it, false
+				}
+				taken = it.Grow(1 - it.Count())
+				return it.Grow(-1), true
+			})
+			if taken.Empty() {
+				continue
+			}
+			if _, err := hoppers[r.Intn(len(hoppers))].Inventory().AddItem(taken); err != nil {
+				_, _ = src.AddItem(taken)
+			}
+		}
+	}()
+	w.Advance(2000)
+	close(done)
+	wg.Wait()
+
+	if cobblestone, diamonds := count(); cobblestone != 64 || diamonds != 32 {
+		t.Errorf("hoppers hold %v cobblestone and %v diamonds, want 64 and 32", cobblestone, diamonds)
+	}
+}
+
+// TestHopperZeroValue checks that a hopper set in the world without being created using NewHopper, as done by
+// commands such as /setblock, is ticked without panicking and transfers items like any other hopper: It pulls
+// items out of the chest above it and pushes them into another zero-value hopper below it.
+func TestHopperZeroValue(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	top, bottom := cube.Pos{0, 6, 0}, cube.Pos{0, 5, 0}
+	chest := block.NewChest()
+	_ = chest.Inventory().SetItem(0, item.NewStack(item.Diamond{}, 2))
+	w.SetBlock(top.Side(cube.FaceUp), chest)
+	w.SetBlock(top, block.Hopper{Facing: cube.FaceDown})
+	w.SetBlock(bottom, block.Hopper{Facing: cube.FaceDown})
+	w.Advance(40)
+
+	var diamonds int
+	for _, pos := range []cube.Pos{top, bottom} {
+		h := w.Block(pos).(block.Hopper)
+		if h.Inventory() == nil {
+			t.Fatalf("hopper at %v was not initialised after being ticked", pos)
+		}
+		for _, it := range h.Inventory().Items() {
+			diamonds += it.Count()
+		}
+	}
+	if diamonds != 2 {
+		t.Errorf("hoppers hold %v diamonds after pulling them out of the chest, want 2", diamonds)
+	}
+}
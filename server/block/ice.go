@@ -0,0 +1,28 @@
+package block
+
+// Ice is a solid, transparent block that entities slide on.
+// TODO: Melt near bright light sources and turn into water when broken without silk touch.
+type Ice struct {
+	solid
+	transparent
+}
+
+// BreakInfo ...
+func (i Ice) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, silkTouchOnlyDrop(i))
+}
+
+// Friction ...
+func (Ice) Friction() float64 {
+	return 0.98
+}
+
+// EncodeItem ...
+func (Ice) EncodeItem() (name string, meta int16) {
+	return "minecraft:ice", 0
+}
+
+// EncodeBlock ...
+func (Ice) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:ice", nil
+}
@@ -12,6 +12,21 @@ func (i instrument) Int32() int32 {
 	return int32(i)
 }
 
+// SoundName returns the name of the sound played by note blocks with this instrument, such as 'note.harp'.
+func (i instrument) SoundName() string {
+	if i < 0 || int(i) >= len(soundNames) {
+		return soundNames[0]
+	}
+	return soundNames[i]
+}
+
+// soundNames holds the sound names of all instruments, indexed by their ID.
+var soundNames = [...]string{
+	"note.harp", "note.bd", "note.snare", "note.hat", "note.bass", "note.bell", "note.flute", "note.chime",
+	"note.guitar", "note.xylophone", "note.iron_xylophone", "note.cow_bell", "note.didgeridoo", "note.bit",
+	"note.banjo", "note.pling",
+}
+
 // Piano is an instrument type for the note block.
 func Piano() Instrument {
 	return Instrument{0}
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// Jukebox is a block used to play music discs. A music disc is inserted by using it on the jukebox and ejected by
+// using the jukebox again.
+type Jukebox struct {
+	solid
+	bass
+
+	// Item is the music disc played by the jukebox. If empty, the jukebox is not playing a disc.
+	Item item.Stack
+}
+
+// BreakInfo ...
+func (j Jukebox) BreakInfo() BreakInfo {
+	drops := []item.Stack{item.NewStack(Jukebox{}, 1)}
+	if !j.Item.Empty() {
+		drops = append(drops, j.Item)
+	}
+	return newBreakInfo(2, alwaysHarvestable, axeEffective, simpleDrops(drops...))
+}
+
+// FlammabilityInfo ...
+func (j Jukebox) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// Activate inserts the music disc held by the user into the jukebox, or ejects the disc currently in the jukebox.
+func (j Jukebox) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) bool {
+	if !j.Item.Empty() {
+		w.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+
+		it := entity.NewItem(j.Item, pos.Vec3Middle().Add(mgl64.Vec3{0, 1}))
+		it.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.1, rand.Float64()*0.2 - 0.1})
+		w.AddEntity(it)
+
+		w.SetBlock(pos, Jukebox{})
+		return true
+	}
+	held, other := u.HeldItems()
+	disc, ok := held.Item().(item.MusicDisc)
+	if !ok {
+		return false
+	}
+	j.Item = held.Grow(1 - held.Count())
+	w.SetBlock(pos, j)
+	w.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: disc.DiscType})
+
+	if g, ok := u.(interface {
+		GameMode() world.GameMode
+	}); !ok || !g.GameMode().CreativeInventory() {
+		u.SetHeldItems(held.Grow(-1), other)
+	}
+	return true
+}
+
+// Tick shows note particles above the jukebox every second while it is playing a music disc.
+func (j Jukebox) Tick(currentTick int64, pos cube.Pos, w *world.World) {
+	if currentTick%20 == 0 && !j.Item.Empty() {
+		w.AddParticle(pos.Vec3Middle().Add(mgl64.Vec3{0, 1.2}), particle.JukeboxNote{})
+	}
+}
+
+// Removed stops the music disc that was playing when the jukebox is broken or replaced. The track is only
+// stopped: The disc is dropped by breaking the jukebox, but is lost if the jukebox is replaced otherwise.
+func (j Jukebox) Removed(pos cube.Pos, w *world.World) {
+	if !j.Item.Empty() {
+		w.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+	}
+}
+
+// EncodeItem ...
+func (Jukebox) EncodeItem() (name string, meta int16) {
+	return "minecraft:jukebox", 0
+}
+
+// EncodeBlock ...
+func (Jukebox) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:jukebox", nil
+}
+
+// DecodeNBT ...
+func (j Jukebox) DecodeNBT(data map[string]interface{}) interface{} {
+	j.Item = nbtconv.MapItem(data, "RecordItem")
+	return j
+}
+
+// EncodeNBT ...
+func (j Jukebox) EncodeNBT() map[string]interface{} {
+	m := map[string]interface{}{"id": "Jukebox"}
+	if !j.Item.Empty() {
+		m["RecordItem"] = nbtconv.WriteItem(j.Item, true)
+	}
+	return m
+}
@@ -52,11 +52,9 @@ func (l Ladder) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 	return placed(ctx)
 }
 
-// EntityInside ...
-func (l Ladder) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
-	if fallEntity, ok := e.(fallDistanceEntity); ok {
-		fallEntity.ResetFallDistance()
-	}
+// ClimbSpeed ...
+func (l Ladder) ClimbSpeed() float64 {
+	return 0.2
 }
 
 // CanDisplace ...
@@ -43,10 +43,16 @@ func (l Lava) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
 		fallEntity.ResetFallDistance()
 	}
 	if flammable, ok := e.(entity.Flammable); ok {
+		// Lava deals damage every half a second: Hurt makes the entity immune to further attacks for that
+		// duration, after which the next tick inside of the lava will hurt the entity again.
 		if l, ok := e.(entity.Living); ok && !l.AttackImmune() {
 			l.Hurt(4, damage.SourceLava{})
 		}
-		flammable.SetOnFire(15 * time.Second)
+		// The burning duration is refreshed for as long as the entity stays in the lava, unless it is fire
+		// proof, such as when it has the fire resistance effect.
+		if !flammable.FireProof() && flammable.OnFireDuration() < time.Second*15 {
+			flammable.SetOnFire(15 * time.Second)
+		}
 	}
 }
 
@@ -0,0 +1,75 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// LightningRod is a block made of copper that attracts lightning striking within 64 blocks of it, protecting the
+// blocks around it from catching fire.
+type LightningRod struct {
+	transparent
+
+	// Facing is the face that the tip of the lightning rod faces.
+	Facing cube.Face
+}
+
+// AttractsLightning returns true: Lightning striking within 64 blocks of a lightning rod exposed to the sky strikes
+// the lightning rod instead.
+func (LightningRod) AttractsLightning() bool {
+	return true
+}
+
+// CanDisplace ...
+func (LightningRod) CanDisplace(b world.Liquid) bool {
+	_, water := b.(Water)
+	return water
+}
+
+// SideClosed ...
+func (LightningRod) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// UseOnBlock ...
+func (l LightningRod) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, face, used = firstReplaceable(w, pos, face, l)
+	if !used {
+		return
+	}
+	l.Facing = face
+
+	place(w, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// BreakInfo ...
+func (l LightningRod) BreakInfo() BreakInfo {
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(LightningRod{}))
+}
+
+// EncodeItem ...
+func (LightningRod) EncodeItem() (name string, meta int16) {
+	return "minecraft:lightning_rod", 0
+}
+
+// EncodeBlock ...
+func (l LightningRod) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:lightning_rod", map[string]interface{}{"facing_direction": int32(l.Facing)}
+}
+
+// Model ...
+func (l LightningRod) Model() world.BlockModel {
+	return model.LightningRod{Axis: l.Facing.Axis()}
+}
+
+// allLightningRods ...
+func allLightningRods() (rods []world.Block) {
+	for _, f := range cube.Faces() {
+		rods = append(rods, LightningRod{Facing: f})
+	}
+	return
+}
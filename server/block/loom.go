@@ -0,0 +1,74 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Loom is a block used to apply patterns on banners. It is also used as a shepherd's job site block.
+type Loom struct {
+	solid
+	bass
+
+	// Facing is the direction the Loom is facing.
+	Facing cube.Direction
+}
+
+// FlammabilityInfo ...
+func (Loom) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// BreakInfo ...
+func (l Loom) BreakInfo() BreakInfo {
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, oneOf(Loom{}))
+}
+
+// Activate opens the loom UI for the user.
+func (Loom) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (l Loom) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, l)
+	if !used {
+		return false
+	}
+	l.Facing = user.Facing().Opposite()
+	place(w, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// EncodeItem ...
+func (Loom) EncodeItem() (name string, meta int16) {
+	return "minecraft:loom", 0
+}
+
+// EncodeBlock ...
+func (l Loom) EncodeBlock() (name string, properties map[string]interface{}) {
+	direction := 2
+	switch l.Facing {
+	case cube.South:
+		direction = 0
+	case cube.West:
+		direction = 1
+	case cube.East:
+		direction = 3
+	}
+	return "minecraft:loom", map[string]interface{}{"direction": int32(direction)}
+}
+
+// allLooms returns all possible states of a loom.
+func allLooms() (looms []world.Block) {
+	for i := cube.Direction(0); i <= 3; i++ {
+		looms = append(looms, Loom{Facing: i})
+	}
+	return
+}
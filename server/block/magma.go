@@ -0,0 +1,50 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Magma is a light-emitting block found in the Nether. It damages entities standing on it, unless they are
+// sneaking or wearing boots enchanted with frost walker.
+type Magma struct {
+	solid
+}
+
+// LightEmissionLevel ...
+func (Magma) LightEmissionLevel() uint8 {
+	return 3
+}
+
+// BreakInfo ...
+func (m Magma) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, pickaxeHarvestable, pickaxeEffective, oneOf(m))
+}
+
+// SteppedOn ...
+func (Magma) SteppedOn(_ cube.Pos, _ *world.World, e world.Entity) {
+	l, ok := e.(entity.Living)
+	if !ok || l.AttackImmune() || sneaking(e) {
+		return
+	}
+	if a, ok := e.(interface{ Armour() *inventory.Armour }); ok {
+		if _, ok := a.Armour().Boots().Enchantment(enchantment.FrostWalker{}); ok {
+			return
+		}
+	}
+	l.Hurt(1, damage.SourceHotFloor{})
+}
+
+// EncodeItem ...
+func (Magma) EncodeItem() (name string, meta int16) {
+	return "minecraft:magma", 0
+}
+
+// EncodeBlock ...
+func (Magma) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:magma", nil
+}
@@ -63,10 +63,11 @@ func (m MelonSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 
 // BoneMeal ...
 func (m MelonSeeds) BoneMeal(pos cube.Pos, w *world.World) bool {
-	if m.Growth == 7 {
+	growth, ok := m.boneMealGrowth()
+	if !ok {
 		return false
 	}
-	m.Growth = min(m.Growth+rand.Intn(4)+2, 7)
+	m.Growth = growth
 	w.PlaceBlock(pos, m)
 	return true
 }
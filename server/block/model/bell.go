@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Bell is a model used by bells. Its shape depends on the way the bell is attached.
+type Bell struct {
+	// Attach is the attachment of the bell. It is either "standing", "hanging", "side" or "multiple".
+	Attach string
+	// Facing is the direction the bell is facing.
+	Facing cube.Direction
+}
+
+// AABB ...
+func (b Bell) AABB(cube.Pos, *world.World) []physics.AABB {
+	switch b.Attach {
+	case "standing":
+		return []physics.AABB{physics.NewAABB(mgl64.Vec3{}, mgl64.Vec3{1, 1, 1}).Stretch(b.Facing.Face().Axis(), -0.25)}
+	case "hanging":
+		return []physics.AABB{physics.NewAABB(mgl64.Vec3{0.25, 0.25, 0.25}, mgl64.Vec3{0.75, 1, 0.75})}
+	}
+	// The bell hangs from a beam attached to the wall behind it, or to the walls on both sides of it.
+	box := physics.NewAABB(mgl64.Vec3{0.25, 0.25, 0.25}, mgl64.Vec3{0.75, 0.9375, 0.75}).ExtendTowards(b.Facing.Opposite().Face(), 0.25)
+	if b.Attach == "multiple" {
+		box = box.ExtendTowards(b.Facing.Face(), 0.25)
+	}
+	return []physics.AABB{box}
+}
+
+// FaceSolid always returns false.
+func (Bell) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Cauldron is a model used by cauldrons. It has a floor and four walls surrounding the space that holds the
+// liquid of the cauldron.
+type Cauldron struct{}
+
+// AABB ...
+func (Cauldron) AABB(cube.Pos, *world.World) []physics.AABB {
+	const floor, wall = 0.25, 0.125
+	return []physics.AABB{
+		physics.NewAABB(mgl64.Vec3{}, mgl64.Vec3{1, floor, 1}),
+		physics.NewAABB(mgl64.Vec3{0, floor, 0}, mgl64.Vec3{wall, 1, 1}),
+		physics.NewAABB(mgl64.Vec3{1 - wall, floor, 0}, mgl64.Vec3{1, 1, 1}),
+		physics.NewAABB(mgl64.Vec3{0, floor, 0}, mgl64.Vec3{1, 1, wall}),
+		physics.NewAABB(mgl64.Vec3{0, floor, 1 - wall}, mgl64.Vec3{1, 1, 1}),
+	}
+}
+
+// FaceSolid ...
+func (Cauldron) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
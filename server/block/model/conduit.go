@@ -0,0 +1,21 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Conduit is a model used by conduits: A small box in the centre of the block.
+type Conduit struct{}
+
+// AABB returns a physics.AABB of 6x6x6 pixels in the centre of the block.
+func (Conduit) AABB(cube.Pos, *world.World) []physics.AABB {
+	return []physics.AABB{physics.NewAABB(mgl64.Vec3{0.3125, 0.3125, 0.3125}, mgl64.Vec3{0.6875, 0.6875, 0.6875})}
+}
+
+// FaceSolid always returns false.
+func (Conduit) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Grindstone is a model used by grindstones. Regardless of its attachment, it occupies a block shrunk by 2/16 on
+// both horizontal sides along the axis of its wheel.
+type Grindstone struct {
+	// Axis is the axis the wheel of the Grindstone turns around.
+	Axis cube.Axis
+}
+
+// AABB ...
+func (g Grindstone) AABB(cube.Pos, *world.World) []physics.AABB {
+	return []physics.AABB{physics.NewAABB(mgl64.Vec3{}, mgl64.Vec3{1, 1, 1}).Stretch(g.Axis, -0.125)}
+}
+
+// FaceSolid always returns false.
+func (g Grindstone) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
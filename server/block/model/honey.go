@@ -0,0 +1,22 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Honey is a model used by honey blocks: A block that is one pixel smaller than a full block on all sides but the
+// bottom, so that entities sink into it slightly.
+type Honey struct{}
+
+// AABB returns a physics.AABB that is one pixel smaller than a full block on all sides but the bottom.
+func (Honey) AABB(cube.Pos, *world.World) []physics.AABB {
+	return []physics.AABB{physics.NewAABB(mgl64.Vec3{0.0625, 0, 0.0625}, mgl64.Vec3{0.9375, 0.9375, 0.9375})}
+}
+
+// FaceSolid always returns true.
+func (Honey) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return true
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Hopper is a model used by hoppers. It has a bowl at the top, in which items may land, and a spout below it.
+type Hopper struct{}
+
+// AABB ...
+func (Hopper) AABB(cube.Pos, *world.World) []physics.AABB {
+	const floor, wall = 0.625, 0.125
+	return []physics.AABB{
+		physics.NewAABB(mgl64.Vec3{0, floor, 0}, mgl64.Vec3{1, floor + 0.0625, 1}),
+		physics.NewAABB(mgl64.Vec3{0, floor, 0}, mgl64.Vec3{wall, 1, 1}),
+		physics.NewAABB(mgl64.Vec3{1 - wall, floor, 0}, mgl64.Vec3{1, 1, 1}),
+		physics.NewAABB(mgl64.Vec3{0, floor, 0}, mgl64.Vec3{1, 1, wall}),
+		physics.NewAABB(mgl64.Vec3{0, floor, 1 - wall}, mgl64.Vec3{1, 1, 1}),
+		physics.NewAABB(mgl64.Vec3{0.25, 0.25, 0.25}, mgl64.Vec3{0.75, floor, 0.75}),
+	}
+}
+
+// FaceSolid ...
+func (Hopper) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// LightningRod is a model used by lightning rods.
+type LightningRod struct {
+	// Axis is the axis along which the lightning rod is placed.
+	Axis cube.Axis
+}
+
+// AABB ...
+func (l LightningRod) AABB(cube.Pos, *world.World) []physics.AABB {
+	return []physics.AABB{physics.NewAABB(mgl64.Vec3{0.375, 0.375, 0.375}, mgl64.Vec3{0.625, 0.625, 0.625}).Stretch(l.Axis, 0.375)}
+}
+
+// FaceSolid ...
+func (LightningRod) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Scaffolding is the model of a scaffolding block. Entities only collide with its top plate, and only if they are
+// standing on top of it without sneaking. Entities that are inside the block or that are sneaking pass through
+// it: Code computing the collision of entities should check Collides before using the AABB of the model.
+type Scaffolding struct{}
+
+// Collides checks if an entity with its feet at the Y position passed collides with the Scaffolding at the
+// position passed. Entities only collide with scaffolding if they are on top of it and are not sneaking.
+func (Scaffolding) Collides(pos cube.Pos, feetY float64, sneaking bool) bool {
+	return !sneaking && feetY >= float64(pos[1])+0.875
+}
+
+// AABB returns the top plate of the scaffolding, which is 0.125 blocks thick.
+func (Scaffolding) AABB(cube.Pos, *world.World) []physics.AABB {
+	return []physics.AABB{physics.NewAABB(mgl64.Vec3{0, 0.875, 0}, mgl64.Vec3{1, 1, 1})}
+}
+
+// FaceSolid always returns false.
+func (Scaffolding) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
@@ -22,6 +22,11 @@ func (NetheriteBlock) PowersBeacon() bool {
 	return true
 }
 
+// FireProof ...
+func (NetheriteBlock) FireProof() bool {
+	return true
+}
+
 // EncodeItem ...
 func (NetheriteBlock) EncodeItem() (name string, meta int16) {
 	return "minecraft:netherite_block", 0
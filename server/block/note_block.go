@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
+	"math"
 )
 
 // NoteBlock is a musical block that emits sounds when powered with redstone.
@@ -19,10 +20,17 @@ type NoteBlock struct {
 	Pitch int
 }
 
-// playNote ...
+// playNote plays the note of the note block using the instrument selected by the block beneath it.
 func (n NoteBlock) playNote(pos cube.Pos, w *world.World) {
-	w.PlaySound(pos.Vec3(), sound.Note{Instrument: n.instrument(pos, w), Pitch: n.Pitch})
-	w.AddParticle(pos.Vec3(), particle.Note{Instrument: n.Instrument(), Pitch: n.Pitch})
+	i := n.instrument(pos, w)
+	w.PlaySound(pos.Vec3Centre(), sound.Custom{Name: i.SoundName(), Volume: 3, Pitch: notePitch(n.Pitch)})
+	w.AddParticle(pos.Vec3(), particle.Note{Instrument: i, Pitch: n.Pitch})
+}
+
+// notePitch converts a note block pitch in the range 0-24 to the pitch of the sound played, ranging from 0.5 to
+// 2.0, where a pitch of 12 plays the sound at its original pitch.
+func notePitch(pitch int) float64 {
+	return math.Pow(2, float64(pitch-12)/12)
 }
 
 // updateInstrument ...
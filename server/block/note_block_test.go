@@ -0,0 +1,48 @@
+package block_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"testing"
+)
+
+// TestNoteBlock checks that activating a note block raises its pitch and plays its note with the instrument of
+// the block beneath it, at a pitch derived from the note.
+func TestNoteBlock(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Focus(mgl64.Vec3{})
+
+	pos := cube.Pos{0, 10, 0}
+	w.SetBlock(pos.Side(cube.FaceDown), block.Glowstone{})
+	w.SetBlock(pos, block.NoteBlock{Pitch: 11})
+	w.Viewer().Reset()
+
+	if !w.Block(pos).(block.NoteBlock).Activate(pos, cube.FaceUp, w.World, nil) {
+		t.Fatalf("note block was not activated")
+	}
+	if n := w.Block(pos).(block.NoteBlock); n.Pitch != 12 {
+		t.Errorf("pitch = %v, want 12", n.Pitch)
+	}
+	calls := w.Viewer().CallsTo("ViewSound")
+	if len(calls) != 1 {
+		t.Fatalf("%v sounds played, want 1", len(calls))
+	}
+	s, ok := calls[0].Args[1].(sound.Custom)
+	if !ok {
+		t.Fatalf("played sound %T, want sound.Custom", calls[0].Args[1])
+	}
+	if s.Name != "note.pling" || math.Abs(s.Pitch-1) > 1e-9 {
+		t.Errorf("played %v at pitch %v, want note.pling at pitch 1", s.Name, s.Pitch)
+	}
+
+	w.SetBlock(pos, block.NoteBlock{Pitch: 24})
+	w.Block(pos).(block.NoteBlock).Activate(pos, cube.FaceUp, w.World, nil)
+	if n := w.Block(pos).(block.NoteBlock); n.Pitch != 0 {
+		t.Errorf("pitch = %v after cycling past 24, want 0", n.Pitch)
+	}
+}
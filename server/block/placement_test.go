@@ -0,0 +1,165 @@
+package block_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// placementPos is the position of the block clicked to place blocks against in placement tests.
+var placementPos = cube.Pos{0, 5, 0}
+
+// clickPositions returns the click positions in the centre of each quadrant of the face passed, relative to the
+// block that the face belongs to.
+func clickPositions(face cube.Face) []mgl64.Vec3 {
+	axis := map[cube.Axis]int{cube.X: 0, cube.Y: 1, cube.Z: 2}[face.Axis()]
+	// The face is on the side of the block furthest along its axis if its neighbour is in the positive direction.
+	offset := cube.Pos{}.Side(face)
+	positive := offset[axis] > 0
+	var positions []mgl64.Vec3
+	for _, a := range []float64{0.25, 0.75} {
+		for _, b := range []float64{0.25, 0.75} {
+			var pos mgl64.Vec3
+			other := []float64{a, b}
+			for i := range pos {
+				switch {
+				case i == axis && positive:
+					pos[i] = 1
+				case i == axis:
+					pos[i] = 0
+				default:
+					pos[i], other = other[0], other[1:]
+				}
+			}
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// newPlacer creates a world with a block of stone at placementPos and a player that places blocks against it.
+func newPlacer(t *testing.T) (*servertest.World, *player.Player) {
+	t.Helper()
+	w := servertest.NewWorld()
+	w.SetBlock(placementPos, block.Stone{})
+	p := w.NewPlayer("placer", mgl64.Vec3{0.5, 5, 4.5})
+	p.SetGameMode(world.GameModeCreative)
+	return w, p
+}
+
+// placeAgainst clicks the face of the block at placementPos passed at the click position passed while holding the
+// block passed, and returns the block that was placed. The block is removed again afterwards.
+func placeAgainst(w *servertest.World, p *player.Player, b world.Block, face cube.Face, clickPos mgl64.Vec3) world.Block {
+	p.SetHeldItems(item.NewStack(b.(world.Item), 1), item.Stack{})
+	p.UseItemOnBlock(placementPos, face, clickPos)
+	placed := w.Block(placementPos.Side(face))
+	w.SetBlock(placementPos.Side(face), block.Air{})
+	return placed
+}
+
+// TestStairsPlacement checks that stairs are placed upside down when clicking the bottom face of a block or the
+// upper half of a side face, and that they face the direction that the player is facing.
+func TestStairsPlacement(t *testing.T) {
+	w, p := newPlacer(t)
+	defer w.Close()
+	defer p.Close()
+
+	for _, face := range cube.Faces() {
+		for _, clickPos := range clickPositions(face) {
+			// Turn the player around between placements, so that the stairs are placed facing every direction.
+			p.Move(mgl64.Vec3{}, 90, 0)
+			facing := p.Facing()
+
+			s, ok := placeAgainst(w, p, block.WoodStairs{Wood: block.OakWood()}, face, clickPos).(block.WoodStairs)
+			if !ok {
+				t.Errorf("face %v, click position %v: stairs not placed", face, clickPos)
+				continue
+			}
+			upsideDown := face == cube.FaceDown || (face != cube.FaceUp && clickPos[1] > 0.5)
+			if s.UpsideDown != upsideDown || s.Facing != facing {
+				t.Errorf("face %v, click position %v: stairs upside down: %v, facing %v, want %v and %v", face, clickPos, s.UpsideDown, s.Facing, upsideDown, facing)
+			}
+		}
+	}
+}
+
+// TestSlabPlacement checks that slabs are placed in the top half of their position when clicking the bottom face
+// of a block or the upper half of a side face, and that placing a slab on a matching half slab turns it into a
+// double slab.
+func TestSlabPlacement(t *testing.T) {
+	w, p := newPlacer(t)
+	defer w.Close()
+	defer p.Close()
+
+	slab := block.WoodSlab{Wood: block.OakWood()}
+	for _, face := range cube.Faces() {
+		for _, clickPos := range clickPositions(face) {
+			s, ok := placeAgainst(w, p, slab, face, clickPos).(block.WoodSlab)
+			if !ok {
+				t.Errorf("face %v, click position %v: slab not placed", face, clickPos)
+				continue
+			}
+			top := face == cube.FaceDown || (face != cube.FaceUp && clickPos[1] > 0.5)
+			if s.Top != top || s.Double {
+				t.Errorf("face %v, click position %v: slab top: %v, double: %v, want %v and false", face, clickPos, s.Top, s.Double, top)
+			}
+		}
+	}
+
+	// Clicking the top of a bottom slab or the bottom of a top slab fills it up, regardless of where on the face
+	// it is clicked.
+	for _, top := range []bool{false, true} {
+		face := cube.FaceUp
+		if top {
+			face = cube.FaceDown
+		}
+		for _, clickPos := range clickPositions(face) {
+			w.SetBlock(placementPos, block.WoodSlab{Wood: block.OakWood(), Top: top})
+			p.SetHeldItems(item.NewStack(slab, 1), item.Stack{})
+			p.UseItemOnBlock(placementPos, face, clickPos)
+			if s, ok := w.Block(placementPos).(block.WoodSlab); !ok || !s.Double {
+				t.Errorf("slab top: %v, click position %v: clicked slab became %#v, want double slab", top, clickPos, w.Block(placementPos))
+			}
+			if b := w.Block(placementPos.Side(face)); b != (block.Air{}) {
+				t.Errorf("slab top: %v, click position %v: %#v placed next to the clicked slab, want air", top, clickPos, b)
+				w.SetBlock(placementPos.Side(face), block.Air{})
+			}
+		}
+	}
+	// A slab of a different type of wood is placed next to the clicked slab instead.
+	w.SetBlock(placementPos, block.WoodSlab{Wood: block.BirchWood()})
+	p.SetHeldItems(item.NewStack(slab, 1), item.Stack{})
+	p.UseItemOnBlock(placementPos, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+	if s, ok := w.Block(placementPos).(block.WoodSlab); !ok || s.Double {
+		t.Errorf("birch slab became %#v after placing an oak slab on it, want half slab", w.Block(placementPos))
+	}
+	if s, ok := w.Block(placementPos.Side(cube.FaceUp)).(block.WoodSlab); !ok || s.Wood != block.OakWood() {
+		t.Errorf("%#v placed on the birch slab, want oak slab", w.Block(placementPos.Side(cube.FaceUp)))
+	}
+}
+
+// TestLogPlacement checks that logs are placed with their axis along the axis of the face clicked, regardless of
+// where on the face it is clicked.
+func TestLogPlacement(t *testing.T) {
+	w, p := newPlacer(t)
+	defer w.Close()
+	defer p.Close()
+
+	for _, face := range cube.Faces() {
+		for _, clickPos := range clickPositions(face) {
+			l, ok := placeAgainst(w, p, block.Log{Wood: block.OakWood()}, face, clickPos).(block.Log)
+			if !ok {
+				t.Errorf("face %v, click position %v: log not placed", face, clickPos)
+				continue
+			}
+			if l.Axis != face.Axis() {
+				t.Errorf("face %v, click position %v: log placed along axis %v, want %v", face, clickPos, l.Axis, face.Axis())
+			}
+		}
+	}
+}
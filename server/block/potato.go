@@ -39,10 +39,11 @@ func (p Potato) Consume(_ *world.World, c item.Consumer) item.Stack {
 
 // BoneMeal ...
 func (p Potato) BoneMeal(pos cube.Pos, w *world.World) bool {
-	if p.Growth == 7 {
+	growth, ok := p.boneMealGrowth()
+	if !ok {
 		return false
 	}
-	p.Growth = min(p.Growth+rand.Intn(4)+2, 7)
+	p.Growth = growth
 	w.PlaceBlock(pos, p)
 	return true
 }
@@ -18,6 +18,11 @@ func (p Prismarine) BreakInfo() BreakInfo {
 	return newBreakInfo(1.5, pickaxeHarvestable, pickaxeEffective, oneOf(p))
 }
 
+// FramesConduit ...
+func (Prismarine) FramesConduit() bool {
+	return true
+}
+
 // EncodeItem ...
 func (p Prismarine) EncodeItem() (id string, meta int16) {
 	return "minecraft:prismarine", int16(p.Type.Uint8())
@@ -63,10 +63,11 @@ func (p PumpkinSeeds) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 
 // BoneMeal ...
 func (p PumpkinSeeds) BoneMeal(pos cube.Pos, w *world.World) bool {
-	if p.Growth == 7 {
+	growth, ok := p.boneMealGrowth()
+	if !ok {
 		return false
 	}
-	p.Growth = min(p.Growth+rand.Intn(4)+2, 7)
+	p.Growth = growth
 	w.PlaceBlock(pos, p)
 	return true
 }
@@ -40,6 +40,7 @@ func init() {
 	world.RegisterBlock(IronBlock{})
 	world.RegisterBlock(CoalBlock{})
 	world.RegisterBlock(Beacon{})
+	world.RegisterBlock(Conduit{})
 	world.RegisterBlock(Sponge{})
 	world.RegisterBlock(Sponge{Wet: true})
 	world.RegisterBlock(LapisBlock{})
@@ -60,6 +61,10 @@ func init() {
 	world.RegisterBlock(Gravel{})
 	world.RegisterBlock(Bricks{})
 	world.RegisterBlock(SoulSand{})
+	world.RegisterBlock(Slime{})
+	world.RegisterBlock(Magma{})
+	world.RegisterBlock(Ice{})
+	world.RegisterBlock(Honey{})
 	world.RegisterBlock(Barrier{})
 	world.RegisterBlock(SeaLantern{})
 	world.RegisterBlock(SoulSoil{})
@@ -72,6 +77,7 @@ func init() {
 	world.RegisterBlock(NetherSprouts{})
 	world.RegisterBlock(Tuff{})
 	world.RegisterBlock(Calcite{})
+	world.RegisterBlock(Jukebox{})
 	for _, ore := range OreTypes() {
 		world.RegisterBlock(CoalOre{Type: ore})
 		world.RegisterBlock(IronOre{Type: ore})
@@ -95,6 +101,9 @@ func init() {
 	world.RegisterBlock(DeadBush{})
 	world.RegisterBlock(Snow{})
 	world.RegisterBlock(Bookshelf{})
+	world.RegisterBlock(SmithingTable{})
+	world.RegisterBlock(FletchingTable{})
+	world.RegisterBlock(CartographyTable{})
 
 	registerAll(allBarrels())
 	registerAll(allBasalt())
@@ -104,6 +113,7 @@ func init() {
 	registerAll(allCarpet())
 	registerAll(allCarrots())
 	registerAll(allChests())
+	registerAll(allEnderChests())
 	registerAll(allConcrete())
 	registerAll(allConcretePowder())
 	registerAll(allCocoaBeans())
@@ -118,6 +128,7 @@ func init() {
 	registerAll(allStainedGlassPane())
 	registerAll(allLanterns())
 	registerAll(allFire())
+	registerAll(allRespawnAnchors())
 	registerAll(allPlanks())
 	registerAll(allFence())
 	registerAll(allFenceGates())
@@ -150,10 +161,25 @@ func init() {
 	registerAll(allSigns())
 	registerAll(allLight())
 	registerAll(allLadders())
+	registerAll(allVines())
+	registerAll(allTwistingVines())
+	registerAll(allWeepingVines())
+	registerAll(allScaffolding())
 	registerAll(allSandstoneStairs())
 	registerAll(allSeaPickles())
 	registerAll(allWood())
 	registerAll(allChains())
+	registerAll(allLightningRods())
+	registerAll(allCauldrons())
+	registerAll(allGrindstones())
+	registerAll(allBeds())
+	registerAll(allBells())
+	registerAll(allDispensers())
+	registerAll(allDroppers())
+	registerAll(allHoppers())
+	registerAll(allFurnaces())
+	registerAll(allBanners())
+	registerAll(allLooms())
 }
 
 func init() {
@@ -174,6 +200,7 @@ func init() {
 	world.RegisterItem(Bedrock{})
 	world.RegisterItem(Kelp{})
 	world.RegisterItem(Chest{})
+	world.RegisterItem(EnderChest{})
 	world.RegisterItem(Cobblestone{Mossy: true})
 	world.RegisterItem(Obsidian{})
 	world.RegisterItem(Obsidian{Crying: true})
@@ -190,6 +217,7 @@ func init() {
 	world.RegisterItem(ItemFrame{Glowing: true})
 	world.RegisterItem(CoalBlock{})
 	world.RegisterItem(Beacon{})
+	world.RegisterItem(Conduit{})
 	world.RegisterItem(Sponge{})
 	world.RegisterItem(Sponge{Wet: true})
 	world.RegisterItem(LapisBlock{})
@@ -226,6 +254,10 @@ func init() {
 	world.RegisterItem(Gravel{})
 	world.RegisterItem(Bricks{})
 	world.RegisterItem(SoulSand{})
+	world.RegisterItem(Slime{})
+	world.RegisterItem(Magma{})
+	world.RegisterItem(Ice{})
+	world.RegisterItem(Honey{})
 	world.RegisterItem(Barrier{})
 	world.RegisterItem(Basalt{})
 	world.RegisterItem(Basalt{Polished: true})
@@ -253,6 +285,10 @@ func init() {
 	world.RegisterItem(HoneycombBlock{})
 	world.RegisterItem(Podzol{})
 	world.RegisterItem(Ladder{})
+	world.RegisterItem(Vines{})
+	world.RegisterItem(TwistingVines{})
+	world.RegisterItem(WeepingVines{})
+	world.RegisterItem(Scaffolding{})
 	world.RegisterItem(AmethystBlock{})
 	world.RegisterItem(PackedIce{})
 	world.RegisterItem(DeadBush{})
@@ -260,6 +296,20 @@ func init() {
 	world.RegisterItem(Snow{})
 	world.RegisterItem(Bookshelf{})
 	world.RegisterItem(Chain{})
+	world.RegisterItem(LightningRod{})
+	world.RegisterItem(Cauldron{})
+	world.RegisterItem(Grindstone{})
+	world.RegisterItem(Bell{})
+	world.RegisterItem(Dispenser{})
+	world.RegisterItem(Dropper{})
+	world.RegisterItem(Hopper{})
+	world.RegisterItem(Furnace{})
+	world.RegisterItem(Jukebox{})
+	world.RegisterItem(RespawnAnchor{})
+	world.RegisterItem(Loom{})
+	world.RegisterItem(SmithingTable{})
+	world.RegisterItem(FletchingTable{})
+	world.RegisterItem(CartographyTable{})
 
 	world.RegisterItem(item.Bucket{Content: Water{}})
 	world.RegisterItem(item.Bucket{Content: Lava{}})
@@ -285,9 +335,12 @@ func init() {
 		world.RegisterItem(StainedTerracotta{Colour: c})
 		world.RegisterItem(Carpet{Colour: c})
 		world.RegisterItem(Wool{Colour: c})
+		item.RegisterTag("minecraft:wool", Wool{Colour: c})
 		world.RegisterItem(StainedGlass{Colour: c})
 		world.RegisterItem(StainedGlassPane{Colour: c})
 		world.RegisterItem(GlazedTerracotta{Colour: c})
+		world.RegisterItem(Banner{Colour: c})
+		world.RegisterItem(Bed{Colour: c})
 	}
 	for _, w := range WoodTypes() {
 		world.RegisterItem(Log{Wood: w})
@@ -296,6 +349,7 @@ func init() {
 			world.RegisterItem(Leaves{Wood: w, Persistent: true})
 		}
 		world.RegisterItem(Planks{Wood: w})
+		item.RegisterTag("minecraft:planks", Planks{Wood: w})
 		world.RegisterItem(WoodStairs{Wood: w})
 		world.RegisterItem(WoodSlab{Wood: w})
 		world.RegisterItem(WoodSlab{Wood: w, Double: true})
@@ -306,6 +360,12 @@ func init() {
 		world.RegisterItem(Sign{Wood: w})
 		world.RegisterItem(Wood{Wood: w})
 		world.RegisterItem(Wood{Wood: w, Stripped: true})
+
+		logs := []world.Item{Log{Wood: w}, Log{Wood: w, Stripped: true}, Wood{Wood: w}, Wood{Wood: w, Stripped: true}}
+		item.RegisterTag("minecraft:logs", logs...)
+		if w.Flammable() {
+			item.RegisterTag("minecraft:logs_that_burn", logs...)
+		}
 	}
 	for _, ore := range OreTypes() {
 		world.RegisterItem(CoalOre{Type: ore})
@@ -0,0 +1,105 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// RespawnAnchor is a block that allows players to set their spawn point in the Nether. It is charged using
+// glowstone and uses up one charge every time a player respawns at it.
+type RespawnAnchor struct {
+	solid
+	bassDrum
+
+	// Charge is the amount of charges the respawn anchor holds. Value ranges from 0-4.
+	Charge int
+}
+
+// respawnAnchorExplosionRadius is the radius of the explosion caused by using a charged respawn anchor outside
+// of the Nether.
+const respawnAnchorExplosionRadius = 5
+
+// LightEmissionLevel ...
+func (r RespawnAnchor) LightEmissionLevel() uint8 {
+	if r.Charge == 0 {
+		return 0
+	}
+	return uint8(r.Charge*4 - 1)
+}
+
+// Activate ...
+func (r RespawnAnchor) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) bool {
+	held, other := u.HeldItems()
+	if _, ok := held.Item().(Glowstone); ok && r.Charge < 4 {
+		r.Charge++
+		w.SetBlock(pos, r)
+		w.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorCharge{})
+		if g, ok := u.(interface {
+			GameMode() world.GameMode
+		}); !ok || !g.GameMode().CreativeInventory() {
+			u.SetHeldItems(held.Grow(-1), other)
+		}
+		return true
+	}
+	if r.Charge == 0 {
+		// The respawn anchor cannot be used to set a spawn point if it isn't charged.
+		return false
+	}
+	if w.Dimension() != world.Nether {
+		r.explode(pos, w)
+		return true
+	}
+	if s, ok := u.(interface {
+		SetSpawnPosition(pos cube.Pos, dim world.Dimension)
+	}); ok {
+		s.SetSpawnPosition(pos, w.Dimension())
+		w.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorSetSpawn{})
+		return true
+	}
+	return false
+}
+
+// Deplete removes a single charge from the respawn anchor at the position passed, as happens when a player
+// respawns at it.
+func (r RespawnAnchor) Deplete(pos cube.Pos, w *world.World) {
+	if r.Charge == 0 {
+		return
+	}
+	r.Charge--
+	w.SetBlock(pos, r)
+	w.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorDeplete{})
+}
+
+// explode makes the respawn anchor explode, breaking it and damaging all entities around it.
+func (r RespawnAnchor) explode(pos cube.Pos, w *world.World) {
+	w.SetBlock(pos, nil)
+	explode(pos.Vec3Centre(), respawnAnchorExplosionRadius, w, r)
+}
+
+// BreakInfo ...
+func (r RespawnAnchor) BreakInfo() BreakInfo {
+	return newBreakInfo(50, func(t tool.Tool) bool {
+		return t.ToolType() == tool.TypePickaxe && t.HarvestLevel() >= tool.TierDiamond.HarvestLevel
+	}, pickaxeEffective, oneOf(RespawnAnchor{}))
+}
+
+// EncodeItem ...
+func (RespawnAnchor) EncodeItem() (name string, meta int16) {
+	return "minecraft:respawn_anchor", 0
+}
+
+// EncodeBlock ...
+func (r RespawnAnchor) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:respawn_anchor", map[string]interface{}{"respawn_anchor_charge": int32(r.Charge)}
+}
+
+// allRespawnAnchors returns all possible respawn anchor states.
+func allRespawnAnchors() (anchors []world.Block) {
+	for charge := 0; charge <= 4; charge++ {
+		anchors = append(anchors, RespawnAnchor{Charge: charge})
+	}
+	return
+}
@@ -34,9 +34,7 @@ func (s SandstoneStairs) UseOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64
 		return
 	}
 	s.Facing = user.Facing()
-	if face == cube.FaceDown || (clickPos[1] > 0.5 && face != cube.FaceUp) {
-		s.UpsideDown = true
-	}
+	s.UpsideDown = calculateTop(face, clickPos)
 
 	place(w, pos, s, user, ctx)
 	return placed(ctx)
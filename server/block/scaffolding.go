@@ -0,0 +1,175 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Scaffolding is a temporary climbable block used to build upwards quickly. Scaffolding is able to extend
+// horizontally from a supported column of scaffolding, but falls once it is more than maxScaffoldingStability
+// blocks away from such a column.
+type Scaffolding struct {
+	transparent
+
+	// Stability is the horizontal distance of the scaffolding to the nearest scaffolding that is supported from
+	// below. It ranges from 0 to 7. Scaffolding with a stability of 7 falls.
+	Stability int
+	// StabilityCheck is true if the stability of the scaffolding is scheduled to be checked.
+	StabilityCheck bool
+}
+
+// maxScaffoldingStability is the maximum stability that scaffolding may have without falling.
+const maxScaffoldingStability = 6
+
+// ClimbSpeed ...
+func (s Scaffolding) ClimbSpeed() float64 {
+	return 0.15
+}
+
+// UseOnBlock ...
+func (s Scaffolding) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	if _, ok := w.Block(pos).(Scaffolding); ok && face == cube.FaceUp {
+		// Scaffolding placed on top of scaffolding is stacked on top of the column that it is part of.
+		for {
+			pos = pos.Side(cube.FaceUp)
+			if pos.OutOfBounds(w.Range()) {
+				return false
+			}
+			if _, ok := w.Block(pos).(Scaffolding); !ok {
+				break
+			}
+		}
+		if !replaceableWith(w, pos, s) {
+			return false
+		}
+	} else {
+		var used bool
+		if pos, _, used = firstReplaceable(w, pos, face, s); !used {
+			return false
+		}
+	}
+	s.Stability, s.StabilityCheck = s.calculateStability(pos, w), false
+	if s.Stability > maxScaffoldingStability {
+		return false
+	}
+
+	ctx.IgnoreAABB = true
+
+	place(w, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (s Scaffolding) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if s.StabilityCheck {
+		return
+	}
+	s.StabilityCheck = true
+	w.SetBlock(pos, s)
+	w.ScheduleBlockUpdate(pos, time.Second/20)
+}
+
+// ScheduledTick ...
+func (s Scaffolding) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	stability := s.calculateStability(pos, w)
+	if stability > maxScaffoldingStability {
+		s.fall(pos, w)
+		return
+	}
+	changed := stability != s.Stability
+	s.Stability, s.StabilityCheck = stability, false
+	w.SetBlock(pos, s)
+	if changed {
+		// Scaffolding around depends on the stability of this scaffolding, so it needs to be checked again.
+		for _, face := range []cube.Face{cube.FaceUp, cube.FaceNorth, cube.FaceEast, cube.FaceSouth, cube.FaceWest} {
+			side := pos.Side(face)
+			if n, ok := w.Block(side).(Scaffolding); ok {
+				n.NeighbourUpdateTick(side, pos, w)
+			}
+		}
+	}
+}
+
+// fall makes the scaffolding at the position passed fall as a falling block if there is nothing below it, or
+// breaks it if it is resting on top of a block.
+func (s Scaffolding) fall(pos cube.Pos, w *world.World) {
+	_, air := w.Block(pos.Side(cube.FaceDown)).(Air)
+	_, liquid := w.Liquid(pos.Side(cube.FaceDown))
+	if !air && !liquid {
+		w.BreakBlock(pos)
+		return
+	}
+	w.BreakBlockWithoutParticles(pos)
+	w.AddEntity(entity.NewFallingBlock(Scaffolding{}, pos.Vec3Middle()))
+}
+
+// calculateStability calculates the stability that scaffolding at the position passed would have. Scaffolding
+// resting on a block has a stability of 0, scaffolding on top of other scaffolding has the same stability as that
+// scaffolding, and any other scaffolding has a stability one higher than the most stable scaffolding next to it.
+func (s Scaffolding) calculateStability(pos cube.Pos, w *world.World) int {
+	below := pos.Side(cube.FaceDown)
+	b := w.Block(below)
+	if scaffolding, ok := b.(Scaffolding); ok {
+		return scaffolding.Stability
+	}
+	if b.Model().FaceSolid(below, cube.FaceUp, w) {
+		return 0
+	}
+	stability := maxScaffoldingStability + 1
+	for _, face := range []cube.Face{cube.FaceNorth, cube.FaceEast, cube.FaceSouth, cube.FaceWest} {
+		if n, ok := w.Block(pos.Side(face)).(Scaffolding); ok && n.Stability+1 < stability {
+			stability = n.Stability + 1
+		}
+	}
+	return stability
+}
+
+// SideClosed ...
+func (s Scaffolding) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// CanDisplace ...
+func (s Scaffolding) CanDisplace(b world.Liquid) bool {
+	_, water := b.(Water)
+	return water
+}
+
+// FlammabilityInfo ...
+func (s Scaffolding) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(60, 60, false)
+}
+
+// BreakInfo ...
+func (s Scaffolding) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Scaffolding{}))
+}
+
+// Model ...
+func (s Scaffolding) Model() world.BlockModel {
+	return model.Scaffolding{}
+}
+
+// EncodeItem ...
+func (s Scaffolding) EncodeItem() (name string, meta int16) {
+	return "minecraft:scaffolding", 0
+}
+
+// EncodeBlock ...
+func (s Scaffolding) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:scaffolding", map[string]interface{}{"stability": int32(s.Stability), "stability_check": boolByte(s.StabilityCheck)}
+}
+
+// allScaffolding ...
+func allScaffolding() (b []world.Block) {
+	for i := 0; i <= 7; i++ {
+		b = append(b, Scaffolding{Stability: i}, Scaffolding{Stability: i, StabilityCheck: true})
+	}
+	return
+}
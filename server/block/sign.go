@@ -59,6 +59,11 @@ func (s Sign) BreakInfo() BreakInfo {
 	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(s))
 }
 
+// PickWithData returns the sign as an item including its text.
+func (s Sign) PickWithData() item.Stack {
+	return item.NewStack(Sign{Wood: s.Wood, Text: s.Text, BaseColour: s.BaseColour, Glowing: s.Glowing}, 1).WithLore("(+DATA)")
+}
+
 // CanDisplace ...
 func (s Sign) CanDisplace(l world.Liquid) bool {
 	_, water := l.(Water)
@@ -0,0 +1,55 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Slime is a storage block equivalent to nine slimeballs. Entities bounce off slime blocks and do not take fall
+// damage when landing on them, unless they are sneaking.
+type Slime struct {
+	solid
+	transparent
+}
+
+// BreakInfo ...
+func (s Slime) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(s))
+}
+
+// Friction ...
+func (Slime) Friction() float64 {
+	return 0.8
+}
+
+// Bounces returns true if the entity passed is not sneaking.
+func (Slime) Bounces(e world.Entity) bool {
+	return !sneaking(e)
+}
+
+// EntityLand ...
+func (Slime) EntityLand(cube.Pos, *world.World, world.Entity) {}
+
+// FallDamageMultiplier cancels fall damage for entities that are not sneaking.
+func (Slime) FallDamageMultiplier(e world.Entity) float64 {
+	if sneaking(e) {
+		return 1
+	}
+	return 0
+}
+
+// EncodeItem ...
+func (Slime) EncodeItem() (name string, meta int16) {
+	return "minecraft:slime", 0
+}
+
+// EncodeBlock ...
+func (Slime) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:slime", nil
+}
+
+// sneaking checks if the entity passed is currently sneaking.
+func sneaking(e world.Entity) bool {
+	s, ok := e.(interface{ Sneaking() bool })
+	return ok && s.Sneaking()
+}
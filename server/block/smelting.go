@@ -0,0 +1,126 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/armour"
+	"github.com/df-mc/dragonfly/server/item/smelting"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// init registers the vanilla smelting recipes and fuels of all items and blocks implemented by Dragonfly.
+// Plugins may remove any of these using smelting.Remove and smelting.RemoveFuel.
+func init() {
+	const smeltDuration = time.Second * 10
+	ore, food, misc := smelting.CategoryOre(), smelting.CategoryFood(), smelting.CategoryMisc()
+
+	for _, t := range OreTypes() {
+		registerSmelt(IronOre{Type: t}, item.NewStack(item.IronIngot{}, 1), 0.7, smeltDuration, ore)
+		registerSmelt(GoldOre{Type: t}, item.NewStack(item.GoldIngot{}, 1), 1, smeltDuration, ore)
+		registerSmelt(CopperOre{Type: t}, item.NewStack(item.CopperIngot{}, 1), 0.7, smeltDuration, ore)
+		registerSmelt(CoalOre{Type: t}, item.NewStack(item.Coal{}, 1), 0.1, smeltDuration, ore)
+		registerSmelt(DiamondOre{Type: t}, item.NewStack(item.Diamond{}, 1), 1, smeltDuration, ore)
+		registerSmelt(EmeraldOre{Type: t}, item.NewStack(item.Emerald{}, 1), 1, smeltDuration, ore)
+		registerSmelt(LapisOre{Type: t}, item.NewStack(item.LapisLazuli{}, 1), 0.2, smeltDuration, ore)
+	}
+	registerSmelt(NetherQuartzOre{}, item.NewStack(item.NetherQuartz{}, 1), 0.2, smeltDuration, ore)
+	registerSmelt(NetherGoldOre{}, item.NewStack(item.GoldIngot{}, 1), 1, smeltDuration, ore)
+	registerSmelt(AncientDebris{}, item.NewStack(item.NetheriteScrap{}, 1), 2, smeltDuration, ore)
+	registerSmelt(item.RawIron{}, item.NewStack(item.IronIngot{}, 1), 0.7, smeltDuration, ore)
+	registerSmelt(item.RawGold{}, item.NewStack(item.GoldIngot{}, 1), 1, smeltDuration, ore)
+	registerSmelt(item.RawCopper{}, item.NewStack(item.CopperIngot{}, 1), 0.7, smeltDuration, ore)
+
+	registerSmeltTag("minecraft:iron_tier", item.NewStack(item.IronNugget{}, 1), 0.1, smeltDuration, ore)
+	registerSmeltTag("minecraft:golden_tier", item.NewStack(item.GoldNugget{}, 1), 0.1, smeltDuration, ore)
+	for _, t := range []armour.Tier{armour.TierIron, armour.TierChain, armour.TierGold} {
+		nugget := item.NewStack(item.IronNugget{}, 1)
+		if t == armour.TierGold {
+			nugget = item.NewStack(item.GoldNugget{}, 1)
+		}
+		for _, it := range []world.Item{item.Helmet{Tier: t}, item.Chestplate{Tier: t}, item.Leggings{Tier: t}, item.Boots{Tier: t}} {
+			registerSmelt(it, nugget, 0.1, smeltDuration, ore)
+		}
+	}
+
+	registerSmelt(item.Beef{}, item.NewStack(item.Beef{Cooked: true}, 1), 0.35, smeltDuration, food)
+	registerSmelt(item.Chicken{}, item.NewStack(item.Chicken{Cooked: true}, 1), 0.35, smeltDuration, food)
+	registerSmelt(item.Cod{}, item.NewStack(item.Cod{Cooked: true}, 1), 0.35, smeltDuration, food)
+	registerSmelt(item.Salmon{}, item.NewStack(item.Salmon{Cooked: true}, 1), 0.35, smeltDuration, food)
+	registerSmelt(item.Mutton{}, item.NewStack(item.Mutton{Cooked: true}, 1), 0.35, smeltDuration, food)
+	registerSmelt(item.Porkchop{}, item.NewStack(item.Porkchop{Cooked: true}, 1), 0.35, smeltDuration, food)
+	registerSmelt(item.Rabbit{}, item.NewStack(item.Rabbit{Cooked: true}, 1), 0.35, smeltDuration, food)
+	registerSmelt(Potato{}, item.NewStack(item.BakedPotato{}, 1), 0.35, smeltDuration, food)
+	registerSmelt(Kelp{}, item.NewStack(item.DriedKelp{}, 1), 0.1, smeltDuration, food)
+
+	registerSmelt(Cobblestone{}, item.NewStack(Stone{}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(Stone{}, item.NewStack(Stone{Smooth: true}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(Sand{}, item.NewStack(Glass{}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(Sand{Red: true}, item.NewStack(Glass{}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(item.ClayBall{}, item.NewStack(item.Brick{}, 1), 0.3, smeltDuration, misc)
+	registerSmelt(Clay{}, item.NewStack(Terracotta{}, 1), 0.35, smeltDuration, misc)
+	registerSmelt(Netherrack{}, item.NewStack(item.NetherBrick{}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(Sponge{Wet: true}, item.NewStack(Sponge{}, 1), 0.15, smeltDuration, misc)
+	registerSmelt(Sandstone{}, item.NewStack(Sandstone{Type: SmoothSandstone()}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(Sandstone{Red: true}, item.NewStack(Sandstone{Type: SmoothSandstone(), Red: true}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(StoneBricks{}, item.NewStack(StoneBricks{Type: CrackedStoneBricks()}, 1), 0.1, smeltDuration, misc)
+	registerSmelt(Quartz{}, item.NewStack(Quartz{Smooth: true}, 1), 0.1, smeltDuration, misc)
+
+	registerFuelTag("minecraft:coals", time.Second*80, nil)
+	registerFuel(CoalBlock{}, time.Second*800, nil)
+	registerFuel(DriedKelpBlock{}, time.Second*200, nil)
+	registerFuel(item.BlazeRod{}, time.Second*120, nil)
+	registerFuel(item.Bucket{Content: Lava{}}, time.Second*1000, item.Bucket{})
+	registerFuel(item.Stick{}, time.Second*5, nil)
+	registerFuel(item.Bowl{}, time.Second*5, nil)
+	registerFuelTag("minecraft:wooden_tier", time.Second*10, nil)
+	for _, it := range []world.Item{Bookshelf{}, Chest{}, Barrel{}, Jukebox{}, NoteBlock{}, Loom{}} {
+		registerFuel(it, time.Second*15, nil)
+	}
+
+	registerSmeltTag("minecraft:logs_that_burn", item.NewStack(item.Charcoal{}, 1), 0.15, smeltDuration, misc)
+	registerFuelTag("minecraft:logs_that_burn", time.Second*15, nil)
+	for _, w := range WoodTypes() {
+		if !w.Flammable() {
+			// Nether wood does not burn, and does not smelt into charcoal either.
+			continue
+		}
+		for _, it := range []world.Item{Planks{Wood: w}, WoodStairs{Wood: w}, WoodFence{Wood: w}, WoodFenceGate{Wood: w}, WoodTrapdoor{Wood: w}} {
+			registerFuel(it, time.Second*15, nil)
+		}
+		registerFuel(WoodSlab{Wood: w}, time.Second*15/2, nil)
+		registerFuel(WoodDoor{Wood: w}, time.Second*10, nil)
+	}
+	registerFuelTag("minecraft:wool", time.Second*5, nil)
+	for _, c := range item.Colours() {
+		registerFuel(Carpet{Colour: c}, time.Millisecond*3350, nil)
+		registerFuel(Banner{Colour: c}, time.Second*15, nil)
+	}
+}
+
+// registerSmelt registers a vanilla smelting recipe, panicking if it could not be registered.
+func registerSmelt(input world.Item, output item.Stack, xp float64, duration time.Duration, c smelting.Category) {
+	if err := smelting.Register(input, output, xp, duration, c); err != nil {
+		panic(err)
+	}
+}
+
+// registerSmeltTag registers a vanilla smelting recipe for an item tag, panicking if it could not be registered.
+func registerSmeltTag(tag string, output item.Stack, xp float64, duration time.Duration, c smelting.Category) {
+	if err := smelting.RegisterTag(tag, output, xp, duration, c); err != nil {
+		panic(err)
+	}
+}
+
+// registerFuel registers a vanilla fuel, panicking if it could not be registered.
+func registerFuel(it world.Item, duration time.Duration, residue world.Item) {
+	if err := smelting.RegisterFuel(it, duration, residue); err != nil {
+		panic(err)
+	}
+}
+
+// registerFuelTag registers a vanilla fuel for an item tag, panicking if it could not be registered.
+func registerFuelTag(tag string, duration time.Duration, residue world.Item) {
+	if err := smelting.RegisterFuelTag(tag, duration, residue); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,43 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// SmithingTable is a block used to upgrade diamond gear to netherite gear, as well as being the job site block of
+// the toolsmith villager. Custom upgrades may be added using item.RegisterSmithingRecipe.
+type SmithingTable struct {
+	solid
+	bass
+}
+
+// FlammabilityInfo ...
+func (SmithingTable) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// BreakInfo ...
+func (s SmithingTable) BreakInfo() BreakInfo {
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, oneOf(s))
+}
+
+// Activate opens the smithing table UI for the user.
+func (SmithingTable) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// EncodeItem ...
+func (SmithingTable) EncodeItem() (name string, meta int16) {
+	return "minecraft:smithing_table", 0
+}
+
+// EncodeBlock ...
+func (SmithingTable) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:smithing_table", nil
+}
@@ -23,6 +23,11 @@ func (s SoulSand) Instrument() instrument.Instrument {
 	return instrument.CowBell()
 }
 
+// EntitySpeedFactor ...
+func (SoulSand) EntitySpeedFactor() float64 {
+	return 0.4
+}
+
 // BreakInfo ...
 func (s SoulSand) BreakInfo() BreakInfo {
 	return newBreakInfo(0.5, alwaysHarvestable, shovelEffective, oneOf(s))
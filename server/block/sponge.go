@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 )
 
@@ -39,14 +40,25 @@ func (s Sponge) EncodeBlock() (string, map[string]interface{}) {
 }
 
 // UseOnBlock places the sponge, absorbs nearby water if it's still dry and flags it as wet if any water has been
-// absorbed.
+// absorbed. Wet sponges placed in a dimension in which water evaporates, such as the nether, dry out instantly.
 func (s Sponge) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
 	pos, _, used = firstReplaceable(w, pos, face, s)
 	if !used {
 		return
 	}
+	if s.Wet && w.Dimension().WaterEvaporates() {
+		place(w, pos, Sponge{}, user, ctx)
+		if placed(ctx) {
+			w.AddParticle(pos.Vec3Centre(), particle.Evaporate{})
+			w.PlaySound(pos.Vec3Centre(), sound.Fizz{})
+		}
+		return placed(ctx)
+	}
 
 	place(w, pos, s, user, ctx)
+	if placed(ctx) && !s.Wet && s.absorbWater(pos, w) > 0 {
+		s.setWet(pos, w)
+	}
 	return placed(ctx)
 }
 
@@ -70,43 +82,48 @@ func (s Sponge) setWet(pos cube.Pos, w *world.World) {
 	w.AddParticle(pos.Vec3().Add(mgl64.Vec3{0.5, 0.5, 0.5}), particle.BlockBreak{Block: Water{Depth: 1}})
 }
 
-// absorbWater replaces water blocks near the sponge by air out to a taxicab geometry of 7 in all directions.
-// The maximum for absorbed blocks is 65.
-// The returned int specifies the amount of replaced water blocks.
+// maxSpongeAbsorption is the maximum amount of water blocks that a sponge absorbs at once. maxSpongeDistance is
+// the maximum taxicab distance from the sponge at which water is absorbed.
+const (
+	maxSpongeAbsorption = 65
+	maxSpongeDistance   = 7
+)
+
+// absorbWater removes water near the sponge, both source and flowing water, using a breadth-first search over
+// connected water out to a taxicab distance of 7 from the sponge. At most 65 water blocks are absorbed. Water in
+// waterlogged blocks is removed while the blocks themselves are kept.
+// The returned int specifies the amount of absorbed water blocks.
 func (s Sponge) absorbWater(pos cube.Pos, w *world.World) int {
-	// distanceToSponge binds a world.Pos to its distance from the sponge's position.
+	// distanceToSponge binds a position to its distance from the sponge's position.
 	type distanceToSponge struct {
-		block    cube.Pos
-		distance int32
+		pos      cube.Pos
+		distance int
 	}
+	queue := []distanceToSponge{{pos, 0}}
+	visited := map[cube.Pos]struct{}{pos: {}}
 
-	queue := make([]distanceToSponge, 0)
-	queue = append(queue, distanceToSponge{pos, 0})
-
-	// A sponge can only absorb up to 65 water blocks.
-	replaced := 0
-	for replaced < 65 {
-		if len(queue) == 0 {
-			break
-		}
-
-		// Pop the next distanceToSponge entry from the queue.
+	var absorbed []cube.Pos
+	for len(queue) > 0 && len(absorbed) < maxSpongeAbsorption {
 		next := queue[0]
 		queue = queue[1:]
-
-		next.block.Neighbours(func(neighbour cube.Pos) {
-			liquid, found := w.Liquid(neighbour)
-			if found {
-				if _, isWater := liquid.(Water); isWater {
-					w.SetLiquid(neighbour, nil)
-					replaced++
-					if next.distance < 7 {
-						queue = append(queue, distanceToSponge{neighbour, next.distance + 1})
-					}
+		if next.distance >= maxSpongeDistance {
+			// Water next to this position would be too far away from the sponge.
+			continue
+		}
+		next.pos.Neighbours(func(neighbour cube.Pos) {
+			if _, ok := visited[neighbour]; ok || len(absorbed) >= maxSpongeAbsorption {
+				return
+			}
+			visited[neighbour] = struct{}{}
+			if liquid, ok := w.Liquid(neighbour); ok {
+				if _, water := liquid.(Water); water {
+					absorbed = append(absorbed, neighbour)
+					queue = append(queue, distanceToSponge{neighbour, next.distance + 1})
 				}
 			}
 		}, w.Range())
 	}
-
-	return replaced
+	// All water is removed at once, so that water doesn't flow back in while the sponge is still absorbing it.
+	w.RemoveLiquids(absorbed)
+	return len(absorbed)
 }
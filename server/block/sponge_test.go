@@ -0,0 +1,106 @@
+package block_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// spongePos is the position at which sponges are placed in sponge tests.
+var spongePos = cube.Pos{0, 5, 0}
+
+// placeSponge places a dry sponge at spongePos by clicking the top of the block beneath it and checks that it
+// turned wet by absorbing water.
+func placeSponge(t *testing.T, w *servertest.World) {
+	t.Helper()
+	w.SetBlock(spongePos.Side(cube.FaceDown), block.Stone{})
+	p := w.NewPlayer("sponger", mgl64.Vec3{0.5, 6, 10.5})
+	defer p.Close()
+	p.SetGameMode(world.GameModeCreative)
+	p.SetHeldItems(item.NewStack(block.Sponge{}, 1), item.Stack{})
+	p.UseItemOnBlock(spongePos.Side(cube.FaceDown), cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+
+	if s, ok := w.Block(spongePos).(block.Sponge); !ok || !s.Wet {
+		t.Fatalf("block at %v is %#v after placing a sponge, want wet sponge", spongePos, w.Block(spongePos))
+	}
+}
+
+// taxicab returns the taxicab distance between the positions a and b.
+func taxicab(a, b cube.Pos) int {
+	d := 0
+	for i := range a {
+		if a[i] > b[i] {
+			d += a[i] - b[i]
+		} else {
+			d += b[i] - a[i]
+		}
+	}
+	return d
+}
+
+// TestSpongeDistance checks that a sponge absorbs water out to a taxicab distance of exactly 7.
+func TestSpongeDistance(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	// A single line of water is not enough to reach the limit of the amount of water absorbed.
+	for x := 1; x <= 10; x++ {
+		w.SetLiquid(spongePos.Add(cube.Pos{x, 0, 0}), block.Water{Still: true, Depth: 8})
+	}
+	placeSponge(t, w)
+	for x := 1; x <= 10; x++ {
+		_, ok := w.Liquid(spongePos.Add(cube.Pos{x, 0, 0}))
+		if want := x > 7; ok != want {
+			t.Errorf("water at distance %v from the sponge left: %v, want %v", x, ok, want)
+		}
+	}
+}
+
+// TestSpongeLimit checks that a sponge absorbs exactly 65 water blocks, and that water closer to the sponge is
+// absorbed before water further away.
+func TestSpongeLimit(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	// The layer of water holds 112 blocks within 7 blocks of the sponge. Within 5 blocks there are 60, and 24 at
+	// a distance of exactly 6, so only 5 of those are absorbed.
+	var water []cube.Pos
+	for x := -9; x <= 9; x++ {
+		for z := -9; z <= 9; z++ {
+			if pos := spongePos.Add(cube.Pos{x, 0, z}); pos != spongePos {
+				w.SetLiquid(pos, block.Water{Still: true, Depth: 8})
+				water = append(water, pos)
+			}
+		}
+	}
+	placeSponge(t, w)
+
+	absorbed := map[int]int{}
+	total := 0
+	for _, pos := range water {
+		if _, ok := w.Liquid(pos); !ok {
+			absorbed[taxicab(pos, spongePos)]++
+			total++
+		}
+	}
+	if total != 65 {
+		t.Errorf("sponge absorbed %v water blocks, want 65", total)
+	}
+	for d := 1; d <= 5; d++ {
+		if want := 4 * d; absorbed[d] != want {
+			t.Errorf("sponge absorbed %v water blocks at distance %v, want all %v", absorbed[d], d, want)
+		}
+	}
+	if absorbed[6] != 5 {
+		t.Errorf("sponge absorbed %v water blocks at distance 6, want 5", absorbed[6])
+	}
+	for d := 7; d <= 18; d++ {
+		if absorbed[d] != 0 {
+			t.Errorf("sponge absorbed %v water blocks at distance %v, want none", absorbed[d], d)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// TwistingVines are climbable vegetation blocks that grow upwards from warped nylium in the nether.
+type TwistingVines struct {
+	transparent
+	empty
+
+	// Age is the age of the TwistingVines block. It ranges from 0 to 25.
+	Age int
+}
+
+// ClimbSpeed ...
+func (t TwistingVines) ClimbSpeed() float64 {
+	return 0.2
+}
+
+// UseOnBlock ...
+func (t TwistingVines) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, t)
+	if !used {
+		return false
+	}
+	if !t.supported(pos, w) {
+		return false
+	}
+	// When first placed, twisting vines get a random age between 0 and 24.
+	t.Age = rand.Intn(25)
+
+	place(w, pos, t, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (t TwistingVines) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !t.supported(pos, w) {
+		w.BreakBlock(pos)
+	}
+}
+
+// supported checks if TwistingVines at the position passed are supported by the block below them.
+func (TwistingVines) supported(pos cube.Pos, w *world.World) bool {
+	below := pos.Side(cube.FaceDown)
+	b := w.Block(below)
+	if _, ok := b.(TwistingVines); ok {
+		return true
+	}
+	return b.Model().FaceSolid(below, cube.FaceUp, w)
+}
+
+// HasLiquidDrops ...
+func (t TwistingVines) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (t TwistingVines) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, vinesDrops(TwistingVines{}))
+}
+
+// EncodeItem ...
+func (t TwistingVines) EncodeItem() (name string, meta int16) {
+	return "minecraft:twisting_vines", 0
+}
+
+// EncodeBlock ...
+func (t TwistingVines) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:twisting_vines", map[string]interface{}{"twisting_vines_age": int32(t.Age)}
+}
+
+// allTwistingVines ...
+func allTwistingVines() (b []world.Block) {
+	for i := 0; i <= 25; i++ {
+		b = append(b, TwistingVines{Age: i})
+	}
+	return
+}
+
+// vinesDrops returns the drops of nether vines: They always drop when broken using shears or a tool with silk
+// touch and have a chance of 1/3 to drop otherwise.
+func vinesDrops(it world.Item) func(tool.Tool, []item.Enchantment) []item.Stack {
+	return func(t tool.Tool, enchantments []item.Enchantment) []item.Stack {
+		if t.ToolType() == tool.TypeShears || hasSilkTouch(enchantments) || rand.Intn(3) == 0 {
+			return []item.Stack{item.NewStack(it, 1)}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,173 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Vines are climbable non-solid vegetation blocks that grow on walls.
+type Vines struct {
+	transparent
+	replaceable
+	empty
+
+	// NorthDirection, EastDirection, SouthDirection and WestDirection specify if the vines are attached to the
+	// block on the north, east, south and west side of them respectively.
+	NorthDirection, EastDirection, SouthDirection, WestDirection bool
+}
+
+// ClimbSpeed ...
+func (v Vines) ClimbSpeed() float64 {
+	return 0.2
+}
+
+// WithAttachment returns the Vines with the attachment to the block in the direction passed changed.
+func (v Vines) WithAttachment(direction cube.Direction, attached bool) Vines {
+	switch direction {
+	case cube.North:
+		v.NorthDirection = attached
+	case cube.East:
+		v.EastDirection = attached
+	case cube.South:
+		v.SouthDirection = attached
+	case cube.West:
+		v.WestDirection = attached
+	}
+	return v
+}
+
+// Attachment checks if the Vines are attached to the block in the direction passed.
+func (v Vines) Attachment(direction cube.Direction) bool {
+	switch direction {
+	case cube.North:
+		return v.NorthDirection
+	case cube.East:
+		return v.EastDirection
+	case cube.South:
+		return v.SouthDirection
+	case cube.West:
+		return v.WestDirection
+	}
+	return false
+}
+
+// Attachments returns all directions that the Vines are attached in.
+func (v Vines) Attachments() (directions []cube.Direction) {
+	for _, d := range []cube.Direction{cube.North, cube.East, cube.South, cube.West} {
+		if v.Attachment(d) {
+			directions = append(directions, d)
+		}
+	}
+	return
+}
+
+// UseOnBlock ...
+func (v Vines) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, v)
+	if !used {
+		return false
+	}
+	if face == cube.FaceUp || face == cube.FaceDown {
+		return false
+	}
+	direction := face.Opposite().Direction()
+	if !v.canAttach(pos, direction, w) {
+		return false
+	}
+	if existing, ok := w.Block(pos).(Vines); ok {
+		// Vines placed into existing vines are merged with them.
+		if existing.Attachment(direction) {
+			return false
+		}
+		v = existing
+	}
+	ctx.IgnoreAABB = true
+
+	place(w, pos, v.WithAttachment(direction, true), user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (v Vines) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	updated := v
+	for _, d := range v.Attachments() {
+		if !v.canAttach(pos, d, w) && !v.supportedAbove(pos, d, w) {
+			updated = updated.WithAttachment(d, false)
+		}
+	}
+	if updated == v {
+		return
+	}
+	if len(updated.Attachments()) == 0 {
+		w.BreakBlock(pos)
+		return
+	}
+	w.SetBlock(pos, updated)
+}
+
+// canAttach checks if Vines at the position passed can attach to the block in the direction passed.
+func (Vines) canAttach(pos cube.Pos, direction cube.Direction, w *world.World) bool {
+	side := pos.Side(direction.Face())
+	return w.Block(side).Model().FaceSolid(side, direction.Opposite().Face(), w)
+}
+
+// supportedAbove checks if the Vines at the position passed are hanging from vines above them that are attached
+// in the direction passed.
+func (Vines) supportedAbove(pos cube.Pos, direction cube.Direction, w *world.World) bool {
+	above, ok := w.Block(pos.Side(cube.FaceUp)).(Vines)
+	return ok && above.Attachment(direction)
+}
+
+// HasLiquidDrops ...
+func (v Vines) HasLiquidDrops() bool {
+	return true
+}
+
+// FlammabilityInfo ...
+func (v Vines) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(15, 100, true)
+}
+
+// BreakInfo ...
+func (v Vines) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, func(t tool.Tool) bool {
+		return t.ToolType() == tool.TypeShears || t.ToolType() == tool.TypeAxe
+	}, func(t tool.Tool, enchantments []item.Enchantment) []item.Stack {
+		if t.ToolType() == tool.TypeShears || hasSilkTouch(enchantments) {
+			return []item.Stack{item.NewStack(Vines{}, 1)}
+		}
+		return nil
+	})
+}
+
+// EncodeItem ...
+func (v Vines) EncodeItem() (name string, meta int16) {
+	return "minecraft:vine", 0
+}
+
+// EncodeBlock ...
+func (v Vines) EncodeBlock() (string, map[string]interface{}) {
+	var bits int32
+	for i, ok := range []bool{v.SouthDirection, v.WestDirection, v.NorthDirection, v.EastDirection} {
+		if ok {
+			bits |= 1 << i
+		}
+	}
+	return "minecraft:vine", map[string]interface{}{"vine_direction_bits": bits}
+}
+
+// allVines ...
+func allVines() (b []world.Block) {
+	for i := 0; i < 16; i++ {
+		b = append(b, Vines{
+			SouthDirection: i&1 != 0,
+			WestDirection:  i&2 != 0,
+			NorthDirection: i&4 != 0,
+			EastDirection:  i&8 != 0,
+		})
+	}
+	return
+}
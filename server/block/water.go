@@ -37,6 +37,11 @@ func (w Water) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
 	}
 }
 
+// ModifyFallDistance resets the fall distance of entities falling into the water.
+func (Water) ModifyFallDistance(float64) float64 {
+	return 0
+}
+
 // FillBottle ...
 func (w Water) FillBottle() (world.Block, item.Stack, bool) {
 	if w.Depth == 8 {
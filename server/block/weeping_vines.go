@@ -0,0 +1,85 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// WeepingVines are climbable vegetation blocks that grow downwards from crimson nylium and nether wart blocks in the
+// nether.
+type WeepingVines struct {
+	transparent
+	empty
+
+	// Age is the age of the WeepingVines block. It ranges from 0 to 25.
+	Age int
+}
+
+// ClimbSpeed ...
+func (t WeepingVines) ClimbSpeed() float64 {
+	return 0.2
+}
+
+// UseOnBlock ...
+func (t WeepingVines) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, t)
+	if !used {
+		return false
+	}
+	if !t.supported(pos, w) {
+		return false
+	}
+	// When first placed, weeping vines get a random age between 0 and 24.
+	t.Age = rand.Intn(25)
+
+	place(w, pos, t, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (t WeepingVines) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !t.supported(pos, w) {
+		w.BreakBlock(pos)
+	}
+}
+
+// supported checks if WeepingVines at the position passed are supported by the block above them.
+func (WeepingVines) supported(pos cube.Pos, w *world.World) bool {
+	above := pos.Side(cube.FaceUp)
+	b := w.Block(above)
+	if _, ok := b.(WeepingVines); ok {
+		return true
+	}
+	return b.Model().FaceSolid(above, cube.FaceDown, w)
+}
+
+// HasLiquidDrops ...
+func (t WeepingVines) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (t WeepingVines) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, vinesDrops(WeepingVines{}))
+}
+
+// EncodeItem ...
+func (t WeepingVines) EncodeItem() (name string, meta int16) {
+	return "minecraft:weeping_vines", 0
+}
+
+// EncodeBlock ...
+func (t WeepingVines) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:weeping_vines", map[string]interface{}{"weeping_vines_age": int32(t.Age)}
+}
+
+// allWeepingVines ...
+func allWeepingVines() (b []world.Block) {
+	for i := 0; i <= 25; i++ {
+		b = append(b, WeepingVines{Age: i})
+	}
+	return
+}
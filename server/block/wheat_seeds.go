@@ -22,10 +22,11 @@ func (WheatSeeds) SameCrop(c Crop) bool {
 
 // BoneMeal ...
 func (s WheatSeeds) BoneMeal(pos cube.Pos, w *world.World) bool {
-	if s.Growth == 7 {
+	growth, ok := s.boneMealGrowth()
+	if !ok {
 		return false
 	}
-	s.Growth = min(s.Growth+rand.Intn(4)+2, 7)
+	s.Growth = growth
 	w.PlaceBlock(pos, s)
 	return true
 }
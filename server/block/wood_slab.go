@@ -64,9 +64,7 @@ func (s WoodSlab) UseOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3,
 	if !used {
 		return
 	}
-	if face == cube.FaceDown || (clickPos[1] > 0.5 && face != cube.FaceUp) {
-		s.Top = true
-	}
+	s.Top = calculateTop(face, clickPos)
 
 	place(w, pos, s, user, ctx)
 	return placed(ctx)
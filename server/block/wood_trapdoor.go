@@ -47,7 +47,7 @@ func (t WoodTrapdoor) UseOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Ve
 		return false
 	}
 	t.Facing = user.Facing().Opposite()
-	t.Top = (clickPos.Y() > 0.5 && face != cube.FaceUp) || face == cube.FaceDown
+	t.Top = calculateTop(face, clickPos)
 
 	place(w, pos, t, user, ctx)
 	return placed(ctx)
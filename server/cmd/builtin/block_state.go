@@ -0,0 +1,44 @@
+package builtin
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"sort"
+	"strings"
+)
+
+// parseBlockState parses a block state from a block name and its properties. The properties may be passed as
+// part of the name, like 'stone_slab[top_slot_bit=true]', or separately, like 'top_slot_bit=true', in which
+// case multiple properties are separated by commas or spaces.
+func parseBlockState(name, properties string) (world.Block, error) {
+	if i := strings.IndexByte(name, '['); i != -1 {
+		name, properties = name[:i], name[i:]+" "+properties
+	}
+	properties = strings.NewReplacer("[", " ", "]", " ", ",", " ").Replace(properties)
+
+	props := make(map[string]string)
+	for _, prop := range strings.Fields(properties) {
+		kv := strings.SplitN(prop, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid block property %v: expected key=value", prop)
+		}
+		props[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+	return world.ParseBlockState(name, props)
+}
+
+// formatBlockState formats a block state in the same format as accepted by parseBlockState.
+func formatBlockState(name string, properties map[string]interface{}) string {
+	if len(properties) == 0 {
+		return name
+	}
+	props := make([]string, 0, len(properties))
+	for k, v := range properties {
+		if b, ok := v.(uint8); ok {
+			v = b == 1
+		}
+		props = append(props, fmt.Sprintf("%v=%v", k, v))
+	}
+	sort.Strings(props)
+	return name + "[" + strings.Join(props, ",") + "]"
+}
@@ -0,0 +1,33 @@
+// Package builtin implements commands that are shipped with Dragonfly, but which are not registered by default.
+// These are mostly operator tools, such as /setblock, /fill, /give and /tp. They may be registered using Register.
+package builtin
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+)
+
+// Register registers all commands implemented in the builtin package, so that they may be run by sources that
+// are allowed to run them.
+func Register() {
+	cmd.Register(cmd.New("setblock", "Changes a block to another block.", nil, SetBlock{}))
+	cmd.Register(cmd.New("fill", "Fills all or parts of a region with a specific block.", nil, Fill{}))
+	cmd.Register(cmd.New("give", "Gives an item to a player.", nil, Give{}))
+	cmd.Register(cmd.New("tp", "Teleports players to other players or worlds.", []string{"teleport"}, TeleportToPlayer{}, TeleportPlayerToPlayer{}, TeleportToWorld{}))
+}
+
+// operator is a cmd.Source that may be an operator, such as a player.
+type operator interface {
+	Operator() bool
+}
+
+// operatorOnly may be embedded by commands that may only be run by operators. Sources that cannot be operators,
+// such as the console, are always allowed to run them.
+type operatorOnly struct{}
+
+// Allow ...
+func (operatorOnly) Allow(src cmd.Source) bool {
+	if o, ok := src.(operator); ok {
+		return o.Operator()
+	}
+	return true
+}
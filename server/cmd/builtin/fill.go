@@ -0,0 +1,67 @@
+package builtin
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// maxFillVolume is the maximum amount of blocks that may be changed using a single Fill command.
+const maxFillVolume = 32768
+
+// Fill is a command that fills the cuboid between two positions with the block state passed. The blocks are set
+// using world.World.BuildStructure, so that large regions are changed in one go per chunk.
+type Fill struct {
+	operatorOnly
+
+	From       mgl64.Vec3
+	To         mgl64.Vec3
+	Block      string
+	Properties cmd.Varargs `optional:""`
+}
+
+// Run ...
+func (f Fill) Run(src cmd.Source, o *cmd.Output) {
+	b, err := parseBlockState(f.Block, string(f.Properties))
+	if err != nil {
+		o.Error(err)
+		return
+	}
+	w := src.World()
+	from, to := cube.PosFromVec3(f.From), cube.PosFromVec3(f.To)
+	min := cube.Pos{int(math.Min(float64(from[0]), float64(to[0]))), int(math.Min(float64(from[1]), float64(to[1]))), int(math.Min(float64(from[2]), float64(to[2])))}
+	max := cube.Pos{int(math.Max(float64(from[0]), float64(to[0]))), int(math.Max(float64(from[1]), float64(to[1]))), int(math.Max(float64(from[2]), float64(to[2])))}
+	if min.OutOfBounds(w.Range()) || max.OutOfBounds(w.Range()) {
+		o.Errorf("Cannot place blocks outside of the world")
+		return
+	}
+	dim := [3]int{max[0] - min[0] + 1, max[1] - min[1] + 1, max[2] - min[2] + 1}
+	if volume := dim[0] * dim[1] * dim[2]; volume > maxFillVolume {
+		o.Errorf("Too many blocks in the specified area (%v > %v)", volume, maxFillVolume)
+		return
+	}
+	w.BuildStructure(min, fillStructure{dim: dim, b: b})
+	o.Printf("%v blocks filled with %v", dim[0]*dim[1]*dim[2], formatBlockState(b.EncodeBlock()))
+}
+
+// fillStructure is a world.Structure that consists of a single block state.
+type fillStructure struct {
+	dim [3]int
+	b   world.Block
+}
+
+// Dimensions ...
+func (s fillStructure) Dimensions() [3]int {
+	return s.dim
+}
+
+// At ...
+func (s fillStructure) At(int, int, int, func(int, int, int) world.Block) (world.Block, world.Liquid) {
+	if nbter, ok := s.b.(world.NBTer); ok {
+		// Every block entity needs its own state, so that chests, for example, do not share their inventory.
+		return nbter.DecodeNBT(map[string]interface{}{}).(world.Block), nil
+	}
+	return s.b, nil
+}
@@ -0,0 +1,45 @@
+package builtin
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Give is a command that adds an item to the inventory of an online player. The names of online players and the
+// identifiers of all registered items are auto-completed client-side.
+type Give struct {
+	operatorOnly
+
+	Player cmd.PlayerName
+	Item   cmd.ItemName
+	Count  int `optional:""`
+}
+
+// Run ...
+func (g Give) Run(src cmd.Source, o *cmd.Output) {
+	t, ok := g.Player.Target(src)
+	if !ok {
+		o.Errorf("Player %v is not online", g.Player)
+		return
+	}
+	holder, ok := t.(interface {
+		Inventory() *inventory.Inventory
+	})
+	if !ok {
+		o.Errorf("%v has no inventory", t.Name())
+		return
+	}
+	it, ok := world.ItemByName(string(g.Item), 0)
+	if !ok {
+		o.Errorf("Unknown item %v", g.Item)
+		return
+	}
+	count := g.Count
+	if count <= 0 {
+		count = 1
+	}
+	n, _ := holder.Inventory().AddItem(item.NewStack(it, count))
+	o.Printf("Gave %v * %v to %v", n, g.Item, t.Name())
+}
@@ -0,0 +1,34 @@
+package builtin
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SetBlock is a command that changes the block at a position to the block state passed. The state is validated
+// against the block registry, so that only existing block states may be set.
+type SetBlock struct {
+	operatorOnly
+
+	Position   mgl64.Vec3
+	Block      string
+	Properties cmd.Varargs `optional:""`
+}
+
+// Run ...
+func (s SetBlock) Run(src cmd.Source, o *cmd.Output) {
+	b, err := parseBlockState(s.Block, string(s.Properties))
+	if err != nil {
+		o.Error(err)
+		return
+	}
+	w, pos := src.World(), cube.PosFromVec3(s.Position)
+	if pos.OutOfBounds(w.Range()) {
+		o.Errorf("Cannot place block outside of the world")
+		return
+	}
+	before := formatBlockState(w.BlockState(pos))
+	w.SetBlock(pos, b)
+	o.Printf("Changed block at %v from %v to %v", pos, before, formatBlockState(w.BlockState(pos)))
+}
@@ -0,0 +1,95 @@
+package builtin
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// TeleportToPlayer is a command that teleports the source to an online player. The names of online players are
+// auto-completed client-side.
+type TeleportToPlayer struct {
+	operatorOnly
+
+	Destination cmd.PlayerName
+}
+
+// Run ...
+func (t TeleportToPlayer) Run(src cmd.Source, o *cmd.Output) {
+	teleportToPlayer(src, src, t.Destination, o)
+}
+
+// TeleportPlayerToPlayer is a command that teleports an online player to another online player.
+type TeleportPlayerToPlayer struct {
+	operatorOnly
+
+	Victim      cmd.PlayerName
+	Destination cmd.PlayerName
+}
+
+// Run ...
+func (t TeleportPlayerToPlayer) Run(src cmd.Source, o *cmd.Output) {
+	victim, ok := t.Victim.Target(src)
+	if !ok {
+		o.Errorf("Player %v is not online", t.Victim)
+		return
+	}
+	teleportToPlayer(src, victim, t.Destination, o)
+}
+
+// TeleportToWorld is a command that teleports the source to the spawn of a world. The names of the worlds of the
+// server are auto-completed client-side.
+type TeleportToWorld struct {
+	operatorOnly
+
+	World cmd.WorldName
+}
+
+// Run ...
+func (t TeleportToWorld) Run(src cmd.Source, o *cmd.Output) {
+	w, ok := t.World.World()
+	if !ok {
+		o.Errorf("World %v does not exist", t.World)
+		return
+	}
+	if !teleport(src, w, w.Spawn().Vec3Middle()) {
+		o.Errorf("%v cannot be teleported", src.Name())
+		return
+	}
+	o.Printf("Teleported %v to world %v", src.Name(), w.Name())
+}
+
+// teleportToPlayer teleports the cmd.Target passed to the online player with the name passed.
+func teleportToPlayer(src cmd.Source, t cmd.Target, name cmd.PlayerName, o *cmd.Output) {
+	dest, ok := name.Target(src)
+	if !ok {
+		o.Errorf("Player %v is not online", name)
+		return
+	}
+	e, ok := dest.(world.Entity)
+	if !ok || !teleport(t, e.World(), e.Position()) {
+		o.Errorf("%v cannot be teleported to %v", t.Name(), dest.Name())
+		return
+	}
+	o.Printf("Teleported %v to %v", t.Name(), dest.Name())
+}
+
+// teleporter is a world.Entity that can be teleported, such as a player.
+type teleporter interface {
+	world.Entity
+	Teleport(pos mgl64.Vec3)
+}
+
+// teleport teleports the cmd.Target passed to a position in the world passed, moving it to that world first if
+// needed. False is returned if the target cannot be teleported.
+func teleport(t cmd.Target, w *world.World, pos mgl64.Vec3) bool {
+	e, ok := t.(teleporter)
+	if !ok || w == nil {
+		return false
+	}
+	if e.World() != w {
+		w.AddEntity(e)
+	}
+	e.Teleport(pos)
+	return true
+}
@@ -22,7 +22,8 @@ type Parameter interface {
 //   func (GameMode) Options(Source) []string { return []string{"survival", "creative"} }
 //
 // Their values will then automatically be set to whichever option returned in Enum.Options is selected by
-// the user.
+// the user. Options are sent to clients as soft enums: Changes to them are sent periodically, or immediately
+// after calling UpdateSoftEnum.
 type Enum interface {
 	// Type returns the type of the enum. This type shows up client-side in the command usage, in the spot
 	// where parameter types otherwise are.
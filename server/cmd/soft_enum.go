@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PlayerName is an Enum holding the names of all players online. It may be used as command parameter type to
+// allow only the names of online players to be passed, which are then auto-completed client-side.
+type PlayerName string
+
+// Type ...
+func (PlayerName) Type() string {
+	return "PlayerName"
+}
+
+// Options returns the names of all players that the Source passed can target.
+func (PlayerName) Options(src Source) []string {
+	_, players := targets(src)
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name()
+	}
+	return names
+}
+
+// Target returns the Target of the online player with the name, if it can be targeted by the Source passed.
+func (n PlayerName) Target(src Source) (Target, bool) {
+	_, players := targets(src)
+	for _, p := range players {
+		if strings.EqualFold(p.Name(), string(n)) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// WorldName is an Enum holding the names of all worlds returned by the functions added using AddWorldFunc.
+type WorldName string
+
+// Type ...
+func (WorldName) Type() string {
+	return "WorldName"
+}
+
+// Options ...
+func (WorldName) Options(Source) []string {
+	all := worlds()
+	names := make([]string, len(all))
+	for i, w := range all {
+		names[i] = w.Name()
+	}
+	return names
+}
+
+// World returns the world with the name, if it is returned by one of the functions added using AddWorldFunc.
+func (n WorldName) World() (*world.World, bool) {
+	for _, w := range worlds() {
+		if w.Name() == string(n) {
+			return w, true
+		}
+	}
+	return nil, false
+}
+
+// AddWorldFunc adds a function used to find the worlds that may be selected using a WorldName. After the worlds
+// returned by the function change, UpdateSoftEnum should be called with a WorldName.
+func AddWorldFunc(f func() []*world.World) {
+	worldFunctions = append(worldFunctions, f)
+}
+
+// worldFunctions holds a list of all functions added using AddWorldFunc.
+var worldFunctions []func() []*world.World
+
+// worlds returns all worlds returned by the functions added using AddWorldFunc.
+func worlds() []*world.World {
+	var all []*world.World
+	for _, f := range worldFunctions {
+		all = append(all, f()...)
+	}
+	return all
+}
+
+// ItemName is an Enum holding the identifiers of all registered items, such as 'minecraft:diamond'.
+type ItemName string
+
+// Type ...
+func (ItemName) Type() string {
+	return "ItemName"
+}
+
+// Options ...
+func (ItemName) Options(Source) []string {
+	items := world.Items()
+	names := make([]string, len(items))
+	for i, it := range items {
+		names[i], _ = it.EncodeItem()
+	}
+	return uniqueSorted(names)
+}
+
+// uniqueSorted sorts the strings passed and removes any duplicates.
+func uniqueSorted(s []string) []string {
+	sort.Strings(s)
+	unique := s[:0]
+	for _, v := range s {
+		if len(unique) == 0 || v != unique[len(unique)-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+var (
+	softEnumMu sync.Mutex
+	// softEnumFuncs holds the functions added using OnSoftEnumUpdate, indexed by a unique ID.
+	softEnumFuncs  = map[int]func(e Enum){}
+	softEnumFuncID int
+)
+
+// UpdateSoftEnum notifies all listeners added using OnSoftEnumUpdate that the options of the Enum passed may
+// have changed. Options of enums are checked for changes periodically, but calling UpdateSoftEnum results in
+// changes being sent to clients immediately, for example after a player joins the server.
+func UpdateSoftEnum(e Enum) {
+	softEnumMu.Lock()
+	defer softEnumMu.Unlock()
+	for _, f := range softEnumFuncs {
+		f(e)
+	}
+}
+
+// OnSoftEnumUpdate adds a function that is called every time UpdateSoftEnum is called. The function returned
+// may be called to remove the function again. The function passed must not block.
+func OnSoftEnumUpdate(f func(e Enum)) (remove func()) {
+	softEnumMu.Lock()
+	defer softEnumMu.Unlock()
+	id := softEnumFuncID
+	softEnumFuncID++
+	softEnumFuncs[id] = f
+	return func() {
+		softEnumMu.Lock()
+		defer softEnumMu.Unlock()
+		delete(softEnumFuncs, id)
+	}
+}
@@ -1,5 +1,10 @@
 package server
 
+import (
+	"github.com/df-mc/dragonfly/server/session"
+	"time"
+)
+
 // Config is the configuration of a Dragonfly server. It holds settings that affect different aspects of the
 // server, such as its name and maximum players.
 type Config struct {
@@ -30,6 +35,23 @@ type Config struct {
 		Name string
 		// Folder is the folder that the data of the world resides in.
 		Folder string
+		// SpawnProtection is the radius in blocks around the spawn of the world within which only operators may
+		// place and break blocks. If set to 0, spawn protection is disabled.
+		SpawnProtection int
+		// Generator is the generator used to generate new terrain in the overworld. It is either "flat", which
+		// generates a flat grass world, or "overworld", which generates natural terrain with hills, oceans and
+		// biomes from the seed of the world.
+		Generator string
+		// Obfuscation controls the obfuscation of blocks in chunks sent to players, which hides blocks such as
+		// ores that are fully surrounded by opaque blocks from players until they are exposed. This prevents
+		// modified clients from finding them by looking through blocks. Blocks are hidden per dimension and are
+		// specified by their name, such as "minecraft:diamond_ore".
+		Obfuscation session.Obfuscation
+		// SaveInterval is the interval at which the worlds of the server and the data of all players online are
+		// saved, so that little progress is lost if the server stops unexpectedly. Setting it to 0 disables
+		// saving periodically: Data is then only saved when chunks are unloaded, players quit and the server is
+		// closed.
+		SaveInterval time.Duration
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server at the same time. If set
@@ -45,6 +67,11 @@ type Config struct {
 		// Folder controls where the player data will be stored by the default LevelDB
 		// player provider if it is enabled.
 		Folder string
+		// RateLimits holds the limits on the rate at which players may chat, execute commands, submit forms, use
+		// items and attack entities. Every limit has a Rate of tokens refilled per second, a Burst of tokens that
+		// may be used in quick succession and a Mode: 0 drops actions exceeding the limit, 1 delays them and 2
+		// drops them and calls the HandleSpamViolation handler of the player.
+		RateLimits session.RateLimits
 	}
 
 	Resources struct {
@@ -64,9 +91,13 @@ func DefaultConfig() Config {
 	c.Server.QuitMessage = "%v has left the game"
 	c.World.Name = "World"
 	c.World.Folder = "world"
+	c.World.Generator = "flat"
+	c.World.Obfuscation = session.DefaultObfuscation()
+	c.World.SaveInterval = time.Minute * 5
 	c.Players.MaximumChunkRadius = 32
 	c.Players.SaveData = true
 	c.Players.Folder = "players"
+	c.Players.RateLimits = session.DefaultRateLimits()
 	c.Resources.Folder = "resources"
 	return c
 }
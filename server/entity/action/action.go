@@ -33,6 +33,19 @@ type PickedUp struct {
 	action
 }
 
+// FireworkExplosion makes a firework entity display its explosion to viewers. The particles shown depend on
+// the explosions of the firework.
+type FireworkExplosion struct{ action }
+
+// StackSizeUpdate is an action sent when the stack size of an item entity changes, for example when it merges with
+// another item entity.
+type StackSizeUpdate struct {
+	// Count is the new count of the stack held by the item entity.
+	Count int
+
+	action
+}
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
@@ -0,0 +1,243 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// AreaEffectCloud is a cloud of particles created when a lingering potion hits a block or entity. It applies the
+// effects of its potion to living entities inside it, while its radius shrinks over time and every time it
+// applies its effects to an entity.
+type AreaEffectCloud struct {
+	transform
+
+	t potion.Potion
+
+	// age is the amount of ticks that the cloud has existed for. duration is the amount of ticks after which the
+	// cloud disappears, not counting the ticks that it spends waiting before it first applies effects.
+	age, duration int
+	// radius is the current radius of the cloud. radiusOnUse is added to the radius every time the cloud applies
+	// its effects to an entity, while radiusGrowth is added to it every tick.
+	radius, radiusOnUse, radiusGrowth float64
+	// targets holds the entities that the cloud applied its effects to, with the age of the cloud at which
+	// effects may be applied to them again.
+	targets map[world.Entity]int
+
+	close bool
+}
+
+const (
+	// areaEffectCloudWaitTicks is the amount of ticks an AreaEffectCloud waits after being created before it
+	// starts applying effects.
+	areaEffectCloudWaitTicks = 10
+	// areaEffectCloudReapplyTicks is the amount of ticks after which an AreaEffectCloud may apply its effects to
+	// the same entity again.
+	areaEffectCloudReapplyTicks = 20
+	// areaEffectCloudMinRadius is the radius below which an AreaEffectCloud disappears.
+	areaEffectCloudMinRadius = 0.5
+)
+
+// NewAreaEffectCloud creates a new AreaEffectCloud at the position passed that applies the effects of the potion
+// passed. The cloud has a radius of 3 blocks and lasts for 30 seconds, during which its radius shrinks to 0.
+func NewAreaEffectCloud(pos mgl64.Vec3, t potion.Potion) *AreaEffectCloud {
+	return NewAreaEffectCloudWith(pos, t, time.Second*30, 3, -0.5)
+}
+
+// NewAreaEffectCloudWith creates a new AreaEffectCloud at the position passed that applies the effects of the
+// potion passed for the duration passed. Its radius starts at the radius passed and shrinks to 0 over its
+// duration. The radiusOnUse passed is added to the radius every time the cloud applies its effects to an entity.
+func NewAreaEffectCloudWith(pos mgl64.Vec3, t potion.Potion, duration time.Duration, radius, radiusOnUse float64) *AreaEffectCloud {
+	ticks := int(duration.Milliseconds() / 50)
+	if ticks < 1 {
+		ticks = 1
+	}
+	a := &AreaEffectCloud{
+		t:            t,
+		duration:     ticks,
+		radius:       radius,
+		radiusOnUse:  radiusOnUse,
+		radiusGrowth: -radius / float64(ticks),
+		targets:      map[world.Entity]int{},
+	}
+	a.transform = newTransform(a, pos)
+	return a
+}
+
+// Name ...
+func (a *AreaEffectCloud) Name() string {
+	return "Area Effect Cloud"
+}
+
+// EncodeEntity ...
+func (a *AreaEffectCloud) EncodeEntity() string {
+	return "minecraft:area_effect_cloud"
+}
+
+// AABB ...
+func (a *AreaEffectCloud) AABB() physics.AABB {
+	r := a.Radius()
+	return physics.NewAABB(mgl64.Vec3{-r, 0, -r}, mgl64.Vec3{r, 0.5, r})
+}
+
+// Type returns the type of potion whose effects the cloud applies.
+func (a *AreaEffectCloud) Type() potion.Potion {
+	return a.t
+}
+
+// Effects returns the effects that the cloud applies to entities inside it. Lasting effects last a quarter of
+// the duration that they have when drinking the potion.
+func (a *AreaEffectCloud) Effects() []effect.Effect {
+	effects := a.t.Effects()
+	for i, eff := range effects {
+		if lasting, ok := eff.Type().(effect.LastingType); ok {
+			effects[i] = effect.New(lasting, eff.Level(), eff.Duration()/4)
+		}
+	}
+	return effects
+}
+
+// Radius returns the current radius of the cloud.
+func (a *AreaEffectCloud) Radius() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.radius
+}
+
+// Duration returns the remaining duration of the cloud, after which it disappears.
+func (a *AreaEffectCloud) Duration() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return time.Duration(areaEffectCloudWaitTicks+a.duration-a.age) * time.Second / 20
+}
+
+// Waiting checks if the cloud is still waiting to start applying its effects.
+func (a *AreaEffectCloud) Waiting() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.age < areaEffectCloudWaitTicks
+}
+
+// Tick ...
+func (a *AreaEffectCloud) Tick(current int64) {
+	if a.close {
+		_ = a.Close()
+		return
+	}
+	a.mu.Lock()
+	a.age++
+	if a.age >= areaEffectCloudWaitTicks+a.duration {
+		a.mu.Unlock()
+		a.close = true
+		return
+	}
+	if a.age < areaEffectCloudWaitTicks {
+		a.mu.Unlock()
+		return
+	}
+	a.radius += a.radiusGrowth
+	age, radius, pos := a.age, a.radius, a.pos
+	a.mu.Unlock()
+
+	if radius < areaEffectCloudMinRadius {
+		a.close = true
+		return
+	}
+	w := a.World()
+	if age == areaEffectCloudWaitTicks || current%20 == 0 {
+		// Viewers are updated periodically, so that the cloud shrinks client-side too.
+		a.updateState(w, pos)
+	}
+	if current%5 != 0 {
+		return
+	}
+	for e, at := range a.targets {
+		if age >= at {
+			delete(a.targets, e)
+		}
+	}
+	effects := a.Effects()
+	if len(effects) == 0 {
+		return
+	}
+	aabb := physics.NewAABB(mgl64.Vec3{-radius, 0, -radius}, mgl64.Vec3{radius, 0.5, radius}).Translate(pos)
+	for _, e := range w.EntitiesWithin(aabb.Grow(2), a.ignores) {
+		if _, ok := a.targets[e]; ok {
+			continue
+		}
+		epos := e.Position()
+		if !e.AABB().Translate(epos).IntersectsWith(aabb) || math.Hypot(epos[0]-pos[0], epos[2]-pos[2]) > radius {
+			continue
+		}
+		l := e.(Living)
+		for _, eff := range effects {
+			if p, ok := eff.Type().(effect.PotentType); ok {
+				l.AddEffect(effect.NewInstant(p.WithPotency(0.5), eff.Level()))
+				continue
+			}
+			l.AddEffect(eff)
+		}
+		a.targets[e] = age + areaEffectCloudReapplyTicks
+
+		a.mu.Lock()
+		a.radius += a.radiusOnUse
+		radius = a.radius
+		a.mu.Unlock()
+		if radius < areaEffectCloudMinRadius {
+			a.close = true
+			return
+		}
+		a.updateState(w, pos)
+	}
+}
+
+// updateState updates the state of the cloud, such as its radius, for all viewers of the cloud.
+func (a *AreaEffectCloud) updateState(w *world.World, pos mgl64.Vec3) {
+	for _, v := range w.Viewers(pos) {
+		v.ViewEntityState(a)
+	}
+}
+
+// ignores checks if the cloud should not apply its effects to the entity passed.
+func (a *AreaEffectCloud) ignores(e world.Entity) bool {
+	_, living := e.(Living)
+	return !living
+}
+
+// DecodeNBT decodes the properties in a map to an AreaEffectCloud and returns a new AreaEffectCloud entity.
+func (a *AreaEffectCloud) DecodeNBT(data map[string]interface{}) interface{} {
+	cloud := NewAreaEffectCloudWith(
+		nbtconv.MapVec3(data, "Pos"),
+		potion.From(int32(nbtconv.MapInt16(data, "PotionId"))),
+		time.Duration(nbtconv.MapInt32(data, "Duration"))*time.Second/20,
+		float64(nbtconv.MapFloat32(data, "Radius")),
+		float64(nbtconv.MapFloat32(data, "RadiusOnUse")),
+	)
+	cloud.age = int(nbtconv.MapInt32(data, "Age"))
+	if growth, ok := data["RadiusPerTick"]; ok {
+		if growth, ok := growth.(float32); ok {
+			cloud.radiusGrowth = float64(growth)
+		}
+	}
+	return cloud
+}
+
+// EncodeNBT encodes the AreaEffectCloud entity's properties as a map and returns it.
+func (a *AreaEffectCloud) EncodeNBT() map[string]interface{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return map[string]interface{}{
+		"Pos":           nbtconv.Vec3ToFloat32Slice(a.pos),
+		"PotionId":      int16(a.t.Uint8()),
+		"Age":           int32(a.age),
+		"Duration":      int32(a.duration),
+		"Radius":        float32(a.radius),
+		"RadiusOnUse":   float32(a.radiusOnUse),
+		"RadiusPerTick": float32(a.radiusGrowth),
+	}
+}
@@ -0,0 +1,407 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/action"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/entity/physics/trace"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Arrow is a projectile shot by bows and crossbows. It damages the entities it hits based on its speed and stays
+// stuck in the blocks it hits until it despawns.
+type Arrow struct {
+	transform
+	yaw, pitch float64
+
+	baseDamage float64
+	// pierce is the amount of entities the arrow may still pass through. pierced holds the entities the arrow has
+	// already passed through, so that they are not hit again.
+	pierce  int
+	pierced []world.Entity
+
+	// age, stuckTicks, stuck and close are guarded by the mutex of the transform, as they may be read by
+	// EncodeNBT while the arrow is ticked.
+	age, stuckTicks int
+	stuck, close    bool
+
+	owner world.Entity
+	// tip is the potion that the arrow is tipped with. Its effects are applied to the entities hit.
+	tip potion.Potion
+	// critical specifies if the arrow deals extra damage. Critical arrows leave a trail of particles behind.
+	critical bool
+	// punch is the level of the Punch enchantment of the bow that shot the arrow, which increases the knock back
+	// of the arrow. flame specifies if the arrow sets the entities it hits on fire.
+	punch int
+	flame bool
+	// pickup specifies who may pick up the arrow once it is stuck in a block.
+	pickup ArrowPickup
+
+	c *ProjectileComputer
+}
+
+// ArrowPickup specifies who may pick up an arrow that is stuck in a block.
+type ArrowPickup uint8
+
+const (
+	// ArrowPickupAny allows any collector to pick up the arrow, adding it to its inventory. This is the case
+	// for arrows shot by players in survival mode and by dispensers.
+	ArrowPickupAny ArrowPickup = iota
+	// ArrowPickupCreative allows only players with a creative inventory to pick up the arrow, which removes the
+	// arrow without adding it to their inventory. This is the case for arrows shot by creative players or by a
+	// bow with Infinity.
+	ArrowPickupCreative
+	// ArrowPickupNone prevents the arrow from being picked up at all.
+	ArrowPickupNone
+)
+
+const (
+	// arrowDespawnTicks is the amount of ticks after which an arrow stuck in a block despawns.
+	arrowDespawnTicks = 1200
+	// arrowFireDuration is the duration that entities hit by a flaming arrow are set on fire for.
+	arrowFireDuration = time.Second * 5
+)
+
+// NewArrow creates a new Arrow at the position passed, shot by the owner passed. The owner may be nil.
+func NewArrow(pos mgl64.Vec3, yaw, pitch float64, owner world.Entity) *Arrow {
+	a := &Arrow{
+		yaw:        yaw,
+		pitch:      pitch,
+		baseDamage: 2,
+		c: &ProjectileComputer{MovementComputer: &MovementComputer{
+			Gravity:           0.05,
+			Drag:              0.01,
+			DragBeforeGravity: true,
+		}},
+		owner: owner,
+	}
+	a.transform = newTransform(a, pos)
+	return a
+}
+
+// NewTippedArrow creates a new Arrow like NewArrow, which applies the effects of the potion passed to the
+// entities that it hits.
+func NewTippedArrow(pos mgl64.Vec3, yaw, pitch float64, owner world.Entity, tip potion.Potion) *Arrow {
+	a := NewArrow(pos, yaw, pitch, owner)
+	a.tip = tip
+	return a
+}
+
+// Tip returns the potion that the arrow is tipped with.
+func (a *Arrow) Tip() potion.Potion {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.tip
+}
+
+// Name ...
+func (a *Arrow) Name() string {
+	return "Arrow"
+}
+
+// EncodeEntity ...
+func (a *Arrow) EncodeEntity() string {
+	return "minecraft:arrow"
+}
+
+// AABB ...
+func (a *Arrow) AABB() physics.AABB {
+	return physics.NewAABB(mgl64.Vec3{-0.25, 0, -0.25}, mgl64.Vec3{0.25, 0.5, 0.25})
+}
+
+// MovementConfig ...
+func (a *Arrow) MovementConfig() MovementConfig {
+	return a.c.Config()
+}
+
+// SetMovementConfig ...
+func (a *Arrow) SetMovementConfig(conf MovementConfig) {
+	a.c.SetConfig(conf)
+}
+
+// Rotation ...
+func (a *Arrow) Rotation() (float64, float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.yaw, a.pitch
+}
+
+// SetPiercing sets the amount of entities the arrow may pass through before it stops, as is the case for arrows
+// shot from a crossbow with the Piercing enchantment.
+func (a *Arrow) SetPiercing(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pierce = n
+}
+
+// Piercing returns the amount of entities the arrow may still pass through before it stops.
+func (a *Arrow) Piercing() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pierce
+}
+
+// SetCritical sets whether the arrow is critical. Critical arrows deal extra damage and leave a trail of
+// particles behind.
+func (a *Arrow) SetCritical(critical bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.critical = critical
+}
+
+// Critical checks if the arrow is critical.
+func (a *Arrow) Critical() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.critical
+}
+
+// SetPunch sets the level of the Punch enchantment that the arrow was shot with, which increases the knock back
+// that the arrow deals.
+func (a *Arrow) SetPunch(level int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.punch = level
+}
+
+// SetFlame sets whether the arrow is on fire, as is the case for arrows shot by a bow with Flame. Flaming arrows
+// set the entities they hit on fire.
+func (a *Arrow) SetFlame(flame bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.flame = flame
+}
+
+// OnFireDuration returns the duration that entities hit by the arrow are set on fire for. It is 0 if the arrow
+// is not on fire.
+func (a *Arrow) OnFireDuration() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.flame {
+		return 0
+	}
+	return arrowFireDuration
+}
+
+// SetPickup sets who may pick up the arrow once it is stuck in a block.
+func (a *Arrow) SetPickup(pickup ArrowPickup) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pickup = pickup
+}
+
+// Pickup returns who may pick up the arrow once it is stuck in a block.
+func (a *Arrow) Pickup() ArrowPickup {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pickup
+}
+
+// Tick ...
+func (a *Arrow) Tick(current int64) {
+	a.mu.Lock()
+	if a.close {
+		a.mu.Unlock()
+		_ = a.Close()
+		return
+	}
+	if a.stuck {
+		a.stuckTicks++
+		despawn := a.stuckTicks >= arrowDespawnTicks
+		a.close = despawn
+		a.mu.Unlock()
+
+		if !despawn {
+			a.checkPickup()
+		}
+		return
+	}
+	vel := a.vel
+	m, result := a.c.TickMovement(a, a.pos, a.vel, a.yaw, a.pitch, a.ignores)
+	a.pos, a.vel, a.yaw, a.pitch = m.pos, m.vel, m.yaw, m.pitch
+	a.age++
+	a.mu.Unlock()
+
+	m.Send()
+
+	closeArrow := a.c.resolveImpact(a, m, result, vel, current)
+	a.mu.Lock()
+	a.close = closeArrow
+	a.mu.Unlock()
+}
+
+// hit makes the arrow get stuck in the block hit, or damages the entity hit and applies the effects of its tip.
+// An arrow that may still pierce entities continues flying with the velocity it had before hitting the entity.
+func (a *Arrow) hit(result trace.Result, vel mgl64.Vec3) bool {
+	a.World().PlaySound(result.Position(), sound.ArrowHit{})
+	r, ok := result.(trace.EntityResult)
+	if !ok {
+		// The arrow hit a block, so it gets stuck in it.
+		a.mu.Lock()
+		a.stuck = true
+		a.mu.Unlock()
+		return false
+	}
+	if l, ok := r.Entity().(Living); ok {
+		a.mu.Lock()
+		critical, punch, flame := a.critical, a.punch, a.flame
+		a.mu.Unlock()
+
+		dmg := math.Ceil(vel.Len() * a.baseDamage)
+		if critical {
+			dmg += float64(rand.Intn(int(dmg)/2 + 2))
+		}
+		if f, ok := l.(Flammable); ok && flame && !f.FireProof() {
+			f.SetOnFire(arrowFireDuration)
+		}
+		if _, vulnerable := l.Hurt(dmg, damage.SourceProjectile{Projectile: a, Owner: a.Owner()}); vulnerable {
+			l.KnockBack(r.Position(), 0.45+float64(punch)*0.6, 0.3608)
+			for _, eff := range a.Tip().Effects() {
+				// Lasting effects of tipped arrows last an eighth of the duration of those of the potion.
+				if lasting, ok := eff.Type().(effect.LastingType); ok {
+					eff = effect.New(lasting, eff.Level(), eff.Duration()/8)
+				}
+				l.AddEffect(eff)
+			}
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pierce > 0 {
+		// The arrow passes through the entity hit and continues flying with the velocity it had.
+		a.pierce--
+		a.pierced = append(a.pierced, r.Entity())
+		a.vel = vel
+		return false
+	}
+	return true
+}
+
+// checkPickup checks for a collector close to the arrow stuck in a block that may pick it up. If one is found, the
+// arrow is collected and closed.
+func (a *Arrow) checkPickup() {
+	pos, pickup := a.Position(), a.Pickup()
+	if pickup == ArrowPickupNone {
+		return
+	}
+	w := a.World()
+	grown := a.AABB().GrowVec3(mgl64.Vec3{1, 0.5, 1}).Translate(pos)
+	for _, e := range w.EntitiesWithin(grown.Grow(2), nil) {
+		collector, ok := e.(Collector)
+		if !ok || !e.AABB().Translate(e.Position()).IntersectsWith(grown) {
+			continue
+		}
+		if pickup == ArrowPickupCreative {
+			// Only players with a creative inventory may pick up the arrow, which does not give them an arrow.
+			g, ok := e.(interface{ GameMode() world.GameMode })
+			if !ok || !g.GameMode().CreativeInventory() || !g.GameMode().AllowsInteraction() {
+				continue
+			}
+		} else if collector.Collect(item.NewStack(item.Arrow{Tip: a.Tip()}, 1)) == 0 {
+			continue
+		}
+		for _, viewer := range w.Viewers(pos) {
+			viewer.ViewEntityAction(a, action.PickedUp{Collector: collector})
+		}
+		a.mu.Lock()
+		a.close = true
+		a.mu.Unlock()
+		return
+	}
+}
+
+// ignores returns whether the arrow should ignore collision with the entity passed.
+func (a *Arrow) ignores(entity world.Entity) bool {
+	if _, ok := entity.(Living); !ok || entity == a || (a.age < 5 && entity == a.owner) {
+		return true
+	}
+	for _, e := range a.pierced {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// New creates an arrow with the position, velocity, yaw, and pitch provided. It doesn't spawn the arrow, only
+// returns it.
+func (a *Arrow) New(pos, vel mgl64.Vec3, yaw, pitch float64) world.Entity {
+	arrow := NewTippedArrow(pos, yaw, pitch, nil, a.tip)
+	arrow.vel = vel
+	return arrow
+}
+
+// Owner ...
+func (a *Arrow) Owner() world.Entity {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.owner
+}
+
+// Own ...
+func (a *Arrow) Own(owner world.Entity) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.owner = owner
+}
+
+// DecodeNBT decodes the properties in a map to an Arrow and returns a new Arrow entity.
+func (a *Arrow) DecodeNBT(data map[string]interface{}) interface{} {
+	arrow := a.New(
+		nbtconv.MapVec3(data, "Pos"),
+		nbtconv.MapVec3(data, "Motion"),
+		float64(nbtconv.MapFloat32(data, "Yaw")),
+		float64(nbtconv.MapFloat32(data, "Pitch")),
+	).(*Arrow)
+	arrow.pierce = int(nbtconv.MapByte(data, "PierceLevel"))
+	arrow.stuck = nbtconv.MapByte(data, "InGround") == 1
+	arrow.critical = nbtconv.MapByte(data, "crit") == 1
+	arrow.punch = int(nbtconv.MapByte(data, "enchantPunch"))
+	arrow.flame = nbtconv.MapByte(data, "enchantFlame") == 1
+	arrow.pickup = ArrowPickup(nbtconv.MapByte(data, "pickup"))
+	if aux := nbtconv.MapByte(data, "auxValue"); aux > 0 {
+		arrow.tip = potion.From(int32(aux) - 1)
+	}
+	return arrow
+}
+
+// EncodeNBT encodes the Arrow entity's properties as a map and returns it.
+func (a *Arrow) EncodeNBT() map[string]interface{} {
+	yaw, pitch := a.Rotation()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	m := map[string]interface{}{
+		"Pos":          nbtconv.Vec3ToFloat32Slice(a.pos),
+		"Yaw":          float32(yaw),
+		"Pitch":        float32(pitch),
+		"Motion":       nbtconv.Vec3ToFloat32Slice(a.vel),
+		"PierceLevel":  uint8(a.pierce),
+		"InGround":     boolByte(a.stuck),
+		"crit":         boolByte(a.critical),
+		"enchantPunch": uint8(a.punch),
+		"enchantFlame": boolByte(a.flame),
+		"pickup":       uint8(a.pickup),
+	}
+	if len(a.tip.Effects()) > 0 {
+		m["auxValue"] = a.tip.Uint8() + 1
+	}
+	return m
+}
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
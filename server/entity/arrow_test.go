@@ -0,0 +1,48 @@
+package entity_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// TestArrowPickup checks that an arrow falling on a block gets stuck in it and is picked up by a player walking
+// up to it, while its state is read concurrently.
+func TestArrowPickup(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	w.Fill(cube.Pos{-2, 9, -2}, cube.Pos{2, 9, 2}, block.Stone{})
+	a := entity.NewArrow(mgl64.Vec3{0.5, 12, 0.5}, 0, 90, nil)
+	w.AddEntity(a)
+
+	done := make(chan struct{})
+	go func() {
+		// Encoding the arrow, as done when saving the world, may happen at any time while it is ticked.
+		for i := 0; i < 100; i++ {
+			_ = a.EncodeNBT()
+		}
+		close(done)
+	}()
+	w.Advance(40)
+	<-done
+
+	if a.EncodeNBT()["InGround"] != uint8(1) {
+		t.Fatalf("arrow at %v did not get stuck in the ground", a.Position())
+	}
+	p := w.NewPlayer("collector", a.Position())
+	defer p.Close()
+	// The arrow is collected in the first tick and removed in the next one.
+	w.Advance(2)
+
+	if a.World() != nil {
+		t.Errorf("arrow not picked up by player standing on it")
+	}
+	if !p.Inventory().ContainsItem(item.NewStack(item.Arrow{}, 1)) {
+		t.Errorf("player has no arrow after picking up arrow")
+	}
+}
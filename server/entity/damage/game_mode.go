@@ -0,0 +1,15 @@
+package damage
+
+import "github.com/df-mc/dragonfly/server/world"
+
+// AllowedByGameMode checks if an entity with the world.GameMode passed may be damaged by the Source passed.
+// Game modes that allow taking damage may be damaged by any Source. Other game modes, such as creative mode,
+// may only be damaged by the void, unless they also have no collision, such as spectator mode: Entities in these
+// game modes move through the world freely and are not affected by the void either.
+func AllowedByGameMode(mode world.GameMode, src Source) bool {
+	if mode.AllowsTakingDamage() {
+		return true
+	}
+	_, void := src.(SourceVoid)
+	return void && mode.HasCollision()
+}
@@ -17,6 +17,14 @@ type SourceEntityAttack struct {
 	Attacker world.Entity
 }
 
+// SourceProjectile is used for damage caused by a projectile, such as an arrow, hitting an entity.
+type SourceProjectile struct {
+	// Projectile holds the projectile entity that hit the entity.
+	Projectile world.Entity
+	// Owner holds the entity that shot the projectile. Owner may be nil.
+	Owner world.Entity
+}
+
 // SourceStarvation is used for damage caused by a completely depleted food bar.
 type SourceStarvation struct{}
 
@@ -45,12 +53,28 @@ type SourceFireTick struct{}
 // SourceLava is used for damage caused by being in lava.
 type SourceLava struct{}
 
+// SourceHotFloor is used for damage caused by standing on a hot block, such as a magma block.
+type SourceHotFloor struct{}
+
 // SourceFall is a source that is used if the player fell.
 type SourceFall struct{}
 
 // SourceLightning is used for damage caused by being struck by lightning.
 type SourceLightning struct{}
 
+// SourceFirework is used for damage caused by a firework exploding near an entity.
+type SourceFirework struct {
+	// Firework holds the firework entity that exploded.
+	Firework world.Entity
+}
+
+// SourceBlockExplosion is used for damage caused by a block exploding, such as a respawn anchor used outside
+// of the Nether.
+type SourceBlockExplosion struct {
+	// Block holds the block that exploded.
+	Block world.Block
+}
+
 // SourceCustom is a cause used for dealing any kind of custom damage. Armour reduces damage to this source,
 // but otherwise no enchantments have an additional effect.
 type SourceCustom struct{}
@@ -65,11 +89,26 @@ func (SourceLightning) ReducedByArmour() bool {
 	return true
 }
 
+// ReducedByArmour ...
+func (SourceFirework) ReducedByArmour() bool {
+	return true
+}
+
+// ReducedByArmour ...
+func (SourceBlockExplosion) ReducedByArmour() bool {
+	return true
+}
+
 // ReducedByArmour ...
 func (SourceEntityAttack) ReducedByArmour() bool {
 	return true
 }
 
+// ReducedByArmour ...
+func (SourceProjectile) ReducedByArmour() bool {
+	return true
+}
+
 // ReducedByArmour ...
 func (SourceStarvation) ReducedByArmour() bool {
 	return false
@@ -114,3 +153,8 @@ func (SourceFireTick) ReducedByArmour() bool {
 func (SourceLava) ReducedByArmour() bool {
 	return true
 }
+
+// ReducedByArmour ...
+func (SourceHotFloor) ReducedByArmour() bool {
+	return true
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"math/rand"
+	"time"
+)
+
+// BurnsInDaylight is a behaviour that may be embedded by mobs, typically undead ones, that ignite when exposed
+// to direct sky light during the day. TickDaylight should be called every tick by the mob embedding it.
+type BurnsInDaylight struct{}
+
+// TickDaylight sets the entity passed on fire if it is day, the entity is exposed to the sky and it is not
+// raining at its position or standing in a liquid. If the entity implements an Armour method returning its
+// armour inventory and is wearing a helmet, the helmet is damaged instead. Entities that do not implement
+// Flammable, or that are fire proof, are never ignited. Nothing happens if daylight burning is disabled in the
+// world of the entity.
+func (BurnsInDaylight) TickDaylight(e world.Entity) {
+	f, ok := e.(Flammable)
+	if !ok || f.FireProof() {
+		return
+	}
+	w := e.World()
+	if !w.DaylightBurning() || !w.Daytime() {
+		return
+	}
+	pos := cube.PosFromVec3(e.Position())
+	if !w.CanSeeSky(pos) || w.RainingAt(pos) {
+		return
+	}
+	if _, ok := w.Liquid(pos); ok {
+		return
+	}
+
+	if a, ok := e.(interface{ Armour() *inventory.Armour }); ok {
+		if helmet := a.Armour().Helmet(); !helmet.Empty() {
+			// The helmet absorbs the sun light at the cost of its durability.
+			helmet = helmet.Damage(rand.Intn(2))
+			if helmet.Empty() {
+				w.PlaySound(e.Position(), sound.ItemBreak{})
+			}
+			a.Armour().SetHelmet(helmet)
+			return
+		}
+	}
+	if f.OnFireDuration() < time.Second {
+		f.SetOnFire(time.Second * 8)
+	}
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"go.uber.org/atomic"
+	"math/rand"
+)
+
+const (
+	// despawnDistance is the distance from the closest player beyond which entities despawn immediately.
+	despawnDistance = 128
+	// randomDespawnDistance is the distance from the closest player beyond which entities have a chance to
+	// despawn every tick.
+	randomDespawnDistance = 32
+	// randomDespawnChance is the chance, one in randomDespawnChance, that an entity further than
+	// randomDespawnDistance away from the closest player despawns in a tick.
+	randomDespawnChance = 800
+)
+
+// DespawnComputer is a component that may be embedded by entities that despawn when no player is close to
+// them, such as most mobs. Entities embedding it implement world.DespawnableEntity, so that the world checks
+// every tick if they should despawn.
+// Entities that have been name-tagged, tamed or bred should be marked persistent using SetPersistent, after
+// which they never despawn.
+type DespawnComputer struct {
+	persistent atomic.Bool
+}
+
+// Persistent returns whether the entity is persistent, meaning it never despawns.
+func (d *DespawnComputer) Persistent() bool {
+	return d.persistent.Load()
+}
+
+// SetPersistent sets whether the entity is persistent. Persistent entities never despawn, regardless of their
+// distance to the closest player.
+func (d *DespawnComputer) SetPersistent(v bool) {
+	d.persistent.Store(v)
+}
+
+// Despawns checks if the entity should despawn, given the distance to the closest player in its world. If no
+// player is in the world, the distance passed is +Inf. Non-persistent entities further than 128 blocks away
+// from the closest player always despawn, while those further than 32 blocks away despawn randomly over time.
+func (d *DespawnComputer) Despawns(dist float64) bool {
+	if d.persistent.Load() || dist <= randomDespawnDistance {
+		return false
+	}
+	return dist > despawnDistance || rand.Intn(randomDespawnChance) == 0
+}
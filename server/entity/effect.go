@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // EffectManager manages the effects of an entity. The effect manager will only store effects that last for
@@ -61,8 +62,9 @@ func (m *EffectManager) Add(e effect.Effect, entity Living) effect.Effect {
 	return e
 }
 
-// Remove removes any Effect present in the EffectManager with the type of the effect passed.
-func (m *EffectManager) Remove(e effect.Type, entity Living) {
+// Remove removes any Effect present in the EffectManager with the type of the effect passed. The effect removed
+// is returned, along with a bool that is false if no effect with the type passed was present.
+func (m *EffectManager) Remove(e effect.Type, entity Living) (effect.Effect, bool) {
 	t := reflect.TypeOf(e)
 
 	m.mu.Lock()
@@ -73,6 +75,46 @@ func (m *EffectManager) Remove(e effect.Type, entity Living) {
 	if ok {
 		existing.Type().(effect.LastingType).End(entity, existing.Level())
 	}
+	return existing, ok
+}
+
+// SetDuration changes the leftover duration of the effect with the type passed to the duration passed, without
+// ending and restarting the effect. The updated effect is returned, along with a bool that is false if no effect
+// with the type passed was present. SetDuration panics if the duration passed is negative.
+func (m *EffectManager) SetDuration(e effect.Type, d time.Duration) (effect.Effect, bool) {
+	if d < 0 {
+		panic(fmt.Sprintf("(*EffectManager).SetDuration: effect cannot have negative duration: %v", d))
+	}
+	return m.update(e, func(existing effect.Effect) time.Duration {
+		return d
+	})
+}
+
+// Extend extends the leftover duration of the effect with the type passed by the duration passed, without ending
+// and restarting the effect. A negative duration may be passed to shorten the effect. The updated effect is
+// returned, along with a bool that is false if no effect with the type passed was present.
+func (m *EffectManager) Extend(e effect.Type, d time.Duration) (effect.Effect, bool) {
+	return m.update(e, func(existing effect.Effect) time.Duration {
+		if dur := existing.Duration() + d; dur > 0 {
+			return dur
+		}
+		return 0
+	})
+}
+
+// update changes the duration of the effect with the type passed to the duration returned by f.
+func (m *EffectManager) update(e effect.Type, f func(existing effect.Effect) time.Duration) (effect.Effect, bool) {
+	t := reflect.TypeOf(e)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, ok := m.effects[t]
+	if !ok {
+		return existing, false
+	}
+	existing = existing.WithDuration(f(existing))
+	m.effects[t] = existing
+	return existing, true
 }
 
 // Effect returns the effect instance and true if the entity has the effect. If not found, it will return an empty
@@ -98,8 +140,8 @@ func (m *EffectManager) Effects() []effect.Effect {
 }
 
 // Tick ticks the EffectManager, applying all of its effects to the Living entity passed when applicable and
-// removing expired effects.
-func (m *EffectManager) Tick(entity Living) {
+// removing expired effects. The effects that expired are returned.
+func (m *EffectManager) Tick(entity Living) []effect.Effect {
 	m.mu.Lock()
 	e := make([]effect.Effect, 0, len(m.effects))
 	var toEnd []effect.Effect
@@ -122,6 +164,7 @@ func (m *EffectManager) Tick(entity Living) {
 	for _, eff := range toEnd {
 		eff.Type().(effect.LastingType).End(entity, eff.Level())
 	}
+	return toEnd
 }
 
 // expired checks if an Effect has expired.
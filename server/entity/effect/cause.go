@@ -0,0 +1,25 @@
+package effect
+
+// CauseExpired is a removal cause used when an effect wears off, for example because its duration ran out.
+type CauseExpired struct{}
+
+// CauseMilk is a removal cause used when an effect is removed because the entity drank milk.
+type CauseMilk struct{}
+
+// CauseDeath is a removal cause used when an effect is removed because the entity died.
+type CauseDeath struct{}
+
+// CausePlugin is a removal cause used when an effect is removed by a plugin, for example by calling
+// RemoveEffect directly.
+type CausePlugin struct{}
+
+// RemovalCause represents the cause of an effect being removed from an entity. It is passed to handlers that
+// handle the removal of effects.
+type RemovalCause interface {
+	__()
+}
+
+func (CauseExpired) __() {}
+func (CauseMilk) __()    {}
+func (CauseDeath) __()   {}
+func (CausePlugin) __()  {}
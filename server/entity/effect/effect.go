@@ -87,6 +87,15 @@ func (e Effect) Duration() time.Duration {
 	return e.d
 }
 
+// WithDuration returns the same Effect with its leftover duration changed to the duration passed. The duration of
+// instant effects is not changed.
+func (e Effect) WithDuration(d time.Duration) Effect {
+	if _, ok := e.t.(LastingType); ok {
+		e.d = d
+	}
+	return e
+}
+
 // Ambient returns whether the Effect is an ambient effect, leading to reduced particles shown to the client. False is
 // always returned if the Effect was created using New or NewInstant.
 func (e Effect) Ambient() bool {
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// EffectEmitter is a world.Emitter that adds effects to all eligible entities within an area at a fixed
+// interval. An EffectEmitter may be added to a world using world.World.AddEmitter and is removed again using
+// world.World.RemoveEmitter, after which no more effects are added.
+type EffectEmitter struct {
+	// Area is the area that entities must be in to have the effects added.
+	Area physics.AABB
+	// Interval is the time between two emissions of the effects.
+	Interval time.Duration
+	// Eligible is called for every entity in the Area to check if it should have the effects added. If nil,
+	// all entities in the Area that are able to have effects are eligible.
+	Eligible func(e world.Entity) bool
+	// Effects returns the effects that are added to eligible entities. It is called once for every emission.
+	Effects func() []effect.Effect
+}
+
+// EmitArea ...
+func (em *EffectEmitter) EmitArea(*world.World) (physics.AABB, bool) {
+	return em.Area, true
+}
+
+// EmitInterval ...
+func (em *EffectEmitter) EmitInterval() time.Duration {
+	return em.Interval
+}
+
+// Emit ...
+func (em *EffectEmitter) Emit(_ *world.World, entities []world.Entity) {
+	AddEffects(entities, em.Eligible, em.Effects()...)
+}
+
+// AddEffects adds the effects passed to all entities that are able to have effects added and for which eligible
+// returns true. If eligible is nil, all entities able to have effects added are eligible.
+func AddEffects(entities []world.Entity, eligible func(e world.Entity) bool, effects ...effect.Effect) {
+	if len(effects) == 0 {
+		return
+	}
+	for _, e := range entities {
+		a, ok := e.(interface{ AddEffect(e effect.Effect) })
+		if !ok || (eligible != nil && !eligible(e)) {
+			continue
+		}
+		for _, eff := range effects {
+			a.AddEffect(eff)
+		}
+	}
+}
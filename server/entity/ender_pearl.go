@@ -29,7 +29,7 @@ func NewEnderPearl(pos mgl64.Vec3, yaw, pitch float64, owner world.Entity) *Ende
 	e := &EnderPearl{
 		yaw:   yaw,
 		pitch: pitch,
-		c: &ProjectileComputer{&MovementComputer{
+		c: &ProjectileComputer{MovementComputer: &MovementComputer{
 			Gravity:           0.03,
 			Drag:              0.01,
 			DragBeforeGravity: true,
@@ -56,6 +56,16 @@ func (e *EnderPearl) AABB() physics.AABB {
 	return physics.NewAABB(mgl64.Vec3{-0.125, 0, -0.125}, mgl64.Vec3{0.125, 0.25, 0.125})
 }
 
+// MovementConfig ...
+func (e *EnderPearl) MovementConfig() MovementConfig {
+	return e.c.Config()
+}
+
+// SetMovementConfig ...
+func (e *EnderPearl) SetMovementConfig(conf MovementConfig) {
+	e.c.SetConfig(conf)
+}
+
 // Rotation ...
 func (e *EnderPearl) Rotation() (float64, float64) {
 	e.mu.Lock()
@@ -77,6 +87,7 @@ func (e *EnderPearl) Tick(current int64) {
 		return
 	}
 	e.mu.Lock()
+	vel := e.vel
 	m, result := e.c.TickMovement(e, e.pos, e.vel, e.yaw, e.pitch, e.ignores)
 	e.pos, e.vel, e.yaw, e.pitch = m.pos, m.vel, m.yaw, m.pitch
 	e.mu.Unlock()
@@ -84,36 +95,34 @@ func (e *EnderPearl) Tick(current int64) {
 	e.age++
 	m.Send()
 
-	w := e.World()
-	if m.pos[1] < float64(w.Range()[0]) && current%10 == 0 {
-		e.close = true
-		return
-	}
+	e.close = e.c.resolveImpact(e, m, result, vel, current)
+}
 
-	if result != nil {
-		if r, ok := result.(trace.EntityResult); ok {
-			if l, ok := r.Entity().(Living); ok {
-				if _, vulnerable := l.Hurt(0.0, damage.SourceEntityAttack{Attacker: e}); vulnerable {
-					l.KnockBack(m.pos, 0.45, 0.3608)
-				}
+// hit knocks back the entity hit, if any, and teleports the owner of the ender pearl to the position of the
+// impact.
+func (e *EnderPearl) hit(r trace.Result, _ mgl64.Vec3) bool {
+	w, pos := e.World(), r.Position()
+	if r, ok := r.(trace.EntityResult); ok {
+		if l, ok := r.Entity().(Living); ok {
+			if _, vulnerable := l.Hurt(0.0, damage.SourceEntityAttack{Attacker: e}); vulnerable {
+				l.KnockBack(pos, 0.45, 0.3608)
 			}
 		}
+	}
 
-		if owner := e.Owner(); owner != nil {
-			if user, ok := owner.(teleporter); ok {
-				w.PlaySound(user.Position(), sound.EndermanTeleport{})
+	if owner := e.Owner(); owner != nil {
+		if user, ok := owner.(teleporter); ok {
+			w.PlaySound(user.Position(), sound.EndermanTeleport{})
 
-				user.Teleport(m.pos)
+			user.Teleport(pos)
 
-				w.AddParticle(m.pos, particle.EndermanTeleportParticle{})
-				w.PlaySound(m.pos, sound.EndermanTeleport{})
+			w.AddParticle(pos, particle.EndermanTeleportParticle{})
+			w.PlaySound(pos, sound.EndermanTeleport{})
 
-				user.Hurt(5, damage.SourceFall{})
-			}
+			user.Hurt(5, damage.SourceFall{})
 		}
-
-		e.close = true
 	}
+	return true
 }
 
 // ignores returns whether the ender pearl should ignore collision with the entity passed.
@@ -0,0 +1,27 @@
+package experience
+
+// SourceSmelting is an experience source used for experience gained from items smelted in a furnace, either when
+// the smelted items are taken out of the furnace or when the furnace is broken.
+type SourceSmelting struct{}
+
+// SourceTrading is an experience source used for experience gained from executing a trade.
+type SourceTrading struct{}
+
+// SourceGrindstone is an experience source used for experience refunded for the enchantments removed from items
+// using a grindstone.
+type SourceGrindstone struct{}
+
+// SourceCustom is an experience source that may be used by users to represent a custom experience source, such
+// as experience given using a command.
+type SourceCustom struct{}
+
+// Source represents a source of experience gained by an entity. This source may be passed to the AddExperience
+// method of a player.
+type Source interface {
+	__()
+}
+
+func (SourceSmelting) __()   {}
+func (SourceTrading) __()    {}
+func (SourceGrindstone) __() {}
+func (SourceCustom) __()     {}
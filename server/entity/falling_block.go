@@ -45,6 +45,16 @@ func (f *FallingBlock) AABB() physics.AABB {
 	return physics.NewAABB(mgl64.Vec3{-0.49, 0, -0.49}, mgl64.Vec3{0.49, 0.98, 0.49})
 }
 
+// MovementConfig ...
+func (f *FallingBlock) MovementConfig() MovementConfig {
+	return f.c.Config()
+}
+
+// SetMovementConfig ...
+func (f *FallingBlock) SetMovementConfig(conf MovementConfig) {
+	f.c.SetConfig(conf)
+}
+
 // Block ...
 func (f *FallingBlock) Block() world.Block {
 	return f.block
@@ -0,0 +1,213 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/action"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/entity/physics/trace"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// Firework is an entity launched by a firework item. It flies for a duration based on the flight duration of
+// the item, after which it explodes with the explosions of the item.
+type Firework struct {
+	transform
+	yaw, pitch float64
+
+	firework    item.Firework
+	directional bool
+	age, ticks  int
+	close       bool
+
+	owner world.Entity
+
+	c *ProjectileComputer
+}
+
+// NewFirework creates a new Firework entity at the position passed. The firework flies upwards and explodes
+// after the duration set in the firework item passed.
+func NewFirework(pos mgl64.Vec3, yaw, pitch float64, firework item.Firework, owner world.Entity) *Firework {
+	f := &Firework{
+		yaw:      yaw,
+		pitch:    pitch,
+		firework: firework,
+		ticks:    int(firework.RandomisedDuration() / (time.Second / 20)),
+		owner:    owner,
+		c:        &ProjectileComputer{MovementComputer: &MovementComputer{}},
+	}
+	f.transform = newTransform(f, pos)
+	f.vel = mgl64.Vec3{0, 0.05}
+	return f
+}
+
+// Name ...
+func (f *Firework) Name() string {
+	return "Firework Rocket"
+}
+
+// EncodeEntity ...
+func (f *Firework) EncodeEntity() string {
+	return "minecraft:fireworks_rocket"
+}
+
+// AABB ...
+func (f *Firework) AABB() physics.AABB {
+	return physics.NewAABB(mgl64.Vec3{-0.125, 0, -0.125}, mgl64.Vec3{0.125, 0.25, 0.125})
+}
+
+// MovementConfig ...
+func (f *Firework) MovementConfig() MovementConfig {
+	return f.c.Config()
+}
+
+// SetMovementConfig ...
+func (f *Firework) SetMovementConfig(conf MovementConfig) {
+	f.c.SetConfig(conf)
+}
+
+// Rotation ...
+func (f *Firework) Rotation() (float64, float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.yaw, f.pitch
+}
+
+// Firework returns the firework item that the entity was launched with.
+func (f *Firework) Firework() item.Firework {
+	return f.firework
+}
+
+// Tick ...
+func (f *Firework) Tick(current int64) {
+	if f.close {
+		_ = f.Close()
+		return
+	}
+	f.mu.Lock()
+	vel := f.vel
+	if !f.directional {
+		// Fireworks that aren't shot in a specific direction accelerate upwards and amplify their horizontal
+		// drift every tick.
+		f.vel[0] *= 1.15
+		f.vel[1] += 0.04
+		f.vel[2] *= 1.15
+	}
+	m, result := f.c.TickMovement(f, f.pos, f.vel, f.yaw, f.pitch, f.ignores)
+	f.pos, f.vel, f.yaw, f.pitch = m.pos, m.vel, m.yaw, m.pitch
+	f.mu.Unlock()
+
+	f.age++
+	m.Send()
+
+	if f.close = f.c.resolveImpact(f, m, result, vel, current); !f.close && f.age >= f.ticks {
+		f.explode()
+		f.close = true
+	}
+}
+
+// hit makes the firework explode when it hits an entity or a block.
+func (f *Firework) hit(trace.Result, mgl64.Vec3) bool {
+	f.explode()
+	return true
+}
+
+// explode makes the firework explode, showing the explosion to viewers and damaging entities close to the
+// firework if it has any explosions.
+func (f *Firework) explode() {
+	w, pos := f.World(), f.Position()
+	for _, v := range w.Viewers(pos) {
+		v.ViewEntityAction(f, action.FireworkExplosion{})
+	}
+	explosions := f.firework.Explosions
+	if len(explosions) == 0 {
+		return
+	}
+
+	large, twinkle := false, false
+	for _, explosion := range explosions {
+		large = large || explosion.Shape == item.FireworkShapeHugeSphere()
+		twinkle = twinkle || explosion.Twinkle
+	}
+	w.PlaySound(pos, sound.FireworkBlast{Large: large})
+	if twinkle {
+		w.PlaySound(pos, sound.FireworkTwinkle{})
+	}
+
+	force := float64(len(explosions)*2) + 5
+	for _, e := range w.EntitiesWithin(f.AABB().Translate(pos).Grow(5.25), f.ignores) {
+		dist := world.Distance(e.Position(), pos)
+		if dist > 5 {
+			continue
+		}
+		if l, ok := e.(Living); ok {
+			l.Hurt(force*math.Sqrt((5-dist)/5), damage.SourceFirework{Firework: f})
+		}
+	}
+}
+
+// ignores returns whether the Firework should ignore collision with the entity passed.
+func (f *Firework) ignores(entity world.Entity) bool {
+	_, ok := entity.(Living)
+	return !ok || entity == f || (f.age < 5 && entity == f.owner)
+}
+
+// New creates a Firework with the position, velocity, yaw and pitch provided. If directional is true, the
+// firework flies in the direction of its velocity without accelerating upwards, such as when it is shot from
+// a crossbow. It doesn't spawn the Firework, only returns it.
+func (f *Firework) New(pos, vel mgl64.Vec3, yaw, pitch float64, firework item.Firework, owner world.Entity, directional bool) world.Entity {
+	fw := NewFirework(pos, yaw, pitch, firework, owner)
+	fw.vel = vel
+	fw.directional = directional
+	return fw
+}
+
+// Owner ...
+func (f *Firework) Owner() world.Entity {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.owner
+}
+
+// Own ...
+func (f *Firework) Own(owner world.Entity) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.owner = owner
+}
+
+// DecodeNBT decodes the properties in a map to a Firework and returns a new Firework entity.
+func (f *Firework) DecodeNBT(data map[string]interface{}) interface{} {
+	fw, _ := nbtconv.MapItem(data, "Item").Item().(item.Firework)
+	return f.New(
+		nbtconv.MapVec3(data, "Pos"),
+		nbtconv.MapVec3(data, "Motion"),
+		float64(nbtconv.MapFloat32(data, "Yaw")),
+		float64(nbtconv.MapFloat32(data, "Pitch")),
+		fw,
+		nil,
+		nbtconv.MapByte(data, "Directional") == 1,
+	)
+}
+
+// EncodeNBT encodes the Firework entity's properties as a map and returns it.
+func (f *Firework) EncodeNBT() map[string]interface{} {
+	yaw, pitch := f.Rotation()
+	directional := uint8(0)
+	if f.directional {
+		directional = 1
+	}
+	return map[string]interface{}{
+		"Pos":         nbtconv.Vec3ToFloat32Slice(f.Position()),
+		"Yaw":         float32(yaw),
+		"Pitch":       float32(pitch),
+		"Motion":      nbtconv.Vec3ToFloat32Slice(f.Velocity()),
+		"Item":        nbtconv.WriteItem(item.NewStack(f.firework, 1), true),
+		"Directional": directional,
+	}
+}
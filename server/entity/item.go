@@ -2,22 +2,26 @@ package entity
 
 import (
 	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/action"
 	"github.com/df-mc/dragonfly/server/entity/physics"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 	"math"
 	"time"
+	"unsafe"
 )
 
 // Item represents an item entity which may be added to the world. Players and several humanoid entities such
 // as zombies are able to pick up these entities so that the items are added to their inventory.
 type Item struct {
 	transform
-	age, pickupDelay int
-	i                item.Stack
+	age, pickupDelay, despawnDelay int
+	i                              item.Stack
+	owner                          world.Entity
 
 	c *MovementComputer
 }
@@ -31,7 +35,7 @@ func NewItem(i item.Stack, pos mgl64.Vec3) *Item {
 	}
 	i = nbtconv.ReadItem(nbtconv.WriteItem(i, true), nil)
 
-	it := &Item{i: i, pickupDelay: 10, c: &MovementComputer{
+	it := &Item{i: i, pickupDelay: 10, despawnDelay: 6000, c: &MovementComputer{
 		Gravity:           0.04,
 		DragBeforeGravity: true,
 		Drag:              0.02,
@@ -42,7 +46,7 @@ func NewItem(i item.Stack, pos mgl64.Vec3) *Item {
 
 // Name ...
 func (it *Item) Name() string {
-	return fmt.Sprintf("%T", it.i.Item())
+	return fmt.Sprintf("%T", it.Item().Item())
 }
 
 // EncodeEntity ...
@@ -55,8 +59,22 @@ func (it *Item) AABB() physics.AABB {
 	return physics.NewAABB(mgl64.Vec3{-0.125, 0, -0.125}, mgl64.Vec3{0.125, 0.25, 0.125})
 }
 
+// MovementConfig ...
+func (it *Item) MovementConfig() MovementConfig {
+	return it.c.Config()
+}
+
+// SetMovementConfig ...
+func (it *Item) SetMovementConfig(conf MovementConfig) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.c.SetConfig(conf)
+}
+
 // Item returns the item stack that the item entity holds.
 func (it *Item) Item() item.Stack {
+	it.mu.Lock()
+	defer it.mu.Unlock()
 	return it.i
 }
 
@@ -67,14 +85,88 @@ func (it *Item) SetPickupDelay(d time.Duration) {
 	if ticks < 0 || ticks >= math.MaxInt16 {
 		ticks = math.MaxInt16
 	}
+	it.mu.Lock()
+	defer it.mu.Unlock()
 	it.pickupDelay = ticks
 }
 
+// Absorb removes up to n items from the item entity and returns them, as if they were collected by a block such
+// as a hopper. Nothing is returned if the pickup delay of the item entity has not yet passed or if it has an
+// owner that may still pick it up. If all items are removed, the item entity is closed.
+func (it *Item) Absorb(n int) item.Stack {
+	if owner, ok := it.Owner(); ok && owner.World() != nil {
+		return item.Stack{}
+	}
+	it.mu.Lock()
+	if it.pickupDelay != 0 || it.i.Empty() {
+		it.mu.Unlock()
+		return item.Stack{}
+	}
+	if n >= it.i.Count() {
+		s := it.i
+		it.i = item.Stack{}
+		it.mu.Unlock()
+		_ = it.Close()
+		return s
+	}
+	s := it.i.Grow(n - it.i.Count())
+	it.i = it.i.Grow(-n)
+	count := it.i.Count()
+	it.mu.Unlock()
+
+	it.viewStackSize(count)
+	return s
+}
+
+// SetDespawnDelay sets the time after which the item entity despawns, counted from the moment it was created. By
+// default, item entities despawn after 5 minutes. If d is negative, the item entity will never despawn.
+func (it *Item) SetDespawnDelay(d time.Duration) {
+	ticks := int(d.Seconds() * 20)
+	if ticks < 0 {
+		ticks = -1
+	}
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.despawnDelay = ticks
+}
+
+// SetGravityFactor changes the gravity of the item entity to the default gravity multiplied by f. A factor of 0
+// makes the item entity float, while a negative factor makes it rise.
+func (it *Item) SetGravityFactor(f float64) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	conf := it.c.Config()
+	conf.Gravity = 0.04 * f
+	it.c.SetConfig(conf)
+}
+
+// SetOwner sets the owner of the item entity. If not nil, only the owner is able to pick up the item entity, as
+// long as the owner is still in a world. Passing nil allows any collector to pick it up again.
+func (it *Item) SetOwner(owner world.Entity) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.owner = owner
+}
+
+// Owner returns the owner of the item entity as set using SetOwner. If the item entity has no owner, false is
+// returned.
+func (it *Item) Owner() (world.Entity, bool) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.owner, it.owner != nil
+}
+
 // Tick ticks the entity, performing movement.
 func (it *Item) Tick(current int64) {
 	it.mu.Lock()
 	m := it.c.TickMovement(it, it.pos, it.vel, 0, 0)
 	it.pos, it.vel = m.pos, m.vel
+	it.age++
+	i, despawn := it.i, it.despawnDelay >= 0 && it.age > it.despawnDelay
+	pickup := it.pickupDelay == 0
+	if !pickup && it.pickupDelay != math.MaxInt16 {
+		it.pickupDelay--
+	}
 	it.mu.Unlock()
 
 	m.Send()
@@ -83,18 +175,28 @@ func (it *Item) Tick(current int64) {
 		_ = it.Close()
 		return
 	}
-	if it.age++; it.age > 6000 {
+	if it.inLava(m.pos) {
+		if f, ok := i.Item().(item.FireProof); !ok || !f.FireProof() {
+			it.World().PlaySound(m.pos, sound.Fizz{})
+			_ = it.Close()
+			return
+		}
+	}
+	if despawn {
 		_ = it.Close()
 		return
 	}
-
-	if it.pickupDelay == 0 {
+	if pickup {
 		it.checkNearby(m.pos)
-	} else if it.pickupDelay != math.MaxInt16 {
-		it.pickupDelay--
 	}
 }
 
+// inLava checks if the item entity is currently inside of lava at the position passed.
+func (it *Item) inLava(pos mgl64.Vec3) bool {
+	l, ok := it.World().Liquid(cube.PosFromVec3(pos))
+	return ok && l.LiquidType() == "lava"
+}
+
 // checkNearby checks the entities of the chunks around for item collectors and other item stacks. If a
 // collector is found in range, the item will be picked up. If another item stack with the same item type is
 // found in range, the item stacks will merge.
@@ -105,50 +207,83 @@ func (it *Item) checkNearby(pos mgl64.Vec3) {
 			// Skip the item entity itself.
 			continue
 		}
-		if e.AABB().Translate(e.Position()).IntersectsWith(grown) {
-			if collector, ok := e.(Collector); ok {
-				// A collector was within range to pick up the entity.
-				it.collect(collector, pos)
-				return
-			} else if other, ok := e.(*Item); ok {
+		if other, ok := e.(*Item); ok {
+			if world.Distance(other.Position(), pos) <= 0.75 && it.merge(other) {
 				// Another item entity was in range to merge with.
-				if it.merge(other, pos) {
-					return
-				}
+				return
 			}
+		} else if collector, ok := e.(Collector); ok && it.collectableBy(collector) && e.AABB().Translate(e.Position()).IntersectsWith(grown) {
+			// A collector was within range to pick up the entity.
+			it.collect(collector, pos)
+			return
 		}
 	}
 }
 
-// merge merges the item entity with another item entity.
-func (it *Item) merge(other *Item, pos mgl64.Vec3) bool {
-	if other.i.Count() == other.i.MaxCount() || it.i.Count() == it.i.MaxCount() {
-		// Either stack is already filled up to the maximum, meaning we can't change anything any way.
+// collectableBy checks if the Collector passed may pick up the item entity. This is the case if the item entity
+// has no owner, if the owner is no longer in a world or if the collector is the owner.
+func (it *Item) collectableBy(collector Collector) bool {
+	owner, ok := it.Owner()
+	return !ok || owner == collector || owner.World() == nil
+}
+
+// merge merges the item entity into another item entity. The other item entity keeps the earliest despawn
+// time of the two. If not all items fit into the other item entity, the remaining items stay in this one.
+func (it *Item) merge(other *Item) bool {
+	owner, _ := it.Owner()
+	if otherOwner, _ := other.Owner(); owner != otherOwner {
+		// Items of different owners can't be merged, as only the owner may pick up an owned item.
 		return false
 	}
-	if !it.i.Comparable(other.i) {
+
+	// The locks of both item entities are acquired in the order of their addresses, so that two item entities
+	// merging into each other at the same time don't deadlock.
+	first, second := it, other
+	if uintptr(unsafe.Pointer(first)) > uintptr(unsafe.Pointer(second)) {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	second.mu.Lock()
+	if other.pickupDelay != 0 || it.i.Empty() || other.i.Empty() {
+		// The other item entity can't be merged with until its pickup delay has elapsed.
+		other.mu.Unlock()
+		it.mu.Unlock()
+		return false
+	}
+	if other.i.Count() == other.i.MaxCount() || it.i.Count() == it.i.MaxCount() || !it.i.Comparable(other.i) {
+		// Either stack is already filled up to the maximum, meaning we can't change anything any way.
+		other.mu.Unlock()
+		it.mu.Unlock()
 		return false
 	}
-
 	a, b := other.i.AddStack(it.i)
+	other.i, it.i = a, b
+	if it.despawnDelay >= 0 && (other.despawnDelay < 0 || it.despawnDelay-it.age < other.despawnDelay-other.age) {
+		other.age, other.despawnDelay = it.age, it.despawnDelay
+	}
+	other.mu.Unlock()
+	it.mu.Unlock()
 
-	newA := NewItem(a, other.Position())
-	newA.SetVelocity(other.Velocity())
-	it.World().AddEntity(newA)
-
+	other.viewStackSize(a.Count())
 	if !b.Empty() {
-		newB := NewItem(b, pos)
-		newB.SetVelocity(it.vel)
-		it.World().AddEntity(newB)
+		it.viewStackSize(b.Count())
+		return true
 	}
 	_ = it.Close()
-	_ = other.Close()
 	return true
 }
 
+// viewStackSize shows the stack size passed as the current stack size of the item entity to its viewers.
+func (it *Item) viewStackSize(count int) {
+	for _, viewer := range it.World().Viewers(it.Position()) {
+		viewer.ViewEntityAction(it, action.StackSizeUpdate{Count: count})
+	}
+}
+
 // collect makes a collector collect the item (or at least part of it).
 func (it *Item) collect(collector Collector, pos mgl64.Vec3) {
-	n := collector.Collect(it.i)
+	i := it.Item()
+	n := collector.Collect(i)
 	if n == 0 {
 		return
 	}
@@ -156,13 +291,13 @@ func (it *Item) collect(collector Collector, pos mgl64.Vec3) {
 		viewer.ViewEntityAction(it, action.PickedUp{Collector: collector})
 	}
 
-	if n == it.i.Count() {
+	if n == i.Count() {
 		// The collector picked up the entire stack.
 		_ = it.Close()
 		return
 	}
 	// Create a new item entity and shrink it by the amount of items that the collector collected.
-	it.World().AddEntity(NewItem(it.i.Grow(-n), pos))
+	it.World().AddEntity(NewItem(i.Grow(-n), pos))
 
 	_ = it.Close()
 }
@@ -177,18 +312,40 @@ func (it *Item) DecodeNBT(data map[string]interface{}) interface{} {
 	n.SetVelocity(nbtconv.MapVec3(data, "Motion"))
 	n.age = int(nbtconv.MapInt16(data, "Age"))
 	n.pickupDelay = int(nbtconv.MapInt64(data, "PickupDelay"))
+	if remaining, ok := data["DespawnDelay"].(int32); ok {
+		// The DespawnDelay tag holds the time left until the item entity despawns, so that the delay does not
+		// depend on the Age tag, which is capped.
+		n.despawnDelay = -1
+		if remaining >= 0 {
+			n.despawnDelay = n.age + int(remaining)
+		}
+	}
 	return n
 }
 
 // EncodeNBT encodes the Item entity's properties as a map and returns it.
 func (it *Item) EncodeNBT() map[string]interface{} {
+	it.mu.Lock()
+	age, pickupDelay, remaining := it.age, it.pickupDelay, it.despawnDelay
+	it.mu.Unlock()
+	if remaining >= 0 {
+		remaining -= age
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+	if age > math.MaxInt16 {
+		// Item entities with a long despawn delay may outlive the maximum value of the Age tag.
+		age = math.MaxInt16
+	}
 	return map[string]interface{}{
-		"Age":         int16(it.age),
-		"PickupDelay": int64(it.pickupDelay),
-		"Pos":         nbtconv.Vec3ToFloat32Slice(it.Position()),
-		"Motion":      nbtconv.Vec3ToFloat32Slice(it.Velocity()),
-		"Health":      int16(5),
-		"Item":        nbtconv.WriteItem(it.Item(), true),
+		"Age":          int16(age),
+		"PickupDelay":  int64(pickupDelay),
+		"DespawnDelay": int32(remaining),
+		"Pos":          nbtconv.Vec3ToFloat32Slice(it.Position()),
+		"Motion":       nbtconv.Vec3ToFloat32Slice(it.Velocity()),
+		"Health":       int16(5),
+		"Item":         nbtconv.WriteItem(it.Item(), true),
 	}
 }
 
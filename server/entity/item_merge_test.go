@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestItemMergeConcurrent checks that two item entities merging into each other at the same time neither
+// deadlock nor lose or duplicate items.
+func TestItemMergeConcurrent(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		a := NewItem(item.NewStack(item.Diamond{}, 20), mgl64.Vec3{})
+		b := NewItem(item.NewStack(item.Diamond{}, 50), mgl64.Vec3{})
+		a.pickupDelay, b.pickupDelay = 0, 0
+
+		// Both merges wait for start to be closed, so that they are as likely as possible to run at the same time.
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+			a.merge(b)
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			b.merge(a)
+		}()
+		close(start)
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("item entities deadlocked merging into each other")
+		}
+		if count := a.Item().Count() + b.Item().Count(); count != 70 {
+			t.Fatalf("item entities hold %v items after merging, want 70", count)
+		}
+	}
+}
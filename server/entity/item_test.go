@@ -0,0 +1,120 @@
+package entity_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"testing"
+	"time"
+)
+
+// dropItems adds n item entities holding a single dirt item each at the same position in the world passed,
+// resting on a stone floor.
+func dropItems(w *servertest.World, n int) {
+	w.Fill(cube.Pos{-2, 9, -2}, cube.Pos{2, 9, 2}, block.Stone{})
+	for i := 0; i < n; i++ {
+		w.AddEntity(entity.NewItem(item.NewStack(block.Dirt{}, 1), mgl64.Vec3{0.5, 10, 0.5}))
+	}
+}
+
+// itemEntities returns all item entities in the world passed and the total count of the stacks they hold.
+func itemEntities(w *servertest.World) (items []*entity.Item, count int) {
+	for _, e := range w.Entities() {
+		if it, ok := e.(*entity.Item); ok {
+			items, count = append(items, it), count+it.Item().Count()
+		}
+	}
+	return items, count
+}
+
+// TestItemMerge checks that overlapping item entities merge into as few full stacks as possible once their
+// pickup delay has passed, without losing or duplicating items.
+func TestItemMerge(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	dropItems(w, 200)
+	w.Advance(40)
+
+	items, count := itemEntities(w)
+	if count != 200 {
+		t.Errorf("item entities hold %v items after merging, want 200", count)
+	}
+	if len(items) != 4 {
+		t.Errorf("%v item entities left after merging, want 4", len(items))
+	}
+}
+
+// BenchmarkItemMerge measures merging 200 overlapping item entities until no more merges are possible.
+func BenchmarkItemMerge(b *testing.B) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for _, it := range w.Entities() {
+			_ = it.Close()
+		}
+		dropItems(w, 200)
+		// Wait for the pickup delay of the item entities to pass.
+		w.Advance(10)
+		b.StartTimer()
+
+		for items, _ := itemEntities(w); len(items) > 4; items, _ = itemEntities(w) {
+			w.Advance(1)
+		}
+	}
+}
+
+// TestItemGravityFactor checks that item entities with a gravity factor of 0 float, while those with the
+// default factor of 1 fall.
+func TestItemGravityFactor(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	floating := entity.NewItem(item.NewStack(block.Dirt{}, 1), mgl64.Vec3{0.5, 20, 0.5})
+	floating.SetGravityFactor(0)
+	falling := entity.NewItem(item.NewStack(block.Dirt{}, 1), mgl64.Vec3{2.5, 20, 0.5})
+	falling.SetGravityFactor(1)
+	w.AddEntity(floating)
+	w.AddEntity(falling)
+	w.Advance(20)
+
+	if y := floating.Position()[1]; y != 20 {
+		t.Errorf("item entity with gravity factor 0 moved to Y %v, want 20", y)
+	}
+	if y := falling.Position()[1]; y >= 20 {
+		t.Errorf("item entity with gravity factor 1 did not fall, Y is %v", y)
+	}
+}
+
+// TestItemDespawnDelayNBT checks that the time left until an item entity with a long despawn delay despawns is
+// kept when it is saved, even once it has outlived the maximum value of the Age tag.
+func TestItemDespawnDelayNBT(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-2, 9, -2}, cube.Pos{2, 9, 2}, block.Stone{})
+
+	it := entity.NewItem(item.NewStack(block.Dirt{}, 1), mgl64.Vec3{0.5, 10, 0.5})
+	it.SetDespawnDelay(time.Hour)
+	data := it.EncodeNBT()
+	data["Age"], data["DespawnDelay"] = int16(math.MaxInt16), int32(40000)
+	loaded := it.DecodeNBT(data).(*entity.Item)
+	w.AddEntity(loaded)
+	w.Advance(1)
+
+	data = loaded.EncodeNBT()
+	if age := data["Age"].(int16); age != math.MaxInt16 {
+		t.Errorf("item entity saved with age %v, want %v", age, math.MaxInt16)
+	}
+	if remaining := data["DespawnDelay"].(int32); remaining != 39999 {
+		t.Errorf("item entity saved with %v ticks left until despawning, want 39999", remaining)
+	}
+	if remaining := it.DecodeNBT(data).(*entity.Item).EncodeNBT()["DespawnDelay"].(int32); remaining != 39999 {
+		t.Errorf("reloaded item entity has %v ticks left until despawning, want 39999", remaining)
+	}
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+)
+
+// Leashable represents an entity that may be leashed to a holder, such as a player or a LeashKnot, by using a
+// lead on it. Entities may implement the LeashHolder and SetLeashHolder methods by embedding a *LeashState.
+// Entities implementing Leashable should call TickLeash every tick, so that they are pulled towards their
+// holder and break free if they get too far away from it.
+type Leashable interface {
+	world.Entity
+	// LeashHolder returns the entity that the Leashable is currently leashed to. If the entity is not leashed,
+	// false is returned.
+	LeashHolder() (world.Entity, bool)
+	// SetLeashHolder leashes the entity to the holder passed and updates the entity for its viewers, so that
+	// the rope between the two is rendered. If nil is passed, the entity is unleashed.
+	SetLeashHolder(holder world.Entity)
+	// Velocity returns the current velocity of the entity.
+	Velocity() mgl64.Vec3
+	// SetVelocity sets the velocity of the entity.
+	SetVelocity(v mgl64.Vec3)
+}
+
+// LeashState holds the leash holder of an entity. It implements the LeashHolder and SetLeashHolder methods of
+// Leashable and may be embedded by entities to implement them.
+type LeashState struct {
+	e world.Entity
+
+	mu     sync.Mutex
+	holder world.Entity
+}
+
+// NewLeashState returns a new LeashState for the entity passed. The entity is not leashed initially.
+func NewLeashState(e world.Entity) *LeashState {
+	return &LeashState{e: e}
+}
+
+// LeashHolder returns the entity that the entity is currently leashed to. If the entity is not leashed, false
+// is returned.
+func (l *LeashState) LeashHolder() (world.Entity, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.holder, l.holder != nil
+}
+
+// SetLeashHolder leashes the entity to the holder passed and updates the entity for its viewers. If nil is
+// passed, the entity is unleashed.
+func (l *LeashState) SetLeashHolder(holder world.Entity) {
+	l.mu.Lock()
+	l.holder = holder
+	l.mu.Unlock()
+
+	if w, ok := world.OfEntity(l.e); ok {
+		for _, v := range w.Viewers(l.e.Position()) {
+			v.ViewEntityState(l.e)
+		}
+	}
+}
+
+const (
+	// leashPullDistance is the distance from which a Leashable entity is pulled towards its holder.
+	leashPullDistance = 6
+	// leashBreakDistance is the distance from which a Leashable entity breaks free from its holder.
+	leashBreakDistance = 10
+)
+
+// Unleash removes the leash from the Leashable passed, if it was leashed at all. The lead is dropped at the
+// position of the entity and the leash snapping sound is played.
+func Unleash(e Leashable) {
+	if _, ok := e.LeashHolder(); !ok {
+		return
+	}
+	e.SetLeashHolder(nil)
+
+	w, pos := e.World(), e.Position()
+	w.PlaySound(pos, sound.LeashKnotBreak{})
+	w.AddEntity(NewItem(item.NewStack(item.Lead{}, 1), pos))
+}
+
+// LeashedTo returns all Leashable entities in the world of the holder that are currently leashed to it.
+func LeashedTo(holder world.Entity) []Leashable {
+	w, ok := world.OfEntity(holder)
+	if !ok {
+		return nil
+	}
+	var leashed []Leashable
+	for _, e := range w.Entities() {
+		if l, ok := e.(Leashable); ok {
+			if h, ok := l.LeashHolder(); ok && h == holder {
+				leashed = append(leashed, l)
+			}
+		}
+	}
+	return leashed
+}
+
+// UnleashAll unleashes all entities currently leashed to the holder passed, dropping a lead for each of them.
+// It is called when the holder dies or is otherwise removed.
+func UnleashAll(holder world.Entity) {
+	for _, l := range LeashedTo(holder) {
+		Unleash(l)
+	}
+}
+
+// TickLeash ticks the leash of the Leashable passed. If the entity is further than 6 blocks away from its
+// holder, it is pulled towards it. If it is further than 10 blocks away, or if the holder is no longer in the
+// same world, the leash breaks.
+func TickLeash(e Leashable) {
+	holder, ok := e.LeashHolder()
+	if !ok {
+		return
+	}
+	if w, ok := world.OfEntity(holder); !ok || w != e.World() {
+		Unleash(e)
+		return
+	}
+	diff := holder.Position().Sub(e.Position())
+	dist := diff.Len()
+	switch {
+	case dist > leashBreakDistance:
+		Unleash(e)
+	case dist > leashPullDistance:
+		e.SetVelocity(e.Velocity().Add(diff.Normalize().Mul((dist - leashPullDistance) * 0.1)))
+	}
+}
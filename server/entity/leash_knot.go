@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+)
+
+// LeashKnot is an entity created when leashed entities are tied to a fence using a lead. The entities remain
+// leashed to the knot until it is broken, either by attacking it or by breaking the fence it is attached to.
+type LeashKnot struct {
+	transform
+
+	// mu guards the lifecycle of the knot, so that it cannot be removed for having no entities leashed to it
+	// while entities are being tied to it.
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewLeashKnot creates a new LeashKnot attached to the fence at the block position passed.
+func NewLeashKnot(pos cube.Pos) *LeashKnot {
+	k := &LeashKnot{}
+	k.transform = newTransform(k, pos.Vec3Middle())
+	return k
+}
+
+// Name ...
+func (k *LeashKnot) Name() string {
+	return "Leash Knot"
+}
+
+// EncodeEntity ...
+func (k *LeashKnot) EncodeEntity() string {
+	return "minecraft:leash_knot"
+}
+
+// AABB ...
+func (k *LeashKnot) AABB() physics.AABB {
+	return physics.NewAABB(mgl64.Vec3{-0.1875, 0.25, -0.1875}, mgl64.Vec3{0.1875, 0.75, 0.1875})
+}
+
+// Immobile always returns true.
+func (k *LeashKnot) Immobile() bool {
+	return true
+}
+
+// Tick checks if the fence that the knot is attached to still exists. If not, the knot breaks. Knots that no
+// longer have any entities leashed to them are removed.
+func (k *LeashKnot) Tick(current int64) {
+	w, pos := k.World(), k.Position()
+	if _, ok := w.Block(cube.PosFromVec3(pos)).Model().(model.Fence); !ok {
+		k.Break()
+		return
+	}
+	if current%20 != 0 {
+		return
+	}
+	k.mu.Lock()
+	empty := !k.closed && len(LeashedTo(k)) == 0
+	if empty {
+		k.closed = true
+	}
+	k.mu.Unlock()
+	if empty {
+		_ = k.Close()
+	}
+}
+
+// Tie ties all entities leashed to the holder passed to the knot and returns the number of entities tied. If
+// the knot is not yet in a world, it is added to the world of the holder before any entities are tied to it.
+// Zero is returned if the knot was already removed, in which case a new knot should be created.
+func (k *LeashKnot) Tie(holder world.Entity) int {
+	w, ok := world.OfEntity(holder)
+	if !ok {
+		return 0
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		return 0
+	}
+	leashed := LeashedTo(holder)
+	if len(leashed) == 0 {
+		return 0
+	}
+	if _, ok := world.OfEntity(k); !ok {
+		w.AddEntity(k)
+	}
+	for _, l := range leashed {
+		l.SetLeashHolder(k)
+	}
+	return len(leashed)
+}
+
+// Break breaks the knot, unleashing all entities leashed to it and dropping their leads.
+func (k *LeashKnot) Break() {
+	k.mu.Lock()
+	k.closed = true
+	k.mu.Unlock()
+
+	UnleashAll(k)
+	k.World().PlaySound(k.Position(), sound.LeashKnotBreak{})
+	_ = k.Close()
+}
+
+// DecodeNBT decodes the data passed to create and return a new LeashKnot.
+func (k *LeashKnot) DecodeNBT(data map[string]interface{}) interface{} {
+	return NewLeashKnot(cube.PosFromVec3(nbtconv.MapVec3(data, "Pos")))
+}
+
+// EncodeNBT encodes the LeashKnot to a map representation that can be encoded to NBT.
+func (k *LeashKnot) EncodeNBT() map[string]interface{} {
+	return map[string]interface{}{
+		"Pos": nbtconv.Vec3ToFloat32Slice(k.Position()),
+	}
+}
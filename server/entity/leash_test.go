@@ -0,0 +1,91 @@
+package entity_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// leashed is a Leashable entity used for testing, which implements its leash using an entity.LeashState.
+type leashed struct {
+	*entity.Text
+	*entity.LeashState
+}
+
+// newLeashed returns a new leashed entity at the position passed.
+func newLeashed(pos mgl64.Vec3) *leashed {
+	l := &leashed{Text: entity.NewText("", pos)}
+	l.LeashState = entity.NewLeashState(l)
+	return l
+}
+
+// Velocity ...
+func (*leashed) Velocity() mgl64.Vec3 { return mgl64.Vec3{} }
+
+// SetVelocity ...
+func (*leashed) SetVelocity(mgl64.Vec3) {}
+
+// leashKnots returns all leash knots in the world passed.
+func leashKnots(w *servertest.World) (knots []*entity.LeashKnot) {
+	for _, e := range w.Entities() {
+		if k, ok := e.(*entity.LeashKnot); ok {
+			knots = append(knots, k)
+		}
+	}
+	return knots
+}
+
+// TestLeashKnot checks that entities leashed to a player are tied to a knot on a fence, that the knot stays as
+// long as entities are tied to it and that breaking the fence unleashes them.
+func TestLeashKnot(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	fence := cube.Pos{2, 10, 0}
+	w.Fill(cube.Pos{-2, 9, -2}, cube.Pos{2, 9, 2}, block.Stone{})
+	w.SetBlock(fence, block.WoodFence{Wood: block.OakWood()})
+	p := w.NewPlayer("holder", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+
+	var _ entity.Leashable = (*leashed)(nil)
+	e := newLeashed(mgl64.Vec3{-0.5, 10, 0.5})
+	w.AddEntity(e)
+
+	p.SetHeldItems(item.NewStack(item.Lead{}, 2), item.Stack{})
+	p.UseItemOnEntity(e)
+	if h, ok := e.LeashHolder(); !ok || h != world.Entity(p) {
+		t.Fatalf("entity leashed to %v after using a lead on it, want player", h)
+	}
+	if held, _ := p.HeldItems(); held.Count() != 1 {
+		t.Errorf("%v leads left after leashing, want 1", held.Count())
+	}
+
+	p.UseItemOnBlock(fence, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+	knots := leashKnots(w)
+	if len(knots) != 1 {
+		t.Fatalf("%v leash knots after tying to fence, want 1", len(knots))
+	}
+	if h, _ := e.LeashHolder(); h != world.Entity(knots[0]) {
+		t.Errorf("entity leashed to %v after tying to fence, want leash knot", h)
+	}
+
+	// The knot must not be removed while an entity is tied to it.
+	w.Advance(40)
+	if len(leashKnots(w)) != 1 {
+		t.Fatalf("leash knot removed while an entity was tied to it")
+	}
+
+	w.SetBlock(fence, nil)
+	w.Advance(1)
+	if _, ok := e.LeashHolder(); ok {
+		t.Errorf("entity still leashed after the fence was broken")
+	}
+	if len(leashKnots(w)) != 0 {
+		t.Errorf("leash knot not removed after the fence was broken")
+	}
+}
@@ -95,7 +95,7 @@ func (li *Lightning) Tick(_ int64) {
 			// Only damage entities that weren't already dead.
 			if l, ok := e.(Living); ok && l.Health() > 0 {
 				l.Hurt(5, damage.SourceLightning{})
-				if f, ok := e.(Flammable); ok && f.OnFireDuration() < time.Second*8 {
+				if f, ok := e.(Flammable); ok && !f.FireProof() && f.OnFireDuration() < time.Second*8 {
 					f.SetOnFire(time.Second * 8)
 				}
 			}
@@ -2,21 +2,70 @@ package entity
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/entity/physics"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"math"
+	"sync"
 )
 
 // MovementComputer is used to compute movement of an entity. When constructed, the Gravity of the entity
-// the movement is computed for must be passed.
+// the movement is computed for must be passed. Once the computer is in use, its Gravity, Drag and
+// DragBeforeGravity may only be changed using SetConfig.
 type MovementComputer struct {
 	Gravity, Drag     float64
 	DragBeforeGravity bool
+	// StepHeight is the height of blocks that the entity may walk up without jumping while on the ground. If
+	// zero, the entity is not able to step up blocks.
+	StepHeight float64
+	// Climbs specifies if the entity is able to climb blocks such as ladders, vines and scaffolding. If true, the
+	// entity moves up such blocks when walking into them and never falls faster than their climb speed.
+	Climbs bool
 
+	// mu protects Gravity, Drag and DragBeforeGravity, as they may be changed while the entity is moving.
+	mu       sync.Mutex
 	onGround bool
 }
 
+// MovementConfig holds the constants that a MovementComputer computes the movement of an entity with.
+type MovementConfig struct {
+	// Gravity is the velocity subtracted from the Y velocity of the entity every tick. It is multiplied by the
+	// gravity multiplier of the world that the entity is in, as returned by world.World.GravityMultiplier.
+	Gravity float64
+	// Drag is the fraction of its velocity that the entity loses every tick.
+	Drag float64
+	// DragBeforeGravity specifies if the Drag is applied to the Y velocity before the Gravity is, rather than
+	// after.
+	DragBeforeGravity bool
+}
+
+// MovementConfigurable represents an entity that has its movement computed by a MovementComputer, of which
+// the MovementConfig may be changed at runtime, for example to let it fall slower.
+type MovementConfigurable interface {
+	world.Entity
+	// MovementConfig returns the MovementConfig currently used to compute the movement of the entity.
+	MovementConfig() MovementConfig
+	// SetMovementConfig changes the MovementConfig used to compute the movement of the entity. The change
+	// takes effect from the next movement tick of the entity.
+	SetMovementConfig(conf MovementConfig)
+}
+
+// Config returns the MovementConfig that the MovementComputer currently uses.
+func (c *MovementComputer) Config() MovementConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return MovementConfig{Gravity: c.Gravity, Drag: c.Drag, DragBeforeGravity: c.DragBeforeGravity}
+}
+
+// SetConfig changes the MovementConfig that the MovementComputer uses. The change takes effect from the next
+// call to TickMovement.
+func (c *MovementComputer) SetConfig(conf MovementConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Gravity, c.Drag, c.DragBeforeGravity = conf.Gravity, conf.Drag, conf.DragBeforeGravity
+}
+
 // Movement represents the movement of a world.Entity as a result of a call to MovementComputer.TickMovement. The
 // resulting position and velocity can be obtained by calling Position and Velocity. These can be sent to viewers by
 // calling Send.
@@ -67,9 +116,25 @@ func (c *MovementComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, yaw
 	w := e.World()
 	viewers := w.Viewers(pos)
 
+	conf := c.Config()
+	conf.Gravity *= w.GravityMultiplier()
+
 	velBefore := vel
-	vel = c.applyHorizontalForces(w, pos, c.applyVerticalForces(vel))
+	vel = c.applyHorizontalForces(w, pos, applyVerticalForces(vel, conf), conf)
+	if l, ok := w.Liquid(cube.PosFromVec3(pos)); ok && l.LiquidType() == "lava" {
+		// Entities move much slower through lava than through air.
+		vel = vel.Mul(lavaDrag)
+	}
+	climbSpeed, climbing := c.climbSpeed(w, pos)
+	if climbing {
+		vel[1] = math.Max(vel[1], -climbSpeed)
+	}
+	attempted := vel
 	dPos, vel := c.checkCollision(e, pos, vel)
+	if climbing && (!mgl64.FloatEqual(dPos[0], attempted[0]) || !mgl64.FloatEqual(dPos[2], attempted[2])) {
+		// The entity walked into a wall while climbing, so it moves up the block climbed.
+		vel[1] = climbSpeed
+	}
 
 	return &Movement{v: viewers, e: e,
 		pos: pos.Add(dPos), vel: vel, dpos: dPos, dvel: vel.Sub(velBefore),
@@ -77,6 +142,24 @@ func (c *MovementComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, yaw
 	}
 }
 
+// climbSpeed returns the climb speed of the block at the position passed if the entity is able to climb and the
+// block is climbable.
+func (c *MovementComputer) climbSpeed(w *world.World, pos mgl64.Vec3) (float64, bool) {
+	if !c.Climbs {
+		return 0, false
+	}
+	if b, ok := w.Block(cube.PosFromVec3(pos)).(climbable); ok {
+		return b.ClimbSpeed(), true
+	}
+	return 0, false
+}
+
+// climbable is a block that entities are able to climb. It is equal to block.Climbable, which cannot be referred
+// to from the entity package.
+type climbable interface {
+	ClimbSpeed() float64
+}
+
 // OnGround checks if the entity that this computer calculates is currently on the ground.
 func (c *MovementComputer) OnGround() bool {
 	return c.onGround
@@ -85,32 +168,49 @@ func (c *MovementComputer) OnGround() bool {
 // zeroVec3 is a mgl64.Vec3 with zero values.
 var zeroVec3 mgl64.Vec3
 
+// minBounceVelocity is the minimum downward velocity that an entity must have when landing on a block.Bouncy to
+// bounce off it. Entities resting on the block, which only move down due to gravity, do not bounce.
+const minBounceVelocity = 0.1
+
+// lavaDrag is the factor with which the velocity of an entity is multiplied every tick when it is in lava.
+const lavaDrag = 0.5
+
 // epsilon is the epsilon used for thresholds for change used for change in position and velocity.
 const epsilon = 0.001
 
-// applyVerticalForces applies gravity and drag on the Y axis, based on the Gravity and Drag values set.
-func (c *MovementComputer) applyVerticalForces(vel mgl64.Vec3) mgl64.Vec3 {
-	if c.DragBeforeGravity {
-		vel[1] *= 1 - c.Drag
+// applyVerticalForces applies gravity and drag on the Y axis, based on the Gravity and Drag values of the
+// MovementConfig passed.
+func applyVerticalForces(vel mgl64.Vec3, conf MovementConfig) mgl64.Vec3 {
+	if conf.DragBeforeGravity {
+		vel[1] *= 1 - conf.Drag
 	}
-	vel[1] -= c.Gravity
-	if !c.DragBeforeGravity {
-		vel[1] *= 1 - c.Drag
+	vel[1] -= conf.Gravity
+	if !conf.DragBeforeGravity {
+		vel[1] *= 1 - conf.Drag
 	}
 	return vel
 }
 
-// applyHorizontalForces applies friction to the velocity based on the Drag value, reducing it on the X and Z axes.
-func (c *MovementComputer) applyHorizontalForces(w *world.World, pos, vel mgl64.Vec3) mgl64.Vec3 {
-	friction := 1 - c.Drag
+// applyHorizontalForces applies friction to the velocity based on the Drag value of the MovementConfig passed,
+// reducing it on the X and Z axes. Entities on the ground are slowed down further by the block below them.
+func (c *MovementComputer) applyHorizontalForces(w *world.World, pos, vel mgl64.Vec3, conf MovementConfig) mgl64.Vec3 {
+	friction := 1 - conf.Drag
 	if c.onGround {
-		if f, ok := w.Block(cube.PosFromVec3(pos).Side(cube.FaceDown)).(interface {
+		// The block half a block below the entity is used, so that blocks lower than a full block, such as honey
+		// blocks and slabs, slow down entities standing on them.
+		below := w.Block(cube.PosFromVec3(pos.Sub(mgl64.Vec3{0, 0.5})))
+		if f, ok := below.(interface {
 			Friction() float64
 		}); ok {
 			friction *= f.Friction()
 		} else {
 			friction *= 0.6
 		}
+		if s, ok := below.(interface {
+			EntitySpeedFactor() float64
+		}); ok {
+			friction *= s.EntitySpeedFactor()
+		}
 	}
 	vel[0] *= friction
 	vel[2] *= friction
@@ -122,30 +222,28 @@ func (c *MovementComputer) applyHorizontalForces(w *world.World, pos, vel mgl64.
 // The final velocity and the Vec3 that the entity should move is returned.
 func (c *MovementComputer) checkCollision(e world.Entity, pos, vel mgl64.Vec3) (mgl64.Vec3, mgl64.Vec3) {
 	// TODO: Implement collision with other entities.
-	deltaX, deltaY, deltaZ := vel[0], vel[1], vel[2]
 
 	// Entities only ever have a single bounding box.
 	entityAABB := e.AABB().Translate(pos)
-	blocks := blockAABBsAround(e, entityAABB.Extend(vel))
+	blocks := blockAABBsAround(e, entityAABB.Extend(vel).Extend(mgl64.Vec3{0, c.StepHeight}), entityAABB.Min()[1])
 
-	if !mgl64.FloatEqualThreshold(deltaY, 0, epsilon) {
-		// First we move the entity AABB on the Y axis.
-		for _, blockAABB := range blocks {
-			deltaY = entityAABB.CalculateYOffset(blockAABB, deltaY)
-		}
-		entityAABB = entityAABB.Translate(mgl64.Vec3{0, deltaY})
-	}
-	if !mgl64.FloatEqualThreshold(deltaX, 0, epsilon) {
-		// Then on the X axis.
+	d := collide(entityAABB, blocks, vel)
+	deltaX, deltaY, deltaZ := d[0], d[1], d[2]
+
+	horizontalCollision := !mgl64.FloatEqual(deltaX, vel[0]) || !mgl64.FloatEqual(deltaZ, vel[2])
+	landed := c.onGround || (vel[1] < 0 && !mgl64.FloatEqual(deltaY, vel[1]))
+	if c.StepHeight > 0 && horizontalCollision && landed {
+		// The entity walked into a block while on the ground. We try moving it up by the step height first,
+		// so that it may walk up blocks such as slabs and stairs, and then move it back down onto the block.
+		step := collide(entityAABB, blocks, mgl64.Vec3{vel[0], c.StepHeight, vel[2]})
+		stepAABB := entityAABB.Translate(step)
+		down := -step[1]
 		for _, blockAABB := range blocks {
-			deltaX = entityAABB.CalculateXOffset(blockAABB, deltaX)
+			down = stepAABB.CalculateYOffset(blockAABB, down)
 		}
-		entityAABB = entityAABB.Translate(mgl64.Vec3{deltaX})
-	}
-	if !mgl64.FloatEqualThreshold(deltaZ, 0, epsilon) {
-		// And finally on the Z axis.
-		for _, blockAABB := range blocks {
-			deltaZ = entityAABB.CalculateZOffset(blockAABB, deltaZ)
+		step[1] += down
+		if step[0]*step[0]+step[2]*step[2] > deltaX*deltaX+deltaZ*deltaZ {
+			deltaX, deltaY, deltaZ = step[0], step[1], step[2]
 		}
 	}
 	if !mgl64.FloatEqual(vel[1], 0) {
@@ -161,8 +259,18 @@ func (c *MovementComputer) checkCollision(e world.Entity, pos, vel mgl64.Vec3) (
 		if vel[1] < 0 {
 			// The entity was going down, so we can assume it is now on the ground.
 			c.onGround = true
+			below := cube.PosFromVec3(pos.Add(mgl64.Vec3{deltaX, deltaY, deltaZ})).Side(cube.FaceDown)
+			if b, ok := e.World().Block(below).(interface {
+				Bounces(e world.Entity) bool
+			}); ok && vel[1] < -minBounceVelocity && b.Bounces(e) {
+				// The entity landed on a block such as a slime block and bounces back up.
+				vel[1] = -vel[1]
+			} else {
+				vel[1] = 0
+			}
+		} else {
+			vel[1] = 0
 		}
-		vel[1] = 0
 	}
 	if !mgl64.FloatEqual(deltaZ, vel[2]) {
 		vel[2] = 0
@@ -170,9 +278,37 @@ func (c *MovementComputer) checkCollision(e world.Entity, pos, vel mgl64.Vec3) (
 	return mgl64.Vec3{deltaX, deltaY, deltaZ}, vel
 }
 
+// collide moves the AABB passed by the delta passed on the Y, X and Z axes respectively, stopping it when it
+// collides with any of the block AABBs passed. The delta that the AABB may actually be moved by is returned.
+func collide(aabb physics.AABB, blocks []physics.AABB, delta mgl64.Vec3) mgl64.Vec3 {
+	deltaX, deltaY, deltaZ := delta[0], delta[1], delta[2]
+	if !mgl64.FloatEqualThreshold(deltaY, 0, epsilon) {
+		// First we move the entity AABB on the Y axis.
+		for _, blockAABB := range blocks {
+			deltaY = aabb.CalculateYOffset(blockAABB, deltaY)
+		}
+		aabb = aabb.Translate(mgl64.Vec3{0, deltaY})
+	}
+	if !mgl64.FloatEqualThreshold(deltaX, 0, epsilon) {
+		// Then on the X axis.
+		for _, blockAABB := range blocks {
+			deltaX = aabb.CalculateXOffset(blockAABB, deltaX)
+		}
+		aabb = aabb.Translate(mgl64.Vec3{deltaX})
+	}
+	if !mgl64.FloatEqualThreshold(deltaZ, 0, epsilon) {
+		// And finally on the Z axis.
+		for _, blockAABB := range blocks {
+			deltaZ = aabb.CalculateZOffset(blockAABB, deltaZ)
+		}
+	}
+	return mgl64.Vec3{deltaX, deltaY, deltaZ}
+}
+
 // blockAABBsAround returns all blocks around the entity passed, using the AABB passed to make a prediction of
-// what blocks need to have their AABB returned.
-func blockAABBsAround(e world.Entity, aabb physics.AABB) []physics.AABB {
+// what blocks need to have their AABB returned. feetY is the Y position of the bottom of the entity, which is
+// used to check if the entity collides with blocks such as scaffolding.
+func blockAABBsAround(e world.Entity, aabb physics.AABB, feetY float64) []physics.AABB {
 	w := e.World()
 	grown := aabb.Grow(0.25)
 	min, max := grown.Min(), grown.Max()
@@ -185,7 +321,11 @@ func blockAABBsAround(e world.Entity, aabb physics.AABB) []physics.AABB {
 		for x := minX; x <= maxX; x++ {
 			for z := minZ; z <= maxZ; z++ {
 				pos := cube.Pos{x, y, z}
-				boxes := w.Block(pos).Model().AABB(pos, w)
+				m := w.Block(pos).Model()
+				if scaffolding, ok := m.(model.Scaffolding); ok && !scaffolding.Collides(pos, feetY, sneaking(e)) {
+					continue
+				}
+				boxes := m.AABB(pos, w)
 				for _, box := range boxes {
 					blockAABBs = append(blockAABBs, box.Translate(mgl64.Vec3{float64(x), float64(y), float64(z)}))
 				}
@@ -194,3 +334,11 @@ func blockAABBsAround(e world.Entity, aabb physics.AABB) []physics.AABB {
 	}
 	return blockAABBs
 }
+
+// sneaking checks if the entity passed is currently sneaking.
+func sneaking(e world.Entity) bool {
+	s, ok := e.(interface {
+		Sneaking() bool
+	})
+	return ok && s.Sneaking()
+}
@@ -0,0 +1,14 @@
+package entity
+
+// NameTagVisibility specifies when the name tag of an entity is shown to its viewers.
+type NameTagVisibility int
+
+const (
+	// NameTagWhenNotSneaking shows the name tag of an entity at all times, unless the entity is sneaking. It
+	// is the default visibility of the name tag of a player.
+	NameTagWhenNotSneaking NameTagVisibility = iota
+	// NameTagAlways shows the name tag of an entity at all times, even if the entity is sneaking.
+	NameTagAlways
+	// NameTagNever never shows the name tag of an entity.
+	NameTagNever
+)
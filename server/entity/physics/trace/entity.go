@@ -37,16 +37,40 @@ func (r EntityResult) Entity() world.Entity {
 }
 
 // EntityIntercept performs a ray trace and calculates the point on the entities bounding box's edge nearest to the start position
-// that the ray collided with.
+// that the ray collided with. The ray is assumed to be travelled during a single tick, during which the entity moved by
+// its velocity to its current position: The ray is swept against the bounding box of the entity as it moves, so that it
+// only collides with the entity if both are at the same place at the same time during that tick.
 // EntityIntercept returns an EntityResult with the entity collided with and with the colliding vector closest to the start position,
 // if no colliding point was found, a zero BlockResult is returned ok is false.
 func EntityIntercept(e world.Entity, start, end mgl64.Vec3) (result EntityResult, ok bool) {
-	bb := e.AABB().Translate(e.Position()).Grow(0.3)
+	vel := entityVelocity(e)
+	// In the frame of reference of the entity, it stands still where it was at the start of the tick, while the ray
+	// moves by the difference between the two movements.
+	bb := e.AABB().Translate(e.Position().Sub(vel)).Grow(0.3)
+	relEnd := end.Sub(vel)
 
-	r, ok := AABBIntercept(bb, start, end)
+	r, ok := AABBIntercept(bb, start, relEnd)
 	if !ok {
 		return
 	}
+	// The part of the tick that passed before the collision decides how far the entity moved before it was hit.
+	t := r.Position().Sub(start).Len() / relEnd.Sub(start).Len()
+	moved := vel.Mul(t)
 
-	return EntityResult{bb: bb, pos: r.Position(), face: r.Face(), entity: e}, true
+	return EntityResult{bb: bb.Translate(moved), pos: r.Position().Add(moved), face: r.Face(), entity: e}, true
+}
+
+// entityVelocity returns the velocity of the entity passed, or a zero vector if the entity has no velocity.
+func entityVelocity(e world.Entity) mgl64.Vec3 {
+	if m, ok := e.(interface{ Velocity() mgl64.Vec3 }); ok {
+		return m.Velocity()
+	}
+	return mgl64.Vec3{}
+}
+
+// sweptAABB returns the bounding box of the entity passed, translated to its position and extended backwards along
+// its velocity, so that it covers the space the entity moved through during its last tick. It contains every
+// position at which EntityIntercept may collide with the entity.
+func sweptAABB(e world.Entity) physics.AABB {
+	return e.AABB().Translate(e.Position()).Extend(entityVelocity(e).Mul(-1))
 }
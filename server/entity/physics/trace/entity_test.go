@@ -0,0 +1,57 @@
+package trace_test
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/physics/trace"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// movingTarget returns an item entity that moved to the end position passed with the velocity passed during its
+// last tick.
+func movingTarget(end, vel mgl64.Vec3) *entity.Item {
+	e := entity.NewItem(item.NewStack(item.Diamond{}, 1), end)
+	e.SetVelocity(vel)
+	return e
+}
+
+// TestEntityInterceptMovingTarget checks that a ray only collides with an entity that crosses its path during the
+// same tick if both are at the same place at the same time, and that the collision is reported where the entity
+// was at that time.
+func TestEntityInterceptMovingTarget(t *testing.T) {
+	// The ray travels 10 blocks along the Z axis during the tick. The targets cross its path along the X axis,
+	// moving from x=-5 to x=5 during the same tick.
+	start, end := mgl64.Vec3{0, 0.1, 0}, mgl64.Vec3{0, 0.1, 10}
+	vel := mgl64.Vec3{10, 0, 0}
+
+	// This target is at x=0 after half of the tick, when the ray is at z=5.
+	crossing := movingTarget(mgl64.Vec3{5, 0, 5.2}, vel)
+	r, ok := trace.EntityIntercept(crossing, start, end)
+	if !ok {
+		t.Fatalf("ray did not collide with a target crossing its path at the same time")
+	}
+	// The bounding box of the item, grown by 0.3, reaches 0.425 blocks from its centre. The ray enters it through
+	// its north face once the ray is at z=4.775.
+	if want := (mgl64.Vec3{0, 0.1, 4.775}); !r.Position().ApproxEqualThreshold(want, 1e-9) {
+		t.Errorf("ray collided at %v, want %v", r.Position(), want)
+	}
+	if r.Entity() != crossing {
+		t.Errorf("ray collided with %v, want the crossing target", r.Entity())
+	}
+	if !r.AABB().Vec3Within(r.Position().Add(mgl64.Vec3{0, 0, 0.01})) {
+		t.Errorf("bounding box %v collided with is not where the target was during the collision", r.AABB())
+	}
+
+	// This target crosses the path of the ray at z=9 before the ray gets there: It is at x=4 when the ray arrives.
+	early := movingTarget(mgl64.Vec3{5, 0, 9}, vel)
+	if r, ok := trace.EntityIntercept(early, start, end); ok {
+		t.Errorf("ray collided at %v with a target that crossed its path before the ray got there", r.Position())
+	}
+
+	// A target standing still in the path of the ray is hit on the face closest to the start of the ray.
+	still := movingTarget(mgl64.Vec3{0, 0, 5}, mgl64.Vec3{})
+	if r, ok := trace.EntityIntercept(still, start, end); !ok || !r.Position().ApproxEqualThreshold(mgl64.Vec3{0, 0.1, 4.575}, 1e-9) {
+		t.Errorf("ray collided with a target standing still: %v at %v, want true at %v", ok, r.Position(), mgl64.Vec3{0, 0.1, 4.575})
+	}
+}
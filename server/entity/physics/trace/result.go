@@ -18,6 +18,12 @@ type Result interface {
 	Face() cube.Face
 }
 
+// entitySearchRadius is the distance around the path of a ray within which Perform looks for entities that may
+// collide with it. Entities are found by their position, so the radius covers both the size of their bounding
+// boxes and the distance that fast entities, such as projectiles, move within the tick that the ray is swept
+// over.
+const entitySearchRadius = 16.0
+
 // Perform performs a ray trace between start and end, checking if any blocks or entities collided with the
 // ray. The physics.AABB that's passed is used for checking if any entity within the bounding box collided
 // with the ray.
@@ -38,8 +44,8 @@ func Perform(start, end mgl64.Vec3, w *world.World, aabb physics.AABB, ignored f
 	// Now check for any entities that we may collide with.
 	dist := math.MaxFloat64
 	bb := aabb.Translate(start).Extend(end.Sub(start))
-	for _, entity := range w.EntitiesWithin(bb.Grow(8.0), ignored) {
-		if ignored != nil && ignored(entity) || !entity.AABB().Translate(entity.Position()).IntersectsWith(bb) {
+	for _, entity := range w.EntitiesWithin(bb.Grow(entitySearchRadius), ignored) {
+		if ignored != nil && ignored(entity) || !sweptAABB(entity).Grow(0.3).IntersectsWith(bb) {
 			continue
 		}
 		// Check if we collide with the entities bounding box.
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/physics/trace"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
@@ -14,23 +15,135 @@ type Owned interface {
 	Own(owner world.Entity)
 }
 
+// projectileHittable represents a block that is affected by being hit by a projectile, such as a bell.
+type projectileHittable interface {
+	// ProjectileHit is called when a projectile hits the block at the position passed on the face passed.
+	ProjectileHit(pos cube.Pos, face cube.Face, w *world.World, projectile world.Entity)
+}
+
+// ProjectileDeflector represents an entity that may prevent a projectile from hitting it right before the impact,
+// for example by blocking it with a shield or, like endermen do, by teleporting away from it.
+type ProjectileDeflector interface {
+	// DeflectProjectile is called right before the projectile passed hits the entity at the position passed. The
+	// Deflection returned specifies what happens to the projectile.
+	DeflectProjectile(projectile world.Entity, pos mgl64.Vec3) Deflection
+}
+
+// Deflection specifies what happens to a projectile that is about to hit a ProjectileDeflector.
+type Deflection int
+
+const (
+	// DeflectionNone makes the projectile hit the entity as usual.
+	DeflectionNone Deflection = iota
+	// DeflectionReflect makes the projectile bounce back from the entity, like it does from a raised shield. The
+	// projectile is owned by the entity that reflected it from then on, if it implements Owned.
+	DeflectionReflect
+	// DeflectionDodge makes the projectile pass through the entity as if it was not there.
+	DeflectionDodge
+)
+
+// impacter is a projectile whose impacts are resolved by ProjectileComputer.resolveImpact.
+type impacter interface {
+	world.Entity
+	// hit is called when the projectile hits an entity or a block. The trace.Result passed is either a
+	// trace.EntityResult, holding the entity hit and the exact position of the impact, or a trace.BlockResult,
+	// holding the position of the block hit and the face it was hit on. vel is the velocity of the projectile
+	// right before the impact. hit returns true if the projectile should despawn after the impact.
+	hit(r trace.Result, vel mgl64.Vec3) (despawn bool)
+}
+
+// resolveImpact resolves the Movement of the projectile passed, as returned by TickMovement along with the
+// trace.Result r. The hit method of the projectile is called if it hit anything, after blocks hit have been
+// notified. vel is the velocity of the projectile before the Movement. resolveImpact returns true if the
+// projectile should despawn, either because it fell out of the world or because of the impact.
+// resolveImpact must be called without holding the lock of the projectile.
+func (c *ProjectileComputer) resolveImpact(p impacter, m *Movement, r trace.Result, vel mgl64.Vec3, current int64) (despawn bool) {
+	if c.reflected {
+		c.reflected = false
+		if o, ok := p.(Owned); ok {
+			o.Own(c.deflector)
+		}
+	}
+	w := p.World()
+	if m.pos[1] < float64(w.Range()[0]) && current%10 == 0 {
+		return true
+	}
+	if r == nil {
+		return false
+	}
+	if res, ok := r.(trace.BlockResult); ok {
+		if h, ok := w.Block(res.BlockPosition()).(projectileHittable); ok {
+			h.ProjectileHit(res.BlockPosition(), res.Face(), w, p)
+		}
+	}
+	return p.hit(r, vel)
+}
+
 // ProjectileComputer is used to compute movement of a projectile. When constructed, a MovementComputer must be passed.
 type ProjectileComputer struct {
 	*MovementComputer
+
+	// deflector is the entity that last reflected the projectile. The projectile can no longer hit it. reflected
+	// is true if the projectile was reflected during the last movement tick and is not yet owned by deflector.
+	deflector world.Entity
+	reflected bool
 }
 
+// maxDodges is the maximum amount of entities that may dodge a projectile in a single tick.
+const maxDodges = 4
+
 // TickMovement performs a movement tick on a projectile. Velocity is applied and changed according to the values
 // of its Drag and Gravity. A ray trace is performed to see if the projectile has collided with any block or entity,
 // the ray trace result is returned.
+// Entities hit that implement ProjectileDeflector are asked to deflect the projectile first: If the projectile is
+// reflected, it bounces back and no result is returned. Entities that dodge the projectile are ignored.
 // The resulting Movement can be sent to viewers by calling Movement.Send.
 func (c *ProjectileComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, yaw, pitch float64, ignored func(world.Entity) bool) (*Movement, trace.Result) {
 	w := e.World()
 	viewers := w.Viewers(pos)
 
+	conf := c.Config()
+	conf.Gravity *= w.GravityMultiplier()
+
 	velBefore := vel
-	vel = c.applyHorizontalForces(w, pos, c.applyVerticalForces(vel))
+	vel = c.applyHorizontalForces(w, pos, applyVerticalForces(vel, conf), conf)
 	end := pos.Add(vel)
-	hit, ok := trace.Perform(pos, end, w, e.AABB().Grow(1.0), ignored)
+
+	var dodged []world.Entity
+	ignore := func(other world.Entity) bool {
+		if other == c.deflector || (ignored != nil && ignored(other)) {
+			return true
+		}
+		for _, d := range dodged {
+			if d == other {
+				return true
+			}
+		}
+		return false
+	}
+	hit, ok := trace.Perform(pos, end, w, e.AABB().Grow(1.0), ignore)
+	for ok && len(dodged) < maxDodges {
+		r, isEntity := hit.(trace.EntityResult)
+		if !isEntity {
+			break
+		}
+		d, isDeflector := r.Entity().(ProjectileDeflector)
+		if !isDeflector {
+			break
+		}
+		switch d.DeflectProjectile(e, r.Position()) {
+		case DeflectionReflect:
+			c.deflector, c.reflected = r.Entity(), true
+			end, vel = r.Position(), vel.Mul(-0.5)
+			hit, ok = nil, false
+			continue
+		case DeflectionDodge:
+			dodged = append(dodged, r.Entity())
+			hit, ok = trace.Perform(pos, end, w, e.AABB().Grow(1.0), ignore)
+			continue
+		}
+		break
+	}
 	if ok {
 		vel = zeroVec3
 		end = hit.Position()
@@ -9,7 +9,12 @@ func init() {
 	world.RegisterEntity(&FallingBlock{})
 	world.RegisterEntity(&Item{})
 	world.RegisterEntity(&Snowball{})
+	world.RegisterEntity(&Arrow{})
 	world.RegisterEntity(&EnderPearl{})
 	world.RegisterEntity(&SplashPotion{})
+	world.RegisterEntity(&SplashPotion{linger: true})
+	world.RegisterEntity(&AreaEffectCloud{})
 	world.RegisterEntity(&Lightning{})
+	world.RegisterEntity(&Firework{})
+	world.RegisterEntity(&LeashKnot{})
 }
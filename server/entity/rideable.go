@@ -6,17 +6,68 @@ import (
 	"github.com/go-gl/mathgl/mgl64"
 )
 
-// Rideable is an interface for entities that can be ridden.
+// Rideable is an interface for entities that can be ridden. A Rideable entity may be a Rider itself, so that
+// entities may be stacked on top of each other.
 type Rideable interface {
 	world.Entity
 	// SeatPositions returns the possible seat positions for an entity in the order that they will be filled.
 	SeatPositions() []mgl32.Vec3
 	// Riders returns a slice entities that are currently riding an entity in the order that they were added.
-	Riders() []Rider
+	Riders() []world.Entity
 	// AddRider adds a rider to the entity.
-	AddRider(e Rider)
+	AddRider(e world.Entity)
 	// RemoveRider removes a rider from the entity.
-	RemoveRider(e Rider)
-	// Move moves the entity using the given vector, yaw, and pitch.
-	Move(vector mgl64.Vec2, yaw, pitch float32)
+	RemoveRider(e world.Entity)
+	// Steer moves the entity using the movement input of the rider in its first seat: The given vector, yaw and
+	// pitch. Entities that cannot be steered by their riders may ignore it.
+	Steer(vector mgl64.Vec2, yaw, pitch float32)
+}
+
+// MoveRiders moves all riders of the Rideable passed to their seats, which are found by adding the seat
+// positions of the Rideable to its position. Riders that are Rideable themselves have their own riders moved
+// too, so that a full stack of entities stays aligned. Rideable entities should call MoveRiders every tick
+// and after they moved. player.Player does so.
+func MoveRiders(r Rideable) {
+	moveRiders(r, map[world.Entity]struct{}{r: {}})
+}
+
+// moveRiders moves the riders of the Rideable passed to their seats. Entities found in the visited map are not
+// moved again, which prevents an endless loop if entities are (incorrectly) riding each other.
+func moveRiders(r Rideable, visited map[world.Entity]struct{}) {
+	pos, positions := r.Position(), r.SeatPositions()
+	for i, e := range r.Riders() {
+		if i >= len(positions) {
+			break
+		}
+		if _, ok := visited[e]; ok {
+			continue
+		}
+		visited[e] = struct{}{}
+		if rider, ok := e.(Rider); ok {
+			seat := positions[i]
+			rider.MoveToSeat(pos.Add(mgl64.Vec3{float64(seat[0]), float64(seat[1]), float64(seat[2])}))
+		}
+		if mount, ok := e.(Rideable); ok {
+			moveRiders(mount, visited)
+		}
+	}
+}
+
+// DismountRiders dismounts all riders of the Rideable passed. Rideable entities should call DismountRiders when
+// they are closed, so that no entities are left riding an entity that no longer exists. player.Player does so
+// when it dies or is closed. Riders that implement
+// Rider are dismounted using Rider.DismountEntity. Other riders are removed from the Rideable directly.
+func DismountRiders(r Rideable) {
+	for _, e := range r.Riders() {
+		if rider, ok := e.(Rider); ok {
+			rider.DismountEntity()
+			continue
+		}
+		r.RemoveRider(e)
+		if w := r.World(); w != nil {
+			for _, v := range w.Viewers(r.Position()) {
+				v.ViewEntityDismount(e, r)
+			}
+		}
+	}
 }
@@ -1,15 +1,68 @@
 package entity
 
 import (
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
 )
 
 // Rider is an interface for entities that can ride other entities.
 type Rider interface {
+	world.Entity
 	// SeatPosition returns the Rider's current seat position.
 	SeatPosition() mgl32.Vec3
 	// MountEntity mounts the Rider to an entity if the entity is Rideable and if there is a seat available.
 	MountEntity(e Rideable)
+	// DismountEntity dismounts the Rider from the entity it is currently riding, if any.
+	DismountEntity()
 	// RidingEntity returns the entity the player is currently riding and the player's seat index.
 	RidingEntity() (Rideable, int)
+	// MoveToSeat moves the Rider to the position passed, which is the position of its seat on the entity that it
+	// is riding. It is called every tick by MoveRiders.
+	MoveToSeat(pos mgl64.Vec3)
+}
+
+// Riding checks if the entity passed is riding the mount passed, either directly or by riding an entity that is
+// in turn riding the mount.
+func Riding(e, mount world.Entity) bool {
+	visited := map[world.Entity]struct{}{}
+	for {
+		rider, ok := e.(Rider)
+		if !ok {
+			return false
+		}
+		r, _ := rider.RidingEntity()
+		if r == nil {
+			return false
+		}
+		if r == mount {
+			return true
+		}
+		if _, ok := visited[r]; ok {
+			return false
+		}
+		visited[r] = struct{}{}
+		e = r
+	}
+}
+
+// BottomEntity returns the entity at the bottom of the stack of entities that the entity passed is part of. If
+// the entity passed is not riding any entity, it is returned itself.
+func BottomEntity(e world.Entity) world.Entity {
+	visited := map[world.Entity]struct{}{e: {}}
+	for {
+		rider, ok := e.(Rider)
+		if !ok {
+			return e
+		}
+		r, _ := rider.RidingEntity()
+		if r == nil {
+			return e
+		}
+		if _, ok := visited[r]; ok {
+			return e
+		}
+		visited[r] = struct{}{}
+		e = r
+	}
 }
@@ -28,7 +28,7 @@ func NewSnowball(pos mgl64.Vec3, yaw, pitch float64, owner world.Entity) *Snowba
 	s := &Snowball{
 		yaw:   yaw,
 		pitch: pitch,
-		c: &ProjectileComputer{&MovementComputer{
+		c: &ProjectileComputer{MovementComputer: &MovementComputer{
 			Gravity:           0.03,
 			Drag:              0.01,
 			DragBeforeGravity: true,
@@ -55,6 +55,16 @@ func (s *Snowball) AABB() physics.AABB {
 	return physics.NewAABB(mgl64.Vec3{-0.125, 0, -0.125}, mgl64.Vec3{0.125, 0.25, 0.125})
 }
 
+// MovementConfig ...
+func (s *Snowball) MovementConfig() MovementConfig {
+	return s.c.Config()
+}
+
+// SetMovementConfig ...
+func (s *Snowball) SetMovementConfig(conf MovementConfig) {
+	s.c.SetConfig(conf)
+}
+
 // Rotation ...
 func (s *Snowball) Rotation() (float64, float64) {
 	s.mu.Lock()
@@ -69,6 +79,7 @@ func (s *Snowball) Tick(current int64) {
 		return
 	}
 	s.mu.Lock()
+	vel := s.vel
 	m, result := s.c.TickMovement(s, s.pos, s.vel, s.yaw, s.pitch, s.ignores)
 	s.pos, s.vel, s.yaw, s.pitch = m.pos, m.vel, m.yaw, m.pitch
 	s.mu.Unlock()
@@ -76,27 +87,23 @@ func (s *Snowball) Tick(current int64) {
 	s.age++
 	m.Send()
 
-	if m.pos[1] < float64(s.World().Range()[0]) && current%10 == 0 {
-		s.close = true
-		return
-	}
-
-	if result != nil {
-		w := s.World()
-		for i := 0; i < 6; i++ {
-			w.AddParticle(result.Position(), particle.SnowballPoof{})
-		}
+	s.close = s.c.resolveImpact(s, m, result, vel, current)
+}
 
-		if r, ok := result.(trace.EntityResult); ok {
-			if l, ok := r.Entity().(Living); ok {
-				if _, vulnerable := l.Hurt(0.0, damage.SourceEntityAttack{Attacker: s}); vulnerable {
-					l.KnockBack(m.pos, 0.45, 0.3608)
-				}
+// hit shows particles at the position of the impact and knocks back the entity hit, if any.
+func (s *Snowball) hit(r trace.Result, _ mgl64.Vec3) bool {
+	w := s.World()
+	for i := 0; i < 6; i++ {
+		w.AddParticle(r.Position(), particle.SnowballPoof{})
+	}
+	if r, ok := r.(trace.EntityResult); ok {
+		if l, ok := r.Entity().(Living); ok {
+			if _, vulnerable := l.Hurt(0.0, damage.SourceEntityAttack{Attacker: s}); vulnerable {
+				l.KnockBack(r.Position(), 0.45, 0.3608)
 			}
 		}
-
-		s.close = true
 	}
+	return true
 }
 
 // ignores returns whether the snowball should ignore collision with the entity passed.
@@ -15,13 +15,15 @@ import (
 	"time"
 )
 
-// SplashPotion is an item that grants effects when thrown.
+// SplashPotion is an item that grants effects when thrown. A lingering SplashPotion leaves an AreaEffectCloud
+// behind when it shatters, rather than applying its effects to the entities around it directly.
 type SplashPotion struct {
 	transform
 	yaw, pitch float64
 
-	age   int
-	close bool
+	age    int
+	close  bool
+	linger bool
 
 	owner world.Entity
 
@@ -37,7 +39,7 @@ func NewSplashPotion(pos mgl64.Vec3, yaw, pitch float64, owner world.Entity, t p
 		owner: owner,
 
 		t: t,
-		c: &ProjectileComputer{&MovementComputer{
+		c: &ProjectileComputer{MovementComputer: &MovementComputer{
 			Gravity:           0.05,
 			Drag:              0.01,
 			DragBeforeGravity: true,
@@ -48,21 +50,50 @@ func NewSplashPotion(pos mgl64.Vec3, yaw, pitch float64, owner world.Entity, t p
 	return s
 }
 
+// NewLingeringPotion creates a lingering SplashPotion, which leaves an AreaEffectCloud behind when it shatters.
+func NewLingeringPotion(pos mgl64.Vec3, yaw, pitch float64, owner world.Entity, t potion.Potion) *SplashPotion {
+	s := NewSplashPotion(pos, yaw, pitch, owner, t)
+	s.linger = true
+	return s
+}
+
 // Name ...
 func (s *SplashPotion) Name() string {
+	if s.linger {
+		return "Lingering Potion"
+	}
 	return "Splash Potion"
 }
 
 // EncodeEntity ...
 func (s *SplashPotion) EncodeEntity() string {
+	if s.linger {
+		return "minecraft:lingering_potion"
+	}
 	return "minecraft:splash_potion"
 }
 
+// Lingering checks if the SplashPotion is a lingering potion, which leaves an AreaEffectCloud behind when it
+// shatters.
+func (s *SplashPotion) Lingering() bool {
+	return s.linger
+}
+
 // AABB ...
 func (s *SplashPotion) AABB() physics.AABB {
 	return physics.NewAABB(mgl64.Vec3{-0.125, 0, -0.125}, mgl64.Vec3{0.125, 0.25, 0.125})
 }
 
+// MovementConfig ...
+func (s *SplashPotion) MovementConfig() MovementConfig {
+	return s.c.Config()
+}
+
+// SetMovementConfig ...
+func (s *SplashPotion) SetMovementConfig(conf MovementConfig) {
+	s.c.SetConfig(conf)
+}
+
 // Rotation ...
 func (s *SplashPotion) Rotation() (float64, float64) {
 	s.mu.Lock()
@@ -84,6 +115,7 @@ func (s *SplashPotion) Tick(current int64) {
 		return
 	}
 	s.mu.Lock()
+	vel := s.vel
 	m, result := s.c.TickMovement(s, s.pos, s.vel, s.yaw, s.pitch, s.ignores)
 	s.pos, s.vel, s.yaw, s.pitch = m.pos, m.vel, m.yaw, m.pitch
 	s.mu.Unlock()
@@ -91,77 +123,78 @@ func (s *SplashPotion) Tick(current int64) {
 	s.age++
 	m.Send()
 
-	w := s.World()
-	if m.pos[1] < float64(w.Range()[0]) && current%10 == 0 {
-		s.close = true
-		return
-	}
+	s.close = s.c.resolveImpact(s, m, result, vel, current)
+}
 
-	if result != nil {
-		aabb := s.AABB().Translate(m.pos)
+// hit makes the splash potion shatter at the position of the impact, applying its effects to the entities around
+// it or creating an area effect cloud if it lingers.
+func (s *SplashPotion) hit(result trace.Result, _ mgl64.Vec3) bool {
+	w, pos := s.World(), result.Position()
+	aabb := s.AABB().Translate(pos)
+
+	colour := color.RGBA{R: 0x38, G: 0x5d, B: 0xc6, A: 0xff}
+	if effects := s.t.Effects(); len(effects) > 0 && s.linger {
+		colour, _ = effect.ResultingColour(effects)
+		w.AddEntity(NewAreaEffectCloud(pos, s.t))
+	} else if len(effects) > 0 {
+		colour, _ = effect.ResultingColour(effects)
+
+		ignore := func(entity world.Entity) bool {
+			_, living := entity.(Living)
+			return !living || entity == s
+		}
 
-		colour := color.RGBA{R: 0x38, G: 0x5d, B: 0xc6, A: 0xff}
-		if effects := s.t.Effects(); len(effects) > 0 {
-			colour, _ = effect.ResultingColour(effects)
+		for _, e := range w.EntitiesWithin(aabb.GrowVec3(mgl64.Vec3{8.25, 4.25, 8.25}), ignore) {
+			if !e.AABB().Translate(e.Position()).IntersectsWith(aabb.GrowVec3(mgl64.Vec3{4.125, 2.125, 4.125})) {
+				continue
+			}
 
-			ignore := func(entity world.Entity) bool {
-				_, living := entity.(Living)
-				return !living || entity == s
+			dist := world.Distance(e.Position(), pos)
+			if dist > 4 {
+				continue
 			}
 
-			for _, e := range w.EntitiesWithin(aabb.GrowVec3(mgl64.Vec3{8.25, 4.25, 8.25}), ignore) {
-				pos := e.Position()
-				if !e.AABB().Translate(pos).IntersectsWith(aabb.GrowVec3(mgl64.Vec3{4.125, 2.125, 4.125})) {
-					continue
-				}
+			f := 1 - dist/4
+			if entityResult, ok := result.(trace.EntityResult); ok && entityResult.Entity() == e {
+				f = 1
+			}
 
-				dist := world.Distance(pos, m.pos)
-				if dist > 4 {
+			splashed := e.(Living)
+			for _, eff := range effects {
+				if p, ok := eff.Type().(effect.PotentType); ok {
+					splashed.AddEffect(effect.NewInstant(p.WithPotency(f), eff.Level()))
 					continue
 				}
 
-				f := 1 - dist/4
-				if entityResult, ok := result.(trace.EntityResult); ok && entityResult.Entity() == e {
-					f = 1
-				}
-
-				splashed := e.(Living)
-				for _, eff := range effects {
-					if p, ok := eff.Type().(effect.PotentType); ok {
-						splashed.AddEffect(effect.NewInstant(p.WithPotency(f), eff.Level()))
-						continue
-					}
-
-					dur := time.Duration(float64(eff.Duration()) * 0.75 * f)
-					if dur < time.Second {
-						continue
-					}
-					splashed.AddEffect(effect.New(eff.Type().(effect.LastingType), eff.Level(), dur))
+				dur := time.Duration(float64(eff.Duration()) * 0.75 * f)
+				if dur < time.Second {
+					continue
 				}
+				splashed.AddEffect(effect.New(eff.Type().(effect.LastingType), eff.Level(), dur))
+			}
+		}
+	} else if s.t.Equals(potion.Water()) {
+		switch result := result.(type) {
+		case trace.BlockResult:
+			blockPos := result.BlockPosition().Side(result.Face())
+			if w.Block(blockPos) == fire() {
+				w.SetBlock(blockPos, air())
 			}
-		} else if s.t.Equals(potion.Water()) {
-			switch result := result.(type) {
-			case trace.BlockResult:
-				pos := result.BlockPosition().Side(result.Face())
-				if w.Block(pos) == fire() {
-					w.SetBlock(pos, air())
-				}
 
-				for _, f := range cube.HorizontalFaces() {
-					if h := pos.Side(f); w.Block(h) == fire() {
-						w.SetBlock(h, air())
-					}
+			for _, f := range cube.HorizontalFaces() {
+				if h := blockPos.Side(f); w.Block(h) == fire() {
+					w.SetBlock(h, air())
 				}
-			case trace.EntityResult:
-				// TODO: Damage endermen, blazes, striders and snow golems when implemented and rehydrate axolotls.
 			}
+		case trace.EntityResult:
+			// TODO: Damage endermen, blazes, striders and snow golems when implemented and rehydrate axolotls.
 		}
+	}
 
-		w.AddParticle(m.pos, particle.Splash{Colour: colour})
-		w.PlaySound(m.pos, sound.GlassBreak{})
+	w.AddParticle(pos, particle.Splash{Colour: colour})
+	w.PlaySound(pos, sound.GlassBreak{})
 
-		s.close = true
-	}
+	return true
 }
 
 // ignores returns whether the SplashPotion should ignore collision with the entity passed.
@@ -175,6 +208,7 @@ func (s *SplashPotion) ignores(entity world.Entity) bool {
 func (s *SplashPotion) New(pos, vel mgl64.Vec3, yaw, pitch float64, t potion.Potion) world.Entity {
 	splash := NewSplashPotion(pos, yaw, pitch, nil, t)
 	splash.vel = vel
+	splash.linger = s.linger
 	return splash
 }
 
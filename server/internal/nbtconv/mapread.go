@@ -18,6 +18,20 @@ func MapString(m map[string]interface{}, key string) string {
 	return b
 }
 
+// MapStrings reads a string slice from a map at the key passed.
+func MapStrings(m map[string]interface{}, key string) []string {
+	if b, ok := m[key].([]string); ok {
+		return b
+	}
+	var b []string
+	for _, v := range MapSlice(m, key) {
+		if str, ok := v.(string); ok {
+			b = append(b, str)
+		}
+	}
+	return b
+}
+
 // MapInt16 reads an int16 from a map at the key passed.
 func MapInt16(m map[string]interface{}, key string) int16 {
 	b, _ := m[key].(int16)
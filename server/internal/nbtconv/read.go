@@ -17,6 +17,9 @@ func ReadItem(data map[string]interface{}, s *item.Stack) item.Stack {
 	readDamage(data, s, disk)
 	readDisplay(data, s)
 	readEnchantments(data, s)
+	readRestrictions(data, s)
+	readAttributes(data, s)
+	readUnbreakable(data, s)
 	readDragonflyData(data, s)
 	return *s
 }
@@ -73,6 +76,49 @@ func readEnchantments(m map[string]interface{}, s *item.Stack) {
 	}
 }
 
+// readRestrictions reads the blocks that an item.Stack can be placed on and can destroy in adventure mode from the
+// CanPlaceOn and CanDestroy tags of the NBT passed.
+func readRestrictions(m map[string]interface{}, s *item.Stack) {
+	if blocks := BlocksByName(MapStrings(m, "CanPlaceOn")); len(blocks) != 0 {
+		*s = s.WithCanPlaceOn(blocks...)
+	}
+	if blocks := BlocksByName(MapStrings(m, "CanDestroy")); len(blocks) != 0 {
+		*s = s.WithCanDestroy(blocks...)
+	}
+}
+
+// readAttributes reads the attack damage and mining speed overrides stored in the dragonflyAttackDamage and
+// dragonflyMiningSpeed tags of the NBT passed and stores them into an item.Stack.
+func readAttributes(m map[string]interface{}, s *item.Stack) {
+	if dmg, ok := m["dragonflyAttackDamage"].(float64); ok {
+		*s = s.WithAttackDamage(dmg)
+	}
+	if speed, ok := m["dragonflyMiningSpeed"].(float64); ok {
+		*s = s.WithMiningSpeed(speed)
+	}
+}
+
+// readUnbreakable reads the Unbreakable tag of the NBT passed and makes the item.Stack unbreakable if it is set.
+func readUnbreakable(m map[string]interface{}, s *item.Stack) {
+	if MapByte(m, "Unbreakable") == 1 {
+		*s = s.WithUnbreakable(true)
+	}
+}
+
+// BlocksByName returns a block for every name passed, such as 'minecraft:stone'. Names that do not belong to a
+// block with an item form are ignored.
+func BlocksByName(names []string) []world.Block {
+	blocks := make([]world.Block, 0, len(names))
+	for _, name := range names {
+		if it, ok := world.ItemByName(name, 0); ok {
+			if b, ok := it.(world.Block); ok {
+				blocks = append(blocks, b)
+			}
+		}
+	}
+	return blocks
+}
+
 // readDisplay reads the display data present in the display field in the NBT. It includes a custom name of the item
 // and the lore.
 func readDisplay(m map[string]interface{}, s *item.Stack) {
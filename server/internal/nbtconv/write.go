@@ -22,6 +22,9 @@ func WriteItem(s item.Stack, disk bool) map[string]interface{} {
 	writeDamage(m, s, disk)
 	writeDisplay(m, s)
 	writeEnchantments(m, s)
+	writeRestrictions(m, s)
+	writeAttributes(m, s)
+	writeUnbreakable(m, s)
 	writeDragonflyData(m, s)
 	return m
 }
@@ -64,6 +67,24 @@ func writeDragonflyData(m map[string]interface{}, s item.Stack) {
 	}
 }
 
+// writeAttributes writes the attack damage and mining speed set using item.Stack.WithAttackDamage and
+// item.Stack.WithMiningSpeed to a map for NBT encoding, if they were set.
+func writeAttributes(m map[string]interface{}, s item.Stack) {
+	if s.AttackDamage() != item.NewStack(s.Item(), 1).AttackDamage() {
+		m["dragonflyAttackDamage"] = s.AttackDamage()
+	}
+	if s.MiningSpeed() != 1 {
+		m["dragonflyMiningSpeed"] = s.MiningSpeed()
+	}
+}
+
+// writeUnbreakable writes the Unbreakable tag to a map for NBT encoding if the item.Stack is unbreakable.
+func writeUnbreakable(m map[string]interface{}, s item.Stack) {
+	if s.Unbreakable() {
+		m["Unbreakable"] = byte(1)
+	}
+}
+
 // writeEnchantments writes the enchantments of an item to a map for NBT encoding.
 func writeEnchantments(m map[string]interface{}, s item.Stack) {
 	if len(s.Enchantments()) != 0 {
@@ -80,6 +101,27 @@ func writeEnchantments(m map[string]interface{}, s item.Stack) {
 	}
 }
 
+// writeRestrictions writes the blocks that an item.Stack can be placed on and can destroy in adventure mode to a map
+// for NBT encoding.
+func writeRestrictions(m map[string]interface{}, s item.Stack) {
+	if len(s.CanPlaceOn()) != 0 {
+		m["CanPlaceOn"] = BlockNames(s.CanPlaceOn())
+	}
+	if len(s.CanDestroy()) != 0 {
+		m["CanDestroy"] = BlockNames(s.CanDestroy())
+	}
+}
+
+// BlockNames returns the names of the blocks passed, such as 'minecraft:stone'.
+func BlockNames(blocks []world.Block) []string {
+	names := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		name, _ := b.EncodeBlock()
+		names = append(names, name)
+	}
+	return names
+}
+
 // writeDisplay writes the display name and lore of an item to a map for NBT encoding.
 func writeDisplay(m map[string]interface{}, s item.Stack) {
 	name, lore := s.CustomName(), s.Lore()
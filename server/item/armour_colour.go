@@ -0,0 +1,24 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/item/armour"
+	"image/color"
+)
+
+// encodeArmourColour encodes the colour of a piece of armour with the tier passed to its NBT representation. Only
+// leather armour may be dyed, so nil is returned for any other tier or if the colour is not set.
+func encodeArmourColour(tier armour.Tier, c color.RGBA) map[string]interface{} {
+	if tier != armour.TierLeather || c.A == 0 {
+		return nil
+	}
+	return map[string]interface{}{"customColor": int32(uint32(c.A)<<24 | uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B))}
+}
+
+// decodeArmourColour decodes the colour of a piece of armour with the tier passed from the NBT data passed.
+func decodeArmourColour(tier armour.Tier, data map[string]interface{}) color.RGBA {
+	v, ok := data["customColor"].(int32)
+	if tier != armour.TierLeather || !ok {
+		return color.RGBA{}
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: uint8(v >> 24)}
+}
@@ -0,0 +1,23 @@
+package item
+
+import "github.com/df-mc/dragonfly/server/item/potion"
+
+// Arrow is used as ammunition for bows and crossbows.
+type Arrow struct {
+	// Tip is the potion effect that is tipped on the arrow. Arrows with a potion that has no effects, such as
+	// water, are regular arrows.
+	Tip potion.Potion
+}
+
+// Tipped checks if the arrow is tipped with a potion that has effects.
+func (a Arrow) Tipped() bool {
+	return a.Tip.Uint8() > 4
+}
+
+// EncodeItem ...
+func (a Arrow) EncodeItem() (name string, meta int16) {
+	if a.Tipped() {
+		return "minecraft:arrow", int16(a.Tip.Uint8()) + 1
+	}
+	return "minecraft:arrow", 0
+}
@@ -71,6 +71,11 @@ func (a Axe) BaseMiningEfficiency(world.Block) float64 {
 	return a.Tier.BaseMiningEfficiency
 }
 
+// FireProof returns true if the axe is made of netherite.
+func (a Axe) FireProof() bool {
+	return a.Tier == tool.TierNetherite
+}
+
 // EncodeItem ...
 func (a Axe) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + a.Tier.Name + "_axe", 0
@@ -0,0 +1,62 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BannerPattern is an item used to apply a special pattern to a banner in a loom. Unlike other patterns, these
+// patterns may only be applied when the item is in the loom. The item is not consumed when applying the pattern.
+type BannerPattern struct {
+	// Type is the type of the banner pattern. Only BannerPatternCreeper, BannerPatternSkull,
+	// BannerPatternFlower, BannerPatternMojang, BannerPatternBricks, BannerPatternCurlyBorder,
+	// BannerPatternPiglin and BannerPatternGlobe have a banner pattern item.
+	Type BannerPatternType
+}
+
+// MaxCount always returns 1.
+func (BannerPattern) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (b BannerPattern) EncodeItem() (name string, meta int16) {
+	for i, t := range bannerPatternItemTypes {
+		if t == b.Type {
+			return "minecraft:banner_pattern", int16(i)
+		}
+	}
+	panic("no banner pattern item for banner pattern type " + b.Type.String())
+}
+
+// RequiresItem checks if a BannerPattern item of the banner pattern type is required to apply the pattern to a
+// banner in a loom.
+func (b BannerPatternType) RequiresItem() bool {
+	for _, t := range bannerPatternItemTypes {
+		if t == b {
+			return true
+		}
+	}
+	return false
+}
+
+// AllBannerPatterns returns all banner pattern items.
+func AllBannerPatterns() []world.Item {
+	b := make([]world.Item, 0, len(bannerPatternItemTypes))
+	for _, t := range bannerPatternItemTypes {
+		b = append(b, BannerPattern{Type: t})
+	}
+	return b
+}
+
+// bannerPatternItemTypes holds all banner pattern types that have a banner pattern item, indexed by the meta of
+// the item.
+var bannerPatternItemTypes = [...]BannerPatternType{
+	BannerPatternCreeper(),
+	BannerPatternSkull(),
+	BannerPatternFlower(),
+	BannerPatternMojang(),
+	BannerPatternBricks(),
+	BannerPatternCurlyBorder(),
+	BannerPatternPiglin(),
+	BannerPatternGlobe(),
+}
@@ -0,0 +1,282 @@
+package item
+
+// BannerPatternType represents a pattern that may be applied to a banner as one of its layers using a loom.
+type BannerPatternType struct {
+	bannerPatternType
+}
+
+// BannerPatternBorder is a banner pattern showing a border around the banner.
+func BannerPatternBorder() BannerPatternType {
+	return BannerPatternType{0}
+}
+
+// BannerPatternBricks is a banner pattern showing a brick pattern.
+func BannerPatternBricks() BannerPatternType {
+	return BannerPatternType{1}
+}
+
+// BannerPatternCircle is a banner pattern showing a circle in the centre of the banner.
+func BannerPatternCircle() BannerPatternType {
+	return BannerPatternType{2}
+}
+
+// BannerPatternCreeper is a banner pattern showing the face of a creeper.
+func BannerPatternCreeper() BannerPatternType {
+	return BannerPatternType{3}
+}
+
+// BannerPatternCross is a banner pattern showing a diagonal cross.
+func BannerPatternCross() BannerPatternType {
+	return BannerPatternType{4}
+}
+
+// BannerPatternCurlyBorder is a banner pattern showing a curly border around the banner.
+func BannerPatternCurlyBorder() BannerPatternType {
+	return BannerPatternType{5}
+}
+
+// BannerPatternDiagonalLeft is a banner pattern showing the upper left half of the banner, split diagonally.
+func BannerPatternDiagonalLeft() BannerPatternType {
+	return BannerPatternType{6}
+}
+
+// BannerPatternDiagonalRight is a banner pattern showing the upper right half of the banner, split diagonally.
+func BannerPatternDiagonalRight() BannerPatternType {
+	return BannerPatternType{7}
+}
+
+// BannerPatternDiagonalUpLeft is a banner pattern showing the lower left half of the banner, split diagonally.
+func BannerPatternDiagonalUpLeft() BannerPatternType {
+	return BannerPatternType{8}
+}
+
+// BannerPatternDiagonalUpRight is a banner pattern showing the lower right half of the banner, split diagonally.
+func BannerPatternDiagonalUpRight() BannerPatternType {
+	return BannerPatternType{9}
+}
+
+// BannerPatternFlower is a banner pattern showing a flower.
+func BannerPatternFlower() BannerPatternType {
+	return BannerPatternType{10}
+}
+
+// BannerPatternGlobe is a banner pattern showing a globe.
+func BannerPatternGlobe() BannerPatternType {
+	return BannerPatternType{11}
+}
+
+// BannerPatternGradient is a banner pattern showing a gradient fading out towards the bottom of the banner.
+func BannerPatternGradient() BannerPatternType {
+	return BannerPatternType{12}
+}
+
+// BannerPatternGradientUp is a banner pattern showing a gradient fading out towards the top of the banner.
+func BannerPatternGradientUp() BannerPatternType {
+	return BannerPatternType{13}
+}
+
+// BannerPatternHalfHorizontal is a banner pattern showing the top half of the banner.
+func BannerPatternHalfHorizontal() BannerPatternType {
+	return BannerPatternType{14}
+}
+
+// BannerPatternHalfHorizontalBottom is a banner pattern showing the bottom half of the banner.
+func BannerPatternHalfHorizontalBottom() BannerPatternType {
+	return BannerPatternType{15}
+}
+
+// BannerPatternHalfVertical is a banner pattern showing the left half of the banner.
+func BannerPatternHalfVertical() BannerPatternType {
+	return BannerPatternType{16}
+}
+
+// BannerPatternHalfVerticalRight is a banner pattern showing the right half of the banner.
+func BannerPatternHalfVerticalRight() BannerPatternType {
+	return BannerPatternType{17}
+}
+
+// BannerPatternMojang is a banner pattern showing the Mojang logo.
+func BannerPatternMojang() BannerPatternType {
+	return BannerPatternType{18}
+}
+
+// BannerPatternPiglin is a banner pattern showing the snout of a piglin.
+func BannerPatternPiglin() BannerPatternType {
+	return BannerPatternType{19}
+}
+
+// BannerPatternRhombus is a banner pattern showing a rhombus in the centre of the banner.
+func BannerPatternRhombus() BannerPatternType {
+	return BannerPatternType{20}
+}
+
+// BannerPatternSkull is a banner pattern showing a skull.
+func BannerPatternSkull() BannerPatternType {
+	return BannerPatternType{21}
+}
+
+// BannerPatternSmallStripes is a banner pattern showing small vertical stripes.
+func BannerPatternSmallStripes() BannerPatternType {
+	return BannerPatternType{22}
+}
+
+// BannerPatternSquareBottomLeft is a banner pattern showing a square in the bottom left corner of the banner.
+func BannerPatternSquareBottomLeft() BannerPatternType {
+	return BannerPatternType{23}
+}
+
+// BannerPatternSquareBottomRight is a banner pattern showing a square in the bottom right corner of the banner.
+func BannerPatternSquareBottomRight() BannerPatternType {
+	return BannerPatternType{24}
+}
+
+// BannerPatternSquareTopLeft is a banner pattern showing a square in the top left corner of the banner.
+func BannerPatternSquareTopLeft() BannerPatternType {
+	return BannerPatternType{25}
+}
+
+// BannerPatternSquareTopRight is a banner pattern showing a square in the top right corner of the banner.
+func BannerPatternSquareTopRight() BannerPatternType {
+	return BannerPatternType{26}
+}
+
+// BannerPatternStraightCross is a banner pattern showing a straight cross.
+func BannerPatternStraightCross() BannerPatternType {
+	return BannerPatternType{27}
+}
+
+// BannerPatternStripeBottom is a banner pattern showing a horizontal stripe at the bottom of the banner.
+func BannerPatternStripeBottom() BannerPatternType {
+	return BannerPatternType{28}
+}
+
+// BannerPatternStripeCentre is a banner pattern showing a vertical stripe in the centre of the banner.
+func BannerPatternStripeCentre() BannerPatternType {
+	return BannerPatternType{29}
+}
+
+// BannerPatternStripeDownLeft is a banner pattern showing a diagonal stripe from the top right to the bottom left of the banner.
+func BannerPatternStripeDownLeft() BannerPatternType {
+	return BannerPatternType{30}
+}
+
+// BannerPatternStripeDownRight is a banner pattern showing a diagonal stripe from the top left to the bottom right of the banner.
+func BannerPatternStripeDownRight() BannerPatternType {
+	return BannerPatternType{31}
+}
+
+// BannerPatternStripeLeft is a banner pattern showing a vertical stripe on the left of the banner.
+func BannerPatternStripeLeft() BannerPatternType {
+	return BannerPatternType{32}
+}
+
+// BannerPatternStripeMiddle is a banner pattern showing a horizontal stripe in the middle of the banner.
+func BannerPatternStripeMiddle() BannerPatternType {
+	return BannerPatternType{33}
+}
+
+// BannerPatternStripeRight is a banner pattern showing a vertical stripe on the right of the banner.
+func BannerPatternStripeRight() BannerPatternType {
+	return BannerPatternType{34}
+}
+
+// BannerPatternStripeTop is a banner pattern showing a horizontal stripe at the top of the banner.
+func BannerPatternStripeTop() BannerPatternType {
+	return BannerPatternType{35}
+}
+
+// BannerPatternTriangleBottom is a banner pattern showing a triangle at the bottom of the banner.
+func BannerPatternTriangleBottom() BannerPatternType {
+	return BannerPatternType{36}
+}
+
+// BannerPatternTriangleTop is a banner pattern showing a triangle at the top of the banner.
+func BannerPatternTriangleTop() BannerPatternType {
+	return BannerPatternType{37}
+}
+
+// BannerPatternTrianglesBottom is a banner pattern showing small triangles along the bottom of the banner.
+func BannerPatternTrianglesBottom() BannerPatternType {
+	return BannerPatternType{38}
+}
+
+// BannerPatternTrianglesTop is a banner pattern showing small triangles along the top of the banner.
+func BannerPatternTrianglesTop() BannerPatternType {
+	return BannerPatternType{39}
+}
+
+// BannerPatternTypes returns all banner pattern types.
+func BannerPatternTypes() []BannerPatternType {
+	t := make([]BannerPatternType, 0, len(bannerPatternCodes))
+	for i := range bannerPatternCodes {
+		t = append(t, BannerPatternType{bannerPatternType(i)})
+	}
+	return t
+}
+
+// BannerPatternByCode returns the banner pattern type with the code passed, as returned by BannerPatternType.String.
+// If no pattern type with the code exists, false is returned.
+func BannerPatternByCode(code string) (BannerPatternType, bool) {
+	for i, c := range bannerPatternCodes {
+		if c == code {
+			return BannerPatternType{bannerPatternType(i)}, true
+		}
+	}
+	return BannerPatternType{}, false
+}
+
+// bannerPatternCodes holds the codes of all banner pattern types, indexed by their bannerPatternType value.
+var bannerPatternCodes = [...]string{
+	"bo",
+	"bri",
+	"mc",
+	"cre",
+	"cr",
+	"cbo",
+	"ld",
+	"rud",
+	"lud",
+	"rd",
+	"flo",
+	"glb",
+	"gra",
+	"gru",
+	"hh",
+	"hhb",
+	"vh",
+	"vhr",
+	"moj",
+	"pig",
+	"mr",
+	"sku",
+	"ss",
+	"bl",
+	"br",
+	"tl",
+	"tr",
+	"sc",
+	"bs",
+	"cs",
+	"dls",
+	"drs",
+	"ls",
+	"ms",
+	"rs",
+	"ts",
+	"bt",
+	"tt",
+	"bts",
+	"tts",
+}
+
+type bannerPatternType uint8
+
+// Uint8 returns the banner pattern type as a uint8.
+func (b bannerPatternType) Uint8() uint8 {
+	return uint8(b)
+}
+
+// String returns the code of the banner pattern type, which is used to store the pattern in NBT.
+func (b bannerPatternType) String() string {
+	return bannerPatternCodes[b]
+}
@@ -10,13 +10,17 @@ import (
 // BoneMeal is an item used to force growth in plants & crops.
 type BoneMeal struct{}
 
-// BoneMealAffected represents a block that is affected when bone meal is used on it.
+// BoneMealAffected represents a block that is affected when bone meal is used on it. Blocks should refer to it
+// as block.BoneMealAffected.
 type BoneMealAffected interface {
-	// BoneMeal attempts to affect the block using a bone meal item.
+	// BoneMeal attempts to affect the block at the position passed using a bone meal item, for example by growing
+	// it. True is returned if the block was affected, in which case the bone meal is consumed and bone meal
+	// particles are shown around the block. If false is returned, nothing happens.
 	BoneMeal(pos cube.Pos, w *world.World) bool
 }
 
-// UseOnBlock ...
+// UseOnBlock uses the bone meal on the block at the position passed if it implements BoneMealAffected. One bone
+// meal is consumed and particles are shown if the block was affected.
 func (b BoneMeal) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, w *world.World, _ User, ctx *UseContext) bool {
 	if bm, ok := w.Block(pos).(BoneMealAffected); ok && bm.BoneMeal(pos, w) {
 		ctx.CountSub = 1
@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/df-mc/dragonfly/server/item/armour"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Boots are a defensive item that may be equipped in the boots armour slot. They come in several tiers, like
@@ -10,6 +11,9 @@ import (
 type Boots struct {
 	// Tier is the tier of the boots.
 	Tier armour.Tier
+	// Colour is the dyed colour of the boots. It is only used for leather armour. If the alpha channel of the colour
+	// is 0, the armour has its default colour.
+	Colour color.RGBA
 }
 
 // Use handles the auto-equipping of boots in the armour slot when using it.
@@ -54,6 +58,22 @@ func (b Boots) Boots() bool {
 	return true
 }
 
+// EncodeNBT ...
+func (b Boots) EncodeNBT() map[string]interface{} {
+	return encodeArmourColour(b.Tier, b.Colour)
+}
+
+// DecodeNBT ...
+func (b Boots) DecodeNBT(data map[string]interface{}) interface{} {
+	b.Colour = decodeArmourColour(b.Tier, data)
+	return b
+}
+
+// FireProof returns true if the boots are made of netherite.
+func (b Boots) FireProof() bool {
+	return b.Tier == armour.TierNetherite
+}
+
 // EncodeItem ...
 func (b Boots) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + b.Tier.Name + "_boots", 0
@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 )
@@ -32,15 +33,21 @@ func (b Bucket) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 		return b.fillFrom(pos, w, ctx)
 	}
 	liq := b.Content.WithDepth(8, false)
-	if bl := w.Block(pos); canDisplace(bl, liq) || replaceableWith(bl, liq) {
-		w.SetLiquid(pos, liq)
-	} else if bl := w.Block(pos.Side(face)); canDisplace(bl, liq) || replaceableWith(bl, liq) {
-		w.SetLiquid(pos.Side(face), liq)
+	if bl := w.Block(pos); !canDisplace(bl, liq) && !replaceableWith(bl, liq) {
+		// The liquid can neither waterlog nor replace the block clicked, so it is placed on the side clicked.
+		pos = pos.Side(face)
+		if bl := w.Block(pos); !canDisplace(bl, liq) && !replaceableWith(bl, liq) {
+			return false
+		}
+	}
+	if liq.LiquidType() == "water" && w.Dimension().WaterEvaporates() {
+		// Water placed in a dimension such as the nether evaporates immediately.
+		w.PlaySound(pos.Vec3Centre(), sound.Fizz{})
+		w.AddParticle(pos.Vec3(), particle.Evaporate{})
 	} else {
-		return false
+		w.SetLiquid(pos, liq)
+		w.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{Liquid: b.Content})
 	}
-
-	w.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{Liquid: b.Content})
 	ctx.NewItem = NewStack(Bucket{}, 1)
 	ctx.NewItemSurvivalOnly = true
 	ctx.SubtractFromCount(1)
@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/df-mc/dragonfly/server/item/armour"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Chestplate is a defensive item that may be equipped in the chestplate slot. Generally, chestplates provide
@@ -10,6 +11,9 @@ import (
 type Chestplate struct {
 	// Tier is the tier of the chestplate.
 	Tier armour.Tier
+	// Colour is the dyed colour of the chestplate. It is only used for leather armour. If the alpha channel of the colour
+	// is 0, the armour has its default colour.
+	Colour color.RGBA
 }
 
 // Use handles the using of a chestplate to auto-equip it in the designated armour slot.
@@ -56,6 +60,22 @@ func (c Chestplate) Chestplate() bool {
 	return true
 }
 
+// EncodeNBT ...
+func (c Chestplate) EncodeNBT() map[string]interface{} {
+	return encodeArmourColour(c.Tier, c.Colour)
+}
+
+// DecodeNBT ...
+func (c Chestplate) DecodeNBT(data map[string]interface{}) interface{} {
+	c.Colour = decodeArmourColour(c.Tier, data)
+	return c
+}
+
+// FireProof returns true if the chestplate is made of netherite.
+func (c Chestplate) FireProof() bool {
+	return c.Tier == armour.TierNetherite
+}
+
 // EncodeItem ...
 func (c Chestplate) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + c.Tier.Name + "_chestplate", 0
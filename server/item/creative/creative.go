@@ -89,4 +89,7 @@ func init() {
 		}
 		RegisterItem(item.NewStack(it, 1))
 	}
+	for _, f := range item.FireworkPresets() {
+		RegisterItem(item.NewStack(f, 1))
+	}
 }
@@ -0,0 +1,84 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// Crossbow is a ranged weapon that, unlike a bow, is charged with a projectile before it is fired. A charged
+// crossbow stays charged until it is used, even if it is put away. Crossbows may be charged with arrows and
+// firework rockets.
+type Crossbow struct {
+	// Item is the projectile that the crossbow is charged with. If empty, the crossbow is not charged.
+	Item Stack
+}
+
+// CrossbowChargeDuration is the time a crossbow without quick charge must be used to charge it.
+const CrossbowChargeDuration = time.Millisecond * 1250
+
+// Charged checks if the crossbow is charged with a projectile.
+func (c Crossbow) Charged() bool {
+	return !c.Item.Empty()
+}
+
+// Ammunition checks if the item passed may be used to charge a crossbow.
+func (c Crossbow) Ammunition(i world.Item) bool {
+	switch i.(type) {
+	case Arrow, Firework:
+		return true
+	}
+	return false
+}
+
+// MaxCount ...
+func (c Crossbow) MaxCount() int {
+	return 1
+}
+
+// DurabilityInfo ...
+func (c Crossbow) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 464,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// EncodeNBT ...
+func (c Crossbow) EncodeNBT() map[string]interface{} {
+	if !c.Charged() {
+		return nil
+	}
+	name, meta := c.Item.Item().EncodeItem()
+	charged := map[string]interface{}{"Name": name, "Damage": meta, "Count": uint8(1)}
+	if nbt, ok := c.Item.Item().(world.NBTer); ok {
+		charged["tag"] = nbt.EncodeNBT()
+	}
+	return map[string]interface{}{"chargedItem": charged}
+}
+
+// DecodeNBT ...
+func (c Crossbow) DecodeNBT(data map[string]interface{}) interface{} {
+	c.Item = Stack{}
+	charged, ok := data["chargedItem"].(map[string]interface{})
+	if !ok {
+		return c
+	}
+	name, _ := charged["Name"].(string)
+	meta, _ := charged["Damage"].(int16)
+	it, ok := world.ItemByName(name, meta)
+	if !ok {
+		return c
+	}
+	if nbt, ok := it.(world.NBTer); ok {
+		if tag, ok := charged["tag"].(map[string]interface{}); ok {
+			it = nbt.DecodeNBT(tag).(world.Item)
+		}
+	}
+	c.Item = NewStack(it, 1)
+	return c
+}
+
+// EncodeItem ...
+func (c Crossbow) EncodeItem() (name string, meta int16) {
+	return "minecraft:crossbow", 0
+}
@@ -23,6 +23,14 @@ type Enchantment interface {
 	CompatibleWith(s Stack) bool
 }
 
+// Curse represents an Enchantment that is a curse. Unlike other enchantments, curses are not removed when an
+// item is put in a grindstone.
+type Curse interface {
+	Enchantment
+	// Curse returns true if the enchantment is a curse.
+	Curse() bool
+}
+
 // RegisterEnchantment registers an enchantment with the ID passed. Once registered, enchantments may be received
 // by instantiating an Enchantment struct (e.g. enchantment.Protection{})
 func RegisterEnchantment(id int, enchantment Enchantment) {
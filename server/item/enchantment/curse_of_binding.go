@@ -0,0 +1,36 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/armour"
+)
+
+// CurseOfBinding is an armour enchantment that prevents the armour it is applied to from being removed by its
+// wearer, unless the wearer is in creative mode.
+type CurseOfBinding struct{ enchantment }
+
+// Name ...
+func (e CurseOfBinding) Name() string {
+	return "Curse of Binding"
+}
+
+// MaxLevel ...
+func (e CurseOfBinding) MaxLevel() int {
+	return 1
+}
+
+// WithLevel ...
+func (e CurseOfBinding) WithLevel(level int) item.Enchantment {
+	return CurseOfBinding{e.withLevel(level, e)}
+}
+
+// CompatibleWith ...
+func (e CurseOfBinding) CompatibleWith(s item.Stack) bool {
+	_, ok := s.Item().(armour.Armour)
+	return ok
+}
+
+// Curse always returns true.
+func (e CurseOfBinding) Curse() bool {
+	return true
+}
@@ -0,0 +1,34 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/armour"
+)
+
+// FrostWalker is an enchantment to boots that protects the wearer from the damage of hot floors, such as magma
+// blocks.
+// TODO: Freeze water below the wearer.
+type FrostWalker struct {
+	enchantment
+}
+
+// Name ...
+func (e FrostWalker) Name() string {
+	return "Frost Walker"
+}
+
+// MaxLevel ...
+func (e FrostWalker) MaxLevel() int {
+	return 2
+}
+
+// WithLevel ...
+func (e FrostWalker) WithLevel(level int) item.Enchantment {
+	return FrostWalker{e.withLevel(level, e)}
+}
+
+// CompatibleWith ...
+func (e FrostWalker) CompatibleWith(s item.Stack) bool {
+	b, ok := s.Item().(armour.Boots)
+	return ok && b.Boots()
+}
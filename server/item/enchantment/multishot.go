@@ -0,0 +1,29 @@
+package enchantment
+
+import "github.com/df-mc/dragonfly/server/item"
+
+// Multishot is an enchantment applied to a crossbow that makes it shoot three projectiles in a spread instead
+// of one, while only using up one projectile.
+type Multishot struct{ enchantment }
+
+// Name ...
+func (e Multishot) Name() string {
+	return "Multishot"
+}
+
+// MaxLevel ...
+func (e Multishot) MaxLevel() int {
+	return 1
+}
+
+// WithLevel ...
+func (e Multishot) WithLevel(level int) item.Enchantment {
+	return Multishot{e.withLevel(level, e)}
+}
+
+// CompatibleWith ...
+func (e Multishot) CompatibleWith(s item.Stack) bool {
+	_, ok := s.Item().(item.Crossbow)
+	_, piercing := s.Enchantment(Piercing{})
+	return ok && !piercing
+}
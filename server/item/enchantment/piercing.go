@@ -0,0 +1,29 @@
+package enchantment
+
+import "github.com/df-mc/dragonfly/server/item"
+
+// Piercing is an enchantment applied to a crossbow that makes the arrows it shoots pass through entities. Every
+// level of the enchantment allows an arrow to pass through one more entity.
+type Piercing struct{ enchantment }
+
+// Name ...
+func (e Piercing) Name() string {
+	return "Piercing"
+}
+
+// MaxLevel ...
+func (e Piercing) MaxLevel() int {
+	return 4
+}
+
+// WithLevel ...
+func (e Piercing) WithLevel(level int) item.Enchantment {
+	return Piercing{e.withLevel(level, e)}
+}
+
+// CompatibleWith ...
+func (e Piercing) CompatibleWith(s item.Stack) bool {
+	_, ok := s.Item().(item.Crossbow)
+	_, multishot := s.Enchantment(Multishot{})
+	return ok && !multishot
+}
@@ -106,7 +106,7 @@ type Protection struct {
 
 // Affects ...
 func (e Protection) Affects(src damage.Source) bool {
-	return src == damage.SourceEntityAttack{} || src == damage.SourceFall{} || src == damage.SourceFire{} || src == damage.SourceFireTick{} || src == damage.SourceLava{}
+	return src == damage.SourceEntityAttack{} || src == damage.SourceFall{} || src == damage.SourceFire{} || src == damage.SourceFireTick{} || src == damage.SourceLava{} || src == damage.SourceHotFloor{}
 }
 
 // Subtrahend returns the amount of damage that should be subtracted with protection.
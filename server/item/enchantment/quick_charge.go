@@ -0,0 +1,35 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"time"
+)
+
+// QuickCharge is an enchantment applied to a crossbow that reduces the time it takes to charge it.
+type QuickCharge struct{ enchantment }
+
+// ChargeDuration returns the time it takes to charge a crossbow with quick charge of the level passed.
+func (e QuickCharge) ChargeDuration(level int) time.Duration {
+	return time.Duration(1250-250*level) * time.Millisecond
+}
+
+// Name ...
+func (e QuickCharge) Name() string {
+	return "Quick Charge"
+}
+
+// MaxLevel ...
+func (e QuickCharge) MaxLevel() int {
+	return 3
+}
+
+// WithLevel ...
+func (e QuickCharge) WithLevel(level int) item.Enchantment {
+	return QuickCharge{e.withLevel(level, e)}
+}
+
+// CompatibleWith ...
+func (e QuickCharge) CompatibleWith(s item.Stack) bool {
+	_, ok := s.Item().(item.Crossbow)
+	return ok
+}
@@ -28,16 +28,16 @@ func init() {
 	// TODO: (22) Infinity.
 	// TODO: (23) Luck of the Sea.
 	// TODO: (24) Lure.
-	// TODO: (25) Frost Walker.
+	item.RegisterEnchantment(25, FrostWalker{})
 	// TODO: (26) Mending.
-	// TODO: (27) Curse of Binding.
+	item.RegisterEnchantment(27, CurseOfBinding{})
 	// TODO: (28) Curse of Vanishing.
 	// TODO: (29) Impaling.
 	// TODO: (30) Riptide.
 	// TODO: (31) Loyalty.
 	// TODO: (32) Channeling.
-	// TODO: (33) Multishot.
-	// TODO: (34) Piercing.
-	// TODO: (35) Quick Charge.
+	item.RegisterEnchantment(33, Multishot{})
+	item.RegisterEnchantment(34, Piercing{})
+	item.RegisterEnchantment(35, QuickCharge{})
 	// TODO: (36) Soul Speed.
 }
@@ -0,0 +1,109 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Firework is an item (and entity) used for creating decorative explosions, boosting when flying with elytra,
+// and loading into a crossbow as ammunition.
+type Firework struct {
+	// Duration is the flight duration of the firework. The duration is stored as a flight level, which is one
+	// half second of flight per level, typically ranging from 1 to 3.
+	Duration time.Duration
+	// Explosions is the list of explosions the firework should create when it explodes. If empty, the firework
+	// will not show any explosion particles and will not damage entities around it.
+	Explosions []FireworkExplosion
+}
+
+// UseOnBlock ...
+func (f Firework) UseOnBlock(pos cube.Pos, _ cube.Face, clickPos mgl64.Vec3, w *world.World, user User, ctx *UseContext) bool {
+	firework, ok := world.EntityByName("minecraft:fireworks_rocket")
+	if !ok {
+		return false
+	}
+	p, ok := firework.(interface {
+		New(pos, vel mgl64.Vec3, yaw, pitch float64, firework Firework, owner world.Entity, directional bool) world.Entity
+	})
+	if !ok {
+		return false
+	}
+
+	// Fireworks launched from a block drift slightly in a random direction while flying upwards.
+	vel := mgl64.Vec3{rand.NormFloat64() * 0.001, 0.05, rand.NormFloat64() * 0.001}
+	w.AddEntity(p.New(pos.Vec3().Add(clickPos), vel, 0, 90, f, user, false))
+	w.PlaySound(pos.Vec3().Add(clickPos), sound.FireworkLaunch{})
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// FireworkPresets returns a few preset firework rockets: Rockets without explosions for every flight duration
+// and a rocket for each explosion shape other than the small sphere, of which vanilla already has variants in
+// every colour. The presets are registered as creative items.
+func FireworkPresets() []Firework {
+	presets := []Firework{{Duration: time.Second}, {Duration: time.Second * 3 / 2}}
+	colours := []Colour{ColourRed(), ColourYellow(), ColourLightBlue(), ColourLime()}
+	for i, shape := range FireworkShapes()[1:] {
+		presets = append(presets, Firework{Duration: time.Second, Explosions: []FireworkExplosion{{
+			Shape:   shape,
+			Colours: []Colour{colours[i]},
+			Fades:   []Colour{ColourWhite()},
+			Twinkle: i%2 == 0,
+			Trail:   i%2 == 1,
+		}}})
+	}
+	return presets
+}
+
+// RandomisedDuration returns the total time that the firework flies for before exploding. It is based on the
+// Duration of the firework, with a small random offset added to it.
+func (f Firework) RandomisedDuration() time.Duration {
+	return f.Duration + time.Second/2 + time.Duration(rand.Intn(6)+rand.Intn(7))*time.Second/20
+}
+
+// MaxCount ...
+func (f Firework) MaxCount() int {
+	return 64
+}
+
+// EncodeNBT ...
+func (f Firework) EncodeNBT() map[string]interface{} {
+	explosions := make([]interface{}, 0, len(f.Explosions))
+	for _, explosion := range f.Explosions {
+		explosions = append(explosions, explosion.EncodeNBT())
+	}
+	return map[string]interface{}{"Fireworks": map[string]interface{}{
+		"Explosions": explosions,
+		"Flight":     uint8(f.Duration / (time.Second / 2)),
+	}}
+}
+
+// DecodeNBT ...
+func (f Firework) DecodeNBT(data map[string]interface{}) interface{} {
+	fireworks, ok := data["Fireworks"].(map[string]interface{})
+	if !ok {
+		return f
+	}
+	if flight, ok := fireworks["Flight"].(uint8); ok {
+		f.Duration = time.Duration(flight) * (time.Second / 2)
+	}
+	f.Explosions = nil
+	if explosions, ok := fireworks["Explosions"].([]interface{}); ok {
+		for _, explosion := range explosions {
+			if m, ok := explosion.(map[string]interface{}); ok {
+				f.Explosions = append(f.Explosions, FireworkExplosion{}.DecodeNBT(m).(FireworkExplosion))
+			}
+		}
+	}
+	return f
+}
+
+// EncodeItem ...
+func (Firework) EncodeItem() (name string, meta int16) {
+	return "minecraft:firework_rocket", 0
+}
@@ -0,0 +1,81 @@
+package item
+
+import (
+	"reflect"
+)
+
+// FireworkExplosion represents an explosion of a firework, which is shown when the firework explodes at the
+// end of its flight. A Firework may hold multiple explosions.
+type FireworkExplosion struct {
+	// Shape represents the shape of the explosion.
+	Shape FireworkShape
+	// Colours holds the colours of the explosion. At least one colour should be set.
+	Colours []Colour
+	// Fades holds the colours that the explosion fades to. If left empty, the explosion does not fade.
+	Fades []Colour
+	// Twinkle specifies if the explosion twinkles, or flickers, after exploding.
+	Twinkle bool
+	// Trail specifies if the explosion leaves a trail of particles behind.
+	Trail bool
+}
+
+// EncodeNBT ...
+func (f FireworkExplosion) EncodeNBT() map[string]interface{} {
+	return map[string]interface{}{
+		"FireworkType":    f.Shape.Uint8(),
+		"FireworkColor":   encodeFireworkColours(f.Colours),
+		"FireworkFade":    encodeFireworkColours(f.Fades),
+		"FireworkFlicker": boolByte(f.Twinkle),
+		"FireworkTrail":   boolByte(f.Trail),
+	}
+}
+
+// DecodeNBT ...
+func (f FireworkExplosion) DecodeNBT(data map[string]interface{}) interface{} {
+	if t, ok := data["FireworkType"].(uint8); ok && int(t) < len(FireworkShapes()) {
+		f.Shape = FireworkShape{fireworkShape(t)}
+	}
+	f.Colours = decodeFireworkColours(data["FireworkColor"])
+	f.Fades = decodeFireworkColours(data["FireworkFade"])
+	if t, ok := data["FireworkFlicker"].(uint8); ok {
+		f.Twinkle = t == 1
+	}
+	if t, ok := data["FireworkTrail"].(uint8); ok {
+		f.Trail = t == 1
+	}
+	return f
+}
+
+// encodeFireworkColours encodes a list of colours into a byte array for NBT. Firework colours are stored as
+// their dye metadata values, which are the inverse of the Colour values. An array is returned rather than a
+// slice, as only arrays are encoded as a TAG_ByteArray.
+func encodeFireworkColours(colours []Colour) interface{} {
+	v := reflect.New(reflect.ArrayOf(len(colours), reflect.TypeOf(uint8(0)))).Elem()
+	for i, c := range colours {
+		v.Index(i).Set(reflect.ValueOf(15 - c.Uint8()))
+	}
+	return v.Interface()
+}
+
+// decodeFireworkColours decodes a list of colours from an NBT byte array. Unknown colours are ignored.
+func decodeFireworkColours(data interface{}) []Colour {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil
+	}
+	colours := make([]Colour, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if c := uint8(v.Index(i).Uint()); c <= 15 {
+			colours = append(colours, Colour{colour(15 - c)})
+		}
+	}
+	return colours
+}
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,60 @@
+package item
+
+// FireworkShape represents a shape of a firework explosion.
+type FireworkShape struct {
+	fireworkShape
+}
+
+// FireworkShapeSmallSphere is a small, spherical firework explosion.
+func FireworkShapeSmallSphere() FireworkShape {
+	return FireworkShape{0}
+}
+
+// FireworkShapeHugeSphere is a large, spherical firework explosion.
+func FireworkShapeHugeSphere() FireworkShape {
+	return FireworkShape{1}
+}
+
+// FireworkShapeStar is a star-shaped firework explosion.
+func FireworkShapeStar() FireworkShape {
+	return FireworkShape{2}
+}
+
+// FireworkShapeCreeperHead is a firework explosion in the shape of a creeper head.
+func FireworkShapeCreeperHead() FireworkShape {
+	return FireworkShape{3}
+}
+
+// FireworkShapeBurst is a firework explosion that bursts in one direction.
+func FireworkShapeBurst() FireworkShape {
+	return FireworkShape{4}
+}
+
+// FireworkShapes returns a list of all existing firework shapes.
+func FireworkShapes() []FireworkShape {
+	return []FireworkShape{FireworkShapeSmallSphere(), FireworkShapeHugeSphere(), FireworkShapeStar(), FireworkShapeCreeperHead(), FireworkShapeBurst()}
+}
+
+type fireworkShape uint8
+
+// Uint8 returns the firework shape as a uint8.
+func (f fireworkShape) Uint8() uint8 {
+	return uint8(f)
+}
+
+// Name ...
+func (f fireworkShape) Name() string {
+	switch f {
+	case 0:
+		return "Small Sphere"
+	case 1:
+		return "Huge Sphere"
+	case 2:
+		return "Star"
+	case 3:
+		return "Creeper Head"
+	case 4:
+		return "Burst"
+	}
+	panic("unknown firework shape")
+}
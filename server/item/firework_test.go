@@ -0,0 +1,23 @@
+package item
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFireworkNBT checks that the explosions and flight duration of fireworks survive encoding them to NBT and
+// decoding them again.
+func TestFireworkNBT(t *testing.T) {
+	for _, f := range FireworkPresets() {
+		decoded := Firework{}.DecodeNBT(f.EncodeNBT()).(Firework)
+		if decoded.Duration != f.Duration || len(decoded.Explosions) != len(f.Explosions) {
+			t.Errorf("decoded firework %+v differs from %+v", decoded, f)
+			continue
+		}
+		for i, explosion := range f.Explosions {
+			if !reflect.DeepEqual(decoded.Explosions[i], explosion) {
+				t.Errorf("decoded explosion %+v differs from %+v", decoded.Explosions[i], explosion)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/df-mc/dragonfly/server/item/armour"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Helmet is a defensive item that may be worn in the head slot. It comes in several tiers, each with
@@ -10,6 +11,9 @@ import (
 type Helmet struct {
 	// Tier is the tier of the armour.
 	Tier armour.Tier
+	// Colour is the dyed colour of the helmet. It is only used for leather armour. If the alpha channel of the colour
+	// is 0, the armour has its default colour.
+	Colour color.RGBA
 }
 
 // Use handles the using of a helmet to auto-equip it in an armour slot.
@@ -54,6 +58,22 @@ func (h Helmet) Helmet() bool {
 	return true
 }
 
+// EncodeNBT ...
+func (h Helmet) EncodeNBT() map[string]interface{} {
+	return encodeArmourColour(h.Tier, h.Colour)
+}
+
+// DecodeNBT ...
+func (h Helmet) DecodeNBT(data map[string]interface{}) interface{} {
+	h.Colour = decodeArmourColour(h.Tier, data)
+	return h
+}
+
+// FireProof returns true if the helmet is made of netherite.
+func (h Helmet) FireProof() bool {
+	return h.Tier == armour.TierNetherite
+}
+
 // EncodeItem ...
 func (h Helmet) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + h.Tier.Name + "_helmet", 0
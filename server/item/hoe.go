@@ -78,6 +78,11 @@ func (h Hoe) DurabilityInfo() DurabilityInfo {
 	}
 }
 
+// FireProof returns true if the hoe is made of netherite.
+func (h Hoe) FireProof() bool {
+	return h.Tier == tool.TierNetherite
+}
+
 // EncodeItem ...
 func (h Hoe) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + h.Tier.Name + "_hoe", 0
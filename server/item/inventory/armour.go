@@ -1,7 +1,9 @@
 package inventory
 
 import (
+	"errors"
 	"fmt"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/armour"
 )
@@ -134,6 +136,44 @@ func (a *Armour) Handle(h Handler) {
 	a.inv.Handle(h)
 }
 
+// ErrSwapCancelled is returned by Armour.SwapWith if one of the Handlers of the inventories involved cancelled
+// the swap.
+var ErrSwapCancelled = errors.New("armour swap was cancelled by an inventory handler")
+
+// SwapWith swaps the item in the slot of the Inventory passed with the armour worn in the armour slot passed,
+// such as when equipping armour by using it. The Handlers of both inventories are called as if the items were
+// moved by hand: HandleTake and HandlePlace of the Inventory passed are called first, followed by those of the
+// Armour. If any of these handlers cancels the event, the handlers after it are not called, nothing is changed
+// and ErrSwapCancelled is returned. An error is also returned if the item cannot be worn in the armour slot.
+func (a *Armour) SwapWith(inv *Inventory, slot, armourSlot int) error {
+	it, err := inv.Item(slot)
+	if err != nil {
+		return err
+	}
+	worn, err := a.inv.Item(armourSlot)
+	if err != nil {
+		return err
+	}
+	if !canAddArmour(it, armourSlot) {
+		return fmt.Errorf("item %v cannot be worn in armour slot %v", it, armourSlot)
+	}
+
+	ctx := event.C()
+	for _, f := range []func(){
+		func() { inv.Handler().HandleTake(ctx, slot, it) },
+		func() { inv.Handler().HandlePlace(ctx, slot, worn) },
+		func() { a.inv.Handler().HandleTake(ctx, armourSlot, worn) },
+		func() { a.inv.Handler().HandlePlace(ctx, armourSlot, it) },
+	} {
+		if f(); ctx.Cancelled() {
+			return ErrSwapCancelled
+		}
+	}
+	_ = inv.SetItem(slot, worn)
+	_ = a.inv.SetItem(armourSlot, it)
+	return nil
+}
+
 // Close closes the armour inventory, removing the slot change function.
 func (a *Armour) Close() error {
 	return a.inv.Close()
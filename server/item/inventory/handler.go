@@ -5,7 +5,8 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 )
 
-// Handler is a type that may be used to handle actions performed on an inventory by a player.
+// Handler is a type that may be used to handle actions performed on an inventory by a player, or by a block
+// such as a hopper moving items into or out of the inventory.
 type Handler interface {
 	// HandleTake handles an item.Stack being taken from a slot in the inventory. This item might be the whole stack or
 	// part of the stack currently present in that slot.
@@ -74,6 +74,31 @@ func (inv *Inventory) SetItem(slot int, item item.Stack) error {
 	return nil
 }
 
+// UpdateItem atomically updates the stack of items in a specific slot of the inventory. The function passed is
+// called with the item currently in the slot and returns the item to replace it with, or false if the slot
+// should be left unchanged. Unlike calling Item and SetItem after each other, no change made to the slot in
+// between is overwritten. The function passed must not use the inventory.
+// UpdateItem returns if the slot was changed, and an error if the slot passed is out of range.
+// (0 <= slot < inventory.Size())
+func (inv *Inventory) UpdateItem(slot int, update func(it item.Stack) (item.Stack, bool)) (bool, error) {
+	inv.check()
+	if !inv.validSlot(slot) {
+		return false, ErrSlotOutOfRange
+	}
+
+	inv.mu.Lock()
+	it, ok := update(inv.slots[slot])
+	if !ok {
+		inv.mu.Unlock()
+		return false, nil
+	}
+	f := inv.setItem(slot, it)
+	inv.mu.Unlock()
+
+	f()
+	return true, nil
+}
+
 // Slots returns the all slots in the inventory as a slice. The index in the slice is the slot of the inventory that a
 // specific item.Stack is in. Note that this item.Stack might be empty.
 func (inv *Inventory) Slots() []item.Stack {
@@ -128,6 +128,13 @@ type BeaconPayment interface {
 	PayableForBeacon() bool
 }
 
+// FireProof represents an item that does not burn up when its item entity ends up in lava or fire, such as
+// items made of netherite.
+type FireProof interface {
+	// FireProof returns true if the item survives lava and fire as an item entity.
+	FireProof() bool
+}
+
 // defaultFood represents a consumable item with a default consumption duration.
 type defaultFood struct{}
 
@@ -0,0 +1,149 @@
+// Package itemcodec implements encoding of item stacks and inventories to NBT and JSON and back. The
+// representation is stable and equal to the one used to store items in worlds and player data, so that
+// items may be stored and inspected by tools outside the server.
+package itemcodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// Slot is the JSON representation of an item.Stack in a slot of an inventory. Item holds the stack encoded as
+// little endian NBT, as returned by MarshalStack.
+type Slot struct {
+	Item []byte
+	Slot int
+}
+
+// EncodeStack encodes an item.Stack to a map that may be encoded as NBT. The map holds the count, damage,
+// custom name, lore, enchantments and custom values of the stack.
+func EncodeStack(s item.Stack) map[string]interface{} {
+	return nbtconv.WriteItem(s, true)
+}
+
+// DecodeStack decodes an item.Stack from a map produced by EncodeStack. An empty stack is returned if the item
+// in the map is not registered.
+func DecodeStack(m map[string]interface{}) item.Stack {
+	return nbtconv.ReadItem(m, nil)
+}
+
+// MarshalStack encodes an item.Stack to little endian NBT. Nil is returned for empty stacks.
+func MarshalStack(s item.Stack) ([]byte, error) {
+	if s.Empty() {
+		return nil, nil
+	}
+	var b bytes.Buffer
+	if err := nbt.NewEncoderWithEncoding(&b, nbt.LittleEndian).Encode(EncodeStack(s)); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalStack decodes an item.Stack from little endian NBT produced by MarshalStack. An empty stack is
+// returned if data is empty.
+func UnmarshalStack(data []byte) (item.Stack, error) {
+	if len(data) == 0 {
+		return item.Stack{}, nil
+	}
+	var m map[string]interface{}
+	if err := nbt.NewDecoderWithEncoding(bytes.NewBuffer(data), nbt.LittleEndian).Decode(&m); err != nil {
+		return item.Stack{}, err
+	}
+	return DecodeStack(m), nil
+}
+
+// EncodeInventory encodes all non-empty slots of an inventory to a slice of maps that may be encoded as NBT.
+// Each map holds the slot of the item under the 'Slot' key.
+func EncodeInventory(inv *inventory.Inventory) []map[string]interface{} {
+	return nbtconv.InvToNBT(inv)
+}
+
+// DecodeInventory decodes the items in the data passed, as produced by EncodeInventory, into the inventory.
+func DecodeInventory(inv *inventory.Inventory, data []map[string]interface{}) {
+	items := make([]interface{}, len(data))
+	for i, m := range data {
+		items[i] = m
+	}
+	nbtconv.InvFromNBT(inv, items)
+}
+
+// MarshalInventory encodes the items in an inventory to little endian NBT. The items are stored in a list
+// under the 'Items' key of the root compound.
+func MarshalInventory(inv *inventory.Inventory) ([]byte, error) {
+	var b bytes.Buffer
+	items := EncodeInventory(inv)
+	if items == nil {
+		items = []map[string]interface{}{}
+	}
+	if err := nbt.NewEncoderWithEncoding(&b, nbt.LittleEndian).Encode(map[string]interface{}{"Items": items}); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalInventory decodes little endian NBT produced by MarshalInventory into the inventory passed.
+func UnmarshalInventory(inv *inventory.Inventory, data []byte) error {
+	var m map[string]interface{}
+	if err := nbt.NewDecoderWithEncoding(bytes.NewBuffer(data), nbt.LittleEndian).Decode(&m); err != nil {
+		return err
+	}
+	items, _ := m["Items"].([]interface{})
+	nbtconv.InvFromNBT(inv, items)
+	return nil
+}
+
+// EncodeSlots encodes the non-empty slots of an inventory to a slice of Slots.
+func EncodeSlots(inv *inventory.Inventory) ([]Slot, error) {
+	var slots []Slot
+	for i, s := range inv.Slots() {
+		if s.Empty() {
+			continue
+		}
+		data, err := MarshalStack(s)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, Slot{Item: data, Slot: i})
+	}
+	return slots, nil
+}
+
+// DecodeSlots decodes a slice of Slots produced by EncodeSlots into the inventory passed.
+func DecodeSlots(inv *inventory.Inventory, slots []Slot) error {
+	for _, slot := range slots {
+		s, err := UnmarshalStack(slot.Item)
+		if err != nil {
+			return err
+		}
+		if s.Empty() {
+			continue
+		}
+		if err := inv.SetItem(slot.Slot, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalInventoryJSON encodes the items in an inventory to JSON. The JSON holds a list of Slots, which is
+// the same representation used by the JSON player provider.
+func MarshalInventoryJSON(inv *inventory.Inventory) ([]byte, error) {
+	slots, err := EncodeSlots(inv)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(slots)
+}
+
+// UnmarshalInventoryJSON decodes JSON produced by MarshalInventoryJSON into the inventory passed.
+func UnmarshalInventoryJSON(inv *inventory.Inventory, data []byte) error {
+	var slots []Slot
+	if err := json.Unmarshal(data, &slots); err != nil {
+		return err
+	}
+	return DecodeSlots(inv, slots)
+}
@@ -0,0 +1,73 @@
+package itemcodec_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/itemcodec"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"testing"
+)
+
+// fuzzItems are the items that stacks are created with in FuzzRoundTrip.
+var fuzzItems = []world.Item{
+	item.Sword{Tier: tool.TierDiamond},
+	item.Pickaxe{Tier: tool.TierIron},
+	item.Diamond{},
+	block.Dirt{},
+}
+
+// FuzzRoundTrip checks that stacks with any count, damage, custom name, lore, enchantment level and custom
+// values are encoded to NBT and JSON and decoded back without losing any data.
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(uint8(0), uint8(1), uint16(10), "Excalibur", "Forged in fire", uint8(3), "owner", "Steve", int64(7))
+	f.Add(uint8(2), uint8(64), uint16(0), "", "", uint8(0), "", "", int64(0))
+	f.Add(uint8(3), uint8(12), uint16(0), "§aDirt", "line", uint8(1), "price", "§c100", int64(-1))
+	f.Fuzz(func(t *testing.T, it, count uint8, damage uint16, name, lore string, level uint8, key, val string, n int64) {
+		s := item.NewStack(fuzzItems[int(it)%len(fuzzItems)], 1)
+		s = s.Grow(int(count) % s.MaxCount())
+		if s.MaxDurability() != -1 {
+			s = s.Damage(int(damage) % s.MaxDurability())
+			if level > 0 {
+				s = s.WithEnchantment(enchantment.Unbreaking{}.WithLevel(int(level)%3 + 1))
+			}
+		}
+		if name != "" {
+			s = s.WithCustomName(name)
+		}
+		if lore != "" {
+			s = s.WithLore(lore)
+		}
+		if key != "" {
+			s = s.WithValue(key, val).WithValue(key+"_n", n)
+		}
+
+		data, err := itemcodec.MarshalStack(s)
+		if err != nil {
+			t.Fatalf("marshal %v: %v", s, err)
+		}
+		decoded, err := itemcodec.UnmarshalStack(data)
+		if err != nil {
+			t.Fatalf("unmarshal %v: %v", s, err)
+		}
+		if !decoded.Equal(s) {
+			t.Fatalf("stack %v decoded from NBT as %v", s, decoded)
+		}
+
+		inv := inventory.New(9, nil)
+		_ = inv.SetItem(4, s)
+		b, err := itemcodec.MarshalInventoryJSON(inv)
+		if err != nil {
+			t.Fatalf("marshal inventory with %v: %v", s, err)
+		}
+		inv2 := inventory.New(9, nil)
+		if err := itemcodec.UnmarshalInventoryJSON(inv2, b); err != nil {
+			t.Fatalf("unmarshal inventory with %v: %v", s, err)
+		}
+		if got, _ := inv2.Item(4); !got.Equal(s) {
+			t.Fatalf("stack %v decoded from JSON as %v", s, got)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Lead is an item used to leash entities to the player or to fences.
+type Lead struct{}
+
+// UseOnEntity leashes the entity passed to the user if it is able to be leashed and if it is not already
+// leashed.
+func (Lead) UseOnEntity(e world.Entity, _ *world.World, user User, ctx *UseContext) bool {
+	l, ok := e.(leashable)
+	if !ok {
+		return false
+	}
+	if _, leashed := l.LeashHolder(); leashed {
+		return false
+	}
+	l.SetLeashHolder(user)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// UseOnBlock ties all entities leashed to the user to the fence clicked, creating a leash knot on the fence if
+// there isn't one yet.
+func (Lead) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, w *world.World, user User, _ *UseContext) bool {
+	if _, ok := w.Block(pos).Model().(model.Fence); !ok {
+		return false
+	}
+	n := 0
+	if knot, ok := leashKnotAt(pos, w); ok {
+		n = knot.Tie(user)
+	}
+	if n == 0 {
+		// Either there was no knot on the fence yet, or it was removed just now. The new knot is only added
+		// to the world once entities are tied to it.
+		knot, ok := world.NewEntity("minecraft:leash_knot", pos.Vec3(), nil)
+		if !ok {
+			return false
+		}
+		if n = knot.(leashKnot).Tie(user); n == 0 {
+			return false
+		}
+	}
+	w.PlaySound(pos.Vec3Centre(), sound.LeashKnotPlace{})
+	return true
+}
+
+// leashKnotAt returns the leash knot attached to the fence at the position passed, if there is one.
+func leashKnotAt(pos cube.Pos, w *world.World) (leashKnot, bool) {
+	bb := physics.NewAABB(pos.Vec3(), pos.Vec3().Add(mgl64.Vec3{1, 1, 1}))
+	for _, e := range w.EntitiesWithin(bb, nil) {
+		if knot, ok := e.(leashKnot); ok {
+			return knot, true
+		}
+	}
+	return nil, false
+}
+
+// leashable represents an entity that may be leashed using a lead. It is implemented by entity.Leashable.
+type leashable interface {
+	world.Entity
+	LeashHolder() (world.Entity, bool)
+	SetLeashHolder(holder world.Entity)
+}
+
+// leashKnot represents a knot on a fence that leashed entities may be tied to. It is implemented by
+// entity.LeashKnot.
+type leashKnot interface {
+	world.Entity
+	Tie(holder world.Entity) int
+}
+
+// EncodeItem ...
+func (Lead) EncodeItem() (name string, meta int16) {
+	return "minecraft:lead", 0
+}
@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/df-mc/dragonfly/server/item/armour"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Leggings are a defensive item that may be equipped in the leggings armour slot. They come in several tiers,
@@ -10,6 +11,9 @@ import (
 type Leggings struct {
 	// Tier is the tier of the leggings.
 	Tier armour.Tier
+	// Colour is the dyed colour of the leggings. It is only used for leather armour. If the alpha channel of the colour
+	// is 0, the armour has its default colour.
+	Colour color.RGBA
 }
 
 // Use handles the auto-equipping of leggings in an armour slot by using the item.
@@ -58,6 +62,22 @@ func (l Leggings) DurabilityInfo() DurabilityInfo {
 	}
 }
 
+// EncodeNBT ...
+func (l Leggings) EncodeNBT() map[string]interface{} {
+	return encodeArmourColour(l.Tier, l.Colour)
+}
+
+// DecodeNBT ...
+func (l Leggings) DecodeNBT(data map[string]interface{}) interface{} {
+	l.Colour = decodeArmourColour(l.Tier, data)
+	return l
+}
+
+// FireProof returns true if the leggings are made of netherite.
+func (l Leggings) FireProof() bool {
+	return l.Tier == armour.TierNetherite
+}
+
 // EncodeItem ...
 func (l Leggings) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + l.Tier.Name + "_leggings", 0
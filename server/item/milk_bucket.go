@@ -0,0 +1,40 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// MilkBucket is a bucket filled with milk. Drinking it removes all effects from the consumer.
+type MilkBucket struct{}
+
+// MaxCount ...
+func (MilkBucket) MaxCount() int {
+	return 1
+}
+
+// AlwaysConsumable ...
+func (MilkBucket) AlwaysConsumable() bool {
+	return true
+}
+
+// ConsumeDuration ...
+func (MilkBucket) ConsumeDuration() time.Duration {
+	return DefaultConsumeDuration
+}
+
+// Consume ...
+func (MilkBucket) Consume(_ *world.World, c Consumer) Stack {
+	if r, ok := c.(interface {
+		RemoveAllEffects(cause effect.RemovalCause)
+	}); ok {
+		r.RemoveAllEffects(effect.CauseMilk{})
+	}
+	return NewStack(Bucket{}, 1)
+}
+
+// EncodeItem ...
+func (MilkBucket) EncodeItem() (name string, meta int16) {
+	return "minecraft:milk_bucket", 0
+}
@@ -0,0 +1,22 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// MusicDisc is an item that may be inserted into a jukebox to play the track of the disc.
+type MusicDisc struct {
+	// DiscType is the type of the music disc. It decides the track played by a jukebox the disc is inserted
+	// into.
+	DiscType sound.DiscType
+}
+
+// MaxCount always returns 1.
+func (MusicDisc) MaxCount() int {
+	return 1
+}
+
+// EncodeItem ...
+func (m MusicDisc) EncodeItem() (name string, meta int16) {
+	return "minecraft:music_disc_" + m.DiscType.String(), 0
+}
@@ -3,6 +3,11 @@ package item
 // NetheriteIngot is a rare mineral crafted with 4 pieces of netherite scrap and 4 gold ingots.
 type NetheriteIngot struct{}
 
+// FireProof ...
+func (NetheriteIngot) FireProof() bool {
+	return true
+}
+
 // EncodeItem ...
 func (NetheriteIngot) EncodeItem() (name string, meta int16) {
 	return "minecraft:netherite_ingot", 0
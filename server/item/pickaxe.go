@@ -49,6 +49,11 @@ func (p Pickaxe) DurabilityInfo() DurabilityInfo {
 	}
 }
 
+// FireProof returns true if the pickaxe is made of netherite.
+func (p Pickaxe) FireProof() bool {
+	return p.Tier == tool.TierNetherite
+}
+
 // EncodeItem ...
 func (p Pickaxe) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + p.Tier.Name + "_pickaxe", 0
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/item/tool"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
 )
 
 //noinspection SpellCheckingInspection
@@ -15,23 +16,44 @@ func init() {
 		world.RegisterItem(Shovel{Tier: t})
 		world.RegisterItem(Sword{Tier: t})
 		world.RegisterItem(Hoe{Tier: t})
+
+		RegisterTag("minecraft:is_pickaxe", Pickaxe{Tier: t})
+		RegisterTag("minecraft:is_axe", Axe{Tier: t})
+		RegisterTag("minecraft:is_shovel", Shovel{Tier: t})
+		RegisterTag("minecraft:is_sword", Sword{Tier: t})
+		RegisterTag("minecraft:is_hoe", Hoe{Tier: t})
+		RegisterTag("minecraft:is_tool", Pickaxe{Tier: t}, Axe{Tier: t}, Shovel{Tier: t}, Sword{Tier: t}, Hoe{Tier: t})
+		RegisterTag("minecraft:"+t.Name+"_tier", Pickaxe{Tier: t}, Axe{Tier: t}, Shovel{Tier: t}, Sword{Tier: t}, Hoe{Tier: t})
 	}
 	for _, t := range armour.Tiers() {
 		world.RegisterItem(Helmet{Tier: t})
 		world.RegisterItem(Chestplate{Tier: t})
 		world.RegisterItem(Leggings{Tier: t})
 		world.RegisterItem(Boots{Tier: t})
+
+		RegisterTag("minecraft:is_armor", Helmet{Tier: t}, Chestplate{Tier: t}, Leggings{Tier: t}, Boots{Tier: t})
 	}
+	RegisterTag("minecraft:is_armor", TurtleShell{})
+	RegisterTag("minecraft:coals", Coal{}, Charcoal{})
 	world.RegisterItem(TurtleShell{})
 
 	world.RegisterItem(Bucket{})
+	world.RegisterItem(MilkBucket{})
 
 	world.RegisterItem(Shears{})
+	world.RegisterItem(Lead{})
 
 	world.RegisterItem(Snowball{})
 	world.RegisterItem(EnderPearl{})
+	world.RegisterItem(Firework{})
+	world.RegisterItem(Arrow{})
+	world.RegisterItem(Crossbow{})
 	for _, pot := range potion.All() {
 		world.RegisterItem(SplashPotion{Type: pot})
+		world.RegisterItem(LingeringPotion{Type: pot})
+		if arrow := (Arrow{Tip: pot}); arrow.Tipped() {
+			world.RegisterItem(arrow)
+		}
 	}
 
 	world.RegisterItem(Diamond{})
@@ -65,6 +87,9 @@ func init() {
 	for _, p := range potion.All() {
 		world.RegisterItem(Potion{Type: p})
 	}
+	for _, t := range StewTypes() {
+		world.RegisterItem(SuspiciousStew{Type: t})
+	}
 
 	world.RegisterItem(FlintAndSteel{})
 
@@ -145,4 +170,10 @@ func init() {
 	}
 	world.RegisterItem(TropicalFish{})
 	world.RegisterItem(AmethystShard{})
+	for _, disc := range sound.MusicDiscs() {
+		world.RegisterItem(MusicDisc{DiscType: disc})
+	}
+	for _, pattern := range AllBannerPatterns() {
+		world.RegisterItem(pattern)
+	}
 }
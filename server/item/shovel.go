@@ -79,6 +79,11 @@ func (s Shovel) DurabilityInfo() DurabilityInfo {
 	}
 }
 
+// FireProof returns true if the shovel is made of netherite.
+func (s Shovel) FireProof() bool {
+	return s.Tier == tool.TierNetherite
+}
+
 // EncodeItem ...
 func (s Shovel) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + s.Tier.Name + "_shovel", 0
@@ -0,0 +1,50 @@
+package smelting
+
+// Category is the category of a smelting recipe. It decides which smelters are able to smelt the input of the
+// recipe.
+type Category struct {
+	category
+}
+
+// CategoryOre returns the category of recipes that smelt ores, raw metals and metal tools and armour. These
+// recipes may be smelted in furnaces and blast furnaces.
+func CategoryOre() Category {
+	return Category{category(0)}
+}
+
+// CategoryFood returns the category of recipes that cook food. These recipes may be smelted in furnaces,
+// smokers and campfires.
+func CategoryFood() Category {
+	return Category{category(1)}
+}
+
+// CategoryMisc returns the category of all other recipes, such as smelting sand into glass. These recipes may
+// only be smelted in furnaces.
+func CategoryMisc() Category {
+	return Category{category(2)}
+}
+
+// Categories returns all smelting recipe categories.
+func Categories() []Category {
+	return []Category{CategoryOre(), CategoryFood(), CategoryMisc()}
+}
+
+type category uint8
+
+// Uint8 returns the category as a uint8.
+func (c category) Uint8() uint8 {
+	return uint8(c)
+}
+
+// String ...
+func (c category) String() string {
+	switch c {
+	case 0:
+		return "ore"
+	case 1:
+		return "food"
+	case 2:
+		return "misc"
+	}
+	panic("unknown smelting category")
+}
@@ -0,0 +1,83 @@
+package smelting
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// MaxStoredExperience is the maximum amount of experience that a StoredExperience holds. Experience of items
+// smelted once this amount is reached is lost.
+const MaxStoredExperience = math.MaxInt16
+
+// StoredExperience holds the experience of the items smelted by a smelter that have not yet been taken out of
+// it. Vanilla smelters store experience until the output is withdrawn, after which the experience is dropped
+// as experience orbs, or until the smelter is broken. A StoredExperience is safe for concurrent use.
+type StoredExperience struct {
+	mu    sync.Mutex
+	xp    float64
+	items int
+}
+
+// NewStoredExperience returns a StoredExperience that holds the amount of experience passed for the amount of
+// items passed. It may be used to restore the experience stored in a smelter when decoding it.
+func NewStoredExperience(xp float64, items int) *StoredExperience {
+	if items <= 0 {
+		return &StoredExperience{}
+	}
+	return &StoredExperience{xp: math.Max(0, math.Min(xp, MaxStoredExperience)), items: items}
+}
+
+// Store stores the experience of an item smelted using the Recipe passed.
+func (s *StoredExperience) Store(r Recipe) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.xp = math.Min(s.xp+r.Experience, MaxStoredExperience)
+	s.items++
+}
+
+// Withdraw withdraws the experience of n of the items stored and returns the amount of experience points that
+// should be dropped. The experience paid out is proportional to the amount of items withdrawn and rounded
+// down, with the remainder kept for the items left. Once the last item is withdrawn, the fraction of an
+// experience point left is paid out with a chance equal to that fraction, decided using the rand.Rand passed, so
+// that repeated partial withdrawals pay out the same total as a single withdrawal of all items.
+func (s *StoredExperience) Withdraw(n int, r *rand.Rand) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n <= 0 || s.items == 0 {
+		return 0
+	}
+	if n >= s.items {
+		return s.drain(r)
+	}
+	points := int(s.xp * float64(n) / float64(s.items))
+	s.xp -= float64(points)
+	s.items -= n
+	return points
+}
+
+// Drain withdraws all experience stored, such as when the smelter is broken, and returns the amount of
+// experience points that should be dropped. The rand.Rand passed decides if the fraction of an experience point
+// stored is paid out, as it does for Withdraw.
+func (s *StoredExperience) Drain(r *rand.Rand) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.drain(r)
+}
+
+// drain withdraws all experience stored. It must be called while s.mu is held.
+func (s *StoredExperience) drain(r *rand.Rand) int {
+	points, fraction := math.Modf(s.xp)
+	if fraction > 0 && r.Float64() < fraction {
+		points++
+	}
+	s.xp, s.items = 0, 0
+	return int(points)
+}
+
+// Experience returns the amount of experience currently stored and the amount of items that it was stored for.
+func (s *StoredExperience) Experience() (xp float64, items int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.xp, s.items
+}
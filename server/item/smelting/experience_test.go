@@ -0,0 +1,47 @@
+package smelting_test
+
+import (
+	"github.com/df-mc/dragonfly/server/item/smelting"
+	"math/rand"
+	"testing"
+)
+
+// TestStoredExperienceWithdraw checks that withdrawing the experience of stored items in parts pays out the same
+// total as draining all of it at once with a rand.Rand seeded the same way, and that the fraction of an experience
+// point left is paid out about as often as the fraction says.
+func TestStoredExperienceWithdraw(t *testing.T) {
+	// Ten items at 0.35 experience each store 3.5 experience points.
+	r := smelting.Recipe{Experience: 0.35}
+	fill := func() *smelting.StoredExperience {
+		s := smelting.NewStoredExperience(0, 0)
+		for i := 0; i < 10; i++ {
+			s.Store(r)
+		}
+		return s
+	}
+
+	rounded := 0
+	for seed := int64(0); seed < 1000; seed++ {
+		partial, rp := fill(), rand.New(rand.NewSource(seed))
+		total := 0
+		for _, n := range []int{3, 3, 3, 1} {
+			total += partial.Withdraw(n, rp)
+		}
+		if xp, items := partial.Experience(); xp != 0 || items != 0 {
+			t.Fatalf("seed %v: %v experience for %v items left after withdrawing all items", seed, xp, items)
+		}
+		all := fill().Drain(rand.New(rand.NewSource(seed)))
+		if total != all {
+			t.Fatalf("seed %v: withdrawing in parts paid out %v points, draining paid out %v", seed, total, all)
+		}
+		if all != 3 && all != 4 {
+			t.Fatalf("seed %v: draining 3.5 experience paid out %v points, want 3 or 4", seed, all)
+		}
+		if all == 4 {
+			rounded++
+		}
+	}
+	if rounded < 400 || rounded > 600 {
+		t.Errorf("half an experience point was paid out %v out of 1000 times, want about 500", rounded)
+	}
+}
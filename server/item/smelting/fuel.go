@@ -0,0 +1,127 @@
+package smelting
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+	"time"
+)
+
+// Fuel is an item that may be burned in a smelter to power it.
+type Fuel struct {
+	// Item is the fuel item. Only the name and meta of the item are compared with the item put into a smelter.
+	// Item is nil if the fuel was registered for a tag using RegisterFuelTag.
+	Item world.Item
+	// Tag is the item tag, such as 'minecraft:coals', of which any item may be burned if the fuel was registered
+	// using RegisterFuelTag. Tag is empty for fuels with a single Item.
+	Tag string
+	// Duration is the time that the fuel keeps a regular furnace burning. Smelters that smelt faster burn their
+	// fuel faster too, as returned by Smelter.Duration.
+	Duration time.Duration
+	// Residue is the item left in the fuel slot after the fuel was burned, such as the empty bucket left by a
+	// lava bucket. Residue is nil if the fuel is consumed entirely.
+	Residue world.Item
+}
+
+// RegisterFuel registers the item passed as a fuel that burns for the duration passed in a regular furnace,
+// leaving the residue passed in the fuel slot after burning. Residue may be nil if nothing is left. An error is
+// returned if the item was already registered as fuel. RemoveFuel may be used first to overwrite a fuel.
+func RegisterFuel(it world.Item, duration time.Duration, residue world.Item) error {
+	k := keyOf(it)
+	if duration <= 0 {
+		return fmt.Errorf("register fuel: duration of %v must be positive, got %v", k, duration)
+	}
+
+	fuelMu.Lock()
+	defer fuelMu.Unlock()
+	if _, ok := fuels[k]; ok {
+		return fmt.Errorf("register fuel: fuel %v already registered", k)
+	}
+	fuels[k] = Fuel{Item: it, Duration: duration, Residue: residue}
+	return nil
+}
+
+// RegisterFuelTag registers all items with the item tag passed, as registered using item.RegisterTag, as fuels
+// that burn for the duration passed in a regular furnace, leaving the residue passed in the fuel slot after
+// burning. Fuels registered for a specific item using RegisterFuel take precedence over fuels registered for a
+// tag. An error is returned if a fuel for the same tag was already registered.
+func RegisterFuelTag(tag string, duration time.Duration, residue world.Item) error {
+	if duration <= 0 {
+		return fmt.Errorf("register fuel: duration of tag %v must be positive, got %v", tag, duration)
+	}
+
+	fuelMu.Lock()
+	defer fuelMu.Unlock()
+	if _, ok := tagFuels[tag]; ok {
+		return fmt.Errorf("register fuel: fuel with tag %v already registered", tag)
+	}
+	tagFuels[tag] = Fuel{Tag: tag, Duration: duration, Residue: residue}
+	return nil
+}
+
+// RemoveFuel removes the fuel with the item passed. False is returned if the item was not registered as fuel.
+func RemoveFuel(it world.Item) bool {
+	k := keyOf(it)
+
+	fuelMu.Lock()
+	defer fuelMu.Unlock()
+	if _, ok := fuels[k]; !ok {
+		return false
+	}
+	delete(fuels, k)
+	return true
+}
+
+// RemoveFuelTag removes the fuel registered for the item tag passed using RegisterFuelTag. False is returned if
+// no fuel was registered for that tag.
+func RemoveFuelTag(tag string) bool {
+	fuelMu.Lock()
+	defer fuelMu.Unlock()
+	if _, ok := tagFuels[tag]; !ok {
+		return false
+	}
+	delete(tagFuels, tag)
+	return true
+}
+
+// FuelOf looks up the fuel registered for the item passed. If no fuel is registered for the item itself, the
+// fuels of its item tags are checked in alphabetical order of the tags. False is returned if the item is not a
+// fuel.
+func FuelOf(it world.Item) (Fuel, bool) {
+	fuelMu.RLock()
+	defer fuelMu.RUnlock()
+	f, ok := fuels[keyOf(it)]
+	if !ok && len(tagFuels) > 0 {
+		for _, tag := range item.Tags(it) {
+			if f, ok = tagFuels[tag]; ok {
+				break
+			}
+		}
+	}
+	return f, ok
+}
+
+// Fuels returns all fuels that are currently registered, in no particular order.
+func Fuels() []Fuel {
+	fuelMu.RLock()
+	defer fuelMu.RUnlock()
+
+	f := make([]Fuel, 0, len(fuels)+len(tagFuels))
+	for _, fuel := range fuels {
+		f = append(f, fuel)
+	}
+	for _, fuel := range tagFuels {
+		f = append(f, fuel)
+	}
+	return f
+}
+
+var (
+	// fuelMu protects fuels, as plugins may register and remove fuels while smelters are being ticked.
+	fuelMu sync.RWMutex
+	// fuels holds all fuels registered, indexed by the name and meta of their item.
+	fuels = map[key]Fuel{}
+	// tagFuels holds all fuels registered for an item tag, indexed by the tag.
+	tagFuels = map[string]Fuel{}
+)
@@ -0,0 +1,160 @@
+package smelting
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+	"time"
+)
+
+// Recipe is a smelting recipe, which turns an input item into an output item stack when smelted by a Smelter.
+type Recipe struct {
+	// Input is the item that is smelted. Only the name and meta of the item are compared with the item put into
+	// a smelter, so properties such as the durability or enchantments of the item are ignored. Input is nil if
+	// the recipe was registered for a tag using RegisterTag.
+	Input world.Item
+	// Tag is the item tag, such as 'minecraft:logs', of which any item may be smelted if the recipe was
+	// registered using RegisterTag. Tag is empty for recipes with a single Input.
+	Tag string
+	// Output is the item stack that is produced when smelting the Input.
+	Output item.Stack
+	// Experience is the amount of experience dropped when taking the Output out of the smelter.
+	Experience float64
+	// Duration is the time it takes to smelt the Input in a regular furnace. Smelters may smelt faster, as
+	// returned by Smelter.Duration.
+	Duration time.Duration
+	// Category is the category of the recipe. It decides which smelters are able to smelt the Input.
+	Category Category
+}
+
+// Register registers a smelting recipe that smelts the input item passed into the output item stack, dropping
+// xp experience and taking the duration passed to smelt in a regular furnace. The category of the recipe
+// decides which smelters are able to smelt it. An error is returned if a recipe with the same input was
+// already registered. Remove may be used first to overwrite a recipe.
+func Register(input world.Item, output item.Stack, xp float64, duration time.Duration, c Category) error {
+	k := keyOf(input)
+	if output.Empty() {
+		return fmt.Errorf("register smelting recipe: output of %v is empty", k)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("register smelting recipe: duration of %v must be positive, got %v", k, duration)
+	}
+
+	recipeMu.Lock()
+	defer recipeMu.Unlock()
+	if _, ok := recipes[k]; ok {
+		return fmt.Errorf("register smelting recipe: recipe with input %v already registered", k)
+	}
+	recipes[k] = Recipe{Input: input, Output: output, Experience: xp, Duration: duration, Category: c}
+	return nil
+}
+
+// RegisterTag registers a smelting recipe that smelts any item with the item tag passed, as registered using
+// item.RegisterTag, into the output item stack. Recipes registered for a specific input item using Register take
+// precedence over recipes registered for a tag. An error is returned if a recipe for the same tag was already
+// registered. RemoveTag may be used first to overwrite a recipe.
+func RegisterTag(tag string, output item.Stack, xp float64, duration time.Duration, c Category) error {
+	if output.Empty() {
+		return fmt.Errorf("register smelting recipe: output of tag %v is empty", tag)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("register smelting recipe: duration of tag %v must be positive, got %v", tag, duration)
+	}
+
+	recipeMu.Lock()
+	defer recipeMu.Unlock()
+	if _, ok := tagRecipes[tag]; ok {
+		return fmt.Errorf("register smelting recipe: recipe with tag %v already registered", tag)
+	}
+	tagRecipes[tag] = Recipe{Tag: tag, Output: output, Experience: xp, Duration: duration, Category: c}
+	return nil
+}
+
+// Remove removes the smelting recipe with the input item passed. False is returned if no recipe with that
+// input was registered.
+func Remove(input world.Item) bool {
+	k := keyOf(input)
+
+	recipeMu.Lock()
+	defer recipeMu.Unlock()
+	if _, ok := recipes[k]; !ok {
+		return false
+	}
+	delete(recipes, k)
+	return true
+}
+
+// RemoveTag removes the smelting recipe registered for the item tag passed using RegisterTag. False is returned
+// if no recipe for that tag was registered.
+func RemoveTag(tag string) bool {
+	recipeMu.Lock()
+	defer recipeMu.Unlock()
+	if _, ok := tagRecipes[tag]; !ok {
+		return false
+	}
+	delete(tagRecipes, tag)
+	return true
+}
+
+// Smelt looks up the smelting recipe with the input item passed that may be smelted by the Smelter passed. If
+// no recipe is registered for the item itself, the recipes of its item tags are checked in alphabetical order of
+// the tags. If no such recipe is registered, or if the smelter does not smelt recipes of its category, false is
+// returned.
+func Smelt(input world.Item, s Smelter) (Recipe, bool) {
+	recipeMu.RLock()
+	r, ok := recipes[keyOf(input)]
+	if !ok && len(tagRecipes) > 0 {
+		for _, tag := range item.Tags(input) {
+			if r, ok = tagRecipes[tag]; ok {
+				break
+			}
+		}
+	}
+	recipeMu.RUnlock()
+	if !ok || !s.Smelts(r.Category) {
+		return Recipe{}, false
+	}
+	return r, true
+}
+
+// Recipes returns all smelting recipes that are currently registered, in no particular order.
+func Recipes() []Recipe {
+	recipeMu.RLock()
+	defer recipeMu.RUnlock()
+
+	r := make([]Recipe, 0, len(recipes)+len(tagRecipes))
+	for _, recipe := range recipes {
+		r = append(r, recipe)
+	}
+	for _, recipe := range tagRecipes {
+		r = append(r, recipe)
+	}
+	return r
+}
+
+var (
+	// recipeMu protects recipes, as plugins may register and remove recipes while smelters are being ticked.
+	recipeMu sync.RWMutex
+	// recipes holds all smelting recipes registered, indexed by the name and meta of their input.
+	recipes = map[key]Recipe{}
+	// tagRecipes holds all smelting recipes registered for an item tag, indexed by the tag.
+	tagRecipes = map[string]Recipe{}
+)
+
+// key is the key of an item in the recipes and fuels maps.
+type key struct {
+	name string
+	meta int16
+}
+
+// String returns the name and meta of the key, separated by a colon.
+func (k key) String() string {
+	return fmt.Sprintf("%v:%v", k.name, k.meta)
+}
+
+// keyOf returns the key of the item passed.
+func keyOf(it world.Item) key {
+	name, meta := it.EncodeItem()
+	return key{name: name, meta: meta}
+}
@@ -0,0 +1,82 @@
+package smelting
+
+import "time"
+
+// Smelter is a type of block that smelts items, such as a furnace or a smoker. Each smelter only smelts the
+// recipes of specific categories.
+type Smelter struct {
+	smelter
+}
+
+// SmelterFurnace returns the smelter of a furnace. Furnaces smelt recipes of all categories.
+func SmelterFurnace() Smelter {
+	return Smelter{smelter(0)}
+}
+
+// SmelterBlastFurnace returns the smelter of a blast furnace. Blast furnaces only smelt recipes of the
+// CategoryOre, but do so twice as fast as a furnace.
+func SmelterBlastFurnace() Smelter {
+	return Smelter{smelter(1)}
+}
+
+// SmelterSmoker returns the smelter of a smoker. Smokers only smelt recipes of the CategoryFood, but do so twice
+// as fast as a furnace.
+func SmelterSmoker() Smelter {
+	return Smelter{smelter(2)}
+}
+
+// SmelterCampfire returns the smelter of a campfire. Campfires only smelt recipes of the CategoryFood, which
+// always takes 30 seconds and does not require fuel.
+func SmelterCampfire() Smelter {
+	return Smelter{smelter(3)}
+}
+
+// Smelters returns all smelters.
+func Smelters() []Smelter {
+	return []Smelter{SmelterFurnace(), SmelterBlastFurnace(), SmelterSmoker(), SmelterCampfire()}
+}
+
+type smelter uint8
+
+// Uint8 returns the smelter as a uint8.
+func (s smelter) Uint8() uint8 {
+	return uint8(s)
+}
+
+// String ...
+func (s smelter) String() string {
+	switch s {
+	case 0:
+		return "furnace"
+	case 1:
+		return "blast_furnace"
+	case 2:
+		return "smoker"
+	case 3:
+		return "campfire"
+	}
+	panic("unknown smelter")
+}
+
+// Smelts checks if the smelter is able to smelt recipes of the Category passed.
+func (s smelter) Smelts(c Category) bool {
+	switch s {
+	case 1:
+		return c == CategoryOre()
+	case 2, 3:
+		return c == CategoryFood()
+	}
+	return true
+}
+
+// Duration returns the time it takes for the smelter to smelt an item of a recipe that has the duration passed
+// in a regular furnace.
+func (s smelter) Duration(d time.Duration) time.Duration {
+	switch s {
+	case 1, 2:
+		return d / 2
+	case 3:
+		return time.Second * 30
+	}
+	return d
+}
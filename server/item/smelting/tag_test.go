@@ -0,0 +1,47 @@
+package smelting_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/smelting"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"testing"
+	"time"
+)
+
+// TestTagRecipes checks that items with an item tag are smelted and burned using the recipes and fuels
+// registered for that tag, and that items outside the tag are not.
+func TestTagRecipes(t *testing.T) {
+	for _, it := range []world.Item{block.Log{Wood: block.OakWood()}, block.Wood{Wood: block.BirchWood(), Stripped: true}} {
+		r, ok := smelting.Smelt(it, smelting.SmelterFurnace())
+		if !ok || r.Tag != "minecraft:logs_that_burn" {
+			t.Errorf("%#v: smelted using recipe %#v, want recipe of minecraft:logs_that_burn", it, r)
+		}
+		if _, ok := r.Output.Item().(item.Charcoal); !ok {
+			t.Errorf("%#v: smelted into %#v, want charcoal", it, r.Output.Item())
+		}
+	}
+	if _, ok := smelting.Smelt(block.Log{Wood: block.CrimsonWood()}, smelting.SmelterFurnace()); ok {
+		t.Errorf("crimson stem could be smelted, but it does not burn")
+	}
+	if _, ok := smelting.Smelt(item.Pickaxe{Tier: tool.TierIron}, smelting.SmelterBlastFurnace()); !ok {
+		t.Errorf("iron pickaxe could not be smelted in a blast furnace")
+	}
+
+	if f, ok := smelting.FuelOf(item.Charcoal{}); !ok || f.Duration != time.Second*80 {
+		t.Errorf("charcoal burns for %v, want 1m20s", f.Duration)
+	}
+	if f, ok := smelting.FuelOf(item.Sword{Tier: tool.TierWood}); !ok || f.Duration != time.Second*10 {
+		t.Errorf("wooden sword burns for %v, want 10s", f.Duration)
+	}
+	if _, ok := smelting.FuelOf(item.Sword{Tier: tool.TierStone}); ok {
+		t.Errorf("stone sword could be used as fuel")
+	}
+
+	// Items added to a tag by plugins are burned using the fuel of the tag.
+	item.RegisterTag("minecraft:coals", item.Diamond{})
+	if _, ok := smelting.FuelOf(item.Diamond{}); !ok {
+		t.Errorf("diamond added to minecraft:coals could not be used as fuel")
+	}
+}
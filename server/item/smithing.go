@@ -0,0 +1,110 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/item/armour"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+	"sync"
+)
+
+// SmithingRecipe is a recipe used in a smithing table. It upgrades a base item into the result item using an
+// addition, such as upgrading diamond gear to netherite gear using a netherite ingot.
+type SmithingRecipe struct {
+	// Base is the item that is upgraded. Only the name and meta of the item are compared with the item put into
+	// a smithing table, so properties such as the durability or enchantments of the item are ignored.
+	Base world.Item
+	// Addition is the item that is consumed to upgrade the Base item.
+	Addition world.Item
+	// Result is the item that the Base item is upgraded into.
+	Result world.Item
+}
+
+// Smith upgrades the base item stack passed into a stack holding the Result of the recipe. The custom name,
+// lore, enchantments and other data of the stack are kept, and the percentage of durability left is the same
+// as that of the base item stack.
+func (r SmithingRecipe) Smith(base Stack) Stack {
+	result := base.Grow(1 - base.Count()).WithItem(r.Result)
+	if base.MaxDurability() <= 0 || result.MaxDurability() <= 0 {
+		return result
+	}
+	durability := float64(base.Durability()) / float64(base.MaxDurability()) * float64(result.MaxDurability())
+	return result.WithDurability(int(math.Ceil(durability)))
+}
+
+// RegisterSmithingRecipe registers a smithing recipe that upgrades the base item passed into the result item
+// when combined with the addition item in a smithing table. A recipe previously registered with the same base
+// and addition is overwritten. Recipes should be registered before any players join, as they are sent to
+// players when they join.
+func RegisterSmithingRecipe(base, addition, result world.Item) {
+	k := smithingKey{base: keyOf(base), addition: keyOf(addition)}
+	r := SmithingRecipe{Base: base, Addition: addition, Result: result}
+
+	smithingMu.Lock()
+	defer smithingMu.Unlock()
+	if i, ok := smithingIndices[k]; ok {
+		smithingRecipes[i] = r
+		return
+	}
+	smithingIndices[k] = len(smithingRecipes)
+	smithingRecipes = append(smithingRecipes, r)
+}
+
+// SmithingRecipes returns all smithing recipes that are currently registered, in the order that they were
+// registered in.
+func SmithingRecipes() []SmithingRecipe {
+	smithingMu.RLock()
+	defer smithingMu.RUnlock()
+	return append([]SmithingRecipe(nil), smithingRecipes...)
+}
+
+// Smithing looks up the smithing recipe with the base and addition items passed. False is returned if no such
+// recipe is registered.
+func Smithing(base, addition world.Item) (SmithingRecipe, bool) {
+	smithingMu.RLock()
+	defer smithingMu.RUnlock()
+	i, ok := smithingIndices[smithingKey{base: keyOf(base), addition: keyOf(addition)}]
+	if !ok {
+		return SmithingRecipe{}, false
+	}
+	return smithingRecipes[i], true
+}
+
+var (
+	// smithingMu protects smithingRecipes and smithingIndices, as plugins may register recipes at any time.
+	smithingMu sync.RWMutex
+	// smithingRecipes holds all smithing recipes registered, in the order that they were registered in.
+	smithingRecipes []SmithingRecipe
+	// smithingIndices holds the indices of the recipes in smithingRecipes, indexed by their base and addition.
+	smithingIndices = map[smithingKey]int{}
+)
+
+// smithingKey is the key of a smithing recipe in smithingIndices.
+type smithingKey struct {
+	base, addition itemKey
+}
+
+// itemKey holds the name and meta of an item.
+type itemKey struct {
+	name string
+	meta int16
+}
+
+// keyOf returns the itemKey of the item passed.
+func keyOf(it world.Item) itemKey {
+	name, meta := it.EncodeItem()
+	return itemKey{name: name, meta: meta}
+}
+
+// init registers the vanilla smithing recipes, which upgrade diamond gear to netherite gear.
+func init() {
+	RegisterSmithingRecipe(Sword{Tier: tool.TierDiamond}, NetheriteIngot{}, Sword{Tier: tool.TierNetherite})
+	RegisterSmithingRecipe(Shovel{Tier: tool.TierDiamond}, NetheriteIngot{}, Shovel{Tier: tool.TierNetherite})
+	RegisterSmithingRecipe(Pickaxe{Tier: tool.TierDiamond}, NetheriteIngot{}, Pickaxe{Tier: tool.TierNetherite})
+	RegisterSmithingRecipe(Axe{Tier: tool.TierDiamond}, NetheriteIngot{}, Axe{Tier: tool.TierNetherite})
+	RegisterSmithingRecipe(Hoe{Tier: tool.TierDiamond}, NetheriteIngot{}, Hoe{Tier: tool.TierNetherite})
+	RegisterSmithingRecipe(Helmet{Tier: armour.TierDiamond}, NetheriteIngot{}, Helmet{Tier: armour.TierNetherite})
+	RegisterSmithingRecipe(Chestplate{Tier: armour.TierDiamond}, NetheriteIngot{}, Chestplate{Tier: armour.TierNetherite})
+	RegisterSmithingRecipe(Leggings{Tier: armour.TierDiamond}, NetheriteIngot{}, Leggings{Tier: armour.TierNetherite})
+	RegisterSmithingRecipe(Boots{Tier: armour.TierDiamond}, NetheriteIngot{}, Boots{Tier: armour.TierNetherite})
+}
@@ -0,0 +1,32 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SpawnEgg is an item that spawns an entity when used on a block. Spawn eggs are registered automatically for
+// entities that implement world.SpawnEggEntity.
+type SpawnEgg struct {
+	// Entity is the name of the entity spawned by the spawn egg, such as 'minecraft:zombie'. The entity must be
+	// registered using world.RegisterEntity.
+	Entity string
+}
+
+// UseOnBlock spawns the entity of the spawn egg on the side of the block clicked.
+func (s SpawnEgg) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, _ User, ctx *UseContext) bool {
+	e, ok := world.NewEntity(s.Entity, pos.Side(face).Vec3Middle(), nil)
+	if !ok {
+		return false
+	}
+	w.AddEntity(e)
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (s SpawnEgg) EncodeItem() (name string, meta int16) {
+	return s.Entity + "_spawn_egg", 0
+}
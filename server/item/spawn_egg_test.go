@@ -0,0 +1,78 @@
+package item_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// zombie is an entity registered for the test that opts in to having a spawn egg.
+type zombie struct {
+	*entity.Snowball
+}
+
+// EncodeEntity ...
+func (*zombie) EncodeEntity() string {
+	return "minecraft:zombie"
+}
+
+// SpawnEgg ...
+func (*zombie) SpawnEgg() world.Item {
+	return item.SpawnEgg{Entity: "minecraft:zombie"}
+}
+
+// DecodeNBT ...
+func (*zombie) DecodeNBT(data map[string]interface{}) interface{} {
+	pos, _ := data["Pos"].([]float32)
+	if invalid, _ := data["Invalid"].(bool); invalid {
+		return (*zombie)(nil)
+	}
+	return &zombie{Snowball: entity.NewSnowball(mgl64.Vec3{float64(pos[0]), float64(pos[1]), float64(pos[2])}, 0, 0, nil)}
+}
+
+func init() {
+	world.RegisterEntity(&zombie{})
+}
+
+// TestSpawnEgg checks that using a spawn egg on the top of a block spawns its entity on top of that block, and
+// that entities returning a typed nil from DecodeNBT are not spawned.
+func TestSpawnEgg(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	ground := cube.Pos{0, 9, 0}
+	w.SetBlock(ground, block.Stone{})
+	p := w.NewPlayer("spawner", mgl64.Vec3{2.5, 10, 0.5})
+	defer p.Close()
+
+	if _, ok := world.ItemByName("minecraft:zombie_spawn_egg", 0); !ok {
+		t.Fatalf("spawn egg of registered entity was not registered")
+	}
+	p.SetHeldItems(item.NewStack(item.SpawnEgg{Entity: "minecraft:zombie"}, 2), item.Stack{})
+	p.UseItemOnBlock(ground, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+
+	var spawned []world.Entity
+	for _, e := range w.Entities() {
+		if _, ok := e.(*zombie); ok {
+			spawned = append(spawned, e)
+		}
+	}
+	if len(spawned) != 1 {
+		t.Fatalf("%v entities spawned, want 1", len(spawned))
+	}
+	if pos, want := spawned[0].Position(), (mgl64.Vec3{0.5, 10, 0.5}); pos != want {
+		t.Errorf("entity spawned at %v, want %v", pos, want)
+	}
+	if held, _ := p.HeldItems(); held.Count() != 1 {
+		t.Errorf("%v spawn eggs left, want 1", held.Count())
+	}
+
+	if e, ok := world.NewEntity("minecraft:zombie", mgl64.Vec3{}, map[string]interface{}{"Invalid": true}); ok || e != nil {
+		t.Errorf("NewEntity returned %#v, %v for typed nil, want nil, false", e, ok)
+	}
+}
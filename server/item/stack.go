@@ -3,6 +3,7 @@ package item
 import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server/world"
+	"math"
 	"reflect"
 	"strings"
 	"sync/atomic"
@@ -19,11 +20,16 @@ type Stack struct {
 	customName string
 	lore       []string
 
-	damage int
+	damage      int
+	unbreakable bool
+
+	attackDamage, miningSpeed float64
 
 	data map[string]interface{}
 
 	enchantments map[reflect.Type]Enchantment
+
+	canPlaceOn, canDestroy []world.Block
 }
 
 // NewStack returns a new stack using the item type and the count passed. NewStack panics if the count passed
@@ -90,12 +96,16 @@ func (s Stack) MaxDurability() int {
 // If the final durability reaches 0 or below, the item returned is the resulting item of the breaking of the
 // item. If the final durability reaches a number higher than the maximum durability, the stack returned will
 // get the maximum durability.
+// Unbreakable stacks are never damaged, but may still have durability added.
 func (s Stack) Damage(d int) Stack {
 	durable, ok := s.Item().(Durable)
 	if !ok {
 		// Not a durable item.
 		return s
 	}
+	if s.unbreakable && d > 0 {
+		return s
+	}
 	info := durable.DurabilityInfo()
 	if s.Durability()-d <= 0 {
 		// A durability of 0, so the item is broken.
@@ -149,9 +159,23 @@ func (s Stack) Item() world.Item {
 	return s.item
 }
 
+// WithItem returns a copy of the Stack with the item type passed. The count, durability, custom name, lore,
+// enchantments and other data of the Stack are kept. WithItem panics if the item type passed is nil.
+func (s Stack) WithItem(t world.Item) Stack {
+	if t == nil {
+		panic("cannot have a stack with item type nil")
+	}
+	s.item = t
+	return s
+}
+
 // AttackDamage returns the attack damage to the stack. By default, the value returned is 2.0. If the item
-// held implements the item.Weapon interface, this damage may be different.
+// held implements the item.Weapon interface, this damage may be different. If the attack damage was set using
+// Stack.WithAttackDamage, that attack damage is returned instead.
 func (s Stack) AttackDamage() float64 {
+	if s.attackDamage > 0 {
+		return s.attackDamage
+	}
 	if weapon, ok := s.Item().(Weapon); ok {
 		// Bonus attack damage from weapons is a bit quirky in Bedrock Edition: Even though tools say they
 		// have, for example, + 5 Attack Damage, it is actually 1 + 5, while punching with a hand in Bedrock
@@ -162,6 +186,46 @@ func (s Stack) AttackDamage() float64 {
 	return 2.0
 }
 
+// WithAttackDamage returns a copy of the Stack that deals the attack damage passed, overriding the attack damage
+// of its item type. Passing 0 resets the attack damage to that of the item type. Note that the client is unaware
+// of the attack damage set, so the attack damage shown in the tooltip of the item is not changed.
+func (s Stack) WithAttackDamage(dmg float64) Stack {
+	s.attackDamage = math.Max(dmg, 0)
+	return s
+}
+
+// WithMiningSpeed returns a copy of the Stack with a mining speed multiplier. The time it takes to break a block
+// with the Stack is divided by the multiplier passed, after all other modifiers are applied. Passing 0 or 1
+// resets the mining speed to that of the item type.
+func (s Stack) WithMiningSpeed(multiplier float64) Stack {
+	s.miningSpeed = math.Max(multiplier, 0)
+	if s.miningSpeed == 1 {
+		s.miningSpeed = 0
+	}
+	return s
+}
+
+// MiningSpeed returns the mining speed multiplier of the Stack, as set using Stack.WithMiningSpeed. By default,
+// the multiplier returned is 1.
+func (s Stack) MiningSpeed() float64 {
+	if s.miningSpeed > 0 {
+		return s.miningSpeed
+	}
+	return 1
+}
+
+// WithUnbreakable returns a copy of the Stack that is unbreakable if true is passed. Unbreakable stacks do not
+// lose durability when used and are shown without a durability bar by the client.
+func (s Stack) WithUnbreakable(unbreakable bool) Stack {
+	s.unbreakable = unbreakable
+	return s
+}
+
+// Unbreakable checks if the Stack is unbreakable, as set using Stack.WithUnbreakable.
+func (s Stack) Unbreakable() bool {
+	return s.unbreakable
+}
+
 // WithCustomName returns a copy of the Stack with the custom name passed. The custom name is formatted
 // according to the rules of fmt.Sprintln.
 func (s Stack) WithCustomName(a ...interface{}) Stack {
@@ -250,6 +314,48 @@ func (s Stack) Enchantments() []Enchantment {
 	return e
 }
 
+// WithCanPlaceOn returns a copy of the Stack that may only be placed on the blocks passed by players whose game
+// mode does not allow editing the world, such as adventure mode. Blocks are matched by their name only, so any
+// state of a block passed matches. Only blocks that are also registered as items persist when the Stack is saved.
+// The restriction may be cleared by passing no blocks.
+func (s Stack) WithCanPlaceOn(blocks ...world.Block) Stack {
+	s.canPlaceOn = uniqueBlocks(blocks)
+	return s
+}
+
+// CanPlaceOn returns the blocks that the Stack may be placed on in game modes that do not allow editing the world,
+// as set using Stack.WithCanPlaceOn.
+func (s Stack) CanPlaceOn() []world.Block {
+	return s.canPlaceOn
+}
+
+// PlaceableOn checks if the Stack may be placed on the block passed in game modes that do not allow editing the
+// world.
+func (s Stack) PlaceableOn(b world.Block) bool {
+	return containsBlock(s.canPlaceOn, b)
+}
+
+// WithCanDestroy returns a copy of the Stack that may be used to break the blocks passed by players whose game
+// mode does not allow editing the world, such as adventure mode. Blocks are matched by their name only, so any
+// state of a block passed matches. Only blocks that are also registered as items persist when the Stack is saved.
+// The restriction may be cleared by passing no blocks.
+func (s Stack) WithCanDestroy(blocks ...world.Block) Stack {
+	s.canDestroy = uniqueBlocks(blocks)
+	return s
+}
+
+// CanDestroy returns the blocks that the Stack may break in game modes that do not allow editing the world, as
+// set using Stack.WithCanDestroy.
+func (s Stack) CanDestroy() []world.Block {
+	return s.canDestroy
+}
+
+// Destroys checks if the Stack may be used to break the block passed in game modes that do not allow editing the
+// world.
+func (s Stack) Destroys(b world.Block) bool {
+	return containsBlock(s.canDestroy, b)
+}
+
 // AddStack adds another stack to the stack and returns both stacks. The first stack returned will have as
 // many items in it as possible to fit in the stack, according to a max count of either 64 or otherwise as
 // returned by Item.MaxCount(). The second stack will have the leftover items: It may be empty if the count of
@@ -281,7 +387,8 @@ func (s Stack) Equal(s2 Stack) bool {
 }
 
 // Comparable checks if two stacks can be considered comparable. True is returned if the two stacks have an
-// equal item type and have equal enchantments, lore and custom names, or if one of the stacks is empty.
+// equal item type and have equal enchantments, lore, custom names, block restrictions, attack damage and
+// mining speed overrides and are both either breakable or unbreakable, or if one of the stacks is empty.
 func (s Stack) Comparable(s2 Stack) bool {
 	if s.Empty() || s2.Empty() {
 		return true
@@ -289,7 +396,7 @@ func (s Stack) Comparable(s2 Stack) bool {
 
 	name, meta := s.Item().EncodeItem()
 	name2, meta2 := s2.Item().EncodeItem()
-	if name != name2 || meta != meta2 || s.damage != s2.damage {
+	if name != name2 || meta != meta2 || s.damage != s2.damage || s.unbreakable != s2.unbreakable || s.attackDamage != s2.attackDamage || s.miningSpeed != s2.miningSpeed {
 		return false
 	}
 	if s.customName != s2.customName || len(s.lore) != len(s2.lore) || len(s.enchantments) != len(s2.enchantments) {
@@ -300,6 +407,9 @@ func (s Stack) Comparable(s2 Stack) bool {
 			return false
 		}
 	}
+	if !sameBlocks(s.canPlaceOn, s2.canPlaceOn) || !sameBlocks(s.canDestroy, s2.canDestroy) {
+		return false
+	}
 	for i := range s.enchantments {
 		if s.enchantments[i] != s2.enchantments[i] {
 			return false
@@ -373,3 +483,40 @@ func copyEnchantments(m map[reflect.Type]Enchantment) map[reflect.Type]Enchantme
 	}
 	return cp
 }
+
+// containsBlock checks if a block with the same name as the block passed is present in the slice of blocks.
+func containsBlock(blocks []world.Block, b world.Block) bool {
+	name, _ := b.EncodeBlock()
+	for _, other := range blocks {
+		if otherName, _ := other.EncodeBlock(); otherName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueBlocks returns the blocks passed without any blocks that have the same name as a block before it.
+func uniqueBlocks(blocks []world.Block) []world.Block {
+	unique := make([]world.Block, 0, len(blocks))
+	for _, b := range blocks {
+		if !containsBlock(unique, b) {
+			unique = append(unique, b)
+		}
+	}
+	return unique
+}
+
+// sameBlocks checks if the two slices of blocks hold blocks with the same names in the same order.
+func sameBlocks(a, b []world.Block) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		name, _ := a[i].EncodeBlock()
+		name2, _ := b[i].EncodeBlock()
+		if name != name2 {
+			return false
+		}
+	}
+	return true
+}
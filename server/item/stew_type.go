@@ -0,0 +1,101 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"time"
+)
+
+// StewType represents a type of suspicious stew, which determines the effect it gives.
+type StewType struct {
+	stewType
+}
+
+type stewType uint8
+
+// NightVisionStew returns suspicious stew made with a poppy, which gives night vision.
+func NightVisionStew() StewType {
+	return StewType{0}
+}
+
+// JumpBoostStew returns suspicious stew made with a cornflower, which gives jump boost.
+func JumpBoostStew() StewType {
+	return StewType{1}
+}
+
+// WeaknessStew returns suspicious stew made with a tulip, which gives weakness.
+func WeaknessStew() StewType {
+	return StewType{2}
+}
+
+// BlindnessStew returns suspicious stew made with an azure bluet, which gives blindness.
+func BlindnessStew() StewType {
+	return StewType{3}
+}
+
+// PoisonStew returns suspicious stew made with a lily of the valley, which gives poison.
+func PoisonStew() StewType {
+	return StewType{4}
+}
+
+// SaturationDandelionStew returns suspicious stew made with a dandelion, which gives saturation.
+func SaturationDandelionStew() StewType {
+	return StewType{5}
+}
+
+// SaturationOrchidStew returns suspicious stew made with a blue orchid, which gives saturation.
+func SaturationOrchidStew() StewType {
+	return StewType{6}
+}
+
+// RegenerationStew returns suspicious stew made with an oxeye daisy, which gives regeneration.
+func RegenerationStew() StewType {
+	return StewType{7}
+}
+
+// FireResistanceStew returns suspicious stew made with an allium, which gives fire resistance.
+func FireResistanceStew() StewType {
+	return StewType{8}
+}
+
+// WitherStew returns suspicious stew made with a wither rose, which gives wither.
+func WitherStew() StewType {
+	return StewType{9}
+}
+
+// Uint8 returns the stew type as a uint8.
+func (s stewType) Uint8() uint8 {
+	return uint8(s)
+}
+
+// Effects returns the effects given by suspicious stew of the type.
+func (s StewType) Effects() []effect.Effect {
+	switch s {
+	case NightVisionStew():
+		return []effect.Effect{effect.New(effect.NightVision{}, 1, time.Second*4)}
+	case JumpBoostStew():
+		return []effect.Effect{effect.New(effect.JumpBoost{}, 1, time.Second*4)}
+	case WeaknessStew():
+		return []effect.Effect{effect.New(effect.Weakness{}, 1, time.Second*7)}
+	case BlindnessStew():
+		return []effect.Effect{effect.New(effect.Blindness{}, 1, time.Second*6)}
+	case PoisonStew():
+		return []effect.Effect{effect.New(effect.Poison{}, 1, time.Second*10)}
+	case SaturationDandelionStew(), SaturationOrchidStew():
+		return []effect.Effect{effect.New(effect.Saturation{}, 1, time.Millisecond*350)}
+	case RegenerationStew():
+		return []effect.Effect{effect.New(effect.Regeneration{}, 1, time.Second*6)}
+	case FireResistanceStew():
+		return []effect.Effect{effect.New(effect.FireResistance{}, 1, time.Second*2)}
+	case WitherStew():
+		return []effect.Effect{effect.New(effect.Wither{}, 1, time.Second*6)}
+	}
+	return nil
+}
+
+// StewTypes returns all suspicious stew types.
+func StewTypes() []StewType {
+	return []StewType{
+		NightVisionStew(), JumpBoostStew(), WeaknessStew(), BlindnessStew(), PoisonStew(), SaturationDandelionStew(),
+		SaturationOrchidStew(), RegenerationStew(), FireResistanceStew(), WitherStew(),
+	}
+}
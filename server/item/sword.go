@@ -48,6 +48,11 @@ func (s Sword) DurabilityInfo() DurabilityInfo {
 	}
 }
 
+// FireProof returns true if the sword is made of netherite.
+func (s Sword) FireProof() bool {
+	return s.Tier == tool.TierNetherite
+}
+
 // EncodeItem ...
 func (s Sword) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + s.Tier.Name + "_sword", 0
@@ -0,0 +1,89 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"sort"
+	"sync"
+)
+
+// tagKey is the key used to identify an item in a tag. Items are identified by the name and metadata value they
+// encode to, so that all variants of an item encoding to the same ID share the same tags.
+type tagKey struct {
+	name string
+	meta int16
+}
+
+var (
+	tagMu sync.RWMutex
+	// tags holds all registered tags, indexed by their name, and the items that are part of each tag.
+	tags = map[string]map[tagKey]world.Item{}
+	// itemTags holds the tags of every item that is part of at least one tag. It is kept in sync with tags so
+	// that checking if an item has a tag does not require scanning the items of a tag.
+	itemTags = map[tagKey]map[string]struct{}{}
+)
+
+// RegisterTag adds the items passed to the tag with the name passed, such as 'minecraft:planks'. If the tag
+// does not yet exist, it is created. Plugins may use RegisterTag both to define new tags and to add items to
+// existing ones, including the vanilla tags registered by default.
+func RegisterTag(tag string, items ...world.Item) {
+	tagMu.Lock()
+	defer tagMu.Unlock()
+	if _, ok := tags[tag]; !ok {
+		tags[tag] = map[tagKey]world.Item{}
+	}
+	for _, it := range items {
+		name, meta := it.EncodeItem()
+		k := tagKey{name: name, meta: meta}
+		tags[tag][k] = it
+		if _, ok := itemTags[k]; !ok {
+			itemTags[k] = map[string]struct{}{}
+		}
+		itemTags[k][tag] = struct{}{}
+	}
+}
+
+// HasTag checks if the item passed is part of the tag with the name passed. A nil item is never part of a tag.
+func HasTag(it world.Item, tag string) bool {
+	if it == nil {
+		return false
+	}
+	name, meta := it.EncodeItem()
+	tagMu.RLock()
+	defer tagMu.RUnlock()
+	_, ok := itemTags[tagKey{name: name, meta: meta}][tag]
+	return ok
+}
+
+// Tags returns the names of all tags that the item passed is part of, sorted alphabetically. If the item is nil,
+// nil is returned.
+func Tags(it world.Item) []string {
+	if it == nil {
+		return nil
+	}
+	name, meta := it.EncodeItem()
+	tagMu.RLock()
+	m := itemTags[tagKey{name: name, meta: meta}]
+	t := make([]string, 0, len(m))
+	for tag := range m {
+		t = append(t, tag)
+	}
+	tagMu.RUnlock()
+	sort.Strings(t)
+	return t
+}
+
+// TagItems returns all items that are part of the tag with the name passed. If the tag does not exist, nil is
+// returned.
+func TagItems(tag string) []world.Item {
+	tagMu.RLock()
+	defer tagMu.RUnlock()
+	m, ok := tags[tag]
+	if !ok {
+		return nil
+	}
+	items := make([]world.Item, 0, len(m))
+	for _, it := range m {
+		items = append(items, it)
+	}
+	return items
+}
@@ -0,0 +1,153 @@
+package trade
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"math"
+	"sync"
+)
+
+// TradeList is a list of trades offered by a Trader. It keeps track of the amount of times each trade was used and
+// of the demand for it. A TradeList is safe for concurrent use.
+type TradeList struct {
+	mu     sync.Mutex
+	trades []Trade
+	// uses and demand hold the amount of times that each trade was used since the last restock and the demand for
+	// each trade, indexed the same as trades.
+	uses, demand []int
+}
+
+// NewTradeList returns a new TradeList that offers the trades passed.
+func NewTradeList(trades ...Trade) *TradeList {
+	return &TradeList{
+		trades: append([]Trade(nil), trades...),
+		uses:   make([]int, len(trades)),
+		demand: make([]int, len(trades)),
+	}
+}
+
+// Add adds a trade to the end of the TradeList.
+func (l *TradeList) Add(t Trade) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.trades = append(l.trades, t)
+	l.uses = append(l.uses, 0)
+	l.demand = append(l.demand, 0)
+}
+
+// Trades returns all trades in the TradeList, in the order that they are offered.
+func (l *TradeList) Trades() []Trade {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Trade(nil), l.trades...)
+}
+
+// Trade returns the trade at the index passed. False is returned if no trade exists at that index.
+func (l *TradeList) Trade(index int) (Trade, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index < 0 || index >= len(l.trades) {
+		return Trade{}, false
+	}
+	return l.trades[index], true
+}
+
+// Uses returns the amount of times that the trade at the index passed was used since the TradeList was last
+// restocked.
+func (l *TradeList) Uses(index int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index < 0 || index >= len(l.uses) {
+		return 0
+	}
+	return l.uses[index]
+}
+
+// Price returns the first input of the trade at the index passed, with its count raised by the demand for the
+// trade. An empty stack is returned if no trade exists at that index.
+func (l *TradeList) Price(index int) item.Stack {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index < 0 || index >= len(l.trades) {
+		return item.Stack{}
+	}
+	return l.price(index)
+}
+
+// price returns the first input of the trade at the index passed, adjusted for demand. price must be called while
+// holding the mutex.
+func (l *TradeList) price(index int) item.Stack {
+	t := l.trades[index]
+	count := t.Input.Count()
+	if d := l.demand[index]; d > 0 {
+		count += int(math.Floor(float64(count*d) * t.PriceMultiplier))
+	}
+	if max := t.Input.MaxCount(); count > max {
+		count = max
+	}
+	return t.Input.Grow(count - t.Input.Count())
+}
+
+// Validate checks if the trade at the index passed may be executed with the two input stacks passed, without
+// executing it. An error is returned if the trade does not exist, is out of uses or if the inputs do not
+// satisfy its price.
+func (l *TradeList) Validate(index int, first, second item.Stack) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _, err := l.validate(index, first, second)
+	return err
+}
+
+// Execute executes the trade at the index passed using the two input stacks passed, increasing the uses of the
+// trade. The stacks left over after paying the price of the trade are returned. An error is returned if the
+// trade could not be executed, in which case nothing is changed.
+func (l *TradeList) Execute(index int, first, second item.Stack) (firstLeft, secondLeft item.Stack, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	firstLeft, secondLeft, err = l.validate(index, first, second)
+	if err != nil {
+		return first, second, err
+	}
+	l.uses[index]++
+	return firstLeft, secondLeft, nil
+}
+
+// validate checks if the trade at the index passed may be executed with the inputs passed and returns the stacks
+// left over after paying its price. validate must be called while holding the mutex.
+func (l *TradeList) validate(index int, first, second item.Stack) (firstLeft, secondLeft item.Stack, err error) {
+	if index < 0 || index >= len(l.trades) {
+		return first, second, fmt.Errorf("trade %v does not exist", index)
+	}
+	t := l.trades[index]
+	if t.MaxUses > 0 && l.uses[index] >= t.MaxUses {
+		return first, second, fmt.Errorf("trade %v is out of uses (%v/%v)", index, l.uses[index], t.MaxUses)
+	}
+	price := l.price(index)
+	if first.Empty() || !first.Comparable(price) || first.Count() < price.Count() {
+		return first, second, fmt.Errorf("first input %v does not satisfy price %v", first, price)
+	}
+	secondLeft = second
+	if !t.SecondInput.Empty() {
+		if second.Empty() || !second.Comparable(t.SecondInput) || second.Count() < t.SecondInput.Count() {
+			return first, second, fmt.Errorf("second input %v does not satisfy price %v", second, t.SecondInput)
+		}
+		secondLeft = second.Grow(-t.SecondInput.Count())
+	}
+	return first.Grow(-price.Count()), secondLeft, nil
+}
+
+// Restock restocks all trades in the TradeList, resetting their uses. The demand for each trade is updated: It
+// rises if the trade was used more than half of its maximum uses since the last restock and falls otherwise.
+func (l *TradeList) Restock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, t := range l.trades {
+		if t.MaxUses > 0 {
+			l.demand[i] += l.uses[i] - (t.MaxUses - l.uses[i])
+			if l.demand[i] < 0 {
+				l.demand[i] = 0
+			}
+		}
+		l.uses[i] = 0
+	}
+}
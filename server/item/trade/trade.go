@@ -0,0 +1,35 @@
+package trade
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Trade is a single offer of a Trader, which exchanges one or two input item stacks for an output item stack.
+type Trade struct {
+	// Input is the item stack that the Trader demands in exchange for the Output. The count of the stack is the
+	// base price of the trade, which may be raised by the demand for the trade, as returned by TradeList.Price.
+	Input item.Stack
+	// SecondInput is an optional second item stack that the Trader demands in exchange for the Output. Its
+	// price is not affected by demand.
+	SecondInput item.Stack
+	// Output is the item stack that is handed out when the trade is executed.
+	Output item.Stack
+	// MaxUses is the maximum amount of times that the trade may be executed before it is locked until the
+	// TradeList is restocked. If MaxUses is 0 or lower, the trade may be executed an unlimited amount of times.
+	MaxUses int
+	// PriceMultiplier is the multiplier with which the demand for the trade raises the price of its Input. For
+	// most vanilla trades, this is 0.05.
+	PriceMultiplier float64
+	// Experience is the amount of experience points rewarded to the player that executes the trade.
+	Experience int
+}
+
+// Trader represents an entity that offers trades, such as a villager or a custom shopkeeper. The trading UI of a
+// Trader may be opened for a player using player.Player.OpenTrade.
+type Trader interface {
+	world.Entity
+	// TradeList returns the list of trades offered by the Trader. The same TradeList should be returned every
+	// time, so that the uses of its trades are tracked.
+	TradeList() *TradeList
+}
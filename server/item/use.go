@@ -1,11 +1,22 @@
 package item
 
+import "github.com/df-mc/dragonfly/server/world"
+
 // UseContext is passed to every item Use methods. It may be used to subtract items or to deal damage to them
 // after the action is complete.
 type UseContext struct {
-	// IgnoreAABB specifies if placing the item should ignore the AABB of the player placing this. This is the case for
-	// items such as cocoa beans.
+	// IgnoreAABB specifies if placing the item should ignore the AABB of the player placing this, and of any other
+	// entities in the way. This is the case for items such as cocoa beans. Handlers may set it in
+	// player.Handler.HandleBlockPlace to allow placing blocks inside entities.
 	IgnoreAABB bool
+	// Obstructed specifies if an entity is in the way of the block being placed. It is set before
+	// player.Handler.HandleBlockPlace is called, so that handlers know the placement will fail unless they set
+	// IgnoreAABB.
+	Obstructed bool
+	// ClickedBlock is the block that was clicked to use the item, if the item was used on a block. Players that
+	// may not edit the world may only place blocks if the item used may be placed on the ClickedBlock, as
+	// specified using Stack.WithCanPlaceOn.
+	ClickedBlock world.Block
 	// Damage is the amount of damage that should be dealt to the item as a result of using it.
 	Damage int
 	// CountSub is how much of the count should be subtracted after using the item.
@@ -0,0 +1,22 @@
+// Package npc implements NPCs: Humanoid entities that look like players, but that are controlled entirely by
+// the server. NPCs are much lighter than a player.Player, as they have no session, inventory, hunger or
+// effects.
+//
+// An NPC is created using npc.New and must be added to a world to be shown to players. Functions may be set
+// in the npc.Config to handle players attacking or interacting with the NPC. The example below spawns an NPC
+// that is only visible to a single player and that opens a menu form when that player interacts with it:
+//
+//	n := npc.New(npc.Config{
+//		Name:     "Shopkeeper",
+//		Skin:     p.Skin(),
+//		Position: p.Position(),
+//		MainHand: item.NewStack(item.Emerald{}, 1),
+//		Viewers:  []*player.Player{p},
+//		Interact: func(n *npc.NPC, p *player.Player) {
+//			n.LookAt(p.Position())
+//			n.SwingArm()
+//			p.SendForm(form.NewMenu(shop{Buy: form.NewButton("Buy", "")}, "Shop"))
+//		},
+//	})
+//	p.World().AddEntity(n)
+package npc
@@ -0,0 +1,288 @@
+package npc
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/action"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math"
+	"sync"
+)
+
+// Config holds the settings used to create an NPC using New.
+type Config struct {
+	// Name is the name of the NPC. It is shown in its name tag, unless it is changed using NPC.SetNameTag.
+	Name string
+	// Skin is the skin that the NPC is shown with.
+	Skin skin.Skin
+	// Position is the position that the NPC is created at. Yaw and Pitch are the initial rotation of the NPC.
+	Position   mgl64.Vec3
+	Yaw, Pitch float64
+	// MainHand and OffHand are the items that the NPC holds.
+	MainHand, OffHand item.Stack
+	// Helmet, Chestplate, Leggings and Boots are the armour pieces worn by the NPC.
+	Helmet, Chestplate, Leggings, Boots item.Stack
+	// Viewers holds the players that the NPC is visible to. If empty, the NPC is visible to every player.
+	// Players may be added or removed later using NPC.ShowTo and NPC.HideFrom.
+	Viewers []*player.Player
+
+	// Attack is called when a player attacks the NPC. It may be left nil.
+	Attack func(n *NPC, p *player.Player)
+	// Interact is called when a player interacts with the NPC, typically by right-clicking it. It may be left
+	// nil.
+	Interact func(n *NPC, p *player.Player)
+}
+
+// NPC is a humanoid entity that looks like a player, but is controlled entirely by the server. Unlike a
+// player.Player, it has no session, health, hunger or effects. It is only shown to viewers and calls the
+// functions passed in its Config when a player attacks or interacts with it.
+type NPC struct {
+	conf   Config
+	uuid   uuid.UUID
+	armour *inventory.Armour
+
+	mu                sync.Mutex
+	pos               mgl64.Vec3
+	yaw, pitch        float64
+	nameTag           string
+	skin              skin.Skin
+	mainHand, offHand item.Stack
+	viewers           map[world.Entity]struct{}
+}
+
+// New creates a new NPC using the Config passed. The NPC is not yet spawned: It must be added to a world using
+// world.World.AddEntity.
+func New(conf Config) *NPC {
+	n := &NPC{
+		conf:     conf,
+		uuid:     uuid.New(),
+		pos:      conf.Position,
+		yaw:      conf.Yaw,
+		pitch:    conf.Pitch,
+		nameTag:  conf.Name,
+		skin:     conf.Skin,
+		mainHand: conf.MainHand,
+		offHand:  conf.OffHand,
+	}
+	if len(conf.Viewers) > 0 {
+		n.viewers = make(map[world.Entity]struct{}, len(conf.Viewers))
+		for _, p := range conf.Viewers {
+			n.viewers[p] = struct{}{}
+		}
+	}
+	n.armour = inventory.NewArmour(func(int, item.Stack) {
+		for _, v := range n.World().Viewers(n.Position()) {
+			v.ViewEntityArmour(n)
+		}
+	})
+	n.armour.Set(conf.Helmet, conf.Chestplate, conf.Leggings, conf.Boots)
+	return n
+}
+
+// Name returns the name of the NPC passed in its Config.
+func (n *NPC) Name() string {
+	return n.conf.Name
+}
+
+// UUID returns the UUID of the NPC. A random UUID is generated when the NPC is created.
+func (n *NPC) UUID() uuid.UUID {
+	return n.uuid
+}
+
+// EncodeEntity ...
+func (n *NPC) EncodeEntity() string {
+	return "minecraft:player"
+}
+
+// AABB returns the same AABB that a player has.
+func (n *NPC) AABB() physics.AABB {
+	return physics.NewAABB(mgl64.Vec3{-0.3, 0, -0.3}, mgl64.Vec3{0.3, 1.8, 0.3})
+}
+
+// EyeHeight returns the eye height of the NPC: 1.62.
+func (n *NPC) EyeHeight() float64 {
+	return 1.62
+}
+
+// Immobile always returns true.
+func (n *NPC) Immobile() bool {
+	return true
+}
+
+// Position returns the current position of the NPC.
+func (n *NPC) Position() mgl64.Vec3 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.pos
+}
+
+// Rotation returns the yaw and pitch of the NPC in degrees.
+func (n *NPC) Rotation() (float64, float64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.yaw, n.pitch
+}
+
+// World returns the world that the NPC is in, or nil if it was not yet added to one.
+func (n *NPC) World() *world.World {
+	w, _ := world.OfEntity(n)
+	return w
+}
+
+// Close removes the NPC from the world it is in.
+func (n *NPC) Close() error {
+	if w := n.World(); w != nil {
+		w.RemoveEntity(n)
+	}
+	return nil
+}
+
+// Skin returns the skin of the NPC.
+func (n *NPC) Skin() skin.Skin {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.skin
+}
+
+// SetSkin changes the skin of the NPC and shows the new skin to its viewers.
+func (n *NPC) SetSkin(s skin.Skin) {
+	n.mu.Lock()
+	n.skin = s
+	n.mu.Unlock()
+	for _, v := range n.World().Viewers(n.Position()) {
+		v.ViewSkin(n)
+	}
+}
+
+// NameTag returns the name tag shown above the head of the NPC.
+func (n *NPC) NameTag() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.nameTag
+}
+
+// SetNameTag changes the name tag shown above the head of the NPC.
+func (n *NPC) SetNameTag(nameTag string) {
+	n.mu.Lock()
+	n.nameTag = nameTag
+	n.mu.Unlock()
+	for _, v := range n.World().Viewers(n.Position()) {
+		v.ViewEntityState(n)
+	}
+}
+
+// HeldItems returns the items held by the NPC in its main hand and off-hand.
+func (n *NPC) HeldItems() (mainHand, offHand item.Stack) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.mainHand, n.offHand
+}
+
+// SetHeldItems changes the items held by the NPC and shows them to its viewers.
+func (n *NPC) SetHeldItems(mainHand, offHand item.Stack) {
+	n.mu.Lock()
+	n.mainHand, n.offHand = mainHand, offHand
+	n.mu.Unlock()
+	for _, v := range n.World().Viewers(n.Position()) {
+		v.ViewEntityItems(n)
+	}
+}
+
+// Armour returns the armour inventory of the NPC. Changes made to it are shown to viewers directly.
+func (n *NPC) Armour() *inventory.Armour {
+	return n.armour
+}
+
+// Teleport moves the NPC to the position passed.
+func (n *NPC) Teleport(pos mgl64.Vec3) {
+	for _, v := range n.World().Viewers(n.Position()) {
+		v.ViewEntityTeleport(n, pos)
+	}
+	n.mu.Lock()
+	n.pos = pos
+	n.mu.Unlock()
+}
+
+// Rotate changes the yaw and pitch of the NPC to the values passed.
+func (n *NPC) Rotate(yaw, pitch float64) {
+	n.mu.Lock()
+	n.yaw, n.pitch = yaw, pitch
+	pos := n.pos
+	n.mu.Unlock()
+	for _, v := range n.World().Viewers(n.Position()) {
+		v.ViewEntityMovement(n, pos, yaw, pitch, true)
+	}
+}
+
+// LookAt rotates the NPC so that it looks at the position passed.
+func (n *NPC) LookAt(pos mgl64.Vec3) {
+	diff := pos.Sub(entity.EyePosition(n))
+	yaw := math.Atan2(-diff[0], diff[2]) * 180 / math.Pi
+	pitch := -math.Atan2(diff[1], math.Sqrt(diff[0]*diff[0]+diff[2]*diff[2])) * 180 / math.Pi
+	n.Rotate(yaw, pitch)
+}
+
+// SwingArm makes the NPC swing its arm.
+func (n *NPC) SwingArm() {
+	n.PlayAction(action.SwingArm{})
+}
+
+// PlayAction shows an entity action, such as action.SwingArm or action.Hurt, to the viewers of the NPC.
+func (n *NPC) PlayAction(a action.Action) {
+	for _, v := range n.World().Viewers(n.Position()) {
+		v.ViewEntityAction(n, a)
+	}
+}
+
+// ShowTo makes the NPC visible to the player passed. If the NPC was visible to every player, it will be
+// visible to only the players passed to ShowTo from now on.
+func (n *NPC) ShowTo(p *player.Player) {
+	n.mu.Lock()
+	if n.viewers == nil {
+		n.viewers = map[world.Entity]struct{}{}
+	}
+	n.viewers[p] = struct{}{}
+	n.mu.Unlock()
+	p.ShowEntity(n)
+}
+
+// HideFrom hides the NPC from the player passed.
+func (n *NPC) HideFrom(p *player.Player) {
+	n.mu.Lock()
+	if n.viewers != nil {
+		delete(n.viewers, p)
+	}
+	n.mu.Unlock()
+	p.HideEntity(n)
+}
+
+// VisibleTo checks if the NPC is visible to the viewer passed.
+func (n *NPC) VisibleTo(viewer world.Entity) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.viewers == nil {
+		return true
+	}
+	_, ok := n.viewers[viewer]
+	return ok
+}
+
+// Attack calls the Attack function of the Config of the NPC, if set.
+func (n *NPC) Attack(p *player.Player) {
+	if n.conf.Attack != nil {
+		n.conf.Attack(n, p)
+	}
+}
+
+// Interact calls the Interact function of the Config of the NPC, if set.
+func (n *NPC) Interact(p *player.Player) {
+	if n.conf.Interact != nil {
+		n.conf.Interact(n, p)
+	}
+}
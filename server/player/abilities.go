@@ -0,0 +1,106 @@
+package player
+
+import (
+	"sync"
+)
+
+// abilities holds the abilities of a player that were explicitly set, overriding the defaults of the game mode of
+// the player. A nil value means that the default of the game mode is used.
+type abilities struct {
+	mu                               sync.RWMutex
+	flight, worldBuilder, instaBuild *bool
+	muted                            bool
+}
+
+// SetAllowFlight sets if the player is allowed to fly, overriding the default of its game mode. If set to false
+// while the player is flying, it stops flying.
+// Explicitly set abilities are kept when the game mode of the player is changed. ResetAbilities may be used to
+// return to the defaults of the game mode.
+func (p *Player) SetAllowFlight(allow bool) {
+	p.setAbility(&p.abilities.flight, allow)
+	if !allow {
+		p.StopFlying()
+	}
+}
+
+// AllowsFlight checks if the player is allowed to fly. Unless changed using SetAllowFlight, this is the case if
+// the game mode of the player allows flying.
+func (p *Player) AllowsFlight() bool {
+	return p.ability(&p.abilities.flight, p.GameMode().AllowsFlying)
+}
+
+// SetWorldBuilder sets if the player is able to place and break blocks, overriding the default of its game mode.
+// Explicitly set abilities are kept when the game mode of the player is changed.
+func (p *Player) SetWorldBuilder(builder bool) {
+	p.setAbility(&p.abilities.worldBuilder, builder)
+}
+
+// WorldBuilder checks if the player is able to place and break blocks. Unless changed using SetWorldBuilder,
+// this is the case if the game mode of the player allows editing the world. Players that are not world builders
+// may still place and break blocks using items with item.Stack.WithCanPlaceOn and item.Stack.WithCanDestroy.
+func (p *Player) WorldBuilder() bool {
+	return p.ability(&p.abilities.worldBuilder, p.GameMode().AllowsEditing)
+}
+
+// SetInstantBuild sets if the player breaks blocks instantly and has the extended reach of creative mode,
+// overriding the default of its game mode.
+// Explicitly set abilities are kept when the game mode of the player is changed.
+func (p *Player) SetInstantBuild(instant bool) {
+	p.setAbility(&p.abilities.instaBuild, instant)
+}
+
+// InstantBuild checks if the player breaks blocks instantly and has the extended reach of creative mode. Unless
+// changed using SetInstantBuild, this is the case if the game mode of the player has a creative inventory.
+func (p *Player) InstantBuild() bool {
+	return p.ability(&p.abilities.instaBuild, p.GameMode().CreativeInventory)
+}
+
+// SetMuted sets if the player is muted. Muted players are unable to send chat messages: Their messages are
+// dropped before Handler.HandleChat is called. Players are not muted by default, regardless of their game mode.
+func (p *Player) SetMuted(muted bool) {
+	p.abilities.mu.Lock()
+	p.abilities.muted = muted
+	p.abilities.mu.Unlock()
+	p.session().SendGameMode(p.GameMode())
+}
+
+// Muted checks if the player is muted, as set using SetMuted.
+func (p *Player) Muted() bool {
+	p.abilities.mu.RLock()
+	defer p.abilities.mu.RUnlock()
+	return p.abilities.muted
+}
+
+// ResetAbilities clears all abilities explicitly set using SetAllowFlight, SetWorldBuilder and SetInstantBuild,
+// so that the defaults of the game mode of the player are used again. It does not unmute the player.
+func (p *Player) ResetAbilities() {
+	p.abilities.mu.Lock()
+	p.abilities.flight, p.abilities.worldBuilder, p.abilities.instaBuild = nil, nil, nil
+	p.abilities.mu.Unlock()
+
+	p.session().SendGameMode(p.GameMode())
+	if !p.AllowsFlight() {
+		p.StopFlying()
+	}
+}
+
+// setAbility explicitly sets the ability pointed to by a to the value passed and sends the new abilities of the
+// player to its client.
+func (p *Player) setAbility(a **bool, v bool) {
+	p.abilities.mu.Lock()
+	*a = &v
+	p.abilities.mu.Unlock()
+	p.session().SendGameMode(p.GameMode())
+}
+
+// ability returns the value of the ability passed if it was explicitly set, or the value returned by def if it
+// was not.
+func (p *Player) ability(a **bool, def func() bool) bool {
+	p.abilities.mu.RLock()
+	v := *a
+	p.abilities.mu.RUnlock()
+	if v != nil {
+		return *v
+	}
+	return def()
+}
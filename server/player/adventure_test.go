@@ -0,0 +1,51 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// TestAdventureRestrictions checks that adventure players only place blocks against the blocks clicked that
+// the held item may be placed on, and only break blocks that the held item may destroy.
+func TestAdventureRestrictions(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	stone, dirt := cube.Pos{0, 9, 0}, cube.Pos{1, 9, 0}
+	w.SetBlock(stone, block.Stone{})
+	w.SetBlock(dirt, block.Dirt{})
+	p := w.NewPlayer("adventurer", mgl64.Vec3{0.5, 10, 2.5})
+	defer p.Close()
+	p.SetGameMode(world.GameModeAdventure)
+
+	p.SetHeldItems(item.NewStack(block.Planks{Wood: block.OakWood()}, 4).WithCanPlaceOn(block.Stone{}), item.Stack{})
+	// The block above the dirt is next to the stone, but the dirt is clicked, so the planks may not be placed.
+	p.UseItemOnBlock(dirt, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+	if _, ok := w.Block(dirt.Side(cube.FaceUp)).(block.Planks); ok {
+		t.Errorf("planks placed on dirt, but may only be placed on stone")
+	}
+	p.UseItemOnBlock(stone, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+	if _, ok := w.Block(stone.Side(cube.FaceUp)).(block.Planks); !ok {
+		t.Errorf("planks not placed on stone")
+	}
+
+	p.SetHeldItems(item.Stack{}, item.Stack{})
+	p.BreakBlock(dirt)
+	if _, ok := w.Block(dirt).(block.Dirt); !ok {
+		t.Errorf("dirt broken without an item that may destroy it")
+	}
+	p.SetHeldItems(item.NewStack(item.Shovel{}, 1).WithCanDestroy(block.Stone{}), item.Stack{})
+	p.BreakBlock(dirt)
+	if _, ok := w.Block(dirt).(block.Dirt); !ok {
+		t.Errorf("dirt broken with an item that may only destroy stone")
+	}
+	p.BreakBlock(stone)
+	if _, ok := w.Block(stone).(block.Air); !ok {
+		t.Errorf("stone not broken with an item that may destroy it")
+	}
+}
@@ -0,0 +1,50 @@
+package player
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/player/audit"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// SetAuditSink sets the audit.Sink that records of the actions of the player, such as chatting and breaking
+// blocks, are written to. Records are written from the goroutine of the player, so the sink should not block:
+// audit.NewBuffer may be used to wrap a Sink that might. Passing nil stops writing records.
+func (p *Player) SetAuditSink(s audit.Sink) {
+	p.auditMu.Lock()
+	defer p.auditMu.Unlock()
+	p.auditSink = s
+}
+
+// AuditHeader returns an audit.Header for an action performed by the player at this moment, which may be used
+// to write records of actions not performed through the Player itself, such as joining the server.
+func (p *Player) AuditHeader(cancelled bool) audit.Header {
+	return audit.Header{Time: time.Now(), Player: p.name, UUID: p.uuid, Cancelled: cancelled}
+}
+
+// writeAudit writes the audit record passed to the audit.Sink of the player, if it has one.
+func (p *Player) writeAudit(r audit.Record) {
+	p.auditMu.RLock()
+	s := p.auditSink
+	p.auditMu.RUnlock()
+	if s != nil {
+		_ = s.Write(r)
+	}
+}
+
+// auditDeath returns an audit.Death record for the player being killed by the damage.Source passed.
+func (p *Player) auditDeath(src damage.Source) audit.Death {
+	var killer world.Entity
+	switch s := src.(type) {
+	case damage.SourceEntityAttack:
+		killer = s.Attacker
+	case damage.SourceProjectile:
+		killer = s.Owner
+	}
+	r := audit.Death{Header: p.AuditHeader(false), Cause: fmt.Sprintf("%T", src)}
+	if named, ok := killer.(interface{ Name() string }); ok {
+		r.Killer = named.Name()
+	}
+	return r
+}
@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/internal"
+	"go.uber.org/atomic"
+	"sync"
+)
+
+// Buffer is a Sink that buffers records written to it and writes them to another Sink on a separate goroutine,
+// so that writing a record never blocks. If the buffer is full, the oldest record in it is dropped to make
+// space for the new one.
+type Buffer struct {
+	s   Sink
+	log internal.Logger
+
+	// mu is held while writing to records, so that the oldest record may be dropped and the new one added
+	// without another record being added in between, and so that records is not written to after closing.
+	mu      sync.Mutex
+	closed  bool
+	records chan Record
+	done    chan struct{}
+
+	dropped atomic.Uint64
+}
+
+// NewBuffer returns a Buffer that buffers up to size records before writing them to the Sink passed. Errors
+// returned by the Sink are logged using the Logger passed.
+func NewBuffer(s Sink, size int, log internal.Logger) *Buffer {
+	if size <= 0 {
+		size = 1
+	}
+	b := &Buffer{s: s, log: log, records: make(chan Record, size), done: make(chan struct{})}
+	go b.run()
+	return b
+}
+
+// Write adds the record passed to the buffer. If the buffer is full, the oldest record in it is dropped. Write
+// never blocks and only returns an error if the Buffer was closed.
+func (b *Buffer) Write(r Record) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return errors.New("write audit record: buffer closed")
+	}
+	for {
+		select {
+		case b.records <- r:
+			return nil
+		default:
+		}
+		// The buffer is full: Drop the oldest record and try again. The oldest record might be taken by the
+		// writing goroutine in the meantime, in which case there is space for the new record anyway.
+		select {
+		case <-b.records:
+			b.dropped.Inc()
+		default:
+		}
+	}
+}
+
+// Dropped returns the amount of records that were dropped because the buffer was full.
+func (b *Buffer) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Close closes the Buffer. Records remaining in the buffer are written to the underlying Sink, after which
+// that Sink is closed too.
+func (b *Buffer) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return errors.New("close audit buffer: already closed")
+	}
+	b.closed = true
+	close(b.records)
+	b.mu.Unlock()
+
+	<-b.done
+	return b.s.Close()
+}
+
+// run writes the records added to the buffer to the underlying Sink until the buffer is closed.
+func (b *Buffer) run() {
+	defer close(b.done)
+	for r := range b.records {
+		if err := b.s.Write(r); err != nil {
+			b.log.Errorf("error writing %v audit record: %v", r.Kind(), err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// JSONFile is a Sink that writes records to a file in the JSON lines format: Every record is written on a
+// single line as a JSON object with a "type" field holding the Kind of the record and a "record" field holding
+// the record itself.
+type JSONFile struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewJSONFile opens the file at the path passed to write records to. If the file already exists, records are
+// appended to it. If not, it is created.
+func NewJSONFile(path string) (*JSONFile, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open audit file: %w", err)
+	}
+	return &JSONFile{f: f}, nil
+}
+
+// jsonRecord is the JSON object written to a JSONFile for every record.
+type jsonRecord struct {
+	Type   string `json:"type"`
+	Record Record `json:"record"`
+}
+
+// Write writes the record passed to the file as a single line of JSON.
+func (j *JSONFile) Write(r Record) error {
+	data, err := json.Marshal(jsonRecord{Type: r.Kind(), Record: r})
+	if err != nil {
+		return fmt.Errorf("encode %v audit record: %w", r.Kind(), err)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write %v audit record: %w", r.Kind(), err)
+	}
+	return nil
+}
+
+// Close closes the file.
+func (j *JSONFile) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.f.Close()
+}
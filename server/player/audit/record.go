@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Join is a record of a player joining the server.
+type Join struct {
+	Header
+	// Address is the network address that the player joined from.
+	Address string `json:"address"`
+	// XUID is the XBOX Live user ID of the player. It is empty if the player was not authenticated.
+	XUID string `json:"xuid"`
+}
+
+// Quit is a record of a player leaving the server.
+type Quit struct {
+	Header
+	// Address is the network address that the player was connected from.
+	Address string `json:"address"`
+	// XUID is the XBOX Live user ID of the player. It is empty if the player was not authenticated.
+	XUID string `json:"xuid"`
+}
+
+// Chat is a record of a player sending a chat message.
+type Chat struct {
+	Header
+	// Message is the message sent, after it was changed by handlers. The message of a muted player is recorded
+	// as it was written.
+	Message string `json:"message"`
+	// Muted specifies if the player was muted when sending the message, in which case the message was not sent
+	// to anyone.
+	Muted bool `json:"muted,omitempty"`
+}
+
+// Kick is a record of a player being kicked from the server using Player.Kick.
+type Kick struct {
+	Header
+	// Reason is the reason the player was kicked for, after it was changed by handlers.
+	Reason string `json:"reason"`
+}
+
+// Command is a record of a player executing a command.
+type Command struct {
+	Header
+	// CommandLine is the full command line executed, including the name of the command and its arguments.
+	CommandLine string `json:"command_line"`
+}
+
+// BlockPlace is a record of a player placing a block.
+type BlockPlace struct {
+	Header
+	// Position is the position at which the block was placed.
+	Position cube.Pos `json:"position"`
+	// Block is the name of the block placed, such as "minecraft:stone".
+	Block string `json:"block"`
+}
+
+// BlockBreak is a record of a player breaking a block.
+type BlockBreak struct {
+	Header
+	// Position is the position of the block broken.
+	Position cube.Pos `json:"position"`
+	// Block is the name of the block broken, such as "minecraft:stone".
+	Block string `json:"block"`
+}
+
+// ItemDrop is a record of a player dropping an item.
+type ItemDrop struct {
+	Header
+	// Item is the name of the item dropped, such as "minecraft:diamond".
+	Item string `json:"item"`
+	// Count is the count of the item stack dropped.
+	Count int `json:"count"`
+}
+
+// ItemPickup is a record of a player picking up an item.
+type ItemPickup struct {
+	Header
+	// Item is the name of the item picked up, such as "minecraft:diamond".
+	Item string `json:"item"`
+	// Count is the amount of items actually added to the inventory of the player. It is lower than the count of
+	// the item stack picked up if the inventory could not hold all of it.
+	Count int `json:"count"`
+}
+
+// Death is a record of a player dying.
+type Death struct {
+	Header
+	// Cause is the type of damage source that killed the player, such as "damage.SourceFall".
+	Cause string `json:"cause"`
+	// Killer is the name of the entity that killed the player, either directly or using a projectile. It is
+	// empty if the player was not killed by an entity.
+	Killer string `json:"killer,omitempty"`
+}
+
+// Kind returns "join".
+func (Join) Kind() string { return "join" }
+
+// Kind returns "quit".
+func (Quit) Kind() string { return "quit" }
+
+// Kind returns "chat".
+func (Chat) Kind() string { return "chat" }
+
+// Kind returns "kick".
+func (Kick) Kind() string { return "kick" }
+
+// Kind returns "command".
+func (Command) Kind() string { return "command" }
+
+// Kind returns "block_place".
+func (BlockPlace) Kind() string { return "block_place" }
+
+// Kind returns "block_break".
+func (BlockBreak) Kind() string { return "block_break" }
+
+// Kind returns "item_drop".
+func (ItemDrop) Kind() string { return "item_drop" }
+
+// Kind returns "item_pickup".
+func (ItemPickup) Kind() string { return "item_pickup" }
+
+// Kind returns "death".
+func (Death) Kind() string { return "death" }
@@ -0,0 +1,48 @@
+// Package audit implements an audit trail of the actions of players, such as joining, chatting and breaking
+// blocks. Records of these actions are written to a Sink, which may store them in a file, a database or pass
+// them on over a channel.
+package audit
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Sink is a destination of audit records. Implementations may write records to a file, a database or any other
+// storage. Sinks written to directly are called from the goroutine of the player that performed the action, so
+// a Sink that may block should be wrapped in a Buffer first.
+type Sink interface {
+	// Write writes the record passed to the sink. Records are passed in the order in which the actions they
+	// describe were performed by a player.
+	Write(r Record) error
+	// Close closes the sink. No records are written to the sink after it is closed.
+	Close() error
+}
+
+// Record is a record of a single action performed by a player. It is one of the record types found in this
+// package, such as Join, Chat or BlockBreak.
+type Record interface {
+	// Head returns the Header of the record, which holds the fields common to all records.
+	Head() Header
+	// Kind returns the kind of the record, such as "join" or "block_break", which identifies its type when
+	// encoded.
+	Kind() string
+}
+
+// Header holds the fields common to all records.
+type Header struct {
+	// Time is the time at which the action was performed.
+	Time time.Time `json:"time"`
+	// Player is the name of the player that performed the action.
+	Player string `json:"player"`
+	// UUID is the UUID of the player that performed the action.
+	UUID uuid.UUID `json:"uuid"`
+	// Cancelled specifies if the action was cancelled by a handler. The record of a cancelled action is still
+	// written, but the action did not have any effect.
+	Cancelled bool `json:"cancelled"`
+}
+
+// Head returns the Header itself.
+func (h Header) Head() Header {
+	return h
+}
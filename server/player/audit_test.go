@@ -0,0 +1,74 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/audit"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// recordSink is an audit.Sink that keeps all records written to it.
+type recordSink struct {
+	records []audit.Record
+}
+
+// Write ...
+func (s *recordSink) Write(r audit.Record) error {
+	s.records = append(s.records, r)
+	return nil
+}
+
+// Close ...
+func (s *recordSink) Close() error {
+	return nil
+}
+
+// TestAuditPickupCount checks that the audit record of an item pickup holds the amount of items that actually
+// fit in the inventory of the player.
+func TestAuditPickupCount(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	p := w.NewPlayer("collector", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+	sink := &recordSink{}
+	p.SetAuditSink(sink)
+
+	inv := p.Inventory()
+	for slot := 1; slot < inv.Size(); slot++ {
+		_ = inv.SetItem(slot, item.NewStack(item.Stick{}, 64))
+	}
+	_ = inv.SetItem(0, item.NewStack(item.Diamond{}, 60))
+
+	if n := p.Collect(item.NewStack(item.Diamond{}, 10)); n != 4 {
+		t.Fatalf("collected %v diamonds, want 4", n)
+	}
+	if len(sink.records) != 1 {
+		t.Fatalf("%v audit records written, want 1", len(sink.records))
+	}
+	if r, ok := sink.records[0].(audit.ItemPickup); !ok || r.Count != 4 {
+		t.Errorf("pickup recorded as %#v, want a count of 4", sink.records[0])
+	}
+}
+
+// TestAuditMutedChat checks that chat messages of muted players are audited, even though they are not sent.
+func TestAuditMutedChat(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	p := w.NewPlayer("quiet", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+	sink := &recordSink{}
+	p.SetAuditSink(sink)
+
+	p.SetMuted(true)
+	p.Chat("hello")
+	if len(sink.records) != 1 {
+		t.Fatalf("%v audit records written, want 1", len(sink.records))
+	}
+	r, ok := sink.records[0].(audit.Chat)
+	if !ok || !r.Muted || !r.Cancelled || r.Message != "hello" {
+		t.Errorf("muted chat recorded as %#v", sink.records[0])
+	}
+}
@@ -0,0 +1,200 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/experience"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+	"time"
+)
+
+// breakTimeHandler is a player.Handler that changes the break time of all blocks to a second.
+type breakTimeHandler struct {
+	player.NopHandler
+}
+
+// HandleBreakTime ...
+func (breakTimeHandler) HandleBreakTime(_ *event.Context, _ cube.Pos, d *time.Duration) {
+	*d = time.Second
+}
+
+// TestBreakBedrock checks that survival players can only break bedrock if their handler gives it a break time,
+// and that breaking it does not drop anything.
+func TestBreakBedrock(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	pos := cube.Pos{1, 9, 0}
+	w.SetBlock(pos, block.Bedrock{})
+	p := w.NewPlayer("miner", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+
+	p.BreakBlock(pos)
+	if _, ok := w.Block(pos).(block.Bedrock); !ok {
+		t.Fatalf("bedrock broken without a break time given by the handler")
+	}
+
+	p.Handle(breakTimeHandler{})
+	p.BreakBlock(pos)
+	if _, ok := w.Block(pos).(block.Air); !ok {
+		t.Fatalf("bedrock not broken with a break time given by the handler")
+	}
+	for _, e := range w.Entities() {
+		if it, ok := e.(*entity.Item); ok {
+			t.Errorf("breaking bedrock dropped %v", it.Item())
+		}
+	}
+}
+
+// TestStartBreakingEmptyHand checks that players may start breaking a block with an empty hand in every game
+// mode, which would previously dereference the nil item held when looking up its tags.
+func TestStartBreakingEmptyHand(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	pos := cube.Pos{1, 9, 0}
+	w.SetBlock(pos, block.Stone{})
+	p := w.NewPlayer("miner", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+
+	for _, mode := range []world.GameMode{world.GameModeSurvival, world.GameModeCreative} {
+		p.SetGameMode(mode)
+		p.StartBreaking(pos, cube.FaceUp)
+		p.AbortBreaking()
+	}
+}
+
+// TestBreakFurnaceExperience checks that breaking a furnace pays out the experience of the items that were smelted
+// in it but never taken out to the player that breaks it, with the smelting experience source.
+func TestBreakFurnaceExperience(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	pos := cube.Pos{1, 9, 0}
+	f := block.NewFurnace()
+	_ = f.Inventory().SetItem(0, item.NewStack(item.RawGold{}, 2))
+	_ = f.Inventory().SetItem(1, item.NewStack(item.Coal{}, 1))
+	w.SetBlock(pos, f)
+	// Smelting raw gold takes 200 ticks and stores a single experience point per ingot.
+	w.Advance(400)
+
+	p := w.NewPlayer("miner", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+	p.SetGameMode(world.GameModeCreative)
+	h := &experienceRecorder{}
+	p.Handle(h)
+	p.BreakBlock(pos)
+	if _, ok := w.Block(pos).(block.Air); !ok {
+		t.Fatalf("furnace was not broken")
+	}
+	if xp := p.Experience(); xp != 2 {
+		t.Errorf("player got %v experience for breaking the furnace, want 2", xp)
+	}
+	if len(h.sources) != 1 || h.sources[0] != (experience.SourceSmelting{}) {
+		t.Errorf("experience gained from sources %#v, want a single smelting source", h.sources)
+	}
+}
+
+// breakStepHandler is a player.Handler that makes blocks break instantly, so that finishing to break them passes
+// break validation, and that cancels the start of breaking blocks if cancelStart is true.
+type breakStepHandler struct {
+	player.NopHandler
+	cancelStart bool
+}
+
+// HandleStartBreak ...
+func (h breakStepHandler) HandleStartBreak(ctx *event.Context, _ cube.Pos) {
+	if h.cancelStart {
+		ctx.Cancel()
+	}
+}
+
+// HandleBreakTime ...
+func (breakStepHandler) HandleBreakTime(_ *event.Context, _ cube.Pos, d *time.Duration) {
+	*d = 0
+}
+
+// TestBreakingInterleavings checks that a player only breaks the block passed to FinishBreaking if it is the
+// block it started breaking last without aborting, for different orders of starting, aborting and finishing to
+// break blocks, and that blocks that are finished but not broken are resent to the player.
+func TestBreakingInterleavings(t *testing.T) {
+	a, b, origin := cube.Pos{1, 9, 0}, cube.Pos{4, 9, 0}, cube.Pos{}
+	start := func(pos cube.Pos) func(p *player.Player) {
+		return func(p *player.Player) { p.StartBreaking(pos, cube.FaceUp) }
+	}
+	finish := func(pos cube.Pos) func(p *player.Player) {
+		return func(p *player.Player) { p.FinishBreaking(pos) }
+	}
+	abort := func(p *player.Player) { p.AbortBreaking() }
+
+	for _, c := range []struct {
+		name        string
+		cancelStart bool
+		steps       []func(p *player.Player)
+		// broken holds the positions of the blocks that must be broken after all steps, and resent the positions
+		// of the blocks that must have been resent. The blocks are far enough apart that resending one does not
+		// resend the other.
+		broken, resent []cube.Pos
+	}{
+		{name: "finish", steps: []func(p *player.Player){finish(a)}, resent: []cube.Pos{a}},
+		{name: "finish origin", steps: []func(p *player.Player){finish(origin)}, resent: []cube.Pos{origin}},
+		{name: "start, finish", steps: []func(p *player.Player){start(a), finish(a)}, broken: []cube.Pos{a}},
+		{name: "start, abort, finish", steps: []func(p *player.Player){start(a), abort, finish(a)}, resent: []cube.Pos{a}},
+		{name: "start, abort, start, finish", steps: []func(p *player.Player){start(a), abort, start(a), finish(a)}, broken: []cube.Pos{a}},
+		{name: "start, abort, start other, finish", steps: []func(p *player.Player){start(a), abort, start(b), finish(a)}, resent: []cube.Pos{a}},
+		{name: "start, abort, start other, finish other", steps: []func(p *player.Player){start(a), abort, start(b), finish(b)}, broken: []cube.Pos{b}},
+		{name: "start, start other, finish", steps: []func(p *player.Player){start(a), start(b), finish(a)}, resent: []cube.Pos{a}},
+		{name: "start, finish, finish", steps: []func(p *player.Player){start(a), finish(a), finish(b)}, broken: []cube.Pos{a}, resent: []cube.Pos{b}},
+		{name: "start, finish other, finish", steps: []func(p *player.Player){start(a), finish(b), finish(a)}, resent: []cube.Pos{a, b}},
+		{name: "cancelled start, finish", cancelStart: true, steps: []func(p *player.Player){start(a), finish(a)}, resent: []cube.Pos{a}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			w := servertest.NewWorld()
+			defer w.Close()
+			w.SetBlock(a, block.Stone{})
+			w.SetBlock(b, block.Stone{})
+			p, conn := w.NewSessionPlayer("miner", mgl64.Vec3{0.5, 10, 0.5})
+			p.Handle(breakStepHandler{cancelStart: c.cancelStart})
+
+			for _, step := range c.steps {
+				step(p)
+			}
+			for _, pos := range []cube.Pos{a, b} {
+				_, broken := w.Block(pos).(block.Air)
+				if want := containsPos(c.broken, pos); broken != want {
+					t.Errorf("block at %v broken: %v, want %v", pos, broken, want)
+				}
+			}
+			for _, pos := range []cube.Pos{a, b, origin} {
+				var resent bool
+				for _, pk := range conn.Packets() {
+					if pk, ok := pk.(*packet.UpdateBlock); ok && pk.Position == (protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}) {
+						resent = true
+					}
+				}
+				if want := containsPos(c.resent, pos); resent != want {
+					t.Errorf("block at %v resent: %v, want %v", pos, resent, want)
+				}
+			}
+		})
+	}
+}
+
+// containsPos checks if the positions passed contain the position pos.
+func containsPos(positions []cube.Pos, pos cube.Pos) bool {
+	for _, p := range positions {
+		if p == pos {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package chat
 
 import (
+	"io"
 	"sync"
 )
 
@@ -8,14 +9,40 @@ import (
 // the chat.
 var Global = New()
 
+// Channel represents a channel in the chat that messages may be sent to. Messages sent to a Channel are
+// delivered to its recipients, which may be subscribed explicitly or resolved when the message is sent.
+// Channel implementations embed the io.Writer and io.StringWriter interfaces, so that fmt.Fprintf and fmt.Fprint
+// may be used to write formatted messages to the channel.
+type Channel interface {
+	io.Writer
+	io.StringWriter
+	// Subscribe adds a subscriber to the channel, sending it every message sent to the channel.
+	Subscribe(s Subscriber)
+	// Subscribed checks if a subscriber is currently subscribed to the channel.
+	Subscribed(s Subscriber) bool
+	// Unsubscribe removes a subscriber from the channel.
+	Unsubscribe(s Subscriber)
+	// Recipients returns the subscribers that a message sent by the sender passed is delivered to. The sender
+	// is nil if the message was not sent by a Subscriber, such as messages written using fmt.Fprintf.
+	Recipients(sender Subscriber) []Subscriber
+}
+
+// Send sends a message s from the sender passed to all recipients of the Channel passed. The sender may be nil
+// if the message was not sent by a Subscriber.
+func Send(ch Channel, sender Subscriber, s string) {
+	for _, r := range ch.Recipients(sender) {
+		r.Message(s)
+	}
+}
+
 // Chat represents the in-game chat. Messages may be written to it to send a message to all subscribers. The
 // zero value of Chat is a chat ready to use.
 // Methods on Chat may be called from multiple goroutines concurrently.
-// Chat implements the io.Writer and io.StringWriter interfaces. fmt.Fprintf and fmt.Fprint may be used to write
-// formatted messages to the chat.
+// Chat implements the Channel interface.
 type Chat struct {
 	m           sync.Mutex
 	subscribers map[Subscriber]struct{}
+	filter      func(s Subscriber) bool
 }
 
 // New returns a new chat.
@@ -23,6 +50,13 @@ func New() *Chat {
 	return &Chat{subscribers: map[Subscriber]struct{}{}}
 }
 
+// NewFiltered returns a new chat that only delivers messages to subscribers for which the predicate f returns
+// true. The predicate is called for every subscriber each time a message is sent, so that membership may change
+// without subscribing or unsubscribing.
+func NewFiltered(f func(s Subscriber) bool) *Chat {
+	return &Chat{subscribers: map[Subscriber]struct{}{}, filter: f}
+}
+
 // Write writes the byte slice p as a string to the chat. It is equivalent to calling
 // Chat.WriteString(string(p)).
 func (chat *Chat) Write(p []byte) (n int, err error) {
@@ -31,12 +65,21 @@ func (chat *Chat) Write(p []byte) (n int, err error) {
 
 // WriteString writes a string s to the chat.
 func (chat *Chat) WriteString(s string) (n int, err error) {
+	Send(chat, nil, s)
+	return len(s), nil
+}
+
+// Recipients returns all subscribers of the chat that pass its filter, if it has one.
+func (chat *Chat) Recipients(Subscriber) []Subscriber {
 	chat.m.Lock()
 	defer chat.m.Unlock()
+	recipients := make([]Subscriber, 0, len(chat.subscribers))
 	for subscriber := range chat.subscribers {
-		subscriber.Message(s)
+		if chat.filter == nil || chat.filter(subscriber) {
+			recipients = append(recipients, subscriber)
+		}
 	}
-	return len(s), nil
+	return recipients
 }
 
 // Subscribe adds a subscriber to the chat, sending it every message written to the chat. In order to remove
@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Ranged is a Channel that delivers messages only to subscribing entities within a specific radius of the
+// sender. Recipients are resolved from the entities in the world when a message is sent, so entities do not
+// need to subscribe to the channel and entities that move or change worlds are never out of date.
+// Subscribers added using Subscribe, such as StdoutSubscriber, receive every message regardless of position.
+type Ranged struct {
+	w      *world.World
+	radius float64
+	c      *Chat
+}
+
+// NewRanged returns a new Ranged channel for the world passed. Messages sent to the channel are delivered to
+// all entities implementing Subscriber in the world within the radius passed of the sender.
+func NewRanged(w *world.World, radius float64) *Ranged {
+	return &Ranged{w: w, radius: radius, c: New()}
+}
+
+// Write writes the byte slice p as a string to the channel. It is equivalent to calling
+// Ranged.WriteString(string(p)).
+func (r *Ranged) Write(p []byte) (n int, err error) {
+	return r.WriteString(string(p))
+}
+
+// WriteString writes a string s to the channel. Because it has no sender, the message is delivered to all
+// subscribing entities in the world of the channel.
+func (r *Ranged) WriteString(s string) (n int, err error) {
+	Send(r, nil, s)
+	return len(s), nil
+}
+
+// Recipients returns all explicit subscribers of the channel and all subscribing entities in the world that
+// are within the radius of the channel around the sender. If the sender is not an entity in the world of the
+// channel, all subscribing entities in the world are returned.
+func (r *Ranged) Recipients(sender Subscriber) []Subscriber {
+	recipients := r.c.Recipients(sender)
+
+	e, positioned := sender.(world.Entity)
+	if positioned {
+		if w, ok := world.OfEntity(e); !ok || w != r.w {
+			positioned = false
+		}
+	}
+	for _, other := range r.w.Entities() {
+		s, ok := other.(Subscriber)
+		if !ok || r.c.Subscribed(s) {
+			continue
+		}
+		if positioned && other.Position().Sub(e.Position()).Len() > r.radius {
+			continue
+		}
+		recipients = append(recipients, s)
+	}
+	return recipients
+}
+
+// Subscribe adds a subscriber to the channel, sending it every message written to the channel regardless of
+// its position.
+func (r *Ranged) Subscribe(s Subscriber) {
+	r.c.Subscribe(s)
+}
+
+// Subscribed checks if a subscriber was explicitly subscribed to the channel.
+func (r *Ranged) Subscribed(s Subscriber) bool {
+	return r.c.Subscribed(s)
+}
+
+// Unsubscribe removes a subscriber that was explicitly subscribed to the channel.
+func (r *Ranged) Unsubscribe(s Subscriber) {
+	r.c.Unsubscribe(s)
+}
@@ -0,0 +1,167 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+// leftChest and rightChest are the positions of the two halves of the double chests placed in the tests below.
+var leftChest, rightChest = cube.Pos{0, 5, 0}, cube.Pos{1, 5, 0}
+
+// placeChest makes the player passed place a chest at the position passed by clicking the top of the block
+// below it. Chests placed next to each other by the same player are paired.
+func placeChest(p *player.Player, pos cube.Pos) {
+	p.SetHeldItems(item.NewStack(block.Chest{}, 1), item.Stack{})
+	p.UseItemOnBlock(pos.Side(cube.FaceDown), cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+}
+
+// openChestAt makes the player passed open the chest at the position passed.
+func openChestAt(p *player.Player, pos cube.Pos) {
+	p.SetHeldItems(item.Stack{}, item.Stack{})
+	p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+}
+
+// windowSizes returns the sizes of the contents sent for the windows opened in the packets passed, in the order
+// the windows were opened.
+func windowSizes(packets []packet.Packet) (sizes []int) {
+	opened := map[uint32]bool{}
+	for _, pk := range packets {
+		switch pk := pk.(type) {
+		case *packet.ContainerOpen:
+			opened[uint32(pk.WindowID)] = true
+		case *packet.InventoryContent:
+			if opened[pk.WindowID] {
+				sizes = append(sizes, len(pk.Content))
+				delete(opened, pk.WindowID)
+			}
+		}
+	}
+	return sizes
+}
+
+// TestChestUnpairReopens checks that players viewing a double chest have it reopened as a single chest when one
+// half is broken if they opened the other half, and closed if they opened the broken half.
+func TestChestUnpairReopens(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-2, 4, -2}, cube.Pos{3, 4, 2}, block.Stone{})
+
+	builder := w.NewPlayer("builder", mgl64.Vec3{0.5, 5, 3.5})
+	defer builder.Close()
+	builder.SetGameMode(world.GameModeCreative)
+	placeChest(builder, leftChest)
+	placeChest(builder, rightChest)
+	if ch, ok := w.Block(leftChest).(block.Chest); !ok || !ch.Paired() {
+		t.Fatalf("chests placed next to each other were not paired")
+	}
+
+	left, leftConn := w.NewSessionPlayer("left", mgl64.Vec3{0.5, 5, 3.5})
+	right, rightConn := w.NewSessionPlayer("right", mgl64.Vec3{1.5, 5, 3.5})
+	openChestAt(left, leftChest)
+	openChestAt(right, rightChest)
+	for _, conn := range []*servertest.Conn{leftConn, rightConn} {
+		if sizes := windowSizes(conn.Packets()); len(sizes) != 1 || sizes[0] != 54 {
+			t.Fatalf("windows of sizes %v opened for the double chest, want [54]", sizes)
+		}
+	}
+	leftConn.Reset()
+	rightConn.Reset()
+
+	builder.BreakBlock(rightChest)
+	w.Advance(1)
+	ch, ok := w.Block(leftChest).(block.Chest)
+	if !ok || ch.Paired() {
+		t.Fatalf("chest still paired after the other half was broken")
+	}
+	if _, closed, _ := containerPackets(leftConn.Packets()); len(closed) != 1 {
+		t.Errorf("%v windows closed for the viewer of the remaining half, want 1", len(closed))
+	}
+	if sizes := windowSizes(leftConn.Packets()); len(sizes) != 1 || sizes[0] != 27 {
+		t.Errorf("windows of sizes %v reopened for the viewer of the remaining half, want [27]", sizes)
+	}
+	if _, closed, _ := containerPackets(rightConn.Packets()); len(closed) != 1 {
+		t.Errorf("%v windows closed for the viewer of the broken half, want 1", len(closed))
+	}
+	if opened, _, _ := containerPackets(rightConn.Packets()); len(opened) != 0 {
+		t.Errorf("window reopened for the viewer of the broken half")
+	}
+
+	// Only the viewer of the remaining half is still sent changes to its inventory.
+	leftConn.Reset()
+	rightConn.Reset()
+	_ = ch.Inventory().SetItem(26, item.NewStack(item.Diamond{}, 1))
+	if _, _, updated := containerPackets(leftConn.Packets()); len(updated) != 1 {
+		t.Errorf("%v slot changes sent to the viewer of the remaining half, want 1", len(updated))
+	}
+	if _, _, updated := containerPackets(rightConn.Packets()); len(updated) != 0 {
+		t.Errorf("%v slot changes sent to the viewer of the broken half, want 0", len(updated))
+	}
+}
+
+// TestChestPairReopens checks that players viewing a single chest have it reopened as a double chest when a
+// chest is placed next to it.
+func TestChestPairReopens(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-2, 4, -2}, cube.Pos{3, 4, 2}, block.Stone{})
+
+	builder := w.NewPlayer("builder", mgl64.Vec3{0.5, 5, 3.5})
+	defer builder.Close()
+	builder.SetGameMode(world.GameModeCreative)
+	placeChest(builder, leftChest)
+
+	viewer, conn := w.NewSessionPlayer("viewer", mgl64.Vec3{0.5, 5, 3.5})
+	openChestAt(viewer, leftChest)
+	if sizes := windowSizes(conn.Packets()); len(sizes) != 1 || sizes[0] != 27 {
+		t.Fatalf("windows of sizes %v opened for the single chest, want [27]", sizes)
+	}
+	conn.Reset()
+
+	placeChest(builder, rightChest)
+	if _, closed, _ := containerPackets(conn.Packets()); len(closed) != 1 {
+		t.Errorf("%v windows closed after pairing the chest, want 1", len(closed))
+	}
+	if sizes := windowSizes(conn.Packets()); len(sizes) != 1 || sizes[0] != 54 {
+		t.Errorf("windows of sizes %v reopened after pairing the chest, want [54]", sizes)
+	}
+}
+
+// TestChestPairLeadPersisted checks that the items in a double chest keep their slots after the world is
+// loaded again, regardless of which half is opened first.
+func TestChestPairLeadPersisted(t *testing.T) {
+	for _, first := range []cube.Pos{leftChest, rightChest} {
+		w := servertest.NewWorld()
+		w.Fill(cube.Pos{-2, 4, -2}, cube.Pos{3, 4, 2}, block.Stone{})
+		p := w.NewPlayer("builder", mgl64.Vec3{0.5, 5, 3.5})
+		p.SetGameMode(world.GameModeCreative)
+		// The chest on the right is placed first, so that the chest placed second, which leads the pair, is not
+		// the one with the lowest coordinates.
+		placeChest(p, rightChest)
+		placeChest(p, leftChest)
+		ch := w.Block(leftChest).(block.Chest)
+		_ = ch.Inventory().SetItem(0, item.NewStack(item.Diamond{}, 1))
+		_ = ch.Inventory().SetItem(53, item.NewStack(item.Emerald{}, 1))
+		_ = p.Close()
+		_ = w.Close()
+
+		w = servertest.NewWorldWithProvider(world.Overworld, w.Provider())
+		p = w.NewPlayer("opener", mgl64.Vec3{0.5, 5, 3.5})
+		openChestAt(p, first)
+		inv := w.Block(first).(block.Chest).Inventory()
+		if it, _ := inv.Item(0); it.Count() != 1 || it.Item() != (item.Diamond{}) {
+			t.Errorf("slot 0 holds %v after loading and opening %v first, want a diamond", it, first)
+		}
+		if it, _ := inv.Item(53); it.Count() != 1 || it.Item() != (item.Emerald{}) {
+			t.Errorf("slot 53 holds %v after loading and opening %v first, want an emerald", it, first)
+		}
+		_ = p.Close()
+		_ = w.Close()
+	}
+}
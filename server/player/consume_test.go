@@ -0,0 +1,51 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+	"time"
+)
+
+// instantFood is an item.Consumable that is consumed instantly and may always be consumed.
+type instantFood struct{}
+
+// AlwaysConsumable ...
+func (instantFood) AlwaysConsumable() bool { return true }
+
+// ConsumeDuration ...
+func (instantFood) ConsumeDuration() time.Duration { return 0 }
+
+// Consume ...
+func (instantFood) Consume(*world.World, item.Consumer) item.Stack { return item.Stack{} }
+
+// EncodeItem ...
+func (instantFood) EncodeItem() (name string, meta int16) { return "minecraft:apple", 0 }
+
+// TestContinuousEating checks that a player that keeps eating after consuming an item keeps using the item, so
+// that viewers keep seeing it eat and every following consume signal consumes another item.
+func TestContinuousEating(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	p := w.NewPlayer("eater", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+	p.SetHeldItems(item.NewStack(instantFood{}, 3), item.Stack{})
+
+	// The first use starts eating, every following use signals that an item was consumed.
+	p.UseItem()
+	for i := 1; i <= 2; i++ {
+		p.UseItem()
+		if held, _ := p.HeldItems(); held.Count() != 3-i {
+			t.Fatalf("player holds %v items after %v consume signals, want %v", held.Count(), i, 3-i)
+		}
+		if !p.UsingItem() {
+			t.Fatalf("player stopped using the item after consuming it")
+		}
+	}
+	p.ReleaseItem()
+	if p.UsingItem() {
+		t.Errorf("player still using the item after releasing it")
+	}
+}
@@ -0,0 +1,130 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+const (
+	// crossbowArrowSpeed and crossbowFireworkSpeed are the speeds in blocks per tick with which arrows and
+	// fireworks are shot from a crossbow.
+	crossbowArrowSpeed, crossbowFireworkSpeed = 3.15, 1.6
+	// multishotSpread is the angle in degrees between the projectiles shot from a crossbow with Multishot.
+	multishotSpread = 10.0
+)
+
+// crossbowChargeDuration returns the time that the crossbow stack passed must be used to charge it, taking
+// into account its Quick Charge enchantment.
+func crossbowChargeDuration(s item.Stack) time.Duration {
+	if e, ok := s.Enchantment(enchantment.QuickCharge{}); ok {
+		return (enchantment.QuickCharge{}).ChargeDuration(e.Level())
+	}
+	return item.CrossbowChargeDuration
+}
+
+// crossbowAmmunition looks for ammunition to charge a crossbow with. Like in vanilla, the item in the off-hand
+// is preferred, after which the inventory is searched for arrows. The stack holding the ammunition is returned
+// with a function to consume one item of it.
+func (p *Player) crossbowAmmunition() (item.Stack, func(), bool) {
+	_, left := p.HeldItems()
+	if (item.Crossbow{}).Ammunition(left.Item()) {
+		return left, func() {
+			held, _ := p.HeldItems()
+			p.SetHeldItems(held, left.Grow(-1))
+		}, true
+	}
+	slot, ok := p.inv.FirstFunc(func(s item.Stack) bool {
+		_, arrow := s.Item().(item.Arrow)
+		return arrow
+	})
+	if !ok {
+		if p.GameMode().CreativeInventory() {
+			// Players with a creative inventory may charge crossbows without having any arrows.
+			return item.NewStack(item.Arrow{}, 1), func() {}, true
+		}
+		return item.Stack{}, nil, false
+	}
+	ammo, _ := p.inv.Item(slot)
+	return ammo, func() {
+		_ = p.inv.SetItem(slot, ammo.Grow(-1))
+	}, true
+}
+
+// chargeCrossbow charges the crossbow held by the player with ammunition from its inventory. The projectile is
+// stored in the crossbow item, so that it stays charged when the player switches slots or logs out.
+func (p *Player) chargeCrossbow(held, left item.Stack) {
+	c, ok := held.Item().(item.Crossbow)
+	if !ok || c.Charged() {
+		return
+	}
+	ammo, consume, ok := p.crossbowAmmunition()
+	if !ok {
+		return
+	}
+	c.Item = ammo.Grow(1 - ammo.Count())
+	p.SetHeldItems(held.WithItem(c), left)
+	if !p.GameMode().CreativeInventory() {
+		consume()
+	}
+	p.World().PlaySound(p.Position(), sound.CrossbowLoad{})
+}
+
+// shootCrossbow fires the projectile that the crossbow held by the player is charged with. A crossbow with
+// Multishot fires three projectiles in a spread, and arrows shot from a crossbow with Piercing pass through
+// entities.
+func (p *Player) shootCrossbow(held, left item.Stack) {
+	c, ok := held.Item().(item.Crossbow)
+	if !ok || !c.Charged() {
+		return
+	}
+	yaw, pitch := p.Rotation()
+	angles, durability := []float64{0}, 1
+	if _, ok := held.Enchantment(enchantment.Multishot{}); ok {
+		angles, durability = []float64{0, -multishotSpread, multishotSpread}, 3
+	}
+	pierce := 0
+	if e, ok := held.Enchantment(enchantment.Piercing{}); ok {
+		pierce = e.Level()
+	}
+
+	w, pos := p.World(), entity.EyePosition(p)
+	for _, angle := range angles {
+		dir := directionFromRotation(yaw+angle, pitch)
+		var e world.Entity
+		switch projectile := c.Item.Item().(type) {
+		case item.Firework:
+			e = (&entity.Firework{}).New(pos, dir.Mul(crossbowFireworkSpeed), yaw+angle, pitch, projectile, p, true)
+		default:
+			ammo, _ := projectile.(item.Arrow)
+			arrow := entity.NewTippedArrow(pos, yaw+angle, pitch, p, ammo.Tip)
+			arrow.SetVelocity(dir.Mul(crossbowArrowSpeed))
+			arrow.SetPiercing(pierce)
+			// Arrows shot from a crossbow are always critical. Only the arrow that was charged may be picked up
+			// again: Additional arrows shot by Multishot and arrows shot by creative players may not.
+			arrow.SetCritical(true)
+			if angle != 0 || p.GameMode().CreativeInventory() {
+				arrow.SetPickup(entity.ArrowPickupCreative)
+			}
+			e = arrow
+		}
+		w.AddEntity(e)
+	}
+	w.PlaySound(pos, sound.CrossbowShoot{})
+
+	c.Item = item.Stack{}
+	p.SetHeldItems(p.damageItem(held.WithItem(c), durability), left)
+}
+
+// directionFromRotation returns a vector with a length of 1 that describes the direction of the yaw and pitch
+// passed, like entity.DirectionVector does for an entity.
+func directionFromRotation(yaw, pitch float64) mgl64.Vec3 {
+	yawRad, pitchRad := mgl64.DegToRad(yaw), mgl64.DegToRad(pitch)
+	m := math.Cos(pitchRad)
+	return mgl64.Vec3{-m * math.Sin(yawRad), -math.Sin(pitchRad), m * math.Cos(yawRad)}
+}
@@ -0,0 +1,108 @@
+package player_test
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// customGameMode is a game mode as a plugin might implement it, based on an existing game mode.
+type customGameMode struct {
+	world.GameMode
+}
+
+// TestHurtGameModes checks for every game mode and damage source if a player with that game mode is damaged by
+// the source: Survival and adventure players are damaged by everything, creative players only by the void and
+// spectators by nothing at all.
+func TestHurtGameModes(t *testing.T) {
+	modes := map[string]world.GameMode{
+		"survival":         world.GameModeSurvival,
+		"adventure":        world.GameModeAdventure,
+		"creative":         world.GameModeCreative,
+		"spectator":        world.GameModeSpectator,
+		"custom survival":  customGameMode{GameMode: world.GameModeSurvival},
+		"custom creative":  customGameMode{GameMode: world.GameModeCreative},
+		"custom spectator": customGameMode{GameMode: world.GameModeSpectator},
+	}
+	sources := []damage.Source{
+		damage.SourceEntityAttack{},
+		damage.SourceProjectile{},
+		damage.SourceStarvation{},
+		damage.SourceInstantDamageEffect{},
+		damage.SourceVoid{},
+		damage.SourcePoisonEffect{},
+		damage.SourceWitherEffect{},
+		damage.SourceFire{},
+		damage.SourceFireTick{},
+		damage.SourceLava{},
+		damage.SourceHotFloor{},
+		damage.SourceFall{},
+		damage.SourceLightning{},
+		damage.SourceFirework{},
+		damage.SourceBlockExplosion{},
+		damage.SourceCustom{},
+	}
+
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	for name, mode := range modes {
+		for _, src := range sources {
+			_, void := src.(damage.SourceVoid)
+			want := mode.AllowsTakingDamage() || (void && mode.HasCollision())
+
+			t.Run(fmt.Sprintf("%v/%T", name, src), func(t *testing.T) {
+				// A new player is used for every case, so that attack immunity of an earlier case does not
+				// influence the result.
+				p := w.NewPlayer("hurt", mgl64.Vec3{0.5, 10, 0.5})
+				defer p.Close()
+				p.SetGameMode(mode)
+
+				health := p.Health()
+				if _, vulnerable := p.Hurt(1, src); vulnerable != want {
+					t.Errorf("vulnerable = %v, want %v", vulnerable, want)
+				}
+				if damaged := p.Health() < health; damaged != want {
+					t.Errorf("damaged = %v, want %v", damaged, want)
+				}
+			})
+		}
+	}
+}
+
+// TestZeroAttackImmunity checks that a player attacked in two consecutive ticks is only damaged by the second
+// attack if its handler sets the attack immunity after the first attack to 0.
+func TestZeroAttackImmunity(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+
+	attacker := w.NewPlayer("attacker", mgl64.Vec3{0.5, 1, 0.5})
+	defer attacker.Close()
+	for _, zero := range []bool{false, true} {
+		victim := w.NewPlayer("victim", mgl64.Vec3{2.5, 1, 0.5})
+		if zero {
+			victim.Handle(immunityHandler{immunity: 0})
+		}
+		w.Advance(1)
+
+		health := victim.Health()
+		attacker.AttackEntity(victim)
+		if victim.Health() >= health {
+			t.Fatalf("zero immunity %v: first attack did not damage the victim", zero)
+		}
+		w.Advance(1)
+
+		health = victim.Health()
+		attacker.AttackEntity(victim)
+		if damaged := victim.Health() < health; damaged != zero {
+			t.Errorf("zero immunity %v: second attack in the next tick damaged the victim: %v, want %v", zero, damaged, zero)
+		}
+		_ = victim.Close()
+	}
+}
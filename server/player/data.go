@@ -31,12 +31,10 @@ type Data struct {
 	// ExhaustionLevel determines how fast the hunger level depletes and is controlled by the kinds
 	// of food the player has eaten. SaturationLevel determines how fast the saturation level depletes.
 	ExhaustionLevel, SaturationLevel float64
-	// XPLevel is the current xp level the player has, XPTotal is the total amount of xp the
-	// player has collected during their lifetime, which is used to display score upon player death.
-	// These are currently not implemented in DF.
+	// XPLevel is the current xp level the player has, XPTotal is the total amount of xp points the
+	// player currently has. The experience of the player is loaded from XPLevel and XPPercentage.
 	XPLevel, XPTotal int
-	// XPPercentage is the player's current progress towards the next level.
-	// This is currently not implemented in DF.
+	// XPPercentage is the player's current progress towards the next level, between 0 and 1.
 	XPPercentage float64
 	// XPSeed is the random seed used to determine the next enchantment in enchantment tables.
 	// This is currently not implemented in DF.
@@ -52,6 +50,11 @@ type Data struct {
 	// FallDistance is the distance the player has currently been falling.
 	// This is used to calculate fall damage.
 	FallDistance float64
+	// FlightSpeed is the speed at which the player flies in blocks/tick, as set using Player.SetFlightSpeed.
+	FlightSpeed float64
+	// Statistics holds the values of the statistics of the player, indexed by the names of the stats, as
+	// returned by stat.Tracker.Values.
+	Statistics map[string]int64
 	// Dimension is the ID of the dimension that the player was last in. The player is added to the correct world based
 	// on this number.
 	Dimension int
@@ -72,4 +75,6 @@ type InventoryData struct {
 	// MainHandSlot saves the slot in the hotbar that the player is currently switched to.
 	// Should be between 0-8.
 	MainHandSlot uint32
+	// EnderChestItems contains all the items in the player's ender chest inventory.
+	EnderChestItems []item.Stack
 }
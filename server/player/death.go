@@ -0,0 +1,33 @@
+package player
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// DeathOptions holds options of the death of a player that may be changed by a Handler in HandleDeath.
+type DeathOptions struct {
+	// Message is a message shown to the dying player. If empty, no message is shown. The protocol version
+	// currently supported has no way to show a message on the death screen itself, so it is sent as a chat
+	// message to the player.
+	Message string
+	// RespawnPosition is a position that the player respawns at the next time it respawns, instead of its spawn
+	// position or the spawn of the world. It is used only once and is kept if the player disconnects before
+	// respawning. If nil, the player respawns as usual.
+	RespawnPosition *mgl64.Vec3
+}
+
+// respawnOverride returns the respawn position set by a Handler in HandleDeath, if any. The override is not
+// consumed.
+func (p *Player) respawnOverride() (mgl64.Vec3, bool) {
+	pos, _ := p.deathRespawn.Load().(*mgl64.Vec3)
+	if pos == nil {
+		return mgl64.Vec3{}, false
+	}
+	return *pos, true
+}
+
+// setRespawnOverride sets the respawn position to be used the next time the player respawns. Passing nil clears
+// the override.
+func (p *Player) setRespawnOverride(pos *mgl64.Vec3) {
+	p.deathRespawn.Store(pos)
+}
@@ -0,0 +1,125 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+	"testing"
+	"time"
+)
+
+// deathHandler is a player.Handler that records the data of the player when it quits, like a server saving the
+// data of a player that disconnects. If closeOnDeath is true, it closes the player while its death is handled.
+type deathHandler struct {
+	player.NopHandler
+	p            *player.Player
+	closeOnDeath bool
+
+	mu    sync.Mutex
+	quits int
+	data  player.Data
+}
+
+// HandleDeath ...
+func (h *deathHandler) HandleDeath(damage.Source, *player.DeathOptions) {
+	if h.closeOnDeath {
+		_ = h.p.Close()
+	}
+}
+
+// HandleQuit ...
+func (h *deathHandler) HandleQuit() {
+	data := h.p.Data()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.quits++
+	h.data = data
+}
+
+// TestCloseDuringDeath checks that a player that dies may be closed at every point of its death: While the death
+// is handled, during the death animation, at the same time as the death is finished and after the player was
+// removed. The player must quit and be removed from the world exactly once, its items must be dropped exactly
+// once and the data saved when it quits must not hold the items it dropped.
+func TestCloseDuringDeath(t *testing.T) {
+	points := map[string]func(p *player.Player){
+		"during HandleDeath": kill,
+		"during death animation": func(p *player.Player) {
+			kill(p)
+			_ = p.Close()
+		},
+		"while finishing death": func(p *player.Player) {
+			kill(p)
+			var wg sync.WaitGroup
+			for _, d := range []time.Duration{1090, 1095, 1100, 1105, 1110} {
+				wg.Add(1)
+				time.AfterFunc(d*time.Millisecond, func() {
+					defer wg.Done()
+					_ = p.Close()
+				})
+			}
+			wg.Wait()
+		},
+		"after death finished": func(p *player.Player) {
+			kill(p)
+			time.Sleep(time.Millisecond * 1300)
+			_ = p.Close()
+		},
+	}
+	for name, f := range points {
+		name, f := name, f
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			w := servertest.NewWorld()
+			defer w.Close()
+
+			p := w.NewPlayer("dying", mgl64.Vec3{0.5, 10, 0.5})
+			_ = p.Inventory().SetItem(0, item.NewStack(item.Diamond{}, 5))
+			h := &deathHandler{p: p, closeOnDeath: name == "during HandleDeath"}
+			p.Handle(h)
+
+			f(p)
+			// Wait until a death timer that might still be pending would have fired.
+			time.Sleep(time.Millisecond * 1300)
+
+			diamonds := 0
+			for _, e := range w.Entities() {
+				switch e := e.(type) {
+				case *player.Player:
+					t.Errorf("player still in world after being closed")
+				case *entity.Item:
+					diamonds += e.Item().Count()
+				}
+			}
+			if diamonds != 5 {
+				t.Errorf("%v diamonds dropped, want 5", diamonds)
+			}
+			if p.World() != nil {
+				t.Errorf("closed player still has a world")
+			}
+
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if h.quits != 1 {
+				t.Fatalf("player quit %v times, want 1", h.quits)
+			}
+			// A player that quits while dead is saved as if it respawned.
+			if h.data.Health != p.MaxHealth() {
+				t.Errorf("saved health is %v, want %v", h.data.Health, p.MaxHealth())
+			}
+			for _, it := range h.data.Inventory.Items {
+				if !it.Empty() {
+					t.Errorf("saved data holds %v after dropping all items", it)
+				}
+			}
+		})
+	}
+}
+
+// kill kills the player passed.
+func kill(p *player.Player) {
+	p.Hurt(p.MaxHealth()*10, damage.SourceVoid{})
+}
@@ -0,0 +1,65 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// DropOptions holds options that specify how item entities are thrown by a player, either by dropping an item
+// using Player.DropWithOptions or when the player dies.
+type DropOptions struct {
+	// Speed is the speed with which the item entity is thrown in the direction that the player is looking.
+	Speed float64
+	// Lift is the upward velocity added to the item entity when it is thrown.
+	Lift float64
+	// Spread is the maximum horizontal velocity in a random direction added to the item entity when it is
+	// thrown.
+	Spread float64
+	// PickupDelay is the delay before the item entity may be picked up. A negative delay means the item entity may
+	// never be picked up.
+	PickupDelay time.Duration
+	// DespawnDelay is the time after which the item entity despawns. If 0, the default despawn delay of 5 minutes
+	// is used. A negative delay means the item entity never despawns.
+	DespawnDelay time.Duration
+	// GravityFactor is the factor that the default gravity of the item entity is multiplied with, as passed to
+	// entity.Item.SetGravityFactor. A factor of 1 keeps the default gravity, while a factor of 0 makes the item
+	// entity float.
+	GravityFactor float64
+	// OwnerOnly specifies if only the player that threw the item entity may pick it up.
+	OwnerOnly bool
+}
+
+// DefaultDropOptions returns the DropOptions used by Player.Drop: The item is thrown in the direction the player
+// is looking and may be picked up after 2 seconds.
+func DefaultDropOptions() DropOptions {
+	return DropOptions{Speed: 0.4, PickupDelay: time.Second * 2, GravityFactor: 1}
+}
+
+// DefaultDeathDropOptions returns the DropOptions used for the items dropped when a player dies: The items are
+// scattered around the player and may be picked up after half a second.
+func DefaultDeathDropOptions() DropOptions {
+	return DropOptions{Lift: 0.2, Spread: 0.1, PickupDelay: time.Second / 2, GravityFactor: 1}
+}
+
+// throw creates an item entity holding the item stack passed at the position passed, configured using the
+// DropOptions passed. The item entity is not added to the world.
+func (p *Player) throw(s item.Stack, pos mgl64.Vec3, opts DropOptions) *entity.Item {
+	e := entity.NewItem(s, pos)
+	vel := entity.DirectionVector(p).Mul(opts.Speed).Add(mgl64.Vec3{0, opts.Lift})
+	if opts.Spread != 0 {
+		vel = vel.Add(mgl64.Vec3{(rand.Float64()*2 - 1) * opts.Spread, 0, (rand.Float64()*2 - 1) * opts.Spread})
+	}
+	e.SetVelocity(vel)
+	e.SetPickupDelay(opts.PickupDelay)
+	if opts.DespawnDelay != 0 {
+		e.SetDespawnDelay(opts.DespawnDelay)
+	}
+	e.SetGravityFactor(opts.GravityFactor)
+	if opts.OwnerOnly {
+		e.SetOwner(p)
+	}
+	return e
+}
@@ -0,0 +1,69 @@
+package player
+
+import (
+	"math"
+	"sync"
+)
+
+// experienceManager handles the experience of a player. It holds the total amount of experience points, from
+// which the experience level and the progress towards the next level are derived.
+type experienceManager struct {
+	mu         sync.RWMutex
+	experience int
+}
+
+// newExperienceManager returns a new experience manager without any experience.
+func newExperienceManager() *experienceManager {
+	return &experienceManager{}
+}
+
+// Experience returns the total amount of experience points held.
+func (m *experienceManager) Experience() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.experience
+}
+
+// Add adds the amount of experience points passed and returns the new total. The total never drops below 0
+// and never exceeds math.MaxInt32.
+func (m *experienceManager) Add(amount int) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.experience = int(math.Max(0, math.Min(float64(m.experience)+float64(amount), math.MaxInt32)))
+	return m.experience
+}
+
+// Level returns the experience level and the progress towards the next level, between 0 and 1, of the
+// experience held.
+func (m *experienceManager) Level() (level int, progress float64) {
+	m.mu.RLock()
+	xp := m.experience
+	m.mu.RUnlock()
+
+	for xp >= experienceForLevel(level) {
+		xp -= experienceForLevel(level)
+		level++
+	}
+	return level, float64(xp) / float64(experienceForLevel(level))
+}
+
+// experienceForLevel returns the amount of experience points needed to go from the level passed to the next.
+func experienceForLevel(level int) int {
+	switch {
+	case level >= 30:
+		return 9*level - 158
+	case level >= 15:
+		return 5*level - 38
+	}
+	return 2*level + 7
+}
+
+// experienceForProgress returns the total amount of experience points held at the level and progress towards
+// the next level passed.
+func experienceForProgress(level int, progress float64) int {
+	xp := 0
+	for l := 0; l < level; l++ {
+		xp += experienceForLevel(l)
+	}
+	return xp + int(math.Round(progress*float64(experienceForLevel(level))))
+}
@@ -0,0 +1,55 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/experience"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"testing"
+)
+
+// experienceRecorder is a player.Handler that records the experience.Source of all experience gained by a player.
+type experienceRecorder struct {
+	player.NopHandler
+	sources []experience.Source
+}
+
+// HandleExperienceGain ...
+func (h *experienceRecorder) HandleExperienceGain(_ *event.Context, _ *int, src experience.Source) {
+	h.sources = append(h.sources, src)
+}
+
+// TestTradeExperience checks that executing a trade rewards the experience of the trade to the player with the
+// trading experience source, and that the experience is saved in the data of the player.
+func TestTradeExperience(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	p := w.NewPlayer("trader", mgl64.Vec3{0.5, 10, 0.5})
+	defer p.Close()
+	h := &experienceRecorder{}
+	p.Handle(h)
+
+	if !p.TradeWith(nil, trade.Trade{Experience: 20}) {
+		t.Fatalf("trade was cancelled")
+	}
+	if xp := p.Experience(); xp != 20 {
+		t.Fatalf("player has %v experience after trading, want 20", xp)
+	}
+	if len(h.sources) != 1 || h.sources[0] != (experience.SourceTrading{}) {
+		t.Errorf("experience gained from sources %#v, want a single trading source", h.sources)
+	}
+	// Level 0 takes 7 points and level 1 takes 9 points, leaving 4 of the 11 points needed for level 3.
+	level, progress := p.ExperienceLevel()
+	if level != 2 || math.Abs(progress-4.0/11) > 1e-9 {
+		t.Errorf("experience level is %v with progress %v, want 2 with progress %v", level, progress, 4.0/11)
+	}
+
+	data := p.Data()
+	if data.XPLevel != 2 || data.XPTotal != 20 {
+		t.Errorf("data holds level %v and total %v, want 2 and 20", data.XPLevel, data.XPTotal)
+	}
+}
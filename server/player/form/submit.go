@@ -32,7 +32,8 @@ type ModalSubmittable MenuSubmittable
 
 // Closer represents a form which has special logic when being closed by a Submitter.
 type Closer interface {
-	// Close is called when the Submitter closes a form.
+	// Close is called when the Submitter closes a form. It is also called for every form still open when the
+	// Submitter disconnects, before the Submitter is closed.
 	Close(submitter Submitter)
 }
 
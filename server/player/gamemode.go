@@ -0,0 +1,46 @@
+package player
+
+// CreativeItemPolicy decides what happens to the items of a player when its game mode changes from a game mode
+// with a creative inventory, such as creative mode, to one without.
+type CreativeItemPolicy uint32
+
+const (
+	// CreativeItemsKept keeps the items of the player when it leaves creative mode. This is the default policy,
+	// matching vanilla.
+	CreativeItemsKept CreativeItemPolicy = iota
+	// CreativeItemsCleared clears the inventory, armour and off-hand of the player when it leaves creative
+	// mode, so that items taken from the creative inventory cannot be used in other game modes.
+	CreativeItemsCleared
+)
+
+// SetCreativeItemPolicy sets the CreativeItemPolicy that is applied when the game mode of the player changes
+// from one with a creative inventory to one without. By default, CreativeItemsKept is used.
+func (p *Player) SetCreativeItemPolicy(policy CreativeItemPolicy) {
+	p.creativeItemPolicy.Store(uint32(policy))
+}
+
+// CreativeItemPolicy returns the CreativeItemPolicy of the player, as set using SetCreativeItemPolicy.
+func (p *Player) CreativeItemPolicy() CreativeItemPolicy {
+	return CreativeItemPolicy(p.creativeItemPolicy.Load())
+}
+
+// SetProvider sets the Provider that the Data of the player is saved to immediately when it changes in a way
+// that should not be lost if the server stops unexpectedly, such as when its game mode changes. Passing nil stops
+// the player from saving its data by itself. Servers set their player Provider on every player that joins.
+func (p *Player) SetProvider(prov Provider) {
+	p.provMu.Lock()
+	defer p.provMu.Unlock()
+	p.prov = prov
+}
+
+// save saves the Data of the player to the Provider set using SetProvider, if any. Errors are ignored: The data
+// is saved again when the player quits, at which point the server reports any error.
+func (p *Player) save() {
+	p.provMu.RLock()
+	prov := p.prov
+	p.provMu.RUnlock()
+	if prov == nil || p.World() == nil {
+		return
+	}
+	_ = prov.Save(p.UUID(), p.Data())
+}
@@ -0,0 +1,81 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/action"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+// containerPackets returns the window IDs of the containers opened and closed in the packets passed, and the
+// window IDs of the inventory slots updated.
+func containerPackets(packets []packet.Packet) (opened, closed, updated []byte) {
+	for _, pk := range packets {
+		switch pk := pk.(type) {
+		case *packet.ContainerOpen:
+			opened = append(opened, pk.WindowID)
+		case *packet.ContainerClose:
+			closed = append(closed, pk.WindowID)
+		case *packet.InventorySlot:
+			updated = append(updated, byte(pk.WindowID))
+		}
+	}
+	return
+}
+
+// TestSpectatorClosesChest checks that a player viewing a chest has it closed when switching to spectator mode,
+// so that the chest is shown closed and the player no longer receives or changes its content.
+func TestSpectatorClosesChest(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	chestPos := cube.Pos{0, 5, 0}
+	chest := block.NewChest()
+	w.SetBlock(chestPos, chest)
+	p, conn := w.NewSessionPlayer("viewer", mgl64.Vec3{0.5, 6, 2.5})
+
+	p.UseItemOnBlock(chestPos, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+	opened, _, _ := containerPackets(conn.Packets())
+	if len(opened) != 1 {
+		t.Fatalf("%v containers opened after clicking a chest, want 1", len(opened))
+	}
+	window := opened[0]
+
+	w.Viewer().Reset()
+	conn.Reset()
+	p.SetGameMode(world.GameModeSpectator)
+
+	_, closed, _ := containerPackets(conn.Packets())
+	if len(closed) != 1 || closed[0] != window {
+		t.Errorf("windows %v closed after switching to spectator mode, want %v", closed, window)
+	}
+	var closeActions int
+	for _, c := range w.Viewer().CallsTo("ViewBlockAction") {
+		if _, ok := c.Args[1].(action.Close); ok && c.Args[0] == chestPos {
+			closeActions++
+		}
+	}
+	if closeActions != 1 {
+		t.Errorf("chest shown closing %v times after switching to spectator mode, want 1", closeActions)
+	}
+
+	// The player is no longer a viewer of the chest, so changes to its content are not sent to it anymore.
+	conn.Reset()
+	_ = chest.Inventory().SetItem(0, item.NewStack(item.Diamond{}, 1))
+	_, _, updated := containerPackets(conn.Packets())
+	for _, id := range updated {
+		if id == window {
+			t.Errorf("chest slot change sent to player after it switched to spectator mode")
+		}
+	}
+	// Spectators cannot interact with blocks, so clicking the chest again does not open it.
+	p.UseItemOnBlock(chestPos, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+	if opened, _, _ := containerPackets(conn.Packets()); len(opened) != 0 {
+		t.Errorf("spectator opened the chest")
+	}
+}
@@ -5,13 +5,19 @@ import (
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/experience"
 	"github.com/df-mc/dragonfly/server/entity/healing"
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"net"
+	"time"
 )
 
 // Handler handles events that are called by a player. Implementations of Handler may be used to listen to
@@ -20,8 +26,17 @@ type Handler interface {
 	// HandleMove handles the movement of a player. ctx.Cancel() may be called to cancel the movement event.
 	// The new position, yaw and pitch are passed.
 	HandleMove(ctx *event.Context, newPos mgl64.Vec3, newYaw, newPitch float64)
+	// HandleMovementCorrection handles the correction of the movement of a player by server-side movement
+	// validation, which is enabled using Player.SetMovementValidation. The position the player tried to move to
+	// and the position it is teleported back to are passed. ctx.Cancel() may be called to cancel the
+	// correction and allow the movement.
+	HandleMovementCorrection(ctx *event.Context, requested, corrected mgl64.Vec3)
 	// HandleTeleport handles the teleportation of a player. ctx.Cancel() may be called to cancel it.
 	HandleTeleport(ctx *event.Context, pos mgl64.Vec3)
+	// HandleJump handles the player jumping. It is called once every time the player starts pressing the jump
+	// button while on the ground, or, if no input is reported, leaves the ground by jumping. It is not called
+	// when the player moves upwards by being knocked back, swimming or being levitated.
+	HandleJump()
 	// HandleToggleSprint handles when the player starts or stops sprinting.
 	// After is true if the player is sprinting after toggling (changing their sprinting state).
 	HandleToggleSprint(ctx *event.Context, after bool)
@@ -30,37 +45,76 @@ type Handler interface {
 	HandleToggleSneak(ctx *event.Context, after bool)
 	// HandleChat handles a message sent in the chat by a player. ctx.Cancel() may be called to cancel the
 	// message being sent in chat.
-	// The message may be changed by assigning to *message.
-	HandleChat(ctx *event.Context, message *string)
+	// The message may be changed by assigning to *message. The chat.Channel that the message is sent to may be
+	// changed by assigning to *channel, for example to a channel that filters its recipients.
+	HandleChat(ctx *event.Context, message *string, channel *chat.Channel)
 	// HandleFoodLoss handles the food bar of a player depleting naturally, for example because the player was
 	// sprinting and jumping. ctx.Cancel() may be called to cancel the food points being lost.
 	HandleFoodLoss(ctx *event.Context, from, to int)
+	// HandleExperienceGain handles the player gaining experience from the experience.Source passed, for example
+	// by executing a trade. The amount of experience gained may be changed by assigning to *amount. ctx.Cancel()
+	// may be called to cancel the experience being gained.
+	HandleExperienceGain(ctx *event.Context, amount *int, src experience.Source)
 	// HandleHeal handles the player being healed by a healing source. ctx.Cancel() may be called to cancel
 	// the healing.
 	// The health added may be changed by assigning to *health.
 	HandleHeal(ctx *event.Context, health *float64, src healing.Source)
 	// HandleHurt handles the player being hurt by any damage source. ctx.Cancel() may be called to cancel the
 	// damage being dealt to the player.
-	// The damage dealt to the player may be changed by assigning to *damage.
-	HandleHurt(ctx *event.Context, damage *float64, src damage.Source)
-	// HandleDeath handles the player dying to a particular damage cause.
-	HandleDeath(src damage.Source)
+	// The damage dealt to the player may be changed by assigning to *damage. The duration that the player is
+	// immune to further attacks after being hurt is 500ms by default and may be changed by assigning to
+	// *attackImmunity. Setting it to 0 allows the player to be hurt again right away.
+	HandleHurt(ctx *event.Context, damage *float64, attackImmunity *time.Duration, src damage.Source)
+	// HandleDeath handles the player dying to a particular damage cause. The DeathOptions passed may be changed
+	// to show a message to the player or to make it respawn at a specific position once.
+	HandleDeath(src damage.Source, opts *DeathOptions)
 	// HandleRespawn handles the respawning of the player in the world. The spawn position passed may be
 	// changed by assigning to *pos.
 	HandleRespawn(pos *mgl64.Vec3)
+	// HandleSleep handles the player going to sleep in the bed with its head at the position passed.
+	// ctx.Cancel() may be called to keep the player from sleeping.
+	HandleSleep(ctx *event.Context, pos cube.Pos)
+	// HandleWake handles the player waking up from sleeping in the bed with its head at the position passed.
+	HandleWake(pos cube.Pos)
+	// HandleEffectAdd handles an effect being added to the player. ctx.Cancel() may be called to prevent the
+	// effect from being added.
+	HandleEffectAdd(ctx *event.Context, e effect.Effect)
+	// HandleEffectExpire handles an effect of the player wearing off, for example because its duration ran out.
+	// HandleEffectRemove is called with effect.CauseExpired right after.
+	HandleEffectExpire(e effect.Effect)
+	// HandleEffectRemove handles an effect being removed from the player. The cause passed specifies why the
+	// effect was removed, such as effect.CauseMilk or effect.CauseDeath.
+	HandleEffectRemove(e effect.Effect, cause effect.RemovalCause)
 	// HandleSkinChange handles the player changing their skin. ctx.Cancel() may be called to cancel the skin
 	// change.
 	HandleSkinChange(ctx *event.Context, skin skin.Skin)
+	// HandleGameModeChange handles the game mode of the player being changed from before to after, for example
+	// using the /gamemode command. ctx.Cancel() may be called to keep the current game mode.
+	HandleGameModeChange(ctx *event.Context, before, after world.GameMode)
 	// HandleStartBreak handles the player starting to break a block at the position passed. ctx.Cancel() may
 	// be called to stop the player from breaking the block completely.
 	HandleStartBreak(ctx *event.Context, pos cube.Pos)
+	// HandleBreakTime handles the time it takes the player to break the block at the position passed being
+	// calculated. duration is the time it takes to break the block, taking into account the item held and effects
+	// such as haste, and may be changed to change the break time. Blocks that cannot normally be broken, such as
+	// bedrock, have a duration of math.MaxInt64: Setting a lower duration makes them breakable for the player.
+	// ctx.Cancel() may be called to make the block unbreakable for the player.
+	HandleBreakTime(ctx *event.Context, pos cube.Pos, duration *time.Duration)
+	// HandleBlockBreakProgress handles the progress of the player breaking a block at the position passed. It is
+	// called when the player starts breaking the block and every time it continues breaking it. progress is the
+	// fraction of the block broken so far, and breakTime is the time it takes to break the block from start to
+	// finish, taking into account the item held and effects such as haste. breakTime may be changed to speed up
+	// or slow down the breaking of the block. The progress made so far is kept when it is changed.
+	HandleBlockBreakProgress(pos cube.Pos, progress float64, breakTime *time.Duration)
 	// HandleBlockBreak handles a block that is being broken by a player. ctx.Cancel() may be called to cancel
 	// the block being broken. A pointer to a slice of the block's drops is passed, and may be altered
 	// to change what items will actually be dropped.
 	HandleBlockBreak(ctx *event.Context, pos cube.Pos, drops *[]item.Stack)
 	// HandleBlockPlace handles the player placing a specific block at a position in its world. ctx.Cancel()
-	// may be called to cancel the block being placed.
-	HandleBlockPlace(ctx *event.Context, pos cube.Pos, b world.Block)
+	// may be called to cancel the block being placed. HandleBlockPlace is only called once the placement is
+	// otherwise valid. useCtx.Obstructed is true if an entity is in the way, in which case useCtx.IgnoreAABB
+	// may be set to true to allow the block to be placed anyway.
+	HandleBlockPlace(ctx *event.Context, pos cube.Pos, b world.Block, useCtx *item.UseContext)
 	// HandleBlockPick handles the player picking a specific block at a position in its world. ctx.Cancel()
 	// may be called to cancel the block being picked.
 	HandleBlockPick(ctx *event.Context, pos cube.Pos, b world.Block)
@@ -68,6 +122,10 @@ type Handler interface {
 	// will not actually do anything. Items such as snowballs may be thrown if HandleItemUse does not cancel
 	// the context using ctx.Cancel(). It is not called if the player is holding no item.
 	HandleItemUse(ctx *event.Context)
+	// HandleItemConsume handles the player consuming an item, such as eating food or drinking a potion. It is
+	// called once the item has been used for long enough to be consumed, before the item is actually consumed.
+	// ctx.Cancel() may be called to prevent the item from being consumed.
+	HandleItemConsume(ctx *event.Context, item item.Stack)
 	// HandleItemUseOnBlock handles the player using the item held in its main hand on a block at the block
 	// position passed. The face of the block clicked is also passed, along with the relative click position.
 	// The click position has X, Y and Z values which are all in the range 0.0-1.0. It is also called if the
@@ -96,6 +154,16 @@ type Handler interface {
 	// HandleSignEdit handles the player editing a sign. It is called for every keystroke while editing a sign and
 	// has both the old text passed and the text after the edit. This typically only has a change of one character.
 	HandleSignEdit(ctx *event.Context, oldText, newText string)
+	// HandleGrindstoneUse handles the player taking the result out of a grindstone at the position passed. The
+	// result may be changed. experience is the amount of experience refunded for the enchantments removed from
+	// the items put in the grindstone. Cancelling the event leaves the items in the grindstone.
+	HandleGrindstoneUse(ctx *event.Context, pos cube.Pos, result *item.Stack, experience int)
+	// HandleSmithing handles the player upgrading the input item passed using the material passed in a smithing
+	// table. The result may be changed. Cancelling the event leaves the items in the smithing table.
+	HandleSmithing(ctx *event.Context, input, material item.Stack, result *item.Stack)
+	// HandleTrade handles the player executing a trade offered by the trade.Trader passed. The trade has already
+	// been validated against the inventory of the player. Cancelling the event leaves the items in the trading UI.
+	HandleTrade(ctx *event.Context, t trade.Trader, tr trade.Trade)
 	// HandleItemDamage handles the event wherein the item either held by the player or as armour takes
 	// damage through usage.
 	// The type of the item may be checked to determine whether it was armour or a tool used. The damage to
@@ -106,7 +174,9 @@ type Handler interface {
 	HandleItemPickup(ctx *event.Context, i item.Stack)
 	// HandleItemDrop handles the player dropping an item on the ground. The dropped item entity is passed.
 	// ctx.Cancel() may be called to prevent the player from dropping the entity.Item passed on the ground.
-	// e.Item() may be called to obtain the item stack dropped.
+	// e.Item() may be called to obtain the item stack dropped. The entity is already configured using the
+	// DropOptions of the drop, but is only added to the world after the handler returns, so its velocity, pickup
+	// delay and other properties may still be changed.
 	HandleItemDrop(ctx *event.Context, e *entity.Item)
 	// HandleMount handles when a player mounts an entity. ctx.Cancel() may be called to cancel the player mounting
 	// an entity.
@@ -120,8 +190,22 @@ type Handler interface {
 	// HandleCommandExecution handles the command execution of a player, who wrote a command in the chat.
 	// ctx.Cancel() may be called to cancel the command execution.
 	HandleCommandExecution(ctx *event.Context, command cmd.Command, args []string)
+	// HandleSpamViolation handles the player exceeding a session.RateLimit that escalates violations, such as
+	// the limit on chat messages. The action that exceeded the limit is dropped, but handlers may decide to warn
+	// or kick the player. The current usage of the limit is passed.
+	HandleSpamViolation(action session.RateLimitedAction, usage session.RateUsage)
+	// HandleClientSettingsChange handles the client of a player changing its settings while connected, such as
+	// its render distance or input mode. The settings before and after the change are passed.
+	HandleClientSettingsChange(before, after session.ClientSettings)
+	// HandleInputModeChange handles the client of a player switching its input mode while connected, for example
+	// from a mouse and keyboard to a controller. It is called before HandleClientSettingsChange.
+	HandleInputModeChange(before, after session.InputMode)
+	// HandleKick handles the player being kicked from the server using Player.Kick. ctx.Cancel() may be called
+	// to keep the player connected. The reason of the kick may be changed by assigning to *reason.
+	HandleKick(ctx *event.Context, reason *string)
 	// HandleQuit handles the closing of a player. It is always called when the player is disconnected,
-	// regardless of the reason.
+	// regardless of the reason. Forms and containers that were still open when the player disconnected are
+	// closed before HandleQuit is called.
 	HandleQuit()
 }
 
@@ -139,9 +223,15 @@ func (NopHandler) HandleItemDrop(*event.Context, *entity.Item) {}
 // HandleMove ...
 func (NopHandler) HandleMove(*event.Context, mgl64.Vec3, float64, float64) {}
 
+// HandleMovementCorrection ...
+func (NopHandler) HandleMovementCorrection(*event.Context, mgl64.Vec3, mgl64.Vec3) {}
+
 // HandleTeleport ...
 func (NopHandler) HandleTeleport(*event.Context, mgl64.Vec3) {}
 
+// HandleJump ...
+func (NopHandler) HandleJump() {}
+
 // HandleToggleSprint ...
 func (NopHandler) HandleToggleSprint(*event.Context, bool) {}
 
@@ -155,19 +245,37 @@ func (NopHandler) HandleCommandExecution(*event.Context, cmd.Command, []string)
 func (NopHandler) HandleTransfer(*event.Context, *net.UDPAddr) {}
 
 // HandleChat ...
-func (NopHandler) HandleChat(*event.Context, *string) {}
+func (NopHandler) HandleChat(*event.Context, *string, *chat.Channel) {}
+
+// HandleEffectAdd ...
+func (NopHandler) HandleEffectAdd(*event.Context, effect.Effect) {}
+
+// HandleEffectExpire ...
+func (NopHandler) HandleEffectExpire(effect.Effect) {}
+
+// HandleEffectRemove ...
+func (NopHandler) HandleEffectRemove(effect.Effect, effect.RemovalCause) {}
 
 // HandleSkinChange ...
 func (NopHandler) HandleSkinChange(*event.Context, skin.Skin) {}
 
+// HandleGameModeChange ...
+func (NopHandler) HandleGameModeChange(*event.Context, world.GameMode, world.GameMode) {}
+
 // HandleStartBreak ...
 func (NopHandler) HandleStartBreak(*event.Context, cube.Pos) {}
 
+// HandleBreakTime ...
+func (NopHandler) HandleBreakTime(*event.Context, cube.Pos, *time.Duration) {}
+
+// HandleBlockBreakProgress ...
+func (NopHandler) HandleBlockBreakProgress(cube.Pos, float64, *time.Duration) {}
+
 // HandleBlockBreak ...
 func (NopHandler) HandleBlockBreak(*event.Context, cube.Pos, *[]item.Stack) {}
 
 // HandleBlockPlace ...
-func (NopHandler) HandleBlockPlace(*event.Context, cube.Pos, world.Block) {}
+func (NopHandler) HandleBlockPlace(*event.Context, cube.Pos, world.Block, *item.UseContext) {}
 
 // HandleBlockPick ...
 func (NopHandler) HandleBlockPick(*event.Context, cube.Pos, world.Block) {}
@@ -175,12 +283,24 @@ func (NopHandler) HandleBlockPick(*event.Context, cube.Pos, world.Block) {}
 // HandleSignEdit ...
 func (NopHandler) HandleSignEdit(*event.Context, string, string) {}
 
+// HandleGrindstoneUse ...
+func (NopHandler) HandleGrindstoneUse(*event.Context, cube.Pos, *item.Stack, int) {}
+
+// HandleSmithing ...
+func (NopHandler) HandleSmithing(*event.Context, item.Stack, item.Stack, *item.Stack) {}
+
+// HandleTrade ...
+func (NopHandler) HandleTrade(*event.Context, trade.Trader, trade.Trade) {}
+
 // HandleItemPickup ...
 func (NopHandler) HandleItemPickup(*event.Context, item.Stack) {}
 
 // HandleItemUse ...
 func (NopHandler) HandleItemUse(*event.Context) {}
 
+// HandleItemConsume ...
+func (NopHandler) HandleItemConsume(*event.Context, item.Stack) {}
+
 // HandleItemUseOnBlock ...
 func (NopHandler) HandleItemUseOnBlock(*event.Context, cube.Pos, cube.Face, mgl64.Vec3) {}
 
@@ -197,7 +317,7 @@ func (NopHandler) HandleAttackEntity(*event.Context, world.Entity, *float64, *fl
 func (NopHandler) HandlePunchAir(*event.Context) {}
 
 // HandleHurt ...
-func (NopHandler) HandleHurt(*event.Context, *float64, damage.Source) {}
+func (NopHandler) HandleHurt(*event.Context, *float64, *time.Duration, damage.Source) {}
 
 // HandleHeal ...
 func (NopHandler) HandleHeal(*event.Context, *float64, healing.Source) {}
@@ -205,8 +325,11 @@ func (NopHandler) HandleHeal(*event.Context, *float64, healing.Source) {}
 // HandleFoodLoss ...
 func (NopHandler) HandleFoodLoss(*event.Context, int, int) {}
 
+// HandleExperienceGain ...
+func (NopHandler) HandleExperienceGain(*event.Context, *int, experience.Source) {}
+
 // HandleDeath ...
-func (NopHandler) HandleDeath(damage.Source) {}
+func (NopHandler) HandleDeath(damage.Source, *DeathOptions) {}
 
 // HandleMount ...
 func (NopHandler) HandleMount(*event.Context, entity.Rideable) {}
@@ -217,5 +340,23 @@ func (NopHandler) HandleDismount(*event.Context) {}
 // HandleRespawn ...
 func (NopHandler) HandleRespawn(*mgl64.Vec3) {}
 
+// HandleSleep ...
+func (NopHandler) HandleSleep(*event.Context, cube.Pos) {}
+
+// HandleWake ...
+func (NopHandler) HandleWake(cube.Pos) {}
+
+// HandleSpamViolation ...
+func (NopHandler) HandleSpamViolation(session.RateLimitedAction, session.RateUsage) {}
+
+// HandleClientSettingsChange ...
+func (NopHandler) HandleClientSettingsChange(session.ClientSettings, session.ClientSettings) {}
+
+// HandleInputModeChange ...
+func (NopHandler) HandleInputModeChange(session.InputMode, session.InputMode) {}
+
+// HandleKick ...
+func (NopHandler) HandleKick(*event.Context, *string) {}
+
 // HandleQuit ...
 func (NopHandler) HandleQuit() {}
@@ -0,0 +1,44 @@
+package player
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Input is a snapshot of the input that a player provided in a single tick. The input is reported by the client
+// of the player and should therefore not be trusted blindly: The movement that results from it is subject to the
+// movement validation of the player if enabled using Player.SetMovementValidation.
+type Input struct {
+	// MoveVector is the directional movement input of the player, with the X component being the sideways input
+	// and the Y component being the forward input. Both components range from -1 to 1, where a positive X is to
+	// the left and a positive Y is forwards. The vector is zero if the player did not press any movement keys.
+	MoveVector mgl64.Vec2
+	// Jumping is true if the player held the jump button during the tick.
+	Jumping bool
+	// Sneaking is true if the player held the sneak button during the tick. Note that players may sneak without
+	// holding the button if they have toggled sneaking.
+	Sneaking bool
+	// Sprinting is true if the player held the sprint button during the tick.
+	Sprinting bool
+}
+
+// CurrentInput returns the input that the player provided in the last tick. For players without a client, such
+// as NPCs, a zero Input is returned.
+func (p *Player) CurrentInput() Input {
+	p.inputMu.Lock()
+	defer p.inputMu.Unlock()
+	return p.input
+}
+
+// UpdateInput updates the input of the player for the current tick, typically called by the session of the player
+// every tick. If the player pressed the jump button this tick while standing on the ground, the player jumps and
+// Handler.HandleJump is called.
+func (p *Player) UpdateInput(moveVector mgl64.Vec2, jumping, sneaking, sprinting bool) {
+	p.inputMu.Lock()
+	pressedJump := jumping && !p.input.Jumping
+	p.input = Input{MoveVector: moveVector, Jumping: jumping, Sneaking: sneaking, Sprinting: sprinting}
+	p.inputMu.Unlock()
+
+	if pressedJump && p.OnGround() && !p.jumping.Load() && p.canJump() {
+		p.jump()
+	}
+}
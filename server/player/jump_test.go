@@ -0,0 +1,79 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// jumpHandler is a player.Handler that counts the number of times a player jumped.
+type jumpHandler struct {
+	player.NopHandler
+	jumps int
+}
+
+// HandleJump ...
+func (h *jumpHandler) HandleJump() {
+	h.jumps++
+}
+
+// TestClimbingNoJump checks that players moving up a ladder from the ground are not considered to jump, while
+// players leaving the ground with an upward movement outside a ladder are.
+func TestClimbingNoJump(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+	w.SetBlock(cube.Pos{0, 1, 0}, block.Ladder{Facing: cube.North})
+
+	for _, c := range []struct {
+		x     float64
+		jumps int
+	}{{x: 0.5, jumps: 0}, {x: 4.5, jumps: 1}} {
+		p := w.NewPlayer("climber", mgl64.Vec3{c.x, 1, 0.5})
+		h := &jumpHandler{}
+		p.Handle(h)
+		w.Advance(5)
+		if !p.OnGround() {
+			t.Fatalf("player at x %v is not on the ground", c.x)
+		}
+		p.Move(mgl64.Vec3{0, 0.42, 0}, 0, 0)
+		if h.jumps != c.jumps {
+			t.Errorf("player moving up at x %v jumped %v times, want %v", c.x, h.jumps, c.jumps)
+		}
+		_ = p.Close()
+	}
+}
+
+// TestImmobileNoJump checks that pressing the jump button only makes players jump if they are neither immobile
+// nor frozen.
+func TestImmobileNoJump(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+
+	for name, c := range map[string]struct {
+		apply func(p *player.Player)
+		jumps int
+	}{
+		"mobile":   {apply: func(*player.Player) {}, jumps: 1},
+		"immobile": {apply: (*player.Player).SetImmobile, jumps: 0},
+		"frozen":   {apply: (*player.Player).Freeze, jumps: 0},
+	} {
+		p := w.NewPlayer("jumper", mgl64.Vec3{0.5, 1, 0.5})
+		h := &jumpHandler{}
+		p.Handle(h)
+		w.Advance(5)
+		if !p.OnGround() {
+			t.Fatalf("%v player is not on the ground", name)
+		}
+		c.apply(p)
+		p.UpdateInput(mgl64.Vec2{}, true, false, false)
+		if h.jumps != c.jumps {
+			t.Errorf("%v player pressing the jump button jumped %v times, want %v", name, h.jumps, c.jumps)
+		}
+		_ = p.Close()
+	}
+}
@@ -0,0 +1,92 @@
+package player
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player/audit"
+	"github.com/df-mc/dragonfly/server/player/mute"
+	"time"
+)
+
+// Kick kicks the player from the server, showing the reason passed on its disconnection screen. Unlike
+// Disconnect, Kick calls Handler.HandleKick, which may cancel the kick or change its reason, and writes an
+// audit record of the kick. The reason is formatted following the rules of fmt.Sprintln without a newline at
+// the end.
+func (p *Player) Kick(reason ...interface{}) {
+	msg := format(reason)
+	ctx := event.C()
+	p.handler().HandleKick(ctx, &msg)
+	p.writeAudit(audit.Kick{Header: p.AuditHeader(ctx.Cancelled()), Reason: msg})
+
+	ctx.Continue(func() {
+		p.Disconnect(msg)
+	})
+}
+
+// SetMuteList sets the mute.List that is consulted when the player sends a chat message. If the player is
+// muted in the list, its messages are dropped before Handler.HandleChat is called. Passing nil removes the
+// list. Servers set the list returned by Server.MuteList on every player that joins.
+func (p *Player) SetMuteList(l *mute.List) {
+	p.muteMu.Lock()
+	defer p.muteMu.Unlock()
+	p.muteList = l
+}
+
+// muted looks up the player in the mute.List set using SetMuteList and returns its mute if it is muted.
+func (p *Player) muted() (mute.Mute, bool) {
+	p.muteMu.RLock()
+	l := p.muteList
+	p.muteMu.RUnlock()
+	if l == nil {
+		return mute.Mute{}, false
+	}
+	return l.Muted(p.UUID())
+}
+
+// sendMuteMessage informs the player that its chat message was not sent because of the mute passed.
+func (p *Player) sendMuteMessage(m mute.Mute) {
+	msg := "You are muted"
+	if !m.Permanent() {
+		msg += fmt.Sprintf(" for %v", time.Until(m.Expiry).Round(time.Second))
+	}
+	if m.Reason != "" {
+		msg += ": " + m.Reason
+	}
+	p.Message(msg + ".")
+}
+
+// Freeze freezes the player: It is made immobile and can no longer use items, interact with blocks or
+// entities, attack or break blocks until Unfreeze is called. It may still look around and chat. A reminder
+// that the player is frozen is shown above its hotbar for as long as it is frozen.
+func (p *Player) Freeze() {
+	if !p.frozen.CAS(false, true) {
+		return
+	}
+	p.wasImmobile.Store(p.Immobile())
+	p.AbortBreaking()
+	p.SetImmobile()
+	p.sendFrozenTip()
+}
+
+// Unfreeze unfreezes a player previously frozen using Freeze. If the player was already immobile before it
+// was frozen, it remains immobile.
+func (p *Player) Unfreeze() {
+	if !p.frozen.CAS(true, false) {
+		return
+	}
+	if !p.wasImmobile.Load() {
+		p.SetMobile()
+	}
+	p.SendTip("")
+}
+
+// Frozen checks if the player is currently frozen using Freeze.
+func (p *Player) Frozen() bool {
+	return p.frozen.Load()
+}
+
+// sendFrozenTip shows the player a tip above its hotbar telling it that it is frozen. Tips fade out after a
+// few seconds, so it is resent periodically while the player is frozen.
+func (p *Player) sendFrozenTip() {
+	p.SendTip("You are frozen")
+}
@@ -0,0 +1,196 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+const (
+	// maxMoveViolation is the total distance in blocks that a player may move further than its movement budget
+	// allows before its movement is corrected. This leniency accounts for movement that is hard to predict, such
+	// as being pushed by knock back, walking on ice or the client sending several ticks of movement at once.
+	maxMoveViolation = 1.5
+	// moveViolationDecay is the distance by which the accumulated movement violation decreases every time the
+	// player moves within its movement budget.
+	moveViolationDecay = 0.05
+	// maxPhaseDistance is the maximum distance in blocks that a player may move into a block before it is
+	// considered to be phasing through it.
+	maxPhaseDistance = 0.1
+	// correctionInterval is the minimum time between two movement corrections of a player. Movement that is
+	// invalid within this time after a correction is dropped without the player being teleported again.
+	correctionInterval = time.Second / 4
+)
+
+// SetMovementValidation changes if the movement of the player is validated server-side. If enabled, which it is
+// not by default, movement that passes through solid blocks or that is faster than the player is able to move
+// is corrected by teleporting the player back to a valid position. Corrections may be observed and cancelled
+// using Handler.HandleMovementCorrection.
+func (p *Player) SetMovementValidation(validate bool) {
+	p.validateMovement.Store(validate)
+	p.moveViolation.Store(0)
+}
+
+// MovementValidation checks if the movement of the player is validated server-side.
+func (p *Player) MovementValidation() bool {
+	return p.validateMovement.Load()
+}
+
+// validMovement validates the movement of the player from pos by deltaPos. If the movement is invalid, false is
+// returned together with the position that the player should be corrected to.
+func (p *Player) validMovement(pos, deltaPos mgl64.Vec3) (mgl64.Vec3, bool) {
+	if !p.validateMovement.Load() || !p.GameMode().HasCollision() {
+		return pos, true
+	}
+	if e, _ := p.RidingEntity(); e != nil {
+		// The movement of the entity ridden is leading while riding.
+		return pos, true
+	}
+	allowed := p.collide(pos, deltaPos)
+	if phased := deltaPos.Sub(allowed); phased.Len() > maxPhaseDistance {
+		return pos.Add(allowed), false
+	}
+
+	horizontal, budget := math.Hypot(deltaPos[0], deltaPos[2]), p.horizontalMoveBudget()
+	excess := horizontal - budget
+	if deltaPos[1] > 0 {
+		excess = math.Max(excess, deltaPos[1]-p.verticalMoveBudget())
+	}
+	violation := p.moveViolation.Load()
+	if excess > 0 {
+		violation += excess
+	} else {
+		violation = math.Max(0, violation-moveViolationDecay)
+	}
+	p.moveViolation.Store(violation)
+	if violation > maxMoveViolation {
+		p.moveViolation.Store(0)
+		return pos, false
+	}
+	return pos, true
+}
+
+// correctMovement corrects the movement of the player, which requested to move to the requested position, by
+// teleporting it to the corrected position. Corrections are rate limited to once every correctionInterval:
+// Invalid movement within this interval is dropped without teleporting the player again. False is returned if
+// the correction was cancelled by the Handler, in which case the requested movement should be applied.
+func (p *Player) correctMovement(requested, corrected mgl64.Vec3) bool {
+	now := time.Now().UnixNano()
+	if last := p.lastCorrection.Load(); now-last < int64(correctionInterval) || !p.lastCorrection.CAS(last, now) {
+		return true
+	}
+	ctx := event.C()
+	p.handler().HandleMovementCorrection(ctx, requested, corrected)
+	ctx.Continue(func() {
+		p.teleport(corrected)
+	})
+	return !ctx.Cancelled()
+}
+
+// horizontalMoveBudget returns the maximum horizontal distance in blocks that the player is expected to be able
+// to move in a single tick, based on its speed, whether it is flying and whether it was knocked back.
+func (p *Player) horizontalMoveBudget() float64 {
+	// Jumping while sprinting gives a player a boost of roughly 0.6 blocks/tick, with a default speed of 0.13
+	// blocks/tick. Walking on ice or soul sand with speed is covered by the violation leniency.
+	budget := p.Speed() * 5
+	if p.Flying() {
+		// Flying players move at their flight speed, which is doubled when sprinting, rather than their walking
+		// speed. The default flight speed results in the same budget as walking at the default speed.
+		budget = math.Max(budget, p.FlightSpeed()*20)
+	}
+	if p.knockedBack.Load() {
+		budget += 1
+	}
+	return budget
+}
+
+// verticalMoveBudget returns the maximum upward distance in blocks that the player is expected to be able to
+// move in a single tick, based on its jump boost, levitation, step height and whether it is flying.
+func (p *Player) verticalMoveBudget() float64 {
+	// A jump gives an upward velocity of 0.42 blocks/tick, but the step height may be crossed at once.
+	budget := math.Max(0.42, p.StepHeight()) + 0.1
+	if e, ok := p.Effect(effect.JumpBoost{}); ok {
+		budget += float64(e.Level()) * 0.1
+	}
+	if e, ok := p.Effect(effect.Levitation{}); ok {
+		budget += float64(e.Level()) * 0.05
+	}
+	if p.Flying() {
+		budget += p.FlightSpeed() * 10
+	}
+	if p.knockedBack.Load() {
+		budget += 1
+	}
+	return budget
+}
+
+// collide sweeps the bounding box of the player at the position passed by deltaPos, stopping it when it
+// collides with the collision boxes of blocks on the Y, X and Z axes respectively. The delta that the player
+// may actually move by is returned. If the player walks into a block, moving up by its step height first is
+// attempted, so that walking up slabs and stairs is not considered phasing.
+func (p *Player) collide(pos, deltaPos mgl64.Vec3) mgl64.Vec3 {
+	aabb := p.AABB().Translate(pos)
+	step := p.StepHeight()
+	boxes := p.blockAABBsAround(aabb.Extend(deltaPos).Extend(mgl64.Vec3{0, step}))
+
+	allowed := sweep(aabb, boxes, deltaPos)
+	if step > 0 && (!mgl64.FloatEqual(allowed[0], deltaPos[0]) || !mgl64.FloatEqual(allowed[2], deltaPos[2])) {
+		stepped := sweep(aabb, boxes, mgl64.Vec3{deltaPos[0], step, deltaPos[2]})
+		steppedAABB := aabb.Translate(stepped)
+		down := deltaPos[1] - stepped[1]
+		for _, box := range boxes {
+			down = steppedAABB.CalculateYOffset(box, down)
+		}
+		stepped[1] += down
+		if stepped.Sub(deltaPos).Len() < allowed.Sub(deltaPos).Len() {
+			return stepped
+		}
+	}
+	return allowed
+}
+
+// blockAABBsAround returns the collision boxes of all blocks that intersect with the AABB passed.
+func (p *Player) blockAABBsAround(aabb physics.AABB) []physics.AABB {
+	w := p.World()
+	min, max := cube.PosFromVec3(aabb.Min()), cube.PosFromVec3(aabb.Max())
+
+	var boxes []physics.AABB
+	for y := min[1]; y <= max[1]; y++ {
+		for x := min[0]; x <= max[0]; x++ {
+			for z := min[2]; z <= max[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				m := w.Block(pos).Model()
+				if scaffolding, ok := m.(model.Scaffolding); ok && !scaffolding.Collides(pos, p.Position()[1], p.Sneaking()) {
+					// Players pass through scaffolding from below and while sneaking.
+					continue
+				}
+				for _, box := range m.AABB(pos, w) {
+					boxes = append(boxes, box.Translate(pos.Vec3()))
+				}
+			}
+		}
+	}
+	return boxes
+}
+
+// sweep moves the AABB passed by the delta passed on the Y, X and Z axes respectively, stopping it when it
+// collides with any of the boxes passed. The delta that the AABB may actually be moved by is returned.
+func sweep(aabb physics.AABB, boxes []physics.AABB, delta mgl64.Vec3) mgl64.Vec3 {
+	for _, box := range boxes {
+		delta[1] = aabb.CalculateYOffset(box, delta[1])
+	}
+	aabb = aabb.Translate(mgl64.Vec3{0, delta[1]})
+	for _, box := range boxes {
+		delta[0] = aabb.CalculateXOffset(box, delta[0])
+	}
+	aabb = aabb.Translate(mgl64.Vec3{delta[0]})
+	for _, box := range boxes {
+		delta[2] = aabb.CalculateZOffset(box, delta[2])
+	}
+	return delta
+}
@@ -0,0 +1,126 @@
+// Package mute implements a registry of muted players. A List is consulted by players before a chat message
+// is sent, so that muted players cannot chat until their mute is lifted or expires. Mutes may be persisted
+// across restarts by passing a Store to the List.
+package mute
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Mute holds the details of a single mute of a player.
+type Mute struct {
+	// Reason is the reason that the player was muted for. It may be empty.
+	Reason string `json:"reason"`
+	// Expiry is the time at which the mute expires. If Expiry is the zero time, the mute is permanent.
+	Expiry time.Time `json:"expiry"`
+}
+
+// Permanent checks if the mute never expires.
+func (m Mute) Permanent() bool {
+	return m.Expiry.IsZero()
+}
+
+// Expired checks if the mute has expired at the time passed.
+func (m Mute) Expired(t time.Time) bool {
+	return !m.Permanent() && !t.Before(m.Expiry)
+}
+
+// List is a registry of muted players, identified by their UUID. A List is safe for concurrent use. The zero
+// value of a List is not usable: NewList must be used to create one.
+type List struct {
+	mu    sync.Mutex
+	mutes map[uuid.UUID]Mute
+	store Store
+}
+
+// NewList returns a new, empty List. Mutes added to it are not persisted until a Store is set using UseStore.
+func NewList() *List {
+	return &List{mutes: map[uuid.UUID]Mute{}, store: NopStore{}}
+}
+
+// UseStore sets the Store that mutes of the List are persisted to. The mutes currently held by the store are
+// loaded into the List, replacing any mutes with the same UUID. Mutes that had already expired are removed
+// from the store. If nil is passed, mutes are no longer persisted.
+func (l *List) UseStore(s Store) error {
+	if s == nil {
+		s = NopStore{}
+	}
+	mutes, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("load mutes: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.store = s
+	now := time.Now()
+	for id, m := range mutes {
+		if m.Expired(now) {
+			if err := s.Delete(id); err != nil {
+				return fmt.Errorf("delete expired mute: %w", err)
+			}
+			continue
+		}
+		l.mutes[id] = m
+	}
+	return nil
+}
+
+// Mute mutes the player with the UUID passed for the duration passed. If d is 0 or negative, the mute is
+// permanent. An existing mute of the player is replaced.
+func (l *List) Mute(id uuid.UUID, reason string, d time.Duration) error {
+	m := Mute{Reason: reason}
+	if d > 0 {
+		m.Expiry = time.Now().Add(d)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mutes[id] = m
+	return l.store.Save(id, m)
+}
+
+// Unmute lifts the mute of the player with the UUID passed. Unmute is a no-op if the player was not muted.
+func (l *List) Unmute(id uuid.UUID) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.mutes[id]; !ok {
+		return nil
+	}
+	delete(l.mutes, id)
+	return l.store.Delete(id)
+}
+
+// Muted looks up the mute of the player with the UUID passed. If the player is not muted, or if its mute has
+// expired, false is returned. Expired mutes are removed from the List when looked up.
+func (l *List) Muted(id uuid.UUID) (Mute, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	m, ok := l.mutes[id]
+	if !ok {
+		return Mute{}, false
+	}
+	if m.Expired(time.Now()) {
+		delete(l.mutes, id)
+		_ = l.store.Delete(id)
+		return Mute{}, false
+	}
+	return m, true
+}
+
+// Mutes returns all mutes currently held by the List that have not yet expired, mapped by the UUID of the
+// muted player.
+func (l *List) Mutes() map[uuid.UUID]Mute {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	m := make(map[uuid.UUID]Mute, len(l.mutes))
+	for id, mute := range l.mutes {
+		if !mute.Expired(now) {
+			m[id] = mute
+		}
+	}
+	return m
+}
@@ -0,0 +1,99 @@
+package mute
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"sync"
+)
+
+// Store persists the mutes of a List, so that they are kept across restarts. Store methods are called while
+// the List is locked, so implementations need not synchronise calls made by a single List.
+type Store interface {
+	// Load loads all mutes held by the store, mapped by the UUID of the muted player.
+	Load() (map[uuid.UUID]Mute, error)
+	// Save saves the mute of the player with the UUID passed, replacing any mute previously saved for it.
+	Save(id uuid.UUID, m Mute) error
+	// Delete deletes the mute of the player with the UUID passed.
+	Delete(id uuid.UUID) error
+}
+
+// NopStore is a Store that does not persist any mutes. It is used by a List by default.
+type NopStore struct{}
+
+// Load returns no mutes.
+func (NopStore) Load() (map[uuid.UUID]Mute, error) { return nil, nil }
+
+// Save ...
+func (NopStore) Save(uuid.UUID, Mute) error { return nil }
+
+// Delete ...
+func (NopStore) Delete(uuid.UUID) error { return nil }
+
+// JSONFile is a Store that keeps all mutes in a single JSON file. The file is rewritten every time a mute is
+// saved or deleted.
+type JSONFile struct {
+	mu    sync.Mutex
+	path  string
+	mutes map[uuid.UUID]Mute
+}
+
+// NewJSONFile returns a JSONFile that stores mutes in the file at the path passed. The file is created when
+// the first mute is saved if it does not yet exist.
+func NewJSONFile(path string) *JSONFile {
+	return &JSONFile{path: path, mutes: map[uuid.UUID]Mute{}}
+}
+
+// Load reads all mutes from the file. If the file does not exist, no mutes are returned.
+func (j *JSONFile) Load() (map[uuid.UUID]Mute, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read mute file: %w", err)
+	}
+	mutes := map[uuid.UUID]Mute{}
+	if err := json.Unmarshal(data, &mutes); err != nil {
+		return nil, fmt.Errorf("decode mute file: %w", err)
+	}
+	j.mutes = mutes
+
+	m := make(map[uuid.UUID]Mute, len(mutes))
+	for id, mute := range mutes {
+		m[id] = mute
+	}
+	return m, nil
+}
+
+// Save saves the mute passed and rewrites the file.
+func (j *JSONFile) Save(id uuid.UUID, m Mute) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.mutes[id] = m
+	return j.write()
+}
+
+// Delete deletes the mute of the player with the UUID passed and rewrites the file.
+func (j *JSONFile) Delete(id uuid.UUID) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.mutes, id)
+	return j.write()
+}
+
+// write writes all mutes held to the file.
+func (j *JSONFile) write() error {
+	data, err := json.MarshalIndent(j.mutes, "", "\t")
+	if err != nil {
+		return fmt.Errorf("encode mute file: %w", err)
+	}
+	if err := os.WriteFile(j.path, data, 0644); err != nil {
+		return fmt.Errorf("write mute file: %w", err)
+	}
+	return nil
+}
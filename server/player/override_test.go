@@ -0,0 +1,71 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+// dayCycle returns the value of the dodaylightcycle game rule last sent in the packets passed, and false if it
+// was never sent.
+func dayCycle(packets []packet.Packet) (cycle, sent bool) {
+	for _, pk := range packets {
+		if pk, ok := pk.(*packet.GameRulesChanged); ok {
+			for _, rule := range pk.GameRules {
+				if rule.Name == "dodaylightcycle" {
+					cycle, sent = rule.Value.(bool), true
+				}
+			}
+		}
+	}
+	return
+}
+
+// TestResetTimeCycle checks that resetting the time shown to a player shows the time of the world again and
+// enables the day cycle client-side only if the time of the world is cycling at the moment of the reset, also if
+// the world started or stopped its time while the time shown to the player was overridden.
+func TestResetTimeCycle(t *testing.T) {
+	for _, c := range []struct {
+		name          string
+		before, after bool
+	}{
+		{name: "cycling", before: true, after: true},
+		{name: "stopped", before: false, after: false},
+		{name: "stopped during override", before: true, after: false},
+		{name: "started during override", before: false, after: true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			w := servertest.NewWorld()
+			defer w.Close()
+			setCycle := func(cycle bool) {
+				if cycle {
+					w.StartTime()
+				} else {
+					w.StopTime()
+				}
+			}
+			setCycle(c.before)
+			w.SetTime(5000)
+			p, conn := w.NewSessionPlayer("watcher", mgl64.Vec3{0.5, 6, 0.5})
+
+			p.SetTime(18000, true)
+			setCycle(c.after)
+			conn.Reset()
+			p.ResetTime()
+
+			if cycle, sent := dayCycle(conn.Packets()); !sent || cycle != c.after {
+				t.Errorf("day cycle sent: %v, enabled: %v after resetting the time, want enabled: %v", sent, cycle, c.after)
+			}
+			var time int32 = -1
+			for _, pk := range conn.Packets() {
+				if pk, ok := pk.(*packet.SetTime); ok {
+					time = pk.Time
+				}
+			}
+			if time != 5000 {
+				t.Errorf("time %v shown after resetting the time, want 5000", time)
+			}
+		})
+	}
+}
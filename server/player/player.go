@@ -1,6 +1,7 @@
 package player
 
 import (
+	"context"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block"
 	blockAction "github.com/df-mc/dragonfly/server/block/action"
@@ -10,6 +11,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity/action"
 	"github.com/df-mc/dragonfly/server/entity/damage"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/experience"
 	"github.com/df-mc/dragonfly/server/entity/healing"
 	"github.com/df-mc/dragonfly/server/entity/physics"
 	"github.com/df-mc/dragonfly/server/event"
@@ -18,11 +20,15 @@ import (
 	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/df-mc/dragonfly/server/player/audit"
 	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/mute"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
 	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/player/stat"
 	"github.com/df-mc/dragonfly/server/player/title"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
@@ -49,11 +55,13 @@ type Player struct {
 	xuid                                string
 	locale                              language.Tag
 	pos, vel                            atomic.Value
-	nameTag                             atomic.String
+	nameTag, scoreTag                   atomic.String
+	nameTagVisibility                   atomic.Int32
 	yaw, pitch, absorptionHealth, scale atomic.Float64
 
 	gameModeMu sync.RWMutex
 	gameMode   world.GameMode
+	abilities  abilities
 
 	skinMu sync.RWMutex
 	skin   skin.Skin
@@ -67,17 +75,29 @@ type Player struct {
 	// h holds the current handler of the player. It may be changed at any time by calling the Start method.
 	h Handler
 
-	inv, offHand *inventory.Inventory
-	armour       *inventory.Armour
-	heldSlot     *atomic.Uint32
+	inv, offHand, enderChest *inventory.Inventory
+	armour                   *inventory.Armour
+	heldSlot                 *atomic.Uint32
 
 	seatPosition atomic.Value
+	spawnPos     atomic.Value
+	deathRespawn atomic.Value
+	deathDrops   atomic.Value
 	ridingMu     sync.Mutex
 	riding       entity.Rideable
+	ridersMu     sync.Mutex
+	riders       []world.Entity
+	spectateMu   sync.Mutex
+	spectating   world.Entity
+	sleepMu      sync.Mutex
+	sleepPos     *cube.Pos
+	sleepTicks   int
 
 	sneaking, sprinting, swimming, flying,
 	invisible, immobile, onGround, usingItem atomic.Bool
-	usingSince atomic.Int64
+	jumping, knockedBack atomic.Bool
+	operator             atomic.Bool
+	usingSince           atomic.Int64
 
 	fireTicks    atomic.Int64
 	fallDistance atomic.Float64
@@ -85,20 +105,64 @@ type Player struct {
 	cooldownMu sync.Mutex
 	cooldowns  map[itemHash]time.Time
 
-	speed    atomic.Float64
-	health   *entity.HealthManager
-	effects  *entity.EffectManager
-	immunity atomic.Value
+	speed      atomic.Float64
+	stepHeight atomic.Float64
+	health     *entity.HealthManager
+	effects    *entity.EffectManager
+	immunity   atomic.Value
 
 	mc *entity.MovementComputer
 
-	breaking          atomic.Bool
-	breakingPos       atomic.Value
-	lastBreakDuration time.Duration
+	breakingMu sync.Mutex
+	// breaking holds the state of the block that the player is currently breaking. It is nil if the player is
+	// not breaking a block.
+	breaking         *breakingState
+	validateBreaking atomic.Bool
 
 	breakParticleCounter atomic.Uint32
+	lastSwing            atomic.Int64
 
-	hunger *hungerManager
+	validateMovement atomic.Bool
+	moveViolation    atomic.Float64
+	lastCorrection   atomic.Int64
+	flightSpeed      atomic.Float64
+
+	inputMu sync.Mutex
+	input   Input
+
+	chatMu      sync.RWMutex
+	chatChannel chat.Channel
+
+	auditMu   sync.RWMutex
+	auditSink audit.Sink
+
+	muteMu   sync.RWMutex
+	muteList *mute.List
+
+	provMu sync.RWMutex
+	prov   Provider
+
+	creativeItemPolicy atomic.Uint32
+
+	stats *stat.Tracker
+
+	// frozen is true while the player is frozen using Freeze. wasImmobile holds if the player was already
+	// immobile when it was frozen, so that Unfreeze does not make it mobile again.
+	frozen, wasImmobile atomic.Bool
+
+	// deathMu guards the death state of the player: deathTimer is the timer that finishes the death of the
+	// player after its death animation and deathGen is increased every time it is started. finishing is
+	// non-nil while that death is being finished and closed once it is done. respawning is true while the
+	// player is being respawned and closed is set once the player is closed.
+	deathMu    sync.Mutex
+	deathTimer *time.Timer
+	deathGen   uint64
+	finishing  chan struct{}
+	respawning bool
+	closed     bool
+
+	hunger     *hungerManager
+	experience *experienceManager
 }
 
 // New returns a new initialised player. A random UUID is generated for the player, so that it may be
@@ -112,29 +176,39 @@ func New(name string, skin skin.Skin, pos mgl64.Vec3) *Player {
 				p.broadcastItems(slot, item)
 			}
 		}),
-		uuid:      uuid.New(),
-		offHand:   inventory.New(1, p.broadcastItems),
-		armour:    inventory.NewArmour(p.broadcastArmour),
-		hunger:    newHungerManager(),
-		health:    entity.NewHealthManager(),
-		effects:   entity.NewEffectManager(),
-		gameMode:  world.GameModeSurvival,
-		h:         NopHandler{},
-		name:      name,
-		skin:      skin,
-		speed:     *atomic.NewFloat64(0.1),
-		nameTag:   *atomic.NewString(name),
-		heldSlot:  atomic.NewUint32(0),
-		locale:    language.BritishEnglish,
-		scale:     *atomic.NewFloat64(1),
-		cooldowns: make(map[itemHash]time.Time),
-	}
-	p.mc = &entity.MovementComputer{Gravity: 0.06, Drag: 0.02, DragBeforeGravity: true}
+		uuid:       uuid.New(),
+		offHand:    inventory.New(1, p.broadcastItems),
+		armour:     inventory.NewArmour(p.broadcastArmour),
+		hunger:     newHungerManager(),
+		experience: newExperienceManager(),
+		health:     entity.NewHealthManager(),
+		effects:    entity.NewEffectManager(),
+		gameMode:   world.GameModeSurvival,
+		h:          NopHandler{},
+		name:       name,
+		skin:       skin,
+		speed:      *atomic.NewFloat64(0.1),
+		stepHeight: *atomic.NewFloat64(defaultStepHeight),
+		nameTag:    *atomic.NewString(name),
+		heldSlot:   atomic.NewUint32(0),
+		locale:     language.BritishEnglish,
+		scale:      *atomic.NewFloat64(1),
+		cooldowns:  make(map[itemHash]time.Time),
+		stats:      stat.NewTracker(),
+
+		chatChannel: chat.Global,
+	}
+	p.enderChest = inventory.New(27, func(slot int, item item.Stack) {
+		p.session().ViewEnderChestSlotChange(p.enderChest, slot, item)
+	})
+	p.mc = &entity.MovementComputer{Gravity: 0.06, Drag: 0.02, DragBeforeGravity: true, Climbs: true}
 	p.pos.Store(pos)
 	p.vel.Store(mgl64.Vec3{})
 	p.immunity.Store(time.Now())
-	p.breakingPos.Store(cube.Pos{})
+	p.validateBreaking.Store(true)
+	p.flightSpeed.Store(defaultFlightSpeed)
 	p.seatPosition.Store(mgl32.Vec3{0, 0, 0})
+	p.deathDrops.Store(DefaultDeathDropOptions())
 	return p
 }
 
@@ -219,11 +293,41 @@ func (p *Player) SetSkin(skin skin.Skin) {
 	})
 }
 
+// SetCape changes the cape of the player's skin to the RGBA pixel data passed, leaving the rest of the skin
+// unchanged. The width and height must be 64x32, or 0x0 to remove the cape. An error is returned if the
+// dimensions are invalid or do not match the length of the data.
+// SetCape calls Handler.HandleSkinChange with the modified skin, similarly to SetSkin.
+func (p *Player) SetCape(data []byte, width, height int) error {
+	c, err := skin.CapeFromData(data, width, height)
+	if err != nil {
+		return err
+	}
+	sk := p.Skin()
+	sk.Cape = c
+	p.SetSkin(sk)
+	return nil
+}
+
 // Locale returns the language and locale of the Player, as selected in the Player's settings.
 func (p *Player) Locale() language.Tag {
 	return p.locale
 }
 
+// ClientInfo returns information on the client of the Player, such as the operating system of its device and
+// its UI profile. A zero session.ClientInfo is returned if the Player has no session.
+func (p *Player) ClientInfo() session.ClientInfo {
+	return p.session().ClientInfo()
+}
+
+// InputMode returns the way that the client of the Player currently provides input, such as using a controller or
+// a touch screen. Zero is returned if the Player has no session.
+func (p *Player) InputMode() session.InputMode {
+	if p.session() == session.Nop {
+		return 0
+	}
+	return p.session().ClientSettings().InputMode
+}
+
 // Handle changes the current handler of the player. As a result, events called by the player will call
 // handlers of the Handler passed.
 // Handle sets the player's handler to NopHandler if nil is passed.
@@ -237,6 +341,22 @@ func (p *Player) Handle(h Handler) {
 	p.h = h
 }
 
+// Session returns the network session of the player, which may be used to intercept and write raw packets. If
+// the player has no session, session.Nop is returned, for which Session.WritePacket returns
+// session.ErrNopSession.
+// Writing or intercepting packets may cause the state of the client to desync from that of the server and
+// should only be done with care.
+func (p *Player) Session() *session.Session {
+	return p.session()
+}
+
+// ResyncInventory resends the full inventory, off-hand, armour and cursor of the player to its client. It is
+// called automatically when an event that changed items client-side is cancelled, but may be called manually
+// if the client ends up out of sync with the server.
+func (p *Player) ResyncInventory() {
+	p.session().ResendInventories()
+}
+
 // Message sends a formatted message to the player. The message is formatted following the rules of
 // fmt.Sprintln, however the newline at the end is not written.
 func (p *Player) Message(a ...interface{}) {
@@ -320,16 +440,44 @@ func (p *Player) RemoveBossBar() {
 	p.session().RemoveBossBar()
 }
 
-// Chat writes a message in the global chat (chat.Global). The message is prefixed with the name of the
-// player and is formatted following the rules of fmt.Sprintln.
+// Chat writes a message in the chat channel of the player, which is chat.Global by default. The message is
+// prefixed with the name of the player and is formatted following the rules of fmt.Sprintln.
 func (p *Player) Chat(msg ...interface{}) {
 	message := format(msg)
+	if p.Muted() {
+		p.writeAudit(audit.Chat{Header: p.AuditHeader(true), Message: message, Muted: true})
+		return
+	}
+	if m, ok := p.muted(); ok {
+		p.sendMuteMessage(m)
+		p.writeAudit(audit.Chat{Header: p.AuditHeader(true), Message: message, Muted: true})
+		return
+	}
+	ch := p.ChatChannel()
 	ctx := event.C()
-	p.handler().HandleChat(ctx, &message)
+	p.handler().HandleChat(ctx, &message, &ch)
 
 	ctx.Continue(func() {
-		_, _ = fmt.Fprintf(chat.Global, "<%v> %v\n", p.name, message)
+		chat.Send(ch, p, fmt.Sprintf("<%v> %v\n", p.name, message))
 	})
+	p.writeAudit(audit.Chat{Header: p.AuditHeader(ctx.Cancelled()), Message: message})
+}
+
+// ChatChannel returns the chat.Channel that messages sent by the player using Chat are sent to. By default,
+// this is chat.Global.
+func (p *Player) ChatChannel() chat.Channel {
+	p.chatMu.RLock()
+	defer p.chatMu.RUnlock()
+	return p.chatChannel
+}
+
+// SetChatChannel changes the chat.Channel that messages sent by the player using Chat are sent to. The player
+// does not subscribe to the channel passed: Channels that require explicit subscription should be subscribed
+// to separately.
+func (p *Player) SetChatChannel(ch chat.Channel) {
+	p.chatMu.Lock()
+	defer p.chatMu.Unlock()
+	p.chatChannel = ch
 }
 
 // ExecuteCommand executes a command passed as the player. If the command could not be found, or if the usage
@@ -354,6 +502,7 @@ func (p *Player) ExecuteCommand(commandLine string) {
 	ctx.Continue(func() {
 		command.Execute(strings.TrimPrefix(strings.TrimPrefix(commandLine, "/"+commandName), " "), p)
 	})
+	p.writeAudit(audit.Command{Header: p.AuditHeader(ctx.Cancelled()), CommandLine: commandLine})
 }
 
 // Disconnect closes the player and removes it from the world.
@@ -414,6 +563,32 @@ func (p *Player) DisableInstantRespawn() {
 	p.session().EnableInstantRespawn(false)
 }
 
+// SetTime sets the time shown to the player without changing the time of the world it is in. If stopped is true,
+// the time does not progress for the player. The time of the world is no longer shown to the player until
+// ResetTime is called, also if the player changes worlds.
+func (p *Player) SetTime(time int, stopped bool) {
+	p.session().SetTimeOverride(time, stopped)
+}
+
+// ResetTime resets the time shown to the player after a call to SetTime, so that the player sees the time of
+// the world it is in again.
+func (p *Player) ResetTime() {
+	p.session().ResetTimeOverride()
+}
+
+// ShowWeather shows the weather passed to the player without changing the weather of the world it is in. The
+// weather of the world is no longer shown to the player until ResetWeather is called, also if the player changes
+// worlds.
+func (p *Player) ShowWeather(raining, thundering bool) {
+	p.session().SetWeatherOverride(raining, thundering)
+}
+
+// ResetWeather resets the weather shown to the player after a call to ShowWeather, so that the player sees the
+// weather of the world it is in again.
+func (p *Player) ResetWeather() {
+	p.session().ResetWeatherOverride()
+}
+
 // SetNameTag changes the name tag displayed over the player in-game. Changing the name tag does not change
 // the player's name in, for example, the player list or the chat.
 func (p *Player) SetNameTag(name string) {
@@ -426,6 +601,32 @@ func (p *Player) NameTag() string {
 	return p.nameTag.Load()
 }
 
+// SetNameTagVisibility changes when the name tag of the player is shown to other players. By default, the name
+// tag is shown unless the player is sneaking.
+func (p *Player) SetNameTagVisibility(v entity.NameTagVisibility) {
+	p.nameTagVisibility.Store(int32(v))
+	p.updateState()
+}
+
+// NameTagVisibility returns when the name tag of the player is shown to other players. It can be changed using
+// SetNameTagVisibility.
+func (p *Player) NameTagVisibility() entity.NameTagVisibility {
+	return entity.NameTagVisibility(p.nameTagVisibility.Load())
+}
+
+// SetScoreTag changes the score tag displayed under the name tag of the player, which is commonly used to
+// display, for example, the health of the player. Passing an empty string removes the score tag.
+func (p *Player) SetScoreTag(tag string) {
+	p.scoreTag.Store(tag)
+	p.updateState()
+}
+
+// ScoreTag returns the score tag displayed under the name tag of the player. It can be changed using
+// SetScoreTag.
+func (p *Player) ScoreTag() string {
+	return p.scoreTag.Load()
+}
+
 // SetSpeed sets the speed of the player. The value passed is the blocks/tick speed that the player will then
 // obtain.
 func (p *Player) SetSpeed(speed float64) {
@@ -439,6 +640,60 @@ func (p *Player) Speed() float64 {
 	return p.speed.Load()
 }
 
+// defaultFlightSpeed is the default flight speed of a player in blocks/tick.
+const defaultFlightSpeed = 0.05
+
+// SetFlightSpeed sets the speed at which the player flies. The value passed is the blocks/tick speed that the
+// player obtains while flying, which is 0.05 by default. The speed applies to both horizontal and vertical
+// flight, as the protocol does not support setting them separately.
+// Note that the current protocol version does not support sending the flight speed to the client, so the speed
+// is currently only used server-side, for example to validate the movement of the player if enabled using
+// SetMovementValidation. Unlike the speed of the player, the flight speed is kept when the player changes game
+// mode and is saved in the Data of the player.
+func (p *Player) SetFlightSpeed(speed float64) {
+	p.flightSpeed.Store(math.Max(0, speed))
+}
+
+// FlightSpeed returns the speed at which the player flies in blocks/tick, as set using SetFlightSpeed. The
+// default flight speed of a player is 0.05.
+func (p *Player) FlightSpeed() float64 {
+	return p.flightSpeed.Load()
+}
+
+// defaultStepHeight is the default step height of a player. maxStepHeight is the highest step height that
+// may be set using Player.SetStepHeight.
+const defaultStepHeight, maxStepHeight = 0.6, 2
+
+// SetStepHeight sets the height of blocks that the player may walk up without jumping. The value passed is
+// clamped between 0 and 2. The default step height of a player is 0.6. While riding an entity, the step
+// height of a player is at least 1.
+func (p *Player) SetStepHeight(height float64) {
+	p.stepHeight.Store(math.Max(0, math.Min(height, maxStepHeight)))
+}
+
+// StepHeight returns the height of blocks that the player may walk up without jumping. While the player is
+// riding an entity, the step height returned is at least 1.
+func (p *Player) StepHeight() float64 {
+	if e, _ := p.RidingEntity(); e != nil {
+		return math.Max(p.stepHeight.Load(), 1)
+	}
+	return p.stepHeight.Load()
+}
+
+// MovementConfig returns the entity.MovementConfig used to compute the movement of the player when it is not
+// controlled by a client, such as for bots without a session. By default, it has a Gravity of 0.06 and a Drag
+// of 0.02, applied before gravity.
+func (p *Player) MovementConfig() entity.MovementConfig {
+	return p.mc.Config()
+}
+
+// SetMovementConfig changes the entity.MovementConfig used to compute the movement of the player when it is not
+// controlled by a client. The movement of players controlled by a client is computed by that client, so the
+// config has no effect on them.
+func (p *Player) SetMovementConfig(conf entity.MovementConfig) {
+	p.mc.SetConfig(conf)
+}
+
 // Health returns the current health of the player. It will always be lower than Player.MaxHealth().
 func (p *Player) Health() float64 {
 	return p.health.Health()
@@ -470,6 +725,7 @@ func (p *Player) addHealth(health float64) {
 // If the health passed is negative, Heal will not do anything.
 func (p *Player) Heal(health float64, source healing.Source) {
 	if p.Dead() || health < 0 || !p.GameMode().AllowsTakingDamage() {
+		// Players in game modes that do not take regular damage, such as creative mode, do not heal either.
 		return
 	}
 	ctx := event.C()
@@ -479,30 +735,73 @@ func (p *Player) Heal(health float64, source healing.Source) {
 	})
 }
 
-// updateFallState is called to update the entities falling state.
-func (p *Player) updateFallState(distanceThisTick float64) {
-	fallDistance := p.fallDistance.Load()
-	if p.OnGround() {
-		if fallDistance > 0 {
-			p.fall(fallDistance)
+// updateFallState is called to update the entities falling state after moving from one position to another.
+// The fall distance is modified by any block.FallDistanceModifier passed through before the player lands.
+func (p *Player) updateFallState(distanceThisTick float64, from, to mgl64.Vec3) {
+	onGround := p.OnGround()
+	if !onGround {
+		if distanceThisTick < p.fallDistance.Load() {
+			p.fallDistance.Sub(distanceThisTick)
+		} else {
 			p.ResetFallDistance()
 		}
-	} else if distanceThisTick < fallDistance {
-		p.fallDistance.Sub(distanceThisTick)
-	} else {
+	}
+	p.modifyFallDistance(from, to)
+	if fallDistance := p.fallDistance.Load(); onGround && fallDistance > 0 {
+		p.fall(fallDistance)
 		p.ResetFallDistance()
 	}
 }
 
-// fall is called when a falling entity hits the ground.
-func (p *Player) fall(fallDistance float64) {
+// modifyFallDistance applies the block.FallDistanceModifiers that the player moved through when moving from one
+// position to another to its fall distance. All blocks between the two positions are checked, so that fast
+// falling players cannot skip over a modifier, such as a single block of water, within one tick.
+func (p *Player) modifyFallDistance(from, to mgl64.Vec3) {
+	fallDistance := p.fallDistance.Load()
+	if fallDistance <= 0 {
+		return
+	}
 	w := p.World()
-	pos := cube.PosFromVec3(p.Position())
-	b := w.Block(pos)
-	if len(b.Model().AABB(pos, w)) == 0 {
-		pos = pos.Side(cube.FaceDown)
-		b = w.Block(pos)
+	a, b := p.AABB().Translate(from), p.AABB().Translate(to)
+	min := cube.PosFromVec3(mgl64.Vec3{math.Min(a.Min()[0], b.Min()[0]), math.Min(a.Min()[1], b.Min()[1]), math.Min(a.Min()[2], b.Min()[2])})
+	max := cube.PosFromVec3(mgl64.Vec3{math.Max(a.Max()[0], b.Max()[0]), math.Max(a.Max()[1], b.Max()[1]), math.Max(a.Max()[2], b.Max()[2])})
+
+	modified := fallDistance
+	for y := min[1]; y <= max[1]; y++ {
+		for x := min[0]; x <= max[0]; x++ {
+			for z := min[2]; z <= max[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				if m, ok := w.Block(pos).(block.FallDistanceModifier); ok {
+					modified = math.Min(modified, m.ModifyFallDistance(fallDistance))
+				}
+				if l, ok := w.Liquid(pos); ok {
+					if m, ok := l.(block.FallDistanceModifier); ok {
+						modified = math.Min(modified, m.ModifyFallDistance(fallDistance))
+					}
+				}
+			}
+		}
+	}
+	if modified != fallDistance {
+		p.fallDistance.Store(modified)
 	}
+}
+
+// floating checks if the player is floating due to the Levitation or Slow Falling effect, in which case it does
+// not accumulate fall distance.
+func (p *Player) floating() bool {
+	if _, ok := p.Effect(effect.Levitation{}); ok {
+		return true
+	}
+	_, ok := p.Effect(effect.SlowFalling{})
+	return ok
+}
+
+// fall is called when a falling entity hits the ground. Blocks implementing block.FallDamageLander change the
+// fall damage dealt to the player.
+func (p *Player) fall(fallDistance float64) {
+	w := p.World()
+	pos, b := p.blockBelow()
 	if h, ok := b.(block.EntityLander); ok {
 		h.EntityLand(pos, w, p)
 	}
@@ -511,6 +810,9 @@ func (p *Player) fall(fallDistance float64) {
 	if boost, ok := p.Effect(effect.JumpBoost{}); ok {
 		fallDamage -= float64(boost.Level())
 	}
+	if l, ok := b.(block.FallDamageLander); ok {
+		fallDamage *= l.FallDamageMultiplier(p)
+	}
 	if fallDamage < 0.5 {
 		return
 	}
@@ -522,39 +824,44 @@ func (p *Player) fall(fallDistance float64) {
 // for example damage.SourceEntityAttack if the player is attacked by another entity.
 // If the final damage exceeds the health that the player currently has, the player is killed and will have to
 // respawn.
-// If the damage passed is negative, Hurt will not do anything.
+// If the damage passed is negative, Hurt will not do anything. Whether the Player can be damaged by the source
+// in its current game mode is determined by damage.AllowedByGameMode.
 // Hurt returns the final damage dealt to the Player and if the Player was vulnerable to this kind of damage.
 func (p *Player) Hurt(dmg float64, source damage.Source) (float64, bool) {
-	if p.Dead() || !p.GameMode().AllowsTakingDamage() {
+	if p.Dead() || !damage.AllowedByGameMode(p.GameMode(), source) {
 		return 0, false
 	}
-	if _, ok := p.Effect(effect.FireResistance{}); ok && (source == damage.SourceFire{} || source == damage.SourceFireTick{} || source == damage.SourceLava{}) {
+	if _, ok := p.Effect(effect.FireResistance{}); ok && (source == damage.SourceFire{} || source == damage.SourceFireTick{} || source == damage.SourceLava{} || source == damage.SourceHotFloor{}) {
 		return 0, false
 	}
 	var (
 		ctx        = event.C()
 		vulnerable = false
 		n          = 0.0
+		immunity   = defaultAttackImmunity
 	)
-	p.handler().HandleHurt(ctx, &dmg, source)
+	p.handler().HandleHurt(ctx, &dmg, &immunity, source)
 
 	ctx.Continue(func() {
 		vulnerable = true
 		if dmg < 0 {
 			return
 		}
+		p.WakeUp()
 		if source.ReducedByArmour() {
 			p.Exhaust(0.1)
 		}
 		finalDamage := p.FinalDamageFrom(dmg, source)
 		n = finalDamage
+		p.stats.Add(stat.DamageTaken, int64(math.Round(finalDamage*10)))
 
 		a := p.absorption()
 		if a > 0 && (effect.Absorption{}).Absorbs(source) {
 			if finalDamage > a {
 				finalDamage -= a
 				p.SetAbsorption(0)
-				p.effects.Remove(effect.Absorption{}, p)
+				// The absorption health ran out, so the effect wears off.
+				p.expireEffect(effect.Absorption{})
 			} else {
 				p.SetAbsorption(a - finalDamage)
 				finalDamage = 0
@@ -588,7 +895,7 @@ func (p *Player) Hurt(dmg float64, source damage.Source) (float64, bool) {
 		for _, viewer := range p.viewers() {
 			viewer.ViewEntityAction(p, action.Hurt{})
 		}
-		p.SetAttackImmunity(time.Second / 2)
+		p.SetAttackImmunity(immunity)
 		if p.Dead() {
 			p.kill(source)
 		}
@@ -661,7 +968,7 @@ func (p *Player) absorption() float64 {
 // source of the velocity, typically the position of an attacking entity. The source is used to calculate the
 // direction which the entity should be knocked back in.
 func (p *Player) KnockBack(src mgl64.Vec3, force, height float64) {
-	if p.Dead() || !p.GameMode().AllowsTakingDamage() {
+	if p.Dead() || !damage.AllowedByGameMode(p.GameMode(), damage.SourceEntityAttack{}) {
 		return
 	}
 	velocity := p.Position().Sub(src)
@@ -676,9 +983,14 @@ func (p *Player) KnockBack(src mgl64.Vec3, force, height float64) {
 		}
 	}
 
+	p.knockedBack.Store(true)
 	p.SetVelocity(velocity.Mul(1 - resistance))
 }
 
+// defaultAttackImmunity is the duration that a player is immune to attacks after being hurt, unless changed by
+// a Handler in HandleHurt.
+const defaultAttackImmunity = time.Second / 2
+
 // AttackImmune checks if the player is currently immune to entity attacks, meaning it was recently attacked.
 func (p *Player) AttackImmune() bool {
 	return p.immunity.Load().(time.Time).After(time.Now())
@@ -728,20 +1040,106 @@ func (p *Player) sendFood() {
 	p.session().SendFood(p.hunger.foodLevel, p.hunger.saturationLevel, p.hunger.exhaustionLevel)
 }
 
+// Experience returns the total amount of experience points of the player.
+func (p *Player) Experience() int {
+	return p.experience.Experience()
+}
+
+// ExperienceLevel returns the experience level of the player, shown above the experience bar, and the progress
+// towards the next level, between 0 and 1.
+func (p *Player) ExperienceLevel() (level int, progress float64) {
+	return p.experience.Level()
+}
+
+// AddExperience adds the amount of experience points passed to the player, calling Handler.HandleExperienceGain
+// with the experience.Source passed first. The amount of experience actually added is returned, which is 0 if the
+// event was cancelled.
+func (p *Player) AddExperience(amount int, src experience.Source) int {
+	if amount <= 0 {
+		return 0
+	}
+	ctx := event.C()
+	p.handler().HandleExperienceGain(ctx, &amount, src)
+	if ctx.Cancelled() || amount <= 0 {
+		return 0
+	}
+	before := p.experience.Experience()
+	added := p.experience.Add(amount) - before
+	p.session().SendExperience(p.experience.Level())
+	return added
+}
+
 // AddEffect adds an entity.Effect to the Player. If the effect is instant, it is applied to the Player
 // immediately. If not, the effect is applied to the player every time the Tick method is called.
 // AddEffect will overwrite any effects present if the level of the effect is higher than the existing one, or
 // if the effects' levels are equal and the new effect has a longer duration.
 func (p *Player) AddEffect(e effect.Effect) {
-	p.session().SendEffect(p.effects.Add(e, p))
-	p.updateState()
+	ctx := event.C()
+	p.handler().HandleEffectAdd(ctx, e)
+	ctx.Continue(func() {
+		p.session().SendEffect(p.effects.Add(e, p))
+		p.updateState()
+	})
 }
 
 // RemoveEffect removes any effect that might currently be active on the Player.
 func (p *Player) RemoveEffect(e effect.Type) {
-	p.effects.Remove(e, p)
+	p.removeEffect(e, effect.CausePlugin{})
+}
+
+// RemoveAllEffects removes all effects currently active on the Player for the cause passed, such as
+// effect.CauseMilk when the Player drinks milk.
+func (p *Player) RemoveAllEffects(cause effect.RemovalCause) {
+	for _, e := range p.Effects() {
+		p.removeEffect(e.Type(), cause)
+	}
+}
+
+// removeEffect removes the effect with the type passed from the Player for the cause passed, if the Player has
+// it.
+func (p *Player) removeEffect(e effect.Type, cause effect.RemovalCause) {
+	removed, ok := p.effects.Remove(e, p)
+	if !ok {
+		return
+	}
 	p.session().SendEffectRemoval(e)
 	p.updateState()
+	p.handler().HandleEffectRemove(removed, cause)
+}
+
+// expireEffect removes the effect with the type passed from the Player because it wore off, if the Player has
+// it.
+func (p *Player) expireEffect(e effect.Type) {
+	removed, ok := p.effects.Remove(e, p)
+	if !ok {
+		return
+	}
+	p.session().SendEffectRemoval(e)
+	p.updateState()
+	p.handler().HandleEffectExpire(removed)
+	p.handler().HandleEffectRemove(removed, effect.CauseExpired{})
+}
+
+// SetEffectDuration changes the leftover duration of the effect with the type passed, if the Player has it. The
+// effect is not removed and added again, so its level and particles stay the same. A bool is returned that is
+// false if the Player did not have the effect.
+func (p *Player) SetEffectDuration(e effect.Type, d time.Duration) bool {
+	updated, ok := p.effects.SetDuration(e, d)
+	if ok {
+		p.session().SendEffectModification(updated)
+	}
+	return ok
+}
+
+// ExtendEffect extends the leftover duration of the effect with the type passed by the duration passed, if the
+// Player has it. A negative duration may be passed to shorten the effect. A bool is returned that is false if the
+// Player did not have the effect.
+func (p *Player) ExtendEffect(e effect.Type, d time.Duration) bool {
+	updated, ok := p.effects.Extend(e, d)
+	if ok {
+		p.session().SendEffectModification(updated)
+	}
+	return ok
 }
 
 // Effect returns the effect instance and true if the Player has the effect. If not found, it will return an empty
@@ -761,10 +1159,15 @@ func (*Player) BeaconAffected() bool {
 	return true
 }
 
+// ConduitAffected ...
+func (*Player) ConduitAffected() bool {
+	return true
+}
+
 // Exhaust exhausts the player by the amount of points passed if the player is in survival mode. If the total
 // exhaustion level exceeds 4, a saturation point, or food point, if saturation is 0, will be subtracted.
 func (p *Player) Exhaust(points float64) {
-	if !p.GameMode().AllowsTakingDamage() {
+	if !damage.AllowedByGameMode(p.GameMode(), damage.SourceStarvation{}) {
 		return
 	}
 	before := p.hunger.Food()
@@ -804,59 +1207,337 @@ func (p *Player) kill(src damage.Source) {
 	p.addHealth(-p.MaxHealth())
 	p.StopSneaking()
 	p.StopSprinting()
+	p.stats.Add(stat.Deaths, 1)
 
 	w := p.World()
 	pos := p.Position()
+	opts := p.DeathDropOptions()
 	for _, it := range append(p.inv.Items(), append(p.armour.Items(), p.offHand.Items()...)...) {
-		itemEntity := entity.NewItem(it, pos)
-		itemEntity.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1})
-		w.AddEntity(itemEntity)
+		w.AddEntity(p.throw(it, pos, opts))
 	}
 	p.inv.Clear()
 	p.armour.Clear()
 	p.offHand.Clear()
+	entity.UnleashAll(p)
 
-	for _, e := range p.Effects() {
-		p.RemoveEffect(e.Type())
-	}
+	p.RemoveAllEffects(effect.CauseDeath{})
 
-	p.handler().HandleDeath(src)
+	deathOpts := DeathOptions{}
+	p.handler().HandleDeath(src, &deathOpts)
+	p.writeAudit(p.auditDeath(src))
+	if deathOpts.Message != "" {
+		p.Message(deathOpts.Message)
+	}
+	if deathOpts.RespawnPosition != nil {
+		respawnPos := *deathOpts.RespawnPosition
+		p.setRespawnOverride(&respawnPos)
+	}
 
 	// Wait a little before removing the entity. The client displays a death animation while the player is dying.
-	time.AfterFunc(time.Millisecond*1100, func() {
-		p.DismountEntity()
-		if p.session() == session.Nop {
-			_ = p.Close()
-			return
-		}
-		if p.Dead() {
-			p.SetInvisible()
-			// We have an actual client connected to this player: We change its position server side so that in
-			// the future, the client won't respawn on the death location when disconnecting. The client should
-			// not see the movement itself yet, though.
-			p.pos.Store(w.Spawn().Vec3())
-		}
+	p.deathMu.Lock()
+	defer p.deathMu.Unlock()
+	if p.closed {
+		return
+	}
+	if p.deathTimer != nil {
+		p.deathTimer.Stop()
+	}
+	p.deathGen++
+	gen := p.deathGen
+	p.deathTimer = time.AfterFunc(time.Millisecond*1100, func() {
+		p.finishDeath(gen, w)
 	})
 }
 
+// finishDeath finishes the death of the player after its death animation was shown. Nothing happens if the
+// generation passed is no longer that of the current death timer, which is the case if the player respawned or
+// was closed in the meantime.
+func (p *Player) finishDeath(gen uint64, w *world.World) {
+	p.deathMu.Lock()
+	if p.closed || p.deathTimer == nil || p.deathGen != gen {
+		p.deathMu.Unlock()
+		return
+	}
+	p.deathTimer = nil
+	// Respawn and close wait until the death is finished, so that they cannot interleave with the changes
+	// below.
+	finishing := make(chan struct{})
+	p.finishing = finishing
+	p.deathMu.Unlock()
+
+	nop := p.session() == session.Nop
+	p.DismountEntity()
+	entity.DismountRiders(p)
+	if !nop && p.Dead() {
+		p.SetInvisible()
+		// We have an actual client connected to this player: We change its position server side so that in
+		// the future, the client won't respawn on the death location when disconnecting. The client should
+		// not see the movement itself yet, though.
+		pos := w.Spawn().Vec3()
+		if override, ok := p.respawnOverride(); ok {
+			pos = override
+		}
+		p.pos.Store(pos)
+	}
+
+	p.deathMu.Lock()
+	p.finishing = nil
+	close(finishing)
+	p.deathMu.Unlock()
+
+	if nop {
+		_ = p.Close()
+	}
+}
+
+// waitDeathFinished waits until a death that is currently being finished by finishDeath is done.
+// waitDeathFinished must be called while holding the deathMu, which is held again when it returns.
+func (p *Player) waitDeathFinished() {
+	for p.finishing != nil {
+		finishing := p.finishing
+		p.deathMu.Unlock()
+		<-finishing
+		p.deathMu.Lock()
+	}
+}
+
 // Respawn spawns the player after it dies, so that its health is replenished and it is spawned in the world
-// again. Nothing will happen if the player does not have a session connected to it.
+// again. Nothing will happen if the player does not have a session connected to it or if it was closed.
+// If the chunks around the respawn position were not yet sent to the player, they are sent first, and the client
+// only leaves the death screen once they arrive, which takes up to 5 seconds. Respawn returns before that.
 func (p *Player) Respawn() {
-	if !p.Dead() || p.World() == nil || p.session() == session.Nop {
-		return
+	p.deathMu.Lock()
+	p.waitDeathFinished()
+	ok := !p.closed && p.prepareRespawn()
+	p.deathMu.Unlock()
+	if ok {
+		p.respawn()
+	}
+}
+
+// prepareRespawn checks if the player can be respawned and, if so, marks it as respawning and stops a pending
+// death timer. A call to respawn must follow if true is returned. prepareRespawn must be called while holding
+// the deathMu.
+func (p *Player) prepareRespawn() bool {
+	if p.respawning || !p.Dead() || p.World() == nil || p.session() == session.Nop {
+		return false
+	}
+	p.respawning = true
+	if p.deathTimer != nil {
+		p.deathTimer.Stop()
+		p.deathTimer = nil
+	}
+	return true
+}
+
+// respawn respawns the player after a call to prepareRespawn returned true. respawn must be called without
+// holding the deathMu, so that handlers and the session may call back into the player.
+func (p *Player) respawn() {
+	defer func() {
+		p.deathMu.Lock()
+		p.respawning = false
+		p.deathMu.Unlock()
+	}()
+	w := p.World()
+	pos, ok := p.respawnOverride()
+	if ok {
+		// The respawn position set in HandleDeath is used only once.
+		p.setRespawnOverride(nil)
+	} else {
+		pos, w = p.respawnPosition()
 	}
-	pos := p.World().Spawn().Vec3Middle()
 	p.handler().HandleRespawn(&pos)
 	p.addHealth(p.MaxHealth())
 	p.hunger.Reset()
 	p.sendFood()
 	p.Extinguish()
 
-	p.World().AddEntity(p)
+	w.AddEntity(p)
 	p.SetVisible()
 
-	p.Teleport(pos)
-	p.session().SendRespawn()
+	ctx := event.C()
+	p.handler().HandleTeleport(ctx, pos)
+	if ctx.Cancelled() {
+		// The player stays where it died, but it must still be respawned client-side.
+		p.session().SendRespawn()
+		return
+	}
+	// The Respawn packet holds the position of the player, so it is only sent once the player was moved. This
+	// happens on a different goroutine, up to 5 seconds later, if the chunks around the position must be sent
+	// first.
+	p.teleportThen(pos, p.session().SendRespawn)
+}
+
+// spawnPosition is the spawn position of a player, such as the position of a bed or respawn anchor, together with
+// the dimension of the world that it is in.
+type spawnPosition struct {
+	pos cube.Pos
+	dim world.Dimension
+}
+
+// SetSpawnPosition sets the spawn position of the player to the block position passed, such as the position of
+// a respawn anchor, in the world with the dimension passed. When the player respawns, it will spawn at this
+// position if the block at it is still valid.
+func (p *Player) SetSpawnPosition(pos cube.Pos, dim world.Dimension) {
+	p.spawnPos.Store(&spawnPosition{pos: pos, dim: dim})
+}
+
+// SpawnPosition returns the spawn position of the player previously set using SetSpawnPosition and the
+// dimension of the world that it is in. If no spawn position was set, false is returned.
+func (p *Player) SpawnPosition() (cube.Pos, world.Dimension, bool) {
+	spawn, _ := p.spawnPos.Load().(*spawnPosition)
+	if spawn == nil {
+		return cube.Pos{}, nil, false
+	}
+	return spawn.pos, spawn.dim, true
+}
+
+// ResetSpawnPosition resets the spawn position of the player, so that it spawns at the spawn of the world it is
+// in.
+func (p *Player) ResetSpawnPosition() {
+	p.spawnPos.Store((*spawnPosition)(nil))
+}
+
+// sleepTicksToSkipNight is the amount of ticks that all players in a world need to have been sleeping for
+// before the night is skipped.
+const sleepTicksToSkipNight = 100
+
+// Sleep makes the player sleep in the bed with its head at the position passed. The spawn position of the
+// player is set to the position of the bed. Sleep does nothing if the player is already sleeping. Once all
+// players in the world are sleeping, the night is skipped and the weather is cleared.
+func (p *Player) Sleep(pos cube.Pos) {
+	if p.Dead() || p.Sleeping() {
+		return
+	}
+	ctx := event.C()
+	p.handler().HandleSleep(ctx, pos)
+	ctx.Continue(func() {
+		p.SetSpawnPosition(pos, p.World().Dimension())
+
+		p.sleepMu.Lock()
+		p.sleepPos, p.sleepTicks = &pos, 0
+		p.sleepMu.Unlock()
+
+		p.Teleport(pos.Vec3Middle().Add(mgl64.Vec3{0, 0.5625}))
+		p.updateState()
+	})
+}
+
+// Sleeping checks if the player is currently sleeping in a bed.
+func (p *Player) Sleeping() bool {
+	_, sleeping := p.BedPosition()
+	return sleeping
+}
+
+// BedPosition returns the position of the head of the bed that the player is sleeping in. If the player is
+// not sleeping, false is returned.
+func (p *Player) BedPosition() (cube.Pos, bool) {
+	p.sleepMu.Lock()
+	defer p.sleepMu.Unlock()
+	if p.sleepPos == nil {
+		return cube.Pos{}, false
+	}
+	return *p.sleepPos, true
+}
+
+// WakeUp wakes the player up if it is sleeping, moving it out of its bed. WakeUp does nothing if the player
+// is not sleeping.
+func (p *Player) WakeUp() {
+	p.sleepMu.Lock()
+	pos := p.sleepPos
+	p.sleepPos, p.sleepTicks = nil, 0
+	p.sleepMu.Unlock()
+	if pos == nil {
+		return
+	}
+	p.handler().HandleWake(*pos)
+	p.updateState()
+	if !p.Dead() {
+		p.Teleport(pos.Side(cube.FaceUp).Vec3Middle())
+	}
+}
+
+// tickSleep wakes the player up if the bed it is sleeping in was removed. If all players in the world have
+// been sleeping long enough, the night is skipped, the weather is cleared and all players are woken up.
+func (p *Player) tickSleep(w *world.World) {
+	pos, ok := p.BedPosition()
+	if !ok {
+		return
+	}
+	if _, ok := w.Block(pos).(block.Bed); !ok {
+		p.WakeUp()
+		return
+	}
+	p.sleepMu.Lock()
+	p.sleepTicks++
+	ticks := p.sleepTicks
+	p.sleepMu.Unlock()
+	if ticks < sleepTicksToSkipNight {
+		return
+	}
+
+	var sleepers []*Player
+	for _, e := range w.Entities() {
+		other, ok := e.(*Player)
+		if !ok {
+			continue
+		}
+		other.sleepMu.Lock()
+		rested := other.sleepPos != nil && other.sleepTicks >= sleepTicksToSkipNight
+		other.sleepMu.Unlock()
+		if !rested {
+			return
+		}
+		sleepers = append(sleepers, other)
+	}
+	if !w.Daytime() {
+		t := w.Time()
+		w.SetTime(t - t%24000 + 24000)
+	}
+	w.StopRaining()
+	for _, other := range sleepers {
+		other.WakeUp()
+	}
+}
+
+// respawnPosition returns the position and world that the player should respawn at. If the player has a spawn
+// position set at a charged respawn anchor, one charge of the anchor is used up and the position above it is
+// returned. If it is set at a bed, the position above the bed is returned. The world returned is the world with
+// the dimension of the spawn position, found through the portal destinations of the world the player is in. If
+// the spawn position is no longer valid, it is reset and the player is notified.
+func (p *Player) respawnPosition() (mgl64.Vec3, *world.World) {
+	w := p.World()
+	spawnPos, dim, ok := p.SpawnPosition()
+	if !ok {
+		return w.ScatteredSpawn().Vec3Middle(), w
+	}
+	if sw := spawnWorld(w, dim); sw != nil {
+		if anchor, ok := sw.Block(spawnPos).(block.RespawnAnchor); ok && anchor.Charge > 0 {
+			anchor.Deplete(spawnPos, sw)
+			return spawnPos.Side(cube.FaceUp).Vec3Middle(), sw
+		}
+		if _, ok := sw.Block(spawnPos).(block.Bed); ok {
+			return spawnPos.Side(cube.FaceUp).Vec3Middle(), sw
+		}
+	}
+	p.ResetSpawnPosition()
+	p.Message("You have no home bed or charged respawn anchor, or it was obstructed")
+	return w.ScatteredSpawn().Vec3Middle(), w
+}
+
+// spawnWorld returns the world with the dimension passed. This is either the world passed itself, or one of its
+// portal destinations. If no such world is found, spawnWorld returns nil.
+func spawnWorld(w *world.World, dim world.Dimension) *world.World {
+	if w.Dimension() == dim {
+		return w
+	}
+	nether, end := w.PortalDestinations()
+	for _, dest := range []*world.World{nether, end} {
+		if dest != nil && dest.Dimension() == dim {
+			return dest
+		}
+	}
+	return nil
 }
 
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
@@ -954,10 +1635,10 @@ func (p *Player) StopSwimming() {
 	p.updateState()
 }
 
-// StartFlying makes the player start flying if they aren't already. It requires the player to be in a gamemode which
-// allows flying.
+// StartFlying makes the player start flying if they aren't already. It requires the player to be allowed to fly,
+// either by its game mode or by SetAllowFlight.
 func (p *Player) StartFlying() {
-	if !p.GameMode().AllowsFlying() || !p.flying.CAS(false, true) {
+	if !p.AllowsFlight() || !p.flying.CAS(false, true) {
 		return
 	}
 	p.session().SendGameMode(p.GameMode())
@@ -1056,6 +1737,12 @@ func (p *Player) Inventory() *inventory.Inventory {
 	return p.inv
 }
 
+// EnderChestInventory returns the 27-slot ender chest inventory of the player. It is shown when the player
+// opens an ender chest and is saved with the player data, but may also be used without any ender chest.
+func (p *Player) EnderChestInventory() *inventory.Inventory {
+	return p.enderChest
+}
+
 // Armour returns the armour inventory of the player. This inventory yields 4 slots, for the helmet,
 // chestplate, leggings and boots respectively.
 func (p *Player) Armour() *inventory.Armour {
@@ -1080,23 +1767,81 @@ func (p *Player) SetHeldItems(mainHand, offHand item.Stack) {
 }
 
 // SetGameMode sets the game mode of a player. The game mode specifies the way that the player can interact
-// with the world that it is in.
+// with the world that it is in. Handler.HandleGameModeChange is called before the game mode is changed, which
+// may cancel the change.
+// Abilities explicitly set using methods such as SetAllowFlight and SetWorldBuilder are kept when the game mode
+// changes and continue to override the defaults of the new game mode until ResetAbilities is called.
+// Players that can no longer interact with the world stop breaking blocks and using items and have any
+// container they opened closed. Players leaving a game mode with a creative inventory have their items handled
+// according to their CreativeItemPolicy. The data of the player is saved to the Provider set using SetProvider
+// right after the change.
 func (p *Player) SetGameMode(mode world.GameMode) {
+	previous := p.GameMode()
+	changed := previous != mode
+	if changed {
+		ctx := event.C()
+		p.handler().HandleGameModeChange(ctx, previous, mode)
+		if ctx.Cancelled() {
+			return
+		}
+	}
+
 	p.gameModeMu.Lock()
-	previous := p.gameMode
 	p.gameMode = mode
 	p.gameModeMu.Unlock()
 
 	p.session().SendGameMode(mode)
+	if !changed {
+		return
+	}
+
+	if !mode.AllowsInteraction() {
+		p.AbortBreaking()
+		p.AbortItemUse()
+		p.session().CloseContainer()
+	}
+	if previous.CreativeInventory() && !mode.CreativeInventory() && p.CreativeItemPolicy() == CreativeItemsCleared {
+		p.inv.Clear()
+		p.armour.Clear()
+		p.offHand.Clear()
+	}
 
-	if !mode.AllowsFlying() {
+	if !p.AllowsFlight() {
 		p.StopFlying()
 	}
+	if mode.HasCollision() {
+		p.StopSpectating()
+	}
 	if !mode.Visible() {
 		p.SetInvisible()
+		if previous.Visible() {
+			for _, v := range p.viewers() {
+				v.HideEntity(p)
+			}
+		}
 	} else if !previous.Visible() {
 		p.SetVisible()
+		for _, v := range p.viewers() {
+			v.ViewEntity(p)
+			v.ViewEntityState(p)
+			v.ViewEntityItems(p)
+			v.ViewEntityArmour(p)
+		}
 	}
+	// The abilities of the player, such as flying, affect the way it is rendered for other players.
+	p.updateState()
+	p.save()
+}
+
+// SetOperator sets whether the player is an operator. Operators are, among other things, able to place and break
+// blocks within the spawn protection radius of a world. Players are not operators by default.
+func (p *Player) SetOperator(operator bool) {
+	p.operator.Store(operator)
+}
+
+// Operator checks if the player is an operator, as set using SetOperator.
+func (p *Player) Operator() bool {
+	return p.operator.Load()
 }
 
 // GameMode returns the current game mode assigned to the player. If not changed, the game mode returned will
@@ -1155,6 +1900,9 @@ func (p *Player) SetCooldown(item world.Item, cooldown time.Duration) {
 // unless the held item implements the item.Usable interface, in which case it will be activated.
 // This generally happens for items such as throwable items like snowballs.
 func (p *Player) UseItem() {
+	if !p.GameMode().AllowsInteraction() || p.Frozen() {
+		return
+	}
 	i, left := p.HeldItems()
 	ctx := event.C()
 	p.handler().HandleItemUse(ctx)
@@ -1171,6 +1919,23 @@ func (p *Player) UseItem() {
 		}
 
 		switch usable := it.(type) {
+		case item.Crossbow:
+			if usable.Charged() {
+				p.ReleaseItem()
+				p.shootCrossbow(i, left)
+				return
+			}
+			if !p.usingItem.CAS(false, true) {
+				// The player was already charging the crossbow, so we try to finish charging it.
+				p.ReleaseItem()
+				return
+			}
+			if _, _, ok := p.crossbowAmmunition(); !ok {
+				p.usingItem.Store(false)
+				return
+			}
+			p.usingSince.Store(time.Now().UnixNano())
+			p.updateState()
 		case item.Usable:
 			ctx := p.useContext()
 			if usable.Use(w, p, ctx) {
@@ -1190,14 +1955,22 @@ func (p *Player) UseItem() {
 			}
 			if !p.usingItem.CAS(false, true) {
 				// The player is currently using the item held. This is a signal the item was consumed, so we
-				// consume it and start using it again.
-				p.ReleaseItem()
+				// consume it and start using it again. The player keeps using the item in the meantime, so that
+				// viewers keep seeing it eat if it continues with the next item.
 
 				// Due to the network overhead and latency, the duration might sometimes be a little off. We
 				// slightly increase the duration to combat this.
 				duration := time.Duration(time.Now().UnixNano()-p.usingSince.Load()) + time.Second/20
 				if duration < usable.ConsumeDuration() {
 					// The required duration for consuming this item was not met, so we don't consume it.
+					p.ReleaseItem()
+					return
+				}
+				consumeCtx := event.C()
+				p.handler().HandleItemConsume(consumeCtx, i)
+				if consumeCtx.Cancelled() {
+					p.ReleaseItem()
+					p.session().ResendHeldItems()
 					return
 				}
 				p.SetHeldItems(p.subtractItem(i, 1), left)
@@ -1211,17 +1984,29 @@ func (p *Player) UseItem() {
 			p.updateState()
 		}
 	})
+	ctx.Stop(p.session().ResendHeldItems)
 }
 
 // ReleaseItem makes the Player release the item it is currently using. This is only applicable for items that
-// implement the item.Consumable interface.
+// implement the item.Consumable interface and crossbows.
 // If the Player is not currently using any item, ReleaseItem returns immediately.
 // ReleaseItem either aborts the using of the item or finished it, depending on the time that elapsed since
-// the item started being used.
+// the item started being used. A crossbow that was used long enough is charged when it is released. Consumable
+// items are never consumed by ReleaseItem: They are consumed through UseItem. AbortItemUse may be used to stop
+// using an item without ever finishing it.
 func (p *Player) ReleaseItem() {
 	if p.usingItem.CAS(true, false) {
 		p.updateState()
 
+		held, left := p.HeldItems()
+		if _, ok := held.Item().(item.Crossbow); ok {
+			// Due to the network overhead and latency, the duration might sometimes be a little off. We
+			// slightly increase the duration to combat this.
+			duration := time.Duration(time.Now().UnixNano()-p.usingSince.Load()) + time.Second/20
+			if duration >= crossbowChargeDuration(held) {
+				p.chargeCrossbow(held, left)
+			}
+		}
 		// TODO: Release items such as bows.
 	}
 }
@@ -1232,6 +2017,25 @@ func (p *Player) UsingItem() bool {
 	return p.usingItem.Load()
 }
 
+// UsingItemDuration returns the duration that the Player has been using the item held for, such as the time that
+// the Player has been eating. If the Player is not currently using an item, 0 is returned.
+func (p *Player) UsingItemDuration() time.Duration {
+	if !p.usingItem.Load() {
+		return 0
+	}
+	return time.Duration(time.Now().UnixNano() - p.usingSince.Load())
+}
+
+// AbortItemUse makes the Player stop using the item it is currently using, without finishing the use: Items
+// being eaten are not consumed and crossbows being charged are not charged. The animation of using the item
+// stops for all viewers. If the Player is not currently using any item, AbortItemUse returns immediately.
+func (p *Player) AbortItemUse() {
+	if p.usingItem.CAS(true, false) {
+		p.usingSince.Store(0)
+		p.updateState()
+	}
+}
+
 // UseItemOnBlock uses the item held in the main hand of the player on a block at the position passed. The
 // player is assumed to have clicked the face passed with the relative click position clickPos.
 // If the item could not be used successfully, for example when the position is out of range, the method
@@ -1245,17 +2049,22 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 	w := p.World()
 
 	ctx := event.C()
-	p.handler().HandleItemUseOnBlock(ctx, pos, face, clickPos)
+	if p.Frozen() {
+		// Frozen players can't interact with blocks: Cancel the action so that the blocks are resent.
+		ctx.Cancel()
+	} else {
+		p.handler().HandleItemUseOnBlock(ctx, pos, face, clickPos)
+	}
 
 	ctx.Continue(func() {
 		if activatable, ok := w.Block(pos).(block.Activatable); ok {
 			// If a player is sneaking, it will not activate the block clicked, unless it is not holding any
 			// items, in which case the block will be activated as usual.
 			if !p.Sneaking() || i.Empty() {
-				p.SwingArm()
 				// The block was activated: Blocks such as doors must always have precedence over the item being
 				// used.
 				if activatable.Activate(pos, face, p.World(), p) {
+					p.SwingArm()
 					return
 				}
 			}
@@ -1266,12 +2075,13 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 		if usableOnBlock, ok := i.Item().(item.UsableOnBlock); ok {
 			// The item does something when used on a block.
 			ctx := p.useContext()
+			ctx.ClickedBlock = w.Block(pos)
 			if usableOnBlock.UseOnBlock(pos, face, clickPos, p.World(), p, ctx) {
 				p.SwingArm()
 				p.SetHeldItems(p.subtractItem(p.damageItem(i, ctx.Damage), ctx.CountSub), left)
 				p.addNewItem(ctx)
 			}
-		} else if b, ok := i.Item().(world.Block); ok && p.GameMode().AllowsEditing() {
+		} else if b, ok := i.Item().(world.Block); ok && (p.WorldBuilder() || len(i.CanPlaceOn()) != 0) {
 			// The item IS a block, meaning it is being placed.
 			replacedPos := pos
 			if replaceable, ok := w.Block(pos).(block.Replaceable); !ok || !replaceable.ReplaceableBy(b) {
@@ -1279,21 +2089,17 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 				replacedPos = pos.Side(face)
 			}
 			if replaceable, ok := w.Block(replacedPos).(block.Replaceable); ok && replaceable.ReplaceableBy(b) && !replacedPos.OutOfBounds(w.Range()) {
-				if p.placeBlock(replacedPos, b, false) && !p.GameMode().CreativeInventory() {
+				if p.placeBlock(replacedPos, b, &item.UseContext{ClickedBlock: w.Block(pos)}) && !p.GameMode().CreativeInventory() {
 					p.SetHeldItems(p.subtractItem(i, 1), left)
 				}
 			}
 		}
 	})
 	ctx.Stop(func() {
-		w.SetBlock(pos, w.Block(pos))
-		w.SetBlock(pos.Side(face), w.Block(pos.Side(face)))
-		if liq, ok := w.Liquid(pos); ok {
-			w.SetLiquid(pos, liq)
-		}
-		if liq, ok := w.Liquid(pos.Side(face)); ok {
-			w.SetLiquid(pos.Side(face), liq)
-		}
+		p.session().ResendHeldItems()
+		// Centring the blocks resent on the side clicked covers both the block clicked and any block placed
+		// against it, including the other halves of multi-block structures such as doors and beds.
+		p.session().ResendBlocks(pos.Side(face), 1)
 	})
 }
 
@@ -1301,15 +2107,24 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 // within range of the player.
 // If the item held in the main hand of the player does nothing when used on an entity, nothing will happen.
 func (p *Player) UseItemOnEntity(e world.Entity) {
-	if !p.canReach(e.Position()) {
+	if !p.canReach(e.Position()) || !targetable(e) {
 		return
 	}
 	i, left := p.HeldItems()
 
 	ctx := event.C()
-	p.handler().HandleItemUseOnEntity(ctx, e)
+	if p.Frozen() {
+		ctx.Cancel()
+	} else {
+		p.handler().HandleItemUseOnEntity(ctx, e)
+	}
 
 	ctx.Continue(func() {
+		if interactable, ok := e.(interface{ Interact(p *Player) }); ok {
+			// The entity handles interaction itself, such as an NPC, so the held item isn't used on it.
+			interactable.Interact(p)
+			return
+		}
 		if usableOnEntity, ok := i.Item().(item.UsableOnEntity); ok {
 			ctx := p.useContext()
 			if usableOnEntity.UseOnEntity(e, e.World(), p, ctx) {
@@ -1319,6 +2134,7 @@ func (p *Player) UseItemOnEntity(e world.Entity) {
 			}
 		}
 	})
+	ctx.Stop(p.session().ResendHeldItems)
 }
 
 // AttackEntity uses the item held in the main hand of the player to attack the entity passed, provided it is
@@ -1327,7 +2143,7 @@ func (p *Player) UseItemOnEntity(e world.Entity) {
 // have.
 // If the player cannot reach the entity at its position, the method returns immediately.
 func (p *Player) AttackEntity(e world.Entity) {
-	if !p.canReach(e.Position()) {
+	if p.Frozen() || !p.canReach(e.Position()) || !targetable(e) {
 		return
 	}
 	i, left := p.HeldItems()
@@ -1342,6 +2158,15 @@ func (p *Player) AttackEntity(e world.Entity) {
 	p.handler().HandleAttackEntity(ctx, e, &force, &height, &critical)
 	ctx.Continue(func() {
 		p.SwingArm()
+		if knot, ok := e.(*entity.LeashKnot); ok {
+			knot.Break()
+			return
+		}
+		if attackable, ok := e.(interface{ Attack(p *Player) }); ok {
+			// The entity handles being attacked itself, such as an NPC.
+			attackable.Attack(p)
+			return
+		}
 		living, ok := e.(entity.Living)
 		if !ok {
 			return
@@ -1366,6 +2191,7 @@ func (p *Player) AttackEntity(e world.Entity) {
 		}
 
 		n, vulnerable := living.Hurt(damageDealt, damage.SourceEntityAttack{Attacker: p})
+		p.stats.Add(stat.DamageDealt, int64(math.Round(n*10)))
 		if mgl64.FloatEqual(n, 0) {
 			p.World().PlaySound(entity.EyePosition(e), sound.Attack{})
 		} else {
@@ -1400,6 +2226,9 @@ func (p *Player) AttackEntity(e world.Entity) {
 // player might be breaking before this method is called.
 func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 	p.AbortBreaking()
+	if p.Frozen() {
+		return
+	}
 	w := p.World()
 	if _, air := w.Block(pos).(block.Air); air || !p.canReach(pos.Vec3Centre()) {
 		// The block was either out of range or air, so it can't be broken by the player.
@@ -1412,44 +2241,110 @@ func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 	}
 
 	held, _ := p.HeldItems()
-	if _, ok := held.Item().(item.Sword); ok && p.GameMode().CreativeInventory() {
+	if !held.Empty() && item.HasTag(held.Item(), "minecraft:is_sword") && p.GameMode().CreativeInventory() {
 		// Can't break blocks with a sword in creative mode.
 		return
 	}
 	ctx := event.C()
 	p.handler().HandleStartBreak(ctx, pos)
 
-	// Note: We intentionally store this regardless of whether the breaking proceeds, so that we
-	// can resend the block to the client when it tries to break the block regardless.
-	p.breakingPos.Store(pos)
+	// Note: We intentionally store the state regardless of whether the breaking proceeds, so that we can
+	// resend the block to the client when it tries to break the block regardless.
+	state := &breakingState{pos: pos}
 	ctx.Continue(func() {
+		p.SwingArm()
 		if punchable, ok := w.Block(pos).(block.Punchable); ok {
-			p.SwingArm()
 			punchable.Punch(pos, face, w, p)
 		}
+		state.started = true
+
+		if p.InstantBuild() || !p.canDestroy(w.Block(pos)) {
+			return
+		}
+		breakTime, ok := p.breakTime(pos)
+		if !ok {
+			return
+		}
+		p.handler().HandleBlockBreakProgress(pos, 0, &breakTime)
+		for _, viewer := range p.viewers() {
+			viewer.ViewBlockAction(pos, blockAction.StartCrack{BreakTime: breakTime})
+		}
+		state.duration, state.lastUpdate = breakTime, time.Now()
+	})
+	p.breakingMu.Lock()
+	p.breaking = state
+	p.breakingMu.Unlock()
+}
+
+// breakingState holds the state of a block that a player is breaking.
+type breakingState struct {
+	// pos is the position of the block being broken.
+	pos cube.Pos
+	// started is false if the breaking of the block was cancelled. The state is then only kept so that the
+	// block can be resent to the client when it finishes breaking it.
+	started bool
+	// duration is the break time of the block last shown to viewers. progress is the fraction of the block
+	// broken up to lastUpdate.
+	duration   time.Duration
+	lastUpdate time.Time
+	progress   float64
+}
+
+// currentProgress returns the fraction of the block broken at the time passed, assuming the break time did
+// not change since the last update.
+func (s *breakingState) currentProgress(now time.Time) float64 {
+	if s.duration <= 0 {
+		return 1
+	}
+	return s.progress + float64(now.Sub(s.lastUpdate))/float64(s.duration)
+}
+
+// update adds the progress made on breaking the block since the last update to the total progress, using
+// the break time that applied during that period.
+func (s *breakingState) update(now time.Time) {
+	s.progress, s.lastUpdate = s.currentProgress(now), now
+}
+
+// minBreakProgress is the minimum fraction of the break time of a block that must have passed when a player
+// finishes breaking it. Some leniency is given to account for latency.
+const minBreakProgress = 0.7
 
-		p.breaking.Store(true)
+// SetBreakValidation changes if the time it takes the player to break a block is validated server-side. If
+// enabled, which it is by default, blocks that are finished breaking too quickly are sent back to the player
+// instead of being broken.
+func (p *Player) SetBreakValidation(validate bool) {
+	p.validateBreaking.Store(validate)
+}
 
-		if p.GameMode().CreativeInventory() {
-			return
-		}
+// BreakValidation checks if the time it takes the player to break a block is validated server-side.
+func (p *Player) BreakValidation() bool {
+	return p.validateBreaking.Load()
+}
 
-		p.SwingArm()
+// unbreakableTime is the break time of blocks that cannot be broken, such as bedrock.
+const unbreakableTime = time.Duration(math.MaxInt64)
 
-		breakTime := p.breakTime(pos)
-		for _, viewer := range p.viewers() {
-			viewer.ViewBlockAction(pos, blockAction.StartCrack{BreakTime: breakTime})
-		}
-		p.lastBreakDuration = breakTime
-	})
+// breakTime returns the time needed to break a block at the position passed, as changed by the HandleBreakTime
+// handler of the player. If the handler cancelled the event, the block cannot be broken by the player and false
+// is returned.
+func (p *Player) breakTime(pos cube.Pos) (time.Duration, bool) {
+	breakTime := p.baseBreakTime(pos)
+
+	ctx := event.C()
+	p.handler().HandleBreakTime(ctx, pos, &breakTime)
+	return breakTime, !ctx.Cancelled()
 }
 
-// breakTime returns the time needed to break a block at the position passed, taking into account the item
+// baseBreakTime returns the time needed to break a block at the position passed, taking into account the item
 // held, if the player is on the ground/underwater and if the player has any effects.
-func (p *Player) breakTime(pos cube.Pos) time.Duration {
+func (p *Player) baseBreakTime(pos cube.Pos) time.Duration {
 	held, _ := p.HeldItems()
 	w := p.World()
 	breakTime := block.BreakDuration(w.Block(pos), held)
+	if breakTime == unbreakableTime {
+		// Don't apply any modifiers to unbreakable blocks, as the duration would overflow.
+		return breakTime
+	}
 	if !p.OnGround() {
 		breakTime *= 5
 	}
@@ -1471,16 +2366,38 @@ func (p *Player) breakTime(pos cube.Pos) time.Duration {
 	return breakTime
 }
 
-// FinishBreaking makes the player finish breaking the block it is currently breaking, or returns immediately
-// if the player isn't breaking anything.
-// FinishBreaking will stop the animation and break the block.
-func (p *Player) FinishBreaking() {
-	pos := p.breakingPos.Load().(cube.Pos)
-	if !p.breaking.Load() {
-		w := p.World()
-		w.SetBlock(pos, w.Block(pos))
+// FinishBreaking makes the player finish breaking the block at the position passed, which must be the block it
+// is currently breaking.
+// FinishBreaking will stop the animation and break the block. If the player is not breaking the block at the
+// position passed, or if break validation is enabled and the block was finished too quickly, the block is
+// resent instead.
+func (p *Player) FinishBreaking(pos cube.Pos) {
+	p.breakingMu.Lock()
+	state := p.breaking
+	if state == nil || !state.started || state.pos != pos {
+		p.breakingMu.Unlock()
+		// The player never started breaking this block, either because the breaking was cancelled or because
+		// it was breaking a different block. The block is resent so that it reappears client-side.
+		p.AbortBreaking()
+		p.session().ResendBlocks(pos, 1)
 		return
 	}
+	if p.validateBreaking.Load() && !p.InstantBuild() {
+		state.update(time.Now())
+		if state.progress < minBreakProgress {
+			duration := state.duration
+			p.breakingMu.Unlock()
+
+			// The block was broken faster than possible: Resend the block and the crack animation.
+			p.session().ResendBlocks(pos, 1)
+			for _, viewer := range p.viewers() {
+				viewer.ViewBlockAction(pos, blockAction.ContinueCrack{BreakTime: duration})
+			}
+			return
+		}
+	}
+	p.breakingMu.Unlock()
+
 	p.AbortBreaking()
 	p.BreakBlock(pos)
 }
@@ -1489,13 +2406,17 @@ func (p *Player) FinishBreaking() {
 // if the player isn't breaking anything.
 // Unlike FinishBreaking, AbortBreaking does not stop the animation.
 func (p *Player) AbortBreaking() {
-	if !p.breaking.CAS(true, false) {
+	p.breakingMu.Lock()
+	state := p.breaking
+	p.breaking = nil
+	p.breakingMu.Unlock()
+
+	if state == nil || !state.started {
 		return
 	}
 	p.breakParticleCounter.Store(0)
-	pos := p.breakingPos.Load().(cube.Pos)
 	for _, viewer := range p.viewers() {
-		viewer.ViewBlockAction(pos, blockAction.StopCrack{})
+		viewer.ViewBlockAction(state.pos, blockAction.StopCrack{})
 	}
 }
 
@@ -1503,85 +2424,157 @@ func (p *Player) AbortBreaking() {
 // Player.StartBreaking().
 // The face passed is used to display particles on the side of the block broken.
 func (p *Player) ContinueBreaking(face cube.Face) {
-	if !p.breaking.Load() {
+	p.breakingMu.Lock()
+	state := p.breaking
+	if state == nil || !state.started {
+		p.breakingMu.Unlock()
 		return
 	}
-	pos := p.breakingPos.Load().(cube.Pos)
-
-	p.SwingArm()
+	pos, duration, progress := state.pos, state.duration, state.currentProgress(time.Now())
+	p.breakingMu.Unlock()
 
 	w := p.World()
 	b := w.Block(pos)
 	w.AddParticle(pos.Vec3(), particle.PunchBlock{Block: b, Face: face})
 
 	if p.breakParticleCounter.Add(1)%5 == 0 {
-		// We send this sound only every so often. Vanilla doesn't send it every tick while breaking
-		// either. Every 5 ticks seems accurate.
+		// We swing the arm and send this sound only every so often. Vanilla doesn't do either every tick
+		// while breaking. Every 5 ticks seems accurate.
+		p.SwingArm()
 		w.PlaySound(pos.Vec3(), sound.BlockBreaking{Block: w.Block(pos)})
 	}
-	breakTime := p.breakTime(pos)
-	if breakTime != p.lastBreakDuration {
-		for _, viewer := range p.viewers() {
-			viewer.ViewBlockAction(pos, blockAction.ContinueCrack{BreakTime: breakTime})
-		}
-		p.lastBreakDuration = breakTime
+	if p.InstantBuild() {
+		return
+	}
+	breakTime, ok := p.breakTime(pos)
+	if !ok {
+		return
+	}
+	p.handler().HandleBlockBreakProgress(pos, progress, &breakTime)
+	if breakTime == duration {
+		return
+	}
+	p.breakingMu.Lock()
+	if p.breaking != state {
+		// The player stopped breaking the block in the meantime.
+		p.breakingMu.Unlock()
+		return
+	}
+	// The break time changed, for example because the player switched items. Progress made so far is kept,
+	// while the remaining progress is made using the new break time.
+	state.update(time.Now())
+	state.duration = breakTime
+	p.breakingMu.Unlock()
+
+	for _, viewer := range p.viewers() {
+		viewer.ViewBlockAction(pos, blockAction.ContinueCrack{BreakTime: breakTime})
 	}
 }
 
 // PlaceBlock makes the player place the block passed at the position passed, granted it is within the range
 // of the player.
 // A use context may be passed to obtain information on if the block placement was successful. (SubCount will
-// be incremented). Nil may also be passed for the context parameter.
+// be incremented). Nil may also be passed for the context parameter. Players that are not world builders may only
+// place the block if the item held may be placed on the item.UseContext.ClickedBlock of the context.
 func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
-	if p.placeBlock(pos, b, ctx.IgnoreAABB) {
-		if ctx != nil {
-			ctx.CountSub++
-		}
+	useCtx := ctx
+	if useCtx == nil {
+		useCtx = &item.UseContext{}
+	}
+	if p.placeBlock(pos, b, useCtx) {
+		useCtx.CountSub++
 	}
 }
 
 // placeBlock makes the player place the block passed at the position passed, granted it is within the range
 // of the player. A bool is returned indicating if a block was placed successfully.
-func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreAABB bool) (success bool) {
+// The handler is only called once the placement is validated. If an entity obstructs the position,
+// useCtx.Obstructed is set so that the handler may set useCtx.IgnoreAABB to allow the placement anyway.
+func (p *Player) placeBlock(pos cube.Pos, b world.Block, useCtx *item.UseContext) (success bool) {
 	w := p.World()
 	defer func() {
 		if !success {
-			pos.Neighbours(func(neighbour cube.Pos) {
-				w.SetBlock(neighbour, w.Block(neighbour))
-			}, w.Range())
-			w.SetBlock(pos, w.Block(pos))
+			// The client predicted the placement and the count of the item held going down, so both are
+			// rolled back.
+			p.session().ResendHeldItems()
+			p.session().ResendBlocks(pos, 1)
 		}
 	}()
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReach(pos.Vec3Centre()) || !p.canPlace(useCtx.ClickedBlock) || p.spawnProtected(pos) {
 		return false
 	}
-	if !ignoreAABB {
-		if p.obstructedPos(pos, b) {
-			return false
-		}
-	}
+	useCtx.Obstructed = !useCtx.IgnoreAABB && p.obstructedPos(pos, b)
 
 	ctx := event.C()
-	p.handler().HandleBlockPlace(ctx, pos, b)
+	p.handler().HandleBlockPlace(ctx, pos, b, useCtx)
 	ctx.Continue(func() {
+		if useCtx.Obstructed && !useCtx.IgnoreAABB {
+			return
+		}
 		w.PlaceBlock(pos, b)
 		w.PlaySound(pos.Vec3(), sound.BlockPlace{Block: b})
 		p.SwingArm()
 		success = true
 	})
+	if success || ctx.Cancelled() {
+		name, _ := b.EncodeBlock()
+		p.writeAudit(audit.BlockPlace{Header: p.AuditHeader(ctx.Cancelled()), Position: pos, Block: name})
+	}
 	return
 }
 
+// canPlace checks if the player is allowed to place a block against the block clicked passed. This is always the
+// case if it is a world builder. Otherwise, the held item must be allowed to be placed on the block clicked using
+// item.Stack.WithCanPlaceOn. Players that are not world builders can never place blocks if no block was clicked.
+func (p *Player) canPlace(clicked world.Block) bool {
+	if p.WorldBuilder() {
+		return true
+	}
+	held, _ := p.HeldItems()
+	return clicked != nil && held.PlaceableOn(clicked)
+}
+
+// canDestroy checks if the player is allowed to break the block passed. This is always the case if it is a world
+// builder. Otherwise, the held item must be allowed to break the block using
+// item.Stack.WithCanDestroy.
+func (p *Player) canDestroy(b world.Block) bool {
+	if p.WorldBuilder() {
+		return true
+	}
+	held, _ := p.HeldItems()
+	return held.Destroys(b)
+}
+
+// spawnProtected checks if the position passed is protected from being edited by the player because it is
+// within the spawn protection radius of the world. Operators are never affected by spawn protection.
+func (p *Player) spawnProtected(pos cube.Pos) bool {
+	return !p.Operator() && p.World().SpawnProtected(pos)
+}
+
+// maxEntityExtent is the maximum distance in blocks that the AABB of an entity is expected to extend from
+// its position on any axis. It is used to find entities whose AABB might intersect with a block placed.
+const maxEntityExtent = 4.0
+
 // obstructedPos checks if the position passed is obstructed if the block passed is attempted to be placed.
 // The function returns true if there is an entity in the way that could prevent the block from being placed.
 func (p *Player) obstructedPos(pos cube.Pos, b world.Block) bool {
 	w := p.World()
 	blockBoxes := b.Model().AABB(pos, w)
+	if len(blockBoxes) == 0 {
+		// The block has no collision boxes, so no entity can obstruct it.
+		return false
+	}
+	min, max := mgl64.Vec3{math.MaxFloat64, math.MaxFloat64, math.MaxFloat64}, mgl64.Vec3{-math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64}
 	for i, box := range blockBoxes {
 		blockBoxes[i] = box.Translate(pos.Vec3())
+		for j := 0; j < 3; j++ {
+			min[j], max[j] = math.Min(min[j], blockBoxes[i].Min()[j]), math.Max(max[j], blockBoxes[i].Max()[j])
+		}
 	}
 
-	around := w.EntitiesWithin(physics.NewAABB(mgl64.Vec3{-3, -3, -3}, mgl64.Vec3{3, 3, 3}).Translate(pos.Vec3()), nil)
+	// The entities are found by their position, so we grow the union of all block boxes by the maximum extent
+	// of an entity's AABB to make sure large entities positioned further away are also found.
+	around := w.EntitiesWithin(physics.NewAABB(min, max).Grow(maxEntityExtent), nil)
 	for _, e := range around {
 		if _, ok := e.(*entity.Item); ok {
 			// Placing blocks inside item entities is fine.
@@ -1597,7 +2590,13 @@ func (p *Player) obstructedPos(pos cube.Pos, b world.Block) bool {
 // BreakBlock makes the player break a block in the world at a position passed. If the player is unable to
 // reach the block passed, the method returns immediately.
 func (p *Player) BreakBlock(pos cube.Pos) {
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReach(pos.Vec3Centre()) {
+		return
+	}
+	if held, _ := p.HeldItems(); !p.WorldBuilder() && len(held.CanDestroy()) == 0 {
+		// Players that may not edit the world can only break blocks using items that are allowed to destroy
+		// them.
+		p.session().ResendBlocks(pos, 1)
 		return
 	}
 	w := p.World()
@@ -1606,21 +2605,35 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		// Don't do anything if the position broken is already air.
 		return
 	}
-	if _, breakable := b.(block.Breakable); !breakable && !p.GameMode().CreativeInventory() {
-		// Block cannot be broken server-side. Set the block back so viewers have it resent and cancel all
-		// further action.
-		w.SetBlock(pos, w.Block(pos))
+	if !p.canDestroy(b) || p.spawnProtected(pos) {
+		p.session().ResendBlocks(pos, 1)
+		return
+	}
+	if !p.breakable(pos) {
+		// Block cannot be broken server-side. Resend the blocks around it so that the client rolls back its
+		// prediction and cancel all further action.
+		p.session().ResendBlocks(pos, 1)
 		return
 	}
 
 	ctx := event.C()
 	held, left := p.HeldItems()
 	drops := p.drops(held, b)
-	p.handler().HandleBlockBreak(ctx, pos, &drops)
+	if p.Frozen() {
+		ctx.Cancel()
+	} else {
+		p.handler().HandleBlockBreak(ctx, pos, &drops)
+	}
 
 	ctx.Continue(func() {
 		p.SwingArm()
 		w.BreakBlock(pos)
+		p.stats.Add(stat.BlocksMined, 1)
+		if f, ok := b.(block.Furnace); ok {
+			// The experience of the items smelted in the furnace that were never taken out is paid out to the
+			// player that breaks it.
+			p.AddExperience(f.DrainExperience(), experience.SourceSmelting{})
+		}
 
 		for _, drop := range drops {
 			itemEntity := entity.NewItem(drop, pos.Vec3Centre())
@@ -1637,8 +2650,23 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		}
 	})
 	ctx.Stop(func() {
-		w.SetBlock(pos, w.Block(pos))
+		p.session().ResendHeldItems()
+		p.session().ResendBlocks(pos, 1)
 	})
+	name, _ := b.EncodeBlock()
+	p.writeAudit(audit.BlockBreak{Header: p.AuditHeader(ctx.Cancelled()), Position: pos, Block: name})
+}
+
+// breakable checks if the player is able to break the block at the position passed. In creative mode, all blocks
+// may be broken. Otherwise, blocks that cannot normally be broken, such as bedrock, may only be broken if the
+// HandleBreakTime handler of the player gives them a break time, and no block may be broken if the handler
+// cancels the event.
+func (p *Player) breakable(pos cube.Pos) bool {
+	if p.GameMode().CreativeInventory() {
+		return true
+	}
+	breakTime, ok := p.breakTime(pos)
+	return ok && breakTime != unbreakableTime
 }
 
 // drops returns the drops that the player can get from the block passed using the item held.
@@ -1671,6 +2699,19 @@ func (p *Player) drops(held item.Stack, b world.Block) []item.Stack {
 // PickBlock makes the player pick a block in the world at a position passed. If the player is unable to
 // pick the block, the method returns immediately.
 func (p *Player) PickBlock(pos cube.Pos) {
+	p.pickBlock(pos, false)
+}
+
+// PickBlockWithData makes the player pick a block in the world at a position passed, including the data of the
+// block, such as the contents of a chest or the text of a sign, if it implements block.PickableWithData. The data
+// is only included if the player has a creative inventory: Otherwise, PickBlockWithData is the same as PickBlock.
+func (p *Player) PickBlockWithData(pos cube.Pos) {
+	p.pickBlock(pos, true)
+}
+
+// pickBlock makes the player pick a block in the world at a position passed, optionally including the data of
+// the block.
+func (p *Player) pickBlock(pos cube.Pos, withData bool) {
 	if !p.canReach(pos.Vec3()) {
 		return
 	}
@@ -1678,7 +2719,9 @@ func (p *Player) PickBlock(pos cube.Pos) {
 	b := p.World().Block(pos)
 
 	var pickedItem item.Stack
-	if pi, ok := b.(block.Pickable); ok {
+	if pd, ok := b.(block.PickableWithData); ok && withData && p.GameMode().CreativeInventory() {
+		pickedItem = pd.PickWithData()
+	} else if pi, ok := b.(block.Pickable); ok {
 		pickedItem = pi.Pick()
 	} else if i, ok := b.(world.Item); ok {
 		it, _ := world.ItemByName(i.EncodeItem())
@@ -1697,36 +2740,71 @@ func (p *Player) PickBlock(pos cube.Pos) {
 
 	ctx.Continue(func() {
 		_, offhand := p.HeldItems()
+		firstEmpty, emptyFound := p.Inventory().FirstEmpty()
+		// The first empty slot is only in the hotbar if there is an empty slot in the hotbar at all.
+		hotbarEmpty := emptyFound && firstEmpty < 9
 
 		if found {
-			if slot < 9 {
+			switch {
+			case slot < 9:
 				_ = p.session().SetHeldSlot(slot)
-				return
+			case hotbarEmpty:
+				_ = p.Inventory().Swap(slot, firstEmpty)
+				_ = p.session().SetHeldSlot(firstEmpty)
+			default:
+				_ = p.Inventory().Swap(slot, int(p.heldSlot.Load()))
 			}
-			_ = p.Inventory().Swap(slot, int(p.heldSlot.Load()))
 			return
 		}
-		firstEmpty, emptyFound := p.Inventory().FirstEmpty()
-
-		if !emptyFound {
-			p.SetHeldItems(pickedItem, offhand)
-		} else if firstEmpty < 8 {
+		switch {
+		case hotbarEmpty:
 			_ = p.session().SetHeldSlot(firstEmpty)
 			_ = p.Inventory().SetItem(firstEmpty, pickedItem)
-		} else {
+		case emptyFound:
+			// Move the held item out of the hotbar so that it isn't overwritten by the picked item.
 			_ = p.Inventory().Swap(firstEmpty, int(p.heldSlot.Load()))
 			p.SetHeldItems(pickedItem, offhand)
+		default:
+			p.SetHeldItems(pickedItem, offhand)
 		}
 	})
 }
 
 // Teleport teleports the player to a target position in the world. Unlike Move, it immediately changes the
-// position of the player, rather than showing an animation.
+// position of the player, rather than showing an animation. The player is dismounted from any entity it is
+// riding and its velocity and fall distance are reset.
+// If the chunks around the target position were not yet sent to the player, they are sent first, during which
+// the client cannot move the player. This does not change Immobile. The player is moved once they arrive, so
+// that it does not fall into the void. In this case, Teleport returns before the player is moved. TeleportThen
+// may be used to find out when it is.
 func (p *Player) Teleport(pos mgl64.Vec3) {
+	p.TeleportThen(pos, nil)
+}
+
+// TeleportThen teleports the player to a target position in the world like Teleport, and calls the function
+// passed once the player was moved there. If the chunks around the target position first have to be sent to
+// the player, the function is called on a different goroutine. If the teleport is cancelled by the Handler, or
+// if the player is teleported again before it was moved, the function is never called.
+func (p *Player) TeleportThen(pos mgl64.Vec3, done func()) {
 	ctx := event.C()
 	p.handler().HandleTeleport(ctx, pos)
 	ctx.Continue(func() {
+		p.teleportThen(pos, done)
+	})
+}
+
+// teleportThen teleports the player to a target position once the chunks around it were sent to the player and
+// calls the function passed, if not nil, after that. It does not call the handler of the player.
+func (p *Player) teleportThen(pos mgl64.Vec3, done func()) {
+	p.DismountEntity()
+	p.vel.Store(mgl64.Vec3{})
+	p.ResetFallDistance()
+
+	p.session().PreloadChunks(pos, func() {
 		p.teleport(pos)
+		if done != nil {
+			done()
+		}
 	})
 }
 
@@ -1737,12 +2815,17 @@ func (p *Player) teleport(pos mgl64.Vec3) {
 		v.ViewEntityTeleport(p, pos)
 	}
 	p.pos.Store(pos)
+	entity.MoveRiders(p)
 }
 
 // Move moves the player from one position to another in the world, by adding the delta passed to the current
 // position of the player.
 // Move also rotates the player, adding deltaYaw and deltaPitch to the respective values.
 func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
+	if _, spectating := p.Spectating(); spectating {
+		// The position of a spectating player is locked to that of the entity it spectates.
+		return
+	}
 	if p.Dead() || p.immobile.Load() || (deltaPos.ApproxEqual(mgl64.Vec3{}) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0)) {
 		return
 	}
@@ -1751,6 +2834,9 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	yaw, pitch := p.Rotation()
 
 	res, resYaw, resPitch := pos.Add(deltaPos), yaw+deltaYaw, pitch+deltaPitch
+	if corrected, valid := p.validMovement(pos, deltaPos); !valid && p.correctMovement(res, corrected) {
+		return
+	}
 
 	ctx := event.C()
 	p.handler().HandleMove(ctx, res, resYaw, resPitch)
@@ -1762,11 +2848,30 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		p.pos.Store(res)
 		p.yaw.Store(resYaw)
 		p.pitch.Store(resPitch)
+		entity.MoveRiders(p)
 
-		p.checkBlockCollisions()
-		p.onGround.Store(p.checkOnGround())
+		wasOnGround, onGround := p.onGround.Load(), false
+		if p.GameMode().HasCollision() {
+			p.checkBlockCollisions()
+			if onGround = p.checkOnGround(); onGround {
+				p.checkSteppedOn()
+			}
+		}
+		p.onGround.Store(onGround)
+		if onGround {
+			p.jumping.Store(false)
+			p.knockedBack.Store(false)
+		} else if wasOnGround && deltaPos[1] > 0 && !p.jumping.Load() && p.canJump() {
+			p.jump()
+		}
 
-		p.updateFallState(deltaPos[1])
+		if p.climbing() || p.floating() {
+			// Players inside a climbable block or floating due to an effect do not accumulate fall distance.
+			p.ResetFallDistance()
+		} else {
+			p.updateFallState(deltaPos[1], pos, res)
+		}
+		p.trackMovement(deltaPos, onGround)
 
 		// The vertical axis isn't relevant for calculation of exhaustion points.
 		deltaPos[1] = 0
@@ -1783,6 +2888,37 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	})
 }
 
+// canJump checks if the player leaving the ground, either by pressing the jump button or by an upward movement,
+// may be considered a jump. This is not the case if the player is immobile or frozen, was knocked back, is
+// flying, swimming, climbing or in a liquid, or if it has levitation.
+func (p *Player) canJump() bool {
+	if p.immobile.Load() || p.Frozen() || p.knockedBack.Load() || p.Flying() || p.Swimming() || p.climbing() {
+		return false
+	}
+	if _, ok := p.Effect(effect.Levitation{}); ok {
+		return false
+	}
+	_, liquid := p.World().Liquid(cube.PosFromVec3(p.Position()))
+	return !liquid
+}
+
+// jump handles the player jumping, exhausting it and calling the Handler.
+func (p *Player) jump() {
+	p.jumping.Store(true)
+	if p.Sprinting() {
+		p.Exhaust(0.2)
+	} else {
+		p.Exhaust(0.05)
+	}
+	p.handler().HandleJump()
+}
+
+// Jumping checks if the player is currently jumping. Jumping returns true from the moment the player jumps
+// until it lands on the ground again.
+func (p *Player) Jumping() bool {
+	return p.jumping.Load()
+}
+
 // Facing returns the horizontal direction that the player is facing.
 func (p *Player) Facing() cube.Direction {
 	return entity.Facing(p)
@@ -1826,7 +2962,7 @@ func (p *Player) Rotation() (float64, float64) {
 
 // Collect makes the player collect the item stack passed, adding it to the inventory.
 func (p *Player) Collect(s item.Stack) (n int) {
-	if p.Dead() {
+	if p.Dead() || !p.GameMode().AllowsInteraction() {
 		return
 	}
 	ctx := event.C()
@@ -1834,6 +2970,8 @@ func (p *Player) Collect(s item.Stack) (n int) {
 	ctx.Continue(func() {
 		n, _ = p.Inventory().AddItem(s)
 	})
+	name, _ := s.Item().EncodeItem()
+	p.writeAudit(audit.ItemPickup{Header: p.AuditHeader(ctx.Cancelled()), Item: name, Count: n})
 	return
 }
 
@@ -1843,9 +2981,16 @@ func (p *Player) Collect(s item.Stack) (n int) {
 // The number of items that was dropped in the end is returned. It is generally the count of the stack passed
 // or 0 if dropping the item.Stack was cancelled.
 func (p *Player) Drop(s item.Stack) (n int) {
-	e := entity.NewItem(s, p.Position().Add(mgl64.Vec3{0, 1.4}))
-	e.SetVelocity(entity.DirectionVector(p).Mul(0.4))
-	e.SetPickupDelay(time.Second * 2)
+	return p.DropWithOptions(s, DefaultDropOptions())
+}
+
+// DropWithOptions drops the item stack passed from the player like Drop, but throws the item entity using the
+// DropOptions passed, which may, for example, change the speed with which it is thrown or the delay before it
+// may be picked up.
+// The item entity is fully configured before Handler.HandleItemDrop is called and is only added to the world
+// after it returns, so handlers may further change its velocity, pickup delay and other properties.
+func (p *Player) DropWithOptions(s item.Stack, opts DropOptions) (n int) {
+	e := p.throw(s, p.Position().Add(mgl64.Vec3{0, 1.4 * p.collisionScale()}), opts)
 
 	ctx := event.C()
 	p.handler().HandleItemDrop(ctx, e)
@@ -1854,9 +2999,24 @@ func (p *Player) Drop(s item.Stack) (n int) {
 		p.World().AddEntity(e)
 		n = s.Count()
 	})
+	ctx.Stop(p.ResyncInventory)
+	name, _ := s.Item().EncodeItem()
+	p.writeAudit(audit.ItemDrop{Header: p.AuditHeader(ctx.Cancelled()), Item: name, Count: s.Count()})
 	return
 }
 
+// SetDeathDropOptions sets the DropOptions used to throw the items of the player when it dies. By default,
+// DefaultDeathDropOptions is used.
+func (p *Player) SetDeathDropOptions(opts DropOptions) {
+	p.deathDrops.Store(opts)
+}
+
+// DeathDropOptions returns the DropOptions used to throw the items of the player when it dies, as set using
+// SetDeathDropOptions.
+func (p *Player) DeathDropOptions() DropOptions {
+	return p.deathDrops.Load().(DropOptions)
+}
+
 // OpenBlockContainer opens a block container, such as a chest, at the position passed. If no container was
 // present at that location, OpenBlockContainer does nothing.
 // OpenBlockContainer will also do nothing if the player has no session connected to it.
@@ -1866,6 +3026,44 @@ func (p *Player) OpenBlockContainer(pos cube.Pos) {
 	}
 }
 
+// OpenEnderChest opens the ender chest inventory of the player using the ender chest at the position passed.
+func (p *Player) OpenEnderChest(pos cube.Pos) {
+	if p.session() != session.Nop {
+		p.session().OpenEnderChest(pos, p.enderChest)
+	}
+}
+
+// OpenInventoryOf opens the inventory of the target Player passed for the player, for example so that staff can
+// view or edit it. Changes made by the player are passed through the inventory.Handler of the inventory and are
+// shown live to the target. If readOnly is true, the player can only view the inventory. The inventory is closed
+// automatically when the target disconnects.
+// The context returned is cancelled once the inventory is closed, like the one returned by ShowInventory.
+// OpenInventoryOf does nothing if the player has no session connected to it.
+func (p *Player) OpenInventoryOf(target *Player, readOnly bool) context.Context {
+	return p.ShowInventory(target.Inventory(), target.Name(), readOnly)
+}
+
+// ShowInventory opens an arbitrary inventory for the player as a chest with the title passed. Inventories with
+// more than 27 slots are shown as a double chest. Changes made by the player are passed through the
+// inventory.Handler of the inventory. If readOnly is true, the player can only view the inventory.
+// The context returned is cancelled once the inventory is closed. Its Err method then returns session.ErrQuit
+// if the player disconnected while viewing the inventory, or session.ErrInventoryClosed otherwise.
+// ShowInventory does nothing if the player has no session connected to it, in which case the context returned
+// is already cancelled.
+func (p *Player) ShowInventory(inv *inventory.Inventory, title string, readOnly bool) context.Context {
+	return p.session().OpenInventory(inv, title, readOnly)
+}
+
+// OpenTrade opens the trading UI of the trade.Trader passed for the player, showing the title passed at the top
+// of the UI. The trader must be visible to the player. Trades executed by the player are validated against the
+// trade.TradeList of the trader and call Handler.HandleTrade.
+// OpenTrade does nothing if the player has no session connected to it.
+func (p *Player) OpenTrade(t trade.Trader, title string) {
+	if p.session() != session.Nop {
+		p.session().OpenTrade(t, title)
+	}
+}
+
 // HideEntity hides a world.Entity from the Player so that it can under no circumstance see it. Hidden entities can be
 // made visible again through a call to ShowEntity.
 func (p *Player) HideEntity(e world.Entity) {
@@ -1893,8 +3091,33 @@ func (p *Player) Latency() time.Duration {
 	return p.session().Latency()
 }
 
+// RateUsage returns the current usage of the session.RateLimit of the session.RateLimitedAction passed, such as the
+// amount of chat messages the player may still send in quick succession. Moderation plugins may use it to display
+// how close a player is to exceeding a limit.
+// If the Player does not have a session associated with it, RateUsage returns an empty session.RateUsage.
+func (p *Player) RateUsage(action session.RateLimitedAction) session.RateUsage {
+	return p.session().RateUsage(action)
+}
+
+// RateLimitExceeded is called by the session of the player when it exceeds a session.RateLimit with the
+// session.RateLimitEscalate mode. It calls the HandleSpamViolation method of the Handler of the player.
+func (p *Player) RateLimitExceeded(action session.RateLimitedAction, usage session.RateUsage) {
+	p.handler().HandleSpamViolation(action, usage)
+}
+
+// ClientSettingsChanged is called by the session of the player when its client changes its settings while
+// connected. It calls the HandleClientSettingsChange method of the Handler of the player. If the input mode of
+// the client changed, HandleInputModeChange is called first.
+func (p *Player) ClientSettingsChanged(before, after session.ClientSettings) {
+	if before.InputMode != after.InputMode {
+		p.handler().HandleInputModeChange(before.InputMode, after.InputMode)
+	}
+	p.handler().HandleClientSettingsChange(before, after)
+}
+
 // Tick ticks the entity, performing actions such as checking if the player is still breaking a block.
 func (p *Player) Tick(current int64) {
+	p.stats.Add(stat.PlayTime, 1)
 	if p.Dead() {
 		return
 	}
@@ -1906,21 +3129,39 @@ func (p *Player) Tick(current int64) {
 		}
 	}
 
-	p.checkBlockCollisions()
-	p.onGround.Store(p.checkOnGround())
+	if p.GameMode().HasCollision() {
+		p.checkBlockCollisions()
+		p.onGround.Store(p.checkOnGround())
+	} else {
+		p.onGround.Store(false)
+	}
+	p.followSpectated()
+	p.tickSleep(w)
+	if p.Frozen() && current%20 == 0 {
+		p.sendFrozenTip()
+	}
 
 	p.tickFood()
-	p.effects.Tick(p)
-	if p.Position()[1] < float64(p.World().Range()[0]) && p.GameMode().AllowsTakingDamage() && current%10 == 0 {
+	if expired := p.effects.Tick(p); len(expired) > 0 {
+		for _, e := range expired {
+			p.session().SendEffectRemoval(e.Type())
+			p.handler().HandleEffectExpire(e)
+			p.handler().HandleEffectRemove(e, effect.CauseExpired{})
+		}
+		p.updateState()
+	}
+	if p.Position()[1] < float64(p.World().Range()[0]) && current%10 == 0 {
 		p.Hurt(4, damage.SourceVoid{})
 	}
 
 	if p.OnFireDuration() > 0 {
 		p.fireTicks.Sub(1)
-		if !p.GameMode().AllowsTakingDamage() || p.OnFireDuration() <= 0 || p.World().RainingAt(cube.PosFromVec3(p.Position())) {
+		if !damage.AllowedByGameMode(p.GameMode(), damage.SourceFireTick{}) || p.OnFireDuration() <= 0 || p.World().RainingAt(cube.PosFromVec3(p.Position())) {
 			p.Extinguish()
 		}
 		if p.OnFireDuration()%time.Second == 0 && !p.AttackImmune() {
+			// Fire damage is dealt through Hurt, so the attack immunity set by the Handler in HandleHurt is
+			// respected and applied for fire ticks just like for any other damage.
 			p.Hurt(1, damage.SourceFireTick{})
 		}
 	}
@@ -1943,7 +3184,14 @@ func (p *Player) Tick(current int64) {
 	}
 	p.cooldownMu.Unlock()
 
-	if p.session() == session.Nop && !p.Immobile() {
+	if !p.ridingAny() {
+		// The player is at the bottom of a stack of entities, so it moves all entities in the stack to their
+		// seats. Riders in the middle of the stack are moved by the entity they are riding.
+		entity.MoveRiders(p)
+	}
+
+	if p.session() == session.Nop && !p.Immobile() && !p.ridingAny() {
+		p.mc.StepHeight = p.StepHeight()
 		m := p.mc.TickMovement(p, p.Position(), p.Velocity(), p.yaw.Load(), p.pitch.Load())
 		m.Send()
 
@@ -2020,6 +3268,37 @@ func (p *Player) checkBlockCollisions() {
 	}
 }
 
+// climbing checks if the player is currently inside a block.Climbable, such as a ladder or vines.
+func (p *Player) climbing() bool {
+	if !p.GameMode().HasCollision() {
+		return false
+	}
+	_, ok := p.World().Block(cube.PosFromVec3(p.Position())).(block.Climbable)
+	return ok
+}
+
+// blockBelow returns the block that the player is standing on and its position. This is the block at the feet of
+// the player if it has a collision box, such as a slab, or the block below it otherwise.
+func (p *Player) blockBelow() (cube.Pos, world.Block) {
+	w := p.World()
+	pos := cube.PosFromVec3(p.Position())
+	b := w.Block(pos)
+	if len(b.Model().AABB(pos, w)) == 0 {
+		pos = pos.Side(cube.FaceDown)
+		b = w.Block(pos)
+	}
+	return pos, b
+}
+
+// checkSteppedOn calls the SteppedOn method of the block that the player is standing on if it implements
+// block.EntityStepper.
+func (p *Player) checkSteppedOn() {
+	pos, b := p.blockBelow()
+	if s, ok := b.(block.EntityStepper); ok {
+		s.SteppedOn(pos, p.World(), p)
+	}
+}
+
 // checkOnGround checks if the player is currently considered to be on the ground.
 func (p *Player) checkOnGround() bool {
 	w := p.World()
@@ -2044,9 +3323,10 @@ func (p *Player) checkOnGround() bool {
 	return false
 }
 
-// AABB returns the axis aligned bounding box of the player.
+// AABB returns the axis aligned bounding box of the player. The box is scaled by the scale of the player, which
+// is clamped to the range minCollisionScale-maxCollisionScale for this purpose.
 func (p *Player) AABB() physics.AABB {
-	s := p.Scale()
+	s := p.collisionScale()
 	switch {
 	case p.Sneaking():
 		return physics.NewAABB(mgl64.Vec3{-0.3 * s, 0, -0.3 * s}, mgl64.Vec3{0.3 * s, 1.65 * s, 0.3 * s})
@@ -2065,11 +3345,27 @@ func (p *Player) Scale() float64 {
 
 // SetScale changes the scale modifier of the Player. The default value for a normal scale is 1. A scale of 0
 // will make the Player completely invisible.
+// The AABB, eye height and reach of the Player scale along with it, although scales below minCollisionScale or
+// above maxCollisionScale are clamped for these purposes, so that collisions do not degenerate at extreme
+// scales.
 func (p *Player) SetScale(s float64) {
 	p.scale.Store(s)
 	p.updateState()
 }
 
+const (
+	// minCollisionScale is the smallest scale that the AABB, eye height and reach of a player are scaled with.
+	// Players with a smaller scale, such as 0, keep a collision box of this scale.
+	minCollisionScale = 0.05
+	// maxCollisionScale is the largest scale that the AABB, eye height and reach of a player are scaled with.
+	maxCollisionScale = 20
+)
+
+// collisionScale returns the scale of the player clamped to the range minCollisionScale-maxCollisionScale.
+func (p *Player) collisionScale() float64 {
+	return math.Max(minCollisionScale, math.Min(p.Scale(), maxCollisionScale))
+}
+
 // OnGround checks if the player is considered to be on the ground.
 func (p *Player) OnGround() bool {
 	if p.session() == session.Nop {
@@ -2078,12 +3374,13 @@ func (p *Player) OnGround() bool {
 	return p.onGround.Load()
 }
 
-// EyeHeight returns the eye height of the player: 1.62, or 0.52 if the player is swimming.
+// EyeHeight returns the eye height of the player: 1.62, or 0.52 if the player is swimming, multiplied by the scale
+// of the player.
 func (p *Player) EyeHeight() float64 {
 	if p.swimming.Load() {
-		return 0.52
+		return 0.52 * p.collisionScale()
 	}
-	return 1.62
+	return 1.62 * p.collisionScale()
 }
 
 // PlaySound plays a world.Sound that only this Player can hear. Unlike World.PlaySound, it is not broadcast
@@ -2109,11 +3406,53 @@ func (p *Player) EditSign(pos cube.Pos, text string) error {
 	p.handler().HandleSignEdit(ctx, sign.Text, text)
 	ctx.Continue(func() {
 		sign.Text = text
+		w.SetBlock(pos, sign)
+	})
+	ctx.Stop(func() {
+		p.session().ResendBlocks(pos, 0)
 	})
-	w.SetBlock(pos, sign)
 	return nil
 }
 
+// UseGrindstone takes the result of the grindstone at the position passed out of it. Handler.HandleGrindstoneUse
+// is called with the result and the experience refunded for the enchantments removed from the items put in the
+// grindstone. The result to be handed to the player is returned, along with false if the event was cancelled.
+func (p *Player) UseGrindstone(pos cube.Pos, result item.Stack, xp int) (item.Stack, bool) {
+	ctx := event.C()
+	p.handler().HandleGrindstoneUse(ctx, pos, &result, xp)
+	if ctx.Cancelled() {
+		return item.Stack{}, false
+	}
+	p.AddExperience(xp, experience.SourceGrindstone{})
+	p.World().PlaySound(pos.Vec3Centre(), sound.GrindstoneUse{})
+	return result, true
+}
+
+// UseSmithingTable upgrades the input item passed using the material passed in the smithing table at the
+// position passed. Handler.HandleSmithing is called with the inputs and the result of the upgrade. The result to
+// be handed to the player is returned, along with false if the event was cancelled.
+func (p *Player) UseSmithingTable(pos cube.Pos, input, material, result item.Stack) (item.Stack, bool) {
+	ctx := event.C()
+	p.handler().HandleSmithing(ctx, input, material, &result)
+	if ctx.Cancelled() {
+		return item.Stack{}, false
+	}
+	p.World().PlaySound(pos.Vec3Centre(), sound.SmithingTableUse{})
+	return result, true
+}
+
+// TradeWith executes the trade passed, offered by the trade.Trader passed. Handler.HandleTrade is called with
+// the trade, and false is returned if the event was cancelled.
+func (p *Player) TradeWith(t trade.Trader, tr trade.Trade) bool {
+	ctx := event.C()
+	p.handler().HandleTrade(ctx, t, tr)
+	if ctx.Cancelled() {
+		return false
+	}
+	p.AddExperience(tr.Experience, experience.SourceTrading{})
+	return true
+}
+
 // updateState updates the state of the player to all viewers of the player.
 func (p *Player) updateState() {
 	for _, v := range p.viewers() {
@@ -2131,11 +3470,19 @@ func (p *Player) Breathing() bool {
 	return !p.GameMode().AllowsTakingDamage() || !submerged || breathing || conduitPower
 }
 
-// SwingArm makes the player swing its arm.
+// swingInterval is the minimum time between two arm swings of a player. Swings that follow each other more
+// quickly, for example because a single interaction resulted in multiple swings, are not shown to viewers.
+const swingInterval = time.Second / 20
+
+// SwingArm makes the player swing its arm. Swings less than a tick apart from the previous one are ignored.
 func (p *Player) SwingArm() {
 	if p.Dead() {
 		return
 	}
+	now := time.Now().UnixNano()
+	if last := p.lastSwing.Load(); now-last < int64(swingInterval) || !p.lastSwing.CAS(last, now) {
+		return
+	}
 	for _, v := range p.viewers() {
 		v.ViewEntityAction(p, action.SwingArm{})
 	}
@@ -2154,12 +3501,24 @@ func (p *Player) PunchAir() {
 	})
 }
 
-// MountEntity mounts the player to an entity if the entity is rideable and if there is a seat available.
+// MountEntity mounts the player to an entity if the entity is rideable and if there is a seat available. The
+// player is first dismounted from any other entity it is riding. Entities that are riding the player, either
+// directly or through other entities, cannot be mounted.
 func (p *Player) MountEntity(r entity.Rideable) {
+	if entity.Riding(r, p) {
+		return
+	}
 	ctx := event.C()
 	p.handler().HandleMount(ctx, r)
 	ctx.Continue(func() {
 		if p.seat(r) == -1 {
+			if current, _ := p.RidingEntity(); current != nil {
+				p.DismountEntity()
+				if current, _ = p.RidingEntity(); current != nil {
+					// Dismounting the entity currently ridden was cancelled.
+					return
+				}
+			}
 			r.AddRider(p)
 			p.setRiding(r)
 			riders := r.Riders()
@@ -2186,7 +3545,7 @@ func (p *Player) DismountEntity() {
 	if e != nil {
 		p.handler().HandleDismount(ctx)
 		ctx.Stop(func() {
-			p.s.ViewEntityMount(p, e, seat-1 == 0)
+			p.session().ViewEntityMount(p, e, seat == 0)
 		})
 		ctx.Continue(func() {
 			e.RemoveRider(p)
@@ -2195,7 +3554,9 @@ func (p *Player) DismountEntity() {
 				v.ViewEntityDismount(p, e)
 			}
 			for _, r := range e.Riders() {
-				r.MountEntity(e)
+				if rider, ok := r.(entity.Rider); ok {
+					rider.MountEntity(e)
+				}
 			}
 		})
 	}
@@ -2218,6 +3579,58 @@ func (p *Player) checkSeats(e entity.Rideable) {
 	}
 }
 
+// MoveToSeat moves the player to the position passed, which is the position of its seat on the entity that it
+// is riding. MoveToSeat has no effect if the player is not riding an entity.
+func (p *Player) MoveToSeat(pos mgl64.Vec3) {
+	if e, _ := p.RidingEntity(); e == nil || p.Position() == pos {
+		return
+	}
+	yaw, pitch := p.Rotation()
+	for _, v := range p.viewers() {
+		v.ViewEntityMovement(p, pos, yaw, pitch, false)
+	}
+	p.pos.Store(pos)
+	p.ResetFallDistance()
+	entity.MoveRiders(p)
+}
+
+// SeatPositions returns the position of the single seat that entities riding the player take, which is on top of
+// its head. Players may carry other entities, such as pets, but are never steered by them.
+func (p *Player) SeatPositions() []mgl32.Vec3 {
+	return []mgl32.Vec3{{0, float32(p.AABB().Height()), 0}}
+}
+
+// Riders returns the entities riding the player, in the order that they started riding it.
+func (p *Player) Riders() []world.Entity {
+	p.ridersMu.Lock()
+	defer p.ridersMu.Unlock()
+	return append([]world.Entity(nil), p.riders...)
+}
+
+// AddRider adds an entity riding the player. AddRider is called by the entity when it mounts the player, using
+// entity.Rider.MountEntity, which should be used to make entities ride the player.
+func (p *Player) AddRider(e world.Entity) {
+	p.ridersMu.Lock()
+	defer p.ridersMu.Unlock()
+	p.riders = append(p.riders, e)
+}
+
+// RemoveRider removes an entity riding the player. RemoveRider is called by the entity when it dismounts the
+// player, using entity.Rider.DismountEntity.
+func (p *Player) RemoveRider(e world.Entity) {
+	p.ridersMu.Lock()
+	defer p.ridersMu.Unlock()
+	for i, r := range p.riders {
+		if r == e {
+			p.riders = append(p.riders[:i], p.riders[i+1:]...)
+			return
+		}
+	}
+}
+
+// Steer does nothing: Players cannot be steered by the entities riding them.
+func (p *Player) Steer(mgl64.Vec2, float32, float32) {}
+
 // SeatPosition returns the position of the player's seat.
 func (p *Player) SeatPosition() mgl32.Vec3 {
 	return p.seatPosition.Load().(mgl32.Vec3)
@@ -2241,6 +3654,13 @@ func (p *Player) setRiding(e entity.Rideable) {
 	p.ridingMu.Unlock()
 }
 
+// ridingAny checks if the player is currently riding an entity.
+func (p *Player) ridingAny() bool {
+	p.ridingMu.Lock()
+	defer p.ridingMu.Unlock()
+	return p.riding != nil
+}
+
 // RidingEntity returns the entity the player is currently riding and the player's seat index.
 func (p *Player) RidingEntity() (entity.Rideable, int) {
 	p.ridingMu.Lock()
@@ -2267,6 +3687,16 @@ func (p *Player) EncodeEntity() string {
 // player with a custom message.
 func (p *Player) Close() error {
 	if p.World() == nil {
+		// The player was already removed from its world, for example because its connection was closed. A
+		// pending death timer must no longer touch the player.
+		p.deathMu.Lock()
+		p.waitDeathFinished()
+		p.closed = true
+		if p.deathTimer != nil {
+			p.deathTimer.Stop()
+			p.deathTimer = nil
+		}
+		p.deathMu.Unlock()
 		return nil
 	}
 	p.session().Disconnect("Connection closed.")
@@ -2322,8 +3752,8 @@ func (p *Player) addNewItem(ctx *item.UseContext) {
 	}
 }
 
-// canReach checks if a player can reach a position with its current range. The range depends on if the player
-// is either survival or creative mode.
+// canReach checks if a player can reach a position with its current range. The range is extended if the player
+// has the instant build ability, which players in creative mode have by default.
 func (p *Player) canReach(pos mgl64.Vec3) bool {
 	const (
 		creativeRange = 13.0
@@ -2333,22 +3763,54 @@ func (p *Player) canReach(pos mgl64.Vec3) bool {
 		return false
 	}
 	eyes := entity.EyePosition(p)
+	// Players scaled up have their eyes further away from the blocks around them, so their reach grows along
+	// with their scale. Players scaled down keep the regular reach.
+	s := math.Max(p.collisionScale(), 1)
 
-	if p.GameMode().CreativeInventory() {
-		return world.Distance(eyes, pos) <= creativeRange && !p.Dead()
+	if p.InstantBuild() {
+		return world.Distance(eyes, pos) <= creativeRange*s && !p.Dead()
 	}
-	return world.Distance(eyes, pos) <= survivalRange && !p.Dead()
+	return world.Distance(eyes, pos) <= survivalRange*s && !p.Dead()
+}
+
+// targetable checks if the entity passed may be attacked or interacted with by a player. Players in a game mode
+// without collision, such as spectator mode, cannot be targeted.
+func targetable(e world.Entity) bool {
+	if t, ok := e.(*Player); ok {
+		return t.GameMode().HasCollision()
+	}
+	return true
 }
 
 // close closes the player without disconnecting it. It executes code shared by both the closing and the
 // disconnecting of players.
 func (p *Player) close() {
-	// If the player is being disconnected while they are dead, we respawn the player
-	// so that the player logic works correctly the next time they join.
-	if p.Dead() {
-		p.Respawn()
+	p.deathMu.Lock()
+	p.waitDeathFinished()
+	if p.closed {
+		p.deathMu.Unlock()
+		return
+	}
+	// If the player is being disconnected while they are dead, we respawn the player so that the player logic
+	// works correctly the next time they join.
+	respawn := p.prepareRespawn()
+	if p.deathTimer != nil {
+		p.deathTimer.Stop()
+		p.deathTimer = nil
+	}
+	p.closed = true
+	p.deathMu.Unlock()
+
+	if respawn {
+		p.respawn()
 	}
+
 	p.DismountEntity()
+	entity.DismountRiders(p)
+
+	// Forms and containers that are still open are closed first, so that any callbacks waiting on them run
+	// before HandleQuit is called.
+	p.session().CloseUIOnQuit()
 
 	p.hMutex.Lock()
 	h := p.h
@@ -2363,14 +3825,18 @@ func (p *Player) close() {
 	p.s = nil
 	p.sMutex.Unlock()
 
-	// Clear the inventories so that they no longer hold references to the connection.
+	// Close the inventories for any players viewing them using OpenInventoryOf or ShowInventory, and clear them
+	// so that they no longer hold references to the connection.
+	session.CloseViewers(p.inv, p.offHand, p.armour.Inventory(), p.enderChest)
 	_ = p.inv.Close()
 	_ = p.offHand.Close()
 	_ = p.armour.Close()
+	_ = p.enderChest.Close()
 
 	if p.World() == nil {
 		return
 	}
+	entity.UnleashAll(p)
 
 	if s == nil {
 		p.World().RemoveEntity(p)
@@ -2392,12 +3858,20 @@ func (p *Player) load(data Data) {
 	p.hunger.foodTick = data.FoodTick
 	p.hunger.exhaustionLevel, p.hunger.saturationLevel = data.ExhaustionLevel, data.SaturationLevel
 
+	p.experience.Add(experienceForProgress(data.XPLevel, data.XPPercentage))
+
 	p.gameMode = data.GameMode
 	for _, potion := range data.Effects {
 		p.AddEffect(potion)
 	}
 	p.fireTicks.Store(data.FireTicks)
 	p.fallDistance.Store(data.FallDistance)
+	if data.FlightSpeed > 0 {
+		// Data saved before the flight speed was stored has a zero flight speed, for which the default is kept.
+		p.flightSpeed.Store(data.FlightSpeed)
+	}
+
+	p.stats.Load(data.Statistics)
 
 	p.loadInventory(data.Inventory)
 }
@@ -2412,6 +3886,9 @@ func (p *Player) loadInventory(data InventoryData) {
 	p.Armour().SetLeggings(data.Leggings)
 	p.Armour().SetChestplate(data.Chestplate)
 	p.Armour().SetHelmet(data.Helmet)
+	for slot, stack := range data.EnderChestItems {
+		_ = p.enderChest.SetItem(slot, stack)
+	}
 }
 
 // Data returns the player data that needs to be saved. This is used when the player
@@ -2420,35 +3897,53 @@ func (p *Player) Data() Data {
 	yaw, pitch := p.Rotation()
 	offHand, _ := p.offHand.Item(0)
 
+	pos, health := p.Position(), p.Health()
+	if p.Dead() && p.World() != nil {
+		// The player is saved while dead, for example because it disconnected on the death screen. It is saved
+		// as if it respawned, so that it does not join dead at its death location.
+		pos, health = p.World().Spawn().Vec3Middle(), p.MaxHealth()
+		if override, ok := p.respawnOverride(); ok {
+			pos = override
+		}
+	}
+
+	level, progress := p.experience.Level()
+
 	p.hunger.mu.RLock()
 	defer p.hunger.mu.RUnlock()
 
 	return Data{
 		UUID:            p.UUID(),
 		Username:        p.Name(),
-		Position:        p.Position(),
+		Position:        pos,
 		Velocity:        mgl64.Vec3{},
 		Yaw:             yaw,
 		Pitch:           pitch,
-		Health:          p.Health(),
+		Health:          health,
 		MaxHealth:       p.MaxHealth(),
 		Hunger:          p.hunger.foodLevel,
 		FoodTick:        p.hunger.foodTick,
 		ExhaustionLevel: p.hunger.exhaustionLevel,
 		SaturationLevel: p.hunger.saturationLevel,
+		XPLevel:         level,
+		XPTotal:         p.experience.Experience(),
+		XPPercentage:    progress,
 		GameMode:        p.GameMode(),
 		Inventory: InventoryData{
-			Items:        p.Inventory().Slots(),
-			Boots:        p.armour.Boots(),
-			Leggings:     p.armour.Leggings(),
-			Chestplate:   p.armour.Chestplate(),
-			Helmet:       p.armour.Helmet(),
-			OffHand:      offHand,
-			MainHandSlot: p.heldSlot.Load(),
+			Items:           p.Inventory().Slots(),
+			Boots:           p.armour.Boots(),
+			Leggings:        p.armour.Leggings(),
+			Chestplate:      p.armour.Chestplate(),
+			Helmet:          p.armour.Helmet(),
+			OffHand:         offHand,
+			MainHandSlot:    p.heldSlot.Load(),
+			EnderChestItems: p.enderChest.Slots(),
 		},
 		Effects:      p.Effects(),
 		FireTicks:    p.fireTicks.Load(),
 		FallDistance: p.fallDistance.Load(),
+		FlightSpeed:  p.flightSpeed.Load(),
+		Statistics:   p.stats.Values(),
 		Dimension:    p.World().Dimension().EncodeDimension(),
 	}
 }
@@ -2468,33 +3963,25 @@ func (p *Player) session() *session.Session {
 
 // useContext returns an item.UseContext initialised for a Player.
 func (p *Player) useContext() *item.UseContext {
-	call := func(ctx *event.Context, slot int, it item.Stack, f func(ctx *event.Context, slot int, it item.Stack)) error {
-		var err error
-		ctx.Stop(func() {
-			err = fmt.Errorf("action was cancelled")
-		})
-		ctx.Continue(func() {
-			f(ctx, slot, it)
-			ctx.Stop(func() {
-				err = fmt.Errorf("action was cancelled")
-			})
-		})
-		return err
-	}
-	return &item.UseContext{SwapHeldWithArmour: func(i int) {
-		src, dst, srcInv, dstInv := int(p.heldSlot.Load()), i, p.inv, p.armour.Inventory()
-		srcIt, _ := srcInv.Item(src)
-		dstIt, _ := dstInv.Item(dst)
+	return &item.UseContext{SwapHeldWithArmour: p.equipHeld}
+}
 
-		ctx := event.C()
-		_ = call(ctx, src, srcIt, srcInv.Handler().HandleTake)
-		_ = call(ctx, src, dstIt, srcInv.Handler().HandlePlace)
-		_ = call(ctx, dst, dstIt, dstInv.Handler().HandleTake)
-		if err := call(ctx, dst, srcIt, dstInv.Handler().HandlePlace); err == nil {
-			_ = srcInv.SetItem(src, dstIt)
-			_ = dstInv.SetItem(dst, srcIt)
-		}
-	}}
+// equipHeld equips the item held in the main hand of the player in the armour slot passed, swapping it with the
+// armour currently worn in that slot. Armour bound by the Curse of Binding is not swapped out unless the player
+// has a creative inventory. The handlers of the inventories involved may cancel the swap, in which case the
+// inventories are resent to the client.
+func (p *Player) equipHeld(slot int) {
+	worn, _ := p.armour.Inventory().Item(slot)
+	if _, bound := worn.Enchantment(enchantment.CurseOfBinding{}); bound && !p.GameMode().CreativeInventory() {
+		p.ResyncInventory()
+		return
+	}
+	held, _ := p.HeldItems()
+	if err := p.armour.SwapWith(p.inv, int(p.heldSlot.Load()), slot); err != nil {
+		p.ResyncInventory()
+		return
+	}
+	p.World().PlaySound(p.Position(), sound.EquipItem{Item: held.Item()})
 }
 
 // handler returns the Handler of the player.
@@ -1,11 +1,9 @@
 package playerdb
 
 import (
-	"bytes"
-	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/itemcodec"
 	"github.com/df-mc/dragonfly/server/player"
-	"github.com/sandertv/gophertunnel/minecraft/nbt"
 )
 
 func invToData(data player.InventoryData) jsonInventoryData {
@@ -27,18 +25,32 @@ func invToData(data player.InventoryData) jsonInventoryData {
 	d.Leggings = encodeItem(data.Leggings)
 	d.Chestplate = encodeItem(data.Chestplate)
 	d.Helmet = encodeItem(data.Helmet)
+	for slot, i := range data.EnderChestItems {
+		itemData := encodeItem(i)
+		if itemData == nil {
+			continue
+		}
+		d.EnderChestItems = append(d.EnderChestItems, jsonSlot{
+			Slot: slot,
+			Item: itemData,
+		})
+	}
 	return d
 }
 
 func dataToInv(data jsonInventoryData) player.InventoryData {
 	d := player.InventoryData{
-		MainHandSlot: data.MainHandSlot,
-		OffHand:      decodeItem(data.OffHand),
-		Items:        make([]item.Stack, 36),
+		MainHandSlot:    data.MainHandSlot,
+		OffHand:         decodeItem(data.OffHand),
+		Items:           make([]item.Stack, 36),
+		EnderChestItems: make([]item.Stack, 27),
 	}
 	for _, i := range data.Items {
 		d.Items[i.Slot] = decodeItem(i.Item)
 	}
+	for _, i := range data.EnderChestItems {
+		d.EnderChestItems[i.Slot] = decodeItem(i.Item)
+	}
 	d.Boots = decodeItem(data.Boots)
 	d.Leggings = decodeItem(data.Leggings)
 	d.Chestplate = decodeItem(data.Chestplate)
@@ -47,26 +59,14 @@ func dataToInv(data jsonInventoryData) player.InventoryData {
 }
 
 func encodeItem(item item.Stack) []byte {
-	if item.Empty() {
-		return nil
-	}
-
-	var b bytes.Buffer
-	itemNBT := nbtconv.WriteItem(item, true)
-	encoder := nbt.NewEncoderWithEncoding(&b, nbt.LittleEndian)
-	err := encoder.Encode(itemNBT)
+	b, err := itemcodec.MarshalStack(item)
 	if err != nil {
 		return nil
 	}
-	return b.Bytes()
+	return b
 }
 
 func decodeItem(data []byte) item.Stack {
-	var itemNBT map[string]interface{}
-	decoder := nbt.NewDecoderWithEncoding(bytes.NewBuffer(data), nbt.LittleEndian)
-	err := decoder.Decode(&itemNBT)
-	if err != nil {
-		return item.Stack{}
-	}
-	return nbtconv.ReadItem(itemNBT, nil)
+	s, _ := itemcodec.UnmarshalStack(data)
+	return s
 }
@@ -1,6 +1,7 @@
 package playerdb
 
 import (
+	"github.com/df-mc/dragonfly/server/item/itemcodec"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
@@ -29,6 +30,8 @@ func fromJson(d jsonData) player.Data {
 		Effects:         dataToEffects(d.Effects),
 		FireTicks:       d.FireTicks,
 		FallDistance:    d.FallDistance,
+		FlightSpeed:     d.FlightSpeed,
+		Statistics:      d.Statistics,
 		Inventory:       dataToInv(d.Inventory),
 		Dimension:       d.Dimension,
 	}
@@ -56,6 +59,8 @@ func toJson(d player.Data) jsonData {
 		Effects:         effectsToData(d.Effects),
 		FireTicks:       d.FireTicks,
 		FallDistance:    d.FallDistance,
+		FlightSpeed:     d.FlightSpeed,
+		Statistics:      d.Statistics,
 		Inventory:       invToData(d.Inventory),
 		Dimension:       d.Dimension,
 	}
@@ -78,23 +83,23 @@ type jsonData struct {
 	Effects                          []jsonEffect
 	FireTicks                        int64
 	FallDistance                     float64
+	FlightSpeed                      float64
+	Statistics                       map[string]int64
 	Dimension                        int
 }
 
 type jsonInventoryData struct {
-	Items        []jsonSlot
-	Boots        []byte
-	Leggings     []byte
-	Chestplate   []byte
-	Helmet       []byte
-	OffHand      []byte
-	MainHandSlot uint32
+	Items           []jsonSlot
+	EnderChestItems []jsonSlot
+	Boots           []byte
+	Leggings        []byte
+	Chestplate      []byte
+	Helmet          []byte
+	OffHand         []byte
+	MainHandSlot    uint32
 }
 
-type jsonSlot struct {
-	Item []byte
-	Slot int
-}
+type jsonSlot = itemcodec.Slot
 
 type jsonEffect struct {
 	ID       int
@@ -0,0 +1,182 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/action"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+	"testing"
+	"time"
+)
+
+// uiRecorder records the callbacks of the UIs opened by a player and its HandleQuit call, in the order in which
+// they are called.
+type uiRecorder struct {
+	player.NopHandler
+
+	mu     sync.Mutex
+	events []string
+	// atQuit is called when HandleQuit is called, so that the state of the UIs may be checked at that moment.
+	atQuit func() []string
+}
+
+// record records the event passed.
+func (r *uiRecorder) record(events ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+}
+
+// Events returns the events recorded so far.
+func (r *uiRecorder) Events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// HandleQuit ...
+func (r *uiRecorder) HandleQuit() {
+	if r.atQuit != nil {
+		r.record(r.atQuit()...)
+	}
+	r.record("quit")
+}
+
+// menuCloser is a form.MenuSubmittable that records being closed.
+type menuCloser struct {
+	rec *uiRecorder
+}
+
+// Submit ...
+func (m menuCloser) Submit(form.Submitter, form.Button) {}
+
+// Close ...
+func (m menuCloser) Close(form.Submitter) { m.rec.record("menu") }
+
+// modalCloser is a form.ModalSubmittable that records being closed.
+type modalCloser struct {
+	Yes, No form.Button
+	rec     *uiRecorder
+}
+
+// Submit ...
+func (m modalCloser) Submit(form.Submitter, form.Button) {}
+
+// Close ...
+func (m modalCloser) Close(form.Submitter) { m.rec.record("modal") }
+
+// customCloser is a form.Submittable that records being closed.
+type customCloser struct {
+	rec *uiRecorder
+}
+
+// Submit ...
+func (c customCloser) Submit(form.Submitter) {}
+
+// Close ...
+func (c customCloser) Close(form.Submitter) { c.rec.record("custom") }
+
+// TestQuitClosesUI checks that the forms and container that a player has open when it quits are closed exactly
+// once, before HandleQuit is called, both when the server closes the player and when the client disconnects.
+func TestQuitClosesUI(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		// container opens a container for the player and returns the events expected to be recorded before
+		// HandleQuit is called when it is closed.
+		container func(w *servertest.World, p *player.Player, rec *uiRecorder) []string
+		quit      func(p *player.Player, conn *servertest.Conn)
+	}{
+		{name: "chest, server close", container: openChest, quit: func(p *player.Player, _ *servertest.Conn) { _ = p.Close() }},
+		{name: "chest, client disconnect", container: openChest, quit: func(_ *player.Player, conn *servertest.Conn) { _ = conn.Close() }},
+		{name: "inventory, server close", container: openInventory, quit: func(p *player.Player, _ *servertest.Conn) { _ = p.Close() }},
+		{name: "inventory, client disconnect", container: openInventory, quit: func(_ *player.Player, conn *servertest.Conn) { _ = conn.Close() }},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			w := servertest.NewWorld()
+			p, conn := w.NewSessionPlayer("quitter", mgl64.Vec3{0.5, 6, 2.5})
+			rec := &uiRecorder{}
+			p.Handle(rec)
+
+			p.SendForm(form.NewMenu(menuCloser{rec: rec}, "menu"))
+			p.SendForm(form.NewModal(modalCloser{Yes: form.YesButton(), No: form.NoButton(), rec: rec}, "modal"))
+			p.SendForm(form.New(customCloser{rec: rec}, "custom"))
+			want := append([]string{"menu", "modal", "custom"}, c.container(w, p, rec)...)
+			want = append(want, "quit")
+
+			c.quit(p, conn)
+			// Closing the world waits for the session of the player to be stopped, after which any UI closed a
+			// second time has been recorded.
+			closed := make(chan struct{})
+			go func() {
+				_ = w.Close()
+				close(closed)
+			}()
+			select {
+			case <-closed:
+			case <-time.After(time.Second * 5):
+				t.Fatalf("session was not stopped after quitting, events %v recorded", rec.Events())
+			}
+			got := rec.Events()
+			if len(got) != len(want) {
+				t.Fatalf("events %v recorded, want %v in any order with quit last", got, want)
+			}
+			counts := map[string]int{}
+			for _, e := range got {
+				counts[e]++
+			}
+			for _, e := range want {
+				if counts[e] != 1 {
+					t.Errorf("event %q recorded %v times, want once: %v", e, counts[e], got)
+				}
+			}
+			if got[len(got)-1] != "quit" {
+				t.Errorf("events %v recorded, want quit last", got)
+			}
+		})
+	}
+}
+
+// openChest opens a chest for the player passed. When HandleQuit is called, the chest must have been shown
+// closing.
+func openChest(w *servertest.World, p *player.Player, rec *uiRecorder) []string {
+	chestPos := cube.Pos{0, 5, 0}
+	w.SetBlock(chestPos, block.NewChest())
+	p.UseItemOnBlock(chestPos, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+
+	rec.atQuit = func() []string {
+		for _, c := range w.Viewer().CallsTo("ViewBlockAction") {
+			if _, ok := c.Args[1].(action.Close); ok && c.Args[0] == chestPos {
+				return []string{"chest"}
+			}
+		}
+		return nil
+	}
+	return []string{"chest"}
+}
+
+// openInventory opens a virtual inventory for the player passed. When HandleQuit is called, the context of the
+// inventory must have been cancelled with session.ErrQuit.
+func openInventory(_ *servertest.World, p *player.Player, rec *uiRecorder) []string {
+	ctx := p.ShowInventory(inventory.New(27, nil), "inventory", false)
+	if ctx.Err() != nil {
+		return []string{"inventory not opened"}
+	}
+	rec.atQuit = func() []string {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == session.ErrQuit {
+				return []string{"inventory"}
+			}
+			return []string{"inventory closed with " + ctx.Err().Error()}
+		default:
+			return nil
+		}
+	}
+	return []string{"inventory"}
+}
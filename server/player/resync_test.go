@@ -0,0 +1,130 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+// cancelHandler is a player.Handler that cancels a single type of action, named after the method of the
+// player.Handler that handles it without the Handle prefix.
+type cancelHandler struct {
+	player.NopHandler
+	action string
+}
+
+// cancel cancels the context passed if the action passed is the one cancelled by the handler.
+func (h cancelHandler) cancel(ctx *event.Context, action string) {
+	if h.action == action {
+		ctx.Cancel()
+	}
+}
+
+// HandleItemUse ...
+func (h cancelHandler) HandleItemUse(ctx *event.Context) {
+	h.cancel(ctx, "ItemUse")
+}
+
+// HandleItemUseOnBlock ...
+func (h cancelHandler) HandleItemUseOnBlock(ctx *event.Context, _ cube.Pos, _ cube.Face, _ mgl64.Vec3) {
+	h.cancel(ctx, "ItemUseOnBlock")
+}
+
+// HandleItemUseOnEntity ...
+func (h cancelHandler) HandleItemUseOnEntity(ctx *event.Context, _ world.Entity) {
+	h.cancel(ctx, "ItemUseOnEntity")
+}
+
+// HandleBlockBreak ...
+func (h cancelHandler) HandleBlockBreak(ctx *event.Context, _ cube.Pos, _ *[]item.Stack) {
+	h.cancel(ctx, "BlockBreak")
+}
+
+// HandleBlockPlace ...
+func (h cancelHandler) HandleBlockPlace(ctx *event.Context, _ cube.Pos, _ world.Block, _ *item.UseContext) {
+	h.cancel(ctx, "BlockPlace")
+}
+
+// HandleItemDrop ...
+func (h cancelHandler) HandleItemDrop(ctx *event.Context, _ *entity.Item) {
+	h.cancel(ctx, "ItemDrop")
+}
+
+// resentWindows returns the IDs of the windows of which the full content was resent in the packets passed, and
+// the stack resent in the slot passed of the inventory, if it was resent.
+func resentWindows(packets []packet.Packet, slot uint32) (windows map[uint32]bool, held protocol.ItemStack, heldResent bool) {
+	windows = map[uint32]bool{}
+	for _, pk := range packets {
+		switch pk := pk.(type) {
+		case *packet.InventoryContent:
+			windows[pk.WindowID] = true
+			if pk.WindowID == protocol.WindowIDInventory && int(slot) < len(pk.Content) {
+				held, heldResent = pk.Content[slot].Stack, true
+			}
+		case *packet.InventorySlot:
+			if pk.WindowID == protocol.WindowIDInventory && pk.Slot == slot {
+				held, heldResent = pk.NewItem.Stack, true
+			}
+		}
+	}
+	return
+}
+
+// TestCancelledActionResync checks that cancelling an action that the client predicts, such as using or
+// dropping an item, resends the items that the client may have changed, so that it is in sync with the server
+// again.
+func TestCancelledActionResync(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	stone := cube.Pos{0, 5, 0}
+	p, conn := w.NewSessionPlayer("resync", mgl64.Vec3{0.5, 6, 2.5})
+	other := w.NewPlayer("other", mgl64.Vec3{1.5, 6, 2.5})
+	defer other.Close()
+
+	held := item.NewStack(block.Planks{Wood: block.OakWood()}, 16)
+	for _, c := range []struct {
+		action string
+		act    func()
+		// full is true if all inventories are resent, instead of only the held items.
+		full bool
+	}{
+		{action: "ItemUse", act: p.UseItem},
+		{action: "ItemUseOnBlock", act: func() { p.UseItemOnBlock(stone, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5}) }},
+		{action: "ItemUseOnEntity", act: func() { p.UseItemOnEntity(other) }},
+		{action: "BlockBreak", act: func() { p.BreakBlock(stone) }},
+		{action: "BlockPlace", act: func() { p.UseItemOnBlock(stone, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5}) }},
+		{action: "ItemDrop", act: func() { p.Drop(item.NewStack(item.Diamond{}, 1)) }, full: true},
+	} {
+		w.SetBlock(stone, block.Stone{})
+		w.SetBlock(stone.Side(cube.FaceUp), block.Air{})
+		p.Handle(cancelHandler{action: c.action})
+		_ = p.Inventory().SetItem(0, held)
+		conn.Reset()
+
+		c.act()
+		windows, resent, ok := resentWindows(conn.Packets(), 0)
+		if !ok {
+			t.Errorf("%v cancelled: held item not resent", c.action)
+		} else if resent.Count != uint16(held.Count()) {
+			t.Errorf("%v cancelled: held item resent with count %v, want %v", c.action, resent.Count, held.Count())
+		}
+		if !windows[protocol.WindowIDOffHand] {
+			t.Errorf("%v cancelled: off-hand not resent", c.action)
+		}
+		for _, window := range []uint32{protocol.WindowIDInventory, protocol.WindowIDUI, protocol.WindowIDArmour} {
+			if c.full && !windows[window] {
+				t.Errorf("%v cancelled: window %v not resent", c.action, window)
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// TestRideStack checks that a stack of three players riding each other stays aligned when the player at the
+// bottom moves, both every tick and right after moving, and that closing the player in the middle dismounts the
+// player on top.
+func TestRideStack(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+
+	bottom := w.NewPlayer("bottom", mgl64.Vec3{0.5, 1, 0.5})
+	defer bottom.Close()
+	middle := w.NewPlayer("middle", mgl64.Vec3{3.5, 1, 0.5})
+	defer middle.Close()
+	top := w.NewPlayer("top", mgl64.Vec3{-3.5, 1, 0.5})
+	defer top.Close()
+
+	middle.MountEntity(bottom)
+	top.MountEntity(middle)
+	if entity.BottomEntity(top) != bottom {
+		t.Fatalf("top player is not riding a stack with the bottom player at the bottom")
+	}
+	aligned := func(when string) {
+		t.Helper()
+		for _, pair := range [][2]*player.Player{{bottom, middle}, {middle, top}} {
+			seat := pair[0].SeatPositions()[0]
+			want := pair[0].Position().Add(mgl64.Vec3{float64(seat[0]), float64(seat[1]), float64(seat[2])})
+			if got := pair[1].Position(); !got.ApproxEqual(want) {
+				t.Errorf("%v: %v is at %v, want %v on top of %v", when, pair[1].Name(), got, want, pair[0].Name())
+			}
+		}
+	}
+	w.Advance(1)
+	aligned("after a tick")
+
+	bottom.Teleport(mgl64.Vec3{5.5, 1, 5.5})
+	aligned("after teleporting")
+
+	bottom.Move(mgl64.Vec3{-1, 0, 0}, 0, 0)
+	aligned("after moving")
+
+	w.Advance(20)
+	aligned("after 20 ticks")
+
+	_ = middle.Close()
+	if r, _ := top.RidingEntity(); r != nil {
+		t.Errorf("top player is still riding after the middle player was closed")
+	}
+	if riders := bottom.Riders(); len(riders) != 0 {
+		t.Errorf("bottom player still has riders %v after the middle player was closed", riders)
+	}
+}
@@ -0,0 +1,135 @@
+package player_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+	"time"
+)
+
+// immunityHandler is a player.Handler that changes the attack immunity after being hurt to a fixed duration.
+type immunityHandler struct {
+	player.NopHandler
+	immunity time.Duration
+}
+
+// HandleHurt ...
+func (h immunityHandler) HandleHurt(_ *event.Context, _ *float64, immunity *time.Duration, _ damage.Source) {
+	*immunity = h.immunity
+}
+
+// TestFallDamage checks that players take fall damage for every block fallen after the third, and none when
+// falling three blocks or fewer.
+func TestFallDamage(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+
+	for height, want := range map[int]float64{3: 0, 10: 7, 20: 17} {
+		p := w.NewPlayer("faller", mgl64.Vec3{0.5, float64(1 + height), 0.5})
+		// The food bar is kept below the level at which health regenerates, so that the damage is not healed.
+		p.SetFood(17)
+		w.Advance(60)
+		if !p.OnGround() {
+			t.Errorf("player falling %v blocks did not land", height)
+		}
+		if dmg := p.MaxHealth() - p.Health(); dmg != want {
+			t.Errorf("player falling %v blocks took %v damage, want %v", height, dmg, want)
+		}
+		_ = p.Close()
+	}
+}
+
+// TestStarvation checks that starving players are damaged every 80 ticks until their health reaches the limit
+// of the difficulty, and that players with a full food bar regenerate health.
+func TestStarvation(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+	w.SetDifficulty(world.DifficultyNormal)
+
+	p := w.NewPlayer("starving", mgl64.Vec3{0.5, 1, 0.5})
+	defer p.Close()
+	p.SetFood(0)
+
+	w.Advance(80)
+	if p.Health() != p.MaxHealth()-1 {
+		t.Errorf("health is %v after 80 ticks of starving, want %v", p.Health(), p.MaxHealth()-1)
+	}
+	w.Advance(80 * 30)
+	if limit := world.DifficultyNormal.StarvationHealthLimit(); p.Health() != limit {
+		t.Errorf("health is %v after starving, want %v", p.Health(), limit)
+	}
+
+	p.SetFood(20)
+	w.Advance(200)
+	if p.Health() <= world.DifficultyNormal.StarvationHealthLimit() {
+		t.Errorf("health did not regenerate with a full food bar")
+	}
+	if p.Food() >= 20 {
+		t.Errorf("food did not decrease by regenerating health")
+	}
+}
+
+// TestFireTicks checks that burning players are damaged once every second until the fire runs out, after which
+// they are extinguished. A handler removes the attack immunity after being hurt, which would otherwise depend
+// on the wall clock.
+func TestFireTicks(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+
+	p := w.NewPlayer("burning", mgl64.Vec3{0.5, 1, 0.5})
+	defer p.Close()
+	p.SetFood(17)
+	p.Handle(immunityHandler{})
+	p.SetOnFire(time.Second * 4)
+
+	w.Advance(79)
+	if p.OnFireDuration() <= 0 {
+		t.Errorf("fire ran out before 80 ticks")
+	}
+	w.Advance(1)
+	if p.OnFireDuration() > 0 {
+		t.Errorf("fire did not run out after 80 ticks")
+	}
+	if dmg := p.MaxHealth() - p.Health(); dmg != 4 {
+		t.Errorf("player took %v fire damage in 4 seconds, want 4", dmg)
+	}
+	w.Advance(40)
+	if dmg := p.MaxHealth() - p.Health(); dmg != 4 {
+		t.Errorf("player took %v fire damage after being extinguished, want 4", dmg)
+	}
+}
+
+// TestScaledGround checks that the ground beneath a player is found using its scaled bounding box: Next to a
+// single block, a player at a scale of 2 stands on it, while a player at a scale of 0.5 at the same position
+// does not touch it and falls.
+func TestScaledGround(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.SetBlock(cube.Pos{0, 5, 0}, block.Stone{})
+
+	// The centre of the players is 0.2 blocks past the edge of the block. A player at a scale of 0.5 is 0.3 blocks
+	// wide, a player at a scale of 2 is 1.2 blocks wide.
+	pos := mgl64.Vec3{1.2, 6, 0.5}
+	for scale, onGround := range map[float64]bool{0.5: false, 2: true} {
+		p := w.NewPlayer("scaled", pos)
+		p.SetScale(scale)
+		w.Advance(20)
+
+		if p.OnGround() != onGround {
+			t.Errorf("scale %v: on ground = %v, want %v", scale, p.OnGround(), onGround)
+		}
+		if fell := p.Position()[1] < pos[1]; fell == onGround {
+			t.Errorf("scale %v: player is at %v after 20 ticks, want on ground: %v", scale, p.Position(), onGround)
+		}
+		_ = p.Close()
+	}
+}
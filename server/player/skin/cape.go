@@ -1,6 +1,7 @@
 package skin
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 )
@@ -22,6 +23,26 @@ func NewCape(width, height int) Cape {
 	return Cape{w: width, h: height, Pix: make([]uint8, width*height*4)}
 }
 
+// CapeFromData creates a new Cape using the RGBA pixel data and dimensions passed. An error is returned if
+// the dimensions are not valid for a cape (64x32 or an empty 0x0 cape) or if the length of the data does not
+// match the dimensions.
+func CapeFromData(data []byte, width, height int) (Cape, error) {
+	if !(width == 64 && height == 32) && !(width == 0 && height == 0) {
+		return Cape{}, fmt.Errorf("invalid cape dimensions %vx%v: must be 64x32 or 0x0", width, height)
+	}
+	if len(data) != width*height*4 {
+		return Cape{}, fmt.Errorf("invalid cape data length %v: expected %v bytes for %vx%v cape", len(data), width*height*4, width, height)
+	}
+	c := NewCape(width, height)
+	copy(c.Pix, data)
+	return c, nil
+}
+
+// Exists checks if the cape has any pixel data, in other words if it is not an empty cape.
+func (c Cape) Exists() bool {
+	return c.w != 0 && c.h != 0 && len(c.Pix) != 0
+}
+
 // ColorModel ...
 func (c Cape) ColorModel() color.Model {
 	return color.RGBAModel
@@ -0,0 +1,29 @@
+package skin
+
+// PersonaPiece represents a single piece of a persona skin, which is a skin created using the in-game skin
+// creator. A persona skin is composed of several of these pieces, such as the body, the hair and the eyes.
+type PersonaPiece struct {
+	// ID is a UUID that identifies the piece itself, which is unique for each separate piece.
+	ID string
+	// Type holds the type of the piece, such as 'persona_body', 'persona_hair' or 'persona_eyes'.
+	Type string
+	// PackID is a UUID that identifies the pack that the persona piece belongs to.
+	PackID string
+	// Default specifies if the piece is one of the default pieces, which is the case for pieces that a Steve
+	// or Alex skin have.
+	Default bool
+	// ProductID is a UUID that identifies the piece when it comes to purchases. It is empty for pieces that
+	// have Default set to true.
+	ProductID string
+}
+
+// PieceTintColour holds the tint colours of one of the PersonaPieces of a skin. Not every piece type has tint
+// colours.
+type PieceTintColour struct {
+	// PieceType is the type of the persona piece that the tint colours apply to. A piece with this type must
+	// be present in the persona pieces of the skin.
+	PieceType string
+	// Colours is a list of up to four colours written in ARGB hex notation, such as '#ffa12722'. Each colour
+	// applies to a different part of the piece.
+	Colours []string
+}
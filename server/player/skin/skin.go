@@ -28,10 +28,28 @@ type Skin struct {
 	// Cape holds the cape of the skin. By default, an empty cape is set in the skin. Cape.Exists() may be
 	// called to check if the cape actually has any data.
 	Cape Cape
+	// PersonaCapeOnClassic specifies if the cape of the skin is a persona cape that is worn on a classic,
+	// non-persona, skin.
+	PersonaCapeOnClassic bool
 
 	// Animations holds a list of all animations that the skin has. These animations must be pointed to in the
 	// ModelConfig, in order to display them on the skin.
 	Animations []Animation
+	// AnimationData holds the raw JSON data of the animations of the skin's model, such as those used by
+	// persona skins. It may be left empty.
+	AnimationData []byte
+
+	// ArmSize is the size of the arms of the skin's model. It is either 'wide' or 'slim', and is only used by
+	// persona skins.
+	ArmSize string
+	// Colour is the hex representation (including #) of the base colour of a persona skin, for example
+	// '#b37b62'.
+	Colour string
+	// PersonaPieces holds all pieces that a persona skin is composed of. Without these, persona skins are not
+	// rendered properly by other players.
+	PersonaPieces []PersonaPiece
+	// PieceTintColours holds the tint colours of (some of) the PersonaPieces of the skin.
+	PieceTintColours []PieceTintColour
 }
 
 // New creates a new skin using the width and height passed. The dimensions passed must be either 64x32,
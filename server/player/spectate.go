@@ -0,0 +1,56 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// SpectateEntity makes the player spectate the entity passed, locking the position and rotation of the player to
+// that of the entity. Movement of the player itself is ignored until StopSpectating is called. Spectating stops
+// automatically when the entity is removed from the world of the player or when the player changes to a game mode
+// with collision. SpectateEntity has no effect if the game mode of the player has collision, such as survival.
+func (p *Player) SpectateEntity(e world.Entity) {
+	if e == p || p.GameMode().HasCollision() {
+		return
+	}
+	p.spectateMu.Lock()
+	p.spectating = e
+	p.spectateMu.Unlock()
+	p.followSpectated()
+}
+
+// StopSpectating stops the player from spectating the entity it spectates, if any, so that it may move freely
+// again.
+func (p *Player) StopSpectating() {
+	p.spectateMu.Lock()
+	p.spectating = nil
+	p.spectateMu.Unlock()
+}
+
+// Spectating returns the entity that the player is currently spectating. If the player is not spectating an
+// entity, false is returned.
+func (p *Player) Spectating() (world.Entity, bool) {
+	p.spectateMu.Lock()
+	defer p.spectateMu.Unlock()
+	return p.spectating, p.spectating != nil
+}
+
+// followSpectated moves the player to the position and rotation of the entity it spectates, if that entity has
+// moved. If the entity is no longer in the world of the player, the player stops spectating it.
+func (p *Player) followSpectated() {
+	e, ok := p.Spectating()
+	if !ok {
+		return
+	}
+	if e.World() != p.World() {
+		p.StopSpectating()
+		return
+	}
+	pos := e.Position()
+	yaw, pitch := e.Rotation()
+	if pos.ApproxEqual(p.Position()) && yaw == p.yaw.Load() && pitch == p.pitch.Load() {
+		return
+	}
+	p.yaw.Store(yaw)
+	p.pitch.Store(pitch)
+	p.teleport(pos)
+}
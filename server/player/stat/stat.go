@@ -0,0 +1,90 @@
+// Package stat implements vanilla-style statistics of players, such as the amount of blocks mined or the
+// distance walked. The values of these stats are tracked for every player using a Tracker, which is saved along
+// with the rest of the data of the player.
+package stat
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Stat is a statistic tracked for a player, such as the amount of blocks it mined or the distance it walked.
+// Stats are identified by their name, so that their values may be saved and loaded again. Custom stats may be
+// created using Register.
+type Stat struct {
+	name string
+}
+
+// Name returns the unique name of the Stat, such as "minecraft:blocks_mined".
+func (s Stat) Name() string {
+	return s.name
+}
+
+// String ...
+func (s Stat) String() string {
+	return s.name
+}
+
+var (
+	// BlocksMined is the amount of blocks broken by the player.
+	BlocksMined = register("minecraft:blocks_mined")
+	// WalkDistance is the distance in centimetres that the player walked on the ground.
+	WalkDistance = register("minecraft:walk_one_cm")
+	// SprintDistance is the distance in centimetres that the player sprinted on the ground.
+	SprintDistance = register("minecraft:sprint_one_cm")
+	// CrouchDistance is the distance in centimetres that the player moved on the ground while sneaking.
+	CrouchDistance = register("minecraft:crouch_one_cm")
+	// SwimDistance is the distance in centimetres that the player swam.
+	SwimDistance = register("minecraft:swim_one_cm")
+	// FallDistance is the distance in centimetres that the player fell.
+	FallDistance = register("minecraft:fall_one_cm")
+	// FlyDistance is the distance in centimetres that the player flew.
+	FlyDistance = register("minecraft:fly_one_cm")
+	// DamageDealt is the damage dealt by the player by attacking entities, in tenths of health points.
+	DamageDealt = register("minecraft:damage_dealt")
+	// DamageTaken is the damage taken by the player, in tenths of health points.
+	DamageTaken = register("minecraft:damage_taken")
+	// Deaths is the amount of times the player died.
+	Deaths = register("minecraft:deaths")
+	// PlayTime is the amount of ticks that the player spent in a world.
+	PlayTime = register("minecraft:play_time")
+)
+
+var (
+	statMu sync.RWMutex
+	stats  = map[string]Stat{}
+)
+
+// Register registers a custom Stat with the name passed and returns it. Names should be prefixed with a
+// namespace, such as "myplugin:fish_caught", so that they do not collide with the stats of dragonfly or other
+// plugins. An error is returned if a Stat with the same name was already registered.
+// Stats should be registered before players join, so that their values are loaded correctly, although values
+// of stats that are not yet registered are kept when loading and saving players.
+func Register(name string) (Stat, error) {
+	statMu.Lock()
+	defer statMu.Unlock()
+	if _, ok := stats[name]; ok {
+		return Stat{}, fmt.Errorf("register stat: stat with name %v already registered", name)
+	}
+	s := Stat{name: name}
+	stats[name] = s
+	return s, nil
+}
+
+// register registers a Stat with the name passed, panicking if the name was already registered.
+func register(name string) Stat {
+	s, err := Register(name)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ByName looks up a registered Stat by its name. False is returned if no Stat with the name passed was
+// registered.
+func ByName(name string) (Stat, bool) {
+	statMu.RLock()
+	defer statMu.RUnlock()
+	s, ok := stats[name]
+	return s, ok
+}
@@ -0,0 +1,94 @@
+package stat
+
+import (
+	"sort"
+	"sync"
+)
+
+// Tracker tracks the values of the stats of a single player. Updating a Tracker never calls any events, and a
+// Tracker is safe for concurrent use, so that its values may be read from, for example, command goroutines.
+type Tracker struct {
+	mu     sync.RWMutex
+	values map[string]int64
+}
+
+// NewTracker returns a Tracker with no values for any stat.
+func NewTracker() *Tracker {
+	return &Tracker{values: map[string]int64{}}
+}
+
+// Add adds n to the value of the Stat passed. n may be negative to lower the value.
+func (t *Tracker) Add(s Stat, n int64) {
+	if n == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.values[s.name] += n
+}
+
+// Set sets the value of the Stat passed to n.
+func (t *Tracker) Set(s Stat, n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.values[s.name] = n
+}
+
+// Get returns the value of the Stat passed, or 0 if it was never changed.
+func (t *Tracker) Get(s Stat) int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.values[s.name]
+}
+
+// All returns the values of all stats that were changed, indexed by their Stat.
+func (t *Tracker) All() map[Stat]int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	m := make(map[Stat]int64, len(t.values))
+	for name, v := range t.values {
+		m[Stat{name: name}] = v
+	}
+	return m
+}
+
+// Range calls f for every stat that was changed, in the order of their names, so that they may, for example,
+// be shown on a scoreboard. Iteration stops if f returns false. The Tracker may not be changed from within f.
+func (t *Tracker) Range(f func(s Stat, v int64) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	names := make([]string, 0, len(t.values))
+	for name := range t.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !f(Stat{name: name}, t.values[name]) {
+			return
+		}
+	}
+}
+
+// Values returns the values of all stats that were changed, indexed by the names of the stats, so that they may
+// be saved.
+func (t *Tracker) Values() map[string]int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	m := make(map[string]int64, len(t.values))
+	for name, v := range t.values {
+		m[name] = v
+	}
+	return m
+}
+
+// Load replaces all values of the Tracker with the values passed, indexed by the names of the stats, as
+// previously returned by Values. Values of stats that are not registered are kept, so that they are not lost if
+// the stat is registered later.
+func (t *Tracker) Load(values map[string]int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.values = make(map[string]int64, len(values))
+	for name, v := range values {
+		t.values[name] = v
+	}
+}
@@ -0,0 +1,48 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/player/stat"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// Statistics returns the stat.Tracker that holds the statistics of the player, such as the amount of blocks it
+// mined or the distance it walked. Custom stats registered using stat.Register may be tracked using it too. The
+// statistics are saved as part of the Data of the player.
+func (p *Player) Statistics() *stat.Tracker {
+	return p.stats
+}
+
+// trackMovement adds the distance moved by the player to the stats matching the way the player moved. onGround
+// specifies if the player ended up on the ground after moving.
+func (p *Player) trackMovement(deltaPos mgl64.Vec3, onGround bool) {
+	switch {
+	case p.Flying():
+		p.stats.Add(stat.FlyDistance, centimetres(deltaPos.Len()))
+		return
+	case p.Swimming():
+		p.stats.Add(stat.SwimDistance, centimetres(deltaPos.Len()))
+		return
+	}
+	if deltaPos[1] < 0 {
+		// The vertical movement of the tick the player lands in still counts as falling.
+		p.stats.Add(stat.FallDistance, centimetres(-deltaPos[1]))
+	}
+	if !onGround {
+		return
+	}
+	horizontal := mgl64.Vec2{deltaPos[0], deltaPos[2]}.Len()
+	switch {
+	case p.Sprinting():
+		p.stats.Add(stat.SprintDistance, centimetres(horizontal))
+	case p.Sneaking():
+		p.stats.Add(stat.CrouchDistance, centimetres(horizontal))
+	default:
+		p.stats.Add(stat.WalkDistance, centimetres(horizontal))
+	}
+}
+
+// centimetres converts a distance in blocks to a whole amount of centimetres.
+func centimetres(blocks float64) int64 {
+	return int64(math.Round(blocks * 100))
+}
@@ -17,6 +17,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -27,6 +28,8 @@ import (
 	"github.com/df-mc/dragonfly/server/internal"
 	_ "github.com/df-mc/dragonfly/server/item" // Imported for compiler directives.
 	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/audit"
+	"github.com/df-mc/dragonfly/server/player/mute"
 	"github.com/df-mc/dragonfly/server/player/playerdb"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/session"
@@ -53,6 +56,11 @@ type Server struct {
 
 	joinMessage, quitMessage atomic.String
 	playerProvider           player.Provider
+	// audit is the buffer that audit records of the actions of players are written to. It is nil if no
+	// audit.Sink was set using AuditSink.
+	audit *audit.Buffer
+	// mutes is the mute.List consulted by players before sending a chat message.
+	mutes *mute.List
 
 	c                  Config
 	log                internal.Logger
@@ -60,6 +68,13 @@ type Server struct {
 	players            chan *player.Player
 	resources          []*resource.Pack
 
+	worldMu sync.RWMutex
+	// worlds holds the additional worlds added using AddWorld, indexed by their name.
+	worlds map[string]*world.World
+
+	// obfuscator hides blocks in the chunks sent to players. It is nil if obfuscation is disabled in the Config.
+	obfuscator *session.Obfuscator
+
 	startTime time.Time
 
 	playerMutex sync.RWMutex
@@ -71,6 +86,8 @@ type Server struct {
 	pwg sync.WaitGroup
 
 	wg sync.WaitGroup
+	// closing is closed when the server is closed, stopping the goroutine that saves the server periodically.
+	closing chan struct{}
 
 	listenMu  sync.Mutex
 	listeners []Listener
@@ -104,9 +121,12 @@ func New(c *Config, log internal.Logger) *Server {
 		log:            log,
 		players:        make(chan *player.Player),
 		p:              make(map[uuid.UUID]*player.Player),
+		worlds:         make(map[string]*world.World),
 		name:           *atomic.NewString(c.Server.Name),
 		playerProvider: player.NopProvider{},
 		a:              allower{},
+		mutes:          mute.NewList(),
+		closing:        make(chan struct{}),
 	}
 	set := new(world.Settings)
 	s.world = s.createWorld(world.Overworld, biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Dirt{}, block.Bedrock{}}, set)
@@ -119,6 +139,12 @@ func New(c *Config, log internal.Logger) *Server {
 
 	s.registerTargetFunc()
 
+	obfuscator, err := session.NewObfuscator(c.World.Obfuscation)
+	if err != nil {
+		log.Fatalf("error creating block obfuscator: %v", err)
+	}
+	s.obfuscator = obfuscator
+
 	s.JoinMessage(c.Server.JoinMessage)
 	s.QuitMessage(c.Server.QuitMessage)
 
@@ -145,6 +171,7 @@ func (server *Server) Accept() (*player.Player, error) {
 	server.playerMutex.Lock()
 	server.p[p.UUID()] = p
 	server.playerMutex.Unlock()
+	cmd.UpdateSoftEnum(cmd.PlayerName(""))
 
 	return p, nil
 }
@@ -167,6 +194,43 @@ func (server *Server) End() *world.World {
 	return server.end
 }
 
+// AddWorld adds a world to the Server, so that it is closed when the Server is closed and may be unloaded at
+// runtime using UnloadWorld. The world is indexed by its name: An error is returned if a world with the same name
+// was already added.
+func (server *Server) AddWorld(w *world.World) error {
+	server.worldMu.Lock()
+	defer server.worldMu.Unlock()
+	name := w.Name()
+	if _, ok := server.worlds[name]; ok {
+		return fmt.Errorf("world with name %v already added", name)
+	}
+	server.worlds[name] = w
+	cmd.UpdateSoftEnum(cmd.WorldName(""))
+	return nil
+}
+
+// UnloadWorld unloads the world with the name passed that was added using AddWorld. All players in the world are
+// moved to the spawn of the overworld of the Server, after which the world is closed, saving all its chunks and
+// entities. An error is returned if no world with the name passed was added or if closing the world failed. The
+// overworld, nether and end of the Server cannot be unloaded.
+func (server *Server) UnloadWorld(name string) error {
+	server.worldMu.Lock()
+	w, ok := server.worlds[name]
+	delete(server.worlds, name)
+	server.worldMu.Unlock()
+	if !ok {
+		return fmt.Errorf("no world with name %v added", name)
+	}
+	cmd.UpdateSoftEnum(cmd.WorldName(""))
+	for _, p := range server.Players() {
+		if p.World() == w {
+			server.world.AddEntity(p)
+			p.Teleport(server.world.Spawn().Vec3Middle())
+		}
+	}
+	return w.Close()
+}
+
 // Start runs the server but does not block, unlike Run, but instead accepts connections on a different
 // goroutine. Connections will be accepted until the listener is closed using a call to Close.
 // Once started, players may be accepted using Server.Accept().
@@ -180,6 +244,7 @@ func (server *Server) Start() error {
 		return err
 	}
 	go server.wait()
+	go server.autoSave()
 	return nil
 }
 
@@ -255,6 +320,31 @@ func (server *Server) PlayerProvider(provider player.Provider) {
 	server.playerProvider = provider
 }
 
+// AuditSink sets the audit.Sink that records of the actions of players, such as joining, chatting and breaking
+// blocks, are written to. Records are buffered and written to the sink on a separate goroutine, so that a slow
+// sink never blocks players: If the sink cannot keep up, the oldest records are dropped. The sink is closed
+// when the server is closed. AuditSink should be called before the server is started.
+func (server *Server) AuditSink(s audit.Sink) {
+	server.audit = audit.NewBuffer(s, auditBufferSize, server.log)
+}
+
+// MuteList returns the mute.List of the server. Players muted in the list cannot send chat messages until they
+// are unmuted or their mute expires. By default, mutes are lost when the server is closed: MuteStore may be
+// used to persist them.
+func (server *Server) MuteList() *mute.List {
+	return server.mutes
+}
+
+// MuteStore sets the mute.Store that the mutes in the MuteList of the server are persisted to, so that they
+// are kept across restarts. The mutes held by the store are loaded immediately. If nil is passed, mutes are no
+// longer persisted.
+func (server *Server) MuteStore(s mute.Store) error {
+	return server.mutes.UseStore(s)
+}
+
+// auditBufferSize is the amount of audit records buffered before the oldest records are dropped.
+const auditBufferSize = 4096
+
 // SetNamef sets the name of the Server, also known as the MOTD. This name is displayed in the server list.
 // The formatting of the name passed follows the rules of fmt.Sprintf.
 func (server *Server) SetNamef(format string, a ...interface{}) {
@@ -287,6 +377,7 @@ func (server *Server) Close() error {
 
 	server.log.Infof("Server shutting down...")
 	defer server.log.Infof("Server stopped.")
+	close(server.closing)
 
 	server.log.Debugf("Disconnecting players...")
 	server.playerMutex.RLock()
@@ -296,6 +387,13 @@ func (server *Server) Close() error {
 	server.playerMutex.RUnlock()
 	server.pwg.Wait()
 
+	if server.audit != nil {
+		server.log.Debugf("Closing audit sink...")
+		if err := server.audit.Close(); err != nil {
+			server.log.Errorf("Error while closing audit sink: %v", err)
+		}
+	}
+
 	server.log.Debugf("Closing player provider...")
 	err := server.playerProvider.Close()
 	if err != nil {
@@ -312,6 +410,14 @@ func (server *Server) Close() error {
 	if err = server.end.Close(); err != nil {
 		server.log.Errorf("Error closing end: %v", err)
 	}
+	server.worldMu.Lock()
+	for name, w := range server.worlds {
+		if err = w.Close(); err != nil {
+			server.log.Errorf("Error closing world %v: %v", name, err)
+		}
+		delete(server.worlds, name)
+	}
+	server.worldMu.Unlock()
 
 	server.log.Debugf("Closing listeners...")
 	server.listenMu.Lock()
@@ -415,6 +521,43 @@ func (server *Server) startListening() error {
 	return nil
 }
 
+// autoSave saves the worlds of the server and the data of all players online every Config.World.SaveInterval
+// until the server is closed. It returns immediately if the interval is 0.
+func (server *Server) autoSave() {
+	if server.c.World.SaveInterval <= 0 {
+		return
+	}
+	t := time.NewTicker(server.c.World.SaveInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			server.save()
+		case <-server.closing:
+			return
+		}
+	}
+}
+
+// save saves all worlds of the server and the data of all players online.
+func (server *Server) save() {
+	server.log.Debugf("Saving worlds and player data...")
+	worlds := []*world.World{server.world, server.nether, server.end}
+	server.worldMu.RLock()
+	for _, w := range server.worlds {
+		worlds = append(worlds, w)
+	}
+	server.worldMu.RUnlock()
+	for _, w := range worlds {
+		w.Save()
+	}
+	for _, p := range server.Players() {
+		if err := server.playerProvider.Save(p.UUID(), p.Data()); err != nil {
+			server.log.Errorf("Error while saving data: %v", err)
+		}
+	}
+}
+
 // wait awaits the closing of all Listeners added to the Server through a call to Listen and closed the players channel
 // once that happens.
 func (server *Server) wait() {
@@ -503,6 +646,10 @@ func (server *Server) handleSessionClose(c session.Controllable) {
 	p := server.p[c.UUID()]
 	delete(server.p, c.UUID())
 	server.playerMutex.Unlock()
+	cmd.UpdateSoftEnum(cmd.PlayerName(""))
+	if server.audit != nil {
+		_ = server.audit.Write(audit.Quit{Header: p.AuditHeader(false), Address: p.Addr().String(), XUID: p.XUID()})
+	}
 	err := server.playerProvider.Save(p.UUID(), p.Data())
 	if err != nil {
 		server.log.Errorf("Error while saving data: %v", err)
@@ -516,8 +663,14 @@ func (server *Server) createPlayer(id uuid.UUID, conn session.Conn, data *player
 	if data != nil {
 		w, gm, pos = server.dimension(data.Dimension), data.GameMode, data.Position
 	}
-	s := session.New(conn, server.c.Players.MaximumChunkRadius, server.log, &server.joinMessage, &server.quitMessage)
+	s := session.New(conn, server.c.Players.MaximumChunkRadius, server.log, &server.joinMessage, &server.quitMessage, server.c.Players.RateLimits, server.obfuscator)
 	p := player.NewWithSession(conn.IdentityData().DisplayName, conn.IdentityData().XUID, id, server.createSkin(conn.ClientData()), s, pos, data)
+	p.SetMuteList(server.mutes)
+	p.SetProvider(server.playerProvider)
+	if server.audit != nil {
+		p.SetAuditSink(server.audit)
+		_ = server.audit.Write(audit.Join{Header: p.AuditHeader(false), Address: conn.RemoteAddr().String(), XUID: p.XUID()})
+	}
 
 	s.Start(p, w, gm, server.handleSessionClose)
 	server.pwg.Add(1)
@@ -525,7 +678,8 @@ func (server *Server) createPlayer(id uuid.UUID, conn session.Conn, data *player
 }
 
 // createWorld loads a world of the server with a specific dimension, ending the program if the world could not be loaded.
-// The layers passed are used to create a generator.Flat that is used as generator for the world.
+// The layers passed are used to create a generator.Flat that is used as generator for the world, unless the overworld
+// is configured to use the generator.Overworld generator.
 func (server *Server) createWorld(d world.Dimension, biome world.Biome, layers []world.Block, s *world.Settings) *world.World {
 	log := server.log
 	if v, ok := log.(interface {
@@ -544,7 +698,15 @@ func (server *Server) createWorld(d world.Dimension, biome world.Biome, layers [
 		log.Fatalf("error loading world: %v", err)
 	}
 	w.Provider(p)
-	w.Generator(generator.Flat{Biome: biome, Layers: layers})
+	switch gen := strings.ToLower(server.c.World.Generator); {
+	case d == world.Overworld && gen == "overworld":
+		w.Generator(generator.NewOverworld(w.Seed(), nil))
+	case gen == "" || gen == "flat" || gen == "overworld":
+		w.Generator(generator.Flat{Biome: biome, Layers: layers})
+	default:
+		log.Fatalf("error loading world: unknown generator %q", server.c.World.Generator)
+	}
+	w.SetSpawnProtection(server.c.World.SpawnProtection)
 
 	log.Debugf(`Loaded world "%v".`, w.Name())
 	return w
@@ -568,6 +730,26 @@ func (server *Server) createSkin(data login.ClientData) skin.Skin {
 
 	playerSkin.Cape = skin.NewCape(data.CapeImageWidth, data.CapeImageHeight)
 	playerSkin.Cape.Pix = capeData
+	playerSkin.PersonaCapeOnClassic = data.CapeOnClassicSkin
+
+	playerSkin.AnimationData = []byte(data.SkinAnimationData)
+	playerSkin.ArmSize = data.ArmSize
+	playerSkin.Colour = data.SkinColour
+	for _, piece := range data.PersonaPieces {
+		playerSkin.PersonaPieces = append(playerSkin.PersonaPieces, skin.PersonaPiece{
+			ID:        piece.PieceID,
+			Type:      piece.PieceType,
+			PackID:    piece.PackID,
+			Default:   piece.Default,
+			ProductID: piece.ProductID,
+		})
+	}
+	for _, tint := range data.PieceTintColours {
+		playerSkin.PieceTintColours = append(playerSkin.PieceTintColours, skin.PieceTintColour{
+			PieceType: tint.PieceType,
+			Colours:   append([]string(nil), tint.Colours[:]...),
+		})
+	}
 
 	for _, animation := range data.AnimatedImageData {
 		var t skin.AnimationType
@@ -591,8 +773,24 @@ func (server *Server) createSkin(data login.ClientData) skin.Skin {
 }
 
 // registerTargetFunc registers a cmd.TargetFunc to be able to get all players connected and all entities in
-// the server's world.
+// the server's world. It also registers a function returning all worlds of the server for cmd.WorldName.
 func (server *Server) registerTargetFunc() {
+	cmd.AddWorldFunc(func() []*world.World {
+		server.worldMu.RLock()
+		defer server.worldMu.RUnlock()
+		names := make([]string, 0, len(server.worlds))
+		for name := range server.worlds {
+			names = append(names, name)
+		}
+		// The names are sorted so that the options of cmd.WorldName don't change order between calls.
+		sort.Strings(names)
+
+		worlds := []*world.World{server.world, server.nether, server.end}
+		for _, name := range names {
+			worlds = append(worlds, server.worlds[name])
+		}
+		return worlds
+	})
 	cmd.AddTargetFunc(func(src cmd.Source) ([]cmd.Target, []cmd.Target) {
 		entities, players := src.World().Entities(), server.Players()
 		eTargets, pTargets := make([]cmd.Target, len(entities)), make([]cmd.Target, len(players))
@@ -0,0 +1,108 @@
+package server_test
+
+import (
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"github.com/sirupsen/logrus"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newServer starts a server that stores its data in a temporary directory and listens on a random local port,
+// and returns it along with a servertest.Listener that clients may join it through.
+func newServer(t *testing.T) (*server.Server, *servertest.Listener) {
+	t.Helper()
+	log := logrus.New()
+	log.Level = logrus.WarnLevel
+
+	dir := t.TempDir()
+	c := server.DefaultConfig()
+	c.Network.Address = "127.0.0.1:0"
+	c.World.Folder = filepath.Join(dir, "world")
+	c.Players.Folder = filepath.Join(dir, "players")
+	c.Resources.Folder = filepath.Join(dir, "resources")
+	srv := server.New(&c, log)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("error starting server: %v", err)
+	}
+	l := servertest.NewListener()
+	srv.Listen(l)
+	return srv, l
+}
+
+// join connects a client with the name passed to the server through the servertest.Listener passed and waits
+// for its player to be accepted.
+func join(t *testing.T, srv *server.Server, l *servertest.Listener, name string) *servertest.Conn {
+	t.Helper()
+	conn, ok := l.Connect(name)
+	if !ok {
+		t.Fatalf("listener closed before %v connected", name)
+	}
+	if _, err := srv.Accept(); err != nil {
+		t.Fatalf("error accepting %v: %v", name, err)
+	}
+	return conn
+}
+
+// TestJoinLevelData checks that players joining after the name, spawn and default game mode of the world were
+// changed receive the new values without the server being restarted, and that the new values are saved to the
+// provider of the world.
+func TestJoinLevelData(t *testing.T) {
+	srv, l := newServer(t)
+	prov := servertest.NewProvider()
+	w := srv.World()
+	w.Provider(prov)
+
+	before := join(t, srv, l, "before")
+	spawn := cube.Pos{100, 20, -40}
+	w.SetName("Renamed World")
+	w.SetSpawn(spawn)
+	w.SetDefaultGameMode(world.GameModeCreative)
+
+	after := join(t, srv, l, "after")
+	data := after.GameData()
+	if data.WorldName != "Renamed World" {
+		t.Errorf("joining player received world name %q, want %q", data.WorldName, "Renamed World")
+	}
+	if want := (mgl32.Vec3{100.5, 22.12, -39.5}); !data.PlayerPosition.ApproxEqual(want) {
+		t.Errorf("joining player spawned at %v, want %v", data.PlayerPosition, want)
+	}
+	if before.GameData().WorldName == "Renamed World" {
+		t.Errorf("player that joined before the world was renamed received the new name")
+	}
+
+	p, ok := srv.PlayerByName("after")
+	if !ok {
+		t.Fatalf("joining player not found")
+	}
+	if p.GameMode() != world.GameModeCreative {
+		t.Errorf("joining player has game mode %#v, want creative", p.GameMode())
+	}
+	var spawnSent bool
+	deadline := time.Now().Add(time.Second * 5)
+	for !spawnSent && time.Now().Before(deadline) {
+		for _, pk := range after.Packets() {
+			if pk, ok := pk.(*packet.SetSpawnPosition); ok && pk.Position == [3]int32{100, 20, -40} {
+				spawnSent = true
+			}
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	if !spawnSent {
+		t.Errorf("joining player was not sent the new spawn position")
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Fatalf("error closing server: %v", err)
+	}
+	var s world.Settings
+	prov.Settings(&s)
+	if s.Name != "Renamed World" || s.Spawn != spawn || s.DefaultGameMode != world.GameModeCreative {
+		t.Errorf("provider holds name %q, spawn %v and default game mode %#v, want %q, %v and creative", s.Name, s.Spawn, s.DefaultGameMode, "Renamed World", spawn)
+	}
+}
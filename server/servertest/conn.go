@@ -0,0 +1,150 @@
+package servertest
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"net"
+	"sync"
+	"time"
+)
+
+// Conn is a session.Conn that is not connected to a client. It records every packet written to it, so that tests
+// may check what would have been sent to the client, and packets may be sent to the session reading from it as if
+// they were sent by the client using Send. A Conn is safe for concurrent use.
+type Conn struct {
+	identity login.IdentityData
+
+	mu       sync.Mutex
+	packets  []packet.Packet
+	gameData minecraft.GameData
+
+	in     chan packet.Packet
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewConn creates a Conn for a client with the name passed.
+func NewConn(name string) *Conn {
+	return &Conn{
+		identity: login.IdentityData{DisplayName: name, Identity: uuid.New().String()},
+		in:       make(chan packet.Packet),
+		closed:   make(chan struct{}),
+	}
+}
+
+// Packets returns all packets written to the Conn since it was created or since Reset was last called, in the
+// order they were written.
+func (c *Conn) Packets() []packet.Packet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]packet.Packet(nil), c.packets...)
+}
+
+// Reset clears all packets recorded by the Conn.
+func (c *Conn) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.packets = nil
+}
+
+// Send sends a packet to the session reading from the Conn as if it was sent by the client. Send blocks until
+// the session reads the packet and returns false if the Conn was closed before it did.
+func (c *Conn) Send(pk packet.Packet) bool {
+	select {
+	case c.in <- pk:
+		return true
+	case <-c.closed:
+		return false
+	}
+}
+
+// Closed returns a channel that is closed once the Conn is closed.
+func (c *Conn) Closed() <-chan struct{} {
+	return c.closed
+}
+
+// Close closes the Conn, after which the session reading from it stops.
+func (c *Conn) Close() error {
+	c.once.Do(func() {
+		close(c.closed)
+	})
+	return nil
+}
+
+// IdentityData ...
+func (c *Conn) IdentityData() login.IdentityData {
+	return c.identity
+}
+
+// ClientData ...
+func (c *Conn) ClientData() login.ClientData {
+	return login.ClientData{LanguageCode: "en_US"}
+}
+
+// ClientCacheEnabled ...
+func (c *Conn) ClientCacheEnabled() bool {
+	return false
+}
+
+// ChunkRadius ...
+func (c *Conn) ChunkRadius() int {
+	return ChunkRadius
+}
+
+// Latency ...
+func (c *Conn) Latency() time.Duration {
+	return 0
+}
+
+// Flush ...
+func (c *Conn) Flush() error {
+	return nil
+}
+
+// RemoteAddr ...
+func (c *Conn) RemoteAddr() net.Addr {
+	return &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 19132}
+}
+
+// ReadPacket returns the next packet passed to Send, or an error once the Conn is closed.
+func (c *Conn) ReadPacket() (packet.Packet, error) {
+	select {
+	case pk := <-c.in:
+		return pk, nil
+	case <-c.closed:
+		return nil, errors.New("servertest: connection closed")
+	}
+}
+
+// WritePacket records the packet passed. An error is returned if the Conn was closed.
+func (c *Conn) WritePacket(pk packet.Packet) error {
+	select {
+	case <-c.closed:
+		return errors.New("servertest: connection closed")
+	default:
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.packets = append(c.packets, pk)
+	return nil
+}
+
+// GameData returns the minecraft.GameData that the game was started with using StartGameContext, as it would
+// have been sent to the client when joining a server.
+func (c *Conn) GameData() minecraft.GameData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gameData
+}
+
+// StartGameContext records the minecraft.GameData passed, so that it may be returned by GameData.
+func (c *Conn) StartGameContext(_ context.Context, data minecraft.GameData) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gameData = data
+	return nil
+}
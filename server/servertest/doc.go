@@ -0,0 +1,25 @@
+// Package servertest provides utilities for testing code that interacts with worlds and players, such as the
+// handlers of a plugin, without a network connection or listener.
+//
+// A World created using servertest.NewWorld holds all of its data in memory and is not ticked by itself: It is
+// only ticked when World.Advance is called, so that tests run deterministically and don't depend on timing. A
+// Viewer is attached to the World, which records every call made to it, so that tests may check what would have
+// been shown to a player. Players created using World.NewPlayer have no session and move using server-side
+// physics, and are ticked by the World like any other player. Players created using World.NewSessionPlayer are
+// controlled by a session instead, which writes the packets that would be sent to the client to a Conn.
+//
+// The example below checks that a player falling 20 blocks onto stone takes fall damage:
+//
+//	func TestFallDamage(t *testing.T) {
+//		w := servertest.NewWorld()
+//		defer w.Close()
+//
+//		w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+//		p := w.NewPlayer("Steve", mgl64.Vec3{0.5, 21, 0.5})
+//
+//		w.Advance(60)
+//		if !p.OnGround() || p.Health() >= p.MaxHealth() {
+//			t.Errorf("expected player to land and take fall damage, health is %v", p.Health())
+//		}
+//	}
+package servertest
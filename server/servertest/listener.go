@@ -0,0 +1,56 @@
+package servertest
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/session"
+	"sync"
+)
+
+// Listener is a server.Listener that accepts the Conns connected to it using Connect, so that players may join a
+// server.Server without a network connection. A Listener is safe for concurrent use.
+type Listener struct {
+	conns  chan *Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewListener creates a Listener that accepts no connections until Connect is called.
+func NewListener() *Listener {
+	return &Listener{conns: make(chan *Conn), closed: make(chan struct{})}
+}
+
+// Connect connects a client with the name passed to the Listener and returns its Conn once it was accepted. False
+// is returned if the Listener was closed before it accepted the Conn.
+func (l *Listener) Connect(name string) (*Conn, bool) {
+	conn := NewConn(name)
+	select {
+	case l.conns <- conn:
+		return conn, true
+	case <-l.closed:
+		return nil, false
+	}
+}
+
+// Accept blocks until a client connects using Connect and returns its Conn. An error is returned once the Listener
+// is closed.
+func (l *Listener) Accept() (session.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errors.New("servertest: listener closed")
+	}
+}
+
+// Disconnect closes the Conn passed.
+func (l *Listener) Disconnect(conn session.Conn, _ string) error {
+	return conn.Close()
+}
+
+// Close closes the Listener, after which no more connections are accepted.
+func (l *Listener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
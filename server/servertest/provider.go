@@ -0,0 +1,167 @@
+package servertest
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"sync"
+)
+
+// Provider is a world.EntityDataProvider and world.ChunkTickProvider that holds all data saved to it in memory,
+// so that worlds may be closed and loaded again in tests without touching the disk. A Provider is safe for
+// concurrent use.
+type Provider struct {
+	mu       sync.Mutex
+	settings *world.Settings
+	chunks   map[world.ChunkPos]*chunk.Chunk
+	entities map[world.ChunkPos][]map[string]interface{}
+	blockNBT map[world.ChunkPos][]map[string]interface{}
+	ticks    map[world.ChunkPos]int64
+}
+
+// NewProvider creates an empty Provider.
+func NewProvider() *Provider {
+	return &Provider{
+		chunks:   map[world.ChunkPos]*chunk.Chunk{},
+		entities: map[world.ChunkPos][]map[string]interface{}{},
+		blockNBT: map[world.ChunkPos][]map[string]interface{}{},
+		ticks:    map[world.ChunkPos]int64{},
+	}
+}
+
+// Settings copies the Settings last saved to the Provider into the Settings passed. If no Settings were saved
+// yet, the Settings passed are left unchanged.
+func (p *Provider) Settings(s *world.Settings) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.settings != nil {
+		copySettings(s, p.settings)
+	}
+}
+
+// SaveSettings saves a copy of the Settings passed.
+func (p *Provider) SaveSettings(s *world.Settings) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.settings == nil {
+		p.settings = &world.Settings{}
+	}
+	copySettings(p.settings, s)
+}
+
+// copySettings copies the exported fields of the Settings src into dst.
+func copySettings(dst, src *world.Settings) {
+	dst.Name, dst.Seed, dst.Spawn, dst.SpawnRadius = src.Name, src.Seed, src.Spawn, src.SpawnRadius
+	dst.Time, dst.TimeCycle, dst.CurrentTick = src.Time, src.TimeCycle, src.CurrentTick
+	dst.RainTime, dst.Raining, dst.ThunderTime, dst.Thundering = src.RainTime, src.Raining, src.ThunderTime, src.Thundering
+	dst.WeatherCycle, dst.DefaultGameMode, dst.Difficulty, dst.TickRange = src.WeatherCycle, src.DefaultGameMode, src.Difficulty, src.TickRange
+	dst.Data = make(map[string]interface{}, len(src.Data))
+	for k, v := range src.Data {
+		dst.Data[k] = v
+	}
+}
+
+// LoadChunk returns the chunk last saved at the position passed, if any.
+func (p *Provider) LoadChunk(pos world.ChunkPos) (*chunk.Chunk, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c, ok := p.chunks[pos]
+	return c, ok, nil
+}
+
+// SaveChunk saves the chunk passed at the position passed.
+func (p *Provider) SaveChunk(pos world.ChunkPos, c *chunk.Chunk) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.chunks[pos] = c
+	return nil
+}
+
+// LoadEntities always returns no entities. Worlds load the entities of a Provider using LoadEntityData.
+func (p *Provider) LoadEntities(world.ChunkPos) ([]world.SaveableEntity, error) {
+	return nil, nil
+}
+
+// SaveEntities does nothing. Worlds save the entities of a Provider using SaveEntityData.
+func (p *Provider) SaveEntities(world.ChunkPos, []world.SaveableEntity) error {
+	return nil
+}
+
+// LoadEntityData returns the entity data last saved in the chunk at the position passed.
+func (p *Provider) LoadEntityData(pos world.ChunkPos) ([]map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.entities[pos], nil
+}
+
+// SaveEntityData saves the entity data passed in the chunk at the position passed.
+func (p *Provider) SaveEntityData(pos world.ChunkPos, data []map[string]interface{}) error {
+	data, err := copyNBT(data)
+	if err != nil {
+		return fmt.Errorf("save entities: %w", err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entities[pos] = data
+	return nil
+}
+
+// LoadBlockNBT returns the block entity data last saved in the chunk at the position passed.
+func (p *Provider) LoadBlockNBT(pos world.ChunkPos) ([]map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.blockNBT[pos], nil
+}
+
+// SaveBlockNBT saves the block entity data passed in the chunk at the position passed.
+func (p *Provider) SaveBlockNBT(pos world.ChunkPos, data []map[string]interface{}) error {
+	data, err := copyNBT(data)
+	if err != nil {
+		return fmt.Errorf("save block NBT: %w", err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.blockNBT[pos] = data
+	return nil
+}
+
+// copyNBT encodes the NBT data passed and decodes it again, so that the data loaded from the Provider has the
+// same types as data loaded from disk, such as []interface{} for lists.
+func copyNBT(data []map[string]interface{}) ([]map[string]interface{}, error) {
+	c := make([]map[string]interface{}, 0, len(data))
+	for _, m := range data {
+		b, err := nbt.MarshalEncoding(m, nbt.LittleEndian)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding NBT: %w", err)
+		}
+		var decoded map[string]interface{}
+		if err := nbt.UnmarshalEncoding(b, &decoded, nbt.LittleEndian); err != nil {
+			return nil, fmt.Errorf("error decoding NBT: %w", err)
+		}
+		c = append(c, decoded)
+	}
+	return c, nil
+}
+
+// LoadChunkTick returns the tick last saved for the chunk at the position passed, if any.
+func (p *Provider) LoadChunkTick(pos world.ChunkPos) (int64, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	tick, ok := p.ticks[pos]
+	return tick, ok, nil
+}
+
+// SaveChunkTick saves the tick passed for the chunk at the position passed.
+func (p *Provider) SaveChunkTick(pos world.ChunkPos, tick int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ticks[pos] = tick
+	return nil
+}
+
+// Close does nothing. The data of the Provider remains available after closing it, so that it may be used for a
+// new world.
+func (p *Provider) Close() error {
+	return nil
+}
@@ -0,0 +1,182 @@
+package servertest
+
+import (
+	blockAction "github.com/df-mc/dragonfly/server/block/action"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/action"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Call is a single call made to a Viewer. Method is the name of the method called, such as "ViewSound", and Args
+// holds the arguments that the method was called with, in order.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Viewer is a world.Viewer that records every call made to it, so that tests may check what would have been
+// shown to a player viewing the world. A Viewer is safe for concurrent use.
+type Viewer struct {
+	mu    sync.Mutex
+	pos   mgl64.Vec3
+	calls []Call
+}
+
+// Calls returns all calls made to the Viewer since it was created or since Reset was last called, in the order
+// they were made.
+func (v *Viewer) Calls() []Call {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]Call(nil), v.calls...)
+}
+
+// CallsTo returns all calls made to the method with the name passed, such as "ViewBlockUpdate", in the order
+// they were made.
+func (v *Viewer) CallsTo(method string) []Call {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var calls []Call
+	for _, c := range v.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset clears all calls recorded by the Viewer.
+func (v *Viewer) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.calls = nil
+}
+
+// record records a call to the method passed with the arguments passed.
+func (v *Viewer) record(method string, args ...interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.calls = append(v.calls, Call{Method: method, Args: args})
+}
+
+// Position returns the position of the Viewer. Blocks around this position are ticked randomly.
+func (v *Viewer) Position() mgl64.Vec3 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.pos
+}
+
+// setPosition changes the position of the Viewer.
+func (v *Viewer) setPosition(pos mgl64.Vec3) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.pos = pos
+}
+
+// ViewEntity ...
+func (v *Viewer) ViewEntity(e world.Entity) {
+	v.record("ViewEntity", e)
+}
+
+// HideEntity ...
+func (v *Viewer) HideEntity(e world.Entity) {
+	v.record("HideEntity", e)
+}
+
+// ViewEntityMovement ...
+func (v *Viewer) ViewEntityMovement(e world.Entity, pos mgl64.Vec3, yaw, pitch float64, onGround bool) {
+	v.record("ViewEntityMovement", e, pos, yaw, pitch, onGround)
+}
+
+// ViewEntityVelocity ...
+func (v *Viewer) ViewEntityVelocity(e world.Entity, velocity mgl64.Vec3) {
+	v.record("ViewEntityVelocity", e, velocity)
+}
+
+// ViewEntityTeleport ...
+func (v *Viewer) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
+	v.record("ViewEntityTeleport", e, position)
+}
+
+// ViewEntityMount ...
+func (v *Viewer) ViewEntityMount(r world.Entity, rd world.Entity, driver bool) {
+	v.record("ViewEntityMount", r, rd, driver)
+}
+
+// ViewEntityDismount ...
+func (v *Viewer) ViewEntityDismount(r world.Entity, rd world.Entity) {
+	v.record("ViewEntityDismount", r, rd)
+}
+
+// ViewChunk ...
+func (v *Viewer) ViewChunk(pos world.ChunkPos, c *chunk.Chunk, blockNBT map[cube.Pos]world.Block) {
+	v.record("ViewChunk", pos, c, blockNBT)
+}
+
+// ViewTime ...
+func (v *Viewer) ViewTime(time int) {
+	v.record("ViewTime", time)
+}
+
+// ViewEntityItems ...
+func (v *Viewer) ViewEntityItems(e world.Entity) {
+	v.record("ViewEntityItems", e)
+}
+
+// ViewEntityArmour ...
+func (v *Viewer) ViewEntityArmour(e world.Entity) {
+	v.record("ViewEntityArmour", e)
+}
+
+// ViewEntityAction ...
+func (v *Viewer) ViewEntityAction(e world.Entity, a action.Action) {
+	v.record("ViewEntityAction", e, a)
+}
+
+// ViewEntityState ...
+func (v *Viewer) ViewEntityState(e world.Entity) {
+	v.record("ViewEntityState", e)
+}
+
+// ViewParticle ...
+func (v *Viewer) ViewParticle(pos mgl64.Vec3, p world.Particle) {
+	v.record("ViewParticle", pos, p)
+}
+
+// ViewSound ...
+func (v *Viewer) ViewSound(pos mgl64.Vec3, s world.Sound) {
+	v.record("ViewSound", pos, s)
+}
+
+// ViewBlockUpdate ...
+func (v *Viewer) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
+	v.record("ViewBlockUpdate", pos, b, layer)
+}
+
+// ViewBlockAction ...
+func (v *Viewer) ViewBlockAction(pos cube.Pos, a blockAction.Action) {
+	v.record("ViewBlockAction", pos, a)
+}
+
+// ViewEmote ...
+func (v *Viewer) ViewEmote(player world.Entity, emote uuid.UUID) {
+	v.record("ViewEmote", player, emote)
+}
+
+// ViewSkin ...
+func (v *Viewer) ViewSkin(e world.Entity) {
+	v.record("ViewSkin", e)
+}
+
+// ViewWorldSpawn ...
+func (v *Viewer) ViewWorldSpawn(pos cube.Pos) {
+	v.record("ViewWorldSpawn", pos)
+}
+
+// ViewWeather ...
+func (v *Viewer) ViewWeather(raining, thunder bool) {
+	v.record("ViewWeather", raining, thunder)
+}
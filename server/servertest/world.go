@@ -0,0 +1,142 @@
+package servertest
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	_ "github.com/df-mc/dragonfly/server/world/biome" // Imported so that biomes are registered.
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/atomic"
+	"math"
+	"sync"
+)
+
+// World is a world.World that is only ticked when Advance is called. A Viewer is attached to it, which views
+// the chunks within ChunkRadius of its focus, so that blocks and entities in these chunks are ticked.
+type World struct {
+	*world.World
+
+	prov   *Provider
+	viewer *Viewer
+	loader *world.Loader
+	log    *logrus.Logger
+
+	// sessions is done once the sessions of all players created using NewSessionPlayer have stopped.
+	sessions sync.WaitGroup
+}
+
+// ChunkRadius is the radius in chunks around the focus of a World within which chunks are loaded and ticked.
+const ChunkRadius = 4
+
+// NewWorld creates a new empty overworld that holds its data in memory. Chunks around the origin of the world
+// are loaded and viewed by its Viewer.
+func NewWorld() *World {
+	return NewWorldWithProvider(world.Overworld, NewProvider())
+}
+
+// NewWorldWithProvider creates a new World of the world.Dimension passed that loads and saves its data using
+// the Provider passed. It may be used to load a world again using the Provider of a World that was closed.
+// The weather cycle of the World is stopped, so that it never starts raining unexpectedly during a test. It may
+// be started again using world.World.StartWeatherCycle.
+func NewWorldWithProvider(d world.Dimension, prov *Provider) *World {
+	log := logrus.New()
+	log.Level = logrus.WarnLevel
+
+	w := &World{World: world.New(log, d, nil), prov: prov, viewer: &Viewer{}, log: log}
+	w.ManualTicking()
+	w.World.Provider(prov)
+	w.StopWeatherCycle()
+	w.loader = world.NewLoader(ChunkRadius, w.World, w.viewer)
+	w.Focus(mgl64.Vec3{})
+	return w
+}
+
+// Provider returns the Provider that the World saves its data to.
+func (w *World) Provider() *Provider {
+	return w.prov
+}
+
+// Viewer returns the Viewer attached to the World, which records everything shown to it.
+func (w *World) Viewer() *Viewer {
+	return w.viewer
+}
+
+// Focus moves the focus of the World to the position passed, loading all chunks within ChunkRadius of it and
+// unloading chunks further away. Only blocks and entities in these chunks are ticked.
+func (w *World) Focus(pos mgl64.Vec3) {
+	w.viewer.setPosition(pos)
+	w.loader.Move(pos)
+	if err := w.loader.Load(math.MaxInt32); err != nil {
+		panic(err)
+	}
+}
+
+// Advance ticks the World n times, synchronously. Every tick, the time of the World advances and blocks and
+// entities within ChunkRadius of the focus of the World are ticked, after which scheduled tasks are run.
+// Entities are ticked in the order in which they were added to the World, so that advancing two Worlds set up
+// in the same way leads to the same result.
+func (w *World) Advance(n int) {
+	for i := 0; i < n; i++ {
+		w.Tick()
+	}
+}
+
+// Fill sets all blocks in the cube between the positions a and b, inclusive, to the block passed.
+func (w *World) Fill(a, b cube.Pos, bl world.Block) {
+	for i := 0; i < 3; i++ {
+		if a[i] > b[i] {
+			a[i], b[i] = b[i], a[i]
+		}
+	}
+	for x := a[0]; x <= b[0]; x++ {
+		for y := a[1]; y <= b[1]; y++ {
+			for z := a[2]; z <= b[2]; z++ {
+				w.SetBlock(cube.Pos{x, y, z}, bl)
+			}
+		}
+	}
+}
+
+// NewPlayer creates a player with the name passed at the position passed and adds it to the World. The player
+// has no session: It moves using server-side physics every tick and is shown to the Viewer of the World like any
+// other player. Handlers may be attached to it using player.Player.Handle.
+func (w *World) NewPlayer(name string, pos mgl64.Vec3) *player.Player {
+	p := player.New(name, skin.New(64, 32), pos)
+	w.AddEntity(p)
+	return p
+}
+
+// NewSessionPlayer creates a player with the name passed at the position passed, controlled by a session that
+// reads from and writes to the Conn returned, and adds it to the World. Unlike players created using NewPlayer,
+// the player has a session like a player connected to a server, so the packets that would have been sent to its
+// client may be checked using Conn.Packets. The player is in survival mode. Closing the player or the Conn
+// returned stops the session.
+func (w *World) NewSessionPlayer(name string, pos mgl64.Vec3) (*player.Player, *Conn) {
+	conn := NewConn(name)
+	id, _ := uuid.Parse(conn.IdentityData().Identity)
+	s := session.New(conn, ChunkRadius, w.log, atomic.NewString(""), atomic.NewString(""), session.RateLimits{}, nil)
+	p := player.NewWithSession(name, "", id, skin.New(64, 32), s, pos, nil)
+
+	w.sessions.Add(1)
+	s.Start(p, w.World, world.GameModeSurvival, func(session.Controllable) {
+		w.sessions.Done()
+	})
+	return p, conn
+}
+
+// Close closes the loader of the World and the World itself, saving its data to its Provider. The sessions of
+// players created using NewSessionPlayer are stopped first.
+func (w *World) Close() error {
+	for _, e := range w.Entities() {
+		if p, ok := e.(*player.Player); ok && p.Session() != session.Nop {
+			_ = p.Close()
+		}
+	}
+	w.sessions.Wait()
+	_ = w.loader.Close()
+	return w.World.Close()
+}
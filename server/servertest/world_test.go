@@ -0,0 +1,57 @@
+package servertest_test
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// TestAdvanceDeterministic checks that advancing two worlds set up in the same way leads to the same state after
+// every tick: The time, the position and health of a falling player, the blocks of a falling column of sand and
+// the methods called on the Viewer must all be equal.
+func TestAdvanceDeterministic(t *testing.T) {
+	run := func() []string {
+		w := servertest.NewWorld()
+		defer w.Close()
+
+		w.Fill(cube.Pos{-8, 0, -8}, cube.Pos{8, 0, 8}, block.Stone{})
+		w.Fill(cube.Pos{3, 4, 3}, cube.Pos{3, 4, 3}, block.Stone{})
+		w.Fill(cube.Pos{3, 5, 3}, cube.Pos{3, 10, 3}, block.Sand{})
+		p := w.NewPlayer("faller", mgl64.Vec3{0.5, 21, 0.5})
+		defer p.Close()
+		w.BreakBlockWithoutParticles(cube.Pos{3, 4, 3})
+		w.Viewer().Reset()
+
+		var states []string
+		for i := 0; i < 100; i++ {
+			w.Advance(1)
+			var methods []string
+			for _, c := range w.Viewer().Calls() {
+				methods = append(methods, c.Method)
+			}
+			w.Viewer().Reset()
+
+			var sand []cube.Pos
+			for y := 0; y <= 10; y++ {
+				if _, ok := w.Block(cube.Pos{3, y, 3}).(block.Sand); ok {
+					sand = append(sand, cube.Pos{3, y, 3})
+				}
+			}
+			states = append(states, fmt.Sprint(w.Time(), p.Position(), p.Health(), sand, methods))
+		}
+		return states
+	}
+
+	a, b := run(), run()
+	if len(a) != len(b) {
+		t.Fatalf("worlds advanced a different number of ticks: %v and %v", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("state after tick %v differs:\n%v\n%v", i+1, a[i], b[i])
+		}
+	}
+}
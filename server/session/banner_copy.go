@@ -0,0 +1,75 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+const (
+	// craftingGridFirstSlot and craftingGridLastSlot are the first and last slot in the UI inventory of the 2x2
+	// crafting grid in the inventory of the player.
+	craftingGridFirstSlot, craftingGridLastSlot = 0x1c, 0x1f
+	// bannerCopyNetworkID is the network ID of the recipe used to copy the patterns of a banner onto a blank
+	// banner in the crafting grid. It is chosen so that it does not collide with the network IDs of smithing
+	// recipes.
+	bannerCopyNetworkID = 0xffff
+)
+
+// bannerCopyRecipe is the multi recipe that allows copying the patterns of a banner onto a blank banner of the
+// same colour in the crafting grid. The client computes the result of the recipe itself.
+var bannerCopyRecipe = &protocol.MultiRecipe{
+	UUID:            uuid.MustParse("b5c5d105-75a2-4076-af2b-923ea2bf4bf0"),
+	RecipeNetworkID: bannerCopyNetworkID,
+}
+
+// handleBannerCopy handles the copying of the patterns of a banner onto a blank banner of the same colour in the
+// crafting grid. The blank banner is consumed and the copy is placed in the output slot in the same request. The
+// banner copied from is left in the crafting grid.
+func (h *ItemStackRequestHandler) handleBannerCopy(s *Session) error {
+	var (
+		source, blank           block.Banner
+		blankSlot               protocol.StackRequestSlotInfo
+		sourceStack             item.Stack
+		foundSource, foundBlank bool
+	)
+	for sl := byte(craftingGridFirstSlot); sl <= craftingGridLastSlot; sl++ {
+		slot := protocol.StackRequestSlotInfo{ContainerID: containerCraftingGrid, Slot: sl}
+		it, _ := h.itemInSlot(slot, s)
+		if it.Empty() {
+			continue
+		}
+		b, ok := it.Item().(block.Banner)
+		if !ok {
+			return fmt.Errorf("item %v in crafting grid is not a banner", it)
+		}
+		switch {
+		case len(b.Patterns) != 0 && !foundSource:
+			source, sourceStack, foundSource = b, it, true
+		case len(b.Patterns) == 0 && !b.Illager && !foundBlank:
+			blank, blankSlot, foundBlank = b, slot, true
+		default:
+			return fmt.Errorf("crafting grid holds more than one banner to copy from and one blank banner")
+		}
+	}
+	if !foundSource || !foundBlank {
+		return fmt.Errorf("copying a banner requires a banner with patterns and a blank banner")
+	}
+	if source.Colour != blank.Colour {
+		return fmt.Errorf("cannot copy banner of colour %v onto banner of colour %v", source.Colour, blank.Colour)
+	}
+	if source.Illager {
+		return fmt.Errorf("ominous banners cannot be copied")
+	}
+
+	result := item.NewStack(source, 1).WithCustomName(sourceStack.CustomName()).WithLore(sourceStack.Lore()...)
+	h.consumeInput(blankSlot, 1, s)
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID:    containerCreativeOutput,
+		Slot:           50,
+		StackNetworkID: item_id(result),
+	}, result, s)
+	return nil
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+// ClientSettings holds settings of the client of a Session that may change while it is connected, such as its
+// render distance. The locale of the client is not part of its ClientSettings: Bedrock clients reconnect when
+// they change their language, so it is fixed for the lifetime of a Session.
+type ClientSettings struct {
+	// ChunkRadius is the render distance of the client in chunks. It is limited by the maximum chunk radius of the
+	// server, so that it never exceeds the render distance that the client is able to display.
+	ChunkRadius int
+	// InputMode is the way the client currently provides input, such as using a mouse and keyboard or using a
+	// controller. Players may switch between input modes while connected.
+	InputMode InputMode
+}
+
+// ClientSettings returns the current ClientSettings of the client of the Session.
+func (s *Session) ClientSettings() ClientSettings {
+	return ClientSettings{ChunkRadius: int(s.chunkRadius), InputMode: InputMode(s.inputMode.Load())}
+}
+
+// ClientInfo holds information on the client of a Session that does not change while it is connected, such as
+// the device that it is playing on.
+type ClientInfo struct {
+	// DeviceOS is the operating system of the device that the client is playing on.
+	DeviceOS protocol.DeviceOS
+	// DeviceModel is the model of the device that the client is playing on, such as 'iPhone12,1'. It is
+	// generally empty for devices other than phones and tablets.
+	DeviceModel string
+	// GameVersion is the version of the game of the client, such as '1.18.1'.
+	GameVersion string
+	// ProtocolVersion is the version of the protocol that the client is connected with.
+	ProtocolVersion int32
+	// UIProfile is the user interface profile selected by the client. Forms and other user interfaces might be
+	// laid out differently depending on the profile.
+	UIProfile UIProfile
+	// DefaultInputMode is the input mode that is used by default on the device of the client.
+	DefaultInputMode InputMode
+}
+
+// ClientInfo returns the ClientInfo of the client of the Session. A zero ClientInfo is returned for the Nop
+// Session.
+func (s *Session) ClientInfo() ClientInfo {
+	if s == Nop {
+		return ClientInfo{}
+	}
+	d := s.ClientData()
+	return ClientInfo{
+		DeviceOS:         d.DeviceOS,
+		DeviceModel:      d.DeviceModel,
+		GameVersion:      d.GameVersion,
+		ProtocolVersion:  protocol.CurrentProtocol,
+		UIProfile:        UIProfile(d.UIProfile),
+		DefaultInputMode: InputMode(d.DefaultInputMode),
+	}
+}
+
+// InputMode is a way in which a client provides input, such as using a touch screen or a controller.
+type InputMode int
+
+const (
+	// InputModeMouse is the input mode of clients playing with a mouse and keyboard.
+	InputModeMouse InputMode = iota + 1
+	// InputModeTouch is the input mode of clients playing on a touch screen.
+	InputModeTouch
+	// InputModeGamePad is the input mode of clients playing with a controller.
+	InputModeGamePad
+	// InputModeMotionController is the input mode of clients playing in virtual reality with motion controllers.
+	InputModeMotionController
+)
+
+// UIProfile is a user interface profile that a client may select in its settings.
+type UIProfile int
+
+const (
+	// UIProfileClassic is the user interface profile used by default on desktop and console devices.
+	UIProfileClassic UIProfile = iota
+	// UIProfilePocket is the user interface profile used by default on phones and tablets. It generally has larger
+	// buttons and less space for text.
+	UIProfilePocket
+)
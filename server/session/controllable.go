@@ -5,7 +5,9 @@ import (
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/experience"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/trade"
 	"github.com/df-mc/dragonfly/server/player/form"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/world"
@@ -25,6 +27,7 @@ type Controllable interface {
 	SetHeldItems(right, left item.Stack)
 
 	Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
+	UpdateInput(moveVector mgl64.Vec2, jumping, sneaking, sprinting bool)
 	Speed() float64
 	Facing() cube.Direction
 
@@ -32,20 +35,28 @@ type Controllable interface {
 	ExecuteCommand(commandLine string)
 	GameMode() world.GameMode
 	SetGameMode(mode world.GameMode)
+	AllowsFlight() bool
+	WorldBuilder() bool
+	Muted() bool
 	Effects() []effect.Effect
+	ExperienceLevel() (level int, progress float64)
+	AddExperience(amount int, src experience.Source) int
 
 	UseItem()
 	ReleaseItem()
+	AbortItemUse()
 	UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3)
 	UseItemOnEntity(e world.Entity)
 	BreakBlock(pos cube.Pos)
 	PickBlock(pos cube.Pos)
+	PickBlockWithData(pos cube.Pos)
 	AttackEntity(e world.Entity)
 	Drop(s item.Stack) (n int)
 	SwingArm()
 	PunchAir()
 
 	Respawn()
+	WakeUp()
 
 	StartSneaking()
 	Sneaking() bool
@@ -67,13 +78,33 @@ type Controllable interface {
 
 	StartBreaking(pos cube.Pos, face cube.Face)
 	ContinueBreaking(face cube.Face)
-	FinishBreaking()
+	FinishBreaking(pos cube.Pos)
 	AbortBreaking()
 
 	Exhaust(points float64)
 
 	EditSign(pos cube.Pos, text string) error
 
+	// UseGrindstone is called when the Controllable takes the result of a grindstone at the position passed. The
+	// result and the experience refunded for the enchantments removed are passed. The item to hand out is returned,
+	// along with false if the use of the grindstone was cancelled.
+	UseGrindstone(pos cube.Pos, result item.Stack, experience int) (item.Stack, bool)
+	// UseSmithingTable is called when the Controllable upgrades the input item passed using the material passed
+	// in the smithing table at the position passed. The item to hand out is returned, along with false if the use
+	// of the smithing table was cancelled.
+	UseSmithingTable(pos cube.Pos, input, material, result item.Stack) (item.Stack, bool)
+	// TradeWith is called when the Controllable executes the trade passed, offered by the trade.Trader passed.
+	// False is returned if the trade was cancelled.
+	TradeWith(t trade.Trader, tr trade.Trade) bool
+
+	// ClientSettingsChanged is called when the client changes one of its ClientSettings while connected. The
+	// settings before and after the change are passed.
+	ClientSettingsChanged(before, after ClientSettings)
+
+	// RateLimitExceeded is called when the controllable exceeds a RateLimit with the RateLimitEscalate mode. The
+	// action that exceeded the limit is dropped.
+	RateLimitExceeded(a RateLimitedAction, usage RateUsage)
+
 	// UUID returns the UUID of the controllable. It must be unique for all controllable entities present in
 	// the server.
 	UUID() uuid.UUID
@@ -85,3 +116,13 @@ type Controllable interface {
 	Skin() skin.Skin
 	SetSkin(skin.Skin)
 }
+
+// Humanoid represents an entity that is shown to viewers as a player, with a skin and a name. Every Controllable
+// entity is a Humanoid, but entities without a Session, such as NPCs, may implement Humanoid too.
+type Humanoid interface {
+	world.Entity
+	// UUID returns the UUID of the humanoid. It must be unique for all humanoid entities present in the server.
+	UUID() uuid.UUID
+	// Skin returns the skin of the humanoid, which defines how the entity looks in the world.
+	Skin() skin.Skin
+}
@@ -1,10 +1,14 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"image/color"
 	"time"
 )
@@ -15,7 +19,7 @@ type entityMetadata map[uint32]interface{}
 
 // parseEntityMetadata returns an entity metadata object with default values. It is equivalent to setting
 // all properties to their default values and disabling all flags.
-func parseEntityMetadata(e world.Entity) entityMetadata {
+func (s *Session) parseEntityMetadata(e world.Entity) entityMetadata {
 	m := entityMetadata{}
 
 	bb := e.AABB()
@@ -42,15 +46,25 @@ func parseEntityMetadata(e world.Entity) entityMetadata {
 	if i, ok := e.(invisible); ok && i.Invisible() {
 		m.setFlag(dataKeyFlags, dataFlagInvisible)
 	}
-	if i, ok := e.(immobile); ok && i.Immobile() {
+	if i, ok := e.(immobile); (ok && i.Immobile()) || s.controlledPreloading(e) {
 		m.setFlag(dataKeyFlags, dataFlagNoAI)
 	}
 	if o, ok := e.(onFire); ok && o.OnFireDuration() > 0 {
 		m.setFlag(dataKeyFlags, dataFlagOnFire)
 	}
 	if u, ok := e.(using); ok && u.UsingItem() {
+		// Using items covers the use animations of items used over a longer duration, such as eating food and
+		// charging a crossbow.
 		m.setFlag(dataKeyFlags, dataFlagUsingItem)
 	}
+	if s, ok := e.(sleeper); ok {
+		m[dataKeyPlayerFlags] = byte(0)
+		if pos, sleeping := s.BedPosition(); sleeping {
+			m[dataKeyPlayerFlags] = byte(1 << dataPlayerFlagSleeping)
+			m[dataKeyPlayerBedPosition] = protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+			m.setFlag(dataKeyFlagsExtended, dataFlagSleeping%64)
+		}
+	}
 	if s, ok := e.(scaled); ok {
 		m[dataKeyScale] = float32(s.Scale())
 	}
@@ -61,11 +75,39 @@ func parseEntityMetadata(e world.Entity) entityMetadata {
 			m.setFlag(dataKeyFlags, dataFlagRiding)
 		}
 	}
+	if l, ok := e.(entity.Leashable); ok {
+		if holder, ok := l.LeashHolder(); ok {
+			m[dataKeyLeashHolder] = int64(s.entityRuntimeID(holder))
+			m.setFlag(dataKeyFlags, dataFlagLeashed)
+		}
+	}
 	if n, ok := e.(named); ok {
-		m[dataKeyNameTag] = n.NameTag()
-		m[dataKeyAlwaysShowNameTag] = uint8(1)
-		m.setFlag(dataKeyFlags, dataFlagAlwaysShowNameTag)
-		m.setFlag(dataKeyFlags, dataFlagCanShowNameTag)
+		visibility := entity.NameTagAlways
+		if v, ok := e.(nameTagVisible); ok {
+			visibility = v.NameTagVisibility()
+		}
+		sneaking := false
+		if s, ok := e.(sneaker); ok {
+			sneaking = s.Sneaking()
+		}
+		switch {
+		case visibility == entity.NameTagNever:
+			m[dataKeyNameTag] = ""
+			m[dataKeyAlwaysShowNameTag] = uint8(0)
+		case visibility == entity.NameTagWhenNotSneaking && sneaking:
+			// The name tag is only shown when looking directly at the entity, similar to vanilla.
+			m[dataKeyNameTag] = n.NameTag()
+			m[dataKeyAlwaysShowNameTag] = uint8(0)
+			m.setFlag(dataKeyFlags, dataFlagCanShowNameTag)
+		default:
+			m[dataKeyNameTag] = n.NameTag()
+			m[dataKeyAlwaysShowNameTag] = uint8(1)
+			m.setFlag(dataKeyFlags, dataFlagAlwaysShowNameTag)
+			m.setFlag(dataKeyFlags, dataFlagCanShowNameTag)
+		}
+	}
+	if s, ok := e.(scoreTagged); ok {
+		m[dataKeyScoreTag] = s.ScoreTag()
 	}
 	if s, ok := e.(splash); ok {
 		pot := s.Type()
@@ -74,6 +116,28 @@ func parseEntityMetadata(e world.Entity) entityMetadata {
 			m.setFlag(dataKeyFlags, dataFlagEnchanted)
 		}
 	}
+	if t, ok := e.(tipped); ok {
+		if tip := t.Tip(); len(tip.Effects()) > 0 {
+			m[dataKeyCustomDisplay] = tip.Uint8() + 1
+			// The potion colour makes the client show a trail of particles in the colour of the effects of the
+			// tip behind the arrow.
+			colour, _ := effect.ResultingColour(tip.Effects())
+			m[dataKeyPotionColour] = (int32(colour.A) << 24) | (int32(colour.R) << 16) | (int32(colour.G) << 8) | int32(colour.B)
+		}
+	}
+	if c, ok := e.(critical); ok && c.Critical() {
+		m.setFlag(dataKeyFlags, dataFlagCritical)
+	}
+	if c, ok := e.(cloud); ok {
+		m[dataKeyAreaEffectCloudRadius] = float32(c.Radius())
+		m[dataKeyAreaEffectCloudWaiting] = byte(0)
+		if c.Waiting() {
+			m[dataKeyAreaEffectCloudWaiting] = byte(1)
+		}
+	}
+	if f, ok := e.(firework); ok {
+		m[dataKeyFireworkItem] = nbtconv.WriteItem(item.NewStack(f.Firework(), 1), false)
+	}
 	if eff, ok := e.(effectBearer); ok && len(eff.Effects()) > 0 {
 		colour, am := effect.ResultingColour(eff.Effects())
 		if (colour != color.RGBA{}) {
@@ -111,12 +175,23 @@ const (
 	dataKeyAir
 	dataKeyPotionColour
 	dataKeyPotionAmbient
+	dataKeyFireworkItem      = 16
+	dataKeyCustomDisplay     = 18
+	dataKeyPlayerFlags       = 26
+	dataKeyPlayerBedPosition = 28
 	dataKeyPotionAuxValue    = 36
+	dataKeyLeashHolder       = 37
 	dataKeyScale             = 38
 	dataKeyBoundingBoxWidth  = 53
 	dataKeyBoundingBoxHeight = 54
 	dataKeyRiderSeatPosition = 56
+
+	dataKeyAreaEffectCloudRadius  = 61
+	dataKeyAreaEffectCloudWaiting = 62
+
 	dataKeyAlwaysShowNameTag = 81
+	dataKeyScoreTag          = 84
+	dataKeyFlagsExtended     = 92
 )
 
 //noinspection GoUnusedConst
@@ -127,16 +202,29 @@ const (
 	dataFlagSprinting
 	dataFlagUsingItem
 	dataFlagInvisible
+	dataFlagCritical          = 13
 	dataFlagCanShowNameTag    = 14
 	dataFlagAlwaysShowNameTag = 15
 	dataFlagNoAI              = 16
 	dataFlagCanClimb          = 19
+	dataFlagLeashed           = 30
 	dataFlagBreathing         = 35
 	dataFlagAffectedByGravity = 48
 	dataFlagEnchanted         = 51
 	dataFlagSwimming          = 56
+	dataFlagSleeping          = 75
 )
 
+const dataPlayerFlagSleeping = 1
+
+type critical interface {
+	Critical() bool
+}
+
+type sleeper interface {
+	BedPosition() (cube.Pos, bool)
+}
+
 type sneaker interface {
 	Sneaking() bool
 }
@@ -169,6 +257,14 @@ type named interface {
 	NameTag() string
 }
 
+type nameTagVisible interface {
+	NameTagVisibility() entity.NameTagVisibility
+}
+
+type scoreTagged interface {
+	ScoreTag() string
+}
+
 type splash interface {
 	Type() potion.Potion
 }
@@ -177,10 +273,23 @@ type onFire interface {
 	OnFireDuration() time.Duration
 }
 
+type tipped interface {
+	Tip() potion.Potion
+}
+
+type cloud interface {
+	Radius() float64
+	Waiting() bool
+}
+
 type effectBearer interface {
 	Effects() []effect.Effect
 }
 
+type firework interface {
+	Firework() item.Firework
+}
+
 type using interface {
 	UsingItem() bool
 }
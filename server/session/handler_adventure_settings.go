@@ -15,13 +15,13 @@ func (a AdventureSettingsHandler) Handle(p packet.Packet, s *Session) error {
 
 	mode := s.c.GameMode()
 	if pk.Flags&packet.AdventureFlagFlying != 0 {
-		if !mode.AllowsFlying() {
+		if !s.c.AllowsFlight() {
 			s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: flying flag enabled while not being able to fly\n", s.conn.RemoteAddr(), s.c.Name())
 			return nil
 		}
 		s.c.StartFlying()
 	}
-	if pk.Flags&packet.AdventureFlagAllowFlight != 0 && !mode.AllowsFlying() {
+	if pk.Flags&packet.AdventureFlagAllowFlight != 0 && !s.c.AllowsFlight() {
 		s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: allow flight flag enabled while not being able to fly\n", s.conn.RemoteAddr(), s.c.Name())
 		return nil
 	}
@@ -29,15 +29,15 @@ func (a AdventureSettingsHandler) Handle(p packet.Packet, s *Session) error {
 		s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: no clip flag enabled while not being able to no clip\n", s.conn.RemoteAddr(), s.c.Name())
 		return nil
 	}
-	if pk.Flags&packet.AdventureFlagWorldImmutable != 0 && mode.AllowsEditing() {
+	if pk.Flags&packet.AdventureFlagWorldImmutable != 0 && s.c.WorldBuilder() {
 		s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: world immutable flag enabled while being able to edit the world\n", s.conn.RemoteAddr(), s.c.Name())
 		return nil
 	}
-	if pk.Flags&packet.AdventureFlagMuted != 0 && mode.Visible() {
+	if pk.Flags&packet.AdventureFlagMuted != 0 && mode.Visible() && !s.c.Muted() {
 		s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: muted flag enabled while visible\n", s.conn.RemoteAddr(), s.c.Name())
 		return nil
 	}
-	if (pk.ActionPermissions&packet.ActionPermissionMine != 0 || pk.ActionPermissions&packet.ActionPermissionBuild != 0) && !mode.AllowsEditing() {
+	if (pk.ActionPermissions&packet.ActionPermissionMine != 0 || pk.ActionPermissions&packet.ActionPermissionBuild != 0) && !s.c.WorldBuilder() {
 		s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: mine or build permission enabled while not being able to edit the world\n", s.conn.RemoteAddr(), s.c.Name())
 		return nil
 	}
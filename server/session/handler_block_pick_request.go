@@ -11,6 +11,11 @@ type BlockPickRequestHandler struct{}
 // Handle ...
 func (b BlockPickRequestHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.BlockPickRequest)
-	s.c.PickBlock(cube.Pos{int(pk.Position.X()), int(pk.Position.Y()), int(pk.Position.Z())})
+	pos := cube.Pos{int(pk.Position.X()), int(pk.Position.Y()), int(pk.Position.Z())}
+	if pk.AddBlockNBT {
+		s.c.PickBlockWithData(pos)
+		return nil
+	}
+	s.c.PickBlock(pos)
 	return nil
 }
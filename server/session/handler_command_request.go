@@ -19,6 +19,8 @@ func (h *CommandRequestHandler) Handle(p packet.Packet, s *Session) error {
 	}
 
 	h.origin = pk.CommandOrigin
-	s.c.ExecuteCommand(pk.CommandLine)
+	s.limit(ActionCommand, func() {
+		s.c.ExecuteCommand(pk.CommandLine)
+	}, nil)
 	return nil
 }
@@ -54,10 +54,7 @@ func (h *InventoryTransactionHandler) Handle(p packet.Packet, s *Session) error
 
 // resendInventories resends all inventories of the player.
 func (h *InventoryTransactionHandler) resendInventories(s *Session) {
-	s.sendInv(s.inv, protocol.WindowIDInventory)
-	s.sendInv(s.ui, protocol.WindowIDUI)
-	s.sendInv(s.offHand, protocol.WindowIDOffHand)
-	s.sendInv(s.armour.Inventory(), protocol.WindowIDArmour)
+	s.ResendInventories()
 }
 
 // handleNormalTransaction ...
@@ -113,12 +110,19 @@ func (h *InventoryTransactionHandler) handleUseItemOnEntityTransaction(data *pro
 	switch data.ActionType {
 	case protocol.UseItemOnEntityActionInteract:
 		// Check if the entity is rideable, and if so ride the entity.
-		if r, ok := e.(entity.Rideable); ok {
-			s.c.MountEntity(r)
-		}
-		s.c.UseItemOnEntity(e)
+		s.limit(ActionItemUse, func() {
+			// Players may carry other entities, but they are never mounted by interacting with them.
+			if _, player := e.(Controllable); !player {
+				if r, ok := e.(entity.Rideable); ok {
+					s.c.MountEntity(r)
+				}
+			}
+			s.c.UseItemOnEntity(e)
+		}, s.ResendHeldItems)
 	case protocol.UseItemOnEntityActionAttack:
-		s.c.AttackEntity(e)
+		s.limit(ActionAttack, func() {
+			s.c.AttackEntity(e)
+		}, nil)
 	default:
 		return fmt.Errorf("unhandled UseItemOnEntity ActionType %v", data.ActionType)
 	}
@@ -141,9 +145,16 @@ func (h *InventoryTransactionHandler) handleUseItemTransaction(data *protocol.Us
 	case protocol.UseItemActionBreakBlock:
 		s.c.BreakBlock(pos)
 	case protocol.UseItemActionClickBlock:
-		s.c.UseItemOnBlock(pos, cube.Face(data.BlockFace), vec32To64(data.ClickedPosition))
+		face := cube.Face(data.BlockFace)
+		s.limit(ActionItemUse, func() {
+			s.c.UseItemOnBlock(pos, face, vec32To64(data.ClickedPosition))
+		}, func() {
+			// The client predicted the use of the item, such as the placement of a block, so we roll it back.
+			s.ResendHeldItems()
+			s.ResendBlocks(pos.Side(face), 1)
+		})
 	case protocol.UseItemActionClickAir:
-		s.c.UseItem()
+		s.limit(ActionItemUse, s.c.UseItem, s.ResendHeldItems)
 	default:
 		return fmt.Errorf("unhandled UseItem ActionType %v", data.ActionType)
 	}
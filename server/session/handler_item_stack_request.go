@@ -5,12 +5,15 @@ import (
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/entity/experience"
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/creative"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"math"
+	"math/rand"
 	"time"
 )
 
@@ -22,6 +25,15 @@ type ItemStackRequestHandler struct {
 	responseChanges map[int32]map[byte]map[byte]responseChange
 	current         time.Time
 	ignoreDestroy   bool
+	// consumed holds the amount of items consumed from the input slots of a crafting UI by a crafting action in
+	// the current request. The client sends a ConsumeStackRequestAction for each of these slots after the
+	// crafting action, which is only accepted if the items were actually consumed.
+	consumed map[consumedSlot]int
+}
+
+// consumedSlot is a slot in a container from which items were consumed by a crafting action.
+type consumedSlot struct {
+	container, slot byte
 }
 
 // responseChange represents a change in a specific item stack response. It holds the timestamp of the
@@ -54,17 +66,26 @@ func (h *ItemStackRequestHandler) Handle(p packet.Packet, s *Session) error {
 			s.log.Debugf("failed processing packet from %v (%v): ItemStackRequest: error resolving item stack request: %v", s.conn.RemoteAddr(), s.c.Name(), err)
 		}
 	}
+	if r, ok := s.remoteInventory(); ok && !r.readOnly {
+		// Other sessions viewing the same inventory might not be notified of the changes by the inventory
+		// itself, so we resend the inventory to them.
+		viewRemoteInventory(r.inv, s)
+	}
 	return nil
 }
 
 // handleRequest resolves a single item stack request from the client.
 func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s *Session) (err error) {
 	defer func() {
+		h.consumed = nil
 		if err != nil {
 			s.log.Debugf("%v", err)
 			h.reject(req.RequestID, s)
 			return
 		}
+		if h.changes[containerGrindstoneIn] != nil || h.changes[containerGrindstoneAdd] != nil {
+			h.sendGrindstonePreview(s)
+		}
 		h.resolve(req.RequestID, s)
 		h.ignoreDestroy = false
 	}()
@@ -85,6 +106,14 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 			err = h.handleBeaconPayment(a, s)
 		case *protocol.CraftCreativeStackRequestAction:
 			err = h.handleCreativeCraft(a, s)
+		case *protocol.CraftGrindstoneRecipeStackRequestAction:
+			err = h.handleGrindstoneCraft(s)
+		case *protocol.CraftLoomRecipeStackRequestAction:
+			err = h.handleLoomCraft(a, s)
+		case *protocol.CraftRecipeStackRequestAction:
+			err = h.handleCraft(a, s)
+		case *protocol.ConsumeStackRequestAction:
+			err = h.handleConsume(a)
 		case *protocol.CraftResultsDeprecatedStackRequestAction:
 			// Don't do anything with this.
 		default:
@@ -124,6 +153,9 @@ func (h *ItemStackRequestHandler) handleTransfer(from, to protocol.StackRequestS
 	if (dest.Count()+int(count) > dest.MaxCount()) && !dest.Empty() {
 		return fmt.Errorf("client tried adding %v to item count %v, but max is %v", count, dest.Count(), dest.MaxCount())
 	}
+	if err := h.verifyUnbound(from, i, s); err != nil {
+		return err
+	}
 	if dest.Empty() {
 		dest = i.Grow(-math.MaxInt32)
 	}
@@ -141,9 +173,20 @@ func (h *ItemStackRequestHandler) handleTransfer(from, to protocol.StackRequestS
 	h.setItemInSlot(from, i.Grow(-int(count)), s)
 	h.setItemInSlot(to, dest.Grow(int(count)), s)
 
+	if from.ContainerID == containerFurnaceOutput && to.ContainerID != containerFurnaceOutput {
+		h.withdrawFurnaceExperience(int(count), s)
+	}
 	return nil
 }
 
+// withdrawFurnaceExperience pays out the experience stored in the furnace opened by the Session for n items taken
+// out of its output slot.
+func (h *ItemStackRequestHandler) withdrawFurnaceExperience(n int, s *Session) {
+	if f, ok := s.c.World().Block(s.openedPos.Load().(cube.Pos)).(block.Furnace); ok {
+		s.c.AddExperience(f.WithdrawExperience(n), experience.SourceSmelting{})
+	}
+}
+
 // handleSwap handles a Swap stack request action.
 func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction, s *Session) error {
 	if err := h.verifySlots(s, a.Source, a.Destination); err != nil {
@@ -151,6 +194,12 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 	}
 	i, _ := h.itemInSlot(a.Source, s)
 	dest, _ := h.itemInSlot(a.Destination, s)
+	if err := h.verifyUnbound(a.Source, i, s); err != nil {
+		return err
+	}
+	if err := h.verifyUnbound(a.Destination, dest, s); err != nil {
+		return err
+	}
 
 	invA, _ := s.invByID(int32(a.Source.ContainerID))
 	invB, _ := s.invByID(int32(a.Destination.ContainerID))
@@ -170,6 +219,19 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 	return nil
 }
 
+// verifyUnbound returns an error if the item passed is taken out of an armour slot while it is bound to the
+// Controllable by the Curse of Binding. Bound armour may still be removed if the Controllable has a creative
+// inventory.
+func (h *ItemStackRequestHandler) verifyUnbound(slot protocol.StackRequestSlotInfo, it item.Stack, s *Session) error {
+	if slot.ContainerID != containerArmour || s.c.GameMode().CreativeInventory() {
+		return nil
+	}
+	if _, bound := it.Enchantment(enchantment.CurseOfBinding{}); bound {
+		return fmt.Errorf("armour %v is bound by curse of binding", it)
+	}
+	return nil
+}
+
 // call uses an event.Context, slot and item.Stack to call the event handler function passed. An error is returned if
 // the event.Context was cancelled either before or after the call.
 func call(ctx *event.Context, slot int, it item.Stack, f func(ctx *event.Context, slot int, it item.Stack)) error {
@@ -206,6 +268,31 @@ func (h *ItemStackRequestHandler) handleCreativeCraft(a *protocol.CraftCreativeS
 	return nil
 }
 
+// handleConsume handles a Consume stack request action. The inputs of a recipe are consumed by the crafting
+// action itself, so the action is only checked against the items that crafting action consumed.
+func (h *ItemStackRequestHandler) handleConsume(a *protocol.ConsumeStackRequestAction) error {
+	slot := consumedSlot{container: a.Source.ContainerID, slot: a.Source.Slot}
+	if n := h.consumed[slot]; int(a.Count) > n {
+		return fmt.Errorf("client tried consuming %v items from slot %v in container %v, but only %v were consumed by crafting", a.Count, a.Source.Slot, a.Source.ContainerID, n)
+	}
+	h.consumed[slot] -= int(a.Count)
+	return nil
+}
+
+// consumeInput removes n items from the input slot of a crafting UI passed and records them as consumed, so
+// that the ConsumeStackRequestAction sent by the client for that slot is accepted.
+func (h *ItemStackRequestHandler) consumeInput(slot protocol.StackRequestSlotInfo, n int, s *Session) {
+	if n <= 0 {
+		return
+	}
+	i, _ := h.itemInSlot(slot, s)
+	h.setItemInSlot(slot, i.Grow(-n), s)
+	if h.consumed == nil {
+		h.consumed = map[consumedSlot]int{}
+	}
+	h.consumed[consumedSlot{container: slot.ContainerID, slot: slot.Slot}] += n
+}
+
 // handleDestroy handles the destroying of an item by moving it into the creative inventory.
 func (h *ItemStackRequestHandler) handleDestroy(a *protocol.DestroyStackRequestAction, s *Session) error {
 	if h.ignoreDestroy {
@@ -236,6 +323,9 @@ func (h *ItemStackRequestHandler) handleDrop(a *protocol.DropStackRequestAction,
 	if i.Count() < int(a.Count) {
 		return fmt.Errorf("client attempted to drop %v items, but only %v present", a.Count, i.Count())
 	}
+	if err := h.verifyUnbound(a.Source, i, s); err != nil {
+		return err
+	}
 
 	inv, _ := s.invByID(int32(a.Source.ContainerID))
 	ctx := event.C()
@@ -245,6 +335,9 @@ func (h *ItemStackRequestHandler) handleDrop(a *protocol.DropStackRequestAction,
 
 	n := s.c.Drop(i.Grow(int(a.Count) - i.Count()))
 	h.setItemInSlot(a.Source, i.Grow(-n), s)
+	if a.Source.ContainerID == containerFurnaceOutput {
+		h.withdrawFurnaceExperience(n, s)
+	}
 	return nil
 }
 
@@ -296,6 +389,184 @@ func (h *ItemStackRequestHandler) handleBeaconPayment(a *protocol.BeaconPaymentS
 	return nil
 }
 
+const (
+	// grindstoneInputSlot and grindstoneAdditionalSlot are the slots in the UI inventory that hold the two
+	// items put in a grindstone.
+	grindstoneInputSlot, grindstoneAdditionalSlot = 0x10, 0x11
+)
+
+// handleGrindstoneCraft handles the taking of the result out of a grindstone. Both inputs are consumed and the
+// result is placed in the output slot in the same request, so that the request is reverted as a whole if it
+// turns out to be invalid.
+func (h *ItemStackRequestHandler) handleGrindstoneCraft(s *Session) error {
+	// First check if there actually is a grindstone opened.
+	if !s.containerOpened.Load() {
+		return fmt.Errorf("no grindstone container opened")
+	}
+	pos := s.openedPos.Load().(cube.Pos)
+	if _, ok := s.c.World().Block(pos).(block.Grindstone); !ok {
+		return fmt.Errorf("no grindstone container opened")
+	}
+
+	inputSlot := protocol.StackRequestSlotInfo{ContainerID: containerGrindstoneIn, Slot: grindstoneInputSlot}
+	additionalSlot := protocol.StackRequestSlotInfo{ContainerID: containerGrindstoneAdd, Slot: grindstoneAdditionalSlot}
+	input, _ := h.itemInSlot(inputSlot, s)
+	additional, _ := h.itemInSlot(additionalSlot, s)
+
+	result, experience, err := grind(input, additional)
+	if err != nil {
+		return err
+	}
+	result, ok := s.c.UseGrindstone(pos, result, experience)
+	if !ok {
+		return fmt.Errorf("grindstone use was cancelled")
+	}
+
+	h.consumeInput(inputSlot, input.Count(), s)
+	h.consumeInput(additionalSlot, additional.Count(), s)
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID:    containerCreativeOutput,
+		Slot:           50,
+		StackNetworkID: item_id(result),
+	}, result, s)
+	return nil
+}
+
+// sendGrindstonePreview sends the result of the items currently in the grindstone to the output slot of the
+// client, so that the preview is updated as the inputs change. An empty item is sent if the items cannot be
+// ground.
+func (h *ItemStackRequestHandler) sendGrindstonePreview(s *Session) {
+	input, _ := h.itemInSlot(protocol.StackRequestSlotInfo{ContainerID: containerGrindstoneIn, Slot: grindstoneInputSlot}, s)
+	additional, _ := h.itemInSlot(protocol.StackRequestSlotInfo{ContainerID: containerGrindstoneAdd, Slot: grindstoneAdditionalSlot}, s)
+	result, _, err := grind(input, additional)
+	if err != nil {
+		result = item.Stack{}
+	}
+	s.writePacket(&packet.InventorySlot{
+		WindowID: protocol.WindowIDUI,
+		Slot:     50,
+		NewItem:  instanceFromItem(result),
+	})
+}
+
+// grind returns the result of putting the two items passed in a grindstone, along with the experience that is
+// refunded for the enchantments removed. Two items of the same durable type are combined, their durability summed
+// with a bonus of 5% of the maximum durability. All enchantments that are not curses are removed from the result.
+func grind(input, additional item.Stack) (item.Stack, int, error) {
+	if input.Empty() {
+		input, additional = additional, input
+	}
+	if input.Empty() {
+		return item.Stack{}, 0, fmt.Errorf("no items in grindstone")
+	}
+	if input.Count() != 1 || additional.Count() > 1 {
+		return item.Stack{}, 0, fmt.Errorf("only single items may be put in a grindstone")
+	}
+
+	result, enchantments := input, input.Enchantments()
+	if !additional.Empty() {
+		name, meta := input.Item().EncodeItem()
+		name2, meta2 := additional.Item().EncodeItem()
+		if name != name2 || meta != meta2 {
+			return item.Stack{}, 0, fmt.Errorf("cannot combine %v and %v in grindstone", name, name2)
+		}
+		if input.MaxDurability() == -1 {
+			return item.Stack{}, 0, fmt.Errorf("cannot combine non-durable items %v in grindstone", name)
+		}
+		result = result.WithDurability(input.Durability() + additional.Durability() + input.MaxDurability()*5/100)
+		enchantments = append(enchantments, additional.Enchantments()...)
+	} else if len(input.Enchantments()) == 0 {
+		return item.Stack{}, 0, fmt.Errorf("item in grindstone has no enchantments to remove")
+	}
+
+	cost := 0
+	for _, e := range result.Enchantments() {
+		result = result.WithoutEnchantment(e)
+	}
+	for _, e := range enchantments {
+		if c, ok := e.(item.Curse); ok && c.Curse() {
+			result = result.WithEnchantment(e)
+			continue
+		}
+		// The minimum cost of enchanting an item with the enchantment, used as an approximation of the experience
+		// that was spent on it.
+		cost += 1 + e.Level()*10
+	}
+	if cost == 0 {
+		return result, 0, nil
+	}
+	// Between half and all of the cost is refunded.
+	half := int(math.Ceil(float64(cost) / 2))
+	return result, half + rand.Intn(half), nil
+}
+
+const (
+	// loomInputSlot, loomDyeSlot and loomPatternSlot are the slots in the UI inventory that hold the banner, the
+	// dye and the optional banner pattern item put in a loom.
+	loomInputSlot, loomDyeSlot, loomPatternSlot = 0x09, 0x0a, 0x0b
+	// maxBannerLayers is the maximum amount of pattern layers that may be applied to a banner using a loom.
+	maxBannerLayers = 6
+)
+
+// handleLoomCraft handles the application of a pattern on a banner in a loom. The banner and the dye are
+// consumed and the banner with the new pattern layer is placed in the output slot in the same request. A banner
+// pattern item in the loom is not consumed.
+func (h *ItemStackRequestHandler) handleLoomCraft(a *protocol.CraftLoomRecipeStackRequestAction, s *Session) error {
+	// First check if there actually is a loom opened.
+	if !s.containerOpened.Load() {
+		return fmt.Errorf("no loom container opened")
+	}
+	if _, ok := s.c.World().Block(s.openedPos.Load().(cube.Pos)).(block.Loom); !ok {
+		return fmt.Errorf("no loom container opened")
+	}
+
+	inputSlot := protocol.StackRequestSlotInfo{ContainerID: containerLoomInput, Slot: loomInputSlot}
+	dyeSlot := protocol.StackRequestSlotInfo{ContainerID: containerLoomDye, Slot: loomDyeSlot}
+	input, _ := h.itemInSlot(inputSlot, s)
+	dye, _ := h.itemInSlot(dyeSlot, s)
+	pattern, _ := h.itemInSlot(protocol.StackRequestSlotInfo{ContainerID: containerLoomPattern, Slot: loomPatternSlot}, s)
+
+	b, ok := input.Item().(block.Banner)
+	if !ok {
+		return fmt.Errorf("input item %v in loom is not a banner", input)
+	}
+	if b.Illager {
+		return fmt.Errorf("patterns cannot be applied to ominous banners")
+	}
+	if len(b.Patterns) >= maxBannerLayers {
+		return fmt.Errorf("banner already has the maximum of %v pattern layers", maxBannerLayers)
+	}
+	d, ok := dye.Item().(item.Dye)
+	if !ok {
+		return fmt.Errorf("dye item %v in loom is not a dye", dye)
+	}
+	t, ok := item.BannerPatternByCode(a.Pattern)
+	if !ok {
+		return fmt.Errorf("unknown banner pattern %v", a.Pattern)
+	}
+	if p, ok := pattern.Item().(item.BannerPattern); ok {
+		if p.Type != t {
+			return fmt.Errorf("banner pattern item %v does not allow applying pattern %v", p.Type, t)
+		}
+	} else if t.RequiresItem() {
+		return fmt.Errorf("banner pattern %v requires a banner pattern item", t)
+	} else if !pattern.Empty() {
+		return fmt.Errorf("item %v in loom is not a banner pattern", pattern)
+	}
+
+	b.Patterns = append(append([]block.BannerPatternLayer(nil), b.Patterns...), block.BannerPatternLayer{Type: t, Colour: d.Colour})
+	result := item.NewStack(b, 1).WithCustomName(input.CustomName()).WithLore(input.Lore()...)
+
+	h.consumeInput(inputSlot, 1, s)
+	h.consumeInput(dyeSlot, 1, s)
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID:    containerCreativeOutput,
+		Slot:           50,
+		StackNetworkID: item_id(result),
+	}, result, s)
+	return nil
+}
+
 // validBeaconEffect checks if the ID passed is a valid beacon effect.
 func (h *ItemStackRequestHandler) validBeaconEffect(id int32, beacon block.Beacon) bool {
 	switch id {
@@ -32,8 +32,9 @@ func (*MobEquipmentHandler) Handle(p packet.Packet, s *Session) error {
 		// Old slot was the same as new slot, so don't do anything.
 		return nil
 	}
-	// The user swapped changed held slots so stop using item right away.
-	s.c.ReleaseItem()
+	// The user swapped changed held slots so stop using item right away. The use is aborted rather than
+	// released, so that the item in the new slot is not affected by it.
+	s.c.AbortItemUse()
 
 	clientSideItem := stackToItem(pk.NewItem.Stack)
 	actual, _ := s.inv.Item(int(pk.InventorySlot))
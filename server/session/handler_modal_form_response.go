@@ -40,8 +40,33 @@ func (h *ModalFormResponseHandler) Handle(p packet.Packet, s *Session) error {
 	if !ok {
 		return fmt.Errorf("no form with ID %v currently opened", pk.FormID)
 	}
-	if err := f.SubmitJSON(pk.ResponseData, s.c); err != nil {
-		return fmt.Errorf("error submitting form data: %w", err)
-	}
+	s.limit(ActionFormSubmit, func() {
+		if err := f.SubmitJSON(pk.ResponseData, s.c); err != nil {
+			// The submission may have been delayed, so we can't return the error here. Close the connection
+			// instead, just like returning the error would.
+			s.log.Debugf("failed processing packet from %v (%v): ModalFormResponse: error submitting form data: %v\n", s.conn.RemoteAddr(), s.c.Name(), err)
+			s.CloseConnection()
+		}
+	}, func() {
+		// The form is closed client-side either way, so a dropped submission is handled as the form being
+		// closed. Submitting nil data never produces an error.
+		_ = f.SubmitJSON(nil, s.c)
+	})
 	return nil
 }
+
+// closeAll closes all forms that are currently open for the Session passed. Forms implementing form.Closer
+// have their Close method called, just like when the form is closed by the client. Each form is closed only
+// once, after which it is removed from the handler.
+func (h *ModalFormResponseHandler) closeAll(s *Session) {
+	h.mu.Lock()
+	forms := h.forms
+	h.forms = make(map[uint32]form.Form)
+	h.mu.Unlock()
+
+	for _, f := range forms {
+		// Submitting nil data is the same as the client closing the form, so this will never produce an
+		// error.
+		_ = f.SubmitJSON(nil, s.c)
+	}
+}
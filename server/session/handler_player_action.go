@@ -54,8 +54,12 @@ func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityR
 		s.c.StartBreaking(s.breakingPos, cube.Face(face))
 	case protocol.PlayerActionAbortBreak:
 		s.c.AbortBreaking()
-	case protocol.PlayerActionPredictDestroyBlock, protocol.PlayerActionStopBreak:
-		s.c.FinishBreaking()
+	case protocol.PlayerActionPredictDestroyBlock:
+		s.c.FinishBreaking(cube.Pos{int(pos[0]), int(pos[1]), int(pos[2])})
+	case protocol.PlayerActionStopBreak:
+		// The client does not send the position of the block with this action, so we use the position of the
+		// block it was breaking.
+		s.c.FinishBreaking(s.breakingPos)
 	case protocol.PlayerActionCrackBreak:
 		s.swingingArm.Store(true)
 		defer s.swingingArm.Store(false)
@@ -70,6 +74,8 @@ func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityR
 			return nil
 		}
 		s.c.ContinueBreaking(cube.Face(face))
+	case protocol.PlayerActionStopSleeping:
+		s.c.WakeUp()
 	case protocol.PlayerActionStartBuildingBlock:
 		// Don't do anything for this action.
 	case protocol.PlayerActionCreativePlayerDestroyBlock:
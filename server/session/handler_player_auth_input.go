@@ -17,6 +17,15 @@ type PlayerAuthInputHandler struct{}
 // Handle ...
 func (h PlayerAuthInputHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.PlayerAuthInput)
+	if mode := int32(pk.InputMode); mode != s.inputMode.Load() {
+		before := s.ClientSettings()
+		s.inputMode.Store(mode)
+		s.c.ClientSettingsChanged(before, s.ClientSettings())
+	}
+	// The input is updated before handling the movement, so that a jump is recognised from the input rather than
+	// from the movement that results from it.
+	m := pk.MoveVector
+	s.c.UpdateInput(mgl64.Vec2{float64(m[0]), float64(m[1])}, pk.InputData&packet.InputFlagJumpDown != 0, pk.InputData&packet.InputFlagSneakDown != 0, pk.InputData&packet.InputFlagSprintDown != 0)
 	if err := h.handleMovement(pk, s); err != nil {
 		return err
 	}
@@ -37,9 +46,9 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 	if riding != nil {
 		if seat == 0 {
 			m := pk.MoveVector
-			riding.Move(mgl64.Vec2{float64(m[0]), float64(m[1])}, pk.Yaw, pk.Pitch)
+			riding.Steer(mgl64.Vec2{float64(m[0]), float64(m[1])}, pk.Yaw, pk.Pitch)
 		}
-		s.ViewEntityMount(s.c, riding, seat-1 == 0)
+		s.ViewEntityMount(s.c, riding, seat == 0)
 	}
 
 	pk.Position = pk.Position.Sub(mgl32.Vec3{0, 1.62}) // Subtract the base offset of players from the pos.
@@ -67,6 +76,13 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 	}
 	s.teleportMu.Unlock()
 
+	if s.preloadingChunks() {
+		// The client is shown as immobile while the chunks around the destination of a teleport are sent, so
+		// it may only look around.
+		s.c.Move(mgl64.Vec3{}, deltaYaw, deltaPitch)
+		return nil
+	}
+
 	_, submergedBefore := s.c.World().Liquid(cube.PosFromVec3(entity.EyePosition(s.c)))
 
 	s.c.Move(deltaPos, deltaYaw, deltaPitch)
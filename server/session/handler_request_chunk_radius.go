@@ -14,10 +14,14 @@ func (*RequestChunkRadiusHandler) Handle(p packet.Packet, s *Session) error {
 	if pk.ChunkRadius > s.maxChunkRadius {
 		pk.ChunkRadius = s.maxChunkRadius
 	}
+	before := s.ClientSettings()
 	s.chunkRadius = pk.ChunkRadius
 
 	s.chunkLoader.ChangeRadius(int(pk.ChunkRadius))
 
 	s.writePacket(&packet.ChunkRadiusUpdated{ChunkRadius: s.chunkRadius})
+	if after := s.ClientSettings(); before.ChunkRadius != after.ChunkRadius {
+		s.c.ClientSettingsChanged(before, after)
+	}
 	return nil
 }
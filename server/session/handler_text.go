@@ -21,6 +21,8 @@ func (TextHandler) Handle(p packet.Packet, s *Session) error {
 	if pk.XUID != s.conn.IdentityData().XUID {
 		return fmt.Errorf("XUID must be equal to player's XUID")
 	}
-	s.c.Chat(pk.Message)
+	s.limit(ActionChat, func() {
+		s.c.Chat(pk.Message)
+	}, nil)
 	return nil
 }
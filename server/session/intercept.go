@@ -0,0 +1,89 @@
+package session
+
+import (
+	"errors"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"sync"
+)
+
+// PacketInterceptor is a function that intercepts a packet sent by or to a Session. The packet passed may be
+// mutated by the interceptor. If false is returned, the packet is dropped and no further interceptors are
+// called for it.
+//
+// Interceptors are an advanced feature: Dropping or changing packets that the Session relies on may cause the
+// state of the client to desync from that of the server.
+type PacketInterceptor func(pk packet.Packet) bool
+
+// ErrNopSession is returned when writing a packet to the Nop session, such as the session of a player that is
+// not connected to a client.
+var ErrNopSession = errors.New("session: cannot write packet to Nop session")
+
+// interceptors holds the inbound and outbound PacketInterceptors registered to a Session. The slices are never
+// modified in place: Registering an interceptor replaces them with a copy, so that they may be read without
+// holding mu once obtained.
+type interceptors struct {
+	mu                sync.RWMutex
+	inbound, outbound []PacketInterceptor
+}
+
+// RegisterPacketInterceptor registers a PacketInterceptor that is called for every packet sent by the client,
+// before the Session handles it. Interceptors are called in the order they are registered.
+// Misuse of interceptors may cause the state of the client to desync from that of the server.
+func (s *Session) RegisterPacketInterceptor(f PacketInterceptor) {
+	if s == Nop {
+		return
+	}
+	s.interceptors.mu.Lock()
+	s.interceptors.inbound = appendInterceptor(s.interceptors.inbound, f)
+	s.interceptors.mu.Unlock()
+}
+
+// RegisterOutboundPacketInterceptor registers a PacketInterceptor that is called for every packet written to
+// the client, before it is sent. Interceptors are called in the order they are registered.
+// Misuse of interceptors may cause the state of the client to desync from that of the server.
+func (s *Session) RegisterOutboundPacketInterceptor(f PacketInterceptor) {
+	if s == Nop {
+		return
+	}
+	s.interceptors.mu.Lock()
+	s.interceptors.outbound = appendInterceptor(s.interceptors.outbound, f)
+	s.interceptors.mu.Unlock()
+}
+
+// WritePacket writes a packet directly to the client of the Session. The packet passes the outbound
+// PacketInterceptors registered like any other packet. ErrNopSession is returned if the Session is Nop.
+// Writing packets that the Session does not expect may cause the state of the client to desync from that of
+// the server.
+func (s *Session) WritePacket(pk packet.Packet) error {
+	if s == Nop {
+		return ErrNopSession
+	}
+	s.writePacket(pk)
+	return nil
+}
+
+// intercept passes the packet to the PacketInterceptors passed and reports if the packet should be handled
+// further. The interceptors are called without holding the lock, so that they may register new interceptors or
+// write packets themselves.
+func (i *interceptors) intercept(pk packet.Packet, outbound bool) bool {
+	i.mu.RLock()
+	l := i.inbound
+	if outbound {
+		l = i.outbound
+	}
+	i.mu.RUnlock()
+
+	for _, f := range l {
+		if !f(pk) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendInterceptor returns a copy of the slice of PacketInterceptors passed with f added to it.
+func appendInterceptor(l []PacketInterceptor, f PacketInterceptor) []PacketInterceptor {
+	n := make([]PacketInterceptor, len(l), len(l)+1)
+	copy(n, l)
+	return append(n, f)
+}
@@ -0,0 +1,180 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"strings"
+)
+
+// Obfuscation holds the configuration of the obfuscation of blocks in chunks sent to clients. If enabled, blocks
+// such as ores that are fully surrounded by opaque blocks are hidden from clients, so that modified clients are
+// unable to find them by looking through blocks (x-ray). Hidden blocks are sent as one of the blocks around
+// them and the actual blocks are sent once they are exposed, for example when a block next to them is broken.
+type Obfuscation struct {
+	// Enabled specifies if blocks are obfuscated.
+	Enabled bool
+	// Overworld, Nether and End hold the names of the blocks hidden in chunks of the overworld, nether and end
+	// respectively, such as "minecraft:diamond_ore". All states of the blocks are hidden.
+	Overworld, Nether, End []string
+}
+
+// DefaultObfuscation returns the default Obfuscation. Obfuscation is disabled by default, but hides all ores and
+// ancient debris if enabled.
+func DefaultObfuscation() Obfuscation {
+	return Obfuscation{
+		Overworld: []string{
+			"minecraft:coal_ore", "minecraft:deepslate_coal_ore",
+			"minecraft:iron_ore", "minecraft:deepslate_iron_ore",
+			"minecraft:copper_ore", "minecraft:deepslate_copper_ore",
+			"minecraft:gold_ore", "minecraft:deepslate_gold_ore",
+			"minecraft:lapis_ore", "minecraft:deepslate_lapis_ore",
+			"minecraft:redstone_ore", "minecraft:deepslate_redstone_ore",
+			"minecraft:diamond_ore", "minecraft:deepslate_diamond_ore",
+			"minecraft:emerald_ore", "minecraft:deepslate_emerald_ore",
+		},
+		Nether: []string{"minecraft:nether_gold_ore", "minecraft:quartz_ore", "minecraft:ancient_debris"},
+	}
+}
+
+// Obfuscator obfuscates the chunks sent to clients as configured in an Obfuscation. An Obfuscator is created
+// once using NewObfuscator and may be shared by all sessions.
+type Obfuscator struct {
+	// dimensions holds the blocks obfuscated in every dimension, indexed by the encoded dimension.
+	dimensions [3]obfuscatedDimension
+}
+
+// obfuscatedDimension holds the blocks obfuscated in a single dimension.
+type obfuscatedDimension struct {
+	// hidden is indexed by block runtime IDs and holds true for blocks that are hidden.
+	hidden []bool
+	// fallback is the runtime ID of the block that hidden blocks are replaced with if all blocks around them are
+	// hidden too.
+	fallback uint32
+}
+
+// NewObfuscator returns an Obfuscator for the Obfuscation passed. Nil is returned if obfuscation is disabled. An
+// error is returned if any of the names configured is not the name of a block. NewObfuscator must only be
+// called after all blocks are registered.
+func NewObfuscator(conf Obfuscation) (*Obfuscator, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+	o := &Obfuscator{}
+	for _, d := range []struct {
+		dim      world.Dimension
+		name     string
+		names    []string
+		fallback world.Block
+	}{
+		{dim: world.Overworld, name: "overworld", names: conf.Overworld, fallback: block.Stone{}},
+		{dim: world.Nether, name: "nether", names: conf.Nether, fallback: block.Netherrack{}},
+		{dim: world.End, name: "end", names: conf.End, fallback: block.EndStone{}},
+	} {
+		hidden, err := hiddenRuntimeIDs(d.names)
+		if err != nil {
+			return nil, fmt.Errorf("obfuscation in %v: %w", d.name, err)
+		}
+		fallback, _ := world.BlockRuntimeID(d.fallback)
+		o.dimensions[d.dim.EncodeDimension()] = obfuscatedDimension{hidden: hidden, fallback: fallback}
+	}
+	return o, nil
+}
+
+// hiddenRuntimeIDs returns a slice indexed by block runtime IDs that holds true for all states of the blocks with
+// the names passed.
+func hiddenRuntimeIDs(names []string) ([]bool, error) {
+	m := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !strings.HasPrefix(name, "minecraft:") {
+			name = "minecraft:" + name
+		}
+		m[name] = false
+	}
+	hidden := make([]bool, len(chunk.FilteringBlocks))
+	for rid := range hidden {
+		b, ok := world.BlockByRuntimeID(uint32(rid))
+		if !ok {
+			break
+		}
+		name, _ := b.EncodeBlock()
+		if _, ok := m[name]; ok {
+			hidden[rid], m[name] = true, true
+		}
+	}
+	for name, found := range m {
+		if !found {
+			return nil, fmt.Errorf("unknown block %v", name)
+		}
+	}
+	return hidden, nil
+}
+
+// obfuscate returns a copy of the chunk passed, which is part of a world with the dimension passed, in which the
+// blocks hidden in that dimension are obfuscated.
+func (o *Obfuscator) obfuscate(dim world.Dimension, c *chunk.Chunk) *chunk.Chunk {
+	d := o.dimensions[dim.EncodeDimension()]
+	return chunk.Obfuscate(c, d.hidden, d.fallback)
+}
+
+// hides checks if the block with the runtime ID passed is hidden in the dimension passed.
+func (o *Obfuscator) hides(dim world.Dimension, rid uint32) bool {
+	hidden := o.dimensions[dim.EncodeDimension()].hidden
+	return int(rid) < len(hidden) && hidden[rid]
+}
+
+// exposes checks if the block with the runtime ID passed could expose blocks next to it, which is the case if it
+// does not filter all light.
+func exposes(rid uint32) bool {
+	return int(rid) >= len(chunk.FilteringBlocks) || chunk.FilteringBlocks[rid] != 15
+}
+
+// queueReveal queues the blocks around the position passed to be revealed to the client, as they might have been
+// hidden from it. The blocks are revealed the next time revealBlocks is called.
+func (s *Session) queueReveal(pos cube.Pos) {
+	s.revealMu.Lock()
+	s.reveals = append(s.reveals, pos)
+	s.revealMu.Unlock()
+}
+
+// revealBlocks sends the actual blocks around the positions queued using queueReveal to the client, if they are
+// blocks that are hidden from the client. Blocks are only revealed in chunks that were sent to the client.
+func (s *Session) revealBlocks() {
+	s.revealMu.Lock()
+	positions := s.reveals
+	s.reveals = nil
+	s.revealMu.Unlock()
+	if len(positions) == 0 {
+		return
+	}
+	w := s.chunkLoader.World()
+	if w == nil {
+		return
+	}
+	dim, revealed := w.Dimension(), make(map[cube.Pos]struct{}, len(positions)*6)
+	for _, pos := range positions {
+		for _, face := range cube.Faces() {
+			side := pos.Side(face)
+			if _, ok := revealed[side]; ok || side.OutOfBounds(w.Range()) {
+				continue
+			}
+			revealed[side] = struct{}{}
+			if !s.chunkLoader.Loaded(world.ChunkPos{int32(side[0] >> 4), int32(side[2] >> 4)}) {
+				continue
+			}
+			rid, _ := world.BlockRuntimeID(w.Block(side))
+			if !s.obfuscator.hides(dim, rid) {
+				continue
+			}
+			s.writePacket(&packet.UpdateBlock{
+				Position:          protocol.BlockPos{int32(side[0]), int32(side[1]), int32(side[2])},
+				NewBlockRuntimeID: rid,
+				Flags:             packet.BlockUpdateNetwork,
+			})
+		}
+	}
+}
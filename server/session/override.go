@@ -0,0 +1,88 @@
+package session
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// timeOverride holds the time shown to the client of a Session instead of the time of its world.
+type timeOverride struct {
+	time    int
+	stopped bool
+}
+
+// weatherOverride holds the weather shown to the client of a Session instead of the weather of its world.
+type weatherOverride struct {
+	raining, thundering bool
+}
+
+// SetTimeOverride shows the time passed to the client instead of the time of the world it is in. If stopped
+// is true, the time does not progress client-side. The time of the world is no longer sent to the client until
+// ResetTimeOverride is called.
+func (s *Session) SetTimeOverride(time int, stopped bool) {
+	if s == Nop {
+		return
+	}
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	s.timeOverride = &timeOverride{time: time, stopped: stopped}
+	s.writeTime(time, !stopped)
+}
+
+// ResetTimeOverride removes a time override set using SetTimeOverride and immediately shows the time of the
+// world to the client again. The day cycle is enabled client-side if the time of the world is currently cycling.
+func (s *Session) ResetTimeOverride() {
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	if s.timeOverride == nil {
+		return
+	}
+	s.timeOverride = nil
+	w := s.c.World()
+	s.writeTime(w.Time(), w.TimeCycle())
+}
+
+// SetWeatherOverride shows the weather passed to the client instead of the weather of the world it is in. The
+// weather of the world is no longer sent to the client until ResetWeatherOverride is called.
+func (s *Session) SetWeatherOverride(raining, thundering bool) {
+	if s == Nop {
+		return
+	}
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	s.weatherOverride = &weatherOverride{raining: raining, thundering: thundering}
+	s.writeWeather(raining, thundering)
+}
+
+// ResetWeatherOverride removes a weather override set using SetWeatherOverride and immediately shows the weather
+// of the world to the client again.
+func (s *Session) ResetWeatherOverride() {
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	if s.weatherOverride == nil {
+		return
+	}
+	s.weatherOverride = nil
+	s.writeWeather(s.c.World().Weather())
+}
+
+// sendOverrides sends the time and weather overrides of the Session to the client again, if set. It is called
+// whenever the client might have reset its time or weather, such as after a dimension change or respawn.
+func (s *Session) sendOverrides() {
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	if t := s.timeOverride; t != nil {
+		s.writeTime(t.time, !t.stopped)
+	}
+	if w := s.weatherOverride; w != nil {
+		s.writeWeather(w.raining, w.thundering)
+	}
+}
+
+// writeTime sends the time passed to the client and enables or disables the day/night cycle client-side.
+// writeTime must be called while holding overrideMu.
+func (s *Session) writeTime(time int, cycle bool) {
+	//noinspection SpellCheckingInspection
+	s.sendGameRules([]protocol.GameRule{{Name: "dodaylightcycle", Value: cycle}})
+	s.writePacket(&packet.SetTime{Time: int32(time)})
+}
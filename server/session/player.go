@@ -44,15 +44,54 @@ func (s *Session) StartShowingEntity(e world.Entity) {
 	s.ViewEntityArmour(e)
 }
 
+// CloseContainer closes the container that the Controllable of the Session currently has open, if any.
+func (s *Session) CloseContainer() {
+	if s == Nop {
+		return
+	}
+	s.closeCurrentContainer()
+}
+
 // closeCurrentContainer closes the container the player might currently have open.
 func (s *Session) closeCurrentContainer() {
 	if !s.containerOpened.Load() {
 		return
 	}
 	s.closeWindow()
+	if s.closeRemoteInventory() {
+		return
+	}
+	if s.closeTrade() {
+		return
+	}
 	pos := s.openedPos.Load().(cube.Pos)
-	if container, ok := s.c.World().Block(pos).(block.Container); ok {
+	switch container := s.c.World().Block(pos).(type) {
+	case block.Container:
+		container.RemoveViewer(s, s.c.World(), pos)
+	case block.EnderChest:
 		container.RemoveViewer(s, s.c.World(), pos)
+	case block.Grindstone:
+		s.returnUIItems(grindstoneInputSlot, grindstoneAdditionalSlot)
+	case block.Loom:
+		s.returnUIItems(loomInputSlot, loomDyeSlot, loomPatternSlot)
+	case block.SmithingTable:
+		s.returnUIItems(smithingInputSlot, smithingMaterialSlot)
+	}
+}
+
+// returnUIItems moves the items in the slots of the UI inventory passed back to the inventory of the
+// Controllable. Items that do not fit in the inventory are dropped.
+func (s *Session) returnUIItems(slots ...int) {
+	for _, slot := range slots {
+		it, _ := s.ui.Item(slot)
+		if it.Empty() {
+			continue
+		}
+		_ = s.ui.SetItem(slot, item.Stack{})
+		n, _ := s.inv.AddItem(it)
+		if n < it.Count() {
+			s.c.Drop(it.Grow(n - it.Count()))
+		}
 	}
 }
 
@@ -63,6 +102,7 @@ func (s *Session) SendRespawn() {
 		State:           packet.RespawnStateReadyToSpawn,
 		EntityRuntimeID: selfEntityRuntimeID,
 	})
+	s.sendOverrides()
 }
 
 // sendInv sends the inventory passed to the client with the window ID.
@@ -77,18 +117,61 @@ func (s *Session) sendInv(inv *inventory.Inventory, windowID uint32) {
 	s.writePacket(pk)
 }
 
+// ResendInventories resends the full inventory, off-hand, armour and UI inventory, which holds the cursor, of
+// the Controllable to the client, so that it is in sync with the server again after a client-side prediction
+// was rejected.
+func (s *Session) ResendInventories() {
+	if s == Nop {
+		return
+	}
+	s.sendInv(s.inv, protocol.WindowIDInventory)
+	s.sendInv(s.ui, protocol.WindowIDUI)
+	s.sendInv(s.offHand, protocol.WindowIDOffHand)
+	s.sendInv(s.armour.Inventory(), protocol.WindowIDArmour)
+}
+
+// ResendHeldItems resends the items held in the main hand and off-hand of the Controllable to the client. It
+// may be used instead of ResendInventories if only the held items could have been changed client-side.
+func (s *Session) ResendHeldItems() {
+	if s == Nop {
+		return
+	}
+	mainHand, offHand := s.c.HeldItems()
+	s.writePacket(&packet.InventorySlot{
+		WindowID: protocol.WindowIDInventory,
+		Slot:     s.heldSlot.Load(),
+		NewItem:  instanceFromItem(mainHand),
+	})
+	s.writePacket(&packet.InventoryContent{
+		WindowID: protocol.WindowIDOffHand,
+		Content:  []protocol.ItemInstance{instanceFromItem(offHand)},
+	})
+}
+
 const (
-	containerArmour         = 6
-	containerChest          = 7
-	containerBeacon         = 8
-	containerFullInventory  = 12
-	containerCraftingGrid   = 13
-	containerHotbar         = 27
-	containerInventory      = 28
-	containerOffHand        = 33
-	containerBarrel         = 57
-	containerCursor         = 58
-	containerCreativeOutput = 59
+	containerSmithingInput    = 3
+	containerSmithingMaterial = 4
+	containerArmour           = 6
+	containerChest            = 7
+	containerBeacon           = 8
+	containerFullInventory    = 12
+	containerCraftingGrid     = 13
+	containerFurnaceFuel      = 23
+	containerFurnaceInput     = 24
+	containerFurnaceOutput    = 25
+	containerHotbar           = 27
+	containerInventory        = 28
+	containerOffHand          = 33
+	containerLoomInput        = 40
+	containerLoomDye          = 41
+	containerLoomPattern      = 42
+	containerTradeInput1      = 46
+	containerTradeInput2      = 47
+	containerGrindstoneIn     = 49
+	containerGrindstoneAdd    = 50
+	containerBarrel           = 57
+	containerCursor           = 58
+	containerCreativeOutput   = 59
 )
 
 // invByID attempts to return an inventory by the ID passed. If found, the inventory is returned and the bool
@@ -108,9 +191,15 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 		return s.armour.Inventory(), true
 	case containerChest:
 		// Chests, potentially other containers too.
+		if r, ok := s.remoteInventory(); ok && s.containerOpened.Load() {
+			// Inventories opened using OpenInventory are shown as a chest. Read-only inventories are not
+			// returned, so that any action involving them fails.
+			return r.inv, !r.readOnly
+		}
 		if s.containerOpened.Load() {
 			b := s.c.World().Block(s.openedPos.Load().(cube.Pos))
-			if _, chest := b.(block.Chest); chest {
+			switch b.(type) {
+			case block.Chest, block.EnderChest, block.Dispenser, block.Dropper, block.Hopper:
 				return s.openedWindow.Load().(*inventory.Inventory), true
 			}
 		}
@@ -121,6 +210,13 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 				return s.openedWindow.Load().(*inventory.Inventory), true
 			}
 		}
+	case containerFurnaceInput, containerFurnaceFuel, containerFurnaceOutput:
+		if s.containerOpened.Load() {
+			b := s.c.World().Block(s.openedPos.Load().(cube.Pos))
+			if _, furnace := b.(block.Furnace); furnace {
+				return s.openedWindow.Load().(*inventory.Inventory), true
+			}
+		}
 	case containerBeacon:
 		if s.containerOpened.Load() {
 			b := s.c.World().Block(s.openedPos.Load().(cube.Pos))
@@ -128,6 +224,31 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 				return s.ui, true
 			}
 		}
+	case containerGrindstoneIn, containerGrindstoneAdd:
+		if s.containerOpened.Load() {
+			b := s.c.World().Block(s.openedPos.Load().(cube.Pos))
+			if _, grindstone := b.(block.Grindstone); grindstone {
+				return s.ui, true
+			}
+		}
+	case containerTradeInput1, containerTradeInput2:
+		if _, ok := s.tradingWith(); ok && s.containerOpened.Load() {
+			return s.ui, true
+		}
+	case containerSmithingInput, containerSmithingMaterial:
+		if s.containerOpened.Load() {
+			b := s.c.World().Block(s.openedPos.Load().(cube.Pos))
+			if _, smithing := b.(block.SmithingTable); smithing {
+				return s.ui, true
+			}
+		}
+	case containerLoomInput, containerLoomDye, containerLoomPattern:
+		if s.containerOpened.Load() {
+			b := s.c.World().Block(s.openedPos.Load().(cube.Pos))
+			if _, loom := b.(block.Loom); loom {
+				return s.ui, true
+			}
+		}
 	}
 	return nil, false
 }
@@ -191,6 +312,26 @@ func (s *Session) SendFood(food int, saturation, exhaustion float64) {
 	})
 }
 
+// SendExperience sends the experience level of the player and its progress towards the next level, so that the
+// experience bar is updated client-side.
+func (s *Session) SendExperience(level int, progress float64) {
+	s.writePacket(&packet.UpdateAttributes{
+		EntityRuntimeID: selfEntityRuntimeID,
+		Attributes: []protocol.Attribute{
+			{
+				Name:  "minecraft:player.level",
+				Value: float32(level),
+				Max:   24791, Min: 0, Default: 0,
+			},
+			{
+				Name:  "minecraft:player.experience",
+				Value: float32(progress),
+				Max:   1, Min: 0, Default: 0,
+			},
+		},
+	})
+}
+
 // SendForm sends a form to the client of the connection. The Submit method of the form is called when the
 // client submits the form.
 func (s *Session) SendForm(f form.Form) {
@@ -216,6 +357,15 @@ func (s *Session) SendForm(f form.Form) {
 	})
 }
 
+// closeForms closes all forms that are currently open for the client. Each form that implements form.Closer
+// has its Close method called.
+func (s *Session) closeForms() {
+	if s == Nop {
+		return
+	}
+	s.handlers[packet.IDModalFormResponse].(*ModalFormResponseHandler).closeAll(s)
+}
+
 // Transfer transfers the player to a server with the IP and port passed.
 func (s *Session) Transfer(ip net.IP, port int) {
 	s.writePacket(&packet.Transfer{
@@ -225,10 +375,14 @@ func (s *Session) Transfer(ip net.IP, port int) {
 }
 
 // SendGameMode sends the game mode of the Controllable entity of the session to the client. It makes sure the right
-// flags are set to create the full game mode.
+// flags are set to create the full game mode, taking into account the abilities of the Controllable that override
+// the defaults of the game mode.
 func (s *Session) SendGameMode(mode world.GameMode) {
+	if s == Nop {
+		return
+	}
 	flags, id, perms := uint32(0), int32(packet.GameTypeSurvivalSpectator), uint32(0)
-	if mode.AllowsFlying() {
+	if s.c.AllowsFlight() {
 		flags |= packet.AdventureFlagAllowFlight
 		if s.c.Flying() {
 			flags |= packet.AdventureFlagFlying
@@ -237,7 +391,7 @@ func (s *Session) SendGameMode(mode world.GameMode) {
 	if !mode.HasCollision() {
 		flags |= packet.AdventureFlagNoClip
 	}
-	if !mode.AllowsEditing() {
+	if !s.c.WorldBuilder() {
 		flags |= packet.AdventureFlagWorldImmutable
 	} else {
 		perms |= packet.ActionPermissionBuild | packet.ActionPermissionMine
@@ -247,7 +401,7 @@ func (s *Session) SendGameMode(mode world.GameMode) {
 	} else {
 		perms |= packet.ActionPermissionDoorsAndSwitches | packet.ActionPermissionOpenContainers | packet.ActionPermissionAttackPlayers | packet.ActionPermissionAttackMobs
 	}
-	if !mode.Visible() {
+	if !mode.Visible() || s.c.Muted() {
 		flags |= packet.AdventureFlagMuted
 	}
 	// Creative or spectator players:
@@ -310,6 +464,20 @@ func (s *Session) SendEffect(e effect.Effect) {
 	})
 }
 
+// SendEffectModification sends a change of an effect that the player already has, such as a change of its
+// duration. Unlike SendEffect, the effect is not removed and added again client-side.
+func (s *Session) SendEffectModification(e effect.Effect) {
+	id, _ := effect.ID(e.Type())
+	s.writePacket(&packet.MobEffect{
+		EntityRuntimeID: selfEntityRuntimeID,
+		Operation:       packet.MobEffectModify,
+		EffectType:      int32(id),
+		Amplifier:       int32(e.Level() - 1),
+		Particles:       !e.ParticlesHidden(),
+		Duration:        int32(e.Duration() / (time.Second / 20)),
+	})
+}
+
 // SendEffectRemoval sends the removal of an effect passed.
 func (s *Session) SendEffectRemoval(e effect.Type) {
 	id, ok := effect.ID(e)
@@ -389,23 +557,43 @@ func skinToProtocol(s skin.Skin) protocol.Skin {
 		protocolAnim.ExpressionType = uint32(animation.AnimationExpression)
 		animations = append(animations, protocolAnim)
 	}
+	pieces := make([]protocol.PersonaPiece, 0, len(s.PersonaPieces))
+	for _, piece := range s.PersonaPieces {
+		pieces = append(pieces, protocol.PersonaPiece{
+			PieceID:   piece.ID,
+			PieceType: piece.Type,
+			PackID:    piece.PackID,
+			Default:   piece.Default,
+			ProductID: piece.ProductID,
+		})
+	}
+	tints := make([]protocol.PersonaPieceTintColour, 0, len(s.PieceTintColours))
+	for _, tint := range s.PieceTintColours {
+		tints = append(tints, protocol.PersonaPieceTintColour{PieceType: tint.PieceType, Colours: tint.Colours})
+	}
 
 	return protocol.Skin{
-		PlayFabID:         s.PlayFabID,
-		SkinID:            uuid.New().String(),
-		SkinResourcePatch: s.ModelConfig.Encode(),
-		SkinImageWidth:    uint32(s.Bounds().Max.X),
-		SkinImageHeight:   uint32(s.Bounds().Max.Y),
-		SkinData:          s.Pix,
-		CapeImageWidth:    uint32(s.Cape.Bounds().Max.X),
-		CapeImageHeight:   uint32(s.Cape.Bounds().Max.Y),
-		CapeData:          s.Cape.Pix,
-		SkinGeometry:      s.Model,
-		PersonaSkin:       s.Persona,
-		CapeID:            uuid.New().String(),
-		FullSkinID:        uuid.New().String(),
-		Animations:        animations,
-		Trusted:           true,
+		PlayFabID:                s.PlayFabID,
+		SkinID:                   uuid.New().String(),
+		SkinResourcePatch:        s.ModelConfig.Encode(),
+		SkinImageWidth:           uint32(s.Bounds().Max.X),
+		SkinImageHeight:          uint32(s.Bounds().Max.Y),
+		SkinData:                 s.Pix,
+		CapeImageWidth:           uint32(s.Cape.Bounds().Max.X),
+		CapeImageHeight:          uint32(s.Cape.Bounds().Max.Y),
+		CapeData:                 s.Cape.Pix,
+		SkinGeometry:             s.Model,
+		PersonaSkin:              s.Persona,
+		PersonaCapeOnClassicSkin: s.PersonaCapeOnClassic,
+		CapeID:                   uuid.New().String(),
+		FullSkinID:               uuid.New().String(),
+		Animations:               animations,
+		AnimationData:            s.AnimationData,
+		ArmSize:                  s.ArmSize,
+		SkinColour:               s.Colour,
+		PersonaPieces:            pieces,
+		PieceTintColours:         tints,
+		Trusted:                  true,
 	}
 }
 
@@ -435,10 +623,15 @@ func (s *Session) HandleInventories() (inv, offHand *inventory.Inventory, armour
 			return
 		}
 		if slot == int(s.heldSlot.Load()) {
+			if !usableOverTime(item) {
+				// The item being used, for example an item being eaten, was removed from the held slot.
+				s.c.AbortItemUse()
+			}
 			for _, viewer := range s.c.World().Viewers(s.c.Position()) {
 				viewer.ViewEntityItems(s.c)
 			}
 		}
+		viewRemoteSlotChange(s.inv, slot, item)
 		if !s.inTransaction.Load() {
 			s.writePacket(&packet.InventorySlot{
 				WindowID: protocol.WindowIDInventory,
@@ -482,13 +675,15 @@ func (s *Session) HandleInventories() (inv, offHand *inventory.Inventory, armour
 	return s.inv, s.offHand, s.armour, s.heldSlot
 }
 
-// SetHeldSlot sets the currently held hotbar slot.
+// SetHeldSlot sets the currently held hotbar slot. Any item being used by the Controllable is no longer used.
 func (s *Session) SetHeldSlot(slot int) error {
 	if slot > 8 {
 		return fmt.Errorf("slot exceeds hotbar range 0-8: slot is %v", slot)
 	}
 
-	s.heldSlot.Store(uint32(slot))
+	if s.heldSlot.Swap(uint32(slot)) != uint32(slot) {
+		s.c.AbortItemUse()
+	}
 
 	for _, viewer := range s.c.World().Viewers(s.c.Position()) {
 		viewer.ViewEntityItems(s.c)
@@ -504,6 +699,16 @@ func (s *Session) SetHeldSlot(slot int) error {
 	return nil
 }
 
+// usableOverTime checks if the item stack passed holds an item that is used over a longer duration, such as food
+// that is eaten or a crossbow that is charged.
+func usableOverTime(it item.Stack) bool {
+	switch it.Item().(type) {
+	case item.Consumable, item.Crossbow:
+		return true
+	}
+	return false
+}
+
 // stackFromItem converts an item.Stack to its network ItemStack representation.
 func stackFromItem(it item.Stack) protocol.ItemStack {
 	if it.Empty() {
@@ -528,6 +733,8 @@ func stackFromItem(it item.Stack) protocol.ItemStack {
 		HasNetworkID:   true,
 		Count:          uint16(it.Count()),
 		NBTData:        nbtconv.WriteItem(it, false),
+		CanBePlacedOn:  nbtconv.BlockNames(it.CanPlaceOn()),
+		CanBreak:       nbtconv.BlockNames(it.CanDestroy()),
 	}
 }
 
@@ -564,6 +771,12 @@ func stackToItem(it protocol.ItemStack) item.Stack {
 		t = nbter.DecodeNBT(it.NBTData).(world.Item)
 	}
 	s := item.NewStack(t, int(it.Count))
+	if blocks := nbtconv.BlocksByName(it.CanBePlacedOn); len(blocks) != 0 {
+		s = s.WithCanPlaceOn(blocks...)
+	}
+	if blocks := nbtconv.BlocksByName(it.CanBreak); len(blocks) != 0 {
+		s = s.WithCanDestroy(blocks...)
+	}
 	return nbtconv.ReadItem(it.NBTData, &s)
 }
 
@@ -592,9 +805,13 @@ func protocolToSkin(sk protocol.Skin) (s skin.Skin, err error) {
 	s.Pix = sk.SkinData
 	s.Model = sk.SkinGeometry
 	s.PlayFabID = sk.PlayFabID
+	s.AnimationData = sk.AnimationData
+	s.ArmSize = sk.ArmSize
+	s.Colour = sk.SkinColour
 
 	s.Cape = skin.NewCape(int(sk.CapeImageWidth), int(sk.CapeImageHeight))
 	s.Cape.Pix = sk.CapeData
+	s.PersonaCapeOnClassic = sk.PersonaCapeOnClassicSkin
 
 	m := make(map[string]interface{})
 	if err = json.Unmarshal(sk.SkinGeometry, &m); err != nil {
@@ -624,6 +841,18 @@ func protocolToSkin(sk protocol.Skin) (s skin.Skin, err error) {
 
 		s.Animations = append(s.Animations, animation)
 	}
+	for _, piece := range sk.PersonaPieces {
+		s.PersonaPieces = append(s.PersonaPieces, skin.PersonaPiece{
+			ID:        piece.PieceID,
+			Type:      piece.PieceType,
+			PackID:    piece.PackID,
+			Default:   piece.Default,
+			ProductID: piece.ProductID,
+		})
+	}
+	for _, tint := range sk.PieceTintColours {
+		s.PieceTintColours = append(s.PieceTintColours, skin.PieceTintColour{PieceType: tint.PieceType, Colours: tint.Colours})
+	}
 	return
 }
 
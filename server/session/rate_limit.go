@@ -0,0 +1,260 @@
+package session
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitedAction is an action performed by a client that is subject to a RateLimit, such as sending a chat
+// message or attacking an entity.
+type RateLimitedAction uint8
+
+const (
+	// ActionChat is the action of sending a chat message.
+	ActionChat RateLimitedAction = iota
+	// ActionCommand is the action of executing a command.
+	ActionCommand
+	// ActionFormSubmit is the action of submitting (or closing) a form.
+	ActionFormSubmit
+	// ActionItemUse is the action of using an item, either in the air, on a block or on an entity.
+	ActionItemUse
+	// ActionAttack is the action of attacking an entity.
+	ActionAttack
+
+	rateLimitedActionCount
+)
+
+// String returns a readable name of the RateLimitedAction.
+func (a RateLimitedAction) String() string {
+	switch a {
+	case ActionChat:
+		return "chat"
+	case ActionCommand:
+		return "command"
+	case ActionFormSubmit:
+		return "form submit"
+	case ActionItemUse:
+		return "item use"
+	case ActionAttack:
+		return "attack"
+	}
+	panic("should never happen")
+}
+
+// RateLimitMode specifies what happens with an action that exceeds its RateLimit.
+type RateLimitMode uint8
+
+const (
+	// RateLimitDrop silently drops actions that exceed the limit.
+	RateLimitDrop RateLimitMode = iota
+	// RateLimitDelay delays actions that exceed the limit until enough tokens are available to perform them. At most
+	// Burst actions are delayed at the same time: Any further actions are dropped.
+	RateLimitDelay
+	// RateLimitEscalate drops actions that exceed the limit and reports them to the Controllable of the session, so
+	// that handlers may decide to warn or kick the client.
+	RateLimitEscalate
+)
+
+// RateLimit is a token bucket based limit on the frequency of a RateLimitedAction. Every action costs one token and
+// tokens are refilled at a constant rate, up to a maximum of Burst tokens, so that short bursts of actions are
+// allowed.
+type RateLimit struct {
+	// Rate is the amount of tokens refilled every second. If Rate is 0 or lower, the action is not limited.
+	Rate float64
+	// Burst is the maximum amount of tokens that may be stored, and therefore the maximum amount of actions that may
+	// be performed in quick succession.
+	Burst int
+	// Mode is the RateLimitMode that specifies what happens with actions that exceed the limit.
+	Mode RateLimitMode
+}
+
+// RateLimits holds the RateLimit of every RateLimitedAction of a Session.
+type RateLimits struct {
+	// Chat limits the chat messages sent by the client.
+	Chat RateLimit
+	// Commands limits the commands executed by the client.
+	Commands RateLimit
+	// Forms limits the form responses submitted by the client.
+	Forms RateLimit
+	// ItemUse limits the use of items in the air, on blocks and on entities.
+	ItemUse RateLimit
+	// Attacks limits the attacks on entities. Legitimate clients attack at most roughly 20 times per second.
+	Attacks RateLimit
+}
+
+// DefaultRateLimits returns the RateLimits used by default. The limits on item use and attacks are generous enough to
+// never affect legitimate clicking at 20 clicks per second.
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		Chat:     RateLimit{Rate: 2, Burst: 5, Mode: RateLimitEscalate},
+		Commands: RateLimit{Rate: 4, Burst: 10, Mode: RateLimitEscalate},
+		Forms:    RateLimit{Rate: 4, Burst: 10, Mode: RateLimitDrop},
+		ItemUse:  RateLimit{Rate: 40, Burst: 80, Mode: RateLimitDrop},
+		Attacks:  RateLimit{Rate: 30, Burst: 60, Mode: RateLimitDrop},
+	}
+}
+
+// limit returns the RateLimit of the RateLimitedAction passed.
+func (l RateLimits) limit(a RateLimitedAction) RateLimit {
+	switch a {
+	case ActionChat:
+		return l.Chat
+	case ActionCommand:
+		return l.Commands
+	case ActionFormSubmit:
+		return l.Forms
+	case ActionItemUse:
+		return l.ItemUse
+	case ActionAttack:
+		return l.Attacks
+	}
+	panic("should never happen")
+}
+
+// RateUsage holds the current usage of the RateLimit of a RateLimitedAction for a Session.
+type RateUsage struct {
+	// Limit is the RateLimit that applies to the action.
+	Limit RateLimit
+	// Tokens is the amount of tokens currently available. It may be negative if actions are currently delayed.
+	Tokens float64
+	// Violations is the total amount of times the client exceeded the limit.
+	Violations int
+}
+
+// bucket is a token bucket that implements a RateLimit.
+type bucket struct {
+	mu         sync.Mutex
+	limit      RateLimit
+	tokens     float64
+	last       time.Time
+	violations int
+}
+
+// newBucket returns a full bucket for the RateLimit passed.
+func newBucket(limit RateLimit) *bucket {
+	return &bucket{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+}
+
+// refill refills the tokens of the bucket according to the time passed since the last refill. refill must be called
+// with b.mu locked.
+func (b *bucket) refill() {
+	now := time.Now()
+	b.tokens = math.Min(b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate, float64(b.limit.Burst))
+	b.last = now
+}
+
+// take attempts to take a token from the bucket. If the bucket is empty, delay specifies if the action may be delayed.
+// If so, the duration to wait before performing the action is returned. take returns false if the action must not
+// be performed at all.
+func (b *bucket) take(delay bool) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	b.violations++
+	if !delay || b.tokens-1 < -float64(b.limit.Burst) {
+		return 0, false
+	}
+	b.tokens--
+	return time.Duration(-b.tokens / b.limit.Rate * float64(time.Second)), true
+}
+
+// usage returns the current RateUsage of the bucket.
+func (b *bucket) usage() RateUsage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	return RateUsage{Limit: b.limit, Tokens: b.tokens, Violations: b.violations}
+}
+
+// newBuckets returns the buckets for all limits in the RateLimits passed. Actions without a limit have a nil bucket.
+func newBuckets(l RateLimits) [rateLimitedActionCount]*bucket {
+	var buckets [rateLimitedActionCount]*bucket
+	for a := RateLimitedAction(0); a < rateLimitedActionCount; a++ {
+		if limit := l.limit(a); limit.Rate > 0 {
+			buckets[a] = newBucket(limit)
+		}
+	}
+	return buckets
+}
+
+// RateUsage returns the current RateUsage of the RateLimitedAction passed for the Session. If the action is not
+// limited, the RateUsage returned is empty.
+func (s *Session) RateUsage(a RateLimitedAction) RateUsage {
+	if s == Nop || s.buckets[a] == nil {
+		return RateUsage{}
+	}
+	return s.buckets[a].usage()
+}
+
+// delayedAction is an action that exceeded a RateLimit with the RateLimitDelay mode. It is performed on the
+// goroutine that handles the packets of the Session once it is due.
+type delayedAction struct {
+	at time.Time
+	f  func()
+}
+
+// limit performs the function f passed if the RateLimit of the RateLimitedAction passed allows it. Depending on the
+// RateLimitMode of the limit, actions exceeding it are dropped, delayed or reported to the Controllable. If the
+// action is dropped, drop is called (if not nil) so that any changes predicted by the client may be rolled back.
+// limit must only be called while handling a packet.
+func (s *Session) limit(a RateLimitedAction, f, drop func()) {
+	b := s.buckets[a]
+	if b == nil {
+		f()
+		return
+	}
+	wait, ok := b.take(b.limit.Mode == RateLimitDelay)
+	switch {
+	case ok && wait == 0:
+		f()
+		return
+	case ok:
+		s.delay(time.Now().Add(wait), f)
+		return
+	case b.limit.Mode == RateLimitEscalate:
+		s.c.RateLimitExceeded(a, b.usage())
+	}
+	if drop != nil {
+		drop()
+	}
+}
+
+// delay queues the function f passed to be performed once the time passed is reached. The queue is ordered by
+// the time the actions are due, so that actions delayed by the same RateLimit are performed in the order they
+// were sent in.
+func (s *Session) delay(at time.Time, f func()) {
+	i := len(s.delayed)
+	for i > 0 && s.delayed[i-1].at.After(at) {
+		i--
+	}
+	s.delayed = append(s.delayed, delayedAction{})
+	copy(s.delayed[i+1:], s.delayed[i:])
+	s.delayed[i] = delayedAction{at: at, f: f}
+}
+
+// performDelayed performs all delayed actions that are due. It is called before every packet handled, so that
+// delayed actions are performed on the same goroutine as all other actions of the client, before any actions
+// that the client sent after them. Clients send packets every tick, so no action is delayed for much longer
+// than needed.
+func (s *Session) performDelayed() {
+	now := time.Now()
+	n := 0
+	for n < len(s.delayed) && !s.delayed[n].at.After(now) {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	due := s.delayed[:n]
+	s.delayed = append([]delayedAction(nil), s.delayed[n:]...)
+	for _, a := range due {
+		a.f()
+	}
+}
@@ -0,0 +1,237 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"sync"
+)
+
+// remoteContainer holds the state of an inventory opened using Session.OpenInventory. Such inventories are not
+// held by a block in the world, so they are shown to the client using fake chests.
+type remoteContainer struct {
+	inv      *inventory.Inventory
+	readOnly bool
+	// chests holds the positions of the fake chests sent to the client to open the inventory.
+	chests []cube.Pos
+	// ctx is the context returned by OpenInventory, which is cancelled once the inventory is closed.
+	ctx *closeContext
+}
+
+var (
+	// ErrInventoryClosed is returned by the Err method of the context returned by Session.OpenInventory once
+	// the inventory was closed, either by the client or because another container was opened.
+	ErrInventoryClosed = errors.New("session: inventory closed")
+	// ErrQuit is returned by the Err method of the context returned by Session.OpenInventory once the inventory
+	// was closed because the client disconnected.
+	ErrQuit = errors.New("session: client disconnected")
+)
+
+// closeContext is a context.Context that is cancelled with a specific error, such as ErrQuit.
+type closeContext struct {
+	context.Context
+	cancel context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+// newCloseContext returns a new closeContext that is not yet cancelled.
+func newCloseContext() *closeContext {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &closeContext{Context: ctx, cancel: cancel}
+}
+
+// Err returns the error that the context was cancelled with, or nil if it was not yet cancelled.
+func (c *closeContext) Err() error {
+	select {
+	case <-c.Done():
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// close cancels the context with the error passed. Only the error passed the first time is kept.
+func (c *closeContext) close(err error) {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = err
+	}
+	c.mu.Unlock()
+	c.cancel()
+}
+
+// remoteViewersMu guards remoteViewers, which holds all sessions that have an inventory opened using
+// Session.OpenInventory, indexed by that inventory.
+var (
+	remoteViewersMu sync.Mutex
+	remoteViewers   = map[*inventory.Inventory]map[*Session]struct{}{}
+)
+
+// OpenInventory opens an arbitrary inventory, such as the inventory of another player, for the client as a chest
+// with the title passed. Inventories larger than 27 slots are opened as a double chest. Changes made by the
+// client are passed through the inventory.Handler of the inventory. If readOnly is true, the client can view the
+// inventory, but cannot take items out of it or put items into it.
+// The context returned is cancelled once the inventory is closed. Its Err method then returns ErrQuit if the
+// client disconnected, or ErrInventoryClosed otherwise.
+func (s *Session) OpenInventory(inv *inventory.Inventory, title string, readOnly bool) context.Context {
+	ctx := newCloseContext()
+	if s == Nop {
+		ctx.close(ErrInventoryClosed)
+		return ctx
+	}
+	s.closeCurrentContainer()
+
+	w := s.c.World()
+	// The fake chests are placed above the head of the controllable, so that they are not in its way.
+	pos := cube.PosFromVec3(s.c.Position()).Add(cube.Pos{0, 3})
+	if max := w.Range()[1]; pos[1] > max {
+		pos[1] = max
+	}
+	chests := []cube.Pos{pos}
+	if inv.Size() > 27 {
+		chests = append(chests, pos.Side(cube.FaceEast))
+	}
+	for i, chestPos := range chests {
+		s.sendFakeChest(chestPos, chests[len(chests)-1-i], len(chests) > 1, title)
+	}
+
+	s.remoteMu.Lock()
+	s.remote = &remoteContainer{inv: inv, readOnly: readOnly, chests: chests, ctx: ctx}
+	s.remoteMu.Unlock()
+
+	remoteViewersMu.Lock()
+	if _, ok := remoteViewers[inv]; !ok {
+		remoteViewers[inv] = map[*Session]struct{}{}
+	}
+	remoteViewers[inv][s] = struct{}{}
+	remoteViewersMu.Unlock()
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inv)
+	s.openedPos.Store(pos)
+
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                nextID,
+		ContainerType:           0,
+		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		ContainerEntityUniqueID: -1,
+	})
+	s.sendInv(inv, uint32(nextID))
+	return ctx
+}
+
+// sendFakeChest sends a chest with a custom name to the client at the position passed, which is paired with a chest
+// at pairPos if paired is true.
+func (s *Session) sendFakeChest(pos, pairPos cube.Pos, paired bool, title string) {
+	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+	s.writePacket(&packet.UpdateBlock{
+		Position:          blockPos,
+		NewBlockRuntimeID: s.blockRuntimeID(block.NewChest()),
+		Flags:             packet.BlockUpdateNetwork,
+	})
+	m := map[string]interface{}{
+		"id":         "Chest",
+		"CustomName": title,
+		"x":          int32(pos[0]), "y": int32(pos[1]), "z": int32(pos[2]),
+	}
+	if paired {
+		m["pairx"], m["pairz"], m["pairlead"] = int32(pairPos[0]), int32(pairPos[2]), boolByte(pos[0] < pairPos[0])
+	}
+	s.writePacket(&packet.BlockActorData{Position: blockPos, NBTData: m})
+}
+
+// remoteInventory returns the remote container currently opened by the session, if any.
+func (s *Session) remoteInventory() (*remoteContainer, bool) {
+	s.remoteMu.Lock()
+	defer s.remoteMu.Unlock()
+	return s.remote, s.remote != nil
+}
+
+// closeRemoteInventory closes the inventory opened using OpenInventory, if any, and restores the blocks replaced
+// by fake chests for the client. It returns false if no such inventory was opened.
+func (s *Session) closeRemoteInventory() bool {
+	s.remoteMu.Lock()
+	r := s.remote
+	s.remote = nil
+	s.remoteMu.Unlock()
+	if r == nil {
+		return false
+	}
+
+	remoteViewersMu.Lock()
+	delete(remoteViewers[r.inv], s)
+	if len(remoteViewers[r.inv]) == 0 {
+		delete(remoteViewers, r.inv)
+	}
+	remoteViewersMu.Unlock()
+
+	w := s.c.World()
+	for _, pos := range r.chests {
+		s.ViewBlockUpdate(pos, w.Block(pos), 0)
+	}
+	if s.closed.Load() {
+		r.ctx.close(ErrQuit)
+	} else {
+		r.ctx.close(ErrInventoryClosed)
+	}
+	return true
+}
+
+// viewersOf returns all sessions that currently have the inventory passed opened using OpenInventory.
+func viewersOf(inv *inventory.Inventory) []*Session {
+	remoteViewersMu.Lock()
+	defer remoteViewersMu.Unlock()
+	viewers := make([]*Session, 0, len(remoteViewers[inv]))
+	for v := range remoteViewers[inv] {
+		viewers = append(viewers, v)
+	}
+	return viewers
+}
+
+// viewRemoteSlotChange shows a change of a slot in the inventory passed to all sessions that have it opened using
+// OpenInventory.
+func viewRemoteSlotChange(inv *inventory.Inventory, slot int, it item.Stack) {
+	for _, v := range viewersOf(inv) {
+		if v.openedWindow.Load() == inv {
+			v.ViewSlotChange(slot, it)
+		}
+	}
+}
+
+// viewRemoteInventory resends the full inventory passed to all sessions other than the one passed that have it
+// opened using OpenInventory.
+func viewRemoteInventory(inv *inventory.Inventory, except *Session) {
+	for _, v := range viewersOf(inv) {
+		if v != except && v.openedWindow.Load() == inv {
+			v.sendInv(inv, v.openedWindowID.Load())
+		}
+	}
+}
+
+// CloseViewers closes the inventories passed for all sessions that have them opened using OpenInventory. It
+// should be called when the owner of shared inventories is removed, such as when a player disconnects.
+func CloseViewers(invs ...*inventory.Inventory) {
+	for _, inv := range invs {
+		for _, v := range viewersOf(inv) {
+			v.closeCurrentContainer()
+		}
+	}
+}
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
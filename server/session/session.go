@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/internal"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/trade"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
 	"github.com/df-mc/dragonfly/server/world"
@@ -43,9 +45,24 @@ type Session struct {
 	chunkBuf                    *bytes.Buffer
 	chunkLoader                 *world.Loader
 	chunkRadius, maxChunkRadius int32
+	// inputMode is the InputMode currently used by the client.
+	inputMode atomic.Int32
+
+	// obfuscator obfuscates the chunks sent to the client. It is nil if obfuscation is disabled. revealMu guards
+	// reveals, which holds the positions of changed blocks around which hidden blocks should be revealed.
+	obfuscator *Obfuscator
+	revealMu   sync.Mutex
+	reveals    []cube.Pos
 
 	teleportMu  sync.Mutex
 	teleportPos *mgl64.Vec3
+	// preloadMu guards preloadGen and preloading. preloadGen is increased for every teleport, so that only the
+	// preload of the chunks around the destination of the latest teleport completes. preloading is true while
+	// those chunks are being sent to the client, during which the chunk loader is not moved along with the
+	// position of the client.
+	preloadMu  sync.Mutex
+	preloadGen uint64
+	preloading bool
 
 	entityMutex sync.RWMutex
 	// currentEntityRuntimeID holds the runtime ID assigned to the last entity. It is incremented for every
@@ -68,12 +85,36 @@ type Session struct {
 	openedWindow, openedPos        atomic.Value
 	swingingArm                    atomic.Bool
 
+	// remoteMu guards remote, which holds the inventory opened using OpenInventory, if any.
+	remoteMu sync.Mutex
+	remote   *remoteContainer
+
+	// tradeMu guards trader, which holds the trader whose trading UI is opened using OpenTrade, if any.
+	tradeMu sync.Mutex
+	trader  trade.Trader
+
 	blobMu                sync.Mutex
 	blobs                 map[uint64][]byte
 	openChunkTransactions []map[uint64]struct{}
 	invOpened             bool
 
+	// overrideMu guards timeOverride and weatherOverride, which hold the time and weather shown to the client
+	// instead of that of the world, if set.
+	overrideMu      sync.Mutex
+	timeOverride    *timeOverride
+	weatherOverride *weatherOverride
+
 	joinMessage, quitMessage *atomic.String
+
+	// buckets holds the token buckets that limit the rate of the actions of the client, indexed by
+	// RateLimitedAction.
+	buckets [rateLimitedActionCount]*bucket
+	// delayed holds the actions delayed by a RateLimit with the RateLimitDelay mode, ordered by the time at
+	// which they are due. It is only accessed on the goroutine handling packets.
+	delayed []delayedAction
+	closed  atomic.Bool
+
+	interceptors interceptors
 }
 
 // Conn represents a connection that packets are read from and written to by a Session. In addition, it holds some
@@ -124,8 +165,9 @@ var ErrSelfRuntimeID = errors.New("invalid entity runtime ID: runtime ID for sel
 // New returns a new session using a controllable entity. The session will control this entity using the
 // packets that it receives.
 // New takes the connection from which to accept packets. It will start handling these packets after a call to
-// Session.Start().
-func New(conn Conn, maxChunkRadius int, log internal.Logger, joinMessage, quitMessage *atomic.String) *Session {
+// Session.Start(). The RateLimits passed limit the rate at which the client may perform actions such as chatting.
+// If the Obfuscator passed is not nil, it is used to hide blocks in chunks sent to the client.
+func New(conn Conn, maxChunkRadius int, log internal.Logger, joinMessage, quitMessage *atomic.String, limits RateLimits, obfuscator *Obfuscator) *Session {
 	r := conn.ChunkRadius()
 	if r > maxChunkRadius {
 		r = maxChunkRadius
@@ -135,7 +177,7 @@ func New(conn Conn, maxChunkRadius int, log internal.Logger, joinMessage, quitMe
 	s := &Session{
 		chunkBuf:               bytes.NewBuffer(make([]byte, 0, 4096)),
 		openChunkTransactions:  make([]map[uint64]struct{}, 0, 8),
-		ui:                     inventory.New(51, nil),
+		ui:                     inventory.New(53, nil),
 		handlers:               map[uint32]packetHandler{},
 		entityRuntimeIDs:       map[world.Entity]uint64{},
 		entities:               map[uint64]world.Entity{},
@@ -149,9 +191,12 @@ func New(conn Conn, maxChunkRadius int, log internal.Logger, joinMessage, quitMe
 		heldSlot:               atomic.NewUint32(0),
 		joinMessage:            joinMessage,
 		quitMessage:            quitMessage,
+		buckets:                newBuckets(limits),
+		obfuscator:             obfuscator,
 	}
 	s.openedWindow.Store(inventory.New(1, nil))
 	s.openedPos.Store(cube.Pos{})
+	s.inputMode.Store(int32(conn.ClientData().CurrentInputMode))
 
 	s.registerHandlers()
 	return s
@@ -176,6 +221,7 @@ func (s *Session) Start(c Controllable, w *world.World, gm world.GameMode, onSto
 	w.AddEntity(s.c)
 	s.c.SetGameMode(gm)
 	s.SendSpeed(0.1)
+	s.SendExperience(s.c.ExperienceLevel())
 	for _, e := range s.c.Effects() {
 		s.SendEffect(e)
 	}
@@ -191,12 +237,13 @@ func (s *Session) Start(c Controllable, w *world.World, gm world.GameMode, onSto
 	s.sendInv(s.offHand, protocol.WindowIDOffHand)
 	s.sendInv(s.armour.Inventory(), protocol.WindowIDArmour)
 	s.writePacket(&packet.CreativeContent{Items: creativeItems()})
+	s.sendRecipes()
 }
 
 // Close closes the session, which in turn closes the controllable and the connection that the session
 // manages.
 func (s *Session) Close() error {
-	s.closeCurrentContainer()
+	s.CloseUIOnQuit()
 
 	_ = s.conn.Close()
 	_ = s.chunkLoader.Close()
@@ -209,8 +256,11 @@ func (s *Session) Close() error {
 		s.onStop(s.c)
 		s.onStop = nil
 
-		s.c.World().RemoveEntity(s.c)
+		// The controllable is closed before it is removed from its world, so that it is closed in the same way
+		// as when the server closes it, which calls the handler's HandleQuit.
+		w := s.c.World()
 		_ = s.c.Close()
+		w.RemoveEntity(s.c)
 	}
 
 	// This should always be called last due to the timing of the removal of entity runtime IDs.
@@ -224,6 +274,19 @@ func (s *Session) Close() error {
 	return nil
 }
 
+// CloseUIOnQuit closes any forms and container that the client still has open, calling the callbacks waiting
+// on them, and marks the session as closing. It is called before the Controllable of the session is closed, so
+// that these callbacks always run before the handler's HandleQuit is called. Inventories opened using
+// OpenInventory are closed with ErrQuit. Calling CloseUIOnQuit again has no effect.
+func (s *Session) CloseUIOnQuit() {
+	if s == Nop {
+		return
+	}
+	s.closed.Store(true)
+	s.closeCurrentContainer()
+	s.closeForms()
+}
+
 // CloseConnection closes the underlying connection of the session so that the session ends up being closed
 // eventually.
 func (s *Session) CloseConnection() {
@@ -267,6 +330,7 @@ func (s *Session) handlePackets() {
 		if err != nil {
 			return
 		}
+		s.performDelayed()
 		if err := s.handlePacket(pk); err != nil {
 			// An error occurred during the handling of a packet. Print the error and stop handling any more
 			// packets.
@@ -295,11 +359,19 @@ func (s *Session) sendChunks(stop <-chan struct{}) {
 				toLoad = 4
 			}
 			if err := s.chunkLoader.Load(toLoad); err != nil {
+				if s.chunkLoader.World().Closed() && s.c.World() != s.chunkLoader.World() {
+					// The world was unloaded right after the Controllable was moved out of it. The switch to
+					// the new world is handled in the next tick.
+					continue
+				}
 				// The world was closed. This should generally never happen, and if it does, we can assume the
 				// world was closed.
 				s.log.Debugf("error loading chunk: %v", err)
 				return
 			}
+			if s.obfuscator != nil {
+				s.revealBlocks()
+			}
 		case <-stop:
 			return
 		}
@@ -319,9 +391,22 @@ func (s *Session) sendCommands(stop <-chan struct{}) {
 		r                 = s.sendAvailableCommands()
 		enums, enumValues = s.enums()
 		ok                bool
+		updates           = make(chan cmd.Enum, 16)
 	)
+	remove := cmd.OnSoftEnumUpdate(func(e cmd.Enum) {
+		select {
+		case updates <- e:
+		default:
+			// The enum will be updated during the next periodic check anyway.
+		}
+	})
+	defer remove()
 	for {
 		select {
+		case e := <-updates:
+			if enum, ok := enums[e.Type()]; ok {
+				s.resendEnums(map[string]cmd.Enum{e.Type(): enum}, enumValues)
+			}
 		case <-tc.C:
 			r, ok = s.resendCommands(r)
 			if ok {
@@ -355,12 +440,20 @@ func (s *Session) handleWorldSwitch() {
 		s.writePacket(&packet.ChangeDimension{Dimension: int32(s.c.World().Dimension().EncodeDimension()), Position: vec64To32(s.c.Position().Add(entityOffset(s.c)))})
 		s.writePacket(&packet.PlayStatus{Status: packet.PlayStatusPlayerSpawn})
 	}
+	s.revealMu.Lock()
+	s.reveals = nil
+	s.revealMu.Unlock()
+
 	s.chunkLoader.ChangeWorld(s.c.World())
+	s.sendOverrides()
 }
 
 // handlePacket handles an incoming packet, processing it accordingly. If the packet had invalid data or was
 // otherwise not valid in its context, an error is returned.
 func (s *Session) handlePacket(pk packet.Packet) error {
+	if !s.interceptors.intercept(pk, false) {
+		return nil
+	}
 	handler, ok := s.handlers[pk.ID()]
 	if !ok {
 		s.log.Debugf("unhandled packet %T%v from %v\n", pk, fmt.Sprintf("%+v", pk)[1:], s.conn.RemoteAddr())
@@ -410,7 +503,7 @@ func (s *Session) registerHandlers() {
 
 // writePacket writes a packet to the session's connection if it is not Nop.
 func (s *Session) writePacket(pk packet.Packet) {
-	if s == Nop {
+	if s == Nop || !s.interceptors.intercept(pk, true) {
 		return
 	}
 	_ = s.conn.WritePacket(pk)
@@ -0,0 +1,121 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+const (
+	// smithingInputSlot and smithingMaterialSlot are the slots in the UI inventory that hold the item to upgrade
+	// and the material used to upgrade it in a smithing table.
+	smithingInputSlot, smithingMaterialSlot = 0x33, 0x34
+	// smithingNetworkIDOffset is added to the index of a smithing recipe to get its network ID, as the client
+	// does not accept recipes with a network ID of 0.
+	smithingNetworkIDOffset = 1
+)
+
+// sendRecipes sends all smithing recipes registered using item.RegisterSmithingRecipe to the client, so that it
+// is able to show the result of an upgrade in the smithing table, along with the recipe for copying banners.
+func (s *Session) sendRecipes() {
+	smithing := item.SmithingRecipes()
+	recipes := make([]protocol.Recipe, 0, len(smithing)+1)
+	for i, r := range smithing {
+		base, ok := recipeIngredient(r.Base)
+		if !ok {
+			continue
+		}
+		addition, ok := recipeIngredient(r.Addition)
+		if !ok {
+			continue
+		}
+		name, _ := r.Result.EncodeItem()
+		recipes = append(recipes, &protocol.ShapelessRecipe{
+			RecipeID:        fmt.Sprintf("smithing_%v_%v", i, name),
+			Input:           []protocol.RecipeIngredientItem{base, addition},
+			Output:          []protocol.ItemStack{stackFromItem(item.NewStack(r.Result, 1))},
+			Block:           "smithing_table",
+			RecipeNetworkID: uint32(i + smithingNetworkIDOffset),
+		})
+	}
+	recipes = append(recipes, bannerCopyRecipe)
+	s.writePacket(&packet.CraftingData{Recipes: recipes, ClearRecipes: true})
+}
+
+// recipeIngredient returns the recipe ingredient of the item passed. False is returned if the item does not
+// have a runtime ID.
+func recipeIngredient(it world.Item) (protocol.RecipeIngredientItem, bool) {
+	rid, meta, ok := world.ItemRuntimeID(it)
+	if !ok {
+		return protocol.RecipeIngredientItem{}, false
+	}
+	return protocol.RecipeIngredientItem{NetworkID: rid, MetadataValue: int32(meta), Count: 1}, true
+}
+
+// handleCraft handles the crafting of a recipe by its network ID. Depending on the UI opened, the recipe is
+// either a trade, the copying of a banner or a smithing recipe.
+func (h *ItemStackRequestHandler) handleCraft(a *protocol.CraftRecipeStackRequestAction, s *Session) error {
+	if _, ok := s.tradingWith(); ok {
+		return h.handleTrade(a, s)
+	}
+	if a.RecipeNetworkID == bannerCopyNetworkID {
+		return h.handleBannerCopy(s)
+	}
+	return h.handleSmithing(a, s)
+}
+
+// handleSmithing handles the upgrading of an item in a smithing table. One of both the input and the material
+// are consumed and the upgraded item is placed in the output slot in the same request, so that the request is
+// reverted as a whole if it turns out to be invalid.
+func (h *ItemStackRequestHandler) handleSmithing(a *protocol.CraftRecipeStackRequestAction, s *Session) error {
+	// First check if there actually is a smithing table opened.
+	if !s.containerOpened.Load() {
+		return fmt.Errorf("no smithing table container opened")
+	}
+	pos := s.openedPos.Load().(cube.Pos)
+	if _, ok := s.c.World().Block(pos).(block.SmithingTable); !ok {
+		return fmt.Errorf("no smithing table container opened")
+	}
+
+	recipes, index := item.SmithingRecipes(), int(a.RecipeNetworkID)-smithingNetworkIDOffset
+	if index < 0 || index >= len(recipes) {
+		return fmt.Errorf("unknown smithing recipe with network ID %v", a.RecipeNetworkID)
+	}
+	recipe := recipes[index]
+
+	inputSlot := protocol.StackRequestSlotInfo{ContainerID: containerSmithingInput, Slot: smithingInputSlot}
+	materialSlot := protocol.StackRequestSlotInfo{ContainerID: containerSmithingMaterial, Slot: smithingMaterialSlot}
+	input, _ := h.itemInSlot(inputSlot, s)
+	material, _ := h.itemInSlot(materialSlot, s)
+	if input.Empty() || material.Empty() {
+		return fmt.Errorf("smithing table requires both an input and a material")
+	}
+	if !sameItem(input.Item(), recipe.Base) || !sameItem(material.Item(), recipe.Addition) {
+		return fmt.Errorf("input %v and material %v do not match smithing recipe %v", input, material, a.RecipeNetworkID)
+	}
+
+	result, ok := s.c.UseSmithingTable(pos, input.Grow(1-input.Count()), material.Grow(1-material.Count()), recipe.Smith(input))
+	if !ok {
+		return fmt.Errorf("smithing table use was cancelled")
+	}
+
+	h.consumeInput(inputSlot, 1, s)
+	h.consumeInput(materialSlot, 1, s)
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID:    containerCreativeOutput,
+		Slot:           50,
+		StackNetworkID: item_id(result),
+	}, result, s)
+	return nil
+}
+
+// sameItem checks if the two items passed have the same name and meta.
+func sameItem(a, b world.Item) bool {
+	name, meta := a.EncodeItem()
+	name2, meta2 := b.EncodeItem()
+	return name == name2 && meta == meta2
+}
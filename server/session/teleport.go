@@ -0,0 +1,137 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"math"
+	"time"
+)
+
+const (
+	// preloadRadius is the radius in chunks around the destination of a teleport that is sent to the client
+	// before the teleport itself, so that the client does not fall into the void while the chunks arrive.
+	preloadRadius = 1
+	// preloadTimeout is the maximum time that is waited for the chunks around the destination of a teleport to
+	// be sent. The teleport is continued once it passes, even if not all chunks were sent.
+	preloadTimeout = time.Second * 5
+)
+
+// PreloadChunks sends the chunks around the position passed to the client and calls f once they were sent, or
+// once sending them takes too long. If the chunks were already sent to the client, f is called immediately.
+// Otherwise, the chunk loader of the Session is moved to the position, so that the chunks are sent to the client
+// before the Controllable is teleported there, and f is called on a different goroutine. If PreloadChunks is
+// called again before the chunks were sent, the earlier preload is abandoned and its f is never called.
+// While the chunks are being sent, the Controllable is shown to the client as immobile, so that it does not move
+// away from its position. This is separate from the immobile state of the Controllable itself, which it does not
+// change.
+func (s *Session) PreloadChunks(pos mgl64.Vec3, f func()) {
+	s.preloadMu.Lock()
+	s.preloadGen++
+	gen := s.preloadGen
+	if s.ChunksSent(pos) {
+		wasPreloading := s.preloading
+		s.preloading = false
+		s.preloadMu.Unlock()
+		f()
+		if wasPreloading {
+			s.ViewEntityState(s.c)
+		}
+		return
+	}
+	wasPreloading := s.preloading
+	s.preloading = true
+	s.preloadMu.Unlock()
+	if !wasPreloading {
+		s.ViewEntityState(s.c)
+	}
+
+	s.chunkLoader.Move(pos)
+	s.writePacket(&packet.NetworkChunkPublisherUpdate{
+		Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		Radius:   uint32(s.chunkRadius) << 4,
+	})
+
+	go func() {
+		t := time.NewTicker(time.Second / 20)
+		defer t.Stop()
+		timeout := time.After(preloadTimeout)
+		for !s.ChunksSent(pos) {
+			select {
+			case <-t.C:
+			case <-timeout:
+				s.log.Debugf("timed out preloading chunks around %v for %v", pos, s.c.Name())
+				if s.finishPreload(gen) {
+					f()
+					s.ViewEntityState(s.c)
+				}
+				return
+			}
+			if s.closed.Load() {
+				s.finishPreload(gen)
+				return
+			}
+			if !s.currentPreload(gen) {
+				// A newer teleport was started, which takes over the chunk loader.
+				return
+			}
+		}
+		if s.finishPreload(gen) {
+			f()
+			// The Controllable was moved, so the client may move it again.
+			s.ViewEntityState(s.c)
+		}
+	}()
+}
+
+// currentPreload checks if the preload with the generation passed is still the latest one.
+func (s *Session) currentPreload(gen uint64) bool {
+	s.preloadMu.Lock()
+	defer s.preloadMu.Unlock()
+	return s.preloadGen == gen
+}
+
+// finishPreload ends the preload with the generation passed, so that the chunk loader follows the client again
+// and the Controllable is no longer shown as immobile once its state is next sent. False is returned if a newer
+// preload was started in the meantime, in which case nothing is changed.
+func (s *Session) finishPreload(gen uint64) bool {
+	s.preloadMu.Lock()
+	defer s.preloadMu.Unlock()
+	if s.preloadGen != gen {
+		return false
+	}
+	s.preloading = false
+	return true
+}
+
+// preloadingChunks checks if the chunks around the destination of a teleport are currently being sent to the
+// client.
+func (s *Session) preloadingChunks() bool {
+	s.preloadMu.Lock()
+	defer s.preloadMu.Unlock()
+	return s.preloading
+}
+
+// controlledPreloading checks if the entity passed is the Controllable of the Session and if chunks are being
+// preloaded for it, in which case it is shown to the client as immobile.
+func (s *Session) controlledPreloading(e world.Entity) bool {
+	return s != Nop && e == s.c && s.preloadingChunks()
+}
+
+// ChunksSent checks if all chunks around the position passed were sent to the client, so that it may be
+// teleported there right away. ChunksSent always returns true for the Nop session.
+func (s *Session) ChunksSent(pos mgl64.Vec3) bool {
+	if s == Nop {
+		return true
+	}
+	x, z := int32(math.Floor(pos[0]))>>4, int32(math.Floor(pos[2]))>>4
+	for i := x - preloadRadius; i <= x+preloadRadius; i++ {
+		for j := z - preloadRadius; j <= z+preloadRadius; j++ {
+			if !s.chunkLoader.Loaded(world.ChunkPos{i, j}) {
+				return false
+			}
+		}
+	}
+	return true
+}
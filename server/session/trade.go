@@ -0,0 +1,161 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"math"
+)
+
+const (
+	// tradeWindowType is the type of window used to show the trading UI.
+	tradeWindowType = 15
+	// tradeInput1Slot and tradeInput2Slot are the slots in the UI inventory that hold the items put in the two
+	// input slots of the trading UI.
+	tradeInput1Slot, tradeInput2Slot = 0x04, 0x05
+)
+
+// OpenTrade opens the trading UI for the trade.Trader passed, showing the title passed at the top of the UI. The
+// Trader must be visible to the client for the UI to open. Trades executed by the client are validated against
+// the trade.TradeList of the Trader.
+func (s *Session) OpenTrade(t trade.Trader, title string) {
+	if s == Nop {
+		return
+	}
+	s.closeCurrentContainer()
+
+	s.tradeMu.Lock()
+	s.trader = t
+	s.tradeMu.Unlock()
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inventory.New(1, nil))
+	s.openedPos.Store(cube.PosFromVec3(t.Position()))
+
+	s.sendTrades(t, title, nextID)
+}
+
+// sendTrades sends the trades of the trade.Trader passed to the client in the window with the ID passed.
+func (s *Session) sendTrades(t trade.Trader, title string, windowID byte) {
+	list := t.TradeList()
+	trades := list.Trades()
+
+	recipes := make([]interface{}, 0, len(trades))
+	for i, tr := range trades {
+		price := list.Price(i)
+		maxUses := tr.MaxUses
+		if maxUses <= 0 {
+			maxUses = math.MaxInt32
+		}
+		recipe := map[string]interface{}{
+			"buyA":             nbtconv.WriteItem(price, true),
+			"buyCountA":        int32(price.Count()),
+			"buyCountB":        int32(tr.SecondInput.Count()),
+			"sell":             nbtconv.WriteItem(tr.Output, true),
+			"uses":             int32(list.Uses(i)),
+			"maxUses":          int32(maxUses),
+			"demand":           int32(0),
+			"priceMultiplierA": float32(tr.PriceMultiplier),
+			"priceMultiplierB": float32(0),
+			"rewardExp":        boolByte(tr.Experience > 0),
+			"traderExp":        int32(0),
+			"tier":             int32(0),
+			"netId":            int32(i + tradeNetworkIDOffset),
+		}
+		if !tr.SecondInput.Empty() {
+			recipe["buyB"] = nbtconv.WriteItem(tr.SecondInput, true)
+		}
+		recipes = append(recipes, recipe)
+	}
+	offers, err := nbt.Marshal(map[string]interface{}{
+		"Recipes":             recipes,
+		"TierExpRequirements": []interface{}{map[string]interface{}{"0": int32(0)}},
+	})
+	if err != nil {
+		s.log.Errorf("error encoding trades: %v", err)
+		return
+	}
+	s.writePacket(&packet.UpdateTrade{
+		WindowID:         windowID,
+		WindowType:       tradeWindowType,
+		Size:             int32(len(trades)),
+		VillagerUniqueID: int64(s.entityRuntimeID(t)),
+		EntityUniqueID:   selfEntityRuntimeID,
+		DisplayName:      title,
+		NewTradeUI:       true,
+		SerialisedOffers: offers,
+	})
+}
+
+// tradeNetworkIDOffset is added to the index of a trade to get its network ID, as the client does not accept
+// recipes with a network ID of 0.
+const tradeNetworkIDOffset = 1
+
+// tradingWith returns the trade.Trader whose trading UI is currently opened by the session, if any.
+func (s *Session) tradingWith() (trade.Trader, bool) {
+	s.tradeMu.Lock()
+	defer s.tradeMu.Unlock()
+	return s.trader, s.trader != nil
+}
+
+// closeTrade closes the trading UI opened using OpenTrade, if any, and returns the items in its input slots to
+// the inventory of the Controllable. It returns false if no trading UI was opened.
+func (s *Session) closeTrade() bool {
+	s.tradeMu.Lock()
+	t := s.trader
+	s.trader = nil
+	s.tradeMu.Unlock()
+	if t == nil {
+		return false
+	}
+	s.returnUIItems(tradeInput1Slot, tradeInput2Slot)
+	return true
+}
+
+// handleTrade handles the execution of a trade in the trading UI. The network ID of the recipe crafted is that of
+// the trade executed. The inputs are consumed and the output is placed in the output slot in the same request,
+// so that the request is reverted as a whole if the trade turns out to be invalid.
+func (h *ItemStackRequestHandler) handleTrade(a *protocol.CraftRecipeStackRequestAction, s *Session) error {
+	t, ok := s.tradingWith()
+	if !ok || !s.containerOpened.Load() {
+		return fmt.Errorf("no trading UI opened")
+	}
+	if t.World() != s.c.World() {
+		return fmt.Errorf("trader is not in the same world")
+	}
+	list, index := t.TradeList(), int(a.RecipeNetworkID)-tradeNetworkIDOffset
+	tr, ok := list.Trade(index)
+	if !ok {
+		return fmt.Errorf("unknown trade with network ID %v", a.RecipeNetworkID)
+	}
+
+	input1Slot := protocol.StackRequestSlotInfo{ContainerID: containerTradeInput1, Slot: tradeInput1Slot}
+	input2Slot := protocol.StackRequestSlotInfo{ContainerID: containerTradeInput2, Slot: tradeInput2Slot}
+	input1, _ := h.itemInSlot(input1Slot, s)
+	input2, _ := h.itemInSlot(input2Slot, s)
+	if err := list.Validate(index, input1, input2); err != nil {
+		return err
+	}
+	if !s.c.TradeWith(t, tr) {
+		return fmt.Errorf("trade was cancelled")
+	}
+	left1, left2, err := list.Execute(index, input1, input2)
+	if err != nil {
+		return err
+	}
+
+	h.consumeInput(input1Slot, input1.Count()-left1.Count(), s)
+	h.consumeInput(input2Slot, input2.Count()-left2.Count(), s)
+	h.setItemInSlot(protocol.StackRequestSlotInfo{
+		ContainerID:    containerCreativeOutput,
+		Slot:           50,
+		StackNetworkID: item_id(tr.Output),
+	}, tr.Output, s)
+	return nil
+}
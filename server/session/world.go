@@ -9,6 +9,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/action"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/armour"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/chunk"
@@ -25,6 +26,9 @@ import (
 
 // ViewChunk ...
 func (s *Session) ViewChunk(pos world.ChunkPos, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) {
+	if w := s.c.World(); s.obfuscator != nil && w != nil {
+		c = s.obfuscator.obfuscate(w.Dimension(), c)
+	}
 	if !s.conn.ClientCacheEnabled() {
 		s.sendNetworkChunk(pos, c, blockEntities)
 		return
@@ -118,17 +122,36 @@ func (s *Session) sendNetworkChunk(pos world.ChunkPos, c *chunk.Chunk, blockEnti
 	s.chunkBuf.Reset()
 }
 
-// entityHidden checks if a world.Entity is being explicitly hidden from the Session.
+// visibilityLimited represents an entity that is only visible to specific entities, such as an NPC shown to a
+// limited set of players.
+type visibilityLimited interface {
+	// VisibleTo checks if the entity is visible to the viewing entity passed.
+	VisibleTo(viewer world.Entity) bool
+}
+
+// entityHidden checks if a world.Entity is being explicitly hidden from the Session, or if it is another
+// Controllable in a game mode that is not visible, such as spectator mode.
 func (s *Session) entityHidden(e world.Entity) bool {
 	s.entityMutex.RLock()
 	_, ok := s.hiddenEntities[e]
 	s.entityMutex.RUnlock()
+	if c, controllable := e.(Controllable); controllable && c != s.c && !c.GameMode().Visible() {
+		return true
+	}
 	return ok
 }
 
 // ViewEntity ...
 func (s *Session) ViewEntity(e world.Entity) {
-	if s.entityRuntimeID(e) == selfEntityRuntimeID || s.entityHidden(e) {
+	if s == Nop || s.entityRuntimeID(e) == selfEntityRuntimeID || s.entityHidden(e) {
+		return
+	}
+	if v, ok := e.(visibilityLimited); ok && !v.VisibleTo(s.c) {
+		// The entity may only be seen by specific viewers, so we hide it from the session until it is
+		// explicitly shown using StartShowingEntity.
+		s.entityMutex.Lock()
+		s.hiddenEntities[e] = struct{}{}
+		s.entityMutex.Unlock()
 		return
 	}
 	var runtimeID uint64
@@ -152,7 +175,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 
 	id := e.EncodeEntity()
 	switch v := e.(type) {
-	case Controllable:
+	case Humanoid:
 		actualPlayer := false
 
 		sessionMu.Lock()
@@ -180,6 +203,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 			Pitch:           float32(pitch),
 			Yaw:             float32(yaw),
 			HeadYaw:         float32(yaw),
+			EntityLinks:     s.entityLinks(e),
 		})
 		if !actualPlayer {
 			s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{
@@ -218,9 +242,44 @@ func (s *Session) ViewEntity(e world.Entity) {
 		Pitch:           float32(pitch),
 		Yaw:             float32(yaw),
 		HeadYaw:         float32(yaw),
+		EntityLinks:     s.entityLinks(e),
 	})
 }
 
+// entityLinks returns the links of the entity passed to the entity it is riding and to the entities riding it,
+// which are sent when the entity is spawned for the session. Links to entities not yet viewed by the session
+// are omitted: They are sent once those entities are spawned, so that stacks of entities riding each other are
+// linked in any order.
+func (s *Session) entityLinks(e world.Entity) []protocol.EntityLink {
+	var links []protocol.EntityLink
+	link := func(rider, ridden world.Entity, seat int) {
+		riderID, riddenID := s.entityRuntimeID(rider), s.entityRuntimeID(ridden)
+		if riderID == 0 || riddenID == 0 {
+			return
+		}
+		linkType := protocol.EntityLinkPassenger
+		if seat == 0 {
+			linkType = protocol.EntityLinkRider
+		}
+		links = append(links, protocol.EntityLink{
+			RiddenEntityUniqueID: int64(riddenID),
+			RiderEntityUniqueID:  int64(riderID),
+			Type:                 byte(linkType),
+		})
+	}
+	if r, ok := e.(entity.Rider); ok {
+		if ridden, seat := r.RidingEntity(); ridden != nil {
+			link(e, ridden, seat)
+		}
+	}
+	if r, ok := e.(entity.Rideable); ok {
+		for seat, rider := range r.Riders() {
+			link(rider, r, seat)
+		}
+	}
+	return links
+}
+
 // HideEntity ...
 func (s *Session) HideEntity(e world.Entity) {
 	if s.entityRuntimeID(e) == selfEntityRuntimeID {
@@ -274,7 +333,7 @@ func (s *Session) ViewEntityVelocity(e world.Entity, velocity mgl64.Vec3) {
 // entityOffset returns the offset that entities have client-side.
 func entityOffset(e world.Entity) mgl64.Vec3 {
 	switch e.(type) {
-	case Controllable:
+	case Humanoid:
 		return mgl64.Vec3{0, 1.62}
 	case *entity.Item:
 		return mgl64.Vec3{0, 0.125}
@@ -286,6 +345,12 @@ func entityOffset(e world.Entity) mgl64.Vec3 {
 
 // ViewTime ...
 func (s *Session) ViewTime(time int) {
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	if s.timeOverride != nil {
+		// The time of the world is not shown to the client while the time is overridden.
+		return
+	}
 	s.writePacket(&packet.SetTime{Time: int32(time)})
 }
 
@@ -307,7 +372,7 @@ func (s *Session) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
 	yaw, pitch := e.Rotation()
 
 	switch e.(type) {
-	case Controllable:
+	case Humanoid:
 		s.writePacket(&packet.MovePlayer{
 			EntityRuntimeID: id,
 			Position:        vec64To32(position.Add(entityOffset(e))),
@@ -424,6 +489,13 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			Position:  vec64To32(pos),
 			EventData: int32((((((abs(pa.Diff.X()) << 16) | (abs(pa.Diff.Y()) << 8)) | abs(pa.Diff.Z())) | xSign) | ySign) | zSign),
 		})
+	case particle.JukeboxNote:
+		s.writePacket(&packet.SpawnParticleEffect{
+			Dimension:      packet.DimensionOverworld,
+			EntityUniqueID: -1,
+			Position:       vec64To32(pos),
+			ParticleName:   "minecraft:note_particle",
+		})
 	case particle.Note:
 		s.writePacket(&packet.BlockEvent{
 			EventType: pa.Instrument.Int32(),
@@ -462,6 +534,12 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			EventType: packet.LevelEventParticlesTeleport,
 			Position:  vec64To32(pos),
 		})
+	case particle.DispenseSmoke:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventParticlesShoot,
+			Position:  vec64To32(pos),
+			EventData: dispenseSmokeData(pa.Face),
+		})
 	case particle.Evaporate:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventParticlesEvaporateWater,
@@ -481,6 +559,14 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 	}
 }
 
+// dispenseSmokeData returns the event data of the smoke particles shown in front of a dispenser facing the face
+// passed. The data is the index of the direction in a 3x3 grid on the X and Z axes, with the centre used for
+// the vertical faces.
+func dispenseSmokeData(face cube.Face) int32 {
+	offset := cube.Pos{}.Side(face)
+	return int32((offset[0] + 1) + (offset[2]+1)*3)
+}
+
 // ViewSound ...
 func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 	pk := &packet.LevelSoundEvent{
@@ -489,6 +575,21 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		ExtraData:  -1,
 	}
 	switch so := soundType.(type) {
+	case sound.Custom:
+		volume, pitch := so.Volume, so.Pitch
+		if volume == 0 {
+			volume = 1
+		}
+		if pitch == 0 {
+			pitch = 1
+		}
+		s.writePacket(&packet.PlaySound{
+			SoundName: so.Name,
+			Position:  vec64To32(pos),
+			Volume:    float32(volume),
+			Pitch:     float32(pitch),
+		})
+		return
 	case sound.Note:
 		pk.SoundType = packet.SoundEventNote
 		pk.ExtraData = (so.Instrument.Int32() << 8) | int32(so.Pitch)
@@ -500,6 +601,19 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		return
 	case sound.Explosion:
 		pk.SoundType = packet.SoundEventExplode
+	case sound.FireworkLaunch:
+		pk.SoundType = packet.SoundEventLaunch
+	case sound.FireworkBlast:
+		pk.SoundType = packet.SoundEventBlast
+		if so.Large {
+			pk.SoundType = packet.SoundEventLargeBlast
+		}
+	case sound.FireworkTwinkle:
+		pk.SoundType = packet.SoundEventTwinkle
+	case sound.LeashKnotPlace:
+		pk.SoundType = packet.SoundEventPlaceLeashKnot
+	case sound.LeashKnotBreak:
+		pk.SoundType = packet.SoundEventBreakLeashKnot
 	case sound.Thunder:
 		pk.SoundType, pk.EntityType = packet.SoundEventThunder, "minecraft:lightning_bolt"
 	case sound.Click:
@@ -508,6 +622,12 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 			Position:  vec64To32(pos),
 		})
 		return
+	case sound.ClickFail:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventSoundClickFail,
+			Position:  vec64To32(pos),
+		})
+		return
 	case sound.Pop:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventSoundInfinityArrowPickup,
@@ -542,8 +662,72 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		pk.SoundType = packet.SoundEventExtinguishFire
 	case sound.Ignite:
 		pk.SoundType = packet.SoundEventIgnite
+	case sound.GrindstoneUse:
+		pk.SoundType = packet.SoundEventGrindstoneUse
+	case sound.SmithingTableUse:
+		pk.SoundType = packet.SoundEventSmithingTableUse
 	case sound.Burp:
 		pk.SoundType = packet.SoundEventBurp
+	case sound.RespawnAnchorCharge:
+		pk.SoundType = packet.SoundEventRespawnAnchorCharge
+	case sound.RespawnAnchorDeplete:
+		pk.SoundType = packet.SoundEventRespawnAnchorDeplete
+	case sound.RespawnAnchorSetSpawn:
+		pk.SoundType = packet.SoundEventRespawnAnchorSetSpawn
+	case sound.CauldronFillWater:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronFillWater,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronTakeWater:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronTakeWater,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronFillLava:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronFillLava,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronTakeLava:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronTakeLava,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronFillPotion:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronFillPotion,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronTakePotion:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronTakePotion,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronAddDye:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronAddDye,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronDyeArmour:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronDyeArmor,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronCleanArmour:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronCleanArmor,
+			Position:  vec64To32(pos),
+		})
+		return
 	case sound.Door:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventSoundOpenDoor,
@@ -558,6 +742,12 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		pk.SoundType = packet.SoundEventChestClosed
 	case sound.ChestOpen:
 		pk.SoundType = packet.SoundEventChestOpen
+	case sound.BellRing:
+		pk.SoundType = packet.SoundEventBell
+	case sound.EnderChestClose:
+		pk.SoundType = packet.SoundEventEnderChestClosed
+	case sound.EnderChestOpen:
+		pk.SoundType = packet.SoundEventEnderChestOpen
 	case sound.BarrelClose:
 		pk.SoundType = packet.SoundEventBarrelClose
 	case sound.BarrelOpen:
@@ -568,6 +758,12 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		pk.SoundType = packet.SoundEventBreak
 	case sound.ItemUseOn:
 		pk.SoundType, pk.ExtraData = packet.SoundEventItemUseOn, int32(s.blockRuntimeID(so.Block))
+	case sound.EquipItem:
+		pk.SoundType = equipSound(so.Item)
+	case sound.MusicDiscPlay:
+		pk.SoundType = musicDiscSound(so.DiscType)
+	case sound.MusicDiscEnd:
+		pk.SoundType = packet.SoundEventRecordNull
 	case sound.Fizz:
 		pk.SoundType = packet.SoundEventFizz
 	case sound.GlassBreak:
@@ -589,6 +785,12 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 			break
 		}
 		pk.SoundType = packet.SoundEventBucketEmptyLava
+	case sound.CrossbowLoad:
+		pk.SoundType = packet.SoundEventCrossbowLoadingEnd
+	case sound.CrossbowShoot:
+		pk.SoundType = packet.SoundEventCrossbowShoot
+	case sound.ArrowHit:
+		pk.SoundType = packet.SoundEventBowHit
 	case sound.ItemThrow:
 		pk.SoundType, pk.EntityType = packet.SoundEventThrow, "minecraft:player"
 	}
@@ -598,6 +800,9 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 // ViewBlockUpdate ...
 func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
 	runtimeID, _ := world.BlockRuntimeID(b)
+	if s.obfuscator != nil && exposes(runtimeID) {
+		s.queueReveal(pos)
+	}
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
 	s.writePacket(&packet.UpdateBlock{
 		Position:          blockPos,
@@ -615,11 +820,46 @@ func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
 	}
 }
 
+// ResendBlocks resends the blocks in a cube with the radius passed around the position passed to the client,
+// along with their liquids and block entity data, to roll back changes that the client predicted but that
+// did not happen server-side, such as a block placement or break that was cancelled. A radius of 1 or more
+// makes sure that both halves of multi-block structures, such as doors, beds and double chests, are resent.
+// Unlike setting the blocks in the world again, only the session itself is sent the blocks.
+func (s *Session) ResendBlocks(pos cube.Pos, radius int) {
+	if s == Nop {
+		return
+	}
+	w := s.c.World()
+	r := w.Range()
+	for x := pos[0] - radius; x <= pos[0]+radius; x++ {
+		for y := pos[1] - radius; y <= pos[1]+radius; y++ {
+			for z := pos[2] - radius; z <= pos[2]+radius; z++ {
+				p := cube.Pos{x, y, z}
+				if p.OutOfBounds(r) {
+					continue
+				}
+				b := w.Block(p)
+				s.ViewBlockUpdate(p, b, 0)
+
+				// The liquid layer is always resent, so that liquids placed or removed client-side, for example
+				// by using a bucket, are rolled back too.
+				var liquid world.Block = block.Air{}
+				if _, ok := b.(world.Liquid); !ok {
+					if l, ok := w.Liquid(p); ok {
+						liquid = l
+					}
+				}
+				s.ViewBlockUpdate(p, liquid, 1)
+			}
+		}
+	}
+}
+
 // ViewEntityAction ...
 func (s *Session) ViewEntityAction(e world.Entity, a action.Action) {
 	switch act := a.(type) {
 	case action.SwingArm:
-		if _, ok := e.(Controllable); ok {
+		if _, ok := e.(Humanoid); ok {
 			if s.entityRuntimeID(e) == selfEntityRuntimeID && s.swingingArm.Load() {
 				return
 			}
@@ -648,11 +888,22 @@ func (s *Session) ViewEntityAction(e world.Entity, a action.Action) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventDeath,
 		})
+	case action.FireworkExplosion:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventFireworksExplode,
+		})
 	case action.PickedUp:
 		s.writePacket(&packet.TakeItemActor{
 			ItemEntityRuntimeID:  s.entityRuntimeID(e),
 			TakerEntityRuntimeID: s.entityRuntimeID(act.Collector.(world.Entity)),
 		})
+	case action.StackSizeUpdate:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventUpdateStackSize,
+			EventData:       int32(act.Count),
+		})
 	case action.Eat:
 		if user, ok := e.(item.User); ok {
 			held, _ := user.HeldItems()
@@ -673,7 +924,7 @@ func (s *Session) ViewEntityAction(e world.Entity, a action.Action) {
 func (s *Session) ViewEntityState(e world.Entity) {
 	s.writePacket(&packet.SetActorData{
 		EntityRuntimeID: s.entityRuntimeID(e),
-		EntityMetadata:  parseEntityMetadata(e),
+		EntityMetadata:  s.parseEntityMetadata(e),
 	})
 }
 
@@ -700,6 +951,14 @@ func (s *Session) OpenBlockContainer(pos cube.Pos) {
 	switch b.(type) {
 	case block.Beacon:
 		containerType = 13
+	case block.Loom:
+		containerType = 24
+	case block.Grindstone:
+		containerType = 26
+	case block.CartographyTable:
+		containerType = 30
+	case block.SmithingTable:
+		containerType = 33
 	}
 	s.writePacket(&packet.ContainerOpen{
 		WindowID:                nextID,
@@ -709,6 +968,43 @@ func (s *Session) OpenBlockContainer(pos cube.Pos) {
 	})
 }
 
+// OpenEnderChest opens the ender chest inventory passed using the ender chest at the position passed. The
+// inventory shown is that of the Controllable of the Session, rather than one held by the block.
+func (s *Session) OpenEnderChest(pos cube.Pos, inv *inventory.Inventory) {
+	if s.containerOpened.Load() && s.openedPos.Load() == pos {
+		return
+	}
+	s.closeCurrentContainer()
+
+	b, ok := s.c.World().Block(pos).(block.EnderChest)
+	if !ok {
+		return
+	}
+	b.AddViewer(s, s.c.World(), pos)
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inv)
+	s.openedPos.Store(pos)
+
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                nextID,
+		ContainerType:           0,
+		ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		ContainerEntityUniqueID: -1,
+	})
+	s.sendInv(inv, uint32(nextID))
+}
+
+// ViewEnderChestSlotChange views a change of a slot in the ender chest inventory passed. The change is only
+// sent if the inventory is currently opened.
+func (s *Session) ViewEnderChestSlotChange(inv *inventory.Inventory, slot int, newItem item.Stack) {
+	if s == Nop || !s.containerOpened.Load() || s.openedWindow.Load() != inv {
+		return
+	}
+	s.ViewSlotChange(slot, newItem)
+}
+
 // openNormalContainer opens a normal container that can hold items in it server-side.
 func (s *Session) openNormalContainer(b block.Container, pos cube.Pos) {
 	b.AddViewer(s, s.c.World(), pos)
@@ -720,6 +1016,14 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos) {
 
 	var containerType byte
 	switch b.(type) {
+	case block.Furnace:
+		containerType = 2
+	case block.Dispenser:
+		containerType = 6
+	case block.Dropper:
+		containerType = 7
+	case block.Hopper:
+		containerType = 8
 	}
 
 	s.writePacket(&packet.ContainerOpen{
@@ -731,6 +1035,20 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos) {
 	s.sendInv(b.Inventory(), uint32(nextID))
 }
 
+// ReopenContainer closes the container currently opened if its inventory is the one passed, and opens the
+// container at the same position again if there still is one. The session must already have been removed as a
+// viewer of the container.
+func (s *Session) ReopenContainer(inv *inventory.Inventory) {
+	if !s.containerOpened.Load() || s.openedWindow.Load() != inv {
+		return
+	}
+	pos := s.openedPos.Load().(cube.Pos)
+	s.closeWindow()
+	if _, ok := s.c.World().Block(pos).(block.Container); ok {
+		s.OpenBlockContainer(pos)
+	}
+}
+
 // ViewSlotChange ...
 func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
 	if !s.containerOpened.Load() {
@@ -762,6 +1080,28 @@ func (s *Session) ViewBlockAction(pos cube.Pos, a blockAction.Action) {
 			Position:  blockPos,
 			EventType: packet.BlockEventChangeChestState,
 		})
+	case blockAction.BellRing:
+		direction := int32(2)
+		switch t.Face.Direction() {
+		case cube.South:
+			direction = 0
+		case cube.West:
+			direction = 1
+		case cube.East:
+			direction = 3
+		}
+		s.writePacket(&packet.BlockActorData{
+			Position: blockPos,
+			NBTData: map[string]interface{}{
+				"id":        "Bell",
+				"Ringing":   uint8(1),
+				"Ticks":     int32(0),
+				"Direction": direction,
+				"x":         int32(pos.X()),
+				"y":         int32(pos.Y()),
+				"z":         int32(pos.Z()),
+			},
+		})
 	case blockAction.StartCrack:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventStartBlockCracking,
@@ -795,7 +1135,7 @@ func (s *Session) ViewEmote(player world.Entity, emote uuid.UUID) {
 // ViewSkin ...
 func (s *Session) ViewSkin(e world.Entity) {
 	switch v := e.(type) {
-	case Controllable:
+	case Humanoid:
 		s.writePacket(&packet.PlayerSkin{
 			UUID: v.UUID(),
 			Skin: skinToProtocol(v.Skin()),
@@ -816,6 +1156,17 @@ func (s *Session) ViewWorldSpawn(pos cube.Pos) {
 
 // ViewWeather ...
 func (s *Session) ViewWeather(raining, thunder bool) {
+	s.overrideMu.Lock()
+	defer s.overrideMu.Unlock()
+	if s.weatherOverride != nil {
+		// The weather of the world is not shown to the client while the weather is overridden.
+		return
+	}
+	s.writeWeather(raining, thunder)
+}
+
+// writeWeather sends the weather passed to the client.
+func (s *Session) writeWeather(raining, thunder bool) {
 	pk := &packet.LevelEvent{
 		EventType: packet.LevelEventStopRaining,
 	}
@@ -898,3 +1249,69 @@ func abs(a int) int {
 	}
 	return a
 }
+
+// equipSound returns the sound event played when equipping the item passed. Armour plays a sound depending on
+// its tier, other items play the generic equip sound.
+func equipSound(it world.Item) uint32 {
+	var tier armour.Tier
+	switch i := it.(type) {
+	case item.Helmet:
+		tier = i.Tier
+	case item.Chestplate:
+		tier = i.Tier
+	case item.Leggings:
+		tier = i.Tier
+	case item.Boots:
+		tier = i.Tier
+	}
+	switch tier {
+	case armour.TierLeather:
+		return packet.SoundEventEquipLeather
+	case armour.TierGold:
+		return packet.SoundEventEquipGold
+	case armour.TierChain:
+		return packet.SoundEventEquipChain
+	case armour.TierIron:
+		return packet.SoundEventEquipIron
+	case armour.TierDiamond:
+		return packet.SoundEventEquipDiamond
+	case armour.TierNetherite:
+		return packet.SoundEventEquipNetherite
+	}
+	return packet.SoundEventEquipGeneric
+}
+
+// musicDiscSound returns the sound event that plays the track of the music disc type passed.
+func musicDiscSound(d sound.DiscType) uint32 {
+	switch d {
+	case sound.Disc13():
+		return packet.SoundEventRecord13
+	case sound.DiscCat():
+		return packet.SoundEventRecordCat
+	case sound.DiscBlocks():
+		return packet.SoundEventRecordBlocks
+	case sound.DiscChirp():
+		return packet.SoundEventRecordChirp
+	case sound.DiscFar():
+		return packet.SoundEventRecordFar
+	case sound.DiscMall():
+		return packet.SoundEventRecordMall
+	case sound.DiscMellohi():
+		return packet.SoundEventRecordMellohi
+	case sound.DiscStal():
+		return packet.SoundEventRecordStal
+	case sound.DiscStrad():
+		return packet.SoundEventRecordStrad
+	case sound.DiscWard():
+		return packet.SoundEventRecordWard
+	case sound.Disc11():
+		return packet.SoundEventRecord11
+	case sound.DiscWait():
+		return packet.SoundEventRecordWait
+	case sound.DiscPigstep():
+		return packet.SoundEventRecordPigstep
+	case sound.DiscOtherside():
+		return packet.SoundEventRecordOtherside
+	}
+	panic("unknown disc type")
+}
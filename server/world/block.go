@@ -7,6 +7,8 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"math"
 	"math/rand"
+	"strconv"
+	"strings"
 )
 
 // Block is a block that may be placed or found in a world. In addition, the block may also be added to an
@@ -84,6 +86,12 @@ func RegisterBlock(b Block) {
 	if _, ok := b.(RandomTicker); ok {
 		randomTickBlocks[rid] = true
 	}
+	if _, ok := b.(ElapsedSimulator); ok {
+		elapsedSimulatorBlocks[rid] = true
+	}
+	if a, ok := b.(lightningAttractor); ok && a.AttractsLightning() {
+		lightningAttractorBlocks[rid] = true
+	}
 }
 
 // BlockRuntimeID attempts to return a runtime ID of a block previously registered using RegisterBlock().
@@ -130,6 +138,69 @@ func BlockByName(name string, properties map[string]interface{}) (Block, bool) {
 	return blocks[rid], true
 }
 
+// ParseBlockState parses a block state from a name and properties with values in their string form, such as
+// entered in a command, and returns the registered Block with that state. The "minecraft:" prefix of the name
+// may be omitted. Properties that are not specified take the value of the default state of the block. An error
+// is returned if no block with the name exists, if a property is unknown or if a value is invalid for it, so
+// that only states that exist in the block registry may be produced.
+func ParseBlockState(name string, properties map[string]string) (Block, error) {
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	defaults, ok := defaultProperties[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown block %v", name)
+	}
+	props := make(map[string]interface{}, len(defaults))
+	for k, v := range defaults {
+		props[k] = v
+	}
+	for k, v := range properties {
+		def, ok := defaults[k]
+		if !ok {
+			return nil, fmt.Errorf("unknown property %v for block %v", k, name)
+		}
+		var err error
+		switch def.(type) {
+		case uint8:
+			var b bool
+			if b, err = strconv.ParseBool(v); err == nil {
+				props[k] = boolByte(b)
+			}
+		case int32:
+			var n int64
+			if n, err = strconv.ParseInt(v, 10, 32); err == nil {
+				props[k] = int32(n)
+			}
+		case string:
+			props[k] = v
+		default:
+			err = fmt.Errorf("unsupported property type %T", def)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %v for property %v of block %v: %w", v, k, name, err)
+		}
+	}
+	b, ok := BlockByName(name, props)
+	if !ok {
+		return nil, fmt.Errorf("invalid block state %v %v", name, props)
+	}
+	if nbter, ok := b.(NBTer); ok {
+		// Blocks with block entities, such as chests, must be initialised properly before they can be used, which
+		// decoding empty NBT does.
+		b = nbter.DecodeNBT(map[string]interface{}{}).(Block)
+	}
+	return b, nil
+}
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // air returns an air block.
 func air() Block {
 	b, _ := BlockByRuntimeID(airRID)
@@ -154,12 +225,59 @@ type ScheduledTicker interface {
 }
 
 // TickerBlock is an implementation of NBTer with an additional Tick method that is called on every world
-// tick for loaded blocks that implement this interface.
+// tick for loaded blocks that implement this interface. A TickerBlock is ticked for as long as the chunk it
+// is in is loaded: Blocks set using World.SetBlock are ticked immediately, and blocks loaded from a Provider
+// are ticked once their chunk is loaded. A TickerBlock is not ticked while its chunk is unloaded, so its progress
+// is paused. LoadListener may be implemented to fast-forward it instead.
 type TickerBlock interface {
 	NBTer
 	Tick(currentTick int64, pos cube.Pos, w *World)
 }
 
+// RemovalListener is an implementation of NBTer with an additional Removed method that is called after the block
+// is removed from the world, either by being broken or by being replaced with a block of a different type using
+// World.SetBlock. It is not called when the block is replaced with another state of the same block.
+type RemovalListener interface {
+	NBTer
+	Removed(pos cube.Pos, w *World)
+}
+
+// UnloadListener is an implementation of NBTer with an additional Unloaded method that is called when the chunk
+// the block is in is unloaded, right before the block is saved. Blocks that keep track of progress, such as
+// blocks implementing TickerBlock, may use it to store their state. The block returned is saved in place of the
+// block. Unloaded is not called for chunks that are saved because the World is closed. Unloaded is called while
+// the chunk is locked, so the block must not use the World it is in.
+type UnloadListener interface {
+	NBTer
+	Unloaded(pos cube.Pos) Block
+}
+
+// LoadListener is an implementation of NBTer with an additional Loaded method that is called when the chunk the
+// block is in is loaded from a Provider, right after the block is decoded and before it is ticked for the first
+// time. The amount of ticks that passed in the World while the chunk was unloaded is passed, so that the block
+// may either resume where it left off or fast-forward its progress. If the amount of ticks is unknown, for
+// example because the chunk was saved by a different program or because the Provider of the World does not
+// implement ChunkTickProvider, -1 is passed. This is the same amount that ElapsedSimulators catch up on, but
+// unlike SimulateElapsed, Loaded is called regardless of World.SetElapsedSimulation. The block returned replaces
+// the block, and may be a different state of it. Loaded is called while the chunk is locked, so the block must
+// not use the World it is in.
+type LoadListener interface {
+	NBTer
+	Loaded(pos cube.Pos, unloadedTicks int64) Block
+}
+
+// ElapsedSimulator represents a block or entity that progresses over time, such as a crop, and that is able to
+// catch up on the time that passed while the chunk it is in was unloaded. SimulateElapsed is only called if
+// enabled for the World using World.SetElapsedSimulation and if the Provider of the World implements
+// ChunkTickProvider.
+type ElapsedSimulator interface {
+	// SimulateElapsed is called shortly after the chunk that the block or entity is in is loaded, with the amount
+	// of ticks that passed in the World since the chunk was last saved. pos is the position of the block, or the
+	// block position of the entity. Implementations should cap the progress they make, so that a chunk that was
+	// unloaded for a long time does not take long to catch up.
+	SimulateElapsed(ticks int64, pos cube.Pos, w *World)
+}
+
 // NeighbourUpdateTicker represents a block that is updated when a block adjacent to it is updated, either
 // through placement or being broken.
 type NeighbourUpdateTicker interface {
@@ -194,6 +312,11 @@ type lightEmitter interface {
 	LightEmissionLevel() uint8
 }
 
+// lightningAttractor is implemented by blocks that attract lightning striking near them, such as lightning rods.
+type lightningAttractor interface {
+	AttractsLightning() bool
+}
+
 // lightDiffuser is identical to a block.LightDiffuser.
 type lightDiffuser interface {
 	LightDiffusionLevel() uint8
@@ -20,12 +20,23 @@ var (
 	blocks []Block
 	// stateRuntimeIDs holds a map for looking up the runtime ID of a block by the stateHash it produces.
 	stateRuntimeIDs = map[stateHash]uint32{}
+	// defaultProperties holds the properties of the first block state registered for every block name. These
+	// properties are used to find the types of properties and to fill out properties that are not specified
+	// when parsing a block state.
+	defaultProperties = map[string]map[string]interface{}{}
 	// nbtBlocks holds a list of NBTer implementations for blocks registered that implement the NBTer interface.
 	// These are indexed by their runtime IDs. Blocks that do not implement NBTer have a false value in this slice.
 	nbtBlocks []bool
 	// randomTickBlocks holds a list of RandomTicker implementations for blocks registered that implement the RandomTicker interface.
 	// These are indexed by their runtime IDs. Blocks that do not implement RandomTicker have a false value in this slice.
 	randomTickBlocks []bool
+	// elapsedSimulatorBlocks holds a list of ElapsedSimulator implementations for blocks registered that implement
+	// the ElapsedSimulator interface. These are indexed by their runtime IDs. Blocks that do not implement
+	// ElapsedSimulator have a false value in this slice.
+	elapsedSimulatorBlocks []bool
+	// lightningAttractorBlocks holds a list of blocks registered that attract lightning, such as lightning rods.
+	// These are indexed by their runtime IDs. Blocks that do not attract lightning have a false value in this slice.
+	lightningAttractorBlocks []bool
 	// airRID is the runtime ID of an air block.
 	airRID uint32
 )
@@ -69,9 +80,14 @@ func registerBlockState(s blockState) {
 	}
 	stateRuntimeIDs[h] = rid
 	blocks = append(blocks, unknownBlock{s})
+	if _, ok := defaultProperties[s.Name]; !ok {
+		defaultProperties[s.Name] = s.Properties
+	}
 
 	nbtBlocks = append(nbtBlocks, false)
 	randomTickBlocks = append(randomTickBlocks, false)
+	elapsedSimulatorBlocks = append(elapsedSimulatorBlocks, false)
+	lightningAttractorBlocks = append(lightningAttractorBlocks, false)
 	chunk.FilteringBlocks = append(chunk.FilteringBlocks, 15)
 	chunk.LightBlocks = append(chunk.LightBlocks, 0)
 }
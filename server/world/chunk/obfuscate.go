@@ -0,0 +1,187 @@
+package chunk
+
+// Obfuscate returns a copy of the Chunk passed in which all blocks on the first layer that are hidden are replaced
+// if they are fully surrounded by opaque blocks, so that clients are unable to see them until they are exposed. A
+// block is hidden if hidden holds true at the index of its runtime ID, and opaque if it filters all light, as
+// specified in FilteringBlocks. Hidden blocks are replaced with one of the opaque blocks around them that is not
+// hidden itself, or with the fallback runtime ID passed if all blocks around them are hidden.
+// Blocks on the horizontal edges of the Chunk are never replaced, as the blocks next to them in neighbouring
+// chunks are not known.
+// Sub chunks without hidden blocks are shared between the Chunk passed and the copy returned, so the copy should
+// not be used after the Chunk passed is changed.
+// The obfuscated copy of every sub chunk is cached in the Chunk passed and only computed again once the sub chunk
+// or one of the sub chunks directly above or below it is changed, so that the same Chunk may be obfuscated for
+// many viewers cheaply. Like other methods of a Chunk, Obfuscate must not be called concurrently for the same
+// Chunk.
+func Obfuscate(c *Chunk, hidden []bool, fallback uint32) *Chunk {
+	o := &Chunk{r: c.r, air: c.air, sub: append([]*SubChunk(nil), c.sub...), biomes: c.biomes}
+	for i, sub := range c.sub {
+		if len(sub.storages) == 0 {
+			continue
+		}
+		layers, versions := c.obfuscationLayers(i)
+		cached := sub.obfuscated
+		if cached == nil || !cached.valid(hidden, fallback, layers, versions) {
+			cached = &obfuscation{fallback: fallback, layers: layers, versions: versions}
+			if len(hidden) != 0 {
+				cached.hidden = &hidden[0]
+			}
+			cached.storage = c.obfuscateSub(int16(i), sub.storages[0], hidden, fallback)
+			sub.obfuscated = cached
+		}
+		if cached.storage != nil {
+			storages := append([]*PalettedStorage{cached.storage}, sub.storages[1:]...)
+			o.sub[i] = &SubChunk{air: sub.air, storages: storages, blockLight: sub.blockLight, skyLight: sub.skyLight}
+		}
+	}
+	return o
+}
+
+// obfuscation is an obfuscated copy of the first layer of a SubChunk, cached so that it is only computed again
+// once the sub chunk or one of the sub chunks directly below or above it changes.
+type obfuscation struct {
+	// hidden points to the first element of the slice of hidden blocks and fallback is the fallback runtime ID
+	// that the copy was obfuscated with.
+	hidden   *bool
+	fallback uint32
+	// layers holds the first layers of the sub chunk below, the sub chunk itself and the sub chunk above, and
+	// versions holds their versions at the time the copy was obfuscated.
+	layers   [3]*PalettedStorage
+	versions [3]uint32
+	// storage is the obfuscated copy of the first layer, or nil if no blocks were concealed.
+	storage *PalettedStorage
+}
+
+// valid checks if the obfuscation may still be used for obfuscating with the hidden blocks and fallback passed,
+// given the current first layers and their versions.
+func (o *obfuscation) valid(hidden []bool, fallback uint32, layers [3]*PalettedStorage, versions [3]uint32) bool {
+	if len(hidden) == 0 || o.hidden != &hidden[0] {
+		return false
+	}
+	return o.fallback == fallback && o.layers == layers && o.versions == versions
+}
+
+// obfuscationLayers returns the first layers of the sub chunk below the one at the index passed, that sub chunk
+// itself and the sub chunk above it, along with their current versions. Layers of sub chunks that do not exist
+// or are empty are nil.
+func (chunk *Chunk) obfuscationLayers(index int) (layers [3]*PalettedStorage, versions [3]uint32) {
+	for i := range layers {
+		j := index + i - 1
+		if j < 0 || j >= len(chunk.sub) || len(chunk.sub[j].storages) == 0 {
+			continue
+		}
+		layers[i] = chunk.sub[j].storages[0]
+		versions[i] = layers[i].version
+	}
+	return layers, versions
+}
+
+// obfuscateSub obfuscates the first layer of the sub chunk at the index passed. It returns a copy of the storage
+// passed with all concealed blocks replaced, or nil if no blocks were replaced.
+func (chunk *Chunk) obfuscateSub(index int16, storage *PalettedStorage, hidden []bool, fallback uint32) *PalettedStorage {
+	values := storage.palette.values
+	hiddenIndices, opaqueIndices, hasHidden := make([]bool, len(values)), make([]bool, len(values)), false
+	for i, v := range values {
+		hiddenIndices[i] = isHidden(v, hidden)
+		opaqueIndices[i] = isOpaque(v)
+		hasHidden = hasHidden || hiddenIndices[i]
+	}
+	if !hasHidden {
+		return nil
+	}
+	// opaque checks if the block at the position passed, which must lie in the same column of sub chunks, is
+	// opaque. Positions within the sub chunk are looked up in the storage directly, which is a lot faster.
+	baseY := chunk.subY(index)
+	opaque := func(x, z byte, y int16) bool {
+		if y < 0 || y > 15 {
+			return isOpaque(chunk.Block(x, baseY+y, z, 0))
+		}
+		return opaqueIndices[storage.paletteIndex(x, byte(y), z)]
+	}
+
+	var obfuscated *PalettedStorage
+	conceal := func(offset int) {
+		x, y, z := byte(offset>>8), int16(offset&15), byte((offset>>4)&15)
+		if x == 0 || x == 15 || z == 0 || z == 15 || (baseY+y) <= int16(chunk.r[0]) || (baseY+y) >= int16(chunk.r[1]) {
+			// The blocks next to those on the edges of the chunk are unknown.
+			return
+		}
+		if !opaque(x, z, y-1) || !opaque(x, z, y+1) || !opaque(x-1, z, y) || !opaque(x+1, z, y) || !opaque(x, z-1, y) || !opaque(x, z+1, y) {
+			return
+		}
+		replacement := fallback
+		for _, n := range [...]uint32{
+			chunk.Block(x, baseY+y-1, z, 0), chunk.Block(x, baseY+y+1, z, 0),
+			storage.At(x-1, byte(y), z), storage.At(x+1, byte(y), z),
+			storage.At(x, byte(y), z-1), storage.At(x, byte(y), z+1),
+		} {
+			if !isHidden(n, hidden) {
+				replacement = n
+				break
+			}
+		}
+		if obfuscated == nil {
+			obfuscated = storage.clone()
+		}
+		obfuscated.Set(x, byte(y), z, replacement)
+	}
+
+	if storage.bitsPerIndex == 0 {
+		for offset := 0; offset < 4096; offset++ {
+			conceal(offset)
+		}
+		return obfuscated
+	}
+	// The indices of the storage are read word by word. Only few blocks in a sub chunk are generally hidden, so
+	// words are first checked for any of the hidden palette indices at once: A field in a word is equal to a
+	// hidden index if the field is zero after XORing the word with that index repeated for every field.
+	bits, mask := uint32(storage.bitsPerIndex), storage.indexMask
+	perWord := int(storage.filledBitsPerIndex / storage.bitsPerIndex)
+	var ones uint32
+	for j := 0; j < perWord; j++ {
+		ones |= 1 << (uint32(j) * bits)
+	}
+	highs := ones << (bits - 1)
+	patterns := make([]uint32, 0, 4)
+	for i, h := range hiddenIndices {
+		if h {
+			patterns = append(patterns, uint32(i)*ones)
+		}
+	}
+	for i, w := range storage.indices {
+		candidate := false
+		for _, p := range patterns {
+			if x := w ^ p; (x-ones)&^x&highs != 0 {
+				candidate = true
+				break
+			}
+		}
+		if !candidate {
+			continue
+		}
+		for j := 0; j < perWord; j++ {
+			if hiddenIndices[(w>>(uint32(j)*bits))&mask] {
+				if offset := i*perWord + j; offset < 4096 {
+					conceal(offset)
+				}
+			}
+		}
+	}
+	return obfuscated
+}
+
+// isOpaque checks if the block with the runtime ID passed filters all light, as specified in FilteringBlocks.
+func isOpaque(rid uint32) bool {
+	return int(rid) < len(FilteringBlocks) && FilteringBlocks[rid] == 15
+}
+
+// isHidden checks if the runtime ID passed is hidden according to the hidden slice passed.
+func isHidden(rid uint32, hidden []bool) bool {
+	return int(rid) < len(hidden) && hidden[rid]
+}
+
+// clone returns a copy of the PalettedStorage that may be changed without changing the original.
+func (storage *PalettedStorage) clone() *PalettedStorage {
+	palette := newPalette(storage.palette.size, append([]uint32(nil), storage.palette.values...))
+	return newPalettedStorage(append([]uint32(nil), storage.indices...), palette)
+}
@@ -0,0 +1,105 @@
+package chunk_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand"
+	"testing"
+)
+
+const (
+	// air, stone and ore are the runtime IDs used for the blocks of the chunks obfuscated in the tests. Stone
+	// and ore are opaque, and ore is hidden.
+	air, stone, ore = 0, 1, 2
+)
+
+func init() {
+	chunk.FilteringBlocks = []uint8{air: 0, stone: 15, ore: 15}
+}
+
+// hidden holds true for the runtime IDs of hidden blocks.
+var hidden = []bool{air: false, stone: false, ore: true}
+
+// oreChunk returns a chunk that is filled with stone up to y=64, with ores scattered through it.
+func oreChunk() *chunk.Chunk {
+	c := chunk.New(air, cube.Range{-64, 319})
+	r := rand.New(rand.NewSource(1))
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			for y := int16(-64); y < 64; y++ {
+				rid := uint32(stone)
+				if r.Intn(50) == 0 {
+					rid = ore
+				}
+				c.SetBlock(x, y, z, 0, rid)
+			}
+		}
+	}
+	return c
+}
+
+// TestObfuscateCache checks that the cached obfuscation of a chunk is computed again once the chunk changes.
+func TestObfuscateCache(t *testing.T) {
+	c := chunk.New(air, cube.Range{-64, 319})
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			for y := int16(0); y < 32; y++ {
+				c.SetBlock(x, y, z, 0, stone)
+			}
+		}
+	}
+	// The ore lies at the bottom of its sub chunk, so that it is also exposed by changes in the sub chunk below.
+	c.SetBlock(8, 16, 8, 0, ore)
+	if rid := chunk.Obfuscate(c, hidden, stone).Block(8, 16, 8, 0); rid != stone {
+		t.Fatalf("enclosed ore was sent as %v, want stone", rid)
+	}
+	if rid := chunk.Obfuscate(c, hidden, stone).Block(8, 16, 8, 0); rid != stone {
+		t.Fatalf("enclosed ore was sent as %v from the cache, want stone", rid)
+	}
+
+	c.SetBlock(8, 15, 8, 0, air)
+	if rid := chunk.Obfuscate(c, hidden, stone).Block(8, 16, 8, 0); rid != ore {
+		t.Errorf("ore exposed from the sub chunk below was sent as %v, want ore", rid)
+	}
+	c.SetBlock(8, 15, 8, 0, stone)
+	if rid := chunk.Obfuscate(c, hidden, stone).Block(8, 16, 8, 0); rid != stone {
+		t.Errorf("enclosed ore was sent as %v after closing it again, want stone", rid)
+	}
+	c.SetBlock(9, 16, 8, 0, air)
+	if rid := chunk.Obfuscate(c, hidden, stone).Block(8, 16, 8, 0); rid != ore {
+		t.Errorf("ore exposed in its own sub chunk was sent as %v, want ore", rid)
+	}
+}
+
+// BenchmarkEncode benchmarks encoding a chunk for the network without obfuscating it.
+func BenchmarkEncode(b *testing.B) {
+	c := oreChunk()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		chunk.Encode(c, chunk.NetworkEncoding)
+	}
+}
+
+// BenchmarkObfuscateEncode benchmarks obfuscating and encoding a chunk for the network when the chunk does not
+// change between viewers, so that the cached obfuscation is used.
+func BenchmarkObfuscateEncode(b *testing.B) {
+	c := oreChunk()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		chunk.Encode(chunk.Obfuscate(c, hidden, stone), chunk.NetworkEncoding)
+	}
+}
+
+// BenchmarkObfuscateEncodeChanged benchmarks obfuscating and encoding a chunk for the network when a block of
+// the chunk changes every time, so that the obfuscation of a sub chunk must be computed again.
+func BenchmarkObfuscateEncodeChanged(b *testing.B) {
+	c := oreChunk()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.SetBlock(8, 8, 8, 0, uint32(stone+i%2))
+		chunk.Encode(chunk.Obfuscate(c, hidden, stone), chunk.NetworkEncoding)
+	}
+}
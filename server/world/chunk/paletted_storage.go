@@ -37,6 +37,10 @@ type PalettedStorage struct {
 	// indices contains all indices in the PalettedStorage. This slice has a variable size, but may not be changed
 	// unless the whole PalettedStorage is resized, including the Palette.
 	indices []uint32
+
+	// version is increased every time a value is set in the PalettedStorage, so that data derived from it, such
+	// as an obfuscated copy, can be recomputed once it changes.
+	version uint32
 }
 
 // newPalettedStorage creates a new block storage using the uint32 slice as the indices and the palette passed.
@@ -79,6 +83,7 @@ func (storage *PalettedStorage) Set(x, y, z byte, v uint32) {
 		index = storage.addNew(v)
 	}
 	storage.setPaletteIndex(x&15, y&15, z&15, uint16(index))
+	storage.version++
 }
 
 // addNew adds a new value to the PalettedStorage's Palette and returns its index. If needed, the storage is resized.
@@ -7,6 +7,8 @@ type SubChunk struct {
 	storages   []*PalettedStorage
 	blockLight []uint8
 	skyLight   []uint8
+	// obfuscated is the obfuscated copy of the first layer of the SubChunk last computed by Obfuscate, if any.
+	obfuscated *obfuscation
 }
 
 // NewSubChunk creates a new sub chunk. All sub chunks should be created through this function
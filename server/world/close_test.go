@@ -0,0 +1,56 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+	"time"
+)
+
+// TestCloseReopen checks that the blocks, block entities and entities of a world are kept when it is populated,
+// closed and loaded again, and that a closed world ignores any changes made to it.
+func TestCloseReopen(t *testing.T) {
+	w := servertest.NewWorld()
+	w.Fill(cube.Pos{0, 5, 0}, cube.Pos{40, 5, 40}, block.Stone{})
+	chest := block.NewChest()
+	_ = chest.Inventory().SetItem(3, item.NewStack(item.Diamond{}, 12))
+	w.SetBlock(cube.Pos{20, 6, 20}, chest)
+	w.AddEntity(&mob{Text: entity.NewText("", mgl64.Vec3{33, 6, 2})})
+	w.ScheduleBlockUpdate(cube.Pos{20, 6, 20}, time.Second)
+	prov := w.Provider()
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing world: %v", err)
+	}
+
+	// Changes to a closed world must not panic and must not have any effect.
+	w.SetBlock(cube.Pos{1, 5, 1}, block.Dirt{})
+	w.AddEntity(&mob{Text: entity.NewText("", mgl64.Vec3{1, 6, 1})})
+	w.ScheduleBlockUpdate(cube.Pos{1, 5, 1}, time.Second)
+	if err := w.Close(); err == nil {
+		t.Errorf("closing a world twice did not return an error")
+	}
+
+	w = servertest.NewWorldWithProvider(world.Overworld, prov)
+	defer w.Close()
+	for _, pos := range []cube.Pos{{0, 5, 0}, {1, 5, 1}, {17, 5, 33}, {40, 5, 40}} {
+		if _, ok := w.Block(pos).(block.Stone); !ok {
+			t.Errorf("block at %v is %#v after reopening, want stone", pos, w.Block(pos))
+		}
+	}
+	c, ok := w.Block(cube.Pos{20, 6, 20}).(block.Chest)
+	if !ok {
+		t.Fatalf("chest was not kept after reopening")
+	}
+	if it, _ := c.Inventory().Item(3); !it.Comparable(item.NewStack(item.Diamond{}, 1)) || it.Count() != 12 {
+		t.Errorf("chest holds %v after reopening, want 12 diamonds", it)
+	}
+	w.Block(cube.Pos{33, 6, 2})
+	if n := len(w.Entities()); n != 1 {
+		t.Errorf("%v entities loaded after reopening, want 1", n)
+	}
+}
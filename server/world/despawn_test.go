@@ -0,0 +1,76 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// mob is an entity used for testing that despawns when no player is close to it.
+type mob struct {
+	*entity.Text
+	entity.DespawnComputer
+}
+
+// EncodeEntity ...
+func (*mob) EncodeEntity() string { return "dragonfly:test_mob" }
+
+// World ...
+func (m *mob) World() *world.World {
+	w, _ := world.OfEntity(m)
+	return w
+}
+
+// Close removes the mob from its world.
+func (m *mob) Close() error {
+	m.World().RemoveEntity(m)
+	return nil
+}
+
+// DecodeNBT ...
+func (*mob) DecodeNBT(data map[string]interface{}) interface{} {
+	return &mob{Text: entity.NewText("", nbtconv.MapVec3(data, "Pos"))}
+}
+
+func init() {
+	world.RegisterEntity(&mob{})
+}
+
+// TestPersistentSaved checks that the persistence of despawnable entities is saved with the world, so that
+// persistent entities do not despawn once the world is loaded again.
+func TestPersistentSaved(t *testing.T) {
+	w := servertest.NewWorld()
+	persistent, other := &mob{Text: entity.NewText("", mgl64.Vec3{1, 10, 1})}, &mob{Text: entity.NewText("", mgl64.Vec3{3, 10, 1})}
+	persistent.SetPersistent(true)
+	w.AddEntity(persistent)
+	w.AddEntity(other)
+	prov := w.Provider()
+	_ = w.Close()
+
+	w = servertest.NewWorldWithProvider(world.Overworld, prov)
+	defer w.Close()
+
+	var loaded []*mob
+	for _, e := range w.Entities() {
+		if m, ok := e.(*mob); ok {
+			loaded = append(loaded, m)
+		}
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("%v entities loaded, want 2", len(loaded))
+	}
+	// No players are in the world, so the entity that is not persistent despawns right away.
+	w.Advance(1)
+	for _, m := range loaded {
+		_, inWorld := world.OfEntity(m)
+		if m.Persistent() != inWorld {
+			t.Errorf("entity at %v persistent: %v, still in world: %v", m.Position(), m.Persistent(), inWorld)
+		}
+		if m.Position()[0] == 1 && !m.Persistent() {
+			t.Errorf("persistent entity loaded as not persistent")
+		}
+	}
+}
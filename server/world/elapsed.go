@@ -0,0 +1,133 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// elapsedSimulationBudget is the maximum amount of blocks and entities that have SimulateElapsed called during a
+// single call to World.simulateElapsed. Anything left over is simulated during the next call.
+const elapsedSimulationBudget = 2048
+
+// elapsedChunk is a chunk loaded from the provider that holds ElapsedSimulators that have not yet caught up on
+// the time that the chunk was unloaded.
+type elapsedChunk struct {
+	pos      ChunkPos
+	ticks    int64
+	blocks   []cube.Pos
+	entities []Entity
+}
+
+// ElapsedSimulation checks if blocks and entities implementing ElapsedSimulator catch up on the time that passed
+// while their chunk was unloaded. This is false by default.
+func (w *World) ElapsedSimulation() bool {
+	if w == nil {
+		return false
+	}
+	return w.elapsedSimulation.Load()
+}
+
+// SetElapsedSimulation changes whether blocks and entities implementing ElapsedSimulator catch up on the time
+// that passed while their chunk was unloaded. Catching up happens shortly after a chunk is loaded by a Loader,
+// spread out over multiple calls to Loader.Load if many blocks or entities need to catch up.
+func (w *World) SetElapsedSimulation(v bool) {
+	if w == nil {
+		return
+	}
+	w.elapsedSimulation.Store(v)
+}
+
+// queueElapsed queues the ElapsedSimulators in the chunk passed, loaded from the provider at the position passed,
+// to catch up on the amount of ticks passed that the chunk spent unloaded. queueElapsed does nothing if elapsed
+// simulation is disabled or if the chunk did not spend any known amount of ticks unloaded.
+// The chunk passed must be locked.
+func (w *World) queueElapsed(pos ChunkPos, c *chunkData, unloadedTicks int64) {
+	if !w.elapsedSimulation.Load() || unloadedTicks <= 0 {
+		return
+	}
+	ec := elapsedChunk{pos: pos, ticks: unloadedTicks}
+
+	cx, cz := int(pos[0]<<4), int(pos[1]<<4)
+	for i, sub := range c.Sub() {
+		if sub.Empty() || !elapsedPalette(sub.Layer(0).Palette()) {
+			// Checking the palette first avoids scanning every block of sub chunks that hold no ElapsedSimulators.
+			continue
+		}
+		subY := (i + (w.ra[0] >> 4)) << 4
+		layer := sub.Layer(0)
+		for x := uint8(0); x < 16; x++ {
+			for y := uint8(0); y < 16; y++ {
+				for z := uint8(0); z < 16; z++ {
+					if rid := layer.At(x, y, z); elapsedSimulatorBlocks[rid] {
+						ec.blocks = append(ec.blocks, cube.Pos{cx + int(x), subY + int(y), cz + int(z)})
+					}
+				}
+			}
+		}
+	}
+	for _, e := range c.entities {
+		if _, ok := e.(ElapsedSimulator); ok {
+			ec.entities = append(ec.entities, e)
+		}
+	}
+	if len(ec.blocks) == 0 && len(ec.entities) == 0 {
+		return
+	}
+	w.elapsedMu.Lock()
+	w.elapsed = append(w.elapsed, ec)
+	w.elapsedMu.Unlock()
+}
+
+// elapsedPalette checks if the palette passed holds at least one block that implements ElapsedSimulator.
+func elapsedPalette(p *chunk.Palette) bool {
+	for i := 0; i < p.Len(); i++ {
+		if elapsedSimulatorBlocks[p.Value(uint16(i))] {
+			return true
+		}
+	}
+	return false
+}
+
+// simulateElapsed calls SimulateElapsed on up to elapsedSimulationBudget queued blocks and entities. Blocks and
+// entities of chunks that were unloaded again in the meantime are dropped: The tick saved with the chunk then
+// already includes the time they did not catch up on.
+func (w *World) simulateElapsed() {
+	// Take the batch out of the queue first, so that SimulateElapsed may load chunks, which in turn queues more
+	// ElapsedSimulators, without deadlocking.
+	w.elapsedMu.Lock()
+	var batch []elapsedChunk
+	for budget := elapsedSimulationBudget; budget > 0 && len(w.elapsed) > 0; {
+		ec := &w.elapsed[0]
+		n := len(ec.blocks) + len(ec.entities)
+		if n <= budget {
+			batch, w.elapsed, budget = append(batch, *ec), w.elapsed[1:], budget-n
+			continue
+		}
+		part := elapsedChunk{pos: ec.pos, ticks: ec.ticks}
+		if len(ec.blocks) > budget {
+			part.blocks, ec.blocks = ec.blocks[:budget], ec.blocks[budget:]
+		} else {
+			k := budget - len(ec.blocks)
+			part.blocks, part.entities = ec.blocks, ec.entities[:k]
+			ec.blocks, ec.entities = nil, ec.entities[k:]
+		}
+		batch, budget = append(batch, part), 0
+	}
+	w.elapsedMu.Unlock()
+
+	for _, ec := range batch {
+		if _, ok := w.chunkFromCache(ec.pos); !ok {
+			continue
+		}
+		for _, pos := range ec.blocks {
+			if s, ok := w.Block(pos).(ElapsedSimulator); ok {
+				s.SimulateElapsed(ec.ticks, pos, w)
+			}
+		}
+		for _, e := range ec.entities {
+			if ew, ok := OfEntity(e); ok && ew == w {
+				e.(ElapsedSimulator).SimulateElapsed(ec.ticks, cube.PosFromVec3(e.Position()), w)
+			}
+		}
+	}
+}
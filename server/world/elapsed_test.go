@@ -0,0 +1,82 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+// TestElapsedSimulation checks that crops catch up on the ticks that their chunk spent unloaded, which is
+// known from the tick that a world.ChunkTickProvider saved for the chunk.
+func TestElapsedSimulation(t *testing.T) {
+	// The crop is placed outside the chunks loaded around the origin, so that elapsed simulation is enabled
+	// before its chunk is loaded again.
+	pos := cube.Pos{200, 5, 200}
+	w := servertest.NewWorld()
+	w.Focus(pos.Vec3())
+	w.SetBlock(pos.Side(cube.FaceDown), block.Farmland{Hydration: 7})
+	w.SetBlock(pos, block.WheatSeeds{})
+	prov := w.Provider()
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing world: %v", err)
+	}
+	chunkPos := world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}
+	saved, ok, _ := prov.LoadChunkTick(chunkPos)
+	if !ok {
+		t.Fatalf("no tick saved for chunk %v", chunkPos)
+	}
+	// Pretend that the chunk was saved a long time ago. Crops only receive a limited amount of random ticks when
+	// catching up, each of which has a chance to grow them, so the crop is not necessarily fully grown afterwards.
+	_ = prov.SaveChunkTick(chunkPos, saved-1000000)
+
+	w = servertest.NewWorldWithProvider(world.Overworld, prov)
+	defer w.Close()
+	w.SetElapsedSimulation(true)
+	w.Focus(pos.Vec3())
+	if c, ok := w.Block(pos).(block.WheatSeeds); !ok || c.Growth == 0 {
+		t.Errorf("crop is %#v after catching up on 1000000 ticks, want it to have grown", w.Block(pos))
+	}
+}
+
+// tickFreeProvider is a world.Provider as a third party might implement it: It implements none of the optional
+// Provider interfaces, such as world.ChunkTickProvider.
+type tickFreeProvider struct {
+	world.Provider
+}
+
+// TestProviderWithoutChunkTicks checks that a world may be saved and loaded again using a Provider that does not
+// implement world.ChunkTickProvider, and that crops then do not catch up on any time.
+func TestProviderWithoutChunkTicks(t *testing.T) {
+	prov := tickFreeProvider{Provider: servertest.NewProvider()}
+	newWorld := func() *world.World {
+		w := world.New(logrus.New(), world.Overworld, nil)
+		w.ManualTicking()
+		w.Provider(prov)
+		w.SetElapsedSimulation(true)
+		return w
+	}
+	pos := cube.Pos{1, 5, 1}
+
+	w := newWorld()
+	w.SetBlock(pos.Side(cube.FaceDown), block.Farmland{Hydration: 7})
+	w.SetBlock(pos, block.WheatSeeds{})
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing world: %v", err)
+	}
+
+	w = newWorld()
+	defer w.Close()
+	l := world.NewLoader(1, w, world.NopViewer{})
+	defer l.Close()
+	l.Move(mgl64.Vec3{})
+	if err := l.Load(100); err != nil {
+		t.Fatalf("error loading chunks: %v", err)
+	}
+	if c, ok := w.Block(pos).(block.WheatSeeds); !ok || c.Growth != 0 {
+		t.Errorf("crop is %#v after loading without a chunk tick, want growth 0", w.Block(pos))
+	}
+}
@@ -0,0 +1,97 @@
+package world
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"reflect"
+	"time"
+)
+
+// Emitter is an area of effect emitter that may be added to a World using World.AddEmitter. The World calls
+// Emit on the Emitter with all entities within its area once every interval, until the Emitter is removed using
+// World.RemoveEmitter. Emitters are typically used for beacons and effect auras.
+type Emitter interface {
+	// EmitArea returns the area that entities must be in to be passed to Emit. If false is returned, the
+	// Emitter is removed from the World, for example because the block it belonged to no longer exists.
+	EmitArea(w *World) (physics.AABB, bool)
+	// EmitInterval returns the time between two emissions. It is rounded down to whole ticks, with a minimum
+	// of one tick.
+	EmitInterval() time.Duration
+	// Emit is called once every interval with all entities within the area returned by EmitArea.
+	Emit(w *World, entities []Entity)
+}
+
+// BlockEmitter is an Emitter that belongs to a block in the World. BlockEmitters are removed from the World
+// when the chunk that their block is in is unloaded.
+type BlockEmitter interface {
+	Emitter
+	// Pos returns the position of the block that the BlockEmitter belongs to.
+	Pos() cube.Pos
+}
+
+// AddEmitter adds an Emitter to the World. The World ticks the Emitter until it is removed using RemoveEmitter.
+// Adding an Emitter that was already added has no effect, so Emitters may be added every time their state is
+// refreshed. Emitters are compared using ==, so the Emitter passed must be of a comparable type, such as a
+// pointer or a struct with only comparable fields. AddEmitter panics if it is not.
+func (w *World) AddEmitter(e Emitter) {
+	if w == nil || e == nil {
+		return
+	}
+	if !reflect.TypeOf(e).Comparable() {
+		panic(fmt.Sprintf("emitter %T added to world is not comparable", e))
+	}
+	w.emitterMu.Lock()
+	defer w.emitterMu.Unlock()
+	w.emitters[e] = struct{}{}
+}
+
+// RemoveEmitter removes an Emitter previously added using AddEmitter from the World, so that it no longer
+// emits to any entities.
+func (w *World) RemoveEmitter(e Emitter) {
+	if w == nil || e == nil || !reflect.TypeOf(e).Comparable() {
+		// Emitters that are not comparable could never have been added.
+		return
+	}
+	w.emitterMu.Lock()
+	defer w.emitterMu.Unlock()
+	delete(w.emitters, e)
+}
+
+// tickEmitters ticks all Emitters added to the World, calling Emit on those that are due to emit.
+func (w *World) tickEmitters(tick int64) {
+	w.emitterMu.Lock()
+	emitters := make([]Emitter, 0, len(w.emitters))
+	for e := range w.emitters {
+		emitters = append(emitters, e)
+	}
+	w.emitterMu.Unlock()
+
+	for _, e := range emitters {
+		interval := int64(e.EmitInterval() / (time.Second / 20))
+		if interval < 1 {
+			interval = 1
+		}
+		if tick%interval != 0 {
+			continue
+		}
+		area, ok := e.EmitArea(w)
+		if !ok {
+			w.RemoveEmitter(e)
+			continue
+		}
+		e.Emit(w, w.EntitiesWithin(area, nil))
+	}
+}
+
+// removeEmitters removes all BlockEmitters with a block in the chunk at the position passed. It is called when
+// a chunk is unloaded, so that the Emitters do not load it again.
+func (w *World) removeEmitters(pos ChunkPos) {
+	w.emitterMu.Lock()
+	defer w.emitterMu.Unlock()
+	for e := range w.emitters {
+		if b, ok := e.(BlockEmitter); ok && chunkPosFromBlockPos(b.Pos()) == pos {
+			delete(w.emitters, e)
+		}
+	}
+}
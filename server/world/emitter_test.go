@@ -0,0 +1,33 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"testing"
+	"time"
+)
+
+// sliceEmitter is an Emitter of a type that is not comparable, because it holds a slice.
+type sliceEmitter struct {
+	emitted []int
+}
+
+func (sliceEmitter) EmitArea(*world.World) (physics.AABB, bool) { return physics.AABB{}, true }
+func (sliceEmitter) EmitInterval() time.Duration                { return time.Second }
+func (sliceEmitter) Emit(*world.World, []world.Entity)          {}
+
+// TestEmitterComparable checks that adding an Emitter that is not comparable panics with a clear message rather
+// than a runtime error deep inside the world, and that removing one is a no-op.
+func TestEmitterComparable(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	w.RemoveEmitter(sliceEmitter{})
+	defer func() {
+		if r := recover(); r != "emitter world_test.sliceEmitter added to world is not comparable" {
+			t.Errorf("AddEmitter panicked with %v, want message about comparability", r)
+		}
+	}()
+	w.AddEmitter(sliceEmitter{})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity/physics"
 	"github.com/go-gl/mathgl/mgl64"
 	"io"
+	"reflect"
 )
 
 // Entity represents an entity in the world, typically an object that may be moved around and can be
@@ -40,6 +41,15 @@ type TickerEntity interface {
 	Tick(current int64)
 }
 
+// DespawnableEntity represents an entity that despawns when no player is close to it, typically by embedding
+// an entity.DespawnComputer. The World checks every tick if the entity should despawn.
+type DespawnableEntity interface {
+	Entity
+	// Despawns returns whether the entity should despawn, given the distance to the closest player in the
+	// World. The distance passed is +Inf if no player is within 128 blocks of the entity.
+	Despawns(dist float64) bool
+}
+
 // SaveableEntity is an Entity that can be saved and loaded with the World it was added to. These entities can be
 // registered on startup using RegisterEntity to allow loading them in a World.
 type SaveableEntity interface {
@@ -47,17 +57,84 @@ type SaveableEntity interface {
 	NBTer
 }
 
+// SpawnEggEntity is a SaveableEntity that may be spawned using a spawn egg. When it is registered using
+// RegisterEntity, the spawn egg returned by SpawnEgg is registered as an item too.
+type SpawnEggEntity interface {
+	SaveableEntity
+	// SpawnEgg returns the spawn egg item of the entity, typically an item.SpawnEgg.
+	SpawnEgg() Item
+}
+
 // entities holds a map of name => SaveableEntity to be used for looking up the entity by a string ID. It is registered
 // to when calling RegisterEntity.
 var entities = map[string]SaveableEntity{}
 
-// RegisterEntity registers a SaveableEntity to the map so that it can be saved and loaded with the world.
+// RegisterEntity registers a SaveableEntity to the map so that it can be saved and loaded with the world. If
+// the entity implements SpawnEggEntity, its spawn egg is registered too.
 func RegisterEntity(e SaveableEntity) {
 	name := e.EncodeEntity()
 	if _, ok := entities[name]; ok {
 		panic("cannot register the same entity (" + name + ") twice")
 	}
 	entities[name] = e
+	if egg, ok := e.(SpawnEggEntity); ok {
+		RegisterItem(egg.SpawnEgg())
+	}
+}
+
+// NewEntity creates a new entity of a type registered using RegisterEntity with the name passed, such as
+// 'minecraft:snowball', at the position passed. The NBT data passed, which may be nil, is used to set any other
+// properties of the entity. The entity is not added to a world. If no entity with the name was registered, or if
+// the entity could not be created from the data passed, false is returned.
+func NewEntity(name string, pos mgl64.Vec3, data map[string]interface{}) (Entity, bool) {
+	e, ok := entities[name]
+	if !ok {
+		return nil, false
+	}
+	m := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		m[k] = v
+	}
+	m["Pos"] = []float32{float32(pos[0]), float32(pos[1]), float32(pos[2])}
+	return decodeEntity(e, m)
+}
+
+// persistentEntity is an Entity that may be marked persistent so that it never despawns, such as an entity
+// embedding an entity.DespawnComputer. Its persistence is saved under the 'Persistent' key of its NBT.
+type persistentEntity interface {
+	Entity
+	Persistent() bool
+	SetPersistent(v bool)
+}
+
+// decodeEntity decodes an Entity of the type of the SaveableEntity passed from the NBT data passed. False is
+// returned if the data could not be decoded into an Entity.
+func decodeEntity(e SaveableEntity, data map[string]interface{}) (Entity, bool) {
+	n, ok := e.DecodeNBT(data).(Entity)
+	if !ok {
+		return nil, false
+	}
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Ptr && v.IsNil() {
+		// DecodeNBT returned a typed nil pointer, which would otherwise pass as a non-nil Entity.
+		return nil, false
+	}
+	if p, ok := n.(persistentEntity); ok {
+		if persistent, _ := data["Persistent"].(uint8); persistent == 1 {
+			p.SetPersistent(true)
+		}
+	}
+	return n, true
+}
+
+// encodeEntity encodes the SaveableEntity passed to NBT data, including its identifier and, if it may be marked
+// persistent, its persistence.
+func encodeEntity(e SaveableEntity) map[string]interface{} {
+	data := e.EncodeNBT()
+	data["identifier"] = e.EncodeEntity()
+	if p, ok := e.(persistentEntity); ok && p.Persistent() {
+		data["Persistent"] = uint8(1)
+	}
+	return data
 }
 
 // EntityByName looks up a SaveableEntity by the name (for example, 'minecraft:slime') and returns it if found.
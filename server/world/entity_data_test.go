@@ -0,0 +1,46 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// TestUnknownEntityDataKept checks that the NBT of entities that cannot be decoded is written back unchanged
+// when the chunk they are in is saved, while known entities are still loaded.
+func TestUnknownEntityDataKept(t *testing.T) {
+	w := servertest.NewWorld()
+	w.AddEntity(&mob{Text: entity.NewText("", mgl64.Vec3{1, 10, 1})})
+	prov := w.Provider()
+	_ = w.Close()
+
+	unknown := map[string]interface{}{"identifier": "minecraft:unknown", "Pos": []float32{2, 10, 2}, "Custom": int32(7)}
+	data, _ := prov.LoadEntityData(world.ChunkPos{})
+	if err := prov.SaveEntityData(world.ChunkPos{}, append(data, unknown)); err != nil {
+		t.Fatalf("error saving entity data: %v", err)
+	}
+
+	w = servertest.NewWorldWithProvider(world.Overworld, prov)
+	w.Block(cube.Pos{1, 10, 1})
+	if n := len(w.Entities()); n != 1 {
+		t.Errorf("%v entities loaded, want 1", n)
+	}
+	_ = w.Close()
+
+	data, _ = prov.LoadEntityData(world.ChunkPos{})
+	if len(data) != 2 {
+		t.Fatalf("%v entities saved, want 2", len(data))
+	}
+	for _, m := range data {
+		if m["identifier"] == "minecraft:unknown" {
+			if m["Custom"] != int32(7) || len(m) != len(unknown) {
+				t.Errorf("unknown entity saved as %v, want %v", m, unknown)
+			}
+			return
+		}
+	}
+	t.Errorf("unknown entity was not saved")
+}
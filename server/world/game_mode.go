@@ -6,7 +6,9 @@ package world
 type GameMode interface {
 	// AllowsEditing specifies if a player with this GameMode can edit the World it's in.
 	AllowsEditing() bool
-	// AllowsTakingDamage specifies if a player with this GameMode can take damage from other entities.
+	// AllowsTakingDamage specifies if a player with this GameMode can take damage from other entities. Players
+	// with a GameMode that does not allow taking damage may still be damaged by the void, unless the GameMode
+	// has no collision, such as GameModeSpectator.
 	AllowsTakingDamage() bool
 	// CreativeInventory specifies if a player with this GameMode has access to the creative inventory.
 	CreativeInventory() bool
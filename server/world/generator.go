@@ -12,6 +12,14 @@ type Generator interface {
 	GenerateChunk(pos ChunkPos, chunk *chunk.Chunk)
 }
 
+// BiomeSource decides on the biome of every column of blocks in a world. Generators may use a BiomeSource to
+// shape the terrain they generate after the biome it is in, so that, for example, deserts are covered with sand.
+type BiomeSource interface {
+	// Biome returns the biome of the column of blocks at the x and z block coordinates passed. Biome may be
+	// called concurrently for different positions.
+	Biome(x, z int) Biome
+}
+
 // NopGenerator is the default generator a world. It places no blocks in the world which results in a void
 // world.
 type NopGenerator struct{}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"math/rand"
+)
+
+// FixedBiome returns a world.BiomeSource that returns the biome passed for every column of blocks.
+func FixedBiome(b world.Biome) world.BiomeSource {
+	return fixed{b: b}
+}
+
+// fixed is a world.BiomeSource that returns the same biome for every column of blocks.
+type fixed struct {
+	b world.Biome
+}
+
+// Biome returns the biome of the fixed biome source.
+func (f fixed) Biome(int, int) world.Biome {
+	return f.b
+}
+
+// Climate is a world.BiomeSource that selects biomes using temperature and humidity noise, so that biomes with
+// a similar climate end up close to each other. Climate only selects land biomes: Generators decide on oceans
+// and beaches themselves, based on the height of the terrain. A Climate is safe for concurrent use.
+type Climate struct {
+	temperature, humidity octaves
+}
+
+// NewClimate creates a Climate biome source. The seed passed is used to generate the temperature and humidity
+// noise, so the same seed always results in the same biomes.
+func NewClimate(seed int64) *Climate {
+	r := rand.New(rand.NewSource(seed ^ 0x62696f6d65))
+	return &Climate{
+		temperature: newOctaves(r, 4, 512),
+		humidity:    newOctaves(r, 4, 384),
+	}
+}
+
+// Climate returns the temperature and humidity at the x and z block coordinates passed. Both values are roughly
+// in the range 0-1.
+func (c *Climate) Climate(x, z int) (temperature, humidity float64) {
+	return c.temperature.at(x, z)*1.4 + 0.5, c.humidity.at(x, z)*1.4 + 0.5
+}
+
+// Biome selects a biome for the column at the x and z block coordinates passed based on its temperature and
+// humidity.
+func (c *Climate) Biome(x, z int) world.Biome {
+	t, h := c.Climate(x, z)
+	switch {
+	case t < 0.25:
+		if h > 0.55 {
+			return biome.SnowyTaiga{}
+		}
+		return biome.SnowyPlains{}
+	case t < 0.5:
+		if h > 0.6 {
+			return biome.Taiga{}
+		}
+		if h > 0.4 {
+			return biome.Forest{}
+		}
+		return biome.Plains{}
+	case t < 0.75:
+		if h > 0.65 {
+			return biome.Swamp{}
+		}
+		if h > 0.45 {
+			return biome.Forest{}
+		}
+		return biome.Plains{}
+	default:
+		if h > 0.6 {
+			return biome.Jungle{}
+		}
+		if h > 0.35 {
+			return biome.Savanna{}
+		}
+		return biome.Desert{}
+	}
+}
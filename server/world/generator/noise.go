@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+// perlin is a source of two-dimensional Perlin noise. Its permutation table is shuffled using a seed, so that
+// the same seed always produces the same noise. A perlin is never modified after it is created, so it is safe
+// to use concurrently.
+type perlin struct {
+	p [512]uint8
+}
+
+// newPerlin creates a perlin noise source with a permutation table shuffled using the rand.Rand passed.
+func newPerlin(r *rand.Rand) *perlin {
+	n := &perlin{}
+	for i, v := range r.Perm(256) {
+		n.p[i], n.p[i+256] = uint8(v), uint8(v)
+	}
+	return n
+}
+
+// noise returns the noise value at the x and y coordinates passed. The value returned is roughly in the range
+// -1 to 1.
+func (n *perlin) noise(x, y float64) float64 {
+	fx, fy := math.Floor(x), math.Floor(y)
+	xi, yi := int(fx)&255, int(fy)&255
+	x, y = x-fx, y-fy
+	u, v := fade(x), fade(y)
+
+	a, b := int(n.p[xi])+yi, int(n.p[xi+1])+yi
+	return lerp(v,
+		lerp(u, grad(n.p[a], x, y), grad(n.p[b], x-1, y)),
+		lerp(u, grad(n.p[a+1], x, y-1), grad(n.p[b+1], x-1, y-1)),
+	)
+}
+
+// octaves is a sum of several layers of perlin noise with increasing frequency and decreasing amplitude,
+// resulting in noise with both large and small features.
+type octaves struct {
+	layers []*perlin
+	scale  float64
+}
+
+// newOctaves creates octaves with n layers of perlin noise, seeded using the rand.Rand passed. The scale is
+// the size, in blocks, of the largest features of the noise.
+func newOctaves(r *rand.Rand, n int, scale float64) octaves {
+	o := octaves{layers: make([]*perlin, n), scale: scale}
+	for i := range o.layers {
+		o.layers[i] = newPerlin(r)
+	}
+	return o
+}
+
+// at returns the noise value of the octaves at the x and z block coordinates passed. The value returned is
+// roughly in the range -1 to 1.
+func (o octaves) at(x, z int) float64 {
+	var sum, max float64
+	freq, amp := 1/o.scale, 1.0
+	for _, l := range o.layers {
+		sum += l.noise(float64(x)*freq, float64(z)*freq) * amp
+		max += amp
+		freq, amp = freq*2, amp/2
+	}
+	return sum / max
+}
+
+// fade is the smoothing curve 6t^5 - 15t^4 + 10t^3 used to interpolate between lattice points.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// lerp linearly interpolates between a and b.
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// grad returns the dot product of one of eight gradient vectors, selected using the hash passed, and the x and y
+// distance passed.
+func grad(hash uint8, x, y float64) float64 {
+	switch hash & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	default:
+		return -y
+	}
+}
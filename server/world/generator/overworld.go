@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand"
+)
+
+// Stage is a step of terrain generation that runs after the base terrain of a chunk has been generated, such as
+// the carving of caves or the placement of structures. Stages are called concurrently for different chunks.
+type Stage interface {
+	// Apply applies the stage to the chunk at the position passed. The world.BiomeSource and seed of the
+	// generator are passed, so that the stage may depend on them.
+	Apply(pos world.ChunkPos, c *chunk.Chunk, biomes world.BiomeSource, seed int64)
+}
+
+// Overworld is a generator that generates natural overworld terrain: Hills and valleys shaped by noise, covered
+// with blocks depending on the biome they are in and with oceans filled with water up to the sea level. The
+// terrain generated depends only on the seed of the generator, so that the same seed always results in the
+// same terrain. An Overworld is safe to use for generating different chunks concurrently.
+type Overworld struct {
+	seed   int64
+	biomes world.BiomeSource
+	stages []Stage
+
+	height, detail octaves
+
+	bedrock, stone, water                      uint32
+	grass, dirt, sand, sandstone, gravel, snow uint32
+}
+
+// SeaLevel is the height up to which the Overworld generator fills oceans with water.
+const SeaLevel = 62
+
+// NewOverworld creates an Overworld generator that generates terrain using the seed passed. Biomes are selected
+// using the world.BiomeSource passed. If nil, a Climate biome source with the same seed is used. The Stages
+// passed are applied to every chunk, in order, after its base terrain is generated.
+func NewOverworld(seed int64, biomes world.BiomeSource, stages ...Stage) *Overworld {
+	if biomes == nil {
+		biomes = NewClimate(seed)
+	}
+	r := rand.New(rand.NewSource(seed))
+	o := &Overworld{
+		seed:   seed,
+		biomes: biomes,
+		stages: stages,
+		height: newOctaves(r, 6, 256),
+		detail: newOctaves(r, 3, 32),
+	}
+	o.bedrock, _ = world.BlockRuntimeID(block.Bedrock{})
+	o.stone, _ = world.BlockRuntimeID(block.Stone{})
+	o.water, _ = world.BlockRuntimeID(block.Water{Still: true, Depth: 8})
+	o.grass, _ = world.BlockRuntimeID(block.Grass{})
+	o.dirt, _ = world.BlockRuntimeID(block.Dirt{})
+	o.sand, _ = world.BlockRuntimeID(block.Sand{})
+	o.sandstone, _ = world.BlockRuntimeID(block.Sandstone{})
+	o.gravel, _ = world.BlockRuntimeID(block.Gravel{})
+	o.snow, _ = world.BlockRuntimeID(block.Snow{})
+	return o
+}
+
+// Seed returns the seed that the Overworld generates terrain with.
+func (o *Overworld) Seed() int64 {
+	return o.seed
+}
+
+// Height returns the height of the surface of the terrain at the x and z block coordinates passed.
+func (o *Overworld) Height(x, z int) int {
+	return SeaLevel + 2 + int(o.height.at(x, z)*40+o.detail.at(x, z)*4)
+}
+
+// GenerateChunk generates the terrain of the chunk at the position passed, after which the stages of the
+// generator are applied to it.
+func (o *Overworld) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			o.generateColumn(c, x, z, baseX+int(x), baseZ+int(z))
+		}
+	}
+	for _, s := range o.stages {
+		s.Apply(pos, c, o.biomes, o.seed)
+	}
+}
+
+// generateColumn generates a single column of blocks at the x and z coordinates passed, relative to the chunk,
+// and at the x and z world coordinates passed.
+func (o *Overworld) generateColumn(c *chunk.Chunk, x, z uint8, worldX, worldZ int) {
+	r := c.Range()
+	height := o.Height(worldX, worldZ)
+	if height >= r[1] {
+		height = r[1] - 1
+	}
+	b := o.columnBiome(worldX, worldZ, height)
+	top, filler := o.surface(b)
+
+	for y := r[0]; y <= height; y++ {
+		rid := o.stone
+		switch {
+		case y == r[0] || (y < r[0]+5 && o.bedrockAt(worldX, y, worldZ, y-r[0])):
+			rid = o.bedrock
+		case y == height:
+			rid = top
+		case y > height-4:
+			rid = filler
+		}
+		c.SetBlock(x, int16(y), z, 0, rid)
+	}
+	for y := height + 1; y <= SeaLevel; y++ {
+		c.SetBlock(x, int16(y), z, 0, o.water)
+	}
+
+	id := uint32(b.EncodeBiome())
+	for y := r[0]; y < r[1]; y++ {
+		c.SetBiome(x, int16(y), z, id)
+	}
+}
+
+// columnBiome returns the biome of the column at the x and z world coordinates passed with the surface height
+// passed. Columns below sea level are turned into oceans and columns just above it into beaches, depending on
+// the climate of the biome returned by the biome source.
+func (o *Overworld) columnBiome(x, z, height int) world.Biome {
+	b := o.biomes.Biome(x, z)
+	switch {
+	case height < SeaLevel-20:
+		if cold(b) {
+			return biome.DeepFrozenOcean{}
+		}
+		return biome.DeepOcean{}
+	case height < SeaLevel:
+		if cold(b) {
+			return biome.FrozenOcean{}
+		} else if b.Temperature() >= 1 {
+			return biome.WarmOcean{}
+		}
+		return biome.Ocean{}
+	case height <= SeaLevel+2:
+		if _, desert := b.(biome.Desert); desert {
+			return b
+		} else if _, swamp := b.(biome.Swamp); swamp {
+			return b
+		} else if cold(b) {
+			return biome.SnowyBeach{}
+		}
+		return biome.Beach{}
+	}
+	return b
+}
+
+// surface returns the runtime IDs of the top block and of the blocks right below it in a column of the biome
+// passed.
+func (o *Overworld) surface(b world.Biome) (top, filler uint32) {
+	switch b.(type) {
+	case biome.DeepOcean, biome.DeepFrozenOcean:
+		return o.gravel, o.gravel
+	case biome.Ocean, biome.FrozenOcean, biome.WarmOcean, biome.Beach, biome.SnowyBeach:
+		return o.sand, o.sand
+	case biome.Desert:
+		return o.sand, o.sandstone
+	}
+	if cold(b) {
+		return o.snow, o.dirt
+	}
+	return o.grass, o.dirt
+}
+
+// bedrockAt checks if there is bedrock at a position in the bottom layers of the world, where level is the
+// amount of blocks above the bottom of the world. The chance of bedrock being present decreases the further up
+// the position is, so that there is no bedrock 5 blocks above the bottom.
+func (o *Overworld) bedrockAt(x, y, z, level int) bool {
+	h := uint64(o.seed) ^ uint64(x)*0x9e3779b97f4a7c15 ^ uint64(y)*0xbf58476d1ce4e5b9 ^ uint64(z)*0x94d049bb133111eb
+	h ^= h >> 31
+	h *= 0xd6e8feb86659fd93
+	h ^= h >> 32
+	return int(h%5) >= level
+}
+
+// cold checks if a biome is cold enough for snow to fall in it.
+func cold(b world.Biome) bool {
+	return b.Temperature() < 0.15
+}
@@ -0,0 +1,70 @@
+package generator_test
+
+import (
+	"bytes"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/generator"
+	"sync"
+	"testing"
+)
+
+// generateChunk generates the chunk at the position passed using the generator passed and returns it encoded
+// for the network.
+func generateChunk(g world.Generator, pos world.ChunkPos) []byte {
+	air, _ := world.BlockRuntimeID(block.Air{})
+	c := chunk.New(air, world.Overworld.Range())
+	g.GenerateChunk(pos, c)
+
+	data := chunk.Encode(c, chunk.NetworkEncoding)
+	return append(bytes.Join(data.SubChunks, nil), data.Biomes...)
+}
+
+// chunkPositions are the positions of the chunks generated in tests, spread out so that they include different
+// biomes.
+var chunkPositions = []world.ChunkPos{{0, 0}, {-1, 0}, {3, -7}, {-40, 25}, {100, 100}, {-250, -1000}}
+
+// TestOverworldDeterministic checks that two Overworld generators with the same seed generate exactly the same
+// chunks, even when generating them concurrently, and that a different seed generates different chunks.
+func TestOverworldDeterministic(t *testing.T) {
+	a, b := generator.NewOverworld(1234, nil), generator.NewOverworld(1234, nil)
+
+	want := make([][]byte, len(chunkPositions))
+	for i, pos := range chunkPositions {
+		want[i] = generateChunk(a, pos)
+	}
+	got := make([][]byte, len(chunkPositions))
+	var wg sync.WaitGroup
+	for i, pos := range chunkPositions {
+		wg.Add(1)
+		go func(i int, pos world.ChunkPos) {
+			defer wg.Done()
+			got[i] = generateChunk(b, pos)
+		}(i, pos)
+	}
+	wg.Wait()
+
+	for i, pos := range chunkPositions {
+		if !bytes.Equal(got[i], want[i]) {
+			t.Errorf("chunk %v differs between two generators with the same seed", pos)
+		}
+	}
+
+	other := generator.NewOverworld(4321, nil)
+	if bytes.Equal(generateChunk(other, chunkPositions[0]), want[0]) {
+		t.Errorf("chunk %v is the same for two different seeds", chunkPositions[0])
+	}
+}
+
+// BenchmarkOverworldGenerateChunk measures the time it takes the Overworld generator to generate a single chunk.
+func BenchmarkOverworldGenerateChunk(b *testing.B) {
+	g := generator.NewOverworld(1234, nil)
+	air, _ := world.BlockRuntimeID(block.Air{})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := chunk.New(air, world.Overworld.Range())
+		g.GenerateChunk(chunkPositions[i%len(chunkPositions)], c)
+	}
+}
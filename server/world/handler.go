@@ -19,6 +19,10 @@ type Handler interface {
 	// HandleSound handles a Sound being played in the World at a specific position. ctx.Cancel() may be called
 	// to stop the Sound from playing to viewers of the position.
 	HandleSound(ctx *event.Context, s Sound, pos mgl64.Vec3)
+	// HandleBellRing handles a bell at a position being rung by an entity hitting it on the face passed, either
+	// by activating it, punching it or hitting it with a projectile. ctx.Cancel() may be called to prevent the
+	// bell from ringing.
+	HandleBellRing(ctx *event.Context, pos cube.Pos, face cube.Face, e Entity)
 }
 
 // NopHandler implements the Handler interface but does not execute any code when an event is called. The
@@ -37,3 +41,6 @@ func (NopHandler) HandleLiquidHarden(*event.Context, cube.Pos, Block, Block, Blo
 
 // HandleSound ...
 func (NopHandler) HandleSound(*event.Context, Sound, mgl64.Vec3) {}
+
+// HandleBellRing ...
+func (NopHandler) HandleBellRing(*event.Context, cube.Pos, cube.Face, Entity) {}
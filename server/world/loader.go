@@ -77,6 +77,14 @@ func (l *Loader) Move(pos mgl64.Vec3) {
 	l.mu.Unlock()
 }
 
+// Loaded checks if the chunk at the position passed was loaded by the Loader and shown to its Viewer.
+func (l *Loader) Loaded(pos ChunkPos) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.loaded[pos]
+	return ok
+}
+
 // Load loads n chunks around the centre of the chunk, starting with the middle and working outwards. For
 // every chunk loaded, the function f is called.
 // The function f must not hold the chunk beyond the function scope.
@@ -109,7 +117,12 @@ func (l *Loader) Load(n int) error {
 		// iteration.
 		l.loadQueue = l.loadQueue[1:]
 	}
+	w := l.w
 	l.mu.Unlock()
+
+	// Blocks and entities in the chunks loaded catch up on the time they were unloaded after the chunks were
+	// sent, so that they don't delay the loading of chunks.
+	w.simulateElapsed()
 	return nil
 }
 
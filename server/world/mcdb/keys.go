@@ -32,6 +32,9 @@ const (
 	// keyChecksum holds a list of checksums of some sort. It's not clear of what data this checksum is composed or what
 	// these checksums are used for.
 	keyChecksums = ';' // 3b
+	// keyLastTick holds a single LE int64 with the tick of the world at which the chunk was last saved. It is not
+	// written by vanilla, which ignores keys it does not know.
+	keyLastTick = 0xdf
 )
 
 // Keys on a per-world basis. These are found only once in a leveldb world save.
@@ -24,6 +24,9 @@ type Provider struct {
 	dim world.Dimension
 	dir string
 	d   data
+	// extra holds fields found in the level.dat that are not present in data. They are kept so that they may be
+	// accessed through world.Settings and written back when the level.dat is saved.
+	extra map[string]interface{}
 }
 
 // chunkVersion is the current version of chunks.
@@ -35,7 +38,7 @@ const chunkVersion = 27
 func New(dir string, d world.Dimension) (*Provider, error) {
 	_ = os.MkdirAll(filepath.Join(dir, "db"), 0777)
 
-	p := &Provider{dir: dir, dim: d}
+	p := &Provider{dir: dir, dim: d, extra: map[string]interface{}{}}
 	if _, err := os.Stat(filepath.Join(dir, "level.dat")); os.IsNotExist(err) {
 		// A level.dat was not currently present for the world.
 		p.initDefaultLevelDat()
@@ -49,7 +52,7 @@ func New(dir string, d world.Dimension) (*Provider, error) {
 			// The file did not have enough content, meaning it is corrupted. We return an error.
 			return nil, fmt.Errorf("level.dat exists but has no data")
 		}
-		if err := nbt.UnmarshalEncoding(f[8:], &p.d, nbt.LittleEndian); err != nil {
+		if err := p.loadLevelDat(f[8:]); err != nil {
 			return nil, fmt.Errorf("error decoding level.dat NBT: %w", err)
 		}
 		p.d.WorldStartCount++
@@ -92,6 +95,7 @@ func (p *Provider) initDefaultLevelDat() {
 	p.d.CommandsEnabled = true
 	p.d.MultiPlayerGame = true
 	p.d.SpawnY = math.MaxInt32
+	p.d.SpawnRadius = 5
 	p.d.Difficulty = 2
 	p.d.DoWeatherCycle = true
 	p.d.RainLevel = 1.0
@@ -100,10 +104,49 @@ func (p *Provider) initDefaultLevelDat() {
 	p.d.NetherScale = 8
 }
 
+// loadLevelDat decodes the level.dat NBT passed into the data of the Provider. All fields that are not present
+// in the data struct are stored in the extra map of the Provider.
+func (p *Provider) loadLevelDat(b []byte) error {
+	m := make(map[string]interface{})
+	if err := nbt.UnmarshalEncoding(b, &m, nbt.LittleEndian); err != nil {
+		return err
+	}
+	known, err := p.dataMap()
+	if err != nil {
+		return err
+	}
+	for k, v := range m {
+		if _, ok := known[k]; !ok {
+			p.extra[k] = v
+			delete(m, k)
+		}
+	}
+	b, err = nbt.MarshalEncoding(m, nbt.LittleEndian)
+	if err != nil {
+		return err
+	}
+	return nbt.UnmarshalEncoding(b, &p.d, nbt.LittleEndian)
+}
+
+// dataMap encodes the data of the Provider into a map, so that it may be combined with the extra data.
+func (p *Provider) dataMap() (map[string]interface{}, error) {
+	b, err := nbt.MarshalEncoding(p.d, nbt.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	if err := nbt.UnmarshalEncoding(b, &m, nbt.LittleEndian); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Settings returns the world.Settings of the world loaded by the Provider.
 func (p *Provider) Settings(s *world.Settings) {
 	s.Name = p.d.LevelName
+	s.Seed = p.d.RandomSeed
 	s.Spawn = cube.Pos{int(p.d.SpawnX), int(p.d.SpawnY), int(p.d.SpawnZ)}
+	s.SpawnRadius = p.d.SpawnRadius
 	s.Time = p.d.Time
 	s.TimeCycle = p.d.DoDayLightCycle
 	s.WeatherCycle = p.d.DoWeatherCycle
@@ -115,12 +158,18 @@ func (p *Provider) Settings(s *world.Settings) {
 	s.DefaultGameMode = p.loadDefaultGameMode()
 	s.Difficulty = p.loadDifficulty()
 	s.TickRange = p.d.ServerChunkTickRange
+	s.Data = make(map[string]interface{}, len(p.extra))
+	for k, v := range p.extra {
+		s.Data[k] = v
+	}
 }
 
 // SaveSettings saves the world.Settings passed to the level.dat.
 func (p *Provider) SaveSettings(s *world.Settings) {
 	p.d.LevelName = s.Name
+	p.d.RandomSeed = s.Seed
 	p.d.SpawnX, p.d.SpawnY, p.d.SpawnZ = int32(s.Spawn.X()), int32(s.Spawn.Y()), int32(s.Spawn.Z())
+	p.d.SpawnRadius = s.SpawnRadius
 	p.d.Time = s.Time
 	p.d.DoDayLightCycle = s.TimeCycle
 	p.d.DoWeatherCycle = s.WeatherCycle
@@ -136,6 +185,10 @@ func (p *Provider) SaveSettings(s *world.Settings) {
 	p.d.ServerChunkTickRange = s.TickRange
 	p.saveDefaultGameMode(s.DefaultGameMode)
 	p.saveDifficulty(s.Difficulty)
+	p.extra = make(map[string]interface{}, len(s.Data))
+	for k, v := range s.Data {
+		p.extra[k] = v
+	}
 }
 
 // LoadChunk loads a chunk at the position passed from the leveldb database. If it doesn't exist, exists is
@@ -261,35 +314,24 @@ func (p *Provider) saveDifficulty(d world.Difficulty) {
 	}
 }
 
-// LoadEntities loads all entities from the chunk position passed.
+// LoadEntities loads all entities from the chunk position passed. Entities that were not registered using
+// world.RegisterEntity are skipped.
 func (p *Provider) LoadEntities(pos world.ChunkPos) ([]world.SaveableEntity, error) {
-	data, err := p.db.Get(append(p.index(pos), keyEntities), nil)
-	if err != leveldb.ErrNotFound && err != nil {
+	data, err := p.LoadEntityData(pos)
+	if err != nil {
 		return nil, err
 	}
 	var a []world.SaveableEntity
-
-	buf := bytes.NewBuffer(data)
-	dec := nbt.NewDecoderWithEncoding(buf, nbt.LittleEndian)
-
-	for buf.Len() != 0 {
-		var m map[string]interface{}
-		if err := dec.Decode(&m); err != nil {
-			return nil, fmt.Errorf("error decoding block NBT: %w", err)
-		}
-		id, ok := m["identifier"]
-		if !ok {
-			return nil, fmt.Errorf("entity has no ID but data (%v)", m)
-		}
-		name, _ := id.(string)
+	for _, m := range data {
+		name, _ := m["identifier"].(string)
 		e, ok := world.EntityByName(name)
 		if !ok {
 			// Entity was not registered: This can only be expected sometimes, so the best we can do is to just
 			// ignore this and proceed.
 			continue
 		}
-		if v := e.DecodeNBT(m); v != nil {
-			a = append(a, v.(world.SaveableEntity))
+		if v, ok := e.DecodeNBT(m).(world.SaveableEntity); ok {
+			a = append(a, v)
 		}
 	}
 	return a, nil
@@ -297,15 +339,45 @@ func (p *Provider) LoadEntities(pos world.ChunkPos) ([]world.SaveableEntity, err
 
 // SaveEntities saves all entities to the chunk position passed.
 func (p *Provider) SaveEntities(pos world.ChunkPos, entities []world.SaveableEntity) error {
-	if len(entities) == 0 {
+	data := make([]map[string]interface{}, 0, len(entities))
+	for _, e := range entities {
+		x := e.EncodeNBT()
+		x["identifier"] = e.EncodeEntity()
+		data = append(data, x)
+	}
+	return p.SaveEntityData(pos, data)
+}
+
+// LoadEntityData loads the NBT of all entities from the chunk position passed.
+func (p *Provider) LoadEntityData(pos world.ChunkPos) ([]map[string]interface{}, error) {
+	data, err := p.db.Get(append(p.index(pos), keyEntities), nil)
+	if err != leveldb.ErrNotFound && err != nil {
+		return nil, err
+	}
+	var a []map[string]interface{}
+
+	buf := bytes.NewBuffer(data)
+	dec := nbt.NewDecoderWithEncoding(buf, nbt.LittleEndian)
+
+	for buf.Len() != 0 {
+		var m map[string]interface{}
+		if err := dec.Decode(&m); err != nil {
+			return nil, fmt.Errorf("error decoding entity NBT: %w", err)
+		}
+		a = append(a, m)
+	}
+	return a, nil
+}
+
+// SaveEntityData saves the NBT of all entities to the chunk position passed.
+func (p *Provider) SaveEntityData(pos world.ChunkPos, data []map[string]interface{}) error {
+	if len(data) == 0 {
 		return p.db.Delete(append(p.index(pos), keyEntities), nil)
 	}
 
 	buf := bytes.NewBuffer(nil)
 	enc := nbt.NewEncoderWithEncoding(buf, nbt.LittleEndian)
-	for _, e := range entities {
-		x := e.EncodeNBT()
-		x["identifier"] = e.EncodeEntity()
+	for _, x := range data {
 		if err := enc.Encode(x); err != nil {
 			return fmt.Errorf("save entities: error encoding NBT: %w", err)
 		}
@@ -349,6 +421,28 @@ func (p *Provider) SaveBlockNBT(position world.ChunkPos, data []map[string]inter
 	return p.db.Put(append(p.index(position), keyBlockEntities), buf.Bytes(), nil)
 }
 
+// LoadChunkTick loads the tick at which the chunk at the position passed was last saved. If the chunk was never
+// saved by dragonfly, exists is false.
+func (p *Provider) LoadChunkTick(position world.ChunkPos) (tick int64, exists bool, err error) {
+	data, err := p.db.Get(append(p.index(position), keyLastTick), nil)
+	if err == leveldb.ErrNotFound {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("error reading chunk tick: %w", err)
+	}
+	if len(data) != 8 {
+		return 0, false, fmt.Errorf("error reading chunk tick: expected 8 bytes, got %v", len(data))
+	}
+	return int64(binary.LittleEndian.Uint64(data)), true, nil
+}
+
+// SaveChunkTick saves the tick at which the chunk at the position passed is saved.
+func (p *Provider) SaveChunkTick(position world.ChunkPos, tick int64) error {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, uint64(tick))
+	return p.db.Put(append(p.index(position), keyLastTick), data, nil)
+}
+
 // Close closes the provider, saving any file that might need to be saved, such as the level.dat.
 func (p *Provider) Close() error {
 	p.d.LastPlayed = time.Now().Unix()
@@ -363,7 +457,17 @@ func (p *Provider) Close() error {
 
 	buf := bytes.NewBuffer(nil)
 	_ = binary.Write(buf, binary.LittleEndian, int32(3))
-	nbtData, err := nbt.MarshalEncoding(p.d, nbt.LittleEndian)
+	m, err := p.dataMap()
+	if err != nil {
+		return fmt.Errorf("error encoding level.dat to NBT: %w", err)
+	}
+	for k, v := range p.extra {
+		if _, ok := m[k]; !ok {
+			// Fields modelled by the data struct always take precedence over extra data.
+			m[k] = v
+		}
+	}
+	nbtData, err := nbt.MarshalEncoding(m, nbt.LittleEndian)
 	if err != nil {
 		return fmt.Errorf("error encoding level.dat to NBT: %w", err)
 	}
@@ -0,0 +1,67 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"testing"
+)
+
+// TestSandColumnFalls checks that breaking the block beneath a column of ten sand blocks turns every sand block
+// in it into a falling block, only through the neighbour updates of the blocks beneath it, and that the column
+// lands on the floor again.
+func TestSandColumnFalls(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-2, 0, -2}, cube.Pos{2, 0, 2}, block.Stone{})
+	w.SetBlock(cube.Pos{0, 4, 0}, block.Dirt{})
+	w.Fill(cube.Pos{0, 5, 0}, cube.Pos{0, 14, 0}, block.Sand{})
+
+	w.BreakBlockWithoutParticles(cube.Pos{0, 4, 0})
+	falling := map[*entity.FallingBlock]struct{}{}
+	for i := 0; i < 200; i++ {
+		w.Advance(1)
+		for _, e := range w.Entities() {
+			if f, ok := e.(*entity.FallingBlock); ok {
+				falling[f] = struct{}{}
+			}
+		}
+	}
+	if len(falling) != 10 {
+		t.Errorf("%v sand blocks turned into falling blocks, want 10", len(falling))
+	}
+	for y := 1; y <= 14; y++ {
+		_, sand := w.Block(cube.Pos{0, y, 0}).(block.Sand)
+		if want := y <= 10; sand != want {
+			t.Errorf("sand at y=%v after the column fell: %v, want %v", y, sand, want)
+		}
+	}
+}
+
+// TestWaterFlowsIntoBrokenBlock checks that water resting on a block flows down to the floor once that block is
+// broken, because breaking the block updates the water above it.
+func TestWaterFlowsIntoBrokenBlock(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+	w.Fill(cube.Pos{-2, 0, -2}, cube.Pos{2, 0, 2}, block.Stone{})
+	w.SetBlock(cube.Pos{0, 4, 0}, block.Stone{})
+	// The water is walled in, so that it cannot spread sideways and flow down next to the stone.
+	for _, face := range cube.HorizontalFaces() {
+		w.SetBlock(cube.Pos{0, 5, 0}.Side(face), block.Glass{})
+	}
+	w.SetLiquid(cube.Pos{0, 5, 0}, block.Water{Still: true, Depth: 8})
+	w.Advance(40)
+
+	floor := cube.Pos{0, 1, 0}
+	if _, ok := w.Liquid(floor); ok {
+		t.Fatalf("water reached the floor before the stone beneath it was broken")
+	}
+	w.BreakBlockWithoutParticles(cube.Pos{0, 4, 0})
+	w.Advance(40)
+	if l, ok := w.Liquid(floor); !ok {
+		t.Errorf("water did not reach the floor after the stone beneath it was broken")
+	} else if _, water := l.(block.Water); !water {
+		t.Errorf("floor holds %#v, want water", l)
+	}
+}
@@ -43,6 +43,9 @@ type Note struct {
 	Pitch int
 }
 
+// JukeboxNote is a particle that shows up above a jukebox while it is playing a music disc.
+type JukeboxNote struct{ particle }
+
 // DragonEggTeleport is a particle that shows up when a dragon egg teleports.
 type DragonEggTeleport struct {
 	particle
@@ -51,6 +54,13 @@ type DragonEggTeleport struct {
 	Diff cube.Pos
 }
 
+// DispenseSmoke is a particle of smoke shown in front of a dispenser or dropper when it dispenses an item.
+type DispenseSmoke struct {
+	particle
+	// Face is the face of the dispenser or dropper that the item was dispensed from.
+	Face cube.Face
+}
+
 // Evaporate is a particle that shows up when a water block evaporates
 type Evaporate struct{ particle }
 
@@ -37,6 +37,35 @@ type Provider interface {
 	SaveBlockNBT(position ChunkPos, data []map[string]interface{}) error
 }
 
+// EntityDataProvider is a Provider that is able to load and save the raw NBT of the entities in a chunk. If the
+// Provider of a World implements EntityDataProvider, the World uses it instead of LoadEntities and SaveEntities,
+// so that entities that cannot be decoded, for example because they are not implemented, are written back
+// unchanged when the chunk is saved instead of being lost.
+type EntityDataProvider interface {
+	Provider
+	// LoadEntityData loads the NBT of all entities stored at a particular chunk position. Each entity has its
+	// name stored under the 'identifier' key. If the entities cannot be read, LoadEntityData returns a non-nil
+	// error.
+	LoadEntityData(position ChunkPos) ([]map[string]interface{}, error)
+	// SaveEntityData saves the NBT of a list of entities in a chunk position. If writing is not successful, an
+	// error is returned.
+	SaveEntityData(position ChunkPos, data []map[string]interface{}) error
+}
+
+// ChunkTickProvider is a Provider that is able to load and save the tick of the World at which a chunk was last
+// saved. If the Provider of a World implements ChunkTickProvider, the World uses it to find out how many ticks a
+// chunk spent unloaded, which is passed to the LoadListeners and ElapsedSimulators in it. Otherwise, this amount
+// is unknown: LoadListeners are passed -1 and ElapsedSimulators do not catch up.
+type ChunkTickProvider interface {
+	Provider
+	// LoadChunkTick loads the tick of the World at which the chunk at a specific position was last saved. If no
+	// tick was saved for the chunk, for example because it was saved by a different program, exists is false.
+	LoadChunkTick(position ChunkPos) (tick int64, exists bool, err error)
+	// SaveChunkTick saves the tick of the World at which the chunk at a specific position is saved. If the tick
+	// cannot be stored, SaveChunkTick returns a non-nil error.
+	SaveChunkTick(position ChunkPos, tick int64) error
+}
+
 // NoIOProvider implements a Provider while not performing any disk I/O. It generates values on the run and
 // dynamically, instead of reading and writing data, and returns otherwise empty values.
 type NoIOProvider struct{}
@@ -0,0 +1,35 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+// TestWorldSave checks that World.Save writes loaded chunks to the provider without unloading them or closing
+// the entities in them.
+func TestWorldSave(t *testing.T) {
+	w := servertest.NewWorld()
+	defer w.Close()
+
+	w.SetBlock(cube.Pos{1, 10, 1}, block.Stone{})
+	p := w.NewPlayer("saver", mgl64.Vec3{0.5, 11, 0.5})
+	w.Save()
+
+	c, ok, err := w.Provider().LoadChunk(world.ChunkPos{})
+	if err != nil || !ok {
+		t.Fatalf("chunk was not saved: found %v, error %v", ok, err)
+	}
+	if stone, _ := world.BlockRuntimeID(block.Stone{}); c.Block(1, 10, 1, 0) != stone {
+		t.Errorf("saved chunk does not hold the stone block set")
+	}
+	if _, ok := w.Block(cube.Pos{1, 10, 1}).(block.Stone); !ok {
+		t.Errorf("block changed after saving")
+	}
+	if ew, ok := world.OfEntity(p); !ok || ew != w.World {
+		t.Errorf("player was removed from the world by saving")
+	}
+}
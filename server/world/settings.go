@@ -14,8 +14,13 @@ type Settings struct {
 
 	// Name is the display name of the World.
 	Name string
+	// Seed is the seed of the World. It is used by generators that generate terrain pseudo-randomly.
+	Seed int64
 	// Spawn is the spawn position of the World. New players that join the world will be spawned here.
 	Spawn cube.Pos
+	// SpawnRadius is the radius in blocks around the Spawn within which players without a spawn position of
+	// their own are scattered when they respawn.
+	SpawnRadius int32
 	// Time is the current time of the World. It advances every tick if TimeCycle is set to true.
 	Time int64
 	// TimeCycle specifies if the time should advance every tick. If set to false, time won't change.
@@ -41,6 +46,10 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// Data holds additional level data that is not modelled by any of the other fields of Settings, indexed by
+	// the key it is stored under. Providers load fields they do not otherwise use into Data and save them back
+	// when saving the Settings.
+	Data map[string]interface{}
 }
 
 // defaultSettings returns the default Settings for a new World.
@@ -52,5 +61,7 @@ func defaultSettings() *Settings {
 		TimeCycle:       true,
 		WeatherCycle:    true,
 		TickRange:       6,
+		SpawnRadius:     5,
+		Data:            map[string]interface{}{},
 	}
 }
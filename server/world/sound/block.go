@@ -31,6 +31,9 @@ type GlassBreak struct{ sound }
 // them turns into a solid block.
 type Fizz struct{ sound }
 
+// BellRing is played when a bell is rung.
+type BellRing struct{ sound }
+
 // ChestOpen is played when a chest is opened.
 type ChestOpen struct{ sound }
 
@@ -55,6 +58,10 @@ type DoorCrash struct{ sound }
 // Click is a clicking sound.
 type Click struct{ sound }
 
+// ClickFail is a clicking sound with a higher pitch than Click, played when a dispenser or dropper fails to
+// dispense an item.
+type ClickFail struct{ sound }
+
 // Ignite is a sound played when using a flint & steel.
 type Ignite struct{ sound }
 
@@ -79,8 +86,68 @@ type ItemFrameRemove struct{ sound }
 // ItemFrameRotate is a sound played when an item frame's item is rotated.
 type ItemFrameRotate struct{ sound }
 
+// RespawnAnchorCharge is a sound played when a respawn anchor is charged using glowstone.
+type RespawnAnchorCharge struct{ sound }
+
+// RespawnAnchorDeplete is a sound played when a charge of a respawn anchor is used up.
+type RespawnAnchorDeplete struct{ sound }
+
+// RespawnAnchorSetSpawn is a sound played when a player sets its spawn point using a respawn anchor.
+type RespawnAnchorSetSpawn struct{ sound }
+
+// CauldronFillWater is a sound played when water is added to a cauldron.
+type CauldronFillWater struct{ sound }
+
+// CauldronTakeWater is a sound played when water is taken out of a cauldron.
+type CauldronTakeWater struct{ sound }
+
+// CauldronFillLava is a sound played when a cauldron is filled with lava.
+type CauldronFillLava struct{ sound }
+
+// CauldronTakeLava is a sound played when lava is taken out of a cauldron.
+type CauldronTakeLava struct{ sound }
+
+// CauldronFillPotion is a sound played when a potion is poured into a cauldron.
+type CauldronFillPotion struct{ sound }
+
+// CauldronTakePotion is a sound played when a potion is taken out of a cauldron.
+type CauldronTakePotion struct{ sound }
+
+// CauldronAddDye is a sound played when the water in a cauldron is dyed.
+type CauldronAddDye struct{ sound }
+
+// CauldronDyeArmour is a sound played when leather armour is dyed using the dyed water in a cauldron.
+type CauldronDyeArmour struct{ sound }
+
+// CauldronCleanArmour is a sound played when the dye is washed off leather armour using a cauldron.
+type CauldronCleanArmour struct{ sound }
+
 // sound implements the world.Sound interface.
 type sound struct{}
 
 // Play ...
 func (sound) Play(*world.World, mgl64.Vec3) {}
+
+// EnderChestOpen is played when an ender chest is opened.
+type EnderChestOpen struct{ sound }
+
+// EnderChestClose is played when an ender chest is closed.
+type EnderChestClose struct{ sound }
+
+// GrindstoneUse is played when an item is taken out of a grindstone after repairing or disenchanting it.
+type GrindstoneUse struct{ sound }
+
+// SmithingTableUse is played when an item is upgraded in a smithing table.
+type SmithingTableUse struct{ sound }
+
+// MusicDiscPlay is a sound played when a music disc is inserted into a jukebox.
+type MusicDiscPlay struct {
+	// DiscType is the type of the music disc inserted. The track played depends on this field.
+	DiscType DiscType
+
+	sound
+}
+
+// MusicDiscEnd is a sound played when a music disc stops playing, such as when it is ejected from a jukebox. It
+// stops the track of the disc for viewers that were hearing it.
+type MusicDiscEnd struct{ sound }
@@ -0,0 +1,15 @@
+package sound
+
+// Custom is a sound played using its name, such as 'random.levelup' or a sound added by a resource pack. Unlike
+// other sounds, the volume and pitch of a Custom sound may be changed.
+type Custom struct {
+	// Name is the name of the sound, as specified in the sound definitions of the client.
+	Name string
+	// Volume is the volume of the sound. The further the sound is heard away from its position, the higher its
+	// volume should be. If left 0, a volume of 1 is used.
+	Volume float64
+	// Pitch is the pitch of the sound. If left 0, a pitch of 1 is used.
+	Pitch float64
+
+	sound
+}
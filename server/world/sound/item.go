@@ -20,6 +20,14 @@ type ItemUseOn struct {
 	sound
 }
 
+// EquipItem is a sound played when an item, such as a piece of armour, is equipped by using it.
+type EquipItem struct {
+	// Item is the item that was equipped. The sound played differs depending on this field.
+	Item world.Item
+
+	sound
+}
+
 // BucketFill is a sound played when a bucket is filled using a liquid source block from the world.
 type BucketFill struct {
 	// Liquid is the liquid that the bucket is filled up with.
@@ -38,3 +46,32 @@ type BucketEmpty struct {
 
 // EndermanTeleport is a sound played upon teleportation of an enderman, or teleportation of a player by an ender pearl or a chorus fruit.
 type EndermanTeleport struct{ sound }
+
+// FireworkLaunch is a sound played when a firework is launched.
+type FireworkLaunch struct{ sound }
+
+// FireworkBlast is a sound played when a firework explodes.
+type FireworkBlast struct {
+	// Large specifies if the explosion of the firework was large. If true, a louder sound is played.
+	Large bool
+
+	sound
+}
+
+// FireworkTwinkle is a sound played after a firework with a twinkling explosion explodes.
+type FireworkTwinkle struct{ sound }
+
+// LeashKnotPlace is a sound played when a leashed entity is tied to a fence, creating a leash knot.
+type LeashKnotPlace struct{ sound }
+
+// LeashKnotBreak is a sound played when a leash snaps or when a leash knot is broken.
+type LeashKnotBreak struct{ sound }
+
+// CrossbowLoad is a sound played when a crossbow is charged with a projectile.
+type CrossbowLoad struct{ sound }
+
+// CrossbowShoot is a sound played when a charged crossbow is fired.
+type CrossbowShoot struct{ sound }
+
+// ArrowHit is a sound played when an arrow hits a block or an entity.
+type ArrowHit struct{ sound }
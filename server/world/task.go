@@ -0,0 +1,114 @@
+package world
+
+import (
+	"go.uber.org/atomic"
+	"runtime/debug"
+)
+
+// task is a function scheduled to be run on the ticking goroutine of a World using World.Exec, World.ExecLater
+// or World.ExecRepeating.
+type task struct {
+	f func()
+	// at is the task tick at which the task is run next.
+	at int64
+	// interval is the amount of ticks between runs of a repeating task. It is 0 for tasks that run only once.
+	interval  int64
+	cancelled atomic.Bool
+}
+
+// Exec queues the function passed to be run on the next tick of the World. The function is run synchronously on
+// the goroutine that ticks the World, so that it may safely access blocks and entities without racing with the
+// World ticking. Unlike most other operations, functions are executed even if the World has no viewers.
+// A panic in the function is recovered and logged. Functions queued from within a queued function are run in
+// the tick after.
+func (w *World) Exec(f func()) {
+	w.schedule(f, 1, 0)
+}
+
+// ExecLater queues the function passed to be run on the ticking goroutine of the World after the amount of ticks
+// passed, like Exec. The function returned may be called to cancel the function before it is run.
+func (w *World) ExecLater(delay int, f func()) (cancel func()) {
+	if delay < 1 {
+		delay = 1
+	}
+	return w.schedule(f, int64(delay), 0)
+}
+
+// ExecRepeating queues the function passed to be run on the ticking goroutine of the World every time the
+// amount of ticks passed has passed, like Exec. The function is first run after one interval. The function
+// returned may be called to stop repeating the function.
+func (w *World) ExecRepeating(interval int, f func()) (cancel func()) {
+	if interval < 1 {
+		interval = 1
+	}
+	return w.schedule(f, int64(interval), int64(interval))
+}
+
+// schedule schedules a function to run after the delay passed, repeating every interval if it is not 0. It
+// returns a function that cancels the task.
+func (w *World) schedule(f func(), delay, interval int64) (cancel func()) {
+	if w == nil {
+		return func() {}
+	}
+	if w.closed.Load() {
+		w.log.Errorf("cannot execute task in closed world %v", w.Name())
+		return func() {}
+	}
+	w.taskMu.Lock()
+	t := &task{f: f, at: w.taskTick + delay, interval: interval}
+	w.tasks = append(w.tasks, t)
+	w.taskMu.Unlock()
+	return func() {
+		t.cancelled.Store(true)
+	}
+}
+
+// tickTasks runs all tasks that are due in the current tick. Tasks are ticked regardless of the viewers of the
+// World, so they use their own tick counter.
+func (w *World) tickTasks() {
+	w.taskMu.Lock()
+	w.taskTick++
+	tick := w.taskTick
+
+	var due []*task
+	pending := w.tasks[:0]
+	for _, t := range w.tasks {
+		switch {
+		case t.cancelled.Load():
+		case t.at <= tick:
+			due = append(due, t)
+		default:
+			pending = append(pending, t)
+		}
+	}
+	for i := len(pending); i < len(w.tasks); i++ {
+		// Clear the references to tasks that were removed so that they may be garbage collected.
+		w.tasks[i] = nil
+	}
+	w.tasks = pending
+	w.taskMu.Unlock()
+
+	for _, t := range due {
+		if t.cancelled.Load() {
+			continue
+		}
+		w.runTask(t)
+		if t.interval != 0 && !t.cancelled.Load() {
+			t.at = tick + t.interval
+			w.taskMu.Lock()
+			w.tasks = append(w.tasks, t)
+			w.taskMu.Unlock()
+		}
+	}
+}
+
+// runTask runs the function of the task passed, recovering and logging any panic so that the World keeps
+// ticking.
+func (w *World) runTask(t *task) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Errorf("panic in task executed in world %v: %v\n%s", w.Name(), r, debug.Stack())
+		}
+	}()
+	t.f()
+}
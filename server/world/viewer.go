@@ -85,6 +85,8 @@ func (NopViewer) HideEntity(Entity)
 func (NopViewer) ViewEntityMovement(Entity, mgl64.Vec3, float64, float64, bool) {}
 func (NopViewer) ViewEntityVelocity(Entity, mgl64.Vec3)                         {}
 func (NopViewer) ViewEntityTeleport(Entity, mgl64.Vec3)                         {}
+func (NopViewer) ViewEntityMount(Entity, Entity, bool)                          {}
+func (NopViewer) ViewEntityDismount(Entity, Entity)                             {}
 func (NopViewer) ViewChunk(ChunkPos, *chunk.Chunk, map[cube.Pos]Block)          {}
 func (NopViewer) ViewTime(int)                                                  {}
 func (NopViewer) ViewEntityItems(Entity)                                        {}
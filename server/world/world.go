@@ -1,6 +1,7 @@
 package world
 
 import (
+	"errors"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/physics"
@@ -9,7 +10,9 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/go-gl/mathgl/mgl64"
 	"go.uber.org/atomic"
+	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 )
@@ -40,6 +43,16 @@ type World struct {
 
 	closing chan struct{}
 	running sync.WaitGroup
+	// manual is set to true once ManualTicking is called, after which stopTicking is closed so that the world
+	// stops ticking by itself.
+	manual      atomic.Bool
+	stopTicking chan struct{}
+	// closeStarted is set to true once Close is called. closed is set to true once the World stopped ticking
+	// after that. Operations on a closed World are no-ops.
+	closeStarted, closed atomic.Bool
+	// closedLog holds the time, in Unix nanoseconds, at which the last attempt to load a chunk of the closed
+	// World was logged, so that these attempts are logged at most once every second.
+	closedLog atomic.Int64
 
 	handlerMu sync.RWMutex
 	handler   Handler
@@ -56,9 +69,18 @@ type World struct {
 	// entities holds a map of entities currently loaded and the last ChunkPos that the Entity was in.
 	// These are tracked so that a call to RemoveEntity can find the correct entity.
 	entities map[Entity]ChunkPos
+	// entityOrder holds the order in which the entities in the entities map were added to the World, so that
+	// they are always ticked in the same order. entityCount is increased for every entity added.
+	entityOrder map[Entity]uint64
+	entityCount uint64
 
 	r               *rand.Rand
 	randomTickSpeed atomic.Uint32
+	daylightBurning atomic.Bool
+	spawnProtection atomic.Int32
+	gravity         atomic.Float64
+	// elapsedSimulation specifies if ElapsedSimulators catch up on the time their chunk was unloaded.
+	elapsedSimulation atomic.Bool
 
 	updateMu sync.Mutex
 	// blockUpdates is a map of tick time values indexed by the block position at which an update is
@@ -74,6 +96,21 @@ type World struct {
 	positionCache       []ChunkPos
 	entitiesToTick      []TickerEntity
 
+	emitterMu sync.Mutex
+	emitters  map[Emitter]struct{}
+
+	elapsedMu sync.Mutex
+	// elapsed holds the chunks loaded with ElapsedSimulators that have yet to catch up on the time that the chunk
+	// was unloaded.
+	elapsed []elapsedChunk
+
+	taskMu sync.Mutex
+	// taskTick is the amount of times the World ticked its tasks. Unlike Settings.CurrentTick, it also increases
+	// if the World has no viewers.
+	taskTick int64
+	// tasks holds the tasks scheduled using Exec, ExecLater and ExecRepeating that have not yet run.
+	tasks []*task
+
 	viewersMu sync.Mutex
 	viewers   map[Viewer]struct{}
 }
@@ -93,19 +130,25 @@ func New(log internal.Logger, d Dimension, s *Settings) *World {
 		r:               rand.New(rand.NewSource(time.Now().Unix())),
 		blockUpdates:    map[cube.Pos]int64{},
 		entities:        map[Entity]ChunkPos{},
+		entityOrder:     map[Entity]uint64{},
 		viewers:         map[Viewer]struct{}{},
+		emitters:        map[Emitter]struct{}{},
 		prov:            NoIOProvider{},
 		gen:             NopGenerator{},
 		handler:         NopHandler{},
 		randomTickSpeed: *atomic.NewUint32(3),
+		daylightBurning: *atomic.NewBool(true),
+		gravity:         *atomic.NewFloat64(1),
 		log:             log,
 		set:             s,
 		closing:         make(chan struct{}),
+		stopTicking:     make(chan struct{}),
 		d:               d,
 		ra:              d.Range(),
 	}
 
 	w.initChunkCache()
+	w.running.Add(2)
 	go w.startTicking()
 	go w.chunkCacheJanitor()
 	return w
@@ -120,6 +163,59 @@ func (w *World) Name() string {
 	return w.set.Name
 }
 
+// SetName changes the display name of the world. Players that join the world after the name is changed will
+// see the new name. The name is saved to the Provider of the world when it is closed.
+func (w *World) SetName(name string) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Name = name
+}
+
+// Seed returns the seed of the world. It is loaded from the Provider of the world and is 0 if the Provider
+// does not have a seed.
+func (w *World) Seed() int64 {
+	if w == nil {
+		return 0
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Seed
+}
+
+// LevelData returns level data stored under the key passed that is not otherwise modelled by the World, such as
+// custom fields in a level.dat. If no data is stored under the key, false is returned.
+func (w *World) LevelData(key string) (interface{}, bool) {
+	if w == nil {
+		return nil, false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	v, ok := w.set.Data[key]
+	return v, ok
+}
+
+// SetLevelData stores the value passed under a key in the level data of the world. The value is saved to the
+// Provider of the world, so it must be a value that the Provider is able to encode, such as one of the basic
+// NBT types for a level.dat. Passing nil as the value removes the key from the level data.
+func (w *World) SetLevelData(key string, value interface{}) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	if value == nil {
+		delete(w.set.Data, key)
+		return
+	}
+	if w.set.Data == nil {
+		w.set.Data = map[string]interface{}{}
+	}
+	w.set.Data[key] = value
+}
+
 // Dimension returns the Dimension assigned to the World in world.New. The sky colour and behaviour of a variety of
 // world features differ based on the Dimension assigned to a World.
 func (w *World) Dimension() Dimension {
@@ -142,7 +238,9 @@ func (w *World) Block(pos cube.Pos) Block {
 	chunkPos := ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}
 	c, err := w.chunk(chunkPos)
 	if err != nil {
-		w.log.Errorf("error getting block: %v", err)
+		if err != errClosed {
+			w.log.Errorf("error getting block: %v", err)
+		}
 		return air()
 	}
 	rid := c.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0)
@@ -160,6 +258,13 @@ func (w *World) Block(pos cube.Pos) Block {
 	return b
 }
 
+// BlockState returns the encoded state of the block at the position passed: Its name and the properties it has,
+// as stored in chunks and sent to viewers. This may be used for debugging, as it shows the exact state rather
+// than the Block implementation.
+func (w *World) BlockState(pos cube.Pos) (name string, properties map[string]interface{}) {
+	return w.Block(pos).EncodeBlock()
+}
+
 // Biome reads the biome at the position passed. If a chunk is not yet loaded at that position, the chunk is
 // loaded, or generated if it could not be found in the world save, and the biome returned. Chunks will be
 // loaded synchronously.
@@ -274,7 +379,9 @@ func (w *World) SetBlock(pos cube.Pos, b Block) {
 		return
 	}
 	c.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, rid)
+	c.indexLightningRod(pos, rid)
 
+	old := c.e[pos]
 	if nbtBlocks[rid] {
 		c.e[pos] = b
 	} else {
@@ -291,6 +398,15 @@ func (w *World) SetBlock(pos cube.Pos, b Block) {
 	for _, viewer := range viewers {
 		viewer.ViewBlockUpdate(pos, b, 0)
 	}
+	if removed, ok := old.(RemovalListener); ok && (b == nil || blockName(old) != blockName(b)) {
+		removed.Removed(pos, w)
+	}
+}
+
+// blockName returns the name of the block passed as encoded using EncodeBlock.
+func blockName(b Block) string {
+	name, _ := b.EncodeBlock()
+	return name
 }
 
 // SetBiome sets the biome at the position passed. If a chunk is not yet loaded at that position, the chunk is
@@ -315,6 +431,8 @@ var breakParticle func(b Block) Particle
 
 // BreakBlock breaks a block at the position passed. Unlike when setting the block at that position to air,
 // BreakBlock will also show particles and update blocks around the position.
+// Blocks and liquids around the position, including those above it, receive a neighbour update in the next
+// tick. This makes gravity affected blocks above the position fall and liquids above it start flowing down.
 func (w *World) BreakBlock(pos cube.Pos) {
 	if w == nil {
 		return
@@ -436,10 +554,12 @@ func (w *World) BuildStructure(pos cube.Pos, s Structure) {
 								}
 								sub.SetBlock(uint8(xOffset), uint8(yOffset), uint8(zOffset), 0, rid)
 
+								actual := cube.Pos{xOffset, yOffset, zOffset}
+								c.indexLightningRod(actual, rid)
 								if nbtBlocks[rid] {
-									c.e[pos] = b
+									c.e[actual] = b
 								} else {
-									delete(c.e, pos)
+									delete(c.e, actual)
 								}
 							}
 							if liq != nil {
@@ -565,6 +685,39 @@ func (w *World) SetLiquid(pos cube.Pos, b Liquid) {
 	w.doBlockUpdatesAround(pos)
 }
 
+// RemoveLiquids removes any liquid present at the positions passed, leaving the blocks that the liquids were
+// in, such as those of waterlogged blocks, in place. Unlike calling SetLiquid with nil for every position, the
+// liquids of every chunk are removed at once, and neighbouring blocks are only updated after all liquids have
+// been removed, so that viewers never see liquid flowing back into positions that are still being cleared.
+func (w *World) RemoveLiquids(positions []cube.Pos) {
+	if w == nil || len(positions) == 0 {
+		return
+	}
+	byChunk := make(map[ChunkPos][]cube.Pos)
+	for _, pos := range positions {
+		if !pos.OutOfBounds(w.ra) {
+			chunkPos := chunkPosFromBlockPos(pos)
+			byChunk[chunkPos] = append(byChunk[chunkPos], pos)
+		}
+	}
+	for chunkPos, chunkPositions := range byChunk {
+		c, err := w.chunk(chunkPos)
+		if err != nil {
+			w.log.Errorf("failed removing liquids: error getting chunk at position %v: %v", chunkPos, err)
+			continue
+		}
+		for _, pos := range chunkPositions {
+			w.removeLiquids(c, pos)
+		}
+		c.Unlock()
+	}
+	for _, chunkPositions := range byChunk {
+		for _, pos := range chunkPositions {
+			w.doBlockUpdatesAround(pos)
+		}
+	}
+}
+
 // removeLiquids removes any liquid blocks that may be present at a specific block position in the chunk
 // passed.
 // The bool returned specifies if no blocks were left on the foreground layer.
@@ -711,6 +864,16 @@ func (w *World) StartTime() {
 	w.enableTimeCycle(true)
 }
 
+// TimeCycle checks if the time of the World is cycling, which it is unless World.StopTime() was called.
+func (w *World) TimeCycle() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.TimeCycle
+}
+
 // enableTimeCycle enables or disables the time cycling of the World.
 func (w *World) enableTimeCycle(v bool) {
 	if w == nil {
@@ -741,6 +904,66 @@ func (w *World) StartWeatherCycle() {
 	w.set.WeatherCycle = true
 }
 
+// CanSeeSky checks if the position passed is exposed to the sky, meaning there are no blocks above it that
+// fully block light. The check only uses the height map of the chunk, so it is cheap to perform.
+func (w *World) CanSeeSky(pos cube.Pos) bool {
+	if w == nil {
+		return false
+	}
+	if pos[1] > w.ra[1] {
+		return true
+	}
+	return w.HighestLightBlocker(pos[0], pos[2]) < pos[1]
+}
+
+// Daytime checks if it is currently day in the World. Daytime always returns false in dimensions without a
+// day/night cycle.
+func (w *World) Daytime() bool {
+	if w == nil || !w.Dimension().TimeCycle() {
+		return false
+	}
+	t := w.Time() % 24000
+	return t < 12000 || t >= 23460
+}
+
+// DaylightBurning checks if entities that burn in daylight, such as undead mobs, are set on fire in the
+// World. This is true by default.
+func (w *World) DaylightBurning() bool {
+	if w == nil {
+		return false
+	}
+	return w.daylightBurning.Load()
+}
+
+// SetDaylightBurning changes whether entities that burn in daylight, such as undead mobs, are set on fire
+// in the World.
+func (w *World) SetDaylightBurning(v bool) {
+	if w == nil {
+		return
+	}
+	w.daylightBurning.Store(v)
+}
+
+// GravityMultiplier returns the multiplier applied to the gravity of all entities in the World, as set using
+// SetGravityMultiplier. It is 1 by default.
+func (w *World) GravityMultiplier() float64 {
+	if w == nil {
+		return 1
+	}
+	return w.gravity.Load()
+}
+
+// SetGravityMultiplier sets the multiplier applied to the gravity of all entities in the World. A multiplier
+// of 0.5, for example, makes entities fall half as fast, while 0 disables gravity altogether. The change takes
+// effect from the next tick of the entities in the World. Note that the movement of players controlled by a
+// client is computed by that client, which is not aware of the multiplier.
+func (w *World) SetGravityMultiplier(m float64) {
+	if w == nil {
+		return
+	}
+	w.gravity.Store(m)
+}
+
 // SnowingAt returns a bool that indicates whether it is snowing at a position in the world.
 func (w *World) SnowingAt(pos cube.Pos) bool {
 	if w == nil || !w.Dimension().WeatherCycle() {
@@ -833,6 +1056,10 @@ func (w *World) AddEntity(e Entity) {
 	if w == nil {
 		return
 	}
+	if w.closed.Load() {
+		w.log.Errorf("cannot add entity %T to closed world %v", e, w.Name())
+		return
+	}
 	if e.World() != nil {
 		e.World().RemoveEntity(e)
 	}
@@ -843,6 +1070,7 @@ func (w *World) AddEntity(e Entity) {
 	chunkPos := chunkPosFromVec3(e.Position())
 	w.entityMu.Lock()
 	w.entities[e] = chunkPos
+	w.addEntityOrder(e)
 	w.entityMu.Unlock()
 
 	c, err := w.chunk(chunkPos)
@@ -912,6 +1140,7 @@ func (w *World) RemoveEntity(e Entity) {
 
 	w.entityMu.Lock()
 	delete(w.entities, e)
+	delete(w.entityOrder, e)
 	w.entityMu.Unlock()
 
 	for _, viewer := range viewers {
@@ -992,7 +1221,8 @@ func (w *World) Spawn() cube.Pos {
 }
 
 // SetSpawn sets the spawn of the world to a different position. The player will be spawned in the center of
-// this position when newly joining.
+// this position when newly joining. All viewers of the world are notified of the new spawn, so that compasses
+// held by players point to it.
 func (w *World) SetSpawn(pos cube.Pos) {
 	if w == nil {
 		return
@@ -1005,6 +1235,97 @@ func (w *World) SetSpawn(pos cube.Pos) {
 	}
 }
 
+// SpawnRadius returns the radius in blocks around the spawn of the world within which players without a spawn
+// position of their own are scattered when they respawn. If 0, players always respawn at the exact spawn.
+func (w *World) SpawnRadius() int {
+	if w == nil {
+		return 0
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return int(w.set.SpawnRadius)
+}
+
+// SetSpawnRadius sets the radius in blocks around the spawn of the world within which players without a spawn
+// position of their own are scattered when they respawn.
+func (w *World) SetSpawnRadius(r int) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.SpawnRadius = int32(r)
+}
+
+// ScatteredSpawn returns a random safe position within the spawn radius around the spawn of the world, as
+// returned by SafePosition. If no safe position could be found or if the spawn radius is 0, the spawn of the
+// world is returned.
+func (w *World) ScatteredSpawn() cube.Pos {
+	spawn, r := w.Spawn(), w.SpawnRadius()
+	if r <= 0 {
+		return spawn
+	}
+	for i := 0; i < 10; i++ {
+		if pos, ok := w.SafePosition(spawn[0]+rand.Intn(r*2+1)-r, spawn[2]+rand.Intn(r*2+1)-r); ok {
+			return pos
+		}
+	}
+	return spawn
+}
+
+// SafePosition finds the highest position at the x and z passed where an entity may safely stand: On top of a
+// block with a solid top face, with two blocks of space above it that have no collision and hold no liquid. The
+// position of the lower of these two blocks is returned. If no such position exists, SafePosition returns false.
+func (w *World) SafePosition(x, z int) (cube.Pos, bool) {
+	if w == nil {
+		return cube.Pos{}, false
+	}
+	ground := cube.Pos{x, w.highestObstructingBlock(x, z), z}
+	if ground[1] >= w.ra[1]-1 || !w.Block(ground).Model().FaceSolid(ground, cube.FaceUp, w) {
+		return cube.Pos{}, false
+	}
+	for _, pos := range []cube.Pos{ground.Side(cube.FaceUp), ground.Add(cube.Pos{0, 2})} {
+		if len(w.Block(pos).Model().AABB(pos, w)) != 0 {
+			return cube.Pos{}, false
+		}
+		if _, ok := w.Liquid(pos); ok {
+			return cube.Pos{}, false
+		}
+	}
+	return ground.Side(cube.FaceUp), true
+}
+
+// SpawnProtection returns the radius in blocks around the spawn of the world within which blocks are protected.
+// Blocks in this area may only be placed and broken by operators. If 0, spawn protection is disabled, which is
+// the default.
+func (w *World) SpawnProtection() int {
+	if w == nil {
+		return 0
+	}
+	return int(w.spawnProtection.Load())
+}
+
+// SetSpawnProtection sets the radius in blocks around the spawn of the world within which blocks are protected.
+// Passing 0 disables spawn protection.
+func (w *World) SetSpawnProtection(r int) {
+	if w == nil {
+		return
+	}
+	w.spawnProtection.Store(int32(r))
+}
+
+// SpawnProtected checks if the position passed is within the spawn protection radius of the world. Like in
+// vanilla, the protected area is a square around the spawn that spans the full height of the world.
+func (w *World) SpawnProtected(pos cube.Pos) bool {
+	r := w.SpawnProtection()
+	if r <= 0 {
+		return false
+	}
+	spawn := w.Spawn()
+	dx, dz := pos[0]-spawn[0], pos[2]-spawn[2]
+	return dx >= -r && dx <= r && dz >= -r && dz <= r
+}
+
 // DefaultGameMode returns the default game mode of the world. When players join, they are given this game
 // mode.
 // The default game mode may be changed using SetDefaultGameMode().
@@ -1036,7 +1357,7 @@ func (w *World) tickRange() int {
 }
 
 // SetDefaultGameMode changes the default game mode of the world. When players join, they are then given that
-// game mode.
+// game mode. Players already in the world keep their current game mode.
 func (w *World) SetDefaultGameMode(mode GameMode) {
 	if w == nil {
 		return
@@ -1077,12 +1398,27 @@ func (w *World) SetRandomTickSpeed(v int) {
 	w.randomTickSpeed.Store(uint32(v))
 }
 
+// RandomTickSpeed returns the random tick speed of blocks, which is the amount of blocks randomly ticked per sub
+// chunk every tick. It is 3 by default.
+func (w *World) RandomTickSpeed() int {
+	if w == nil {
+		return 0
+	}
+	return int(w.randomTickSpeed.Load())
+}
+
 // ScheduleBlockUpdate schedules a block update at the position passed after a specific delay. If the block at
-// that position does not handle block updates, nothing will happen.
+// that position does not handle block updates, nothing will happen. The update is a one-shot update: Blocks
+// that implement ScheduledTicker have their ScheduledTick method called once the delay has passed. If the chunk
+// of the position is unloaded before that, the update is discarded.
 func (w *World) ScheduleBlockUpdate(pos cube.Pos, delay time.Duration) {
 	if w == nil || pos.OutOfBounds(w.ra) {
 		return
 	}
+	if w.closed.Load() {
+		w.log.Errorf("cannot schedule block update at %v in closed world %v", pos, w.Name())
+		return
+	}
 	w.updateMu.Lock()
 	if _, exists := w.blockUpdates[pos]; exists {
 		w.updateMu.Unlock()
@@ -1096,7 +1432,20 @@ func (w *World) ScheduleBlockUpdate(pos cube.Pos, delay time.Duration) {
 	w.updateMu.Unlock()
 }
 
-// doBlockUpdatesAround schedules block updates directly around and on the position passed.
+// removeScheduledUpdates removes all block updates scheduled using ScheduleBlockUpdate in the chunk at the
+// position passed. It is called when a chunk is unloaded, so that no updates are kept for it.
+func (w *World) removeScheduledUpdates(pos ChunkPos) {
+	w.updateMu.Lock()
+	for blockPos := range w.blockUpdates {
+		if chunkPosFromBlockPos(blockPos) == pos {
+			delete(w.blockUpdates, blockPos)
+		}
+	}
+	w.updateMu.Unlock()
+}
+
+// doBlockUpdatesAround schedules block updates directly around and on the position passed. Both the block and
+// the liquid in the additional layer at each of these positions are updated in the next tick.
 func (w *World) doBlockUpdatesAround(pos cube.Pos) {
 	if w == nil || pos.OutOfBounds(w.ra) {
 		return
@@ -1217,13 +1566,22 @@ func (w *World) PortalDestinations() (nether, end *World) {
 	return w.npd, w.epd
 }
 
-// Close closes the world and saves all chunks currently loaded.
+// Close closes the world and saves all chunks currently loaded. The world stops ticking and all entities still
+// in it, including players, are saved and closed, so players should be moved to a different world first. Once
+// closed, operations on the world such as setting blocks or adding entities are no-ops. An error is returned if
+// the world was already closed or if entities still referenced the world after closing it.
 func (w *World) Close() error {
 	if w == nil {
 		return nil
 	}
+	if !w.closeStarted.CAS(false, true) {
+		return fmt.Errorf("world %v is already closed", w.Name())
+	}
 	close(w.closing)
 	w.running.Wait()
+	// The World is only marked closed once it stopped ticking, so that changes made during the last tick are
+	// still saved.
+	w.closed.Store(true)
 
 	w.log.Debugf("Saving chunks in memory to disk...")
 
@@ -1238,7 +1596,9 @@ func (w *World) Close() error {
 	w.chunkMu.Unlock()
 
 	for pos, c := range chunksToSave {
-		w.saveChunk(pos, c)
+		// Entities left in the world, including any players that were not moved to a different world, are
+		// closed after being saved.
+		w.saveChunk(pos, c, false)
 	}
 
 	if !w.rdonly.Load() {
@@ -1253,28 +1613,101 @@ func (w *World) Close() error {
 		w.log.Errorf("error closing world provider: %v", err)
 	}
 	w.Handle(NopHandler{})
+	return w.release()
+}
+
+// release releases the memory held by a closed World. An error is returned if any entities still reference the
+// World after all of its chunks were saved and the entities in them closed.
+func (w *World) release() error {
+	w.updateMu.Lock()
+	w.blockUpdates = map[cube.Pos]int64{}
+	w.updatePositions, w.neighbourUpdatePositions, w.neighbourUpdatesSync = nil, nil, nil
+	w.updateMu.Unlock()
+
+	w.emitterMu.Lock()
+	w.emitters = map[Emitter]struct{}{}
+	w.emitterMu.Unlock()
+
+	w.viewersMu.Lock()
+	w.viewers = map[Viewer]struct{}{}
+	w.viewersMu.Unlock()
+
+	w.entityMu.Lock()
+	w.entities = map[Entity]ChunkPos{}
+	w.entityOrder = map[Entity]uint64{}
+	w.entityMu.Unlock()
+
+	w.portalMu.Lock()
+	w.npd, w.epd = nil, nil
+	w.portalMu.Unlock()
+
+	n := 0
+	worldsMu.Lock()
+	for e, ew := range entityWorlds {
+		if ew == w {
+			delete(entityWorlds, e)
+			n++
+		}
+	}
+	worldsMu.Unlock()
+	if n > 0 {
+		return fmt.Errorf("%v entities still referenced world %v after closing it", n, w.Name())
+	}
 	return nil
 }
 
+// Closed checks if the World was closed using Close. Blocks cannot be changed and entities cannot be added to a
+// closed World.
+func (w *World) Closed() bool {
+	return w != nil && w.closed.Load()
+}
+
+// errClosed is returned when trying to load a chunk of a closed World.
+var errClosed = errors.New("world is closed")
+
 // startTicking starts ticking the world, updating all entities, blocks and other features such as the time of
 // the world, as required.
 func (w *World) startTicking() {
 	ticker := time.NewTicker(time.Second / 20)
 	defer ticker.Stop()
 
-	w.running.Add(1)
 	for {
 		select {
 		case <-ticker.C:
 			w.tick()
+			w.tickTasks()
 		case <-w.closing:
 			// World is being closed: Stop ticking and get rid of a task.
 			w.running.Done()
 			return
+		case <-w.stopTicking:
+			// The world is ticked manually from now on.
+			w.running.Done()
+			return
 		}
 	}
 }
 
+// ManualTicking stops the World from ticking by itself every 50 milliseconds. After calling ManualTicking, the
+// World is only ticked when Tick is called, which allows ticking it deterministically, for example in tests.
+// ManualTicking cannot be undone.
+func (w *World) ManualTicking() {
+	if w.manual.CAS(false, true) {
+		close(w.stopTicking)
+	}
+}
+
+// Tick ticks the World once, synchronously, updating the time, blocks and entities and running scheduled tasks
+// just like the World does by itself every 50 milliseconds. Tick does nothing unless ManualTicking was called
+// first, or if the World is closed.
+func (w *World) Tick() {
+	if !w.manual.Load() || w.closeStarted.Load() {
+		return
+	}
+	w.tick()
+	w.tickTasks()
+}
+
 // tick ticks the world and updates the time, blocks and entities that require updates.
 func (w *World) tick() {
 	viewers := w.allViewers()
@@ -1350,6 +1783,7 @@ func (w *World) tick() {
 	w.tickEntities(tick)
 	w.tickRandomBlocks(viewers, tick)
 	w.tickScheduledBlocks(tick)
+	w.tickEmitters(tick)
 }
 
 // strikeLightning attempts to strike lightning in the world at a specific ChunkPos. The final position is influenced by
@@ -1368,7 +1802,7 @@ func (w *World) strikeLightning(c ChunkPos) {
 			// Any (living) entity that is positioned higher than the highest block at its position is eligible to be
 			// struck by lightning. We first save all entity positions where this is the case.
 			pos := cube.PosFromVec3(e.Position())
-			if w.HighestBlock(pos[0], pos[1]) < pos[2] {
+			if w.HighestBlock(pos[0], pos[2]) < pos[1] {
 				list = append(list, e.Position())
 			}
 		}
@@ -1379,6 +1813,11 @@ func (w *World) strikeLightning(c ChunkPos) {
 		vec = list[w.r.Intn(len(list))]
 	}
 
+	if rod, ok := w.lightningRodNear(cube.PosFromVec3(vec)); ok {
+		// A lightning rod nearby attracts the lightning, so that it strikes the top of the rod instead.
+		vec = rod.Side(cube.FaceUp).Vec3()
+	}
+
 	pos := cube.PosFromVec3(vec)
 	if len(w.Block(pos).Model().AABB(pos, w)) != 0 {
 		// If lightning is about to strike inside a block that is not fully transparent. In this case, move the
@@ -1397,6 +1836,48 @@ func (w *World) strikeLightning(c ChunkPos) {
 	}).New(vec))
 }
 
+// lightningRodRadius is the horizontal radius in blocks within which lightning rods attract lightning.
+const lightningRodRadius = 64
+
+// lightningRodNear finds the lightning rod closest to the position passed within lightningRodRadius blocks. Only
+// lightning rods that are the highest block in their column, and thus exposed to the sky, in loaded chunks are
+// considered. If no lightning rod was found, false is returned.
+func (w *World) lightningRodNear(pos cube.Pos) (cube.Pos, bool) {
+	var candidates []cube.Pos
+	for x := (pos[0] - lightningRodRadius) >> 4; x <= (pos[0]+lightningRodRadius)>>4; x++ {
+		for z := (pos[2] - lightningRodRadius) >> 4; z <= (pos[2]+lightningRodRadius)>>4; z++ {
+			c, ok := w.chunkFromCache(ChunkPos{int32(x), int32(z)})
+			if !ok {
+				continue
+			}
+			c.Lock()
+			for rodPos := range c.rods {
+				dx, dz := rodPos[0]-pos[0], rodPos[2]-pos[2]
+				if dx >= -lightningRodRadius && dx <= lightningRodRadius && dz >= -lightningRodRadius && dz <= lightningRodRadius {
+					candidates = append(candidates, rodPos)
+				}
+			}
+			c.Unlock()
+		}
+	}
+
+	var (
+		rod   cube.Pos
+		found bool
+		dist  = math.MaxInt64
+	)
+	for _, candidate := range candidates {
+		if w.HighestBlock(candidate[0], candidate[2]) != candidate[1] {
+			continue
+		}
+		dx, dy, dz := candidate[0]-pos[0], candidate[1]-pos[1], candidate[2]-pos[2]
+		if d := dx*dx + dy*dy + dz*dz; d < dist {
+			rod, found, dist = candidate, true, d
+		}
+	}
+	return rod, found
+}
+
 // tickScheduledBlocks executes scheduled block ticks in chunks that are still loaded at the time of
 // execution.
 func (w *World) tickScheduledBlocks(tick int64) {
@@ -1412,6 +1893,10 @@ func (w *World) tickScheduledBlocks(tick int64) {
 	w.updateMu.Unlock()
 
 	for _, pos := range w.updatePositions {
+		if _, ok := w.chunkFromCache(chunkPosFromBlockPos(pos)); !ok {
+			// The chunk was unloaded in the meantime, so we don't load it again just to tick the block.
+			continue
+		}
 		if ticker, ok := w.Block(pos).(ScheduledTicker); ok {
 			ticker.ScheduledTick(pos, w, w.r)
 		}
@@ -1454,10 +1939,6 @@ type blockEntityToTick struct {
 // registered from the viewers passed.
 func (w *World) tickRandomBlocks(viewers []Viewer, tick int64) {
 	r := int32(w.tickRange())
-	if r == 0 {
-		// NOP if the simulation distance is 0.
-		return
-	}
 	tickSpeed := w.randomTickSpeed.Load()
 
 	for _, viewer := range viewers {
@@ -1474,6 +1955,19 @@ func (w *World) tickRandomBlocks(viewers []Viewer, tick int64) {
 
 	w.chunkMu.Lock()
 	for pos, c := range w.chunks {
+		c.Lock()
+		// Blocks implementing TickerBlock are ticked every tick as long as the chunk they are in is loaded,
+		// regardless of the simulation distance.
+		for pos, b := range c.e {
+			if ticker, ok := b.(TickerBlock); ok {
+				w.blockEntitiesToTick = append(w.blockEntitiesToTick, blockEntityToTick{
+					b:   ticker,
+					pos: pos,
+				})
+			}
+		}
+		c.Unlock()
+
 		withinSimDist := false
 		for _, chunkPos := range w.positionCache {
 			xDiff, zDiff := chunkPos[0]-pos[0], chunkPos[1]-pos[1]
@@ -1484,20 +1978,11 @@ func (w *World) tickRandomBlocks(viewers []Viewer, tick int64) {
 				break
 			}
 		}
-		if !withinSimDist {
+		if !withinSimDist || r == 0 {
 			// No viewers in this chunk that are within the simulation distance, so proceed to the next.
 			continue
 		}
 		c.Lock()
-		for pos, b := range c.e {
-			if ticker, ok := b.(TickerBlock); ok {
-				w.blockEntitiesToTick = append(w.blockEntitiesToTick, blockEntityToTick{
-					b:   ticker,
-					pos: pos,
-				})
-			}
-		}
-
 		subChunks := c.Sub()
 		cx, cz := int(pos[0]<<4), int(pos[1]<<4)
 
@@ -1575,11 +2060,18 @@ func (w *World) tickEntities(tick int64) {
 		after         *chunkData
 		viewersBefore []Viewer
 	}
-	var entitiesToMove []entityToMove
+	var (
+		entitiesToMove    []entityToMove
+		entitiesToDespawn []DespawnableEntity
+	)
 
 	w.entityMu.Lock()
 	w.chunkMu.Lock()
-	for e, lastPos := range w.entities {
+	for _, e := range w.orderedEntities() {
+		lastPos := w.entities[e]
+		if d, ok := e.(DespawnableEntity); ok {
+			entitiesToDespawn = append(entitiesToDespawn, d)
+		}
 		chunkPos := chunkPosFromVec3(e.Position())
 
 		c, ok := w.chunks[chunkPos]
@@ -1656,6 +2148,53 @@ func (w *World) tickEntities(tick int64) {
 		ticker.Tick(tick)
 	}
 	w.entitiesToTick = w.entitiesToTick[:0]
+
+	for _, e := range entitiesToDespawn {
+		if _, ok := OfEntity(e); !ok {
+			continue
+		}
+		if e.Despawns(w.closestPlayerDistance(e.Position())) {
+			_ = e.Close()
+		}
+	}
+}
+
+// addEntityOrder records that the entity passed was added to the World after all entities currently in it.
+// addEntityOrder must be called while holding a write lock on the entityMu.
+func (w *World) addEntityOrder(e Entity) {
+	w.entityCount++
+	w.entityOrder[e] = w.entityCount
+}
+
+// orderedEntities returns all entities in the World in the order in which they were added to it. It must be
+// called while holding a lock on the entityMu.
+func (w *World) orderedEntities() []Entity {
+	entities := make([]Entity, 0, len(w.entities))
+	for e := range w.entities {
+		entities = append(entities, e)
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return w.entityOrder[entities[i]] < w.entityOrder[entities[j]]
+	})
+	return entities
+}
+
+// despawnCheckRadius is the radius around an entity in which players are searched for to check if the
+// entity should despawn.
+const despawnCheckRadius = 128
+
+// closestPlayerDistance returns the distance from the position passed to the closest player within
+// despawnCheckRadius blocks. If no player is found, +Inf is returned.
+func (w *World) closestPlayerDistance(pos mgl64.Vec3) float64 {
+	r := mgl64.Vec3{despawnCheckRadius, despawnCheckRadius, despawnCheckRadius}
+	players := w.EntitiesWithin(physics.NewAABB(pos.Sub(r), pos.Add(r)), func(e Entity) bool {
+		return e.EncodeEntity() != "minecraft:player"
+	})
+	dist := math.Inf(1)
+	for _, p := range players {
+		dist = math.Min(dist, p.Position().Sub(pos).Len())
+	}
+	return dist
 }
 
 // StartRaining makes it rain in the current world where the time.Duration passed will determine how long it will rain.
@@ -1703,6 +2242,16 @@ func (w *World) StopThundering() {
 	w.set.Unlock()
 }
 
+// Weather returns the current weather in the world. Thundering is only true if it is also raining.
+func (w *World) Weather() (raining, thundering bool) {
+	if w == nil {
+		return false, false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Raining, w.set.Raining && w.set.Thundering
+}
+
 // setThunder toggles thundering depending on the thundering argument.
 // This does not lock the world mutex as opposed to StartThundering and StopThundering.
 func (w *World) setThunder(thundering bool, x time.Duration) {
@@ -1729,10 +2278,7 @@ func (w *World) addWorldViewer(viewer Viewer) {
 	w.viewers[viewer] = struct{}{}
 	w.viewersMu.Unlock()
 	viewer.ViewTime(w.Time())
-	w.set.Lock()
-	raining, thundering := w.set.Raining, w.set.Raining && w.set.Thundering
-	w.set.Unlock()
-	viewer.ViewWeather(raining, thundering)
+	viewer.ViewWeather(w.Weather())
 	viewer.ViewWorldSpawn(w.Spawn())
 }
 
@@ -1863,6 +2409,12 @@ func showEntity(e Entity, viewer Viewer) {
 // chunk locks the chunk returned, meaning that any call to chunk made at the same time has to wait until the
 // user calls Chunk.Unlock() on the chunk returned.
 func (w *World) chunk(pos ChunkPos) (*chunkData, error) {
+	if w.closed.Load() {
+		if now, last := time.Now().UnixNano(), w.closedLog.Load(); now-last > int64(time.Second) && w.closedLog.CAS(last, now) {
+			w.log.Debugf("cannot load chunk %v of closed world %v", pos, w.Name())
+		}
+		return nil, errClosed
+	}
 	w.chunkMu.Lock()
 	if pos == w.lastPos && w.lastChunk != nil {
 		c := w.lastChunk
@@ -1908,14 +2460,16 @@ func (w *World) loadChunk(pos ChunkPos) (*chunkData, error) {
 		w.chunkMu.Unlock()
 
 		w.generator().GenerateChunk(pos, c)
+		data.indexLightningRods(pos)
 		return data, nil
 	}
 	data := newChunkData(c)
+	data.indexLightningRods(pos)
 	w.chunks[pos] = data
 	data.Lock()
 	w.chunkMu.Unlock()
 
-	ent, err := w.provider().LoadEntities(pos)
+	ent, err := w.loadEntities(pos, data)
 	if err != nil {
 		return nil, fmt.Errorf("error loading entities of chunk %v: %w", pos, err)
 	}
@@ -1933,6 +2487,7 @@ func (w *World) loadChunk(pos ChunkPos) (*chunkData, error) {
 	w.entityMu.Lock()
 	for _, e := range ent {
 		w.entities[e] = pos
+		w.addEntityOrder(e)
 	}
 	w.entityMu.Unlock()
 
@@ -1940,10 +2495,34 @@ func (w *World) loadChunk(pos ChunkPos) (*chunkData, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error loading block entities of chunk %v: %w", pos, err)
 	}
-	w.loadIntoBlocks(data, blockEntities)
+	unloadedTicks := w.unloadedTicks(pos)
+	w.loadIntoBlocks(data, blockEntities, unloadedTicks)
+	w.queueElapsed(pos, data, unloadedTicks)
 	return data, nil
 }
 
+// unloadedTicks returns the amount of ticks that passed in the World since the chunk at the position passed was
+// last saved, which is the time the chunk spent unloaded. If this is unknown, because the Provider of the World
+// does not implement ChunkTickProvider or holds no tick for the chunk, -1 is returned.
+func (w *World) unloadedTicks(pos ChunkPos) int64 {
+	prov, ok := w.provider().(ChunkTickProvider)
+	if !ok {
+		return -1
+	}
+	saved, ok, err := prov.LoadChunkTick(pos)
+	if err != nil {
+		w.log.Errorf("error loading tick of chunk %v: %v", pos, err)
+		return -1
+	}
+	w.set.Lock()
+	tick := w.set.CurrentTick
+	w.set.Unlock()
+	if !ok || tick < saved {
+		return -1
+	}
+	return tick - saved
+}
+
 // calculateLight calculates the light in the chunk passed and spreads the light of the surrounding
 // neighbours if they have all chunks loaded around it as a result of the one passed.
 func (w *World) calculateLight(c *chunk.Chunk, pos ChunkPos) {
@@ -1997,8 +2576,9 @@ func (w *World) spreadLight(c *chunk.Chunk, pos ChunkPos) {
 }
 
 // loadIntoBlocks loads the block entity data passed into blocks located in a specific chunk. The blocks that
-// have NBT will then be stored into memory.
-func (w *World) loadIntoBlocks(c *chunkData, blockEntityData []map[string]interface{}) {
+// have NBT will then be stored into memory. LoadListeners are passed the amount of ticks that the chunk spent
+// unloaded.
+func (w *World) loadIntoBlocks(c *chunkData, blockEntityData []map[string]interface{}, unloadedTicks int64) {
 	c.e = make(map[cube.Pos]Block, len(blockEntityData))
 	for _, data := range blockEntityData {
 		pos := blockPosFromNBT(data)
@@ -2012,17 +2592,143 @@ func (w *World) loadIntoBlocks(c *chunkData, blockEntityData []map[string]interf
 		if nbt, ok := b.(NBTer); ok {
 			b = nbt.DecodeNBT(data).(Block)
 		}
+		if l, ok := b.(LoadListener); ok {
+			b = l.Loaded(pos, unloadedTicks)
+			if rid, ok := BlockRuntimeID(b); ok && rid != id {
+				c.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, rid)
+			}
+		}
 		c.e[pos] = b
 	}
 }
 
+// loadEntities loads the entities of the chunk at the position passed from the Provider of the World. If the
+// Provider implements EntityDataProvider, the NBT of entities that could not be decoded, for example because no
+// entity with their name was registered using RegisterEntity, is kept in the chunkData passed, so that it can be
+// written back unchanged when the chunk is saved.
+func (w *World) loadEntities(pos ChunkPos, c *chunkData) ([]Entity, error) {
+	prov, ok := w.provider().(EntityDataProvider)
+	if !ok {
+		saveable, err := w.provider().LoadEntities(pos)
+		if err != nil {
+			return nil, err
+		}
+		ent := make([]Entity, 0, len(saveable))
+		for _, e := range saveable {
+			ent = append(ent, e)
+		}
+		return ent, nil
+	}
+	entityData, err := prov.LoadEntityData(pos)
+	if err != nil {
+		return nil, err
+	}
+	ent := make([]Entity, 0, len(entityData))
+	for _, data := range entityData {
+		name, _ := data["identifier"].(string)
+		e, ok := EntityByName(name)
+		if !ok {
+			// Entity was not registered: This can only be expected sometimes, for example for entities that are
+			// not implemented yet, so the best we can do is to keep its data as it is.
+			w.log.Debugf("keeping data of unknown entity %q in chunk %v", name, pos)
+			c.entityData = append(c.entityData, data)
+			continue
+		}
+		v, ok := decodeEntity(e, data)
+		if !ok {
+			w.log.Errorf("keeping data of entity %q in chunk %v: entity could not be decoded", name, pos)
+			c.entityData = append(c.entityData, data)
+			continue
+		}
+		ent = append(ent, v)
+	}
+	return ent, nil
+}
+
+// saveEntities saves the entities of the chunk at the position passed to the Provider of the World. If the
+// Provider implements EntityDataProvider, the NBT of entities that could not be decoded when the chunk was
+// loaded is saved along with them.
+func (w *World) saveEntities(pos ChunkPos, c *chunkData) error {
+	prov, ok := w.provider().(EntityDataProvider)
+	if !ok {
+		s := make([]SaveableEntity, 0, len(c.entities))
+		for _, e := range c.entities {
+			if saveable, ok := e.(SaveableEntity); ok {
+				s = append(s, saveable)
+			}
+		}
+		return w.provider().SaveEntities(pos, s)
+	}
+	s := make([]map[string]interface{}, 0, len(c.entities)+len(c.entityData))
+	for _, e := range c.entities {
+		if saveable, ok := e.(SaveableEntity); ok {
+			// An entity is only ever present in the entities of a single chunk, so it is saved exactly once,
+			// even if its bounding box straddles the border of multiple chunks.
+			s = append(s, encodeEntity(saveable))
+		}
+	}
+	return prov.SaveEntityData(pos, append(s, c.entityData...))
+}
+
 // saveChunk is called when a chunk is removed from the cache. We first compact the chunk, then we write it to
-// the provider.
-func (w *World) saveChunk(pos ChunkPos, c *chunkData) {
+// the provider. If unload is true, the chunk is removed because it is no longer used, rather than because the
+// World is closed, and UnloadListeners in it are notified.
+func (w *World) saveChunk(pos ChunkPos, c *chunkData, unload bool) {
 	c.Lock()
+	w.writeChunk(pos, c, unload)
+	ent := c.entities
+	c.entities = nil
+	c.Unlock()
+
+	for _, e := range ent {
+		_ = e.Close()
+	}
+}
+
+// Save saves all chunks that are currently loaded, including the entities and block entities in them, and the
+// Settings of the World to its Provider, without unloading any of them. Save does nothing if the World is
+// read-only or closed. Providers may hold on to the Settings until they are closed, as mcdb.Provider does with
+// its level.dat.
+func (w *World) Save() {
+	if w == nil || w.rdonly.Load() || w.closeStarted.Load() {
+		return
+	}
+	w.chunkMu.Lock()
+	chunks := make(map[ChunkPos]*chunkData, len(w.chunks))
+	for pos, c := range w.chunks {
+		chunks[pos] = c
+	}
+	w.chunkMu.Unlock()
+
+	for pos, c := range chunks {
+		c.Lock()
+		w.writeChunk(pos, c, false)
+		c.Unlock()
+	}
+	prov := w.provider()
+	w.set.Lock()
+	prov.SaveSettings(w.set)
+	w.set.Unlock()
+}
+
+// writeChunk writes the chunk passed, including its entities and block entities, to the provider. If unload is
+// true, UnloadListeners in the chunk are notified that the chunk is being unloaded. The chunk passed must be
+// locked.
+func (w *World) writeChunk(pos ChunkPos, c *chunkData, unload bool) {
+	w.set.Lock()
+	tick := w.set.CurrentTick
+	w.set.Unlock()
+
 	// We allocate a new map for all block entities.
 	m := make([]map[string]interface{}, 0, len(c.e))
 	for pos, b := range c.e {
+		if l, ok := b.(UnloadListener); ok && unload {
+			b = l.Unloaded(pos)
+			c.e[pos] = b
+			if rid, ok := BlockRuntimeID(b); ok {
+				c.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, rid)
+			}
+		}
 		if n, ok := b.(NBTer); ok {
 			// Encode the block entities and add the 'x', 'y' and 'z' tags to it.
 			data := n.EncodeNBT()
@@ -2035,25 +2741,18 @@ func (w *World) saveChunk(pos ChunkPos, c *chunkData) {
 		if err := w.provider().SaveChunk(pos, c.Chunk); err != nil {
 			w.log.Errorf("error saving chunk %v to provider: %v", pos, err)
 		}
-		s := make([]SaveableEntity, 0, len(c.entities))
-		for _, e := range c.entities {
-			if saveable, ok := e.(SaveableEntity); ok {
-				s = append(s, saveable)
-			}
-		}
-		if err := w.provider().SaveEntities(pos, s); err != nil {
+		if err := w.saveEntities(pos, c); err != nil {
 			w.log.Errorf("error saving entities in chunk %v to provider: %v", pos, err)
 		}
 		if err := w.provider().SaveBlockNBT(pos, m); err != nil {
 			w.log.Errorf("error saving block NBT in chunk %v to provider: %v", pos, err)
 		}
-	}
-	ent := c.entities
-	c.entities = nil
-	c.Unlock()
-
-	for _, e := range ent {
-		_ = e.Close()
+		if prov, ok := w.provider().(ChunkTickProvider); ok {
+			// The tick is saved so that the time the chunk spends unloaded is known once it is loaded again.
+			if err := prov.SaveChunkTick(pos, tick); err != nil {
+				w.log.Errorf("error saving tick of chunk %v to provider: %v", pos, err)
+			}
+		}
 	}
 }
 
@@ -2069,30 +2768,10 @@ func (w *World) chunkCacheJanitor() {
 	t := time.NewTicker(time.Minute * 5)
 	defer t.Stop()
 
-	w.running.Add(1)
-	chunksToRemove := map[ChunkPos]*chunkData{}
 	for {
 		select {
 		case <-t.C:
-			w.chunkMu.Lock()
-			for pos, c := range w.chunks {
-				c.Lock()
-				v := len(c.v)
-				c.Unlock()
-				if v == 0 {
-					chunksToRemove[pos] = c
-					delete(w.chunks, pos)
-					if w.lastPos == pos {
-						w.lastChunk = nil
-					}
-				}
-			}
-			w.chunkMu.Unlock()
-
-			for pos, c := range chunksToRemove {
-				w.saveChunk(pos, c)
-				delete(chunksToRemove, pos)
-			}
+			w.UnloadUnusedChunks()
 		case <-w.closing:
 			w.running.Done()
 			return
@@ -2100,6 +2779,35 @@ func (w *World) chunkCacheJanitor() {
 	}
 }
 
+// UnloadUnusedChunks saves and unloads all chunks that are not viewed by any Viewer. The World does this by
+// itself every five minutes, but UnloadUnusedChunks may be called to free memory earlier.
+func (w *World) UnloadUnusedChunks() {
+	if w == nil || w.closeStarted.Load() {
+		return
+	}
+	chunksToRemove := map[ChunkPos]*chunkData{}
+	w.chunkMu.Lock()
+	for pos, c := range w.chunks {
+		c.Lock()
+		v := len(c.v)
+		c.Unlock()
+		if v == 0 {
+			chunksToRemove[pos] = c
+			delete(w.chunks, pos)
+			if w.lastPos == pos {
+				w.lastChunk = nil
+			}
+		}
+	}
+	w.chunkMu.Unlock()
+
+	for pos, c := range chunksToRemove {
+		w.removeScheduledUpdates(pos)
+		w.removeEmitters(pos)
+		w.saveChunk(pos, c, true)
+	}
+}
+
 // chunkData represents the data of a chunk including the block entities and viewers. This data is protected
 // by the mutex present in the chunk.Chunk held.
 type chunkData struct {
@@ -2107,9 +2815,56 @@ type chunkData struct {
 	e        map[cube.Pos]Block
 	v        []Viewer
 	entities []Entity
+	// rods holds the positions of all blocks in the chunk that attract lightning, so that lightning strikes do
+	// not have to search all blocks around them for lightning rods.
+	rods map[cube.Pos]struct{}
+	// entityData holds the NBT of entities in the chunk that could not be decoded when it was loaded. It is
+	// written back unchanged when the chunk is saved.
+	entityData []map[string]interface{}
 }
 
 // newChunkData returns a new chunkData wrapper around the chunk.Chunk passed.
 func newChunkData(c *chunk.Chunk) *chunkData {
-	return &chunkData{Chunk: c, e: map[cube.Pos]Block{}}
+	return &chunkData{Chunk: c, e: map[cube.Pos]Block{}, rods: map[cube.Pos]struct{}{}}
+}
+
+// indexLightningRods adds the positions of all blocks that attract lightning in the chunk at the position passed
+// to the lightning rods of the chunkData. Only sub chunks that have such a block in their palette are searched.
+func (c *chunkData) indexLightningRods(pos ChunkPos) {
+	baseX, baseZ, minY := int(pos[0])<<4, int(pos[1])<<4, c.Range().Min()
+	for i, sub := range c.Sub() {
+		if sub.Empty() || !hasLightningAttractor(sub.Layer(0).Palette()) {
+			continue
+		}
+		storage := sub.Layer(0)
+		for x := byte(0); x < 16; x++ {
+			for y := byte(0); y < 16; y++ {
+				for z := byte(0); z < 16; z++ {
+					if lightningAttractorBlocks[storage.At(x, y, z)] {
+						c.rods[cube.Pos{baseX + int(x), minY + i<<4 + int(y), baseZ + int(z)}] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+}
+
+// indexLightningRod adds the position passed to the lightning rods of the chunkData if the block with the
+// runtime ID passed attracts lightning, or removes it otherwise.
+func (c *chunkData) indexLightningRod(pos cube.Pos, rid uint32) {
+	if lightningAttractorBlocks[rid] {
+		c.rods[pos] = struct{}{}
+	} else {
+		delete(c.rods, pos)
+	}
+}
+
+// hasLightningAttractor checks if the chunk.Palette passed holds a block that attracts lightning.
+func hasLightningAttractor(p *chunk.Palette) bool {
+	for i := 0; i < p.Len(); i++ {
+		if lightningAttractorBlocks[p.Value(uint16(i))] {
+			return true
+		}
+	}
+	return false
 }